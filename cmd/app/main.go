@@ -1,6 +1,9 @@
 package main
 
-import "github.com/GlebMoskalev/go-pickup-point-api/internal/app"
+import (
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/app"
+	_ "time/tzdata"
+)
 
 const configPath = "config/config.yaml"
 
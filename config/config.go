@@ -9,12 +9,17 @@ import (
 
 type (
 	Config struct {
-		Env        string     `env-required:"true", yaml:"env"`
+		Env        string     `env-required:"true" yaml:"env"`
 		Server     Server     `yaml:"server"`
 		Database   Database   `yaml:"database"`
 		Token      Token      `yaml:"token"`
 		Salt       string     `env-required:"true" env:"SALT"`
 		Prometheus Prometheus `yaml:"prometheus"`
+
+		StaleReceptions StaleReceptions `yaml:"stale_receptions"`
+		StorageExpiry   StorageExpiry   `yaml:"storage_expiry"`
+		BlobStore       BlobStore       `yaml:"blob_store"`
+		InsuredValue    InsuredValue    `yaml:"insured_value"`
 	}
 	Server struct {
 		Host            string        `env-required:"true" env:"HOST"`
@@ -42,8 +47,72 @@ type (
 		Port string `env-required:"true" yaml:"port"`
 		Path string `env-required:"true" yaml:"path"`
 	}
+
+	// StaleReceptions настраивает фоновую проверку приёмок, открытых дольше MaxAge.
+	// Policy: close — закрыть автоматически, flag — только пометить. Если Enabled не задан, проверка включена.
+	StaleReceptions struct {
+		Enabled  *bool         `yaml:"enabled"`
+		MaxAge   time.Duration `yaml:"max_age" env-default:"24h"`
+		Interval time.Duration `yaml:"interval" env-default:"10m"`
+		Policy   string        `yaml:"policy" env-default:"flag"`
+	}
+
+	// StorageExpiry настраивает фоновый поиск товаров с истёкшим сроком хранения. DefaultDays —
+	// срок, если он не задан ни для типа товара, ни для ПВЗ. Если Enabled не задан, поиск включён.
+	StorageExpiry struct {
+		Enabled     *bool         `yaml:"enabled"`
+		DefaultDays int           `yaml:"default_days" env-default:"7"`
+		Interval    time.Duration `yaml:"interval" env-default:"1h"`
+	}
+
+	// InsuredValue задаёт лимит объявленной ценности хранящихся в ПВЗ товаров. Ceiling — в минимальных
+	// единицах валюты Currency (копейках); 0 отключает проверку. Товары в других валютах в лимите
+	// не учитываются. Фоновая проверка раз в Interval сообщает о ПВЗ, превысивших лимит; если Enabled
+	// не задан, она включена.
+	InsuredValue struct {
+		Enabled  *bool         `yaml:"enabled"`
+		Ceiling  int64         `yaml:"ceiling" env-default:"0"`
+		Currency string        `yaml:"currency" env-default:"RUB"`
+		Interval time.Duration `yaml:"interval" env-default:"15m"`
+	}
+
+	// BlobStore выбирает хранилище фотографий товаров: local — каталог Dir на диске,
+	// s3 — S3-совместимое хранилище (Amazon S3, MinIO).
+	BlobStore struct {
+		Driver string `yaml:"driver" env:"BLOB_STORE_DRIVER" env-default:"local"`
+		Dir    string `yaml:"dir" env-default:"./data/blobs"`
+		S3     S3     `yaml:"s3"`
+	}
+	S3 struct {
+		Endpoint  string `yaml:"endpoint" env:"S3_ENDPOINT"`
+		Region    string `yaml:"region" env:"S3_REGION" env-default:"us-east-1"`
+		Bucket    string `yaml:"bucket" env:"S3_BUCKET"`
+		AccessKey string `env:"S3_ACCESS_KEY"`
+		SecretKey string `env:"S3_SECRET_KEY"`
+	}
 )
 
+// IsEnabled сообщает, включена ли фоновая проверка зависших приёмок.
+func (s StaleReceptions) IsEnabled() bool {
+	return enabledByDefault(s.Enabled)
+}
+
+// IsEnabled сообщает, включён ли фоновый поиск товаров с истёкшим сроком хранения.
+func (s StorageExpiry) IsEnabled() bool {
+	return enabledByDefault(s.Enabled)
+}
+
+// IsEnabled сообщает, включена ли фоновая проверка объявленной ценности.
+func (v InsuredValue) IsEnabled() bool {
+	return enabledByDefault(v.Enabled)
+}
+
+// enabledByDefault трактует незаданный флаг как включённый: cleanenv не отличает false в YAML
+// от отсутствующего поля, поэтому env-default:"true" у bool перезаписал бы явное выключение.
+func enabledByDefault(flag *bool) bool {
+	return flag == nil || *flag
+}
+
 func (db Database) DSN() string {
 	return fmt.Sprintf(
 		"postgres://%s:%s@%s:%s/%s?sslmode=%s",
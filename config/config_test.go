@@ -0,0 +1,118 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testEnv = `HOST=localhost
+PORT=8080
+DB_USER=user
+DB_PASSWORD=password
+DB_HOST=localhost
+DB_PORT=5432
+DB_NAME=pvz
+SSL_MODE=disable
+JWT_SIGN_KEY=secret
+SALT=salt
+`
+
+const testBaseConfig = `env: "local"
+server:
+  shutdown_timeout: 5s
+database:
+  max_conns: 30
+  min_conns: 5
+  max_conn_life_time: 3600s
+  max_conn_idle_time: 1800s
+token:
+  ttl: 120m
+prometheus:
+  port: "9000"
+  path: "/metrics"
+`
+
+func loadTestConfig(t *testing.T, workers string) *Config {
+	t.Helper()
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".env"), []byte(testEnv), 0o600))
+	configPath := filepath.Join(dir, "config.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte(testBaseConfig+workers), 0o600))
+	t.Chdir(dir)
+
+	cfg, err := NewConfig(configPath)
+	require.NoError(t, err)
+	return cfg
+}
+
+func TestNewConfigWorkerToggles(t *testing.T) {
+	testCases := []struct {
+		name           string
+		workers        string
+		staleEnabled   bool
+		expiryEnabled  bool
+		insuredEnabled bool
+	}{
+		{
+			name:           "enabled by default",
+			workers:        "",
+			staleEnabled:   true,
+			expiryEnabled:  true,
+			insuredEnabled: true,
+		},
+		{
+			name: "explicitly enabled",
+			workers: `stale_receptions:
+  enabled: true
+storage_expiry:
+  enabled: true
+insured_value:
+  enabled: true
+`,
+			staleEnabled:   true,
+			expiryEnabled:  true,
+			insuredEnabled: true,
+		},
+		{
+			name: "stale receptions disabled",
+			workers: `stale_receptions:
+  enabled: false
+`,
+			staleEnabled:   false,
+			expiryEnabled:  true,
+			insuredEnabled: true,
+		},
+		{
+			name: "storage expiry disabled",
+			workers: `storage_expiry:
+  enabled: false
+`,
+			staleEnabled:   true,
+			expiryEnabled:  false,
+			insuredEnabled: true,
+		},
+		{
+			name: "insured value disabled",
+			workers: `insured_value:
+  enabled: false
+`,
+			staleEnabled:   true,
+			expiryEnabled:  true,
+			insuredEnabled: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := loadTestConfig(t, tc.workers)
+
+			assert.Equal(t, tc.staleEnabled, cfg.StaleReceptions.IsEnabled())
+			assert.Equal(t, tc.expiryEnabled, cfg.StorageExpiry.IsEnabled())
+			assert.Equal(t, tc.insuredEnabled, cfg.InsuredValue.IsEnabled())
+		})
+	}
+}
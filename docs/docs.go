@@ -113,14 +113,58 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/products": {
+        "/api/v1/product-types": {
+            "get": {
+                "security": [
+                    {
+                        "JWT": []
+                    }
+                ],
+                "description": "Доступно для сотрудников и модераторов. Возвращает типы товаров, которые можно принимать, со схемами их атрибутов.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "product-types"
+                ],
+                "summary": "Каталог типов товаров",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/v1.productTypeDTO"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не авторизован",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Доступ запрещён",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    }
+                }
+            },
             "post": {
                 "security": [
                     {
                         "JWT": []
                     }
                 ],
-                "description": "Добавляет товар в последнюю незакрытую приёмку в указанном ПВЗ. Доступно только для сотрудников ПВЗ. Требуется незакрытая приёмка.",
+                "description": "Только для модераторов. Добавляет тип в каталог; название приводится к нижнему регистру. Схема атрибутов необязательна: без неё товары этого типа принимаются без атрибутов. Срок хранения тоже необязателен.",
                 "consumes": [
                     "application/json"
                 ],
@@ -128,17 +172,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "products"
+                    "product-types"
                 ],
-                "summary": "Добавление товара в приёмку",
+                "summary": "Добавление типа товара",
                 "parameters": [
                     {
-                        "description": "Данные для добавления товара",
+                        "description": "Название и схема атрибутов",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/v1.createProductRequest"
+                            "$ref": "#/definitions/v1.productTypeDTO"
                         }
                     }
                 ],
@@ -146,11 +190,11 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/v1.createProductResponse"
+                            "$ref": "#/definitions/v1.productTypeDTO"
                         }
                     },
                     "400": {
-                        "description": "Неверный идентификатор ПВЗ, тип товара или отсутствие открытой приёмки",
+                        "description": "Неверное название, схема атрибутов или срок хранения",
                         "schema": {
                             "$ref": "#/definitions/httpresponse.ErrorResponse"
                         }
@@ -162,7 +206,13 @@ const docTemplate = `{
                         }
                     },
                     "403": {
-                        "description": "Доступ запрещён",
+                        "description": "Доступ запрещён: требуется роль модератора",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Тип уже есть в каталоге",
                         "schema": {
                             "$ref": "#/definitions/httpresponse.ErrorResponse"
                         }
@@ -176,14 +226,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/pvz": {
-            "get": {
+        "/api/v1/product-types/{name}/schema": {
+            "put": {
                 "security": [
                     {
                         "JWT": []
                     }
                 ],
-                "description": "Доступно для сотрудников и модераторов. Возвращает список ПВЗ с информацией о приёмках и товарах, с поддержкой пагинации и фильтрации по датам приёмок.",
+                "description": "Только для модераторов. Заменяет схему атрибутов; уже принятые товары не перепроверяются.",
                 "consumes": [
                     "application/json"
                 ],
@@ -191,44 +241,36 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "pvz"
+                    "product-types"
                 ],
-                "summary": "Получение списка ПВЗ с приёмками и товарами",
+                "summary": "Изменение схемы атрибутов типа товара",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Начальная дата приёмок (формат: RFC3339)",
-                        "name": "startDate",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Конечная дата приёмок (формат: RFC3339)",
-                        "name": "endDate",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Номер страницы (начинается с 1)",
-                        "name": "page",
-                        "in": "query"
+                        "description": "Название типа",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "Количество записей на страницу (1-30)",
-                        "name": "limit",
-                        "in": "query"
+                        "description": "Новая схема атрибутов",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.productTypeSchemaRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Список ПВЗ с приёмками и товарами",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/v1.listPVZWithDetailsResponse"
+                            "$ref": "#/definitions/v1.productTypeDTO"
                         }
                     },
                     "400": {
-                        "description": "Неверные параметры запроса",
+                        "description": "Неверная схема атрибутов",
                         "schema": {
                             "$ref": "#/definitions/httpresponse.ErrorResponse"
                         }
@@ -240,7 +282,13 @@ const docTemplate = `{
                         }
                     },
                     "403": {
-                        "description": "Доступ запрещён: требуется роль сотрудника или модератора",
+                        "description": "Доступ запрещён: требуется роль модератора",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Тип не найден",
                         "schema": {
                             "$ref": "#/definitions/httpresponse.ErrorResponse"
                         }
@@ -252,14 +300,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/api/v1/product-types/{name}/storage-period": {
+            "put": {
                 "security": [
                     {
                         "JWT": []
                     }
                 ],
-                "description": "Только для модераторов. Создаёт пункт выдачи заказов (ПВЗ) в одном из поддерживаемых городов: Москва, Санкт-Петербург, Казань.",
+                "description": "Только для модераторов. Задаёт срок хранения товаров типа; 0 сбрасывает его, и тогда действует срок ПВЗ или срок по умолчанию. Товары, уже попавшие в очередь на возврат, остаются в ней.",
                 "consumes": [
                     "application/json"
                 ],
@@ -267,29 +317,36 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "pvz"
+                    "product-types"
                 ],
-                "summary": "Создание ПВЗ",
+                "summary": "Изменение срока хранения товаров типа",
                 "parameters": [
                     {
-                        "description": "Данные для создания ПВЗ",
+                        "type": "string",
+                        "description": "Название типа",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Срок хранения",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/v1.createPVZRequest"
+                            "$ref": "#/definitions/v1.productTypeStoragePeriodRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "ПВЗ успешно создан",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/v1.createPVZResponse"
+                            "$ref": "#/definitions/v1.productTypeDTO"
                         }
                     },
                     "400": {
-                        "description": "Неверный город или некорректное тело запроса",
+                        "description": "Неверный срок хранения",
                         "schema": {
                             "$ref": "#/definitions/httpresponse.ErrorResponse"
                         }
@@ -306,6 +363,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/httpresponse.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Тип не найден",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Внутренняя ошибка сервера",
                         "schema": {
@@ -315,14 +378,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/pvz/{pvzId}/close_last_reception": {
+        "/api/v1/products": {
             "post": {
                 "security": [
                     {
                         "JWT": []
                     }
                 ],
-                "description": "Закрывает последнюю открытое приёмку в ПВЗ. Доступно только для сотрудников ПВЗ. Приёмка должна быть открытой.",
+                "description": "Добавляет товар в незакрытую приёмку. Доступно только для сотрудников ПВЗ. Приёмка выбирается по receptionId, затем по dockId; если указан только pvzId, в ПВЗ должна быть ровно одна открытая приёмка. Штрихкод необязателен и уникален среди хранящихся товаров: повторное сканирование возвращает 409 с идентификатором уже принятого товара. Тип выбирается из каталога, атрибуты проверяются по JSON-схеме типа. В приёмку-возврат товар добавляется с причиной возврата и связывается с последним выданным товаром с тем же штрихкодом.",
                 "consumes": [
                     "application/json"
                 ],
@@ -330,27 +393,29 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "pvz"
+                    "products"
                 ],
-                "summary": "Закрытие последней приёмки",
+                "summary": "Добавление товара в приёмку",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Идентификатор ПВЗ",
-                        "name": "pvzId",
-                        "in": "path",
-                        "required": true
+                        "description": "Данные для добавления товара",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.createProductRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/v1.closeReceptionResponse"
+                            "$ref": "#/definitions/v1.createProductResponse"
                         }
                     },
                     "400": {
-                        "description": "Неверный идентификатор ПВЗ или приёмка не найдена",
+                        "description": "Неверный идентификатор ПВЗ, дока или приёмки, тип товара, штрихкод, атрибуты или причина возврата, отсутствие открытой приёмки или не указан док",
                         "schema": {
                             "$ref": "#/definitions/httpresponse.ErrorResponse"
                         }
@@ -367,6 +432,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/httpresponse.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Приёмка не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Товар с таким штрихкодом уже хранится",
+                        "schema": {
+                            "$ref": "#/definitions/v1.productConflictResponse"
+                        }
+                    },
                     "500": {
                         "description": "Внутренняя ошибка сервера",
                         "schema": {
@@ -376,41 +453,52 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/pvz/{pvzId}/delete_last_product": {
+        "/api/v1/products/batch": {
             "post": {
                 "security": [
                     {
                         "JWT": []
                     }
                 ],
-                "description": "Удаляет последний добавленный товар в последней незакрытой приёмке указанного ПВЗ. Доступно только для сотрудников ПВЗ. Требуется наличие незакрытой приёмки и хотя бы одного товара в ней.",
+                "description": "Доступно только для сотрудников ПВЗ. Добавляет до 1000 товаров в одну открытую приёмку одной транзакцией; приёмка выбирается так же, как при добавлении одного товара. В режиме all_or_nothing ошибка в любой позиции отклоняет весь пакет (400 с результатами по позициям), в режиме best_effort добавляются корректные позиции, а по остальным возвращаются ошибки. Штрихкоды не должны повторяться внутри пакета и среди хранящихся товаров.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "pvz"
+                    "products"
                 ],
-                "summary": "Удаление последнего добавленного товара",
+                "summary": "Пакетное добавление товаров в приёмку",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Идентификатор ПВЗ (uuid)",
-                        "name": "pvzId",
-                        "in": "path",
-                        "required": true
+                        "description": "Пакет товаров",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.productBatchRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Сообщение об успешном удалении",
+                        "description": "Режим best_effort: добавлена часть позиций",
                         "schema": {
-                            "$ref": "#/definitions/v1.deleteProductResponse"
+                            "$ref": "#/definitions/v1.productBatchResponse"
+                        }
+                    },
+                    "201": {
+                        "description": "Все позиции добавлены",
+                        "schema": {
+                            "$ref": "#/definitions/v1.productBatchResponse"
                         }
                     },
                     "400": {
-                        "description": "Неверный идентификатор ПВЗ, отсутствие открытой приёмки или отсутствие товаров в приёмке",
+                        "description": "Пакет отклонён, неверный идентификатор ПВЗ, дока или приёмки, режим или размер пакета",
                         "schema": {
-                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                            "$ref": "#/definitions/v1.productBatchResponse"
                         }
                     },
                     "401": {
@@ -425,6 +513,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/httpresponse.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Приёмка не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Товар с одним из штрихкодов добавлен параллельно",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Внутренняя ошибка сервера",
                         "schema": {
@@ -434,44 +534,39 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/receptions": {
-            "post": {
+        "/api/v1/products/by-barcode/{code}": {
+            "get": {
                 "security": [
                     {
                         "JWT": []
                     }
                 ],
-                "description": "Создаёт новую приёмку товаров в указанном ПВЗ. Доступно только для сотрудников ПВЗ. Нельзя создать, если есть открытая приёмка.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Доступно для сотрудников и модераторов. Возвращает хранящийся (неудалённый) товар с указанным штрихкодом.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "receptions"
+                    "products"
                 ],
-                "summary": "Создание приёмки товаров",
+                "summary": "Поиск товара по штрихкоду",
                 "parameters": [
                     {
-                        "description": "Данные для создания приёмки",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/v1.createReceptionRequest"
-                        }
+                        "type": "string",
+                        "description": "Штрихкод товара",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/v1.createReceptionResponse"
+                            "$ref": "#/definitions/v1.createProductResponse"
                         }
                     },
                     "400": {
-                        "description": "Неверный идентификатор ПВЗ или открытая приёмка существует",
+                        "description": "Неверный штрихкод",
                         "schema": {
                             "$ref": "#/definitions/httpresponse.ErrorResponse"
                         }
@@ -488,6 +583,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/httpresponse.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Товар не найден",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Внутренняя ошибка сервера",
                         "schema": {
@@ -497,90 +598,3955 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/register": {
-            "post": {
-                "description": "Регистрация нового пользователя",
-                "consumes": [
-                    "application/json"
+        "/api/v1/products/{productId}": {
+            "delete": {
+                "security": [
+                    {
+                        "JWT": []
+                    }
                 ],
+                "description": "Удаляет конкретный товар, пока его приёмка открыта. Доступно только для сотрудников ПВЗ. Товар помечается удалённым с автором и временем удаления и остаётся в списке удалённых товаров приёмки. Товар должен принадлежать ПВЗ pvzId.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "products"
                 ],
-                "summary": "Register",
+                "summary": "Удаление товара из открытой приёмки",
                 "parameters": [
                     {
-                        "description": "Данные для регистрации",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/v1.registerRequest"
-                        }
+                        "type": "string",
+                        "description": "Идентификатор товара",
+                        "name": "productId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Идентификатор ПВЗ",
+                        "name": "pvzId",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Возвращает данные зарегистрированного пользователя",
+                    "200": {
+                        "description": "Сообщение об успешном удалении",
                         "schema": {
-                            "$ref": "#/definitions/v1.registerResponse"
+                            "$ref": "#/definitions/v1.deleteProductResponse"
                         }
                     },
                     "400": {
-                        "description": "Некорректное тело запроса",
+                        "description": "Неверный идентификатор товара или ПВЗ",
                         "schema": {
                             "$ref": "#/definitions/httpresponse.ErrorResponse"
                         }
                     },
-                    "409": {
-                        "description": "Пользователь с таким email уже существует",
+                    "401": {
+                        "description": "Пользователь не авторизован",
                         "schema": {
                             "$ref": "#/definitions/httpresponse.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Внутренняя ошибка сервера",
+                    "403": {
+                        "description": "Доступ запрещён",
                         "schema": {
                             "$ref": "#/definitions/httpresponse.ErrorResponse"
                         }
-                    }
-                }
-            }
-        }
-    },
-    "definitions": {
-        "httpresponse.ErrorResponse": {
+                    },
+                    "404": {
+                        "description": "Товар не найден",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Приёмка товара уже закрыта или товар уже не хранится",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/products/{productId}/cell": {
+            "put": {
+                "security": [
+                    {
+                        "JWT": []
+                    }
+                ],
+                "description": "Только для сотрудников ПВЗ. Перекладывает хранящийся товар в ячейку его ПВЗ, если ячейка принимает тип товара и в ней есть место.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Перемещение товара в другую ячейку",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор товара",
+                        "name": "productId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Новая ячейка",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.assignCellRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.createProductResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный идентификатор товара или ячейки, ячейка не принимает этот тип товара",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не авторизован",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Доступ запрещён",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Товар или ячейка не найдены",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Ячейка заполнена или товар уже не хранится",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/products/{productId}/damage": {
+            "put": {
+                "security": [
+                    {
+                        "JWT": []
+                    }
+                ],
+                "description": "Только для сотрудников ПВЗ. Отмечает хранящийся товар повреждённым с описанием повреждения для претензии к перевозчику; повторная отметка заменяет описание.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Отметка о повреждении товара",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор товара",
+                        "name": "productId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Описание повреждения",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.markDamagedRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.createProductResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный идентификатор товара или описание повреждения",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не авторизован",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Доступ запрещён",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Товар не найден",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Товар не хранится в ПВЗ",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/products/{productId}/issue": {
+            "post": {
+                "security": [
+                    {
+                        "JWT": []
+                    }
+                ],
+                "description": "Только для сотрудников ПВЗ. Проверяет код получения и отмечает хранящийся товар выданным, сохраняя, кто и когда его выдал. Выдать можно только товар закрытой приёмки этого ПВЗ.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Выдача товара покупателю",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор товара",
+                        "name": "productId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "ПВЗ и код получения",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.issueProductRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.createProductResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный идентификатор товара или ПВЗ, неверный код получения",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не авторизован",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Доступ запрещён",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Товар не найден в этом ПВЗ",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Товар уже выдан или возвращён, либо приёмка товара не закрыта",
+                        "schema": {
+                            "$ref": "#/definitions/v1.productStatusConflictResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/products/{productId}/locations": {
+            "get": {
+                "security": [
+                    {
+                        "JWT": []
+                    }
+                ],
+                "description": "Доступно для сотрудников и модераторов. Возвращает ПВЗ, в которых хранился товар, и перемещения между ними в хронологическом порядке.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "История местонахождения товара",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор товара",
+                        "name": "productId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/v1.productLocationResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный идентификатор товара",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не авторизован",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Доступ запрещён",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Товар не найден",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/products/{productId}/photos": {
+            "get": {
+                "security": [
+                    {
+                        "JWT": []
+                    }
+                ],
+                "description": "Доступно для сотрудников и модераторов. Возвращает фотографии повреждения товара в порядке загрузки.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Фотографии повреждения товара",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор товара",
+                        "name": "productId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/v1.productPhotoResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный идентификатор товара",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не авторизован",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Доступ запрещён",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Товар не найден",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "JWT": []
+                    }
+                ],
+                "description": "Только для сотрудников ПВЗ. Принимает фотографию в поле photo формы multipart/form-data: JPEG, PNG или WebP до 10 МБ, не больше 10 фотографий на товар. Товар должен быть отмечен повреждённым.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Загрузка фотографии повреждения",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор товара",
+                        "name": "productId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Фотография",
+                        "name": "photo",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/v1.productPhotoResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный идентификатор товара, нет фотографии или неподдерживаемый формат",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не авторизован",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Доступ запрещён",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Товар не найден",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Товар не отмечен повреждённым или у него уже 10 фотографий",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Фотография больше 10 МБ",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/products/{productId}/photos/{photoId}": {
+            "get": {
+                "security": [
+                    {
+                        "JWT": []
+                    }
+                ],
+                "description": "Доступно для сотрудников и модераторов. Возвращает файл фотографии.",
+                "produces": [
+                    "image/jpeg",
+                    "image/png",
+                    "image/webp"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Скачивание фотографии повреждения",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор товара",
+                        "name": "productId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Идентификатор фотографии",
+                        "name": "photoId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Фотография",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный идентификатор товара или фотографии",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не авторизован",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Доступ запрещён",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Фотография не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/products/{productId}/pickup-code.png": {
+            "get": {
+                "security": [
+                    {
+                        "JWT": []
+                    }
+                ],
+                "description": "Доступно для сотрудников и модераторов. Возвращает код получения товара в виде PNG: QR-код (по умолчанию) или штрихкод Code128 для печати на этикетке.",
+                "produces": [
+                    "image/png"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Изображение кода получения",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор товара",
+                        "name": "productId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "qr",
+                            "code128"
+                        ],
+                        "type": "string",
+                        "default": "qr",
+                        "description": "Формат изображения",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "PNG-изображение кода получения",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный идентификатор товара или формат",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не авторизован",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Доступ запрещён",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Товар не найден",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/products/{productId}/return-to-sender": {
+            "post": {
+                "security": [
+                    {
+                        "JWT": []
+                    }
+                ],
+                "description": "Только для сотрудников ПВЗ. Подтверждает отправку товара из очереди на возврат обратно отправителю, сохраняя, кто и когда его отправил. Товар должен храниться в этом ПВЗ и иметь истёкший срок хранения.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Возврат товара отправителю",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор товара",
+                        "name": "productId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "ПВЗ",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.returnToSenderRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.createProductResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный идентификатор товара или ПВЗ",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не авторизован",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Доступ запрещён",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Товар не найден в этом ПВЗ",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Товар не хранится или срок его хранения не истёк",
+                        "schema": {
+                            "$ref": "#/definitions/v1.productStatusConflictResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/pvz": {
+            "get": {
+                "security": [
+                    {
+                        "JWT": []
+                    }
+                ],
+                "description": "Доступно для сотрудников и модераторов. Возвращает список ПВЗ с информацией о приёмках и товарах, с поддержкой пагинации и фильтрации по датам приёмок и номеру накладной. По умолчанию возвращаются приёмки всех видов; параметр kind оставляет только поставки (delivery), возвраты (return) или приёмки перемещений (transfer).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pvz"
+                ],
+                "summary": "Получение списка ПВЗ с приёмками и товарами",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Начальная дата приёмок (формат: RFC3339)",
+                        "name": "startDate",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Конечная дата приёмок (формат: RFC3339)",
+                        "name": "endDate",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Номер накладной: возвращаются только приёмки по этой накладной",
+                        "name": "waybillNumber",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "delivery",
+                            "return",
+                            "transfer"
+                        ],
+                        "type": "string",
+                        "description": "Вид приёмок: delivery, return или transfer (по умолчанию все)",
+                        "name": "kind",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Трактовать startDate и endDate как местное время каждого ПВЗ (смещение в дате игнорируется, допускается формат 2006-01-02T15:04:05)",
+                        "name": "localTime",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Номер страницы (начинается с 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Количество записей на страницу (1-30)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Список ПВЗ с приёмками и товарами",
+                        "schema": {
+                            "$ref": "#/definitions/v1.listPVZWithDetailsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверные параметры запроса",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не авторизован",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Доступ запрещён: требуется роль сотрудника или модератора",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "JWT": []
+                    }
+                ],
+                "description": "Только для модераторов. Создаёт пункт выдачи заказов (ПВЗ) в одном из поддерживаемых городов: Москва, Санкт-Петербург, Казань. Часовой пояс задаётся в формате IANA.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pvz"
+                ],
+                "summary": "Создание ПВЗ",
+                "parameters": [
+                    {
+                        "description": "Данные для создания ПВЗ",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.createPVZRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "ПВЗ успешно создан",
+                        "schema": {
+                            "$ref": "#/definitions/v1.createPVZResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный город, часовой пояс или некорректное тело запроса",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не авторизован",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Доступ запрещён: требуется роль модератора",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/pvz/{pvzId}/cells": {
+            "get": {
+                "security": [
+                    {
+                        "JWT": []
+                    }
+                ],
+                "description": "Доступно для сотрудников и модераторов. Возвращает ячейки ПВЗ по стеллажам с их занятостью.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pvz"
+                ],
+                "summary": "Схема хранения ПВЗ",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор ПВЗ",
+                        "name": "pvzId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.listStorageCellsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный идентификатор ПВЗ",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не авторизован",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Доступ запрещён",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "JWT": []
+                    }
+                ],
+                "description": "Только для модераторов. Добавляет ячейку в схему хранения ПВЗ. Ячейка с типом товара принимает только товары этого типа.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pvz"
+                ],
+                "summary": "Создание ячейки хранения",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор ПВЗ",
+                        "name": "pvzId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Данные ячейки",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.createStorageCellRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/v1.storageCellDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный идентификатор ПВЗ, данные ячейки или тип товара",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не авторизован",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Доступ запрещён: требуется роль модератора",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Ячейка с таким кодом уже есть",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/pvz/{pvzId}/close_last_reception": {
+            "post": {
+                "security": [
+                    {
+                        "JWT": []
+                    }
+                ],
+                "description": "Закрывает последнюю открытое приёмку в ПВЗ. Доступно только для сотрудников ПВЗ. Приёмка должна быть открытой. Если в ПВЗ открыто несколько приёмок в разных доках, нужно указать dockId или receptionId. Если приёмка создавалась с манифестом, возвращается отчёт о недостачах и излишках. Если в теле переданы пересчитанные количества (countedTotals), они сверяются с учтёнными товарами: при расхождении приёмка не закрывается либо закрывается с отметкой о расхождении — в зависимости от настроек ПВЗ.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pvz"
+                ],
+                "summary": "Закрытие последней приёмки",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор ПВЗ",
+                        "name": "pvzId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Идентификатор дока",
+                        "name": "dockId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Идентификатор приёмки",
+                        "name": "receptionId",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Пересчитанные количества",
+                        "name": "input",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/v1.closeReceptionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.closeReceptionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный идентификатор ПВЗ, дока или приёмки, пересчёт, приёмка не найдена или не указан док",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не авторизован",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Доступ запрещён",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Приёмка не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Пересчёт не совпал с учтёнными товарами или в приёмку перемещения приняты не все товары",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/pvz/{pvzId}/delete_last_product": {
+            "post": {
+                "security": [
+                    {
+                        "JWT": []
+                    }
+                ],
+                "description": "Удаляет последний добавленный товар в последней незакрытой приёмке указанного ПВЗ. Доступно только для сотрудников ПВЗ. Требуется наличие незакрытой приёмки и хотя бы одного товара в ней. Если в ПВЗ открыто несколько приёмок в разных доках, нужно указать dockId или receptionId.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pvz"
+                ],
+                "summary": "Удаление последнего добавленного товара",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор ПВЗ (uuid)",
+                        "name": "pvzId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Идентификатор дока",
+                        "name": "dockId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Идентификатор приёмки",
+                        "name": "receptionId",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Сообщение об успешном удалении",
+                        "schema": {
+                            "$ref": "#/definitions/v1.deleteProductResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный идентификатор ПВЗ, дока или приёмки, отсутствие открытой приёмки, не указан док или отсутствие товаров в приёмке",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не авторизован",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Доступ запрещён",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Приёмка не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Последний товар уже не хранится или приёмка принимает только перемещённые товары",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/pvz/{pvzId}/docks": {
+            "get": {
+                "security": [
+                    {
+                        "JWT": []
+                    }
+                ],
+                "description": "Доступно для сотрудников и модераторов. Док по умолчанию идёт первым.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pvz"
+                ],
+                "summary": "Список доков ПВЗ",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор ПВЗ",
+                        "name": "pvzId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.listDocksResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный идентификатор ПВЗ",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не авторизован",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Доступ запрещён",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "JWT": []
+                    }
+                ],
+                "description": "Только для модераторов. Добавляет в ПВЗ док, в котором можно вести отдельную приёмку параллельно с другими доками.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pvz"
+                ],
+                "summary": "Создание дока",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор ПВЗ",
+                        "name": "pvzId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Данные дока",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.createDockRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/v1.dockDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный идентификатор ПВЗ, название дока или док с таким названием уже есть",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не авторизован",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Доступ запрещён: требуется роль модератора",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/pvz/{pvzId}/overdue": {
+            "get": {
+                "security": [
+                    {
+                        "JWT": []
+                    }
+                ],
+                "description": "Доступно для сотрудников и модераторов. Возвращает хранящиеся в ПВЗ товары с истёкшим сроком хранения, начиная с самых давних.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pvz"
+                ],
+                "summary": "Очередь на возврат отправителю",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор ПВЗ",
+                        "name": "pvzId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/v1.createProductResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный идентификатор ПВЗ",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не авторизован",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Доступ запрещён",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/pvz/{pvzId}/pickup/{code}": {
+            "post": {
+                "security": [
+                    {
+                        "JWT": []
+                    }
+                ],
+                "description": "Только для сотрудников ПВЗ. Возвращает хранящийся в ПВЗ товар с предъявленным кодом получения; коды уникальны в пределах ПВЗ.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pvz"
+                ],
+                "summary": "Поиск товара по коду получения",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор ПВЗ",
+                        "name": "pvzId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Код получения",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.createProductResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный идентификатор ПВЗ или код получения",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не авторизован",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Доступ запрещён",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Товар не найден",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/pvz/{pvzId}/schedule": {
+            "get": {
+                "security": [
+                    {
+                        "JWT": []
+                    }
+                ],
+                "description": "Доступно для сотрудников и модераторов. Возвращает часовой пояс, недельное расписание и праздничные дни ПВЗ.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pvz"
+                ],
+                "summary": "Получение расписания ПВЗ",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор ПВЗ",
+                        "name": "pvzId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.scheduleResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный идентификатор ПВЗ",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не авторизован",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Доступ запрещён",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "JWT": []
+                    }
+                ],
+                "description": "Только для модераторов. Полностью заменяет часовой пояс, недельное расписание и праздничные дни ПВЗ. Время задаётся по местному времени ПВЗ.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pvz"
+                ],
+                "summary": "Установка расписания ПВЗ",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор ПВЗ",
+                        "name": "pvzId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Расписание ПВЗ",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.setScheduleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.scheduleResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный идентификатор ПВЗ, часовой пояс или расписание",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не авторизован",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Доступ запрещён: требуется роль модератора",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/pvz/{pvzId}/settings": {
+            "get": {
+                "security": [
+                    {
+                        "JWT": []
+                    }
+                ],
+                "description": "Доступно для сотрудников и модераторов. Возвращает настройки приёмки и хранения в ПВЗ.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pvz"
+                ],
+                "summary": "Настройки ПВЗ",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор ПВЗ",
+                        "name": "pvzId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.pvzSettingsDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный идентификатор ПВЗ",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не авторизован",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Доступ запрещён",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "JWT": []
+                    }
+                ],
+                "description": "Только для модераторов. Полностью заменяет настройки приёмки и хранения в ПВЗ.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pvz"
+                ],
+                "summary": "Изменение настроек ПВЗ",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор ПВЗ",
+                        "name": "pvzId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Настройки ПВЗ",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.pvzSettingsDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.pvzSettingsDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный идентификатор ПВЗ или настройки",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не авторизован",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Доступ запрещён: требуется роль модератора",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/pvz/{pvzId}/stock": {
+            "get": {
+                "security": [
+                    {
+                        "JWT": []
+                    }
+                ],
+                "description": "Доступно для сотрудников и модераторов. Возвращает число хранящихся, выданных, возвращённых отправителю и отправленных в другой ПВЗ товаров; удалённые товары и товары отменённых приёмок не учитываются. Также возвращает объявленную ценность хранящихся товаров по валютам и признак превышения предела страховой суммы.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pvz"
+                ],
+                "summary": "Остатки ПВЗ",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор ПВЗ",
+                        "name": "pvzId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.pvzStockResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный идентификатор ПВЗ",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не авторизован",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Доступ запрещён",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/receptions": {
+            "post": {
+                "security": [
+                    {
+                        "JWT": []
+                    }
+                ],
+                "description": "Создаёт новую приёмку товаров в указанном ПВЗ. Доступно только для сотрудников ПВЗ. Приёмка открывается в указанном доке или в доке по умолчанию; нельзя создать, если в доке есть открытая приёмка. С draft=true приёмка заводится черновиком, который начинается запросом /start. Можно передать манифест — ожидаемое количество товаров по типам, а также перевозчика, номер накладной, номер машины и комментарий. С kind=return открывается приёмка возвратов от покупателей: товары в неё добавляются с причиной возврата.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "receptions"
+                ],
+                "summary": "Создание приёмки товаров",
+                "parameters": [
+                    {
+                        "description": "Данные для создания приёмки",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.createReceptionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/v1.createReceptionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный идентификатор ПВЗ или дока, вид приёмки, манифест или данные о поставке, открытая приёмка существует или ПВЗ не работает в это время",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не авторизован",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Доступ запрещён",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/receptions/{receptionId}/act": {
+            "get": {
+                "security": [
+                    {
+                        "JWT": []
+                    }
+                ],
+                "description": "Доступно для сотрудников и модераторов. Возвращает акт, зафиксированный при последнем закрытии приёмки: ПВЗ, перевозчик, время приёмки, количество по типам, список товаров и объявленная ценность по валютам. Последующие изменения товаров на акт не влияют, а повторное закрытие после переоткрытия фиксирует следующую редакцию, не меняя прежние; хэш содержимого сверяется с сохранённым перед выдачей. Формат выбирается параметром format: json (по умолчанию), text или html.",
+                "produces": [
+                    "application/json",
+                    "text/plain",
+                    "text/html"
+                ],
+                "tags": [
+                    "receptions"
+                ],
+                "summary": "Акт приёма товаров",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор приёмки",
+                        "name": "receptionId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "text",
+                            "html"
+                        ],
+                        "type": "string",
+                        "description": "Формат акта",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.acceptanceActResponse"
+                        },
+                        "headers": {
+                            "X-Content-Hash": {
+                                "type": "string",
+                                "description": "SHA-256 содержимого акта"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный идентификатор, формат или приёмка не закрыта",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не авторизован",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Доступ запрещён",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Приёмка не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/receptions/{receptionId}/cancel": {
+            "post": {
+                "security": [
+                    {
+                        "JWT": []
+                    }
+                ],
+                "description": "Доступно для сотрудников и модераторов. Отменяет черновик или открытую по ошибке приёмку. Причина и автор изменения сохраняются в истории статусов. Товары отменённой приёмки помечаются удалёнными; приёмку, товары которой уже выданы или отправлены, отменить нельзя.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "receptions"
+                ],
+                "summary": "Отмена приёмки",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор приёмки",
+                        "name": "receptionId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Причина",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.receptionStatusRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.createReceptionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный идентификатор или не указана причина",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не авторизован",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Доступ запрещён",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Приёмка не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Приёмка уже закрыта или отменена либо её товары уже не хранятся",
+                        "schema": {
+                            "$ref": "#/definitions/v1.transitionErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/receptions/{receptionId}/reopen": {
+            "post": {
+                "security": [
+                    {
+                        "JWT": []
+                    }
+                ],
+                "description": "Только для модераторов. Открывает закрытую приёмку, если в её доке нет другой открытой приёмки. Причина и автор изменения сохраняются в истории статусов.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "receptions"
+                ],
+                "summary": "Повторное открытие приёмки",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор приёмки",
+                        "name": "receptionId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Причина",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.receptionStatusRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.createReceptionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный идентификатор, не указана причина или в доке есть открытая приёмка",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не авторизован",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Доступ запрещён: требуется роль модератора",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Приёмка не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Приёмка не закрыта",
+                        "schema": {
+                            "$ref": "#/definitions/v1.transitionErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/receptions/{receptionId}/start": {
+            "post": {
+                "security": [
+                    {
+                        "JWT": []
+                    }
+                ],
+                "description": "Только для сотрудников. Переводит черновик в работу, если в его доке нет другой открытой приёмки, а ПВЗ, соблюдающий рабочие часы, сейчас работает. Тело запроса необязательно; причина сохраняется в истории статусов как комментарий.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "receptions"
+                ],
+                "summary": "Начало приёмки из черновика",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор приёмки",
+                        "name": "receptionId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Комментарий",
+                        "name": "input",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/v1.receptionStatusRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.createReceptionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный идентификатор, в доке есть открытая приёмка или ПВЗ сейчас не работает",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не авторизован",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Доступ запрещён",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Приёмка не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Приёмка не является черновиком",
+                        "schema": {
+                            "$ref": "#/definitions/v1.transitionErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/receptions/{receptionId}/verify": {
+            "post": {
+                "security": [
+                    {
+                        "JWT": []
+                    }
+                ],
+                "description": "Только для модераторов. Отмечает закрытую приёмку как проверенную; после этого её нельзя открыть повторно. Тело запроса необязательно; причина сохраняется в истории статусов как комментарий.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "receptions"
+                ],
+                "summary": "Проверка приёмки",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор приёмки",
+                        "name": "receptionId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Комментарий",
+                        "name": "input",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/v1.receptionStatusRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.createReceptionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный идентификатор",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не авторизован",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Доступ запрещён: требуется роль модератора",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Приёмка не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Приёмка не закрыта",
+                        "schema": {
+                            "$ref": "#/definitions/v1.transitionErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/register": {
+            "post": {
+                "description": "Регистрация нового пользователя",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Register",
+                "parameters": [
+                    {
+                        "description": "Данные для регистрации",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.registerRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Возвращает данные зарегистрированного пользователя",
+                        "schema": {
+                            "$ref": "#/definitions/v1.registerResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректное тело запроса",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Пользователь с таким email уже существует",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/transfers": {
+            "post": {
+                "security": [
+                    {
+                        "JWT": []
+                    }
+                ],
+                "description": "Доступно для сотрудников и модераторов. Заводит заказ на перемещение хранящихся товаров из одного ПВЗ в другой, например при переполнении или закрытии ПВЗ. Товары должны храниться в ПВЗ отправления, относиться к закрытым приёмкам и не входить в другие незавершённые перемещения.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transfers"
+                ],
+                "summary": "Создание перемещения",
+                "parameters": [
+                    {
+                        "description": "ПВЗ и товары",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.createTransferRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/v1.transferResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный запрос, идентификатор ПВЗ или список товаров",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не авторизован",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Доступ запрещён",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Товар не найден в ПВЗ отправления",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Товар не хранится, его приёмка не закрыта или он уже перемещается",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/transfers/{transferId}": {
+            "get": {
+                "security": [
+                    {
+                        "JWT": []
+                    }
+                ],
+                "description": "Доступно для сотрудников и модераторов. Возвращает перемещение с товарами и отметками об их приёмке.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transfers"
+                ],
+                "summary": "Перемещение",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор перемещения",
+                        "name": "transferId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.transferResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный идентификатор перемещения",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не авторизован",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Доступ запрещён",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Перемещение не найдено",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/transfers/{transferId}/cancel": {
+            "post": {
+                "security": [
+                    {
+                        "JWT": []
+                    }
+                ],
+                "description": "Доступно для сотрудников и модераторов. Отменяет перемещение, пока товары не отправлены.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transfers"
+                ],
+                "summary": "Отмена перемещения",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор перемещения",
+                        "name": "transferId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.transferResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный идентификатор перемещения",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не авторизован",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Доступ запрещён",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Перемещение не найдено",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Перемещение уже отправлено или отменено",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/transfers/{transferId}/dispatch": {
+            "post": {
+                "security": [
+                    {
+                        "JWT": []
+                    }
+                ],
+                "description": "Доступно для сотрудников и модераторов. Переводит товары в статус in_transit и заводит в ПВЗ назначения черновик приёмки с манифестом по отправленным товарам. Отправить можно только созданное перемещение.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transfers"
+                ],
+                "summary": "Отправка перемещения",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор перемещения",
+                        "name": "transferId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.transferResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный идентификатор перемещения",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не авторизован",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Доступ запрещён",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Перемещение или товар не найдены",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Перемещение уже отправлено или отменено, либо товар больше не хранится",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/transfers/{transferId}/items/{productId}/receive": {
+            "post": {
+                "security": [
+                    {
+                        "JWT": []
+                    }
+                ],
+                "description": "Только для сотрудников ПВЗ. Принимает отправленный товар в приёмку ПВЗ назначения: товар заводится в ней новой строкой с новым кодом получения и ячейкой, а в приёмке отправления остаётся в статусе transferred. Приёмку нужно предварительно начать. Когда приняты все товары, перемещение завершается.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transfers"
+                ],
+                "summary": "Приёмка товара перемещения",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор перемещения",
+                        "name": "transferId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Идентификатор товара",
+                        "name": "productId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.createProductResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный идентификатор перемещения или товара",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не авторизован",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Доступ запрещён",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Перемещение или товар не найдены",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Перемещение не отправлено, товар уже принят, приёмка не начата или товар с таким штрихкодом уже хранится",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpresponse.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "httpresponse.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1.acceptanceActResponse": {
+            "description": "Акт приёма товаров по закрытой приёмке",
+            "type": "object",
+            "properties": {
+                "city": {
+                    "description": "Город ПВЗ",
+                    "type": "string"
+                },
+                "closedAt": {
+                    "description": "Время закрытия приёмки; пусто для приёмок, закрытых до учёта времени закрытия\nformat: date-time",
+                    "type": "string"
+                },
+                "closedBy": {
+                    "description": "Кто закрыл приёмку\nformat: uuid",
+                    "type": "string"
+                },
+                "contentHash": {
+                    "description": "SHA-256 содержимого акта",
+                    "type": "string"
+                },
+                "counts": {
+                    "description": "Количество принятых товаров по типам",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.manifestItemDTO"
+                    }
+                },
+                "declaredValue": {
+                    "description": "Объявленная ценность принятых товаров по валютам",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.valueTotalDTO"
+                    }
+                },
+                "delivery": {
+                    "description": "Сведения о поставке",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/v1.deliveryDTO"
+                        }
+                    ]
+                },
+                "openedAt": {
+                    "description": "Время открытия приёмки\nformat: date-time",
+                    "type": "string"
+                },
+                "openedBy": {
+                    "description": "Кто открыл приёмку\nformat: uuid",
+                    "type": "string"
+                },
+                "products": {
+                    "description": "Принятые товары в порядке добавления",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.actProductDTO"
+                    }
+                },
+                "pvzId": {
+                    "description": "Идентификатор ПВЗ\nformat: uuid",
+                    "type": "string"
+                },
+                "receptionId": {
+                    "description": "Идентификатор приёмки\nformat: uuid",
+                    "type": "string"
+                },
+                "total": {
+                    "description": "Всего принято товаров",
+                    "type": "integer"
+                },
+                "version": {
+                    "description": "Редакция акта: каждое повторное закрытие приёмки фиксирует следующую",
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "v1.actProductDTO": {
+            "description": "Товар в акте приёма",
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "description": "Валюта объявленной ценности",
+                    "type": "string"
+                },
+                "dateTime": {
+                    "description": "Время добавления товара\nformat: date-time",
+                    "type": "string"
+                },
+                "declaredValue": {
+                    "description": "Объявленная ценность в минимальных единицах валюты",
+                    "type": "integer"
+                },
+                "id": {
+                    "description": "Идентификатор товара\nformat: uuid",
+                    "type": "string"
+                },
+                "type": {
+                    "description": "Тип товара",
+                    "type": "string"
+                }
+            }
+        },
+        "v1.assignCellRequest": {
+            "description": "Запрос на перемещение товара в другую ячейку",
+            "type": "object",
+            "properties": {
+                "cellId": {
+                    "description": "Идентификатор ячейки ПВЗ товара\nformat: uuid",
+                    "type": "string"
+                }
+            }
+        },
+        "v1.blindCountDTO": {
+            "description": "Результат «слепого» пересчёта при закрытии приёмки",
+            "type": "object",
+            "properties": {
+                "hasMismatch": {
+                    "description": "Есть ли расхождения между пересчётом и учтёнными товарами",
+                    "type": "boolean"
+                },
+                "items": {
+                    "description": "Сверка по типам товаров",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.blindCountItemDTO"
+                    }
+                }
+            }
+        },
+        "v1.blindCountItemDTO": {
+            "description": "Сверка пересчёта по одному типу товара",
+            "type": "object",
+            "properties": {
+                "counted": {
+                    "description": "Пересчитано сотрудником",
+                    "type": "integer"
+                },
+                "recorded": {
+                    "description": "Учтено в системе",
+                    "type": "integer"
+                },
+                "type": {
+                    "description": "Тип товара",
+                    "type": "string"
+                }
+            }
+        },
+        "v1.closeReceptionRequest": {
+            "description": "Запрос на закрытие приёмки",
+            "type": "object",
+            "properties": {
+                "countedTotals": {
+                    "description": "Пересчитанное сотрудником количество товаров по типам; если задано, приёмка закрывается только после сверки с учтёнными товарами",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.manifestItemDTO"
+                    }
+                }
+            }
+        },
+        "v1.closeReceptionResponse": {
+            "description": "Ответ с сообщением о закрытие приемки",
+            "type": "object",
+            "properties": {
+                "blindCount": {
+                    "description": "Результат «слепого» пересчёта; отсутствует, если приёмка закрывалась без пересчёта",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/v1.blindCountDTO"
+                        }
+                    ]
+                },
+                "discrepancyReport": {
+                    "description": "Отчёт о расхождениях с манифестом; отсутствует, если приёмка создавалась без манифеста",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/v1.discrepancyReportDTO"
+                        }
+                    ]
+                },
+                "message": {
+                    "description": "Сообщение о статусе закрытия приёмки",
+                    "type": "string"
+                }
+            }
+        },
+        "v1.createDockRequest": {
+            "description": "Запрос для создания дока",
+            "type": "object",
+            "properties": {
+                "name": {
+                    "description": "Название дока, уникальное в пределах ПВЗ",
+                    "type": "string",
+                    "example": "ворота 2"
+                }
+            }
+        },
+        "v1.createPVZRequest": {
+            "description": "Запрос для создания ПВЗ",
+            "type": "object",
+            "properties": {
+                "city": {
+                    "description": "Город\nenum: Москва, Санкт-Петербург, Казань",
+                    "type": "string"
+                },
+                "timezone": {
+                    "description": "Часовой пояс ПВЗ в формате IANA (по умолчанию Europe/Moscow)",
+                    "type": "string",
+                    "example": "Europe/Moscow"
+                }
+            }
+        },
+        "v1.createPVZResponse": {
+            "description": "Ответ с данными о созданном ПВЗ",
+            "type": "object",
+            "properties": {
+                "city": {
+                    "description": "Город\nenum: Москва, Санкт-Петербург, Казань",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "Уникальный идентификатор ПВЗ\nformat: uuid",
+                    "type": "string"
+                },
+                "registration_date": {
+                    "description": "Дата регистрации ПВЗ\nformat: date-time",
+                    "type": "string"
+                },
+                "timezone": {
+                    "description": "Часовой пояс ПВЗ в формате IANA",
+                    "type": "string"
+                }
+            }
+        },
+        "v1.createProductRequest": {
+            "description": "Запрос для добавления товара",
+            "type": "object",
+            "properties": {
+                "attributes": {
+                    "description": "Дополнительные атрибуты по JSON-схеме типа, например {\"size\": 42}",
+                    "type": "object"
+                },
+                "barcode": {
+                    "description": "Штрихкод или номер заказа: 4–64 символа, латинские буквы, цифры и дефис; регистр не важен",
+                    "type": "string",
+                    "example": "4601234567890"
+                },
+                "currency": {
+                    "description": "Валюта объявленной ценности, код ISO 4217",
+                    "type": "string",
+                    "example": "RUB"
+                },
+                "declaredValue": {
+                    "description": "Объявленная ценность в минимальных единицах валюты (копейках); указывается вместе с currency",
+                    "type": "integer",
+                    "example": 1299000
+                },
+                "dockId": {
+                    "description": "Идентификатор дока, в открытую приёмку которого добавляется товар\nformat: uuid",
+                    "type": "string"
+                },
+                "pvzId": {
+                    "description": "Идентификатор ПВЗ; можно не указывать, если задан dockId или receptionId\nformat: uuid",
+                    "type": "string"
+                },
+                "receptionId": {
+                    "description": "Идентификатор открытой приёмки\nformat: uuid",
+                    "type": "string"
+                },
+                "returnReason": {
+                    "description": "Причина возврата; обязательна в приёмке-возврате и недопустима в поставке",
+                    "type": "string",
+                    "example": "не подошёл размер"
+                },
+                "type": {
+                    "description": "Тип товара из каталога (GET /api/v1/product-types)",
+                    "type": "string",
+                    "example": "обувь"
+                }
+            }
+        },
+        "v1.createProductResponse": {
+            "description": "Ответ с данными о добавленном товаре",
+            "type": "object",
+            "properties": {
+                "addedBy": {
+                    "description": "Идентификатор пользователя, добавившего товар; не указывается для товаров без сведений об авторе\nformat: uuid",
+                    "type": "string"
+                },
+                "attributes": {
+                    "description": "Дополнительные атрибуты товара",
+                    "type": "object"
+                },
+                "barcode": {
+                    "description": "Штрихкод товара",
+                    "type": "string"
+                },
+                "cell": {
+                    "description": "Ячейка хранения; нет, если подходящих свободных ячеек не было",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/v1.productCellResponse"
+                        }
+                    ]
+                },
+                "currency": {
+                    "description": "Валюта объявленной ценности, код ISO 4217",
+                    "type": "string",
+                    "example": "RUB"
+                },
+                "damageDescription": {
+                    "description": "Описание повреждения",
+                    "type": "string"
+                },
+                "damaged": {
+                    "description": "Товар отмечен повреждённым",
+                    "type": "boolean"
+                },
+                "damagedAt": {
+                    "description": "Время отметки о повреждении\nformat: date-time",
+                    "type": "string"
+                },
+                "damagedBy": {
+                    "description": "Идентификатор сотрудника, отметившего повреждение\nformat: uuid",
+                    "type": "string"
+                },
+                "dateTime": {
+                    "description": "Дата и время добавления товара\nformat: date-time",
+                    "type": "string"
+                },
+                "declaredValue": {
+                    "description": "Объявленная ценность в минимальных единицах валюты",
+                    "type": "integer",
+                    "example": 1299000
+                },
+                "id": {
+                    "description": "Уникальный идентификатор товара\nformat: uuid",
+                    "type": "string"
+                },
+                "issuedAt": {
+                    "description": "Время выдачи товара\nformat: date-time",
+                    "type": "string"
+                },
+                "issuedBy": {
+                    "description": "Идентификатор сотрудника, выдавшего товар\nformat: uuid",
+                    "type": "string"
+                },
+                "originalProductId": {
+                    "description": "Идентификатор выданного ранее товара с тем же штрихкодом, к которому относится возврат\nformat: uuid",
+                    "type": "string"
+                },
+                "overdueAt": {
+                    "description": "Когда у товара истёк срок хранения и он попал в очередь на возврат отправителю\nformat: date-time",
+                    "type": "string"
+                },
+                "pickupCode": {
+                    "description": "Код получения, который покупатель предъявляет при выдаче",
+                    "type": "string",
+                    "example": "042917"
+                },
+                "position": {
+                    "description": "Порядковый номер товара в приёмке, начиная с 1",
+                    "type": "integer",
+                    "example": 1
+                },
+                "pvzId": {
+                    "description": "Идентификатор ПВЗ, в котором хранится товар\nformat: uuid",
+                    "type": "string"
+                },
+                "receptionId": {
+                    "description": "Идентификатор приёмки\nformat: uuid",
+                    "type": "string"
+                },
+                "returnReason": {
+                    "description": "Причина возврата товара покупателем",
+                    "type": "string"
+                },
+                "returnedAt": {
+                    "description": "Время отправки товара обратно отправителю\nformat: date-time",
+                    "type": "string"
+                },
+                "returnedBy": {
+                    "description": "Идентификатор сотрудника, отправившего товар обратно отправителю\nformat: uuid",
+                    "type": "string"
+                },
+                "sourceProductId": {
+                    "description": "Идентификатор строки товара в ПВЗ отправления, если товар принят по перемещению\nformat: uuid",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "Статус товара: stored, in_transit, transferred, issued или returned_to_sender",
+                    "type": "string",
+                    "example": "stored"
+                },
+                "type": {
+                    "description": "Тип товара",
+                    "type": "string"
+                }
+            }
+        },
+        "v1.createReceptionRequest": {
+            "description": "Запрос для создания приёмки",
+            "type": "object",
+            "properties": {
+                "carrier": {
+                    "description": "Перевозчик",
+                    "type": "string",
+                    "example": "СДЭК"
+                },
+                "comment": {
+                    "description": "Комментарий",
+                    "type": "string"
+                },
+                "dockId": {
+                    "description": "Идентификатор дока; если не задан, приёмка открывается в доке по умолчанию\nformat: uuid",
+                    "type": "string"
+                },
+                "draft": {
+                    "description": "Завести приёмку черновиком; черновик не занимает док и начинается отдельным запросом",
+                    "type": "boolean"
+                },
+                "kind": {
+                    "description": "Вид приёмки: delivery — поставка, return — возврат от покупателей; по умолчанию delivery.\nПриёмки перемещений (transfer) заводятся только при отправке перемещения\nenum: delivery, return",
+                    "type": "string",
+                    "example": "delivery"
+                },
+                "manifest": {
+                    "description": "Ожидаемый состав поставки; если не задан, приёмка «слепая»",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.manifestItemDTO"
+                    }
+                },
+                "pvz_id": {
+                    "description": "Идентификатор ПВЗ\nformat: uuid",
+                    "type": "string"
+                },
+                "vehiclePlate": {
+                    "description": "Государственный номер машины",
+                    "type": "string",
+                    "example": "А123ВС777"
+                },
+                "waybillNumber": {
+                    "description": "Номер накладной",
+                    "type": "string",
+                    "example": "WB-2025-000123"
+                }
+            }
+        },
+        "v1.createReceptionResponse": {
+            "description": "Ответ с данными о созданной приёмке",
+            "type": "object",
+            "properties": {
+                "carrier": {
+                    "description": "Перевозчик",
+                    "type": "string",
+                    "example": "СДЭК"
+                },
+                "comment": {
+                    "description": "Комментарий",
+                    "type": "string"
+                },
+                "dateTime": {
+                    "description": "Дата и время создания приёмки\nformat: date-time",
+                    "type": "string"
+                },
+                "dockId": {
+                    "description": "Идентификатор дока\nformat: uuid",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "Уникальный идентификатор приёмки\nformat: uuid",
+                    "type": "string"
+                },
+                "kind": {
+                    "description": "Вид приёмки\nenum: delivery, return, transfer",
+                    "type": "string"
+                },
+                "manifest": {
+                    "description": "Ожидаемый состав поставки",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.manifestItemDTO"
+                    }
+                },
+                "openedBy": {
+                    "description": "Идентификатор пользователя, открывшего приёмку\nformat: uuid",
+                    "type": "string"
+                },
+                "pvzId": {
+                    "description": "Идентификатор ПВЗ\nformat: uuid",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "Статус приёмки\nenum: draft, in_progress, close, verified, cancelled",
+                    "type": "string"
+                },
+                "vehiclePlate": {
+                    "description": "Государственный номер машины",
+                    "type": "string",
+                    "example": "А123ВС777"
+                },
+                "waybillNumber": {
+                    "description": "Номер накладной",
+                    "type": "string",
+                    "example": "WB-2025-000123"
+                }
+            }
+        },
+        "v1.createStorageCellRequest": {
+            "description": "Запрос для создания ячейки хранения",
+            "type": "object",
+            "properties": {
+                "capacity": {
+                    "description": "Вместимость ячейки в товарах, от 1 до 10000",
+                    "type": "integer",
+                    "example": 20
+                },
+                "code": {
+                    "description": "Код ячейки, уникальный в пределах ПВЗ",
+                    "type": "string",
+                    "example": "A-03"
+                },
+                "productType": {
+                    "description": "Тип товара из каталога; без типа ячейка принимает любые товары",
+                    "type": "string",
+                    "example": "обувь"
+                },
+                "shelf": {
+                    "description": "Стеллаж (полка), на котором находится ячейка",
+                    "type": "string",
+                    "example": "A"
+                }
+            }
+        },
+        "v1.createTransferRequest": {
+            "description": "Запрос на перемещение товаров в другой ПВЗ",
+            "type": "object",
+            "properties": {
+                "fromPvzId": {
+                    "description": "Идентификатор ПВЗ отправления\nformat: uuid",
+                    "type": "string"
+                },
+                "productIds": {
+                    "description": "Идентификаторы перемещаемых товаров, от 1 до 1000",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "toPvzId": {
+                    "description": "Идентификатор ПВЗ назначения\nformat: uuid",
+                    "type": "string"
+                }
+            }
+        },
+        "v1.deleteProductResponse": {
+            "description": "Ответ с сообщением об удалении товара",
+            "type": "object",
+            "properties": {
+                "message": {
+                    "description": "Сообщение об успешном удалении товара",
+                    "type": "string"
+                }
+            }
+        },
+        "v1.deliveryDTO": {
+            "description": "Сведения о поставке от перевозчика",
+            "type": "object",
+            "properties": {
+                "carrier": {
+                    "description": "Перевозчик",
+                    "type": "string",
+                    "example": "СДЭК"
+                },
+                "comment": {
+                    "description": "Комментарий",
+                    "type": "string"
+                },
+                "vehiclePlate": {
+                    "description": "Государственный номер машины",
+                    "type": "string",
+                    "example": "А123ВС777"
+                },
+                "waybillNumber": {
+                    "description": "Номер накладной",
+                    "type": "string",
+                    "example": "WB-2025-000123"
+                }
+            }
+        },
+        "v1.discrepancyItemDTO": {
+            "description": "Расхождение по одному типу товара",
+            "type": "object",
+            "properties": {
+                "expected": {
+                    "description": "Ожидалось по манифесту",
+                    "type": "integer"
+                },
+                "received": {
+                    "description": "Фактически принято",
+                    "type": "integer"
+                },
+                "shortage": {
+                    "description": "Недостача",
+                    "type": "integer"
+                },
+                "surplus": {
+                    "description": "Излишек",
+                    "type": "integer"
+                },
+                "type": {
+                    "description": "Тип товара",
+                    "type": "string"
+                }
+            }
+        },
+        "v1.discrepancyReportDTO": {
+            "description": "Отчёт о расхождениях между манифестом и принятыми товарами",
+            "type": "object",
+            "properties": {
+                "hasDiscrepancies": {
+                    "description": "Есть ли недостачи или излишки",
+                    "type": "boolean"
+                },
+                "items": {
+                    "description": "Сверка по типам товаров",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.discrepancyItemDTO"
+                    }
+                }
+            }
+        },
+        "v1.dockDTO": {
+            "description": "Док (зона разгрузки) ПВЗ",
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "description": "Дата создания дока\nformat: date-time",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "Идентификатор дока\nformat: uuid",
+                    "type": "string"
+                },
+                "isDefault": {
+                    "description": "Док по умолчанию: в нём открываются приёмки без указания дока",
+                    "type": "boolean"
+                },
+                "name": {
+                    "description": "Название дока",
+                    "type": "string"
+                },
+                "pvzId": {
+                    "description": "Идентификатор ПВЗ\nformat: uuid",
+                    "type": "string"
+                }
+            }
+        },
+        "v1.dummyLoginRequest": {
+            "description": "Запрос для получения тестового токена авторизации",
+            "type": "object",
+            "properties": {
+                "role": {
+                    "description": "Роль пользователя (employee или moderator)\nenum: employee,moderator",
+                    "type": "string"
+                }
+            }
+        },
+        "v1.dummyLoginResponse": {
+            "description": "Ответ с тестовым токеном авторизации",
+            "type": "object",
+            "properties": {
+                "token": {
+                    "description": "JWT-токен для аутентификации",
+                    "type": "string"
+                }
+            }
+        },
+        "v1.holidayDTO": {
+            "description": "Исключение из недельного расписания на конкретную дату",
+            "type": "object",
+            "properties": {
+                "closeTime": {
+                    "description": "Время закрытия (HH:MM); пустое значение означает выходной",
+                    "type": "string"
+                },
+                "comment": {
+                    "description": "Комментарий",
+                    "type": "string"
+                },
+                "date": {
+                    "description": "Дата исключения\nformat: date",
+                    "type": "string",
+                    "example": "2025-01-01"
+                },
+                "openTime": {
+                    "description": "Время открытия (HH:MM); пустое значение означает выходной",
+                    "type": "string"
+                }
+            }
+        },
+        "v1.issueProductRequest": {
+            "description": "Запрос на выдачу товара покупателю",
+            "type": "object",
+            "properties": {
+                "pickupCode": {
+                    "description": "Код получения, который предъявил покупатель",
+                    "type": "string",
+                    "example": "042917"
+                },
+                "pvzId": {
+                    "description": "Идентификатор ПВЗ, в котором выдаётся товар\nformat: uuid",
+                    "type": "string"
+                }
+            }
+        },
+        "v1.listDocksResponse": {
+            "description": "Список доков ПВЗ",
+            "type": "object",
+            "properties": {
+                "docks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.dockDTO"
+                    }
+                }
+            }
+        },
+        "v1.listPVZWithDetailsResponse": {
+            "description": "Ответ с данными о ПВЗ, включая приёмки и товары",
+            "type": "object",
+            "properties": {
+                "pvzs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.pvzWithDetails"
+                    }
+                }
+            }
+        },
+        "v1.listStorageCellsResponse": {
+            "description": "Схема хранения ПВЗ",
+            "type": "object",
+            "properties": {
+                "cells": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.storageCellDTO"
+                    }
+                }
+            }
+        },
+        "v1.loginRequest": {
+            "description": "Запрос для аутентификации пользователя",
+            "type": "object",
+            "properties": {
+                "email": {
+                    "description": "Электронная почта пользователя\nformat: email",
+                    "type": "string"
+                },
+                "password": {
+                    "description": "Пароль пользователя",
+                    "type": "string"
+                }
+            }
+        },
+        "v1.loginResponse": {
+            "description": "Ответ с токеном авторизации после успешной аутентификации",
+            "type": "object",
+            "properties": {
+                "token": {
+                    "description": "JWT-токен для аутентификации",
+                    "type": "string"
+                }
+            }
+        },
+        "v1.manifestItemDTO": {
+            "description": "Ожидаемое количество товаров одного типа",
+            "type": "object",
+            "properties": {
+                "count": {
+                    "description": "Ожидаемое количество",
+                    "type": "integer",
+                    "example": 10
+                },
+                "type": {
+                    "description": "Тип товара\nТип товара из каталога (GET /api/v1/product-types)",
+                    "type": "string"
+                }
+            }
+        },
+        "v1.markDamagedRequest": {
+            "description": "Запрос на отметку о повреждении товара",
+            "type": "object",
+            "properties": {
+                "description": {
+                    "description": "Описание повреждения, до 1000 символов",
+                    "type": "string",
+                    "example": "смята коробка, порвана упаковка"
+                }
+            }
+        },
+        "v1.productBatchItemRequest": {
+            "description": "Позиция пакета",
+            "type": "object",
+            "properties": {
+                "attributes": {
+                    "description": "Дополнительные атрибуты по JSON-схеме типа",
+                    "type": "object"
+                },
+                "barcode": {
+                    "description": "Штрихкод товара",
+                    "type": "string",
+                    "example": "4601234567890"
+                },
+                "currency": {
+                    "description": "Валюта объявленной ценности, код ISO 4217",
+                    "type": "string",
+                    "example": "RUB"
+                },
+                "declaredValue": {
+                    "description": "Объявленная ценность в минимальных единицах валюты; указывается вместе с currency",
+                    "type": "integer",
+                    "example": 1299000
+                },
+                "returnReason": {
+                    "description": "Причина возврата; обязательна в приёмке-возврате",
+                    "type": "string",
+                    "example": "не подошёл размер"
+                },
+                "type": {
+                    "description": "Тип товара из каталога",
+                    "type": "string",
+                    "example": "обувь"
+                }
+            }
+        },
+        "v1.productBatchItemResponse": {
+            "description": "Результат по позиции пакета",
+            "type": "object",
+            "properties": {
+                "error": {
+                    "description": "Текст ошибки позиции",
+                    "type": "string"
+                },
+                "existingProductId": {
+                    "description": "Идентификатор уже хранящегося товара с тем же штрихкодом\nformat: uuid",
+                    "type": "string"
+                },
+                "index": {
+                    "description": "Номер позиции в запросе, с нуля",
+                    "type": "integer"
+                },
+                "product": {
+                    "description": "Добавленный товар",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/v1.createProductResponse"
+                        }
+                    ]
+                },
+                "status": {
+                    "description": "created, failed или not_created (позиция корректна, но пакет отклонён)",
+                    "type": "string",
+                    "enum": [
+                        "created",
+                        "failed",
+                        "not_created"
+                    ]
+                }
+            }
+        },
+        "v1.productBatchRequest": {
+            "description": "Запрос на пакетное добавление товаров в одну открытую приёмку",
+            "type": "object",
+            "properties": {
+                "dockId": {
+                    "description": "Идентификатор дока, в открытую приёмку которого добавляются товары\nformat: uuid",
+                    "type": "string"
+                },
+                "items": {
+                    "description": "Товары пакета, от 1 до 1000",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.productBatchItemRequest"
+                    }
+                },
+                "mode": {
+                    "description": "Режим: all_or_nothing (по умолчанию) или best_effort",
+                    "type": "string",
+                    "enum": [
+                        "all_or_nothing",
+                        "best_effort"
+                    ],
+                    "example": "all_or_nothing"
+                },
+                "pvzId": {
+                    "description": "Идентификатор ПВЗ; можно не указывать, если задан dockId или receptionId\nformat: uuid",
+                    "type": "string"
+                },
+                "receptionId": {
+                    "description": "Идентификатор открытой приёмки\nformat: uuid",
+                    "type": "string"
+                }
+            }
+        },
+        "v1.productBatchResponse": {
+            "description": "Результат пакетного добавления товаров",
             "type": "object",
             "properties": {
+                "created": {
+                    "description": "Число добавленных товаров",
+                    "type": "integer"
+                },
                 "error": {
+                    "description": "Текст ошибки, если пакет отклонён",
+                    "type": "string",
+                    "example": "product batch rejected"
+                },
+                "failed": {
+                    "description": "Число позиций с ошибками",
+                    "type": "integer"
+                },
+                "items": {
+                    "description": "Результаты по позициям в порядке запроса",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.productBatchItemResponse"
+                    }
+                },
+                "mode": {
+                    "description": "Режим обработки пакета",
+                    "type": "string",
+                    "example": "all_or_nothing"
+                },
+                "receptionId": {
+                    "description": "Идентификатор приёмки\nformat: uuid",
                     "type": "string"
                 }
             }
         },
-        "v1.closeReceptionResponse": {
-            "description": "Ответ с сообщением о закрытие приемки",
+        "v1.productCellResponse": {
+            "description": "Ячейка, в которой хранится товар",
             "type": "object",
             "properties": {
-                "message": {
-                    "description": "Сообщение о статусе закрытия приёмки",
+                "code": {
+                    "description": "Код ячейки",
+                    "type": "string",
+                    "example": "A-03"
+                },
+                "id": {
+                    "description": "Идентификатор ячейки\nformat: uuid",
                     "type": "string"
+                },
+                "shelf": {
+                    "description": "Стеллаж",
+                    "type": "string",
+                    "example": "A"
                 }
             }
         },
-        "v1.createPVZRequest": {
-            "description": "Запрос для создания ПВЗ",
+        "v1.productConflictResponse": {
+            "description": "Ошибка повторного сканирования: товар с таким штрихкодом уже хранится",
             "type": "object",
             "properties": {
-                "city": {
-                    "description": "Город\nenum: Москва, Санкт-Петербург, Казань",
+                "error": {
+                    "description": "Текст ошибки",
+                    "type": "string",
+                    "example": "product with this barcode is already stored"
+                },
+                "existingProductId": {
+                    "description": "Идентификатор уже хранящегося товара\nformat: uuid",
+                    "type": "string"
+                },
+                "receptionId": {
+                    "description": "Идентификатор приёмки, в которой принят этот товар\nformat: uuid",
                     "type": "string"
                 }
             }
         },
-        "v1.createPVZResponse": {
-            "description": "Ответ с данными о созданном ПВЗ",
+        "v1.productDetails": {
+            "description": "Детали товара",
+            "type": "object",
+            "properties": {
+                "addedBy": {
+                    "description": "Идентификатор пользователя, добавившего товар\nformat: uuid",
+                    "type": "string"
+                },
+                "attributes": {
+                    "description": "Дополнительные атрибуты товара по схеме его типа",
+                    "type": "object"
+                },
+                "barcode": {
+                    "description": "Штрихкод товара",
+                    "type": "string"
+                },
+                "cell": {
+                    "description": "Ячейка хранения товара",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/v1.productCellResponse"
+                        }
+                    ]
+                },
+                "currency": {
+                    "description": "Валюта объявленной ценности, код ISO 4217",
+                    "type": "string"
+                },
+                "date_time": {
+                    "description": "Дата и время добавления товара\nformat: date-time",
+                    "type": "string"
+                },
+                "declaredValue": {
+                    "description": "Объявленная ценность в минимальных единицах валюты",
+                    "type": "integer"
+                },
+                "deletedAt": {
+                    "description": "Время удаления товара\nformat: date-time",
+                    "type": "string"
+                },
+                "deletedBy": {
+                    "description": "Идентификатор пользователя, удалившего товар\nformat: uuid",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "Уникальный идентификатор товара\nformat: uuid",
+                    "type": "string"
+                },
+                "originalProductId": {
+                    "description": "Идентификатор выданного ранее товара, к которому относится возврат\nformat: uuid",
+                    "type": "string"
+                },
+                "position": {
+                    "description": "Порядковый номер товара в приёмке, начиная с 1",
+                    "type": "integer",
+                    "example": 1
+                },
+                "reception_id": {
+                    "description": "Идентификатор приёмки, к которой относится товар\nformat: uuid",
+                    "type": "string"
+                },
+                "returnReason": {
+                    "description": "Причина возврата товара покупателем",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "Статус товара: stored, in_transit, transferred, issued или returned_to_sender",
+                    "type": "string",
+                    "example": "stored"
+                },
+                "type": {
+                    "description": "Тип товара из каталога",
+                    "type": "string"
+                }
+            }
+        },
+        "v1.productLocationResponse": {
+            "description": "Запись истории местонахождения товара",
+            "type": "object",
+            "properties": {
+                "changedAt": {
+                    "description": "Время изменения\nformat: date-time",
+                    "type": "string"
+                },
+                "changedBy": {
+                    "description": "Кто изменил местонахождение\nformat: uuid",
+                    "type": "string"
+                },
+                "pvzId": {
+                    "description": "ПВЗ, в котором хранится товар; пусто, пока товар в пути\nformat: uuid",
+                    "type": "string"
+                },
+                "receptionId": {
+                    "description": "Приёмка, в которой товар хранится в ПВЗ\nformat: uuid",
+                    "type": "string"
+                },
+                "transferId": {
+                    "description": "Перемещение, с которым товар отправлен или принят\nformat: uuid",
+                    "type": "string"
+                }
+            }
+        },
+        "v1.productPhotoResponse": {
+            "description": "Фотография повреждения товара",
+            "type": "object",
+            "properties": {
+                "contentType": {
+                    "description": "Формат фотографии: image/jpeg, image/png или image/webp",
+                    "type": "string",
+                    "example": "image/jpeg"
+                },
+                "id": {
+                    "description": "Идентификатор фотографии\nformat: uuid",
+                    "type": "string"
+                },
+                "productId": {
+                    "description": "Идентификатор товара\nformat: uuid",
+                    "type": "string"
+                },
+                "size": {
+                    "description": "Размер в байтах",
+                    "type": "integer",
+                    "example": 482113
+                },
+                "uploadedAt": {
+                    "description": "Время загрузки\nformat: date-time",
+                    "type": "string"
+                },
+                "uploadedBy": {
+                    "description": "Кто загрузил фотографию\nformat: uuid",
+                    "type": "string"
+                },
+                "url": {
+                    "description": "Адрес для скачивания фотографии",
+                    "type": "string",
+                    "example": "/api/v1/products/3fa85f64-5717-4562-b3fc-2c963f66afa6/photos/9b2d3a1e-6f0c-4a8e-9d4b-1c2e3f4a5b6c"
+                }
+            }
+        },
+        "v1.productStatusConflictResponse": {
+            "description": "Товар нельзя выдать в его текущем статусе",
+            "type": "object",
+            "properties": {
+                "currentStatus": {
+                    "description": "Текущий статус товара",
+                    "type": "string",
+                    "example": "issued"
+                },
+                "error": {
+                    "description": "Текст ошибки",
+                    "type": "string",
+                    "example": "product is not stored"
+                }
+            }
+        },
+        "v1.productTypeDTO": {
+            "description": "Тип товара из каталога",
+            "type": "object",
+            "properties": {
+                "attributesSchema": {
+                    "description": "JSON-схема дополнительных атрибутов товара; поддерживаются type=object, properties\n(string, number, integer, boolean с enum, pattern, minLength, maxLength, minimum, maximum),\nrequired и additionalProperties",
+                    "type": "object"
+                },
+                "createdAt": {
+                    "description": "Время добавления типа\nformat: date-time",
+                    "type": "string"
+                },
+                "createdBy": {
+                    "description": "Кто добавил тип\nformat: uuid",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "Название типа",
+                    "type": "string",
+                    "example": "обувь"
+                },
+                "storageDays": {
+                    "description": "Срок хранения товаров типа в днях, от 1 до 365; если не задан, действует срок ПВЗ",
+                    "type": "integer",
+                    "example": 14
+                }
+            }
+        },
+        "v1.productTypeSchemaRequest": {
+            "description": "Запрос на изменение схемы атрибутов типа товара",
+            "type": "object",
+            "properties": {
+                "attributesSchema": {
+                    "description": "Новая JSON-схема атрибутов; null удаляет схему",
+                    "type": "object"
+                }
+            }
+        },
+        "v1.productTypeStoragePeriodRequest": {
+            "description": "Запрос на изменение срока хранения товаров типа",
+            "type": "object",
+            "properties": {
+                "storageDays": {
+                    "description": "Срок хранения в днях, от 1 до 365; 0 сбрасывает срок типа",
+                    "type": "integer",
+                    "example": 14
+                }
+            }
+        },
+        "v1.pvzSettingsDTO": {
+            "description": "Настройки приёмки и хранения в ПВЗ",
+            "type": "object",
+            "properties": {
+                "blindCountPolicy": {
+                    "description": "Что делать, если «слепой» пересчёт при закрытии не совпал с учтёнными товарами:\nreject — не закрывать приёмку, flag — закрыть с отметкой о расхождении\nenum: reject, flag",
+                    "type": "string",
+                    "example": "reject"
+                },
+                "storageDays": {
+                    "description": "Срок хранения товаров в днях, от 1 до 365; 0 или отсутствие — срок по умолчанию.\nСрок типа товара важнее срока ПВЗ",
+                    "type": "integer",
+                    "example": 14
+                }
+            }
+        },
+        "v1.pvzStockResponse": {
+            "description": "Число товаров ПВЗ по статусам",
+            "type": "object",
+            "properties": {
+                "exceedsCeiling": {
+                    "description": "Ценность хранящихся товаров превышает предел страховой суммы",
+                    "type": "boolean"
+                },
+                "inTransit": {
+                    "description": "Товары, отправленные в другой ПВЗ и ещё не принятые там",
+                    "type": "integer"
+                },
+                "insuredCeiling": {
+                    "description": "Предел страховой суммы ПВЗ в минимальных единицах валюты; не задан, если проверка отключена",
+                    "type": "integer",
+                    "example": 500000000
+                },
+                "insuredCurrency": {
+                    "description": "Валюта предела страховой суммы",
+                    "type": "string",
+                    "example": "RUB"
+                },
+                "issued": {
+                    "description": "Выданные покупателям товары",
+                    "type": "integer"
+                },
+                "pvzId": {
+                    "description": "Идентификатор ПВЗ\nformat: uuid",
+                    "type": "string"
+                },
+                "returnedToSender": {
+                    "description": "Возвращённые отправителю товары",
+                    "type": "integer"
+                },
+                "stored": {
+                    "description": "Хранящиеся товары",
+                    "type": "integer"
+                },
+                "storedValue": {
+                    "description": "Объявленная ценность хранящихся товаров по валютам",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.valueTotalDTO"
+                    }
+                }
+            }
+        },
+        "v1.pvzWithDetails": {
+            "description": "Детали ПВЗ",
             "type": "object",
             "properties": {
                 "city": {
@@ -591,255 +4557,486 @@ const docTemplate = `{
                     "description": "Уникальный идентификатор ПВЗ\nformat: uuid",
                     "type": "string"
                 },
+                "receptions": {
+                    "description": "Список приёмок",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.receptionDetails"
+                    }
+                },
                 "registration_date": {
                     "description": "Дата регистрации ПВЗ\nformat: date-time",
                     "type": "string"
+                },
+                "timezone": {
+                    "description": "Часовой пояс ПВЗ в формате IANA",
+                    "type": "string"
                 }
             }
         },
-        "v1.createProductRequest": {
-            "description": "Запрос для добавления товара",
+        "v1.receptionDetails": {
+            "description": "Детали приёмки",
             "type": "object",
             "properties": {
-                "pvzId": {
-                    "description": "Идентификатор ПВЗ\nformat: uuid",
+                "blindCount": {
+                    "description": "Результат «слепого» пересчёта при закрытии",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/v1.blindCountDTO"
+                        }
+                    ]
+                },
+                "carrier": {
+                    "description": "Перевозчик",
+                    "type": "string",
+                    "example": "СДЭК"
+                },
+                "closedAt": {
+                    "description": "Время закрытия приёмки\nformat: date-time",
                     "type": "string"
                 },
-                "type": {
-                    "description": "Тип товара\nenum: электроника, одежда, обувь",
+                "closedBy": {
+                    "description": "Идентификатор пользователя, закрывшего приёмку; пусто, если приёмка закрыта автоматически\nformat: uuid",
                     "type": "string"
-                }
-            }
-        },
-        "v1.createProductResponse": {
-            "description": "Ответ с данными о добавленном товаре",
-            "type": "object",
-            "properties": {
-                "dateTime": {
-                    "description": "Дата и время добавления товара\nformat: date-time",
+                },
+                "comment": {
+                    "description": "Комментарий",
+                    "type": "string"
+                },
+                "date_time": {
+                    "description": "Дата и время приёмки\nformat: date-time",
+                    "type": "string"
+                },
+                "declaredValue": {
+                    "description": "Объявленная ценность товаров приёмки по валютам",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.valueTotalDTO"
+                    }
+                },
+                "deletedProducts": {
+                    "description": "Удалённые из приёмки товары",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.productDetails"
+                    }
+                },
+                "discrepancyReport": {
+                    "description": "Отчёт о расхождениях, формируется при закрытии приёмки с манифестом",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/v1.discrepancyReportDTO"
+                        }
+                    ]
+                },
+                "dockId": {
+                    "description": "Идентификатор дока, в котором велась приёмка\nformat: uuid",
                     "type": "string"
                 },
                 "id": {
-                    "description": "Уникальный идентификатор товара\nformat: uuid",
+                    "description": "Уникальный идентификатор приёмки\nformat: uuid",
                     "type": "string"
                 },
-                "receptionId": {
-                    "description": "Идентификатор приёмки\nformat: uuid",
+                "kind": {
+                    "description": "Вид приёмки\nenum: delivery, return, transfer",
                     "type": "string"
                 },
-                "type": {
-                    "description": "Тип товара",
+                "manifest": {
+                    "description": "Ожидаемый состав поставки",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.manifestItemDTO"
+                    }
+                },
+                "openedBy": {
+                    "description": "Идентификатор пользователя, открывшего приёмку\nformat: uuid",
+                    "type": "string"
+                },
+                "products": {
+                    "description": "Список товаров в приёмке",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.productDetails"
+                    }
+                },
+                "pvz_id": {
+                    "description": "Идентификатор ПВЗ, к которому относится приёмка\nformat: uuid",
+                    "type": "string"
+                },
+                "staleFlaggedAt": {
+                    "description": "Когда приёмка помечена как зависшая (открыта дольше допустимого)\nformat: date-time",
+                    "type": "string"
+                },
+                "staleReason": {
+                    "description": "Причина пометки",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "Статус приёмки\nenum: draft, in_progress, close, verified, cancelled",
                     "type": "string"
+                },
+                "statusHistory": {
+                    "description": "История смен статуса",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.statusChangeDTO"
+                    }
+                },
+                "vehiclePlate": {
+                    "description": "Государственный номер машины",
+                    "type": "string",
+                    "example": "А123ВС777"
+                },
+                "waybillNumber": {
+                    "description": "Номер накладной",
+                    "type": "string",
+                    "example": "WB-2025-000123"
                 }
             }
         },
-        "v1.createReceptionRequest": {
-            "description": "Запрос для создания приёмки",
+        "v1.receptionStatusRequest": {
+            "description": "Запрос на смену статуса приёмки",
             "type": "object",
             "properties": {
-                "pvz_id": {
-                    "description": "Идентификатор ПВЗ\nformat: uuid",
-                    "type": "string"
+                "reason": {
+                    "description": "Причина смены статуса",
+                    "type": "string",
+                    "example": "закрыта по ошибке до выгрузки второй машины"
                 }
             }
         },
-        "v1.createReceptionResponse": {
-            "description": "Ответ с данными о созданной приёмке",
+        "v1.registerRequest": {
+            "description": "Запрос для регистрации нового пользователя",
             "type": "object",
             "properties": {
-                "dateTime": {
-                    "description": "Дата и время создания приёмки\nformat: date-time",
-                    "type": "string"
-                },
-                "id": {
-                    "description": "Уникальный идентификатор приёмки\nformat: uuid",
+                "email": {
+                    "description": "Электронная почта пользователя\nformat: email",
                     "type": "string"
                 },
-                "pvzId": {
-                    "description": "Идентификатор ПВЗ\nformat: uuid",
+                "password": {
+                    "description": "Пароль пользователя",
                     "type": "string"
                 },
-                "status": {
-                    "description": "Статус приёмки\nenum: open, close",
+                "role": {
+                    "description": "Роль пользователя (employee или moderator)\nenum: employee,moderator",
                     "type": "string"
                 }
             }
         },
-        "v1.deleteProductResponse": {
-            "description": "Ответ с сообщением об удалении товара",
+        "v1.registerResponse": {
+            "description": "Ответ с данными зарегистрированного пользователя",
             "type": "object",
             "properties": {
-                "message": {
-                    "description": "Сообщение об успешном удалении товара",
+                "email": {
+                    "description": "Электронная почта пользователя\nformat: email",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "Уникальный идентификатор пользователя\nformat: uuid",
+                    "type": "string"
+                },
+                "role": {
+                    "description": "Роль пользователя (employee или moderator)\nenum: employee,moderator",
                     "type": "string"
                 }
             }
         },
-        "v1.dummyLoginRequest": {
-            "description": "Запрос для получения тестового токена авторизации",
+        "v1.returnToSenderRequest": {
+            "description": "Запрос на возврат товара отправителю",
             "type": "object",
             "properties": {
-                "role": {
-                    "description": "Роль пользователя (employee или moderator)\nenum: employee,moderator",
+                "pvzId": {
+                    "description": "Идентификатор ПВЗ, из которого отправляется товар\nformat: uuid",
                     "type": "string"
                 }
             }
         },
-        "v1.dummyLoginResponse": {
-            "description": "Ответ с тестовым токеном авторизации",
+        "v1.scheduleResponse": {
+            "description": "Расписание работы ПВЗ",
             "type": "object",
             "properties": {
-                "token": {
-                    "description": "JWT-токен для аутентификации",
+                "enforceWorkingHours": {
+                    "description": "Запрещено ли создание приёмок вне рабочего времени",
+                    "type": "boolean"
+                },
+                "holidays": {
+                    "description": "Праздничные и особые дни",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.holidayDTO"
+                    }
+                },
+                "pvzId": {
+                    "description": "Идентификатор ПВЗ\nformat: uuid",
                     "type": "string"
+                },
+                "timezone": {
+                    "description": "Часовой пояс ПВЗ в формате IANA",
+                    "type": "string"
+                },
+                "workingHours": {
+                    "description": "Недельное расписание",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.workingHoursDTO"
+                    }
                 }
             }
         },
-        "v1.listPVZWithDetailsResponse": {
-            "description": "Ответ с данными о ПВЗ, включая приёмки и товары",
+        "v1.setScheduleRequest": {
+            "description": "Запрос для установки расписания ПВЗ",
             "type": "object",
             "properties": {
-                "pvzs": {
+                "enforceWorkingHours": {
+                    "description": "Запрещать создание приёмок вне рабочего времени",
+                    "type": "boolean"
+                },
+                "holidays": {
+                    "description": "Праздничные и особые дни",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/v1.pvzWithDetails"
+                        "$ref": "#/definitions/v1.holidayDTO"
+                    }
+                },
+                "timezone": {
+                    "description": "Часовой пояс ПВЗ в формате IANA",
+                    "type": "string",
+                    "example": "Europe/Moscow"
+                },
+                "workingHours": {
+                    "description": "Недельное расписание",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.workingHoursDTO"
                     }
                 }
             }
         },
-        "v1.loginRequest": {
-            "description": "Запрос для аутентификации пользователя",
+        "v1.statusChangeDTO": {
+            "description": "Запись истории смены статуса приёмки",
             "type": "object",
             "properties": {
-                "email": {
-                    "description": "Электронная почта пользователя\nformat: email",
+                "changedAt": {
+                    "description": "Время смены статуса\nformat: date-time",
                     "type": "string"
                 },
-                "password": {
-                    "description": "Пароль пользователя",
+                "changedBy": {
+                    "description": "Идентификатор пользователя, сменившего статус\nformat: uuid",
+                    "type": "string"
+                },
+                "from": {
+                    "description": "Предыдущий статус",
+                    "type": "string"
+                },
+                "reason": {
+                    "description": "Причина",
+                    "type": "string"
+                },
+                "to": {
+                    "description": "Новый статус",
                     "type": "string"
                 }
             }
         },
-        "v1.loginResponse": {
-            "description": "Ответ с токеном авторизации после успешной аутентификации",
+        "v1.storageCellDTO": {
+            "description": "Ячейка хранения ПВЗ",
             "type": "object",
             "properties": {
-                "token": {
-                    "description": "JWT-токен для аутентификации",
+                "capacity": {
+                    "description": "Вместимость ячейки",
+                    "type": "integer",
+                    "example": 20
+                },
+                "code": {
+                    "description": "Код ячейки",
+                    "type": "string",
+                    "example": "A-03"
+                },
+                "createdAt": {
+                    "description": "Дата создания ячейки\nformat: date-time",
+                    "type": "string"
+                },
+                "free": {
+                    "description": "Число свободных мест",
+                    "type": "integer",
+                    "example": 13
+                },
+                "id": {
+                    "description": "Идентификатор ячейки\nformat: uuid",
+                    "type": "string"
+                },
+                "occupied": {
+                    "description": "Число хранящихся в ячейке товаров",
+                    "type": "integer",
+                    "example": 7
+                },
+                "productType": {
+                    "description": "Тип товара, для которого предназначена ячейка",
+                    "type": "string",
+                    "example": "обувь"
+                },
+                "pvzId": {
+                    "description": "Идентификатор ПВЗ\nformat: uuid",
                     "type": "string"
+                },
+                "shelf": {
+                    "description": "Стеллаж",
+                    "type": "string",
+                    "example": "A"
                 }
             }
         },
-        "v1.productDetails": {
-            "description": "Детали товара",
+        "v1.transferItemResponse": {
+            "description": "Товар перемещения",
             "type": "object",
             "properties": {
-                "date_time": {
-                    "description": "Дата и время добавления товара\nformat: date-time",
+                "barcode": {
+                    "description": "Штрихкод товара",
                     "type": "string"
                 },
-                "id": {
-                    "description": "Уникальный идентификатор товара\nformat: uuid",
+                "productId": {
+                    "description": "Идентификатор товара\nformat: uuid",
                     "type": "string"
                 },
-                "reception_id": {
-                    "description": "Идентификатор приёмки, к которой относится товар\nformat: uuid",
+                "receivedAt": {
+                    "description": "Время приёмки товара в ПВЗ назначения\nformat: date-time",
+                    "type": "string"
+                },
+                "receivedBy": {
+                    "description": "Кто принял товар в ПВЗ назначения\nformat: uuid",
                     "type": "string"
                 },
                 "type": {
-                    "description": "Тип товара\nenum: электроника, одежда, продукты",
+                    "description": "Тип товара",
                     "type": "string"
                 }
             }
         },
-        "v1.pvzWithDetails": {
-            "description": "Детали ПВЗ",
+        "v1.transferResponse": {
+            "description": "Перемещение товаров между ПВЗ",
             "type": "object",
             "properties": {
-                "city": {
-                    "description": "Город\nenum: Москва, Санкт-Петербург, Казань",
+                "createdAt": {
+                    "description": "Время создания\nformat: date-time",
                     "type": "string"
                 },
-                "id": {
-                    "description": "Уникальный идентификатор ПВЗ\nformat: uuid",
+                "createdBy": {
+                    "description": "Кто создал перемещение\nformat: uuid",
                     "type": "string"
                 },
-                "receptions": {
-                    "description": "Список приёмок",
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/v1.receptionDetails"
-                    }
+                "dispatchedAt": {
+                    "description": "Время отправки\nformat: date-time",
+                    "type": "string"
                 },
-                "registration_date": {
-                    "description": "Дата регистрации ПВЗ\nformat: date-time",
+                "dispatchedBy": {
+                    "description": "Кто отправил товары\nformat: uuid",
                     "type": "string"
-                }
-            }
-        },
-        "v1.receptionDetails": {
-            "description": "Детали приёмки",
-            "type": "object",
-            "properties": {
-                "date_time": {
-                    "description": "Дата и время приёмки\nformat: date-time",
+                },
+                "fromPvzId": {
+                    "description": "Идентификатор ПВЗ отправления\nformat: uuid",
                     "type": "string"
                 },
                 "id": {
-                    "description": "Уникальный идентификатор приёмки\nformat: uuid",
+                    "description": "Идентификатор перемещения\nformat: uuid",
                     "type": "string"
                 },
-                "products": {
-                    "description": "Список товаров в приёмке",
+                "items": {
+                    "description": "Товары перемещения",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/v1.productDetails"
+                        "$ref": "#/definitions/v1.transferItemResponse"
                     }
                 },
-                "pvz_id": {
-                    "description": "Идентификатор ПВЗ, к которому относится приёмка\nformat: uuid",
+                "receivedAt": {
+                    "description": "Время приёмки всех товаров\nformat: date-time",
+                    "type": "string"
+                },
+                "receptionId": {
+                    "description": "Приёмка в ПВЗ назначения, заводится при отправке\nformat: uuid",
                     "type": "string"
                 },
                 "status": {
-                    "description": "Статус приёмки\nenum: in_progress, closed",
+                    "description": "Статус перемещения",
+                    "type": "string",
+                    "enum": [
+                        "created",
+                        "in_transit",
+                        "received",
+                        "cancelled"
+                    ]
+                },
+                "toPvzId": {
+                    "description": "Идентификатор ПВЗ назначения\nformat: uuid",
                     "type": "string"
                 }
             }
         },
-        "v1.registerRequest": {
-            "description": "Запрос для регистрации нового пользователя",
+        "v1.transitionErrorResponse": {
+            "description": "Ошибка недопустимого перехода статуса приёмки",
             "type": "object",
             "properties": {
-                "email": {
-                    "description": "Электронная почта пользователя\nformat: email",
-                    "type": "string"
+                "allowedActions": {
+                    "description": "Переходы, допустимые из текущего статуса",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "reopen",
+                        "verify"
+                    ]
                 },
-                "password": {
-                    "description": "Пароль пользователя",
-                    "type": "string"
+                "currentStatus": {
+                    "description": "Текущий статус приёмки\nenum: draft, in_progress, close, verified, cancelled",
+                    "type": "string",
+                    "example": "close"
                 },
-                "role": {
-                    "description": "Роль пользователя (employee или moderator)\nenum: employee,moderator",
-                    "type": "string"
+                "error": {
+                    "description": "Текст ошибки",
+                    "type": "string",
+                    "example": "invalid status transition"
                 }
             }
         },
-        "v1.registerResponse": {
-            "description": "Ответ с данными зарегистрированного пользователя",
+        "v1.valueTotalDTO": {
+            "description": "Суммарная объявленная ценность товаров в одной валюте",
             "type": "object",
             "properties": {
-                "email": {
-                    "description": "Электронная почта пользователя\nformat: email",
-                    "type": "string"
+                "amount": {
+                    "description": "Сумма в минимальных единицах валюты",
+                    "type": "integer",
+                    "example": 1299000
                 },
-                "id": {
-                    "description": "Уникальный идентификатор пользователя\nformat: uuid",
-                    "type": "string"
+                "count": {
+                    "description": "Число товаров с объявленной ценностью в этой валюте",
+                    "type": "integer",
+                    "example": 1
                 },
-                "role": {
-                    "description": "Роль пользователя (employee или moderator)\nenum: employee,moderator",
-                    "type": "string"
+                "currency": {
+                    "description": "Валюта, код ISO 4217",
+                    "type": "string",
+                    "example": "RUB"
+                }
+            }
+        },
+        "v1.workingHoursDTO": {
+            "description": "Рабочие часы ПВЗ в один из дней недели",
+            "type": "object",
+            "properties": {
+                "closeTime": {
+                    "description": "Время закрытия по местному времени ПВЗ (HH:MM)",
+                    "type": "string",
+                    "example": "21:00"
+                },
+                "openTime": {
+                    "description": "Время открытия по местному времени ПВЗ (HH:MM)",
+                    "type": "string",
+                    "example": "09:00"
+                },
+                "weekday": {
+                    "description": "День недели (0 — воскресенье, 6 — суббота)",
+                    "type": "integer",
+                    "example": 1
                 }
             }
         }
@@ -0,0 +1,73 @@
+package integration
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/GlebMoskalev/go-pickup-point-api/integration/helperstest"
+	v1 "github.com/GlebMoskalev/go-pickup-point-api/internal/api/v1"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/repo"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/service"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCancelledReceptionReleasesBarcodes(t *testing.T) {
+	ctx := context.Background()
+	postgresContainer, dbConfig := helperstest.SetupPostgresContainer(t, ctx)
+	defer postgresContainer.Terminate(ctx)
+
+	dbPool := helperstest.SetupDatabaseConnection(t, ctx, dbConfig)
+	defer dbPool.Close()
+
+	helperstest.ApplyMigrations(t, dbConfig)
+
+	repositories := repo.NewRepositories(dbPool, helperstest.SetupBlobStore(t))
+	services := service.NewServices(repositories, helperstest.CreateTestConfig(dbConfig))
+
+	router := v1.NewRouter(services)
+
+	employeeToken := getEmployeeToken(t, router, "employee")
+	moderatorToken := getEmployeeToken(t, router, "moderator")
+
+	pvzID := createPickupPoint(t, router, moderatorToken)
+	receptionID := createReception(t, router, employeeToken, pvzID)
+
+	recorder := addProductWithBarcode(t, router, employeeToken, pvzID, "ORD-77")
+	require.Equal(t, http.StatusCreated, recorder.Code, recorder.Body.String())
+
+	reqBody, err := json.Marshal(map[string]string{"reason": "приёмка открыта не в том ПВЗ"})
+	require.NoError(t, err, "failed to marshal cancel reception request")
+
+	req := httptest.NewRequest("POST", "/api/v1/receptions/"+receptionID+"/cancel", bytes.NewReader(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+employeeToken)
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+
+	req = httptest.NewRequest("GET", "/api/v1/products/by-barcode/ORD-77", nil)
+	req.Header.Set("Authorization", "Bearer "+employeeToken)
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+	require.Equal(t, http.StatusNotFound, recorder.Code, "products of a cancelled reception are not stored")
+
+	_ = createReception(t, router, employeeToken, pvzID)
+	recorder = addProductWithBarcode(t, router, employeeToken, pvzID, "ORD-77")
+	require.Equal(t, http.StatusCreated, recorder.Code, "barcode of a cancelled reception can be received again: %s",
+		recorder.Body.String())
+}
+
+func addProductWithBarcode(t *testing.T, router http.Handler, token, pvzID, barcode string) *httptest.ResponseRecorder {
+	reqBody, err := json.Marshal(map[string]string{"pvzId": pvzID, "type": "обувь", "barcode": barcode})
+	require.NoError(t, err, "failed to marshal create product request")
+
+	req := httptest.NewRequest("POST", "/api/v1/products", bytes.NewReader(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+	return recorder
+}
@@ -0,0 +1,78 @@
+package integration
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/GlebMoskalev/go-pickup-point-api/integration/helperstest"
+	v1 "github.com/GlebMoskalev/go-pickup-point-api/internal/api/v1"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/repo"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/service"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+func TestConcurrentReceptionCreation(t *testing.T) {
+	ctx := context.Background()
+	postgresContainer, dbConfig := helperstest.SetupPostgresContainer(t, ctx)
+	defer postgresContainer.Terminate(ctx)
+
+	dbPool := helperstest.SetupDatabaseConnection(t, ctx, dbConfig)
+	defer dbPool.Close()
+
+	helperstest.ApplyMigrations(t, dbConfig)
+
+	repositories := repo.NewRepositories(dbPool, helperstest.SetupBlobStore(t))
+	services := service.NewServices(repositories, helperstest.CreateTestConfig(dbConfig))
+
+	router := v1.NewRouter(services)
+
+	employeeToken := getEmployeeToken(t, router, "employee")
+	moderatorToken := getEmployeeToken(t, router, "moderator")
+
+	pvzID := createPickupPoint(t, router, moderatorToken)
+
+	reqBody, err := json.Marshal(map[string]string{"pvz_id": pvzID})
+	require.NoError(t, err, "failed to marshal create reception request")
+
+	const workers = 20
+	var (
+		wg    sync.WaitGroup
+		mu    sync.Mutex
+		codes = make(map[int]int)
+		start = make(chan struct{})
+	)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			req := httptest.NewRequest("POST", "/api/v1/receptions", bytes.NewReader(reqBody))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("Authorization", "Bearer "+employeeToken)
+			recorder := httptest.NewRecorder()
+
+			<-start
+			router.ServeHTTP(recorder, req)
+
+			mu.Lock()
+			codes[recorder.Code]++
+			mu.Unlock()
+		}()
+	}
+	close(start)
+	wg.Wait()
+
+	require.Equal(t, 1, codes[http.StatusCreated], "exactly one reception should be opened")
+	require.Equal(t, workers-1, codes[http.StatusBadRequest], "other requests should see the open reception")
+
+	var openCount int
+	err = dbPool.QueryRow(ctx,
+		`SELECT COUNT(*) FROM receptions WHERE pvz_id = $1 AND status = 'in_progress'`,
+		pvzID,
+	).Scan(&openCount)
+	require.NoError(t, err)
+	require.Equal(t, 1, openCount)
+}
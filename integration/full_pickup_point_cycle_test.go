@@ -26,7 +26,7 @@ func TestFullPickupPointCycle(t *testing.T) {
 
 	helperstest.ApplyMigrations(t, dbConfig)
 
-	repositories := repo.NewRepositories(dbPool)
+	repositories := repo.NewRepositories(dbPool, helperstest.SetupBlobStore(t))
 	services := service.NewServices(repositories, helperstest.CreateTestConfig(dbConfig))
 
 	router := v1.NewRouter(services)
@@ -3,6 +3,7 @@ package helperstest
 import (
 	"context"
 	"github.com/GlebMoskalev/go-pickup-point-api/internal/entity"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/repo/blobstore"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/stretchr/testify/require"
@@ -35,15 +36,10 @@ func CreateAndCloseReception(t *testing.T, ctx context.Context, dbPool *pgxpool.
 	var receptionID uuid.UUID
 	err := dbPool.QueryRow(ctx,
 		`INSERT INTO receptions (pvz_id, status) VALUES ($1, $2) RETURNING id`,
-		pvzID.String(), entity.StatusInProgress,
+		pvzID.String(), entity.StatusClose,
 	).Scan(&receptionID)
 	require.NoError(t, err)
 
-	_, err = dbPool.Exec(ctx,
-		`UPDATE receptions SET status = 'close' WHERE id = $1`,
-		receptionID)
-	require.NoError(t, err)
-
 	return receptionID
 }
 
@@ -56,3 +52,10 @@ func CreateReceptionWithStatus(t *testing.T, ctx context.Context, dbPool *pgxpoo
 	require.NoError(t, err)
 	return receptionID
 }
+
+// SetupBlobStore возвращает хранилище файлов во временном каталоге теста.
+func SetupBlobStore(t *testing.T) *blobstore.Local {
+	store, err := blobstore.NewLocal(t.TempDir())
+	require.NoError(t, err)
+	return store
+}
@@ -0,0 +1,84 @@
+package integration
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/GlebMoskalev/go-pickup-point-api/integration/helperstest"
+	v1 "github.com/GlebMoskalev/go-pickup-point-api/internal/api/v1"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/repo"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/service"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestReceptionWithManifest(t *testing.T) {
+	ctx := context.Background()
+	postgresContainer, dbConfig := helperstest.SetupPostgresContainer(t, ctx)
+	defer postgresContainer.Terminate(ctx)
+
+	dbPool := helperstest.SetupDatabaseConnection(t, ctx, dbConfig)
+	defer dbPool.Close()
+
+	helperstest.ApplyMigrations(t, dbConfig)
+
+	repositories := repo.NewRepositories(dbPool, helperstest.SetupBlobStore(t))
+	services := service.NewServices(repositories, helperstest.CreateTestConfig(dbConfig))
+
+	router := v1.NewRouter(services)
+
+	employeeToken := getEmployeeToken(t, router, "employee")
+	moderatorToken := getEmployeeToken(t, router, "moderator")
+
+	pvzID := createPickupPoint(t, router, moderatorToken)
+
+	reqBody, err := json.Marshal(map[string]any{
+		"pvz_id": pvzID,
+		"manifest": []map[string]any{
+			{"type": "электроника", "count": 2},
+			{"type": "одежда", "count": 1},
+		},
+	})
+	require.NoError(t, err, "failed to marshal create reception request")
+
+	req := httptest.NewRequest("POST", "/api/v1/receptions", bytes.NewReader(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+employeeToken)
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+	require.Equal(t, http.StatusCreated, recorder.Code, recorder.Body.String())
+
+	// addProducts чередует электронику, одежду и обувь
+	addProducts(t, router, employeeToken, pvzID, 3)
+
+	req = httptest.NewRequest("POST", "/api/v1/pvz/"+pvzID+"/close_last_reception", nil)
+	req.Header.Set("Authorization", "Bearer "+employeeToken)
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+
+	var resp struct {
+		DiscrepancyReport struct {
+			HasDiscrepancies bool `json:"hasDiscrepancies"`
+			Items            []struct {
+				Type     string `json:"type"`
+				Shortage int    `json:"shortage"`
+				Surplus  int    `json:"surplus"`
+			} `json:"items"`
+		} `json:"discrepancyReport"`
+	}
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &resp))
+	require.True(t, resp.DiscrepancyReport.HasDiscrepancies)
+
+	shortages := make(map[string]int)
+	surpluses := make(map[string]int)
+	for _, item := range resp.DiscrepancyReport.Items {
+		shortages[item.Type] = item.Shortage
+		surpluses[item.Type] = item.Surplus
+	}
+	require.Equal(t, 1, shortages["электроника"])
+	require.Equal(t, 0, shortages["одежда"])
+	require.Equal(t, 1, surpluses["обувь"])
+}
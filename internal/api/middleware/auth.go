@@ -3,6 +3,7 @@ package middleware
 import (
 	"context"
 	"errors"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/entity"
 	"github.com/GlebMoskalev/go-pickup-point-api/internal/service"
 	"github.com/GlebMoskalev/go-pickup-point-api/pkg/httpresponse"
 	"log/slog"
@@ -12,6 +13,12 @@ import (
 
 const ClaimsContext = "claims"
 
+// UserClaimsFromContext возвращает данные пользователя, положенные в контекст AuthMiddleware.
+func UserClaimsFromContext(ctx context.Context) (*entity.UserClaims, bool) {
+	claims, ok := ctx.Value(ClaimsContext).(*entity.UserClaims)
+	return claims, ok
+}
+
 func AuthMiddleware(authService service.Auth) func(handler http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -0,0 +1,289 @@
+package v1
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/entity"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/service"
+	"github.com/GlebMoskalev/go-pickup-point-api/pkg/httpresponse"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	htmltemplate "html/template"
+	"net/http"
+	"text/template"
+	"time"
+)
+
+const (
+	actFormatJSON = "json"
+	actFormatText = "text"
+	actFormatHTML = "html"
+)
+
+// @Description Акт приёма товаров по закрытой приёмке
+type acceptanceActResponse struct {
+	// Идентификатор приёмки
+	// format: uuid
+	ReceptionID string `json:"receptionId"`
+	// Идентификатор ПВЗ
+	// format: uuid
+	PVZID string `json:"pvzId"`
+	// Город ПВЗ
+	City string `json:"city"`
+	// Сведения о поставке
+	Delivery deliveryDTO `json:"delivery"`
+	// Время открытия приёмки
+	// format: date-time
+	OpenedAt string `json:"openedAt"`
+	// Время закрытия приёмки; пусто для приёмок, закрытых до учёта времени закрытия
+	// format: date-time
+	ClosedAt string `json:"closedAt,omitempty"`
+	// Кто открыл приёмку
+	// format: uuid
+	OpenedBy string `json:"openedBy,omitempty"`
+	// Кто закрыл приёмку
+	// format: uuid
+	ClosedBy string `json:"closedBy,omitempty"`
+	// Количество принятых товаров по типам
+	Counts []manifestItemDTO `json:"counts"`
+	// Всего принято товаров
+	Total int `json:"total"`
+	// Принятые товары в порядке добавления
+	Products []actProductDTO `json:"products"`
+	// Объявленная ценность принятых товаров по валютам
+	DeclaredValue []valueTotalDTO `json:"declaredValue,omitempty"`
+	// SHA-256 содержимого акта
+	ContentHash string `json:"contentHash"`
+	// Редакция акта: каждое повторное закрытие приёмки фиксирует следующую
+	Version int `json:"version" example:"1"`
+}
+
+// @Description Товар в акте приёма
+type actProductDTO struct {
+	// Идентификатор товара
+	// format: uuid
+	ID string `json:"id"`
+	// Тип товара
+	Type string `json:"type"`
+	// Время добавления товара
+	// format: date-time
+	DateTime string `json:"dateTime"`
+	// Объявленная ценность в минимальных единицах валюты
+	DeclaredValue int64 `json:"declaredValue,omitempty"`
+	// Валюта объявленной ценности
+	Currency string `json:"currency,omitempty"`
+}
+
+func newAcceptanceActResponse(act *entity.AcceptanceAct) acceptanceActResponse {
+	resp := acceptanceActResponse{
+		ReceptionID: act.ReceptionID.String(),
+		PVZID:       act.PVZ.ID.String(),
+		City:        act.PVZ.City,
+		Delivery:    newDeliveryDTO(act.Delivery),
+		OpenedAt:    act.OpenedAt.Format(time.RFC3339),
+		OpenedBy:    uuidString(act.OpenedBy),
+		ClosedBy:    uuidString(act.ClosedBy),
+		Counts:      make([]manifestItemDTO, len(act.Counts)),
+		Total:       len(act.Products),
+		Products:    make([]actProductDTO, len(act.Products)),
+		ContentHash: act.ContentHash,
+		Version:     act.Version,
+	}
+	if len(act.DeclaredValue) > 0 {
+		resp.DeclaredValue = newValueTotalsDTO(act.DeclaredValue)
+	}
+	if act.ClosedAt != nil {
+		resp.ClosedAt = act.ClosedAt.Format(time.RFC3339)
+	}
+	for i, count := range act.Counts {
+		resp.Counts[i] = manifestItemDTO{Type: count.ProductType, Count: count.Count}
+	}
+	for i, product := range act.Products {
+		resp.Products[i] = actProductDTO{
+			ID:            product.ID.String(),
+			Type:          product.Type,
+			DateTime:      product.DateTime.Format(time.RFC3339),
+			DeclaredValue: product.DeclaredValue,
+			Currency:      product.Currency,
+		}
+	}
+	return resp
+}
+
+const actTextTemplate = `АКТ ПРИЁМА ТОВАРОВ
+Приёмка: {{.ReceptionID}}
+Редакция: {{.Version}}
+ПВЗ: {{.PVZID}} ({{.City}})
+Перевозчик: {{or .Delivery.Carrier "—"}}
+Накладная: {{or .Delivery.WaybillNumber "—"}}
+Машина: {{or .Delivery.VehiclePlate "—"}}
+{{- if .Delivery.Comment}}
+Комментарий: {{.Delivery.Comment}}
+{{- end}}
+Открыта: {{.OpenedAt}}
+Закрыта: {{or .ClosedAt "—"}}
+
+Итого по типам:
+{{- range .Counts}}
+  {{.Type}}: {{.Count}}
+{{- end}}
+Всего: {{.Total}}
+{{- if .DeclaredValue}}
+
+Объявленная ценность (в минимальных единицах валюты):
+{{- range .DeclaredValue}}
+  {{.Currency}}: {{.Amount}} ({{.Count}} шт.)
+{{- end}}
+{{- end}}
+
+Товары:
+{{- range $i, $p := .Products}}
+  {{inc $i}}. {{$p.ID}} {{$p.Type}} {{$p.DateTime}}{{if $p.Currency}} {{$p.DeclaredValue}} {{$p.Currency}}{{end}}
+{{- end}}
+
+Хэш содержимого (SHA-256): {{.ContentHash}}
+
+Сдал: ____________________    Принял: ____________________
+`
+
+const actHTMLTemplate = `<!DOCTYPE html>
+<html lang="ru">
+<head>
+<meta charset="utf-8">
+<title>Акт приёма {{.ReceptionID}}</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+table { border-collapse: collapse; margin-bottom: 1em; }
+th, td { border: 1px solid #444; padding: 4px 8px; text-align: left; }
+.signatures { margin-top: 3em; display: flex; gap: 6em; }
+</style>
+</head>
+<body>
+<h1>Акт приёма товаров</h1>
+<table>
+<tr><th>Приёмка</th><td>{{.ReceptionID}}</td></tr>
+<tr><th>Редакция</th><td>{{.Version}}</td></tr>
+<tr><th>ПВЗ</th><td>{{.PVZID}} ({{.City}})</td></tr>
+<tr><th>Перевозчик</th><td>{{or .Delivery.Carrier "—"}}</td></tr>
+<tr><th>Накладная</th><td>{{or .Delivery.WaybillNumber "—"}}</td></tr>
+<tr><th>Машина</th><td>{{or .Delivery.VehiclePlate "—"}}</td></tr>
+{{- if .Delivery.Comment}}
+<tr><th>Комментарий</th><td>{{.Delivery.Comment}}</td></tr>
+{{- end}}
+<tr><th>Открыта</th><td>{{.OpenedAt}}</td></tr>
+<tr><th>Закрыта</th><td>{{or .ClosedAt "—"}}</td></tr>
+</table>
+<h2>Итого по типам</h2>
+<table>
+<tr><th>Тип</th><th>Количество</th></tr>
+{{- range .Counts}}
+<tr><td>{{.Type}}</td><td>{{.Count}}</td></tr>
+{{- end}}
+<tr><th>Всего</th><th>{{.Total}}</th></tr>
+</table>
+{{- if .DeclaredValue}}
+<h2>Объявленная ценность</h2>
+<table>
+<tr><th>Валюта</th><th>Сумма, мин. единиц</th><th>Товаров</th></tr>
+{{- range .DeclaredValue}}
+<tr><td>{{.Currency}}</td><td>{{.Amount}}</td><td>{{.Count}}</td></tr>
+{{- end}}
+</table>
+{{- end}}
+<h2>Товары</h2>
+<table>
+<tr><th>№</th><th>Идентификатор</th><th>Тип</th><th>Время</th><th>Ценность</th></tr>
+{{- range $i, $p := .Products}}
+<tr><td>{{inc $i}}</td><td>{{$p.ID}}</td><td>{{$p.Type}}</td><td>{{$p.DateTime}}</td><td>{{if $p.Currency}}{{$p.DeclaredValue}} {{$p.Currency}}{{else}}—{{end}}</td></tr>
+{{- end}}
+</table>
+<p>Хэш содержимого (SHA-256): <code>{{.ContentHash}}</code></p>
+<div class="signatures"><p>Сдал: ____________________</p><p>Принял: ____________________</p></div>
+</body>
+</html>
+`
+
+var (
+	actTemplateFuncs = map[string]any{"inc": func(i int) int { return i + 1 }}
+
+	actText = template.Must(template.New("act").Funcs(actTemplateFuncs).Parse(actTextTemplate))
+	actHTML = htmltemplate.Must(htmltemplate.New("act").Funcs(actTemplateFuncs).Parse(actHTMLTemplate))
+)
+
+// @Summary Акт приёма товаров
+// @Description Доступно для сотрудников и модераторов. Возвращает акт, зафиксированный при последнем закрытии приёмки: ПВЗ, перевозчик, время приёмки, количество по типам, список товаров и объявленная ценность по валютам. Последующие изменения товаров на акт не влияют, а повторное закрытие после переоткрытия фиксирует следующую редакцию, не меняя прежние; хэш содержимого сверяется с сохранённым перед выдачей. Формат выбирается параметром format: json (по умолчанию), text или html.
+// @Tags receptions
+// @Produce json
+// @Produce plain
+// @Produce html
+// @Param receptionId path string true "Идентификатор приёмки"
+// @Param format query string false "Формат акта" Enums(json, text, html)
+// @Success 200 {object} acceptanceActResponse
+// @Header 200 {string} X-Content-Hash "SHA-256 содержимого акта"
+// @Failure 400 {object} httpresponse.ErrorResponse "Неверный идентификатор, формат или приёмка не закрыта"
+// @Failure 401 {object} httpresponse.ErrorResponse "Пользователь не авторизован"
+// @Failure 403 {object} httpresponse.ErrorResponse "Доступ запрещён"
+// @Failure 404 {object} httpresponse.ErrorResponse "Приёмка не найдена"
+// @Failure 500 {object} httpresponse.ErrorResponse "Внутренняя ошибка сервера"
+// @Security JWT
+// @Router /api/v1/receptions/{receptionId}/act [get]
+func (h *receptionHandler) getAcceptanceAct(w http.ResponseWriter, r *http.Request) {
+	receptionID := chi.URLParam(r, "receptionId")
+	if _, err := uuid.Parse(receptionID); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid reception id")
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = actFormatJSON
+	}
+	if format != actFormatJSON && format != actFormatText && format != actFormatHTML {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid format")
+		return
+	}
+
+	act, err := h.receptionService.GetAcceptanceAct(r.Context(), receptionID)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrReceptionNotFound):
+			httpresponse.Error(w, http.StatusNotFound, "reception not found")
+		case errors.Is(err, service.ErrInvalidReceptionStatus):
+			httpresponse.Error(w, http.StatusBadRequest, "reception is not closed")
+		default:
+			httpresponse.Error(w, http.StatusInternalServerError, "internal server error")
+		}
+		return
+	}
+
+	resp := newAcceptanceActResponse(act)
+	w.Header().Set("X-Content-Hash", act.ContentHash)
+
+	var (
+		buf         bytes.Buffer
+		contentType string
+		extension   string
+	)
+	switch format {
+	case actFormatText:
+		contentType, extension = "text/plain; charset=utf-8", "txt"
+		err = actText.Execute(&buf, resp)
+	case actFormatHTML:
+		contentType, extension = "text/html; charset=utf-8", "html"
+		err = actHTML.Execute(&buf, resp)
+	default:
+		w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="act-%s.json"`, receptionID))
+		httpresponse.JSON(w, http.StatusOK, resp)
+		return
+	}
+	if err != nil {
+		httpresponse.Error(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="act-%s.%s"`, receptionID, extension))
+	w.WriteHeader(http.StatusOK)
+	w.Write(buf.Bytes())
+}
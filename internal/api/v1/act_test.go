@@ -0,0 +1,214 @@
+package v1
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/entity"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/service"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/service/mocks"
+	"github.com/GlebMoskalev/go-pickup-point-api/pkg/httpresponse"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestGetAcceptanceAct(t *testing.T) {
+	receptionID := uuid.New()
+	pvzID := uuid.New()
+	productID := uuid.New()
+	openedAt := time.Date(2025, 4, 1, 9, 0, 0, 0, time.UTC)
+	closedAt := openedAt.Add(2 * time.Hour)
+	act := &entity.AcceptanceAct{
+		ReceptionID: receptionID,
+		PVZ:         entity.PVZ{ID: pvzID, City: entity.CityMoscow},
+		Delivery:    entity.DeliveryInfo{Carrier: "СДЭК", WaybillNumber: "WB-1", Comment: "<b>помято</b>"},
+		OpenedAt:    openedAt,
+		ClosedAt:    &closedAt,
+		Counts:      []entity.TypeCount{{ProductType: entity.ProductTypeShoes, Count: 1}},
+		Products:    []entity.Product{{ID: productID, Type: entity.ProductTypeShoes, DateTime: openedAt}},
+		ContentHash: "abc123",
+		Version:     2,
+	}
+	valuedAct := *act
+	valuedAct.Products = []entity.Product{{ID: productID, Type: entity.ProductTypeShoes, DateTime: openedAt,
+		DeclaredValue: 1299000, Currency: "RUB"}}
+	valuedAct.DeclaredValue = []entity.ValueTotal{{Currency: "RUB", Amount: 1299000, Count: 1}}
+
+	testCases := []struct {
+		name                    string
+		receptionID             string
+		format                  string
+		prepareReceptionService func(mockService *mocks.Reception)
+		expectedHTTPStatus      int
+		expectedContentType     string
+		expectedBodyParts       []string
+		expectedResponse        any
+	}{
+		{
+			name:        "json act",
+			receptionID: receptionID.String(),
+			prepareReceptionService: func(mockService *mocks.Reception) {
+				mockService.On("GetAcceptanceAct", mock.Anything, receptionID.String()).Return(act, nil)
+			},
+			expectedHTTPStatus:  http.StatusOK,
+			expectedContentType: "application/json",
+			expectedResponse: acceptanceActResponse{
+				ReceptionID: receptionID.String(),
+				PVZID:       pvzID.String(),
+				City:        entity.CityMoscow,
+				Delivery:    deliveryDTO{Carrier: "СДЭК", WaybillNumber: "WB-1", Comment: "<b>помято</b>"},
+				OpenedAt:    "2025-04-01T09:00:00Z",
+				ClosedAt:    "2025-04-01T11:00:00Z",
+				Counts:      []manifestItemDTO{{Type: entity.ProductTypeShoes, Count: 1}},
+				Total:       1,
+				Products: []actProductDTO{
+					{ID: productID.String(), Type: entity.ProductTypeShoes, DateTime: "2025-04-01T09:00:00Z"},
+				},
+				ContentHash: "abc123",
+				Version:     2,
+			},
+		},
+		{
+			name:        "text act",
+			receptionID: receptionID.String(),
+			format:      "text",
+			prepareReceptionService: func(mockService *mocks.Reception) {
+				mockService.On("GetAcceptanceAct", mock.Anything, receptionID.String()).Return(act, nil)
+			},
+			expectedHTTPStatus:  http.StatusOK,
+			expectedContentType: "text/plain; charset=utf-8",
+			expectedBodyParts: []string{
+				"АКТ ПРИЁМА ТОВАРОВ",
+				"Редакция: 2",
+				"Перевозчик: СДЭК",
+				"Машина: —",
+				"обувь: 1",
+				"1. " + productID.String(),
+				"Хэш содержимого (SHA-256): abc123",
+			},
+		},
+		{
+			name:        "text act with declared value",
+			receptionID: receptionID.String(),
+			format:      "text",
+			prepareReceptionService: func(mockService *mocks.Reception) {
+				mockService.On("GetAcceptanceAct", mock.Anything, receptionID.String()).Return(&valuedAct, nil)
+			},
+			expectedHTTPStatus:  http.StatusOK,
+			expectedContentType: "text/plain; charset=utf-8",
+			expectedBodyParts: []string{
+				"Объявленная ценность (в минимальных единицах валюты):",
+				"RUB: 1299000 (1 шт.)",
+				"2025-04-01T09:00:00Z 1299000 RUB",
+			},
+		},
+		{
+			name:        "html act escapes user input",
+			receptionID: receptionID.String(),
+			format:      "html",
+			prepareReceptionService: func(mockService *mocks.Reception) {
+				mockService.On("GetAcceptanceAct", mock.Anything, receptionID.String()).Return(act, nil)
+			},
+			expectedHTTPStatus:  http.StatusOK,
+			expectedContentType: "text/html; charset=utf-8",
+			expectedBodyParts:   []string{"<h1>Акт приёма товаров</h1>", "&lt;b&gt;помято&lt;/b&gt;", "abc123"},
+		},
+		{
+			name:                    "invalid reception id",
+			receptionID:             "not-a-uuid",
+			prepareReceptionService: func(mockService *mocks.Reception) {},
+			expectedHTTPStatus:      http.StatusBadRequest,
+			expectedResponse:        httpresponse.ErrorResponse{Error: "invalid reception id"},
+		},
+		{
+			name:                    "invalid format",
+			receptionID:             receptionID.String(),
+			format:                  "pdf",
+			prepareReceptionService: func(mockService *mocks.Reception) {},
+			expectedHTTPStatus:      http.StatusBadRequest,
+			expectedResponse:        httpresponse.ErrorResponse{Error: "invalid format"},
+		},
+		{
+			name:        "reception not found",
+			receptionID: receptionID.String(),
+			prepareReceptionService: func(mockService *mocks.Reception) {
+				mockService.On("GetAcceptanceAct", mock.Anything, receptionID.String()).
+					Return(nil, service.ErrReceptionNotFound)
+			},
+			expectedHTTPStatus: http.StatusNotFound,
+			expectedResponse:   httpresponse.ErrorResponse{Error: "reception not found"},
+		},
+		{
+			name:        "reception not closed",
+			receptionID: receptionID.String(),
+			prepareReceptionService: func(mockService *mocks.Reception) {
+				mockService.On("GetAcceptanceAct", mock.Anything, receptionID.String()).
+					Return(nil, service.ErrInvalidReceptionStatus)
+			},
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse:   httpresponse.ErrorResponse{Error: "reception is not closed"},
+		},
+		{
+			name:        "internal server error",
+			receptionID: receptionID.String(),
+			prepareReceptionService: func(mockService *mocks.Reception) {
+				mockService.On("GetAcceptanceAct", mock.Anything, receptionID.String()).
+					Return(nil, errors.New("database error"))
+			},
+			expectedHTTPStatus: http.StatusInternalServerError,
+			expectedResponse:   httpresponse.ErrorResponse{Error: "internal server error"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			receptionService := mocks.NewReception(t)
+			tc.prepareReceptionService(receptionService)
+
+			handler := newReceptionHandler(receptionService)
+
+			r := chi.NewRouter()
+			r.Get("/receptions/{receptionId}/act", handler.getAcceptanceAct)
+			target := "/receptions/" + tc.receptionID + "/act"
+			if tc.format != "" {
+				target += "?format=" + tc.format
+			}
+			req := httptest.NewRequest("GET", target, nil)
+			rec := httptest.NewRecorder()
+
+			r.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedHTTPStatus, rec.Code)
+
+			if tc.expectedHTTPStatus != http.StatusOK {
+				var actualResponse httpresponse.ErrorResponse
+				err := json.NewDecoder(rec.Body).Decode(&actualResponse)
+				if err != nil {
+					t.Fatalf("failed to decode response body: %v", err)
+				}
+				assert.Equal(t, tc.expectedResponse, actualResponse)
+				return
+			}
+
+			assert.Equal(t, tc.expectedContentType, rec.Header().Get("Content-Type"))
+			assert.Equal(t, "abc123", rec.Header().Get("X-Content-Hash"))
+			assert.Contains(t, rec.Header().Get("Content-Disposition"), "act-"+receptionID.String())
+			if tc.expectedResponse != nil {
+				var actualResponse acceptanceActResponse
+				err := json.NewDecoder(rec.Body).Decode(&actualResponse)
+				if err != nil {
+					t.Fatalf("failed to decode response body: %v", err)
+				}
+				assert.Equal(t, tc.expectedResponse, actualResponse)
+			}
+			for _, part := range tc.expectedBodyParts {
+				assert.Contains(t, rec.Body.String(), part)
+			}
+		})
+	}
+}
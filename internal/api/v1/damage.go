@@ -0,0 +1,307 @@
+package v1
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/api/middleware"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/entity"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/service"
+	"github.com/GlebMoskalev/go-pickup-point-api/pkg/httpresponse"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	// maxPhotoRequestSize ограничивает тело запроса с фотографией: сама фотография — до 10 МБ,
+	// остальное — на заголовки multipart.
+	maxPhotoRequestSize = 11 << 20
+	photoFormMemory     = 1 << 20
+)
+
+// @Description Запрос на отметку о повреждении товара
+type markDamagedRequest struct {
+	// Описание повреждения, до 1000 символов
+	Description string `json:"description" example:"смята коробка, порвана упаковка"`
+}
+
+// @Description Фотография повреждения товара
+type productPhotoResponse struct {
+	// Идентификатор фотографии
+	// format: uuid
+	ID string `json:"id"`
+	// Идентификатор товара
+	// format: uuid
+	ProductID string `json:"productId"`
+	// Формат фотографии: image/jpeg, image/png или image/webp
+	ContentType string `json:"contentType" example:"image/jpeg"`
+	// Размер в байтах
+	Size int64 `json:"size" example:"482113"`
+	// Кто загрузил фотографию
+	// format: uuid
+	UploadedBy string `json:"uploadedBy,omitempty"`
+	// Время загрузки
+	// format: date-time
+	UploadedAt string `json:"uploadedAt"`
+	// Адрес для скачивания фотографии
+	URL string `json:"url" example:"/api/v1/products/3fa85f64-5717-4562-b3fc-2c963f66afa6/photos/9b2d3a1e-6f0c-4a8e-9d4b-1c2e3f4a5b6c"`
+}
+
+func newProductPhotoResponse(photo entity.ProductPhoto) productPhotoResponse {
+	return productPhotoResponse{
+		ID:          photo.ID.String(),
+		ProductID:   photo.ProductID.String(),
+		ContentType: photo.ContentType,
+		Size:        photo.Size,
+		UploadedBy:  uuidString(photo.UploadedBy),
+		UploadedAt:  photo.UploadedAt.Format(time.RFC3339),
+		URL:         fmt.Sprintf("/api/v1/products/%s/photos/%s", photo.ProductID, photo.ID),
+	}
+}
+
+func SetupDamageRoutes(r chi.Router, damageService service.Damage) {
+	handler := newDamageHandler(damageService)
+
+	r.With(middleware.RoleMiddleware(entity.RoleEmployee)).
+		Put("/{productId}/damage", handler.markDamaged)
+
+	r.With(middleware.RoleMiddleware(entity.RoleEmployee)).
+		Post("/{productId}/photos", handler.uploadPhoto)
+
+	r.With(middleware.RoleMiddleware(entity.RoleEmployee, entity.RoleModerator)).
+		Get("/{productId}/photos", handler.listPhotos)
+
+	r.With(middleware.RoleMiddleware(entity.RoleEmployee, entity.RoleModerator)).
+		Get("/{productId}/photos/{photoId}", handler.getPhoto)
+}
+
+type damageHandler struct {
+	damageService service.Damage
+}
+
+func newDamageHandler(damageService service.Damage) *damageHandler {
+	return &damageHandler{damageService: damageService}
+}
+
+// @Summary Отметка о повреждении товара
+// @Description Только для сотрудников ПВЗ. Отмечает хранящийся товар повреждённым с описанием повреждения для претензии к перевозчику; повторная отметка заменяет описание.
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param productId path string true "Идентификатор товара"
+// @Param input body markDamagedRequest true "Описание повреждения"
+// @Success 200 {object} createProductResponse
+// @Failure 400 {object} httpresponse.ErrorResponse "Неверный идентификатор товара или описание повреждения"
+// @Failure 401 {object} httpresponse.ErrorResponse "Пользователь не авторизован"
+// @Failure 403 {object} httpresponse.ErrorResponse "Доступ запрещён"
+// @Failure 404 {object} httpresponse.ErrorResponse "Товар не найден"
+// @Failure 409 {object} httpresponse.ErrorResponse "Товар не хранится в ПВЗ"
+// @Failure 500 {object} httpresponse.ErrorResponse "Внутренняя ошибка сервера"
+// @Security JWT
+// @Router /api/v1/products/{productId}/damage [put]
+func (h *damageHandler) markDamaged(w http.ResponseWriter, r *http.Request) {
+	productID := chi.URLParam(r, "productId")
+	if _, err := uuid.Parse(productID); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid product id")
+		return
+	}
+
+	var req markDamagedRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	claims, ok := middleware.UserClaimsFromContext(r.Context())
+	if !ok {
+		httpresponse.Error(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	product, err := h.damageService.MarkDamaged(r.Context(), entity.DamageParams{
+		ProductID:   productID,
+		Description: req.Description,
+		MarkedBy:    claims.UserID,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidDamage):
+			httpresponse.Error(w, http.StatusBadRequest, "invalid damage description")
+		case errors.Is(err, service.ErrProductNotFound):
+			httpresponse.Error(w, http.StatusNotFound, "product not found")
+		case errors.Is(err, service.ErrProductNotStored):
+			httpresponse.Error(w, http.StatusConflict, "product is not stored")
+		default:
+			httpresponse.Error(w, http.StatusInternalServerError, "internal server error")
+		}
+		return
+	}
+
+	httpresponse.JSON(w, http.StatusOK, newCreateProductResponse(product))
+}
+
+// @Summary Загрузка фотографии повреждения
+// @Description Только для сотрудников ПВЗ. Принимает фотографию в поле photo формы multipart/form-data: JPEG, PNG или WebP до 10 МБ, не больше 10 фотографий на товар. Товар должен быть отмечен повреждённым.
+// @Tags products
+// @Accept multipart/form-data
+// @Produce json
+// @Param productId path string true "Идентификатор товара"
+// @Param photo formData file true "Фотография"
+// @Success 201 {object} productPhotoResponse
+// @Failure 400 {object} httpresponse.ErrorResponse "Неверный идентификатор товара, нет фотографии или неподдерживаемый формат"
+// @Failure 401 {object} httpresponse.ErrorResponse "Пользователь не авторизован"
+// @Failure 403 {object} httpresponse.ErrorResponse "Доступ запрещён"
+// @Failure 404 {object} httpresponse.ErrorResponse "Товар не найден"
+// @Failure 409 {object} httpresponse.ErrorResponse "Товар не отмечен повреждённым или у него уже 10 фотографий"
+// @Failure 413 {object} httpresponse.ErrorResponse "Фотография больше 10 МБ"
+// @Failure 500 {object} httpresponse.ErrorResponse "Внутренняя ошибка сервера"
+// @Security JWT
+// @Router /api/v1/products/{productId}/photos [post]
+func (h *damageHandler) uploadPhoto(w http.ResponseWriter, r *http.Request) {
+	productID := chi.URLParam(r, "productId")
+	if _, err := uuid.Parse(productID); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid product id")
+		return
+	}
+
+	claims, ok := middleware.UserClaimsFromContext(r.Context())
+	if !ok {
+		httpresponse.Error(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxPhotoRequestSize)
+	if err := r.ParseMultipartForm(photoFormMemory); err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			httpresponse.Error(w, http.StatusRequestEntityTooLarge, "photo is too large")
+			return
+		}
+		httpresponse.Error(w, http.StatusBadRequest, "invalid multipart form")
+		return
+	}
+	defer r.MultipartForm.RemoveAll()
+
+	file, header, err := r.FormFile("photo")
+	if err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "photo is required")
+		return
+	}
+	defer file.Close()
+
+	photo, err := h.damageService.UploadPhoto(r.Context(), entity.PhotoUpload{
+		ProductID:  productID,
+		Content:    file,
+		Size:       header.Size,
+		UploadedBy: claims.UserID,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidPhoto):
+			httpresponse.Error(w, http.StatusBadRequest, "invalid photo")
+		case errors.Is(err, service.ErrPhotoTooLarge):
+			httpresponse.Error(w, http.StatusRequestEntityTooLarge, "photo is too large")
+		case errors.Is(err, service.ErrProductNotFound):
+			httpresponse.Error(w, http.StatusNotFound, "product not found")
+		case errors.Is(err, service.ErrProductNotDamaged):
+			httpresponse.Error(w, http.StatusConflict, "product is not marked damaged")
+		case errors.Is(err, service.ErrTooManyPhotos):
+			httpresponse.Error(w, http.StatusConflict, "too many photos")
+		default:
+			httpresponse.Error(w, http.StatusInternalServerError, "internal server error")
+		}
+		return
+	}
+
+	httpresponse.JSON(w, http.StatusCreated, newProductPhotoResponse(*photo))
+}
+
+// @Summary Фотографии повреждения товара
+// @Description Доступно для сотрудников и модераторов. Возвращает фотографии повреждения товара в порядке загрузки.
+// @Tags products
+// @Produce json
+// @Param productId path string true "Идентификатор товара"
+// @Success 200 {array} productPhotoResponse
+// @Failure 400 {object} httpresponse.ErrorResponse "Неверный идентификатор товара"
+// @Failure 401 {object} httpresponse.ErrorResponse "Пользователь не авторизован"
+// @Failure 403 {object} httpresponse.ErrorResponse "Доступ запрещён"
+// @Failure 404 {object} httpresponse.ErrorResponse "Товар не найден"
+// @Failure 500 {object} httpresponse.ErrorResponse "Внутренняя ошибка сервера"
+// @Security JWT
+// @Router /api/v1/products/{productId}/photos [get]
+func (h *damageHandler) listPhotos(w http.ResponseWriter, r *http.Request) {
+	productID := chi.URLParam(r, "productId")
+	if _, err := uuid.Parse(productID); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid product id")
+		return
+	}
+
+	photos, err := h.damageService.ListPhotos(r.Context(), productID)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrProductNotFound):
+			httpresponse.Error(w, http.StatusNotFound, "product not found")
+		default:
+			httpresponse.Error(w, http.StatusInternalServerError, "internal server error")
+		}
+		return
+	}
+
+	resp := make([]productPhotoResponse, len(photos))
+	for i, photo := range photos {
+		resp[i] = newProductPhotoResponse(photo)
+	}
+	httpresponse.JSON(w, http.StatusOK, resp)
+}
+
+// @Summary Скачивание фотографии повреждения
+// @Description Доступно для сотрудников и модераторов. Возвращает файл фотографии.
+// @Tags products
+// @Produce image/jpeg,image/png,image/webp
+// @Param productId path string true "Идентификатор товара"
+// @Param photoId path string true "Идентификатор фотографии"
+// @Success 200 {file} file "Фотография"
+// @Failure 400 {object} httpresponse.ErrorResponse "Неверный идентификатор товара или фотографии"
+// @Failure 401 {object} httpresponse.ErrorResponse "Пользователь не авторизован"
+// @Failure 403 {object} httpresponse.ErrorResponse "Доступ запрещён"
+// @Failure 404 {object} httpresponse.ErrorResponse "Фотография не найдена"
+// @Failure 500 {object} httpresponse.ErrorResponse "Внутренняя ошибка сервера"
+// @Security JWT
+// @Router /api/v1/products/{productId}/photos/{photoId} [get]
+func (h *damageHandler) getPhoto(w http.ResponseWriter, r *http.Request) {
+	productID := chi.URLParam(r, "productId")
+	if _, err := uuid.Parse(productID); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid product id")
+		return
+	}
+	photoID := chi.URLParam(r, "photoId")
+	if _, err := uuid.Parse(photoID); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid photo id")
+		return
+	}
+
+	photo, content, err := h.damageService.GetPhoto(r.Context(), productID, photoID)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrPhotoNotFound):
+			httpresponse.Error(w, http.StatusNotFound, "photo not found")
+		default:
+			httpresponse.Error(w, http.StatusInternalServerError, "internal server error")
+		}
+		return
+	}
+	defer content.Close()
+
+	w.Header().Set("Content-Type", photo.ContentType)
+	w.Header().Set("Content-Length", strconv.FormatInt(photo.Size, 10))
+	w.Header().Set("Cache-Control", "private, no-store")
+	w.WriteHeader(http.StatusOK)
+	if _, err := io.Copy(w, content); err != nil {
+		slog.Warn("failed to write photo", "photoID", photoID, "error", err)
+	}
+}
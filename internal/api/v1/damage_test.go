@@ -0,0 +1,558 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/api/middleware"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/entity"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/service"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/service/mocks"
+	"github.com/GlebMoskalev/go-pickup-point-api/pkg/httpresponse"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+var photoBytes = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\x0dIHDR")
+
+func TestMarkDamaged(t *testing.T) {
+	userID := uuid.New()
+	productID := uuid.New()
+	claims := &entity.UserClaims{UserID: userID, Role: entity.RoleEmployee}
+	damagedAt := time.Date(2026, 6, 2, 9, 30, 0, 0, time.UTC)
+	damaged := &entity.Product{ID: productID, DateTime: damagedAt.AddDate(0, 0, -1), Type: "обувь",
+		ReceptionID: uuid.New(), OrderNumber: 1, Status: entity.ProductStatusStored, AddedBy: uuid.New(),
+		DamageDescription: "смята коробка", DamagedBy: userID, DamagedAt: &damagedAt}
+	params := entity.DamageParams{ProductID: productID.String(), Description: "смята коробка", MarkedBy: userID}
+
+	testCases := []struct {
+		name                 string
+		productID            string
+		requestBody          any
+		claims               *entity.UserClaims
+		prepareDamageService func(mockService *mocks.Damage)
+		expectedHTTPStatus   int
+		expectedResponse     any
+	}{
+		{
+			name:        "product marked damaged",
+			productID:   productID.String(),
+			requestBody: markDamagedRequest{Description: "смята коробка"},
+			claims:      claims,
+			prepareDamageService: func(mockService *mocks.Damage) {
+				mockService.On("MarkDamaged", mock.Anything, params).Return(damaged, nil)
+			},
+			expectedHTTPStatus: http.StatusOK,
+			expectedResponse:   newCreateProductResponse(damaged),
+		},
+		{
+			name:                 "invalid product id",
+			productID:            "not-a-uuid",
+			requestBody:          markDamagedRequest{Description: "смята коробка"},
+			claims:               claims,
+			prepareDamageService: func(mockService *mocks.Damage) {},
+			expectedHTTPStatus:   http.StatusBadRequest,
+			expectedResponse:     httpresponse.ErrorResponse{Error: "invalid product id"},
+		},
+		{
+			name:                 "invalid request body",
+			productID:            productID.String(),
+			requestBody:          "{",
+			claims:               claims,
+			prepareDamageService: func(mockService *mocks.Damage) {},
+			expectedHTTPStatus:   http.StatusBadRequest,
+			expectedResponse:     httpresponse.ErrorResponse{Error: "invalid request body"},
+		},
+		{
+			name:                 "unauthorized",
+			productID:            productID.String(),
+			requestBody:          markDamagedRequest{Description: "смята коробка"},
+			prepareDamageService: func(mockService *mocks.Damage) {},
+			expectedHTTPStatus:   http.StatusUnauthorized,
+			expectedResponse:     httpresponse.ErrorResponse{Error: "unauthorized"},
+		},
+		{
+			name:        "invalid description",
+			productID:   productID.String(),
+			requestBody: markDamagedRequest{Description: "смята коробка"},
+			claims:      claims,
+			prepareDamageService: func(mockService *mocks.Damage) {
+				mockService.On("MarkDamaged", mock.Anything, params).Return(nil, service.ErrInvalidDamage)
+			},
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse:   httpresponse.ErrorResponse{Error: "invalid damage description"},
+		},
+		{
+			name:        "product not found",
+			productID:   productID.String(),
+			requestBody: markDamagedRequest{Description: "смята коробка"},
+			claims:      claims,
+			prepareDamageService: func(mockService *mocks.Damage) {
+				mockService.On("MarkDamaged", mock.Anything, params).Return(nil, service.ErrProductNotFound)
+			},
+			expectedHTTPStatus: http.StatusNotFound,
+			expectedResponse:   httpresponse.ErrorResponse{Error: "product not found"},
+		},
+		{
+			name:        "product not stored",
+			productID:   productID.String(),
+			requestBody: markDamagedRequest{Description: "смята коробка"},
+			claims:      claims,
+			prepareDamageService: func(mockService *mocks.Damage) {
+				mockService.On("MarkDamaged", mock.Anything, params).Return(nil, service.ErrProductNotStored)
+			},
+			expectedHTTPStatus: http.StatusConflict,
+			expectedResponse:   httpresponse.ErrorResponse{Error: "product is not stored"},
+		},
+		{
+			name:        "internal server error",
+			productID:   productID.String(),
+			requestBody: markDamagedRequest{Description: "смята коробка"},
+			claims:      claims,
+			prepareDamageService: func(mockService *mocks.Damage) {
+				mockService.On("MarkDamaged", mock.Anything, params).Return(nil, errors.New("database error"))
+			},
+			expectedHTTPStatus: http.StatusInternalServerError,
+			expectedResponse:   httpresponse.ErrorResponse{Error: "internal server error"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			damageService := mocks.NewDamage(t)
+			tc.prepareDamageService(damageService)
+
+			handler := newDamageHandler(damageService)
+
+			var body []byte
+			if raw, ok := tc.requestBody.(string); ok {
+				body = []byte(raw)
+			} else {
+				body, _ = json.Marshal(tc.requestBody)
+			}
+
+			r := chi.NewRouter()
+			r.Put("/products/{productId}/damage", handler.markDamaged)
+			req := httptest.NewRequest(http.MethodPut, "/products/"+tc.productID+"/damage", bytes.NewReader(body))
+			if tc.claims != nil {
+				req = req.WithContext(context.WithValue(req.Context(), middleware.ClaimsContext, tc.claims))
+			}
+			rec := httptest.NewRecorder()
+
+			r.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedHTTPStatus, rec.Code)
+
+			switch expected := tc.expectedResponse.(type) {
+			case createProductResponse:
+				var actualResponse createProductResponse
+				if err := json.NewDecoder(rec.Body).Decode(&actualResponse); err != nil {
+					t.Fatalf("failed to decode response body: %v", err)
+				}
+				assert.Equal(t, expected, actualResponse)
+				assert.True(t, actualResponse.Damaged)
+			default:
+				var actualResponse httpresponse.ErrorResponse
+				if err := json.NewDecoder(rec.Body).Decode(&actualResponse); err != nil {
+					t.Fatalf("failed to decode response body: %v", err)
+				}
+				assert.Equal(t, expected, actualResponse)
+			}
+		})
+	}
+}
+
+func TestUploadPhoto(t *testing.T) {
+	userID := uuid.New()
+	productID := uuid.New()
+	claims := &entity.UserClaims{UserID: userID, Role: entity.RoleEmployee}
+	photo := &entity.ProductPhoto{ID: uuid.New(), ProductID: productID, BlobKey: "products/p/f.png",
+		ContentType: "image/png", Size: int64(len(photoBytes)), UploadedBy: userID,
+		UploadedAt: time.Date(2026, 6, 2, 9, 35, 0, 0, time.UTC)}
+	upload := mock.MatchedBy(func(upload entity.PhotoUpload) bool {
+		content, _ := io.ReadAll(upload.Content)
+		return upload.ProductID == productID.String() && upload.UploadedBy == userID &&
+			upload.Size == int64(len(photoBytes)) && bytes.Equal(content, photoBytes)
+	})
+
+	multipartBody := func(field string, content []byte) (string, []byte) {
+		var buf bytes.Buffer
+		writer := multipart.NewWriter(&buf)
+		part, _ := writer.CreateFormFile(field, "damage.png")
+		_, _ = part.Write(content)
+		_ = writer.Close()
+		return writer.FormDataContentType(), buf.Bytes()
+	}
+
+	testCases := []struct {
+		name                 string
+		productID            string
+		field                string
+		content              []byte
+		contentType          string
+		claims               *entity.UserClaims
+		prepareDamageService func(mockService *mocks.Damage)
+		expectedHTTPStatus   int
+		expectedResponse     any
+	}{
+		{
+			name:      "photo uploaded",
+			productID: productID.String(),
+			field:     "photo",
+			content:   photoBytes,
+			claims:    claims,
+			prepareDamageService: func(mockService *mocks.Damage) {
+				mockService.On("UploadPhoto", mock.Anything, upload).Return(photo, nil)
+			},
+			expectedHTTPStatus: http.StatusCreated,
+			expectedResponse:   newProductPhotoResponse(*photo),
+		},
+		{
+			name:                 "invalid product id",
+			productID:            "not-a-uuid",
+			field:                "photo",
+			content:              photoBytes,
+			claims:               claims,
+			prepareDamageService: func(mockService *mocks.Damage) {},
+			expectedHTTPStatus:   http.StatusBadRequest,
+			expectedResponse:     httpresponse.ErrorResponse{Error: "invalid product id"},
+		},
+		{
+			name:                 "unauthorized",
+			productID:            productID.String(),
+			field:                "photo",
+			content:              photoBytes,
+			prepareDamageService: func(mockService *mocks.Damage) {},
+			expectedHTTPStatus:   http.StatusUnauthorized,
+			expectedResponse:     httpresponse.ErrorResponse{Error: "unauthorized"},
+		},
+		{
+			name:                 "not a multipart form",
+			productID:            productID.String(),
+			contentType:          "application/json",
+			claims:               claims,
+			prepareDamageService: func(mockService *mocks.Damage) {},
+			expectedHTTPStatus:   http.StatusBadRequest,
+			expectedResponse:     httpresponse.ErrorResponse{Error: "invalid multipart form"},
+		},
+		{
+			name:                 "photo field missing",
+			productID:            productID.String(),
+			field:                "file",
+			content:              photoBytes,
+			claims:               claims,
+			prepareDamageService: func(mockService *mocks.Damage) {},
+			expectedHTTPStatus:   http.StatusBadRequest,
+			expectedResponse:     httpresponse.ErrorResponse{Error: "photo is required"},
+		},
+		{
+			name:                 "request too large",
+			productID:            productID.String(),
+			field:                "photo",
+			content:              make([]byte, maxPhotoRequestSize),
+			claims:               claims,
+			prepareDamageService: func(mockService *mocks.Damage) {},
+			expectedHTTPStatus:   http.StatusRequestEntityTooLarge,
+			expectedResponse:     httpresponse.ErrorResponse{Error: "photo is too large"},
+		},
+		{
+			name:      "unsupported format",
+			productID: productID.String(),
+			field:     "photo",
+			content:   photoBytes,
+			claims:    claims,
+			prepareDamageService: func(mockService *mocks.Damage) {
+				mockService.On("UploadPhoto", mock.Anything, upload).Return(nil, service.ErrInvalidPhoto)
+			},
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse:   httpresponse.ErrorResponse{Error: "invalid photo"},
+		},
+		{
+			name:      "photo too large",
+			productID: productID.String(),
+			field:     "photo",
+			content:   photoBytes,
+			claims:    claims,
+			prepareDamageService: func(mockService *mocks.Damage) {
+				mockService.On("UploadPhoto", mock.Anything, upload).Return(nil, service.ErrPhotoTooLarge)
+			},
+			expectedHTTPStatus: http.StatusRequestEntityTooLarge,
+			expectedResponse:   httpresponse.ErrorResponse{Error: "photo is too large"},
+		},
+		{
+			name:      "product not found",
+			productID: productID.String(),
+			field:     "photo",
+			content:   photoBytes,
+			claims:    claims,
+			prepareDamageService: func(mockService *mocks.Damage) {
+				mockService.On("UploadPhoto", mock.Anything, upload).Return(nil, service.ErrProductNotFound)
+			},
+			expectedHTTPStatus: http.StatusNotFound,
+			expectedResponse:   httpresponse.ErrorResponse{Error: "product not found"},
+		},
+		{
+			name:      "product not damaged",
+			productID: productID.String(),
+			field:     "photo",
+			content:   photoBytes,
+			claims:    claims,
+			prepareDamageService: func(mockService *mocks.Damage) {
+				mockService.On("UploadPhoto", mock.Anything, upload).Return(nil, service.ErrProductNotDamaged)
+			},
+			expectedHTTPStatus: http.StatusConflict,
+			expectedResponse:   httpresponse.ErrorResponse{Error: "product is not marked damaged"},
+		},
+		{
+			name:      "too many photos",
+			productID: productID.String(),
+			field:     "photo",
+			content:   photoBytes,
+			claims:    claims,
+			prepareDamageService: func(mockService *mocks.Damage) {
+				mockService.On("UploadPhoto", mock.Anything, upload).Return(nil, service.ErrTooManyPhotos)
+			},
+			expectedHTTPStatus: http.StatusConflict,
+			expectedResponse:   httpresponse.ErrorResponse{Error: "too many photos"},
+		},
+		{
+			name:      "internal server error",
+			productID: productID.String(),
+			field:     "photo",
+			content:   photoBytes,
+			claims:    claims,
+			prepareDamageService: func(mockService *mocks.Damage) {
+				mockService.On("UploadPhoto", mock.Anything, upload).Return(nil, errors.New("storage error"))
+			},
+			expectedHTTPStatus: http.StatusInternalServerError,
+			expectedResponse:   httpresponse.ErrorResponse{Error: "internal server error"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			damageService := mocks.NewDamage(t)
+			tc.prepareDamageService(damageService)
+
+			handler := newDamageHandler(damageService)
+
+			contentType, body := tc.contentType, []byte("{}")
+			if contentType == "" {
+				contentType, body = multipartBody(tc.field, tc.content)
+			}
+
+			r := chi.NewRouter()
+			r.Post("/products/{productId}/photos", handler.uploadPhoto)
+			req := httptest.NewRequest(http.MethodPost, "/products/"+tc.productID+"/photos", bytes.NewReader(body))
+			req.Header.Set("Content-Type", contentType)
+			if tc.claims != nil {
+				req = req.WithContext(context.WithValue(req.Context(), middleware.ClaimsContext, tc.claims))
+			}
+			rec := httptest.NewRecorder()
+
+			r.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedHTTPStatus, rec.Code)
+
+			switch expected := tc.expectedResponse.(type) {
+			case productPhotoResponse:
+				var actualResponse productPhotoResponse
+				if err := json.NewDecoder(rec.Body).Decode(&actualResponse); err != nil {
+					t.Fatalf("failed to decode response body: %v", err)
+				}
+				assert.Equal(t, expected, actualResponse)
+				assert.Equal(t, "/api/v1/products/"+productID.String()+"/photos/"+photo.ID.String(), actualResponse.URL)
+			default:
+				var actualResponse httpresponse.ErrorResponse
+				if err := json.NewDecoder(rec.Body).Decode(&actualResponse); err != nil {
+					t.Fatalf("failed to decode response body: %v", err)
+				}
+				assert.Equal(t, expected, actualResponse)
+			}
+		})
+	}
+}
+
+func TestListPhotos(t *testing.T) {
+	productID := uuid.New()
+	photos := []entity.ProductPhoto{{ID: uuid.New(), ProductID: productID, ContentType: "image/jpeg", Size: 1024,
+		UploadedBy: uuid.New(), UploadedAt: time.Date(2026, 6, 2, 9, 35, 0, 0, time.UTC)}}
+
+	testCases := []struct {
+		name                 string
+		productID            string
+		prepareDamageService func(mockService *mocks.Damage)
+		expectedHTTPStatus   int
+		expectedResponse     any
+	}{
+		{
+			name:      "photos returned",
+			productID: productID.String(),
+			prepareDamageService: func(mockService *mocks.Damage) {
+				mockService.On("ListPhotos", mock.Anything, productID.String()).Return(photos, nil)
+			},
+			expectedHTTPStatus: http.StatusOK,
+			expectedResponse:   []productPhotoResponse{newProductPhotoResponse(photos[0])},
+		},
+		{
+			name:                 "invalid product id",
+			productID:            "not-a-uuid",
+			prepareDamageService: func(mockService *mocks.Damage) {},
+			expectedHTTPStatus:   http.StatusBadRequest,
+			expectedResponse:     httpresponse.ErrorResponse{Error: "invalid product id"},
+		},
+		{
+			name:      "product not found",
+			productID: productID.String(),
+			prepareDamageService: func(mockService *mocks.Damage) {
+				mockService.On("ListPhotos", mock.Anything, productID.String()).Return(nil, service.ErrProductNotFound)
+			},
+			expectedHTTPStatus: http.StatusNotFound,
+			expectedResponse:   httpresponse.ErrorResponse{Error: "product not found"},
+		},
+		{
+			name:      "internal server error",
+			productID: productID.String(),
+			prepareDamageService: func(mockService *mocks.Damage) {
+				mockService.On("ListPhotos", mock.Anything, productID.String()).Return(nil, errors.New("database error"))
+			},
+			expectedHTTPStatus: http.StatusInternalServerError,
+			expectedResponse:   httpresponse.ErrorResponse{Error: "internal server error"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			damageService := mocks.NewDamage(t)
+			tc.prepareDamageService(damageService)
+
+			handler := newDamageHandler(damageService)
+
+			r := chi.NewRouter()
+			r.Get("/products/{productId}/photos", handler.listPhotos)
+			req := httptest.NewRequest(http.MethodGet, "/products/"+tc.productID+"/photos", nil)
+			rec := httptest.NewRecorder()
+
+			r.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedHTTPStatus, rec.Code)
+
+			switch expected := tc.expectedResponse.(type) {
+			case []productPhotoResponse:
+				var actualResponse []productPhotoResponse
+				if err := json.NewDecoder(rec.Body).Decode(&actualResponse); err != nil {
+					t.Fatalf("failed to decode response body: %v", err)
+				}
+				assert.Equal(t, expected, actualResponse)
+			default:
+				var actualResponse httpresponse.ErrorResponse
+				if err := json.NewDecoder(rec.Body).Decode(&actualResponse); err != nil {
+					t.Fatalf("failed to decode response body: %v", err)
+				}
+				assert.Equal(t, expected, actualResponse)
+			}
+		})
+	}
+}
+
+func TestGetPhoto(t *testing.T) {
+	productID := uuid.NewString()
+	photoID := uuid.NewString()
+	photo := &entity.ProductPhoto{ContentType: "image/png", Size: int64(len(photoBytes))}
+
+	testCases := []struct {
+		name                 string
+		productID            string
+		photoID              string
+		prepareDamageService func(mockService *mocks.Damage)
+		expectedHTTPStatus   int
+		expectedError        string
+	}{
+		{
+			name:      "photo returned",
+			productID: productID,
+			photoID:   photoID,
+			prepareDamageService: func(mockService *mocks.Damage) {
+				mockService.On("GetPhoto", mock.Anything, productID, photoID).
+					Return(photo, io.NopCloser(bytes.NewReader(photoBytes)), nil)
+			},
+			expectedHTTPStatus: http.StatusOK,
+		},
+		{
+			name:                 "invalid product id",
+			productID:            "not-a-uuid",
+			photoID:              photoID,
+			prepareDamageService: func(mockService *mocks.Damage) {},
+			expectedHTTPStatus:   http.StatusBadRequest,
+			expectedError:        "invalid product id",
+		},
+		{
+			name:                 "invalid photo id",
+			productID:            productID,
+			photoID:              "not-a-uuid",
+			prepareDamageService: func(mockService *mocks.Damage) {},
+			expectedHTTPStatus:   http.StatusBadRequest,
+			expectedError:        "invalid photo id",
+		},
+		{
+			name:      "photo not found",
+			productID: productID,
+			photoID:   photoID,
+			prepareDamageService: func(mockService *mocks.Damage) {
+				mockService.On("GetPhoto", mock.Anything, productID, photoID).Return(nil, nil, service.ErrPhotoNotFound)
+			},
+			expectedHTTPStatus: http.StatusNotFound,
+			expectedError:      "photo not found",
+		},
+		{
+			name:      "internal server error",
+			productID: productID,
+			photoID:   photoID,
+			prepareDamageService: func(mockService *mocks.Damage) {
+				mockService.On("GetPhoto", mock.Anything, productID, photoID).
+					Return(nil, nil, errors.New("storage error"))
+			},
+			expectedHTTPStatus: http.StatusInternalServerError,
+			expectedError:      "internal server error",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			damageService := mocks.NewDamage(t)
+			tc.prepareDamageService(damageService)
+
+			handler := newDamageHandler(damageService)
+
+			r := chi.NewRouter()
+			r.Get("/products/{productId}/photos/{photoId}", handler.getPhoto)
+			req := httptest.NewRequest(http.MethodGet, "/products/"+tc.productID+"/photos/"+tc.photoID, nil)
+			rec := httptest.NewRecorder()
+
+			r.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedHTTPStatus, rec.Code)
+
+			if tc.expectedError != "" {
+				var actualResponse httpresponse.ErrorResponse
+				if err := json.NewDecoder(rec.Body).Decode(&actualResponse); err != nil {
+					t.Fatalf("failed to decode response body: %v", err)
+				}
+				assert.Equal(t, tc.expectedError, actualResponse.Error)
+				return
+			}
+			assert.Equal(t, "image/png", rec.Header().Get("Content-Type"))
+			assert.Equal(t, "private, no-store", rec.Header().Get("Cache-Control"))
+			assert.Equal(t, photoBytes, rec.Body.Bytes())
+		})
+	}
+}
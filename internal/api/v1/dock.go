@@ -0,0 +1,170 @@
+package v1
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/entity"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/service"
+	"github.com/GlebMoskalev/go-pickup-point-api/pkg/httpresponse"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"net/http"
+	"time"
+)
+
+// @Description Запрос для создания дока
+type createDockRequest struct {
+	// Название дока, уникальное в пределах ПВЗ
+	Name string `json:"name" example:"ворота 2"`
+}
+
+// @Description Док (зона разгрузки) ПВЗ
+type dockDTO struct {
+	// Идентификатор дока
+	// format: uuid
+	ID string `json:"id"`
+	// Идентификатор ПВЗ
+	// format: uuid
+	PVZID string `json:"pvzId"`
+	// Название дока
+	Name string `json:"name"`
+	// Док по умолчанию: в нём открываются приёмки без указания дока
+	IsDefault bool `json:"isDefault"`
+	// Дата создания дока
+	// format: date-time
+	CreatedAt string `json:"createdAt"`
+}
+
+// @Description Список доков ПВЗ
+type listDocksResponse struct {
+	Docks []dockDTO `json:"docks"`
+}
+
+func newDockDTO(dock entity.Dock) dockDTO {
+	return dockDTO{
+		ID:        dock.ID.String(),
+		PVZID:     dock.PVZID.String(),
+		Name:      dock.Name,
+		IsDefault: dock.IsDefault,
+		CreatedAt: dock.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+// @Summary Создание дока
+// @Description Только для модераторов. Добавляет в ПВЗ док, в котором можно вести отдельную приёмку параллельно с другими доками.
+// @Tags pvz
+// @Accept json
+// @Produce json
+// @Param pvzId path string true "Идентификатор ПВЗ"
+// @Param input body createDockRequest true "Данные дока"
+// @Success 201 {object} dockDTO
+// @Failure 400 {object} httpresponse.ErrorResponse "Неверный идентификатор ПВЗ, название дока или док с таким названием уже есть"
+// @Failure 401 {object} httpresponse.ErrorResponse "Пользователь не авторизован"
+// @Failure 403 {object} httpresponse.ErrorResponse "Доступ запрещён: требуется роль модератора"
+// @Failure 500 {object} httpresponse.ErrorResponse "Внутренняя ошибка сервера"
+// @Security JWT
+// @Router /api/v1/pvz/{pvzId}/docks [post]
+func (h *pvzHandler) createDock(w http.ResponseWriter, r *http.Request) {
+	pvzID := chi.URLParam(r, "pvzId")
+	if _, err := uuid.Parse(pvzID); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid pvz id")
+		return
+	}
+
+	var req createDockRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	dock, err := h.pvzService.CreateDock(r.Context(), pvzID, req.Name)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidPVZID):
+			httpresponse.Error(w, http.StatusBadRequest, "invalid pvz id")
+		case errors.Is(err, service.ErrInvalidDockName):
+			httpresponse.Error(w, http.StatusBadRequest, "invalid dock name")
+		case errors.Is(err, service.ErrDockExists):
+			httpresponse.Error(w, http.StatusBadRequest, "dock already exists")
+		default:
+			httpresponse.Error(w, http.StatusInternalServerError, "internal server error")
+		}
+		return
+	}
+
+	httpresponse.JSON(w, http.StatusCreated, newDockDTO(*dock))
+}
+
+// @Summary Список доков ПВЗ
+// @Description Доступно для сотрудников и модераторов. Док по умолчанию идёт первым.
+// @Tags pvz
+// @Produce json
+// @Param pvzId path string true "Идентификатор ПВЗ"
+// @Success 200 {object} listDocksResponse
+// @Failure 400 {object} httpresponse.ErrorResponse "Неверный идентификатор ПВЗ"
+// @Failure 401 {object} httpresponse.ErrorResponse "Пользователь не авторизован"
+// @Failure 403 {object} httpresponse.ErrorResponse "Доступ запрещён"
+// @Failure 500 {object} httpresponse.ErrorResponse "Внутренняя ошибка сервера"
+// @Security JWT
+// @Router /api/v1/pvz/{pvzId}/docks [get]
+func (h *pvzHandler) listDocks(w http.ResponseWriter, r *http.Request) {
+	pvzID := chi.URLParam(r, "pvzId")
+	if _, err := uuid.Parse(pvzID); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid pvz id")
+		return
+	}
+
+	docks, err := h.pvzService.ListDocks(r.Context(), pvzID)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidPVZID):
+			httpresponse.Error(w, http.StatusBadRequest, "invalid pvz id")
+		default:
+			httpresponse.Error(w, http.StatusInternalServerError, "internal server error")
+		}
+		return
+	}
+
+	resp := listDocksResponse{Docks: make([]dockDTO, len(docks))}
+	for i, dock := range docks {
+		resp.Docks[i] = newDockDTO(dock)
+	}
+	httpresponse.JSON(w, http.StatusOK, resp)
+}
+
+// receptionTargetFromRequest собирает адрес приёмки из пути {pvzId} и необязательных
+// параметров dockId и receptionId. Вторым значением возвращается текст ошибки для клиента.
+func receptionTargetFromRequest(r *http.Request) (entity.ReceptionTarget, string) {
+	target := entity.ReceptionTarget{
+		PVZID:       chi.URLParam(r, "pvzId"),
+		DockID:      r.URL.Query().Get("dockId"),
+		ReceptionID: r.URL.Query().Get("receptionId"),
+	}
+	if _, err := uuid.Parse(target.PVZID); err != nil {
+		return target, "invalid pvz id"
+	}
+	return target, validateReceptionTarget(target)
+}
+
+// validateReceptionTarget проверяет формат идентификаторов. Нужен хотя бы один из них.
+func validateReceptionTarget(target entity.ReceptionTarget) string {
+	if target.PVZID == "" && target.DockID == "" && target.ReceptionID == "" {
+		return "invalid pvz id"
+	}
+	if target.PVZID != "" {
+		if _, err := uuid.Parse(target.PVZID); err != nil {
+			return "invalid pvz id"
+		}
+	}
+	if target.DockID != "" {
+		if _, err := uuid.Parse(target.DockID); err != nil {
+			return "invalid dock id"
+		}
+	}
+	if target.ReceptionID != "" {
+		if _, err := uuid.Parse(target.ReceptionID); err != nil {
+			return "invalid reception id"
+		}
+	}
+	return ""
+}
@@ -0,0 +1,220 @@
+package v1
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/entity"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/service"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/service/mocks"
+	"github.com/GlebMoskalev/go-pickup-point-api/pkg/httpresponse"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestCreateDock(t *testing.T) {
+	pvzID := uuid.New()
+	dockID := uuid.New()
+	createdAt := time.Date(2025, 4, 1, 9, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name               string
+		pvzID              string
+		request            any
+		preparePVZService  func(mockService *mocks.PVZ)
+		expectedHTTPStatus int
+		expectedResponse   any
+	}{
+		{
+			name:    "successful creation",
+			pvzID:   pvzID.String(),
+			request: createDockRequest{Name: "ворота 2"},
+			preparePVZService: func(mockService *mocks.PVZ) {
+				mockService.On("CreateDock", mock.Anything, pvzID.String(), "ворота 2").
+					Return(&entity.Dock{ID: dockID, PVZID: pvzID, Name: "ворота 2", CreatedAt: createdAt}, nil)
+			},
+			expectedHTTPStatus: http.StatusCreated,
+			expectedResponse: dockDTO{
+				ID:        dockID.String(),
+				PVZID:     pvzID.String(),
+				Name:      "ворота 2",
+				CreatedAt: "2025-04-01T09:00:00Z",
+			},
+		},
+		{
+			name:               "invalid pvz id",
+			pvzID:              "not-a-uuid",
+			request:            createDockRequest{Name: "ворота 2"},
+			preparePVZService:  func(mockService *mocks.PVZ) {},
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse:   httpresponse.ErrorResponse{Error: "invalid pvz id"},
+		},
+		{
+			name:               "invalid request body",
+			pvzID:              pvzID.String(),
+			request:            "invalid json",
+			preparePVZService:  func(mockService *mocks.PVZ) {},
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse:   httpresponse.ErrorResponse{Error: "invalid request body"},
+		},
+		{
+			name:    "invalid dock name",
+			pvzID:   pvzID.String(),
+			request: createDockRequest{Name: " "},
+			preparePVZService: func(mockService *mocks.PVZ) {
+				mockService.On("CreateDock", mock.Anything, pvzID.String(), " ").
+					Return(nil, service.ErrInvalidDockName)
+			},
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse:   httpresponse.ErrorResponse{Error: "invalid dock name"},
+		},
+		{
+			name:    "dock exists",
+			pvzID:   pvzID.String(),
+			request: createDockRequest{Name: "основной"},
+			preparePVZService: func(mockService *mocks.PVZ) {
+				mockService.On("CreateDock", mock.Anything, pvzID.String(), "основной").
+					Return(nil, service.ErrDockExists)
+			},
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse:   httpresponse.ErrorResponse{Error: "dock already exists"},
+		},
+		{
+			name:    "internal server error",
+			pvzID:   pvzID.String(),
+			request: createDockRequest{Name: "ворота 2"},
+			preparePVZService: func(mockService *mocks.PVZ) {
+				mockService.On("CreateDock", mock.Anything, pvzID.String(), "ворота 2").
+					Return(nil, errors.New("database error"))
+			},
+			expectedHTTPStatus: http.StatusInternalServerError,
+			expectedResponse:   httpresponse.ErrorResponse{Error: "internal server error"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pvzService := mocks.NewPVZ(t)
+			tc.preparePVZService(pvzService)
+
+			handler := newPVZHandler(pvzService)
+
+			reqBody, err := json.Marshal(tc.request)
+			if err != nil {
+				t.Fatalf("failed to marshal request: %v", err)
+			}
+			r := chi.NewRouter()
+			r.Post("/pvz/{pvzId}/docks", handler.createDock)
+			req := httptest.NewRequest("POST", "/pvz/"+tc.pvzID+"/docks", bytes.NewReader(reqBody))
+			rec := httptest.NewRecorder()
+
+			r.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedHTTPStatus, rec.Code)
+
+			if tc.expectedHTTPStatus == http.StatusCreated {
+				var actualResponse dockDTO
+				err := json.NewDecoder(rec.Body).Decode(&actualResponse)
+				if err != nil {
+					t.Fatalf("failed to decode response body: %v", err)
+				}
+				assert.Equal(t, tc.expectedResponse, actualResponse)
+			} else {
+				var actualResponse httpresponse.ErrorResponse
+				err := json.NewDecoder(rec.Body).Decode(&actualResponse)
+				if err != nil {
+					t.Fatalf("failed to decode response body: %v", err)
+				}
+				assert.Equal(t, tc.expectedResponse, actualResponse)
+			}
+		})
+	}
+}
+
+func TestListDocks(t *testing.T) {
+	pvzID := uuid.New()
+	defaultDockID := uuid.New()
+	createdAt := time.Date(2025, 4, 1, 9, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name               string
+		pvzID              string
+		preparePVZService  func(mockService *mocks.PVZ)
+		expectedHTTPStatus int
+		expectedResponse   any
+	}{
+		{
+			name:  "successful list",
+			pvzID: pvzID.String(),
+			preparePVZService: func(mockService *mocks.PVZ) {
+				mockService.On("ListDocks", mock.Anything, pvzID.String()).Return([]entity.Dock{
+					{ID: defaultDockID, PVZID: pvzID, Name: entity.DefaultDockName, IsDefault: true, CreatedAt: createdAt},
+				}, nil)
+			},
+			expectedHTTPStatus: http.StatusOK,
+			expectedResponse: listDocksResponse{Docks: []dockDTO{{
+				ID:        defaultDockID.String(),
+				PVZID:     pvzID.String(),
+				Name:      entity.DefaultDockName,
+				IsDefault: true,
+				CreatedAt: "2025-04-01T09:00:00Z",
+			}}},
+		},
+		{
+			name:               "invalid pvz id",
+			pvzID:              "not-a-uuid",
+			preparePVZService:  func(mockService *mocks.PVZ) {},
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse:   httpresponse.ErrorResponse{Error: "invalid pvz id"},
+		},
+		{
+			name:  "pvz not found",
+			pvzID: pvzID.String(),
+			preparePVZService: func(mockService *mocks.PVZ) {
+				mockService.On("ListDocks", mock.Anything, pvzID.String()).Return(nil, service.ErrInvalidPVZID)
+			},
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse:   httpresponse.ErrorResponse{Error: "invalid pvz id"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pvzService := mocks.NewPVZ(t)
+			tc.preparePVZService(pvzService)
+
+			handler := newPVZHandler(pvzService)
+
+			r := chi.NewRouter()
+			r.Get("/pvz/{pvzId}/docks", handler.listDocks)
+			req := httptest.NewRequest("GET", "/pvz/"+tc.pvzID+"/docks", nil)
+			rec := httptest.NewRecorder()
+
+			r.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedHTTPStatus, rec.Code)
+
+			if tc.expectedHTTPStatus == http.StatusOK {
+				var actualResponse listDocksResponse
+				err := json.NewDecoder(rec.Body).Decode(&actualResponse)
+				if err != nil {
+					t.Fatalf("failed to decode response body: %v", err)
+				}
+				assert.Equal(t, tc.expectedResponse, actualResponse)
+			} else {
+				var actualResponse httpresponse.ErrorResponse
+				err := json.NewDecoder(rec.Body).Decode(&actualResponse)
+				if err != nil {
+					t.Fatalf("failed to decode response body: %v", err)
+				}
+				assert.Equal(t, tc.expectedResponse, actualResponse)
+			}
+		})
+	}
+}
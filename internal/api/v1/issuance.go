@@ -0,0 +1,102 @@
+package v1
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/api/middleware"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/entity"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/service"
+	"github.com/GlebMoskalev/go-pickup-point-api/pkg/httpresponse"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"net/http"
+)
+
+// @Description Запрос на выдачу товара покупателю
+type issueProductRequest struct {
+	// Идентификатор ПВЗ, в котором выдаётся товар
+	// format: uuid
+	PVZID string `json:"pvzId"`
+	// Код получения, который предъявил покупатель
+	PickupCode string `json:"pickupCode" example:"042917"`
+}
+
+// @Description Товар нельзя выдать в его текущем статусе
+type productStatusConflictResponse struct {
+	// Текст ошибки
+	Error string `json:"error" example:"product is not stored"`
+	// Текущий статус товара
+	CurrentStatus string `json:"currentStatus,omitempty" example:"issued"`
+}
+
+// @Summary Выдача товара покупателю
+// @Description Только для сотрудников ПВЗ. Проверяет код получения и отмечает хранящийся товар выданным, сохраняя, кто и когда его выдал. Выдать можно только товар закрытой приёмки этого ПВЗ.
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param productId path string true "Идентификатор товара"
+// @Param input body issueProductRequest true "ПВЗ и код получения"
+// @Success 200 {object} createProductResponse
+// @Failure 400 {object} httpresponse.ErrorResponse "Неверный идентификатор товара или ПВЗ, неверный код получения"
+// @Failure 401 {object} httpresponse.ErrorResponse "Пользователь не авторизован"
+// @Failure 403 {object} httpresponse.ErrorResponse "Доступ запрещён"
+// @Failure 404 {object} httpresponse.ErrorResponse "Товар не найден в этом ПВЗ"
+// @Failure 409 {object} productStatusConflictResponse "Товар уже выдан или возвращён, либо приёмка товара не закрыта"
+// @Failure 500 {object} httpresponse.ErrorResponse "Внутренняя ошибка сервера"
+// @Security JWT
+// @Router /api/v1/products/{productId}/issue [post]
+func (h *productHandler) issueProduct(w http.ResponseWriter, r *http.Request) {
+	productID := chi.URLParam(r, "productId")
+	if _, err := uuid.Parse(productID); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid product id")
+		return
+	}
+
+	var req issueProductRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if _, err := uuid.Parse(req.PVZID); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid pvz id")
+		return
+	}
+
+	claims, ok := middleware.UserClaimsFromContext(r.Context())
+	if !ok {
+		httpresponse.Error(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	product, err := h.productService.Issue(r.Context(), entity.IssueParams{
+		ProductID:  productID,
+		PVZID:      req.PVZID,
+		PickupCode: req.PickupCode,
+		IssuedBy:   claims.UserID,
+	})
+	if err != nil {
+		var transitionErr *service.TransitionError
+		switch {
+		case errors.As(err, &transitionErr):
+			httpresponse.JSON(w, http.StatusConflict, productStatusConflictResponse{
+				Error:         "product is not stored",
+				CurrentStatus: transitionErr.Current,
+			})
+		case errors.Is(err, service.ErrInvalidTransition):
+			httpresponse.JSON(w, http.StatusConflict, productStatusConflictResponse{Error: "product is not stored"})
+		case errors.Is(err, service.ErrProductNotReceived):
+			httpresponse.Error(w, http.StatusConflict, "product reception is not closed")
+		case errors.Is(err, service.ErrInvalidPickupCode):
+			httpresponse.Error(w, http.StatusBadRequest, "invalid pickup code")
+		case errors.Is(err, service.ErrInvalidPVZID):
+			httpresponse.Error(w, http.StatusBadRequest, "invalid pvz id")
+		case errors.Is(err, service.ErrProductNotFound):
+			httpresponse.Error(w, http.StatusNotFound, "product not found")
+		default:
+			httpresponse.Error(w, http.StatusInternalServerError, "internal server error")
+		}
+		return
+	}
+
+	httpresponse.JSON(w, http.StatusOK, newCreateProductResponse(product))
+}
@@ -0,0 +1,167 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/api/middleware"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/entity"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/service"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/service/mocks"
+	"github.com/GlebMoskalev/go-pickup-point-api/pkg/httpresponse"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestIssueProduct(t *testing.T) {
+	userID := uuid.New()
+	pvzID := uuid.New().String()
+	productID := uuid.New()
+	claims := &entity.UserClaims{UserID: userID, Role: entity.RoleEmployee}
+	issuedAt := time.Date(2026, 5, 4, 12, 0, 0, 0, time.UTC)
+	issued := &entity.Product{ID: productID, DateTime: issuedAt.Add(-time.Hour), Type: "обувь",
+		ReceptionID: uuid.New(), OrderNumber: 3, Status: entity.ProductStatusIssued, AddedBy: uuid.New(),
+		IssuedBy: userID, IssuedAt: &issuedAt}
+	validRequest := issueProductRequest{PVZID: pvzID, PickupCode: "042917"}
+	params := entity.IssueParams{ProductID: productID.String(), PVZID: pvzID, PickupCode: "042917", IssuedBy: userID}
+
+	testCases := []struct {
+		name                  string
+		productID             string
+		request               any
+		prepareProductService func(mockService *mocks.Product)
+		expectedHTTPStatus    int
+		expectedResponse      any
+	}{
+		{
+			name:      "successful issuance",
+			productID: productID.String(),
+			request:   validRequest,
+			prepareProductService: func(mockService *mocks.Product) {
+				mockService.On("Issue", mock.Anything, params).Return(issued, nil)
+			},
+			expectedHTTPStatus: http.StatusOK,
+			expectedResponse:   newCreateProductResponse(issued),
+		},
+		{
+			name:                  "invalid product id",
+			productID:             "not-a-uuid",
+			request:               validRequest,
+			prepareProductService: func(mockService *mocks.Product) {},
+			expectedHTTPStatus:    http.StatusBadRequest,
+			expectedResponse:      httpresponse.ErrorResponse{Error: "invalid product id"},
+		},
+		{
+			name:                  "invalid pvz id",
+			productID:             productID.String(),
+			request:               issueProductRequest{PVZID: "not-a-uuid", PickupCode: "042917"},
+			prepareProductService: func(mockService *mocks.Product) {},
+			expectedHTTPStatus:    http.StatusBadRequest,
+			expectedResponse:      httpresponse.ErrorResponse{Error: "invalid pvz id"},
+		},
+		{
+			name:      "wrong pickup code",
+			productID: productID.String(),
+			request:   validRequest,
+			prepareProductService: func(mockService *mocks.Product) {
+				mockService.On("Issue", mock.Anything, params).Return(nil, service.ErrInvalidPickupCode)
+			},
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse:   httpresponse.ErrorResponse{Error: "invalid pickup code"},
+		},
+		{
+			name:      "product not found",
+			productID: productID.String(),
+			request:   validRequest,
+			prepareProductService: func(mockService *mocks.Product) {
+				mockService.On("Issue", mock.Anything, params).Return(nil, service.ErrProductNotFound)
+			},
+			expectedHTTPStatus: http.StatusNotFound,
+			expectedResponse:   httpresponse.ErrorResponse{Error: "product not found"},
+		},
+		{
+			name:      "already issued",
+			productID: productID.String(),
+			request:   validRequest,
+			prepareProductService: func(mockService *mocks.Product) {
+				mockService.On("Issue", mock.Anything, params).Return(nil, &service.TransitionError{
+					Current: entity.ProductStatusIssued, Event: entity.ProductEventIssue})
+			},
+			expectedHTTPStatus: http.StatusConflict,
+			expectedResponse: productStatusConflictResponse{Error: "product is not stored",
+				CurrentStatus: entity.ProductStatusIssued},
+		},
+		{
+			name:      "reception not closed",
+			productID: productID.String(),
+			request:   validRequest,
+			prepareProductService: func(mockService *mocks.Product) {
+				mockService.On("Issue", mock.Anything, params).Return(nil, service.ErrProductNotReceived)
+			},
+			expectedHTTPStatus: http.StatusConflict,
+			expectedResponse:   httpresponse.ErrorResponse{Error: "product reception is not closed"},
+		},
+		{
+			name:      "internal server error",
+			productID: productID.String(),
+			request:   validRequest,
+			prepareProductService: func(mockService *mocks.Product) {
+				mockService.On("Issue", mock.Anything, params).Return(nil, errors.New("database error"))
+			},
+			expectedHTTPStatus: http.StatusInternalServerError,
+			expectedResponse:   httpresponse.ErrorResponse{Error: "internal server error"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			productService := mocks.NewProduct(t)
+			tc.prepareProductService(productService)
+
+			handler := newProductHandler(productService)
+
+			reqBody, err := json.Marshal(tc.request)
+			if err != nil {
+				t.Fatalf("failed to marshal request: %v", err)
+			}
+			r := chi.NewRouter()
+			r.Post("/products/{productId}/issue", handler.issueProduct)
+			req := httptest.NewRequest(http.MethodPost, "/products/"+tc.productID+"/issue", bytes.NewReader(reqBody))
+			req = req.WithContext(context.WithValue(req.Context(), middleware.ClaimsContext, claims))
+			rec := httptest.NewRecorder()
+
+			r.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedHTTPStatus, rec.Code)
+
+			switch expected := tc.expectedResponse.(type) {
+			case createProductResponse:
+				var actualResponse createProductResponse
+				if err := json.NewDecoder(rec.Body).Decode(&actualResponse); err != nil {
+					t.Fatalf("failed to decode response body: %v", err)
+				}
+				assert.Equal(t, expected, actualResponse)
+				assert.Equal(t, userID.String(), actualResponse.IssuedBy)
+			case productStatusConflictResponse:
+				var actualResponse productStatusConflictResponse
+				if err := json.NewDecoder(rec.Body).Decode(&actualResponse); err != nil {
+					t.Fatalf("failed to decode response body: %v", err)
+				}
+				assert.Equal(t, expected, actualResponse)
+			default:
+				var actualResponse httpresponse.ErrorResponse
+				if err := json.NewDecoder(rec.Body).Decode(&actualResponse); err != nil {
+					t.Fatalf("failed to decode response body: %v", err)
+				}
+				assert.Equal(t, expected, actualResponse)
+			}
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package v1
+
+import "github.com/GlebMoskalev/go-pickup-point-api/internal/entity"
+
+// @Description Ожидаемое количество товаров одного типа
+type manifestItemDTO struct {
+	// Тип товара
+	// Тип товара из каталога (GET /api/v1/product-types)
+	Type string `json:"type"`
+	// Ожидаемое количество
+	Count int `json:"count" example:"10"`
+}
+
+// @Description Расхождение по одному типу товара
+type discrepancyItemDTO struct {
+	// Тип товара
+	Type string `json:"type"`
+	// Ожидалось по манифесту
+	Expected int `json:"expected"`
+	// Фактически принято
+	Received int `json:"received"`
+	// Недостача
+	Shortage int `json:"shortage"`
+	// Излишек
+	Surplus int `json:"surplus"`
+}
+
+// @Description Отчёт о расхождениях между манифестом и принятыми товарами
+type discrepancyReportDTO struct {
+	// Есть ли недостачи или излишки
+	HasDiscrepancies bool `json:"hasDiscrepancies"`
+	// Сверка по типам товаров
+	Items []discrepancyItemDTO `json:"items"`
+}
+
+// @Description Результат «слепого» пересчёта при закрытии приёмки
+type blindCountDTO struct {
+	// Есть ли расхождения между пересчётом и учтёнными товарами
+	HasMismatch bool `json:"hasMismatch"`
+	// Сверка по типам товаров
+	Items []blindCountItemDTO `json:"items"`
+}
+
+// @Description Сверка пересчёта по одному типу товара
+type blindCountItemDTO struct {
+	// Тип товара
+	Type string `json:"type"`
+	// Пересчитано сотрудником
+	Counted int `json:"counted"`
+	// Учтено в системе
+	Recorded int `json:"recorded"`
+}
+
+func newManifest(items []manifestItemDTO) []entity.ManifestItem {
+	if len(items) == 0 {
+		return nil
+	}
+	manifest := make([]entity.ManifestItem, len(items))
+	for i, item := range items {
+		manifest[i] = entity.ManifestItem{ProductType: item.Type, ExpectedCount: item.Count}
+	}
+	return manifest
+}
+
+func newManifestDTO(manifest []entity.ManifestItem) []manifestItemDTO {
+	if len(manifest) == 0 {
+		return nil
+	}
+	items := make([]manifestItemDTO, len(manifest))
+	for i, item := range manifest {
+		items[i] = manifestItemDTO{Type: item.ProductType, Count: item.ExpectedCount}
+	}
+	return items
+}
+
+func newDiscrepancyReportDTO(report *entity.DiscrepancyReport) *discrepancyReportDTO {
+	if report == nil {
+		return nil
+	}
+	dto := &discrepancyReportDTO{
+		HasDiscrepancies: report.HasDiscrepancies(),
+		Items:            make([]discrepancyItemDTO, len(report.Items)),
+	}
+	for i, item := range report.Items {
+		dto.Items[i] = discrepancyItemDTO{
+			Type:     item.ProductType,
+			Expected: item.ExpectedCount,
+			Received: item.ReceivedCount,
+			Shortage: item.Shortage(),
+			Surplus:  item.Surplus(),
+		}
+	}
+	return dto
+}
+
+// newCountedTotals сохраняет различие между отсутствующим пересчётом (nil) и пустым.
+func newCountedTotals(items []manifestItemDTO) []entity.TypeCount {
+	if items == nil {
+		return nil
+	}
+	counted := make([]entity.TypeCount, len(items))
+	for i, item := range items {
+		counted[i] = entity.TypeCount{ProductType: item.Type, Count: item.Count}
+	}
+	return counted
+}
+
+func newBlindCountDTO(report *entity.BlindCountReport) *blindCountDTO {
+	if report == nil {
+		return nil
+	}
+	dto := &blindCountDTO{
+		HasMismatch: report.HasMismatch(),
+		Items:       make([]blindCountItemDTO, len(report.Items)),
+	}
+	for i, item := range report.Items {
+		dto.Items[i] = blindCountItemDTO{
+			Type:     item.ProductType,
+			Counted:  item.CountedCount,
+			Recorded: item.RecordedCount,
+		}
+	}
+	return dto
+}
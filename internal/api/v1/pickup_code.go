@@ -0,0 +1,112 @@
+package v1
+
+import (
+	"errors"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/service"
+	"github.com/GlebMoskalev/go-pickup-point-api/pkg/codeimage"
+	"github.com/GlebMoskalev/go-pickup-point-api/pkg/httpresponse"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"log/slog"
+	"net/http"
+)
+
+// Форматы изображения кода получения.
+const (
+	pickupCodeFormatQR      = "qr"
+	pickupCodeFormatCode128 = "code128"
+)
+
+// @Summary Изображение кода получения
+// @Description Доступно для сотрудников и модераторов. Возвращает код получения товара в виде PNG: QR-код (по умолчанию) или штрихкод Code128 для печати на этикетке.
+// @Tags products
+// @Produce png
+// @Param productId path string true "Идентификатор товара"
+// @Param format query string false "Формат изображения" Enums(qr, code128) default(qr)
+// @Success 200 {file} binary "PNG-изображение кода получения"
+// @Failure 400 {object} httpresponse.ErrorResponse "Неверный идентификатор товара или формат"
+// @Failure 401 {object} httpresponse.ErrorResponse "Пользователь не авторизован"
+// @Failure 403 {object} httpresponse.ErrorResponse "Доступ запрещён"
+// @Failure 404 {object} httpresponse.ErrorResponse "Товар не найден"
+// @Failure 500 {object} httpresponse.ErrorResponse "Внутренняя ошибка сервера"
+// @Security JWT
+// @Router /api/v1/products/{productId}/pickup-code.png [get]
+func (h *productHandler) getPickupCodeImage(w http.ResponseWriter, r *http.Request) {
+	productID := chi.URLParam(r, "productId")
+	if _, err := uuid.Parse(productID); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid product id")
+		return
+	}
+
+	encode := codeimage.QR
+	switch r.URL.Query().Get("format") {
+	case "", pickupCodeFormatQR:
+	case pickupCodeFormatCode128:
+		encode = codeimage.Code128
+	default:
+		httpresponse.Error(w, http.StatusBadRequest, "invalid image format")
+		return
+	}
+
+	product, err := h.productService.GetPickupCode(r.Context(), productID)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrProductNotFound):
+			httpresponse.Error(w, http.StatusNotFound, "product not found")
+		default:
+			httpresponse.Error(w, http.StatusInternalServerError, "internal server error")
+		}
+		return
+	}
+
+	image, err := encode(product.PickupCode)
+	if err != nil {
+		slog.Error("failed to render pickup code", "layer", "productHandler", "productID", productID, "error", err)
+		httpresponse.Error(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	w.Header().Set("Content-Type", "image/png")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(image)
+}
+
+// @Summary Поиск товара по коду получения
+// @Description Только для сотрудников ПВЗ. Возвращает хранящийся в ПВЗ товар с предъявленным кодом получения; коды уникальны в пределах ПВЗ.
+// @Tags pvz
+// @Produce json
+// @Param pvzId path string true "Идентификатор ПВЗ"
+// @Param code path string true "Код получения"
+// @Success 200 {object} createProductResponse
+// @Failure 400 {object} httpresponse.ErrorResponse "Неверный идентификатор ПВЗ или код получения"
+// @Failure 401 {object} httpresponse.ErrorResponse "Пользователь не авторизован"
+// @Failure 403 {object} httpresponse.ErrorResponse "Доступ запрещён"
+// @Failure 404 {object} httpresponse.ErrorResponse "Товар не найден"
+// @Failure 500 {object} httpresponse.ErrorResponse "Внутренняя ошибка сервера"
+// @Security JWT
+// @Router /api/v1/pvz/{pvzId}/pickup/{code} [post]
+func (h *productHandler) getProductByPickupCode(w http.ResponseWriter, r *http.Request) {
+	pvzID := chi.URLParam(r, "pvzId")
+	if _, err := uuid.Parse(pvzID); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid pvz id")
+		return
+	}
+
+	product, err := h.productService.GetByPickupCode(r.Context(), pvzID, chi.URLParam(r, "code"))
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidPickupCode):
+			httpresponse.Error(w, http.StatusBadRequest, "invalid pickup code")
+		case errors.Is(err, service.ErrInvalidPVZID):
+			httpresponse.Error(w, http.StatusBadRequest, "invalid pvz id")
+		case errors.Is(err, service.ErrProductNotFound):
+			httpresponse.Error(w, http.StatusNotFound, "product not found")
+		default:
+			httpresponse.Error(w, http.StatusInternalServerError, "internal server error")
+		}
+		return
+	}
+
+	httpresponse.JSON(w, http.StatusOK, newCreateProductResponse(product))
+}
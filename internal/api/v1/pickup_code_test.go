@@ -0,0 +1,213 @@
+package v1
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/entity"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/service"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/service/mocks"
+	"github.com/GlebMoskalev/go-pickup-point-api/pkg/httpresponse"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestGetPickupCodeImage(t *testing.T) {
+	productID := uuid.New().String()
+	product := &entity.Product{ID: uuid.New(), Status: entity.ProductStatusStored, PickupCode: "042917"}
+
+	testCases := []struct {
+		name                  string
+		productID             string
+		format                string
+		prepareProductService func(mockService *mocks.Product)
+		expectedHTTPStatus    int
+		expectedError         string
+	}{
+		{
+			name:      "qr by default",
+			productID: productID,
+			prepareProductService: func(mockService *mocks.Product) {
+				mockService.On("GetPickupCode", mock.Anything, productID).Return(product, nil)
+			},
+			expectedHTTPStatus: http.StatusOK,
+		},
+		{
+			name:      "code128",
+			productID: productID,
+			format:    pickupCodeFormatCode128,
+			prepareProductService: func(mockService *mocks.Product) {
+				mockService.On("GetPickupCode", mock.Anything, productID).Return(product, nil)
+			},
+			expectedHTTPStatus: http.StatusOK,
+		},
+		{
+			name:                  "invalid product id",
+			productID:             "invalid",
+			prepareProductService: func(mockService *mocks.Product) {},
+			expectedHTTPStatus:    http.StatusBadRequest,
+			expectedError:         "invalid product id",
+		},
+		{
+			name:                  "invalid format",
+			productID:             productID,
+			format:                "ean13",
+			prepareProductService: func(mockService *mocks.Product) {},
+			expectedHTTPStatus:    http.StatusBadRequest,
+			expectedError:         "invalid image format",
+		},
+		{
+			name:      "product not found",
+			productID: productID,
+			prepareProductService: func(mockService *mocks.Product) {
+				mockService.On("GetPickupCode", mock.Anything, productID).Return(nil, service.ErrProductNotFound)
+			},
+			expectedHTTPStatus: http.StatusNotFound,
+			expectedError:      "product not found",
+		},
+		{
+			name:      "internal error",
+			productID: productID,
+			prepareProductService: func(mockService *mocks.Product) {
+				mockService.On("GetPickupCode", mock.Anything, productID).Return(nil, errors.New("unexpected"))
+			},
+			expectedHTTPStatus: http.StatusInternalServerError,
+			expectedError:      "internal server error",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			productService := mocks.NewProduct(t)
+			tc.prepareProductService(productService)
+
+			handler := newProductHandler(productService)
+
+			r := chi.NewRouter()
+			r.Get("/products/{productId}/pickup-code.png", handler.getPickupCodeImage)
+			target := "/products/" + tc.productID + "/pickup-code.png"
+			if tc.format != "" {
+				target += "?format=" + tc.format
+			}
+			req := httptest.NewRequest(http.MethodGet, target, nil)
+			rec := httptest.NewRecorder()
+
+			r.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedHTTPStatus, rec.Code)
+			if tc.expectedError != "" {
+				var actualResponse httpresponse.ErrorResponse
+				if err := json.NewDecoder(rec.Body).Decode(&actualResponse); err != nil {
+					t.Fatalf("failed to decode response body: %v", err)
+				}
+				assert.Equal(t, tc.expectedError, actualResponse.Error)
+				return
+			}
+			assert.Equal(t, "image/png", rec.Header().Get("Content-Type"))
+			_, err := png.Decode(bytes.NewReader(rec.Body.Bytes()))
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestGetProductByPickupCode(t *testing.T) {
+	pvzID := uuid.New()
+	addedAt := time.Date(2026, 5, 4, 12, 0, 0, 0, time.UTC)
+	product := &entity.Product{ID: uuid.New(), DateTime: addedAt, Type: "обувь", ReceptionID: uuid.New(),
+		PVZID: pvzID, OrderNumber: 2, Status: entity.ProductStatusStored, PickupCode: "042917", AddedBy: uuid.New()}
+
+	testCases := []struct {
+		name                  string
+		pvzID                 string
+		prepareProductService func(mockService *mocks.Product)
+		expectedHTTPStatus    int
+		expectedResponse      any
+	}{
+		{
+			name:  "successful lookup",
+			pvzID: pvzID.String(),
+			prepareProductService: func(mockService *mocks.Product) {
+				mockService.On("GetByPickupCode", mock.Anything, pvzID.String(), "042917").Return(product, nil)
+			},
+			expectedHTTPStatus: http.StatusOK,
+			expectedResponse:   newCreateProductResponse(product),
+		},
+		{
+			name:                  "invalid pvz id",
+			pvzID:                 "invalid",
+			prepareProductService: func(mockService *mocks.Product) {},
+			expectedHTTPStatus:    http.StatusBadRequest,
+			expectedResponse:      httpresponse.ErrorResponse{Error: "invalid pvz id"},
+		},
+		{
+			name:  "pvz does not exist",
+			pvzID: pvzID.String(),
+			prepareProductService: func(mockService *mocks.Product) {
+				mockService.On("GetByPickupCode", mock.Anything, pvzID.String(), "042917").
+					Return(nil, service.ErrInvalidPVZID)
+			},
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse:   httpresponse.ErrorResponse{Error: "invalid pvz id"},
+		},
+		{
+			name:  "product not found",
+			pvzID: pvzID.String(),
+			prepareProductService: func(mockService *mocks.Product) {
+				mockService.On("GetByPickupCode", mock.Anything, pvzID.String(), "042917").
+					Return(nil, service.ErrProductNotFound)
+			},
+			expectedHTTPStatus: http.StatusNotFound,
+			expectedResponse:   httpresponse.ErrorResponse{Error: "product not found"},
+		},
+		{
+			name:  "internal error",
+			pvzID: pvzID.String(),
+			prepareProductService: func(mockService *mocks.Product) {
+				mockService.On("GetByPickupCode", mock.Anything, pvzID.String(), "042917").
+					Return(nil, service.ErrInternal)
+			},
+			expectedHTTPStatus: http.StatusInternalServerError,
+			expectedResponse:   httpresponse.ErrorResponse{Error: "internal server error"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			productService := mocks.NewProduct(t)
+			tc.prepareProductService(productService)
+
+			handler := newProductHandler(productService)
+
+			r := chi.NewRouter()
+			r.Post("/pvz/{pvzId}/pickup/{code}", handler.getProductByPickupCode)
+			req := httptest.NewRequest(http.MethodPost, "/pvz/"+tc.pvzID+"/pickup/042917", nil)
+			rec := httptest.NewRecorder()
+
+			r.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedHTTPStatus, rec.Code)
+			switch expected := tc.expectedResponse.(type) {
+			case createProductResponse:
+				var actualResponse createProductResponse
+				if err := json.NewDecoder(rec.Body).Decode(&actualResponse); err != nil {
+					t.Fatalf("failed to decode response body: %v", err)
+				}
+				assert.Equal(t, expected, actualResponse)
+				assert.Equal(t, pvzID.String(), actualResponse.PVZID)
+			default:
+				var actualResponse httpresponse.ErrorResponse
+				if err := json.NewDecoder(rec.Body).Decode(&actualResponse); err != nil {
+					t.Fatalf("failed to decode response body: %v", err)
+				}
+				assert.Equal(t, expected, actualResponse)
+			}
+		})
+	}
+}
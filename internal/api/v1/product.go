@@ -15,12 +15,27 @@ import (
 
 // @Description Запрос для добавления товара
 type createProductRequest struct {
-	// Идентификатор ПВЗ
+	// Идентификатор ПВЗ; можно не указывать, если задан dockId или receptionId
 	// format: uuid
-	PVZID string `json:"pvzId"`
-	// Тип товара
-	// enum: электроника, одежда, обувь
-	Type string `json:"type"`
+	PVZID string `json:"pvzId,omitempty"`
+	// Идентификатор дока, в открытую приёмку которого добавляется товар
+	// format: uuid
+	DockID string `json:"dockId,omitempty"`
+	// Идентификатор открытой приёмки
+	// format: uuid
+	ReceptionID string `json:"receptionId,omitempty"`
+	// Тип товара из каталога (GET /api/v1/product-types)
+	Type string `json:"type" example:"обувь"`
+	// Штрихкод или номер заказа: 4–64 символа, латинские буквы, цифры и дефис; регистр не важен
+	Barcode string `json:"barcode,omitempty" example:"4601234567890"`
+	// Дополнительные атрибуты по JSON-схеме типа, например {"size": 42}
+	Attributes json.RawMessage `json:"attributes,omitempty" swaggertype:"object"`
+	// Причина возврата; обязательна в приёмке-возврате и недопустима в поставке
+	ReturnReason string `json:"returnReason,omitempty" example:"не подошёл размер"`
+	// Объявленная ценность в минимальных единицах валюты (копейках); указывается вместе с currency
+	DeclaredValue int64 `json:"declaredValue,omitempty" example:"1299000"`
+	// Валюта объявленной ценности, код ISO 4217
+	Currency string `json:"currency,omitempty" example:"RUB"`
 }
 
 // @Description Ответ с данными о добавленном товаре
@@ -33,9 +48,76 @@ type createProductResponse struct {
 	DateTime string `json:"dateTime"`
 	// Тип товара
 	Type string `json:"type"`
+	// Штрихкод товара
+	Barcode string `json:"barcode,omitempty"`
+	// Дополнительные атрибуты товара
+	Attributes json.RawMessage `json:"attributes,omitempty" swaggertype:"object"`
 	// Идентификатор приёмки
 	// format: uuid
 	ReceptionID string `json:"receptionId"`
+	// Идентификатор ПВЗ, в котором хранится товар
+	// format: uuid
+	PVZID string `json:"pvzId,omitempty"`
+	// Порядковый номер товара в приёмке, начиная с 1
+	Position int `json:"position" example:"1"`
+	// Статус товара: stored, in_transit, transferred, issued или returned_to_sender
+	Status string `json:"status,omitempty" example:"stored"`
+	// Код получения, который покупатель предъявляет при выдаче
+	PickupCode string `json:"pickupCode,omitempty" example:"042917"`
+	// Ячейка хранения; нет, если подходящих свободных ячеек не было
+	Cell *productCellResponse `json:"cell,omitempty"`
+	// Причина возврата товара покупателем
+	ReturnReason string `json:"returnReason,omitempty"`
+	// Идентификатор выданного ранее товара с тем же штрихкодом, к которому относится возврат
+	// format: uuid
+	OriginalProductID string `json:"originalProductId,omitempty"`
+	// Идентификатор строки товара в ПВЗ отправления, если товар принят по перемещению
+	// format: uuid
+	SourceProductID string `json:"sourceProductId,omitempty"`
+	// Идентификатор пользователя, добавившего товар; не указывается для товаров без сведений об авторе
+	// format: uuid
+	AddedBy string `json:"addedBy,omitempty"`
+	// Идентификатор сотрудника, выдавшего товар
+	// format: uuid
+	IssuedBy string `json:"issuedBy,omitempty"`
+	// Время выдачи товара
+	// format: date-time
+	IssuedAt string `json:"issuedAt,omitempty"`
+	// Когда у товара истёк срок хранения и он попал в очередь на возврат отправителю
+	// format: date-time
+	OverdueAt string `json:"overdueAt,omitempty"`
+	// Идентификатор сотрудника, отправившего товар обратно отправителю
+	// format: uuid
+	ReturnedBy string `json:"returnedBy,omitempty"`
+	// Время отправки товара обратно отправителю
+	// format: date-time
+	ReturnedAt string `json:"returnedAt,omitempty"`
+	// Товар отмечен повреждённым
+	Damaged bool `json:"damaged,omitempty"`
+	// Описание повреждения
+	DamageDescription string `json:"damageDescription,omitempty"`
+	// Идентификатор сотрудника, отметившего повреждение
+	// format: uuid
+	DamagedBy string `json:"damagedBy,omitempty"`
+	// Время отметки о повреждении
+	// format: date-time
+	DamagedAt string `json:"damagedAt,omitempty"`
+	// Объявленная ценность в минимальных единицах валюты
+	DeclaredValue int64 `json:"declaredValue,omitempty" example:"1299000"`
+	// Валюта объявленной ценности, код ISO 4217
+	Currency string `json:"currency,omitempty" example:"RUB"`
+}
+
+// @Description Ошибка повторного сканирования: товар с таким штрихкодом уже хранится
+type productConflictResponse struct {
+	// Текст ошибки
+	Error string `json:"error" example:"product with this barcode is already stored"`
+	// Идентификатор уже хранящегося товара
+	// format: uuid
+	ExistingProductID string `json:"existingProductId"`
+	// Идентификатор приёмки, в которой принят этот товар
+	// format: uuid
+	ReceptionID string `json:"receptionId"`
 }
 
 // @Description Ответ с сообщением об удалении товара
@@ -49,6 +131,30 @@ func SetupProductRoutes(r chi.Router, productService service.Product) {
 
 	r.With(middleware.RoleMiddleware(entity.RoleEmployee)).
 		Post("/", handler.createProduct)
+
+	r.With(middleware.RoleMiddleware(entity.RoleEmployee)).
+		Post("/batch", handler.createProductBatch)
+
+	r.With(middleware.RoleMiddleware(entity.RoleEmployee, entity.RoleModerator)).
+		Get("/by-barcode/{code}", handler.getProductByBarcode)
+
+	r.With(middleware.RoleMiddleware(entity.RoleEmployee)).
+		Delete("/{productId}", handler.deleteProductByID)
+
+	r.With(middleware.RoleMiddleware(entity.RoleEmployee)).
+		Post("/{productId}/issue", handler.issueProduct)
+
+	r.With(middleware.RoleMiddleware(entity.RoleEmployee, entity.RoleModerator)).
+		Get("/{productId}/pickup-code.png", handler.getPickupCodeImage)
+
+	r.With(middleware.RoleMiddleware(entity.RoleEmployee)).
+		Put("/{productId}/cell", handler.assignCell)
+
+	r.With(middleware.RoleMiddleware(entity.RoleEmployee, entity.RoleModerator)).
+		Get("/{productId}/locations", handler.listProductLocations)
+
+	r.With(middleware.RoleMiddleware(entity.RoleEmployee)).
+		Post("/{productId}/return-to-sender", handler.returnToSender)
 }
 
 type productHandler struct {
@@ -60,15 +166,17 @@ func newProductHandler(productService service.Product) *productHandler {
 }
 
 // @Summary Добавление товара в приёмку
-// @Description Добавляет товар в последнюю незакрытую приёмку в указанном ПВЗ. Доступно только для сотрудников ПВЗ. Требуется незакрытая приёмка.
+// @Description Добавляет товар в незакрытую приёмку. Доступно только для сотрудников ПВЗ. Приёмка выбирается по receptionId, затем по dockId; если указан только pvzId, в ПВЗ должна быть ровно одна открытая приёмка. Штрихкод необязателен и уникален среди хранящихся товаров: повторное сканирование возвращает 409 с идентификатором уже принятого товара. Тип выбирается из каталога, атрибуты проверяются по JSON-схеме типа. В приёмку-возврат товар добавляется с причиной возврата и связывается с последним выданным товаром с тем же штрихкодом.
 // @Tags products
 // @Accept json
 // @Produce json
 // @Param input body createProductRequest true "Данные для добавления товара"
 // @Success 201 {object} createProductResponse
-// @Failure 400 {object} httpresponse.ErrorResponse "Неверный идентификатор ПВЗ, тип товара или отсутствие открытой приёмки"
+// @Failure 400 {object} httpresponse.ErrorResponse "Неверный идентификатор ПВЗ, дока или приёмки, тип товара, штрихкод, атрибуты или причина возврата, отсутствие открытой приёмки или не указан док"
 // @Failure 401 {object} httpresponse.ErrorResponse "Пользователь не авторизован"
 // @Failure 403 {object} httpresponse.ErrorResponse "Доступ запрещён"
+// @Failure 404 {object} httpresponse.ErrorResponse "Приёмка не найдена"
+// @Failure 409 {object} productConflictResponse "Товар с таким штрихкодом уже хранится"
 // @Failure 500 {object} httpresponse.ErrorResponse "Внутренняя ошибка сервера"
 // @Security JWT
 // @Router /api/v1/products [post]
@@ -79,63 +187,245 @@ func (h *productHandler) createProduct(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if _, err := uuid.Parse(req.PVZID); err != nil {
-		httpresponse.Error(w, http.StatusBadRequest, "invalid pvz id")
+	target := entity.ReceptionTarget{PVZID: req.PVZID, DockID: req.DockID, ReceptionID: req.ReceptionID}
+	if msg := validateReceptionTarget(target); msg != "" {
+		httpresponse.Error(w, http.StatusBadRequest, msg)
+		return
+	}
+
+	claims, ok := middleware.UserClaimsFromContext(r.Context())
+	if !ok {
+		httpresponse.Error(w, http.StatusUnauthorized, "unauthorized")
 		return
 	}
 
-	product, err := h.productService.Create(r.Context(), req.PVZID, req.Type)
+	product, err := h.productService.Create(r.Context(), entity.ProductParams{
+		ReceptionTarget: target,
+		Type:            req.Type,
+		Barcode:         req.Barcode,
+		Attributes:      req.Attributes,
+		ReturnReason:    req.ReturnReason,
+		DeclaredValue:   req.DeclaredValue,
+		Currency:        req.Currency,
+		AddedBy:         claims.UserID,
+	})
 	if err != nil {
+		var (
+			conflictErr  *service.BarcodeConflictError
+			attributeErr *service.AttributeError
+		)
 		switch {
+		case errors.As(err, &conflictErr):
+			httpresponse.JSON(w, http.StatusConflict, productConflictResponse{
+				Error:             "product with this barcode is already stored",
+				ExistingProductID: conflictErr.Existing.ID.String(),
+				ReceptionID:       conflictErr.Existing.ReceptionID.String(),
+			})
 		case errors.Is(err, service.ErrInvalidPVZID):
 			httpresponse.Error(w, http.StatusBadRequest, "invalid pvz id")
 		case errors.Is(err, service.ErrNoOpenReception):
 			httpresponse.Error(w, http.StatusBadRequest, "no open reception exists")
+		case errors.Is(err, service.ErrDockRequired):
+			httpresponse.Error(w, http.StatusBadRequest, "several open receptions, dock or reception id is required")
+		case errors.Is(err, service.ErrReceptionNotFound):
+			httpresponse.Error(w, http.StatusNotFound, "reception not found")
 		case errors.Is(err, service.ErrInvalidProductType):
 			httpresponse.Error(w, http.StatusBadRequest, "invalid product type")
+		case errors.As(err, &attributeErr):
+			httpresponse.Error(w, http.StatusBadRequest, attributeErr.Error())
+		case errors.Is(err, service.ErrInvalidBarcode):
+			httpresponse.Error(w, http.StatusBadRequest, "invalid barcode")
+		case errors.Is(err, service.ErrReturnReasonRequired):
+			httpresponse.Error(w, http.StatusBadRequest, "return reason is required")
+		case errors.Is(err, service.ErrUnexpectedReturnReason):
+			httpresponse.Error(w, http.StatusBadRequest, "return reason is allowed only in return receptions")
+		case errors.Is(err, service.ErrReturnReasonTooLong):
+			httpresponse.Error(w, http.StatusBadRequest, "return reason is too long")
+		case errors.Is(err, service.ErrInvalidValue):
+			httpresponse.Error(w, http.StatusBadRequest, "invalid declared value")
+		case errors.Is(err, service.ErrTransferReception):
+			httpresponse.Error(w, http.StatusConflict, "transfer reception accepts only transferred products")
 		default:
 			httpresponse.Error(w, http.StatusInternalServerError, "internal server error")
 		}
 		return
 	}
 
+	httpresponse.JSON(w, http.StatusCreated, newCreateProductResponse(product))
+}
+
+func newCreateProductResponse(product *entity.Product) createProductResponse {
 	resp := createProductResponse{
-		ID:          product.ID.String(),
-		DateTime:    product.DateTime.Format(time.RFC3339),
-		Type:        product.Type,
-		ReceptionID: product.ReceptionID.String(),
+		ID:                product.ID.String(),
+		DateTime:          product.DateTime.Format(time.RFC3339),
+		Type:              product.Type,
+		Barcode:           product.Barcode,
+		Attributes:        product.Attributes,
+		ReceptionID:       product.ReceptionID.String(),
+		PVZID:             uuidString(product.PVZID),
+		Position:          product.OrderNumber,
+		Status:            product.Status,
+		PickupCode:        product.PickupCode,
+		Cell:              newProductCellResponse(product),
+		AddedBy:           uuidString(product.AddedBy),
+		IssuedBy:          uuidString(product.IssuedBy),
+		ReturnReason:      product.ReturnReason,
+		OriginalProductID: uuidString(product.OriginalProductID),
+		SourceProductID:   uuidString(product.SourceProductID),
+		ReturnedBy:        uuidString(product.ReturnedBy),
+		Damaged:           product.DamagedAt != nil,
+		DamageDescription: product.DamageDescription,
+		DamagedBy:         uuidString(product.DamagedBy),
+		DeclaredValue:     product.DeclaredValue,
+		Currency:          product.Currency,
+	}
+	if product.IssuedAt != nil {
+		resp.IssuedAt = product.IssuedAt.Format(time.RFC3339)
+	}
+	if product.OverdueAt != nil {
+		resp.OverdueAt = product.OverdueAt.Format(time.RFC3339)
+	}
+	if product.ReturnedAt != nil {
+		resp.ReturnedAt = product.ReturnedAt.Format(time.RFC3339)
 	}
-	httpresponse.JSON(w, http.StatusCreated, resp)
+	if product.DamagedAt != nil {
+		resp.DamagedAt = product.DamagedAt.Format(time.RFC3339)
+	}
+	return resp
+}
+
+// @Summary Поиск товара по штрихкоду
+// @Description Доступно для сотрудников и модераторов. Возвращает хранящийся (неудалённый) товар с указанным штрихкодом.
+// @Tags products
+// @Produce json
+// @Param code path string true "Штрихкод товара"
+// @Success 200 {object} createProductResponse
+// @Failure 400 {object} httpresponse.ErrorResponse "Неверный штрихкод"
+// @Failure 401 {object} httpresponse.ErrorResponse "Пользователь не авторизован"
+// @Failure 403 {object} httpresponse.ErrorResponse "Доступ запрещён"
+// @Failure 404 {object} httpresponse.ErrorResponse "Товар не найден"
+// @Failure 500 {object} httpresponse.ErrorResponse "Внутренняя ошибка сервера"
+// @Security JWT
+// @Router /api/v1/products/by-barcode/{code} [get]
+func (h *productHandler) getProductByBarcode(w http.ResponseWriter, r *http.Request) {
+	product, err := h.productService.GetByBarcode(r.Context(), chi.URLParam(r, "code"))
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidBarcode):
+			httpresponse.Error(w, http.StatusBadRequest, "invalid barcode")
+		case errors.Is(err, service.ErrProductNotFound):
+			httpresponse.Error(w, http.StatusNotFound, "product not found")
+		default:
+			httpresponse.Error(w, http.StatusInternalServerError, "internal server error")
+		}
+		return
+	}
+
+	httpresponse.JSON(w, http.StatusOK, newCreateProductResponse(product))
 }
 
 // @Summary Удаление последнего добавленного товара
-// @Description Удаляет последний добавленный товар в последней незакрытой приёмке указанного ПВЗ. Доступно только для сотрудников ПВЗ. Требуется наличие незакрытой приёмки и хотя бы одного товара в ней.
+// @Description Удаляет последний добавленный товар в последней незакрытой приёмке указанного ПВЗ. Доступно только для сотрудников ПВЗ. Требуется наличие незакрытой приёмки и хотя бы одного товара в ней. Если в ПВЗ открыто несколько приёмок в разных доках, нужно указать dockId или receptionId.
 // @Tags pvz
 // @Produce json
 // @Param pvzId path string true "Идентификатор ПВЗ (uuid)"
+// @Param dockId query string false "Идентификатор дока"
+// @Param receptionId query string false "Идентификатор приёмки"
 // @Success 200 {object} deleteProductResponse "Сообщение об успешном удалении"
-// @Failure 400 {object} httpresponse.ErrorResponse "Неверный идентификатор ПВЗ, отсутствие открытой приёмки или отсутствие товаров в приёмке"
+// @Failure 400 {object} httpresponse.ErrorResponse "Неверный идентификатор ПВЗ, дока или приёмки, отсутствие открытой приёмки, не указан док или отсутствие товаров в приёмке"
+// @Failure 404 {object} httpresponse.ErrorResponse "Приёмка не найдена"
 // @Failure 401 {object} httpresponse.ErrorResponse "Пользователь не авторизован"
 // @Failure 403 {object} httpresponse.ErrorResponse "Доступ запрещён"
+// @Failure 409 {object} httpresponse.ErrorResponse "Последний товар уже не хранится или приёмка принимает только перемещённые товары"
 // @Failure 500 {object} httpresponse.ErrorResponse "Внутренняя ошибка сервера"
 // @Security JWT
 // @Router /api/v1/pvz/{pvzId}/delete_last_product [post]
 func (h *productHandler) deleteProduct(w http.ResponseWriter, r *http.Request) {
-	pvzID := chi.URLParam(r, "pvzId")
-	if _, err := uuid.Parse(pvzID); err != nil {
-		httpresponse.Error(w, http.StatusBadRequest, "invalid pvz id")
+	target, msg := receptionTargetFromRequest(r)
+	if msg != "" {
+		httpresponse.Error(w, http.StatusBadRequest, msg)
+		return
+	}
+
+	claims, ok := middleware.UserClaimsFromContext(r.Context())
+	if !ok {
+		httpresponse.Error(w, http.StatusUnauthorized, "unauthorized")
 		return
 	}
 
-	err := h.productService.DeleteLastProduct(r.Context(), pvzID)
+	err := h.productService.DeleteLastProduct(r.Context(), target, claims.UserID)
 	if err != nil {
 		switch {
 		case errors.Is(err, service.ErrInvalidPVZID):
 			httpresponse.Error(w, http.StatusBadRequest, "invalid pvz id")
 		case errors.Is(err, service.ErrNoOpenReception):
 			httpresponse.Error(w, http.StatusBadRequest, "no open reception exists")
+		case errors.Is(err, service.ErrDockRequired):
+			httpresponse.Error(w, http.StatusBadRequest, "several open receptions, dock or reception id is required")
+		case errors.Is(err, service.ErrReceptionNotFound):
+			httpresponse.Error(w, http.StatusNotFound, "reception not found")
 		case errors.Is(err, service.ErrNoProducts):
 			httpresponse.Error(w, http.StatusBadRequest, "no products in open reception")
+		case errors.Is(err, service.ErrProductNotStored):
+			httpresponse.Error(w, http.StatusConflict, "product is not stored")
+		case errors.Is(err, service.ErrTransferReception):
+			httpresponse.Error(w, http.StatusConflict, "transfer reception accepts only transferred products")
+		default:
+			httpresponse.Error(w, http.StatusInternalServerError, "internal server error")
+		}
+		return
+	}
+
+	httpresponse.JSON(w, http.StatusOK, deleteProductResponse{Message: "successfully delete"})
+}
+
+// @Summary Удаление товара из открытой приёмки
+// @Description Удаляет конкретный товар, пока его приёмка открыта. Доступно только для сотрудников ПВЗ. Товар помечается удалённым с автором и временем удаления и остаётся в списке удалённых товаров приёмки. Товар должен принадлежать ПВЗ pvzId.
+// @Tags products
+// @Produce json
+// @Param productId path string true "Идентификатор товара"
+// @Param pvzId query string true "Идентификатор ПВЗ"
+// @Success 200 {object} deleteProductResponse "Сообщение об успешном удалении"
+// @Failure 400 {object} httpresponse.ErrorResponse "Неверный идентификатор товара или ПВЗ"
+// @Failure 401 {object} httpresponse.ErrorResponse "Пользователь не авторизован"
+// @Failure 403 {object} httpresponse.ErrorResponse "Доступ запрещён"
+// @Failure 404 {object} httpresponse.ErrorResponse "Товар не найден"
+// @Failure 409 {object} httpresponse.ErrorResponse "Приёмка товара уже закрыта или товар уже не хранится"
+// @Failure 500 {object} httpresponse.ErrorResponse "Внутренняя ошибка сервера"
+// @Security JWT
+// @Router /api/v1/products/{productId} [delete]
+func (h *productHandler) deleteProductByID(w http.ResponseWriter, r *http.Request) {
+	productID := chi.URLParam(r, "productId")
+	if _, err := uuid.Parse(productID); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid product id")
+		return
+	}
+
+	pvzID := r.URL.Query().Get("pvzId")
+	if _, err := uuid.Parse(pvzID); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid pvz id")
+		return
+	}
+
+	claims, ok := middleware.UserClaimsFromContext(r.Context())
+	if !ok {
+		httpresponse.Error(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	err := h.productService.Delete(r.Context(), productID, pvzID, claims.UserID)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidPVZID):
+			httpresponse.Error(w, http.StatusBadRequest, "invalid pvz id")
+		case errors.Is(err, service.ErrProductNotFound):
+			httpresponse.Error(w, http.StatusNotFound, "product not found")
+		case errors.Is(err, service.ErrInvalidReceptionStatus):
+			httpresponse.Error(w, http.StatusConflict, "reception is not open")
+		case errors.Is(err, service.ErrProductNotStored):
+			httpresponse.Error(w, http.StatusConflict, "product is not stored")
+		case errors.Is(err, service.ErrTransferReception):
+			httpresponse.Error(w, http.StatusConflict, "transfer reception accepts only transferred products")
 		default:
 			httpresponse.Error(w, http.StatusInternalServerError, "internal server error")
 		}
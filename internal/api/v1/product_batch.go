@@ -0,0 +1,221 @@
+package v1
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/api/middleware"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/entity"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/service"
+	"github.com/GlebMoskalev/go-pickup-point-api/pkg/httpresponse"
+	"net/http"
+)
+
+// Статусы позиций пакета в ответе.
+const (
+	batchItemCreated    = "created"
+	batchItemFailed     = "failed"
+	batchItemNotCreated = "not_created"
+)
+
+// @Description Запрос на пакетное добавление товаров в одну открытую приёмку
+type productBatchRequest struct {
+	// Идентификатор ПВЗ; можно не указывать, если задан dockId или receptionId
+	// format: uuid
+	PVZID string `json:"pvzId,omitempty"`
+	// Идентификатор дока, в открытую приёмку которого добавляются товары
+	// format: uuid
+	DockID string `json:"dockId,omitempty"`
+	// Идентификатор открытой приёмки
+	// format: uuid
+	ReceptionID string `json:"receptionId,omitempty"`
+	// Режим: all_or_nothing (по умолчанию) или best_effort
+	Mode string `json:"mode,omitempty" enums:"all_or_nothing,best_effort" example:"all_or_nothing"`
+	// Товары пакета, от 1 до 1000
+	Items []productBatchItemRequest `json:"items"`
+}
+
+// @Description Позиция пакета
+type productBatchItemRequest struct {
+	// Тип товара из каталога
+	Type string `json:"type" example:"обувь"`
+	// Штрихкод товара
+	Barcode string `json:"barcode,omitempty" example:"4601234567890"`
+	// Дополнительные атрибуты по JSON-схеме типа
+	Attributes json.RawMessage `json:"attributes,omitempty" swaggertype:"object"`
+	// Причина возврата; обязательна в приёмке-возврате
+	ReturnReason string `json:"returnReason,omitempty" example:"не подошёл размер"`
+	// Объявленная ценность в минимальных единицах валюты; указывается вместе с currency
+	DeclaredValue int64 `json:"declaredValue,omitempty" example:"1299000"`
+	// Валюта объявленной ценности, код ISO 4217
+	Currency string `json:"currency,omitempty" example:"RUB"`
+}
+
+// @Description Результат пакетного добавления товаров
+type productBatchResponse struct {
+	// Текст ошибки, если пакет отклонён
+	Error string `json:"error,omitempty" example:"product batch rejected"`
+	// Режим обработки пакета
+	Mode string `json:"mode" example:"all_or_nothing"`
+	// Идентификатор приёмки
+	// format: uuid
+	ReceptionID string `json:"receptionId,omitempty"`
+	// Число добавленных товаров
+	Created int `json:"created"`
+	// Число позиций с ошибками
+	Failed int `json:"failed"`
+	// Результаты по позициям в порядке запроса
+	Items []productBatchItemResponse `json:"items"`
+}
+
+// @Description Результат по позиции пакета
+type productBatchItemResponse struct {
+	// Номер позиции в запросе, с нуля
+	Index int `json:"index"`
+	// created, failed или not_created (позиция корректна, но пакет отклонён)
+	Status string `json:"status" enums:"created,failed,not_created"`
+	// Добавленный товар
+	Product *createProductResponse `json:"product,omitempty"`
+	// Текст ошибки позиции
+	Error string `json:"error,omitempty"`
+	// Идентификатор уже хранящегося товара с тем же штрихкодом
+	// format: uuid
+	ExistingProductID string `json:"existingProductId,omitempty"`
+}
+
+// @Summary Пакетное добавление товаров в приёмку
+// @Description Доступно только для сотрудников ПВЗ. Добавляет до 1000 товаров в одну открытую приёмку одной транзакцией; приёмка выбирается так же, как при добавлении одного товара. В режиме all_or_nothing ошибка в любой позиции отклоняет весь пакет (400 с результатами по позициям), в режиме best_effort добавляются корректные позиции, а по остальным возвращаются ошибки. Штрихкоды не должны повторяться внутри пакета и среди хранящихся товаров.
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param input body productBatchRequest true "Пакет товаров"
+// @Success 201 {object} productBatchResponse "Все позиции добавлены"
+// @Success 200 {object} productBatchResponse "Режим best_effort: добавлена часть позиций"
+// @Failure 400 {object} productBatchResponse "Пакет отклонён, неверный идентификатор ПВЗ, дока или приёмки, режим или размер пакета"
+// @Failure 401 {object} httpresponse.ErrorResponse "Пользователь не авторизован"
+// @Failure 403 {object} httpresponse.ErrorResponse "Доступ запрещён"
+// @Failure 404 {object} httpresponse.ErrorResponse "Приёмка не найдена"
+// @Failure 409 {object} httpresponse.ErrorResponse "Товар с одним из штрихкодов добавлен параллельно"
+// @Failure 500 {object} httpresponse.ErrorResponse "Внутренняя ошибка сервера"
+// @Security JWT
+// @Router /api/v1/products/batch [post]
+func (h *productHandler) createProductBatch(w http.ResponseWriter, r *http.Request) {
+	var req productBatchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	target := entity.ReceptionTarget{PVZID: req.PVZID, DockID: req.DockID, ReceptionID: req.ReceptionID}
+	if msg := validateReceptionTarget(target); msg != "" {
+		httpresponse.Error(w, http.StatusBadRequest, msg)
+		return
+	}
+
+	claims, ok := middleware.UserClaimsFromContext(r.Context())
+	if !ok {
+		httpresponse.Error(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	params := entity.ProductBatchParams{
+		ReceptionTarget: target,
+		Mode:            req.Mode,
+		Items:           make([]entity.ProductBatchItem, len(req.Items)),
+		AddedBy:         claims.UserID,
+	}
+	for i, item := range req.Items {
+		params.Items[i] = entity.ProductBatchItem{Type: item.Type, Barcode: item.Barcode, Attributes: item.Attributes,
+			ReturnReason: item.ReturnReason, DeclaredValue: item.DeclaredValue, Currency: item.Currency}
+	}
+	if params.Mode == "" {
+		params.Mode = entity.BatchModeAllOrNothing
+	}
+
+	result, err := h.productService.CreateBatch(r.Context(), params)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrBatchRejected):
+			resp := newProductBatchResponse(params.Mode, result)
+			resp.Error = "product batch rejected"
+			httpresponse.JSON(w, http.StatusBadRequest, resp)
+		case errors.Is(err, service.ErrInvalidBatch):
+			httpresponse.Error(w, http.StatusBadRequest, "invalid batch")
+		case errors.Is(err, service.ErrInvalidPVZID):
+			httpresponse.Error(w, http.StatusBadRequest, "invalid pvz id")
+		case errors.Is(err, service.ErrNoOpenReception):
+			httpresponse.Error(w, http.StatusBadRequest, "no open reception exists")
+		case errors.Is(err, service.ErrDockRequired):
+			httpresponse.Error(w, http.StatusBadRequest, "several open receptions, dock or reception id is required")
+		case errors.Is(err, service.ErrReceptionNotFound):
+			httpresponse.Error(w, http.StatusNotFound, "reception not found")
+		case errors.Is(err, service.ErrBarcodeExists):
+			httpresponse.Error(w, http.StatusConflict, "product with this barcode is already stored")
+		case errors.Is(err, service.ErrTransferReception):
+			httpresponse.Error(w, http.StatusConflict, "transfer reception accepts only transferred products")
+		default:
+			httpresponse.Error(w, http.StatusInternalServerError, "internal server error")
+		}
+		return
+	}
+
+	resp := newProductBatchResponse(params.Mode, result)
+	status := http.StatusCreated
+	if resp.Failed > 0 {
+		status = http.StatusOK
+	}
+	httpresponse.JSON(w, status, resp)
+}
+
+func newProductBatchResponse(mode string, result *entity.ProductBatchResult) productBatchResponse {
+	resp := productBatchResponse{
+		Mode:        mode,
+		ReceptionID: uuidString(result.ReceptionID),
+		Items:       make([]productBatchItemResponse, len(result.Items)),
+	}
+	for i, item := range result.Items {
+		itemResp := productBatchItemResponse{Index: i, Status: batchItemNotCreated}
+		switch {
+		case item.Product != nil:
+			product := newCreateProductResponse(item.Product)
+			itemResp.Status = batchItemCreated
+			itemResp.Product = &product
+			resp.Created++
+		case item.Err != nil:
+			itemResp.Status = batchItemFailed
+			itemResp.Error, itemResp.ExistingProductID = batchItemError(item.Err)
+			resp.Failed++
+		}
+		resp.Items[i] = itemResp
+	}
+	return resp
+}
+
+// batchItemError возвращает текст ошибки позиции и идентификатор товара, с которым совпал штрихкод.
+func batchItemError(err error) (string, string) {
+	var (
+		conflictErr  *service.BarcodeConflictError
+		attributeErr *service.AttributeError
+	)
+	switch {
+	case errors.As(err, &conflictErr):
+		return "product with this barcode is already stored", conflictErr.Existing.ID.String()
+	case errors.As(err, &attributeErr):
+		return attributeErr.Error(), ""
+	case errors.Is(err, service.ErrInvalidProductType):
+		return "invalid product type", ""
+	case errors.Is(err, service.ErrInvalidBarcode):
+		return "invalid barcode", ""
+	case errors.Is(err, service.ErrBarcodeRepeated):
+		return "barcode is repeated in the batch", ""
+	case errors.Is(err, service.ErrReturnReasonRequired):
+		return "return reason is required", ""
+	case errors.Is(err, service.ErrUnexpectedReturnReason):
+		return "return reason is allowed only in return receptions", ""
+	case errors.Is(err, service.ErrReturnReasonTooLong):
+		return "return reason is too long", ""
+	case errors.Is(err, service.ErrInvalidValue):
+		return "invalid declared value", ""
+	default:
+		return "internal server error", ""
+	}
+}
@@ -0,0 +1,194 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/api/middleware"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/entity"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/service"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/service/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestCreateProductBatch(t *testing.T) {
+	userID := uuid.New()
+	receptionID := uuid.New()
+	pvzID := uuid.New().String()
+	claims := &entity.UserClaims{UserID: userID, Role: entity.RoleEmployee}
+	product := &entity.Product{ID: uuid.New(), DateTime: time.Now(), Type: "обувь", Barcode: "ORD-1",
+		ReceptionID: receptionID, AddedBy: userID}
+	existing := entity.Product{ID: uuid.New(), ReceptionID: uuid.New(), Barcode: "ORD-2"}
+	twoItems := []productBatchItemRequest{{Type: "обувь", Barcode: "ord-1"}, {Type: "одежда", Barcode: "ORD-2"}}
+
+	testCases := []struct {
+		name             string
+		request          any
+		prepareService   func(productService *mocks.Product)
+		expectedStatus   int
+		expectedError    string
+		expectedStatuses []string
+	}{
+		{
+			name:    "all items created",
+			request: productBatchRequest{PVZID: pvzID, Items: twoItems[:1]},
+			prepareService: func(productService *mocks.Product) {
+				productService.On("CreateBatch", mock.Anything, entity.ProductBatchParams{
+					ReceptionTarget: entity.ReceptionTarget{PVZID: pvzID},
+					Mode:            entity.BatchModeAllOrNothing,
+					Items:           []entity.ProductBatchItem{{Type: "обувь", Barcode: "ord-1"}},
+					AddedBy:         userID,
+				}).Return(&entity.ProductBatchResult{ReceptionID: receptionID,
+					Items: []entity.ProductBatchItemResult{{Product: product}}}, nil)
+			},
+			expectedStatus:   http.StatusCreated,
+			expectedStatuses: []string{batchItemCreated},
+		},
+		{
+			name: "declared value passed to service",
+			request: productBatchRequest{PVZID: pvzID, Items: []productBatchItemRequest{
+				{Type: "обувь", Barcode: "ord-1", DeclaredValue: 550000, Currency: "RUB"}}},
+			prepareService: func(productService *mocks.Product) {
+				productService.On("CreateBatch", mock.Anything, entity.ProductBatchParams{
+					ReceptionTarget: entity.ReceptionTarget{PVZID: pvzID},
+					Mode:            entity.BatchModeAllOrNothing,
+					Items: []entity.ProductBatchItem{
+						{Type: "обувь", Barcode: "ord-1", DeclaredValue: 550000, Currency: "RUB"}},
+					AddedBy: userID,
+				}).Return(&entity.ProductBatchResult{ReceptionID: receptionID,
+					Items: []entity.ProductBatchItemResult{{Product: product}}}, nil)
+			},
+			expectedStatus:   http.StatusCreated,
+			expectedStatuses: []string{batchItemCreated},
+		},
+		{
+			name:    "best effort with failed items",
+			request: productBatchRequest{PVZID: pvzID, Mode: entity.BatchModeBestEffort, Items: twoItems},
+			prepareService: func(productService *mocks.Product) {
+				productService.On("CreateBatch", mock.Anything, mock.AnythingOfType("entity.ProductBatchParams")).
+					Return(&entity.ProductBatchResult{ReceptionID: receptionID, Items: []entity.ProductBatchItemResult{
+						{Product: product},
+						{Err: &service.BarcodeConflictError{Existing: existing}},
+					}}, nil)
+			},
+			expectedStatus:   http.StatusOK,
+			expectedStatuses: []string{batchItemCreated, batchItemFailed},
+		},
+		{
+			name:    "batch rejected",
+			request: productBatchRequest{PVZID: pvzID, Items: twoItems},
+			prepareService: func(productService *mocks.Product) {
+				productService.On("CreateBatch", mock.Anything, mock.AnythingOfType("entity.ProductBatchParams")).
+					Return(&entity.ProductBatchResult{ReceptionID: receptionID, Items: []entity.ProductBatchItemResult{
+						{},
+						{Err: &service.BarcodeConflictError{Existing: existing}},
+					}}, service.ErrBatchRejected)
+			},
+			expectedStatus:   http.StatusBadRequest,
+			expectedError:    "product batch rejected",
+			expectedStatuses: []string{batchItemNotCreated, batchItemFailed},
+		},
+		{
+			name:           "invalid request body",
+			request:        "invalid",
+			prepareService: func(productService *mocks.Product) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "invalid request body",
+		},
+		{
+			name:           "invalid pvz id",
+			request:        productBatchRequest{PVZID: "invalid", Items: twoItems},
+			prepareService: func(productService *mocks.Product) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "invalid pvz id",
+		},
+		{
+			name:    "invalid batch",
+			request: productBatchRequest{PVZID: pvzID, Mode: "partial", Items: twoItems},
+			prepareService: func(productService *mocks.Product) {
+				productService.On("CreateBatch", mock.Anything, mock.AnythingOfType("entity.ProductBatchParams")).
+					Return(nil, service.ErrInvalidBatch)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "invalid batch",
+		},
+		{
+			name:    "no open reception",
+			request: productBatchRequest{PVZID: pvzID, Items: twoItems},
+			prepareService: func(productService *mocks.Product) {
+				productService.On("CreateBatch", mock.Anything, mock.AnythingOfType("entity.ProductBatchParams")).
+					Return(nil, service.ErrNoOpenReception)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "no open reception exists",
+		},
+		{
+			name:    "barcode stored concurrently",
+			request: productBatchRequest{PVZID: pvzID, Items: twoItems},
+			prepareService: func(productService *mocks.Product) {
+				productService.On("CreateBatch", mock.Anything, mock.AnythingOfType("entity.ProductBatchParams")).
+					Return(nil, service.ErrBarcodeExists)
+			},
+			expectedStatus: http.StatusConflict,
+			expectedError:  "product with this barcode is already stored",
+		},
+		{
+			name:    "internal error",
+			request: productBatchRequest{PVZID: pvzID, Items: twoItems},
+			prepareService: func(productService *mocks.Product) {
+				productService.On("CreateBatch", mock.Anything, mock.AnythingOfType("entity.ProductBatchParams")).
+					Return(nil, service.ErrInternal)
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedError:  "internal server error",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			productService := mocks.NewProduct(t)
+			tc.prepareService(productService)
+			handler := newProductHandler(productService)
+
+			reqBody, err := json.Marshal(tc.request)
+			if err != nil {
+				t.Fatalf("failed to marshal request: %v", err)
+			}
+			req := httptest.NewRequest("POST", "/products/batch", bytes.NewReader(reqBody))
+			req = req.WithContext(context.WithValue(req.Context(), middleware.ClaimsContext, claims))
+			rec := httptest.NewRecorder()
+
+			handler.createProductBatch(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			var resp productBatchResponse
+			if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+				t.Fatalf("failed to decode response body: %v", err)
+			}
+			assert.Equal(t, tc.expectedError, resp.Error)
+			if tc.expectedStatuses == nil {
+				return
+			}
+
+			assert.Equal(t, receptionID.String(), resp.ReceptionID)
+			assert.Len(t, resp.Items, len(tc.expectedStatuses))
+			for i, status := range tc.expectedStatuses {
+				assert.Equal(t, i, resp.Items[i].Index)
+				assert.Equal(t, status, resp.Items[i].Status)
+				switch status {
+				case batchItemCreated:
+					assert.Equal(t, product.ID.String(), resp.Items[i].Product.ID)
+				case batchItemFailed:
+					assert.Equal(t, "product with this barcode is already stored", resp.Items[i].Error)
+					assert.Equal(t, existing.ID.String(), resp.Items[i].ExistingProductID)
+				}
+			}
+		})
+	}
+}
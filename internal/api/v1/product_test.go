@@ -2,8 +2,10 @@ package v1
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/api/middleware"
 	"github.com/GlebMoskalev/go-pickup-point-api/internal/entity"
 	"github.com/GlebMoskalev/go-pickup-point-api/internal/service"
 	"github.com/GlebMoskalev/go-pickup-point-api/internal/service/mocks"
@@ -19,9 +21,14 @@ import (
 )
 
 func TestCreateProduct(t *testing.T) {
+	userID := uuid.New()
+	receptionID := uuid.New()
+	claims := &entity.UserClaims{UserID: userID, Role: entity.RoleEmployee}
+
 	testCases := []struct {
 		name                  string
 		request               any
+		anonymous             bool
 		prepareProductService func(mockService *mocks.Product)
 		expectedHTTPStatus    int
 		expectedResponse      any
@@ -32,12 +39,15 @@ func TestCreateProduct(t *testing.T) {
 			prepareProductService: func(mockService *mocks.Product) {
 				productID := uuid.New()
 				receptionID := uuid.New()
-				mockService.On("Create", mock.Anything, mock.AnythingOfType("string"), "электроника").
+				mockService.On("Create", mock.Anything, mock.MatchedBy(func(params entity.ProductParams) bool {
+					return params.Type == "электроника" && params.AddedBy == userID
+				})).
 					Return(&entity.Product{
 						ID:          productID,
 						DateTime:    time.Now(),
 						Type:        "электроника",
 						ReceptionID: receptionID,
+						AddedBy:     userID,
 					}, nil)
 			},
 			expectedHTTPStatus: http.StatusCreated,
@@ -46,7 +56,60 @@ func TestCreateProduct(t *testing.T) {
 				DateTime:    "date",
 				Type:        "электроника",
 				ReceptionID: "reception_id",
+				AddedBy:     userID.String(),
+			},
+		},
+		{
+			name:                  "missing claims",
+			request:               createProductRequest{PVZID: uuid.New().String(), Type: "электроника"},
+			anonymous:             true,
+			prepareProductService: func(mockService *mocks.Product) {},
+			expectedHTTPStatus:    http.StatusUnauthorized,
+			expectedResponse:      httpresponse.ErrorResponse{Error: "unauthorized"},
+		},
+		{
+			name:    "creation by reception id",
+			request: createProductRequest{ReceptionID: receptionID.String(), Type: "обувь"},
+			prepareProductService: func(mockService *mocks.Product) {
+				mockService.On("Create", mock.Anything, entity.ProductParams{
+					ReceptionTarget: entity.ReceptionTarget{ReceptionID: receptionID.String()},
+					Type:            "обувь",
+					AddedBy:         userID,
+				}).
+					Return(&entity.Product{
+						ID:          uuid.New(),
+						DateTime:    time.Now(),
+						Type:        "обувь",
+						ReceptionID: receptionID,
+						AddedBy:     userID,
+					}, nil)
+			},
+			expectedHTTPStatus: http.StatusCreated,
+			expectedResponse:   createProductResponse{Type: "обувь", AddedBy: userID.String()},
+		},
+		{
+			name:                  "no reception target",
+			request:               createProductRequest{Type: "электроника"},
+			prepareProductService: func(mockService *mocks.Product) {},
+			expectedHTTPStatus:    http.StatusBadRequest,
+			expectedResponse:      httpresponse.ErrorResponse{Error: "invalid pvz id"},
+		},
+		{
+			name:                  "invalid dock id",
+			request:               createProductRequest{DockID: "not-a-uuid", Type: "электроника"},
+			prepareProductService: func(mockService *mocks.Product) {},
+			expectedHTTPStatus:    http.StatusBadRequest,
+			expectedResponse:      httpresponse.ErrorResponse{Error: "invalid dock id"},
+		},
+		{
+			name:    "reception not found",
+			request: createProductRequest{ReceptionID: receptionID.String(), Type: "электроника"},
+			prepareProductService: func(mockService *mocks.Product) {
+				mockService.On("Create", mock.Anything, mock.AnythingOfType("entity.ProductParams")).
+					Return(nil, service.ErrReceptionNotFound)
 			},
+			expectedHTTPStatus: http.StatusNotFound,
+			expectedResponse:   httpresponse.ErrorResponse{Error: "reception not found"},
 		},
 		{
 			name:                  "invalid pvz id",
@@ -59,17 +122,105 @@ func TestCreateProduct(t *testing.T) {
 			name:    "invalid product type",
 			request: createProductRequest{PVZID: uuid.New().String(), Type: "неизвестный"},
 			prepareProductService: func(mockService *mocks.Product) {
-				mockService.On("Create", mock.Anything, mock.AnythingOfType("string"), "неизвестный").
+				mockService.On("Create", mock.Anything, mock.AnythingOfType("entity.ProductParams")).
 					Return(nil, service.ErrInvalidProductType)
 			},
 			expectedHTTPStatus: http.StatusBadRequest,
 			expectedResponse:   httpresponse.ErrorResponse{Error: "invalid product type"},
 		},
+		{
+			name:    "invalid barcode",
+			request: createProductRequest{PVZID: uuid.New().String(), Type: "обувь", Barcode: "12 34"},
+			prepareProductService: func(mockService *mocks.Product) {
+				mockService.On("Create", mock.Anything, mock.MatchedBy(func(params entity.ProductParams) bool {
+					return params.Barcode == "12 34"
+				})).
+					Return(nil, service.ErrInvalidBarcode)
+			},
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse:   httpresponse.ErrorResponse{Error: "invalid barcode"},
+		},
+		{
+			name: "attributes do not match schema",
+			request: createProductRequest{PVZID: uuid.New().String(), Type: "обувь",
+				Attributes: json.RawMessage(`{"size": 100}`)},
+			prepareProductService: func(mockService *mocks.Product) {
+				mockService.On("Create", mock.Anything, mock.MatchedBy(func(params entity.ProductParams) bool {
+					return string(params.Attributes) == `{"size":100}`
+				})).
+					Return(nil, &service.AttributeError{Attribute: "size", Reason: "must be at most 55"})
+			},
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse:   httpresponse.ErrorResponse{Error: "invalid product attributes: size: must be at most 55"},
+		},
+		{
+			name:    "return reason required",
+			request: createProductRequest{PVZID: uuid.New().String(), Type: "обувь"},
+			prepareProductService: func(mockService *mocks.Product) {
+				mockService.On("Create", mock.Anything, mock.AnythingOfType("entity.ProductParams")).
+					Return(nil, service.ErrReturnReasonRequired)
+			},
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse:   httpresponse.ErrorResponse{Error: "return reason is required"},
+		},
+		{
+			name: "return reason passed to service",
+			request: createProductRequest{PVZID: uuid.New().String(), Type: "обувь", Barcode: "4600000000011",
+				ReturnReason: "не подошёл размер"},
+			prepareProductService: func(mockService *mocks.Product) {
+				mockService.On("Create", mock.Anything, mock.MatchedBy(func(params entity.ProductParams) bool {
+					return params.ReturnReason == "не подошёл размер"
+				})).
+					Return(&entity.Product{
+						ID:                uuid.New(),
+						DateTime:          time.Now(),
+						Type:              "обувь",
+						ReceptionID:       receptionID,
+						ReturnReason:      "не подошёл размер",
+						OriginalProductID: uuid.New(),
+						AddedBy:           userID,
+					}, nil)
+			},
+			expectedHTTPStatus: http.StatusCreated,
+			expectedResponse:   createProductResponse{Type: "обувь", AddedBy: userID.String()},
+		},
+		{
+			name: "declared value passed to service",
+			request: createProductRequest{PVZID: uuid.New().String(), Type: "электроника",
+				DeclaredValue: 1299000, Currency: "rub"},
+			prepareProductService: func(mockService *mocks.Product) {
+				mockService.On("Create", mock.Anything, mock.MatchedBy(func(params entity.ProductParams) bool {
+					return params.DeclaredValue == 1299000 && params.Currency == "rub"
+				})).
+					Return(&entity.Product{
+						ID:            uuid.New(),
+						DateTime:      time.Now(),
+						Type:          "электроника",
+						ReceptionID:   receptionID,
+						DeclaredValue: 1299000,
+						Currency:      "RUB",
+						AddedBy:       userID,
+					}, nil)
+			},
+			expectedHTTPStatus: http.StatusCreated,
+			expectedResponse: createProductResponse{Type: "электроника", AddedBy: userID.String(),
+				DeclaredValue: 1299000, Currency: "RUB"},
+		},
+		{
+			name:    "invalid declared value",
+			request: createProductRequest{PVZID: uuid.New().String(), Type: "электроника", DeclaredValue: 100},
+			prepareProductService: func(mockService *mocks.Product) {
+				mockService.On("Create", mock.Anything, mock.AnythingOfType("entity.ProductParams")).
+					Return(nil, service.ErrInvalidValue)
+			},
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse:   httpresponse.ErrorResponse{Error: "invalid declared value"},
+		},
 		{
 			name:    "no open reception",
 			request: createProductRequest{PVZID: uuid.New().String(), Type: "электроника"},
 			prepareProductService: func(mockService *mocks.Product) {
-				mockService.On("Create", mock.Anything, mock.AnythingOfType("string"), "электроника").
+				mockService.On("Create", mock.Anything, mock.AnythingOfType("entity.ProductParams")).
 					Return(nil, service.ErrNoOpenReception)
 			},
 			expectedHTTPStatus: http.StatusBadRequest,
@@ -79,7 +230,7 @@ func TestCreateProduct(t *testing.T) {
 			name:    "internal server error",
 			request: createProductRequest{PVZID: uuid.New().String(), Type: "электроника"},
 			prepareProductService: func(mockService *mocks.Product) {
-				mockService.On("Create", mock.Anything, mock.AnythingOfType("string"), "электроника").
+				mockService.On("Create", mock.Anything, mock.AnythingOfType("entity.ProductParams")).
 					Return(nil, errors.New("database error"))
 			},
 			expectedHTTPStatus: http.StatusInternalServerError,
@@ -106,6 +257,9 @@ func TestCreateProduct(t *testing.T) {
 				t.Fatalf("failed to marshal request: %v", err)
 			}
 			req := httptest.NewRequest("POST", "/products", bytes.NewReader(reqBody))
+			if !tc.anonymous {
+				req = req.WithContext(context.WithValue(req.Context(), middleware.ClaimsContext, claims))
+			}
 			rec := httptest.NewRecorder()
 
 			handler.createProduct(rec, req)
@@ -125,6 +279,9 @@ func TestCreateProduct(t *testing.T) {
 				_, err = time.Parse(time.RFC3339, actualResponse.DateTime)
 				assert.NoError(t, err, "DateTime should be in correct format")
 				assert.Equal(t, tc.expectedResponse.(createProductResponse).Type, actualResponse.Type)
+				assert.Equal(t, tc.expectedResponse.(createProductResponse).AddedBy, actualResponse.AddedBy)
+				assert.Equal(t, tc.expectedResponse.(createProductResponse).DeclaredValue, actualResponse.DeclaredValue)
+				assert.Equal(t, tc.expectedResponse.(createProductResponse).Currency, actualResponse.Currency)
 			} else {
 				var actualResponse httpresponse.ErrorResponse
 				err := json.NewDecoder(rec.Body).Decode(&actualResponse)
@@ -137,10 +294,137 @@ func TestCreateProduct(t *testing.T) {
 	}
 }
 
+func TestCreateProductDuplicateBarcode(t *testing.T) {
+	existing := entity.Product{ID: uuid.New(), ReceptionID: uuid.New(), Barcode: "4601234567890"}
+	productService := mocks.NewProduct(t)
+	productService.On("Create", mock.Anything, mock.AnythingOfType("entity.ProductParams")).
+		Return(nil, &service.BarcodeConflictError{Existing: existing})
+
+	handler := newProductHandler(productService)
+
+	reqBody, err := json.Marshal(createProductRequest{PVZID: uuid.New().String(), Type: "обувь",
+		Barcode: existing.Barcode})
+	if err != nil {
+		t.Fatalf("failed to marshal request: %v", err)
+	}
+	req := httptest.NewRequest("POST", "/products", bytes.NewReader(reqBody))
+	req = req.WithContext(context.WithValue(req.Context(), middleware.ClaimsContext,
+		&entity.UserClaims{UserID: uuid.New(), Role: entity.RoleEmployee}))
+	rec := httptest.NewRecorder()
+
+	handler.createProduct(rec, req)
+
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	var actualResponse productConflictResponse
+	if err := json.NewDecoder(rec.Body).Decode(&actualResponse); err != nil {
+		t.Fatalf("failed to decode response body: %v", err)
+	}
+	assert.Equal(t, productConflictResponse{
+		Error:             "product with this barcode is already stored",
+		ExistingProductID: existing.ID.String(),
+		ReceptionID:       existing.ReceptionID.String(),
+	}, actualResponse)
+}
+
+func TestGetProductByBarcode(t *testing.T) {
+	dateTime := time.Date(2025, 4, 1, 9, 0, 0, 0, time.UTC)
+	product := &entity.Product{ID: uuid.New(), DateTime: dateTime, Type: entity.ProductTypeShoes,
+		Barcode: "ORD-1001", ReceptionID: uuid.New(), AddedBy: uuid.New()}
+
+	testCases := []struct {
+		name                  string
+		code                  string
+		prepareProductService func(mockService *mocks.Product)
+		expectedHTTPStatus    int
+		expectedResponse      any
+	}{
+		{
+			name: "found",
+			code: "ORD-1001",
+			prepareProductService: func(mockService *mocks.Product) {
+				mockService.On("GetByBarcode", mock.Anything, "ORD-1001").Return(product, nil)
+			},
+			expectedHTTPStatus: http.StatusOK,
+			expectedResponse: createProductResponse{
+				ID:          product.ID.String(),
+				DateTime:    "2025-04-01T09:00:00Z",
+				Type:        entity.ProductTypeShoes,
+				Barcode:     "ORD-1001",
+				ReceptionID: product.ReceptionID.String(),
+				AddedBy:     product.AddedBy.String(),
+			},
+		},
+		{
+			name: "invalid barcode",
+			code: "x",
+			prepareProductService: func(mockService *mocks.Product) {
+				mockService.On("GetByBarcode", mock.Anything, "x").Return(nil, service.ErrInvalidBarcode)
+			},
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse:   httpresponse.ErrorResponse{Error: "invalid barcode"},
+		},
+		{
+			name: "not found",
+			code: "ORD-1002",
+			prepareProductService: func(mockService *mocks.Product) {
+				mockService.On("GetByBarcode", mock.Anything, "ORD-1002").Return(nil, service.ErrProductNotFound)
+			},
+			expectedHTTPStatus: http.StatusNotFound,
+			expectedResponse:   httpresponse.ErrorResponse{Error: "product not found"},
+		},
+		{
+			name: "internal server error",
+			code: "ORD-1003",
+			prepareProductService: func(mockService *mocks.Product) {
+				mockService.On("GetByBarcode", mock.Anything, "ORD-1003").Return(nil, errors.New("database error"))
+			},
+			expectedHTTPStatus: http.StatusInternalServerError,
+			expectedResponse:   httpresponse.ErrorResponse{Error: "internal server error"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			productService := mocks.NewProduct(t)
+			tc.prepareProductService(productService)
+
+			handler := newProductHandler(productService)
+
+			r := chi.NewRouter()
+			r.Get("/products/by-barcode/{code}", handler.getProductByBarcode)
+			req := httptest.NewRequest("GET", "/products/by-barcode/"+tc.code, nil)
+			rec := httptest.NewRecorder()
+
+			r.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedHTTPStatus, rec.Code)
+			if tc.expectedHTTPStatus == http.StatusOK {
+				var actualResponse createProductResponse
+				if err := json.NewDecoder(rec.Body).Decode(&actualResponse); err != nil {
+					t.Fatalf("failed to decode response body: %v", err)
+				}
+				assert.Equal(t, tc.expectedResponse, actualResponse)
+				return
+			}
+			var actualResponse httpresponse.ErrorResponse
+			if err := json.NewDecoder(rec.Body).Decode(&actualResponse); err != nil {
+				t.Fatalf("failed to decode response body: %v", err)
+			}
+			assert.Equal(t, tc.expectedResponse, actualResponse)
+		})
+	}
+}
+
 func TestDeleteProduct(t *testing.T) {
+	userID := uuid.New()
+	pvzID := uuid.New()
+	dockID := uuid.New()
+	claims := &entity.UserClaims{UserID: userID, Role: entity.RoleEmployee}
+
 	testCases := []struct {
 		name                  string
 		pvzID                 string
+		query                 string
 		prepareProductService func(mockService *mocks.Product)
 		expectedHTTPStatus    int
 		expectedResponse      any
@@ -149,7 +433,19 @@ func TestDeleteProduct(t *testing.T) {
 			name:  "successful deletion",
 			pvzID: uuid.New().String(),
 			prepareProductService: func(mockService *mocks.Product) {
-				mockService.On("DeleteLastProduct", mock.Anything, mock.AnythingOfType("string")).
+				mockService.On("DeleteLastProduct", mock.Anything, mock.AnythingOfType("entity.ReceptionTarget"), userID).
+					Return(nil)
+			},
+			expectedHTTPStatus: http.StatusOK,
+			expectedResponse:   deleteProductResponse{Message: "successfully delete"},
+		},
+		{
+			name:  "deletion from dock",
+			pvzID: pvzID.String(),
+			query: "?dockId=" + dockID.String(),
+			prepareProductService: func(mockService *mocks.Product) {
+				mockService.On("DeleteLastProduct", mock.Anything,
+					entity.ReceptionTarget{PVZID: pvzID.String(), DockID: dockID.String()}, userID).
 					Return(nil)
 			},
 			expectedHTTPStatus: http.StatusOK,
@@ -162,11 +458,31 @@ func TestDeleteProduct(t *testing.T) {
 			expectedHTTPStatus:    http.StatusBadRequest,
 			expectedResponse:      httpresponse.ErrorResponse{Error: "invalid pvz id"},
 		},
+		{
+			name:                  "invalid dock id",
+			pvzID:                 pvzID.String(),
+			query:                 "?dockId=not-a-uuid",
+			prepareProductService: func(mockService *mocks.Product) {},
+			expectedHTTPStatus:    http.StatusBadRequest,
+			expectedResponse:      httpresponse.ErrorResponse{Error: "invalid dock id"},
+		},
+		{
+			name:  "several open receptions",
+			pvzID: pvzID.String(),
+			prepareProductService: func(mockService *mocks.Product) {
+				mockService.On("DeleteLastProduct", mock.Anything, mock.AnythingOfType("entity.ReceptionTarget"), userID).
+					Return(service.ErrDockRequired)
+			},
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse: httpresponse.ErrorResponse{
+				Error: "several open receptions, dock or reception id is required",
+			},
+		},
 		{
 			name:  "no open reception",
 			pvzID: uuid.New().String(),
 			prepareProductService: func(mockService *mocks.Product) {
-				mockService.On("DeleteLastProduct", mock.Anything, mock.AnythingOfType("string")).
+				mockService.On("DeleteLastProduct", mock.Anything, mock.AnythingOfType("entity.ReceptionTarget"), userID).
 					Return(service.ErrNoOpenReception)
 			},
 			expectedHTTPStatus: http.StatusBadRequest,
@@ -176,7 +492,7 @@ func TestDeleteProduct(t *testing.T) {
 			name:  "no products in reception",
 			pvzID: uuid.New().String(),
 			prepareProductService: func(mockService *mocks.Product) {
-				mockService.On("DeleteLastProduct", mock.Anything, mock.AnythingOfType("string")).
+				mockService.On("DeleteLastProduct", mock.Anything, mock.AnythingOfType("entity.ReceptionTarget"), userID).
 					Return(service.ErrNoProducts)
 			},
 			expectedHTTPStatus: http.StatusBadRequest,
@@ -186,7 +502,7 @@ func TestDeleteProduct(t *testing.T) {
 			name:  "internal server error",
 			pvzID: uuid.New().String(),
 			prepareProductService: func(mockService *mocks.Product) {
-				mockService.On("DeleteLastProduct", mock.Anything, mock.AnythingOfType("string")).
+				mockService.On("DeleteLastProduct", mock.Anything, mock.AnythingOfType("entity.ReceptionTarget"), userID).
 					Return(errors.New("database error"))
 			},
 			expectedHTTPStatus: http.StatusInternalServerError,
@@ -203,7 +519,8 @@ func TestDeleteProduct(t *testing.T) {
 
 			r := chi.NewRouter()
 			r.Post("/pvz/{pvzId}/delete_last_product", handler.deleteProduct)
-			req := httptest.NewRequest("POST", "/pvz/"+tc.pvzID+"/delete_last_product", nil)
+			req := httptest.NewRequest("POST", "/pvz/"+tc.pvzID+"/delete_last_product"+tc.query, nil)
+			req = req.WithContext(context.WithValue(req.Context(), middleware.ClaimsContext, claims))
 			rec := httptest.NewRecorder()
 
 			r.ServeHTTP(rec, req)
@@ -228,3 +545,152 @@ func TestDeleteProduct(t *testing.T) {
 		})
 	}
 }
+
+func TestDeleteProductByID(t *testing.T) {
+	userID := uuid.New()
+	productID := uuid.New()
+	pvzID := uuid.New()
+	claims := &entity.UserClaims{UserID: userID, Role: entity.RoleEmployee}
+
+	testCases := []struct {
+		name                  string
+		productID             string
+		query                 string
+		prepareProductService func(mockService *mocks.Product)
+		expectedHTTPStatus    int
+		expectedResponse      any
+	}{
+		{
+			name:      "successful deletion",
+			productID: productID.String(),
+			query:     "?pvzId=" + pvzID.String(),
+			prepareProductService: func(mockService *mocks.Product) {
+				mockService.On("Delete", mock.Anything, productID.String(), pvzID.String(), userID).Return(nil)
+			},
+			expectedHTTPStatus: http.StatusOK,
+			expectedResponse:   deleteProductResponse{Message: "successfully delete"},
+		},
+		{
+			name:                  "invalid product id",
+			productID:             "not-a-uuid",
+			prepareProductService: func(mockService *mocks.Product) {},
+			expectedHTTPStatus:    http.StatusBadRequest,
+			expectedResponse:      httpresponse.ErrorResponse{Error: "invalid product id"},
+		},
+		{
+			name:                  "invalid pvz id",
+			productID:             productID.String(),
+			query:                 "?pvzId=not-a-uuid",
+			prepareProductService: func(mockService *mocks.Product) {},
+			expectedHTTPStatus:    http.StatusBadRequest,
+			expectedResponse:      httpresponse.ErrorResponse{Error: "invalid pvz id"},
+		},
+		{
+			name:                  "missing pvz id",
+			productID:             productID.String(),
+			prepareProductService: func(mockService *mocks.Product) {},
+			expectedHTTPStatus:    http.StatusBadRequest,
+			expectedResponse:      httpresponse.ErrorResponse{Error: "invalid pvz id"},
+		},
+		{
+			name:      "product of another pvz",
+			productID: productID.String(),
+			query:     "?pvzId=" + pvzID.String(),
+			prepareProductService: func(mockService *mocks.Product) {
+				mockService.On("Delete", mock.Anything, productID.String(), pvzID.String(), userID).
+					Return(service.ErrProductNotFound)
+			},
+			expectedHTTPStatus: http.StatusNotFound,
+			expectedResponse:   httpresponse.ErrorResponse{Error: "product not found"},
+		},
+		{
+			name:      "product not found",
+			productID: productID.String(),
+			query:     "?pvzId=" + pvzID.String(),
+			prepareProductService: func(mockService *mocks.Product) {
+				mockService.On("Delete", mock.Anything, productID.String(), pvzID.String(), userID).
+					Return(service.ErrProductNotFound)
+			},
+			expectedHTTPStatus: http.StatusNotFound,
+			expectedResponse:   httpresponse.ErrorResponse{Error: "product not found"},
+		},
+		{
+			name:      "reception closed",
+			productID: productID.String(),
+			query:     "?pvzId=" + pvzID.String(),
+			prepareProductService: func(mockService *mocks.Product) {
+				mockService.On("Delete", mock.Anything, productID.String(), pvzID.String(), userID).
+					Return(service.ErrInvalidReceptionStatus)
+			},
+			expectedHTTPStatus: http.StatusConflict,
+			expectedResponse:   httpresponse.ErrorResponse{Error: "reception is not open"},
+		},
+		{
+			name:      "product is not stored",
+			productID: productID.String(),
+			query:     "?pvzId=" + pvzID.String(),
+			prepareProductService: func(mockService *mocks.Product) {
+				mockService.On("Delete", mock.Anything, productID.String(), pvzID.String(), userID).
+					Return(service.ErrProductNotStored)
+			},
+			expectedHTTPStatus: http.StatusConflict,
+			expectedResponse:   httpresponse.ErrorResponse{Error: "product is not stored"},
+		},
+		{
+			name:      "internal server error",
+			productID: productID.String(),
+			query:     "?pvzId=" + pvzID.String(),
+			prepareProductService: func(mockService *mocks.Product) {
+				mockService.On("Delete", mock.Anything, productID.String(), pvzID.String(), userID).
+					Return(errors.New("database error"))
+			},
+			expectedHTTPStatus: http.StatusInternalServerError,
+			expectedResponse:   httpresponse.ErrorResponse{Error: "internal server error"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			productService := mocks.NewProduct(t)
+			tc.prepareProductService(productService)
+
+			handler := newProductHandler(productService)
+
+			r := chi.NewRouter()
+			r.Delete("/products/{productId}", handler.deleteProductByID)
+			req := httptest.NewRequest("DELETE", "/products/"+tc.productID+tc.query, nil)
+			req = req.WithContext(context.WithValue(req.Context(), middleware.ClaimsContext, claims))
+			rec := httptest.NewRecorder()
+
+			r.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedHTTPStatus, rec.Code)
+
+			if tc.expectedHTTPStatus == http.StatusOK {
+				var actualResponse deleteProductResponse
+				if err := json.NewDecoder(rec.Body).Decode(&actualResponse); err != nil {
+					t.Fatalf("failed to decode response body: %v", err)
+				}
+				assert.Equal(t, tc.expectedResponse, actualResponse)
+				return
+			}
+			var actualResponse httpresponse.ErrorResponse
+			if err := json.NewDecoder(rec.Body).Decode(&actualResponse); err != nil {
+				t.Fatalf("failed to decode response body: %v", err)
+			}
+			assert.Equal(t, tc.expectedResponse, actualResponse)
+		})
+	}
+}
+
+func TestNewCreateProductResponseWithoutAuthor(t *testing.T) {
+	product := &entity.Product{ID: uuid.New(), DateTime: time.Now(), Type: entity.ProductTypeShoes,
+		ReceptionID: uuid.New(), Status: entity.ProductStatusStored}
+
+	body, err := json.Marshal(newCreateProductResponse(product))
+	assert.NoError(t, err)
+
+	var fields map[string]any
+	assert.NoError(t, json.Unmarshal(body, &fields))
+	assert.NotContains(t, fields, "addedBy")
+}
@@ -0,0 +1,242 @@
+package v1
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/api/middleware"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/entity"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/service"
+	"github.com/GlebMoskalev/go-pickup-point-api/pkg/httpresponse"
+	"github.com/go-chi/chi/v5"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// @Description Тип товара из каталога
+type productTypeDTO struct {
+	// Название типа
+	Name string `json:"name" example:"обувь"`
+	// JSON-схема дополнительных атрибутов товара; поддерживаются type=object, properties
+	// (string, number, integer, boolean с enum, pattern, minLength, maxLength, minimum, maximum),
+	// required и additionalProperties
+	AttributesSchema json.RawMessage `json:"attributesSchema,omitempty" swaggertype:"object"`
+	// Срок хранения товаров типа в днях, от 1 до 365; если не задан, действует срок ПВЗ
+	StorageDays int `json:"storageDays,omitempty" example:"14"`
+	// Время добавления типа
+	// format: date-time
+	CreatedAt string `json:"createdAt,omitempty"`
+	// Кто добавил тип
+	// format: uuid
+	CreatedBy string `json:"createdBy,omitempty"`
+}
+
+// @Description Запрос на изменение схемы атрибутов типа товара
+type productTypeSchemaRequest struct {
+	// Новая JSON-схема атрибутов; null удаляет схему
+	AttributesSchema json.RawMessage `json:"attributesSchema" swaggertype:"object"`
+}
+
+// @Description Запрос на изменение срока хранения товаров типа
+type productTypeStoragePeriodRequest struct {
+	// Срок хранения в днях, от 1 до 365; 0 сбрасывает срок типа
+	StorageDays int `json:"storageDays" example:"14"`
+}
+
+func newProductTypeDTO(productType entity.ProductType) productTypeDTO {
+	return productTypeDTO{
+		Name:             productType.Name,
+		AttributesSchema: productType.AttributesSchema,
+		StorageDays:      productType.StorageDays,
+		CreatedAt:        productType.CreatedAt.Format(time.RFC3339),
+		CreatedBy:        uuidString(productType.CreatedBy),
+	}
+}
+
+func SetupProductTypeRoutes(r chi.Router, productTypeService service.ProductType) {
+	handler := newProductTypeHandler(productTypeService)
+
+	r.With(middleware.RoleMiddleware(entity.RoleEmployee, entity.RoleModerator)).
+		Get("/", handler.listProductTypes)
+
+	r.With(middleware.RoleMiddleware(entity.RoleModerator)).
+		Post("/", handler.createProductType)
+
+	r.With(middleware.RoleMiddleware(entity.RoleModerator)).
+		Put("/{name}/schema", handler.setProductTypeSchema)
+
+	r.With(middleware.RoleMiddleware(entity.RoleModerator)).
+		Put("/{name}/storage-period", handler.setProductTypeStoragePeriod)
+}
+
+type productTypeHandler struct {
+	productTypeService service.ProductType
+}
+
+func newProductTypeHandler(productTypeService service.ProductType) *productTypeHandler {
+	return &productTypeHandler{productTypeService: productTypeService}
+}
+
+// @Summary Каталог типов товаров
+// @Description Доступно для сотрудников и модераторов. Возвращает типы товаров, которые можно принимать, со схемами их атрибутов.
+// @Tags product-types
+// @Produce json
+// @Success 200 {array} productTypeDTO
+// @Failure 401 {object} httpresponse.ErrorResponse "Пользователь не авторизован"
+// @Failure 403 {object} httpresponse.ErrorResponse "Доступ запрещён"
+// @Failure 500 {object} httpresponse.ErrorResponse "Внутренняя ошибка сервера"
+// @Security JWT
+// @Router /api/v1/product-types [get]
+func (h *productTypeHandler) listProductTypes(w http.ResponseWriter, r *http.Request) {
+	productTypes, err := h.productTypeService.List(r.Context())
+	if err != nil {
+		httpresponse.Error(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	resp := make([]productTypeDTO, len(productTypes))
+	for i, productType := range productTypes {
+		resp[i] = newProductTypeDTO(productType)
+	}
+	httpresponse.JSON(w, http.StatusOK, resp)
+}
+
+// @Summary Добавление типа товара
+// @Description Только для модераторов. Добавляет тип в каталог; название приводится к нижнему регистру. Схема атрибутов необязательна: без неё товары этого типа принимаются без атрибутов. Срок хранения тоже необязателен.
+// @Tags product-types
+// @Accept json
+// @Produce json
+// @Param input body productTypeDTO true "Название и схема атрибутов"
+// @Success 201 {object} productTypeDTO
+// @Failure 400 {object} httpresponse.ErrorResponse "Неверное название, схема атрибутов или срок хранения"
+// @Failure 401 {object} httpresponse.ErrorResponse "Пользователь не авторизован"
+// @Failure 403 {object} httpresponse.ErrorResponse "Доступ запрещён: требуется роль модератора"
+// @Failure 409 {object} httpresponse.ErrorResponse "Тип уже есть в каталоге"
+// @Failure 500 {object} httpresponse.ErrorResponse "Внутренняя ошибка сервера"
+// @Security JWT
+// @Router /api/v1/product-types [post]
+func (h *productTypeHandler) createProductType(w http.ResponseWriter, r *http.Request) {
+	var req productTypeDTO
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	claims, ok := middleware.UserClaimsFromContext(r.Context())
+	if !ok {
+		httpresponse.Error(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	productType, err := h.productTypeService.Create(r.Context(), entity.ProductType{
+		Name:             req.Name,
+		AttributesSchema: req.AttributesSchema,
+		StorageDays:      req.StorageDays,
+		CreatedBy:        claims.UserID,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidProductTypeName):
+			httpresponse.Error(w, http.StatusBadRequest, "invalid product type name")
+		case errors.Is(err, service.ErrInvalidAttributeSchema):
+			httpresponse.Error(w, http.StatusBadRequest, "invalid attributes schema")
+		case errors.Is(err, service.ErrInvalidStoragePeriod):
+			httpresponse.Error(w, http.StatusBadRequest, "invalid storage period")
+		case errors.Is(err, service.ErrProductTypeExists):
+			httpresponse.Error(w, http.StatusConflict, "product type already exists")
+		default:
+			httpresponse.Error(w, http.StatusInternalServerError, "internal server error")
+		}
+		return
+	}
+
+	httpresponse.JSON(w, http.StatusCreated, newProductTypeDTO(*productType))
+}
+
+// @Summary Изменение схемы атрибутов типа товара
+// @Description Только для модераторов. Заменяет схему атрибутов; уже принятые товары не перепроверяются.
+// @Tags product-types
+// @Accept json
+// @Produce json
+// @Param name path string true "Название типа"
+// @Param input body productTypeSchemaRequest true "Новая схема атрибутов"
+// @Success 200 {object} productTypeDTO
+// @Failure 400 {object} httpresponse.ErrorResponse "Неверная схема атрибутов"
+// @Failure 401 {object} httpresponse.ErrorResponse "Пользователь не авторизован"
+// @Failure 403 {object} httpresponse.ErrorResponse "Доступ запрещён: требуется роль модератора"
+// @Failure 404 {object} httpresponse.ErrorResponse "Тип не найден"
+// @Failure 500 {object} httpresponse.ErrorResponse "Внутренняя ошибка сервера"
+// @Security JWT
+// @Router /api/v1/product-types/{name}/schema [put]
+func (h *productTypeHandler) setProductTypeSchema(w http.ResponseWriter, r *http.Request) {
+	var req productTypeSchemaRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	name, err := url.PathUnescape(chi.URLParam(r, "name"))
+	if err != nil {
+		httpresponse.Error(w, http.StatusNotFound, "product type not found")
+		return
+	}
+
+	productType, err := h.productTypeService.SetSchema(r.Context(), name, req.AttributesSchema)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidAttributeSchema):
+			httpresponse.Error(w, http.StatusBadRequest, "invalid attributes schema")
+		case errors.Is(err, service.ErrProductTypeNotFound):
+			httpresponse.Error(w, http.StatusNotFound, "product type not found")
+		default:
+			httpresponse.Error(w, http.StatusInternalServerError, "internal server error")
+		}
+		return
+	}
+
+	httpresponse.JSON(w, http.StatusOK, newProductTypeDTO(*productType))
+}
+
+// @Summary Изменение срока хранения товаров типа
+// @Description Только для модераторов. Задаёт срок хранения товаров типа; 0 сбрасывает его, и тогда действует срок ПВЗ или срок по умолчанию. Товары, уже попавшие в очередь на возврат, остаются в ней.
+// @Tags product-types
+// @Accept json
+// @Produce json
+// @Param name path string true "Название типа"
+// @Param input body productTypeStoragePeriodRequest true "Срок хранения"
+// @Success 200 {object} productTypeDTO
+// @Failure 400 {object} httpresponse.ErrorResponse "Неверный срок хранения"
+// @Failure 401 {object} httpresponse.ErrorResponse "Пользователь не авторизован"
+// @Failure 403 {object} httpresponse.ErrorResponse "Доступ запрещён: требуется роль модератора"
+// @Failure 404 {object} httpresponse.ErrorResponse "Тип не найден"
+// @Failure 500 {object} httpresponse.ErrorResponse "Внутренняя ошибка сервера"
+// @Security JWT
+// @Router /api/v1/product-types/{name}/storage-period [put]
+func (h *productTypeHandler) setProductTypeStoragePeriod(w http.ResponseWriter, r *http.Request) {
+	var req productTypeStoragePeriodRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	name, err := url.PathUnescape(chi.URLParam(r, "name"))
+	if err != nil {
+		httpresponse.Error(w, http.StatusNotFound, "product type not found")
+		return
+	}
+
+	productType, err := h.productTypeService.SetStorageDays(r.Context(), name, req.StorageDays)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidStoragePeriod):
+			httpresponse.Error(w, http.StatusBadRequest, "invalid storage period")
+		case errors.Is(err, service.ErrProductTypeNotFound):
+			httpresponse.Error(w, http.StatusNotFound, "product type not found")
+		default:
+			httpresponse.Error(w, http.StatusInternalServerError, "internal server error")
+		}
+		return
+	}
+
+	httpresponse.JSON(w, http.StatusOK, newProductTypeDTO(*productType))
+}
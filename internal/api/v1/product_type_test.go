@@ -0,0 +1,207 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/api/middleware"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/entity"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/service"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/service/mocks"
+	"github.com/GlebMoskalev/go-pickup-point-api/pkg/httpresponse"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestProductTypes(t *testing.T) {
+	moderatorID := uuid.New()
+	createdAt := time.Date(2025, 4, 1, 9, 0, 0, 0, time.UTC)
+	schema := json.RawMessage(`{"type":"object","properties":{"size":{"type":"number"}}}`)
+	shoes := entity.ProductType{Name: entity.ProductTypeShoes, AttributesSchema: schema, CreatedAt: createdAt}
+	groceries := entity.ProductType{Name: "продукты", CreatedAt: createdAt, CreatedBy: moderatorID}
+
+	testCases := []struct {
+		name                      string
+		method                    string
+		target                    string
+		request                   any
+		prepareProductTypeService func(mockService *mocks.ProductType)
+		expectedHTTPStatus        int
+		expectedResponse          any
+	}{
+		{
+			name:   "list catalog",
+			method: http.MethodGet,
+			target: "/product-types",
+			prepareProductTypeService: func(mockService *mocks.ProductType) {
+				mockService.On("List", mock.Anything).Return([]entity.ProductType{groceries, shoes}, nil)
+			},
+			expectedHTTPStatus: http.StatusOK,
+			expectedResponse: []productTypeDTO{
+				{Name: "продукты", CreatedAt: "2025-04-01T09:00:00Z", CreatedBy: moderatorID.String()},
+				{Name: entity.ProductTypeShoes, AttributesSchema: schema, CreatedAt: "2025-04-01T09:00:00Z"},
+			},
+		},
+		{
+			name:   "list error",
+			method: http.MethodGet,
+			target: "/product-types",
+			prepareProductTypeService: func(mockService *mocks.ProductType) {
+				mockService.On("List", mock.Anything).Return(nil, errors.New("database error"))
+			},
+			expectedHTTPStatus: http.StatusInternalServerError,
+			expectedResponse:   httpresponse.ErrorResponse{Error: "internal server error"},
+		},
+		{
+			name:    "create type",
+			method:  http.MethodPost,
+			target:  "/product-types",
+			request: productTypeDTO{Name: "продукты"},
+			prepareProductTypeService: func(mockService *mocks.ProductType) {
+				mockService.On("Create", mock.Anything, entity.ProductType{Name: "продукты", CreatedBy: moderatorID}).
+					Return(&groceries, nil)
+			},
+			expectedHTTPStatus: http.StatusCreated,
+			expectedResponse: productTypeDTO{Name: "продукты", CreatedAt: "2025-04-01T09:00:00Z",
+				CreatedBy: moderatorID.String()},
+		},
+		{
+			name:    "create with invalid schema",
+			method:  http.MethodPost,
+			target:  "/product-types",
+			request: productTypeDTO{Name: "продукты", AttributesSchema: json.RawMessage(`{"type":"array"}`)},
+			prepareProductTypeService: func(mockService *mocks.ProductType) {
+				mockService.On("Create", mock.Anything, mock.AnythingOfType("entity.ProductType")).
+					Return(nil, service.ErrInvalidAttributeSchema)
+			},
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse:   httpresponse.ErrorResponse{Error: "invalid attributes schema"},
+		},
+		{
+			name:    "create existing type",
+			method:  http.MethodPost,
+			target:  "/product-types",
+			request: productTypeDTO{Name: entity.ProductTypeShoes},
+			prepareProductTypeService: func(mockService *mocks.ProductType) {
+				mockService.On("Create", mock.Anything, mock.AnythingOfType("entity.ProductType")).
+					Return(nil, service.ErrProductTypeExists)
+			},
+			expectedHTTPStatus: http.StatusConflict,
+			expectedResponse:   httpresponse.ErrorResponse{Error: "product type already exists"},
+		},
+		{
+			name:                      "create with invalid body",
+			method:                    http.MethodPost,
+			target:                    "/product-types",
+			request:                   "invalid json",
+			prepareProductTypeService: func(mockService *mocks.ProductType) {},
+			expectedHTTPStatus:        http.StatusBadRequest,
+			expectedResponse:          httpresponse.ErrorResponse{Error: "invalid request body"},
+		},
+		{
+			name:    "set schema",
+			method:  http.MethodPut,
+			target:  "/product-types/" + url.PathEscape(entity.ProductTypeShoes) + "/schema",
+			request: productTypeSchemaRequest{AttributesSchema: schema},
+			prepareProductTypeService: func(mockService *mocks.ProductType) {
+				mockService.On("SetSchema", mock.Anything, entity.ProductTypeShoes, schema).Return(&shoes, nil)
+			},
+			expectedHTTPStatus: http.StatusOK,
+			expectedResponse: productTypeDTO{Name: entity.ProductTypeShoes, AttributesSchema: schema,
+				CreatedAt: "2025-04-01T09:00:00Z"},
+		},
+		{
+			name:    "set schema of unknown type",
+			method:  http.MethodPut,
+			target:  "/product-types/unknown/schema",
+			request: productTypeSchemaRequest{AttributesSchema: schema},
+			prepareProductTypeService: func(mockService *mocks.ProductType) {
+				mockService.On("SetSchema", mock.Anything, "unknown", schema).Return(nil, service.ErrProductTypeNotFound)
+			},
+			expectedHTTPStatus: http.StatusNotFound,
+			expectedResponse:   httpresponse.ErrorResponse{Error: "product type not found"},
+		},
+		{
+			name:    "set storage period",
+			method:  http.MethodPut,
+			target:  "/product-types/" + url.PathEscape(entity.ProductTypeShoes) + "/storage-period",
+			request: productTypeStoragePeriodRequest{StorageDays: 14},
+			prepareProductTypeService: func(mockService *mocks.ProductType) {
+				withPeriod := shoes
+				withPeriod.StorageDays = 14
+				mockService.On("SetStorageDays", mock.Anything, entity.ProductTypeShoes, 14).Return(&withPeriod, nil)
+			},
+			expectedHTTPStatus: http.StatusOK,
+			expectedResponse: productTypeDTO{Name: entity.ProductTypeShoes, AttributesSchema: schema, StorageDays: 14,
+				CreatedAt: "2025-04-01T09:00:00Z"},
+		},
+		{
+			name:    "invalid storage period",
+			method:  http.MethodPut,
+			target:  "/product-types/" + url.PathEscape(entity.ProductTypeShoes) + "/storage-period",
+			request: productTypeStoragePeriodRequest{StorageDays: 1000},
+			prepareProductTypeService: func(mockService *mocks.ProductType) {
+				mockService.On("SetStorageDays", mock.Anything, entity.ProductTypeShoes, 1000).
+					Return(nil, service.ErrInvalidStoragePeriod)
+			},
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse:   httpresponse.ErrorResponse{Error: "invalid storage period"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			productTypeService := mocks.NewProductType(t)
+			tc.prepareProductTypeService(productTypeService)
+
+			handler := newProductTypeHandler(productTypeService)
+
+			reqBody, err := json.Marshal(tc.request)
+			if err != nil {
+				t.Fatalf("failed to marshal request: %v", err)
+			}
+			r := chi.NewRouter()
+			r.Get("/product-types", handler.listProductTypes)
+			r.Post("/product-types", handler.createProductType)
+			r.Put("/product-types/{name}/schema", handler.setProductTypeSchema)
+			r.Put("/product-types/{name}/storage-period", handler.setProductTypeStoragePeriod)
+			req := httptest.NewRequest(tc.method, tc.target, bytes.NewReader(reqBody))
+			req = req.WithContext(context.WithValue(req.Context(), middleware.ClaimsContext,
+				&entity.UserClaims{UserID: moderatorID, Role: entity.RoleModerator}))
+			rec := httptest.NewRecorder()
+
+			r.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedHTTPStatus, rec.Code)
+
+			switch expected := tc.expectedResponse.(type) {
+			case []productTypeDTO:
+				var actualResponse []productTypeDTO
+				if err := json.NewDecoder(rec.Body).Decode(&actualResponse); err != nil {
+					t.Fatalf("failed to decode response body: %v", err)
+				}
+				assert.Equal(t, expected, actualResponse)
+			case productTypeDTO:
+				var actualResponse productTypeDTO
+				if err := json.NewDecoder(rec.Body).Decode(&actualResponse); err != nil {
+					t.Fatalf("failed to decode response body: %v", err)
+				}
+				assert.Equal(t, expected, actualResponse)
+			default:
+				var actualResponse httpresponse.ErrorResponse
+				if err := json.NewDecoder(rec.Body).Decode(&actualResponse); err != nil {
+					t.Fatalf("failed to decode response body: %v", err)
+				}
+				assert.Equal(t, tc.expectedResponse, actualResponse)
+			}
+		})
+	}
+}
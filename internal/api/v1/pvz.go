@@ -19,6 +19,8 @@ type createPVZRequest struct {
 	// Город
 	// enum: Москва, Санкт-Петербург, Казань
 	City string `json:"city"`
+	// Часовой пояс ПВЗ в формате IANA (по умолчанию Europe/Moscow)
+	Timezone string `json:"timezone,omitempty" example:"Europe/Moscow"`
 }
 
 // @Description Ответ с данными о созданном ПВЗ
@@ -32,6 +34,8 @@ type createPVZResponse struct {
 	// Город
 	// enum: Москва, Санкт-Петербург, Казань
 	City string `json:"city"`
+	// Часовой пояс ПВЗ в формате IANA
+	Timezone string `json:"timezone"`
 }
 
 // @Description Ответ с данными о ПВЗ, включая приёмки и товары
@@ -50,6 +54,8 @@ type pvzWithDetails struct {
 	// Город
 	// enum: Москва, Санкт-Петербург, Казань
 	City string `json:"city"`
+	// Часовой пояс ПВЗ в формате IANA
+	Timezone string `json:"timezone"`
 	// Список приёмок
 	Receptions []receptionDetails `json:"receptions"`
 }
@@ -65,11 +71,60 @@ type receptionDetails struct {
 	// Идентификатор ПВЗ, к которому относится приёмка
 	// format: uuid
 	PVZID uuid.UUID `json:"pvz_id"`
+	// Идентификатор дока, в котором велась приёмка
+	// format: uuid
+	DockID string `json:"dockId,omitempty"`
+	// Вид приёмки
+	// enum: delivery, return, transfer
+	Kind string `json:"kind"`
 	// Статус приёмки
-	// enum: in_progress, closed
+	// enum: draft, in_progress, close, verified, cancelled
 	Status string `json:"status"`
 	// Список товаров в приёмке
 	Products []productDetails `json:"products"`
+	// Удалённые из приёмки товары
+	DeletedProducts []productDetails `json:"deletedProducts,omitempty"`
+	// Объявленная ценность товаров приёмки по валютам
+	DeclaredValue []valueTotalDTO `json:"declaredValue,omitempty"`
+	// Идентификатор пользователя, открывшего приёмку
+	// format: uuid
+	OpenedBy string `json:"openedBy,omitempty"`
+	// Идентификатор пользователя, закрывшего приёмку; пусто, если приёмка закрыта автоматически
+	// format: uuid
+	ClosedBy string `json:"closedBy,omitempty"`
+	// Время закрытия приёмки
+	// format: date-time
+	ClosedAt string `json:"closedAt,omitempty"`
+	deliveryDTO
+	// Ожидаемый состав поставки
+	Manifest []manifestItemDTO `json:"manifest,omitempty"`
+	// Отчёт о расхождениях, формируется при закрытии приёмки с манифестом
+	DiscrepancyReport *discrepancyReportDTO `json:"discrepancyReport,omitempty"`
+	// Результат «слепого» пересчёта при закрытии
+	BlindCount *blindCountDTO `json:"blindCount,omitempty"`
+	// История смен статуса
+	StatusHistory []statusChangeDTO `json:"statusHistory,omitempty"`
+	// Когда приёмка помечена как зависшая (открыта дольше допустимого)
+	// format: date-time
+	StaleFlaggedAt string `json:"staleFlaggedAt,omitempty"`
+	// Причина пометки
+	StaleReason string `json:"staleReason,omitempty"`
+}
+
+// @Description Запись истории смены статуса приёмки
+type statusChangeDTO struct {
+	// Предыдущий статус
+	From string `json:"from"`
+	// Новый статус
+	To string `json:"to"`
+	// Причина
+	Reason string `json:"reason"`
+	// Идентификатор пользователя, сменившего статус
+	// format: uuid
+	ChangedBy string `json:"changedBy,omitempty"`
+	// Время смены статуса
+	// format: date-time
+	ChangedAt string `json:"changedAt"`
 }
 
 // @Description Детали товара
@@ -80,12 +135,39 @@ type productDetails struct {
 	// Дата и время добавления товара
 	// format: date-time
 	DateTime string `json:"date_time"`
-	// Тип товара
-	// enum: электроника, одежда, продукты
+	// Тип товара из каталога
 	Type string `json:"type"`
+	// Штрихкод товара
+	Barcode string `json:"barcode,omitempty"`
+	// Дополнительные атрибуты товара по схеме его типа
+	Attributes json.RawMessage `json:"attributes,omitempty" swaggertype:"object"`
 	// Идентификатор приёмки, к которой относится товар
 	// format: uuid
 	ReceptionID uuid.UUID `json:"reception_id"`
+	// Порядковый номер товара в приёмке, начиная с 1
+	Position int `json:"position" example:"1"`
+	// Статус товара: stored, in_transit, transferred, issued или returned_to_sender
+	Status string `json:"status,omitempty" example:"stored"`
+	// Ячейка хранения товара
+	Cell *productCellResponse `json:"cell,omitempty"`
+	// Причина возврата товара покупателем
+	ReturnReason string `json:"returnReason,omitempty"`
+	// Идентификатор выданного ранее товара, к которому относится возврат
+	// format: uuid
+	OriginalProductID string `json:"originalProductId,omitempty"`
+	// Объявленная ценность в минимальных единицах валюты
+	DeclaredValue int64 `json:"declaredValue,omitempty"`
+	// Валюта объявленной ценности, код ISO 4217
+	Currency string `json:"currency,omitempty"`
+	// Идентификатор пользователя, добавившего товар
+	// format: uuid
+	AddedBy string `json:"addedBy,omitempty"`
+	// Идентификатор пользователя, удалившего товар
+	// format: uuid
+	DeletedBy string `json:"deletedBy,omitempty"`
+	// Время удаления товара
+	// format: date-time
+	DeletedAt string `json:"deletedAt,omitempty"`
 }
 
 func SetupPVZRoutes(r chi.Router, pvzService service.PVZ, productService service.Product, receptionService service.Reception) {
@@ -104,6 +186,39 @@ func SetupPVZRoutes(r chi.Router, pvzService service.PVZ, productService service
 
 	r.With(middleware.RoleMiddleware(entity.RoleEmployee, entity.RoleModerator)).
 		Get("/", pvzHandler.listPVZWithDetails)
+
+	r.With(middleware.RoleMiddleware(entity.RoleEmployee, entity.RoleModerator)).
+		Get("/{pvzId}/schedule", pvzHandler.getSchedule)
+
+	r.With(middleware.RoleMiddleware(entity.RoleModerator)).
+		Put("/{pvzId}/schedule", pvzHandler.setSchedule)
+
+	r.With(middleware.RoleMiddleware(entity.RoleEmployee, entity.RoleModerator)).
+		Get("/{pvzId}/settings", pvzHandler.getSettings)
+
+	r.With(middleware.RoleMiddleware(entity.RoleModerator)).
+		Put("/{pvzId}/settings", pvzHandler.setSettings)
+
+	r.With(middleware.RoleMiddleware(entity.RoleModerator)).
+		Post("/{pvzId}/docks", pvzHandler.createDock)
+
+	r.With(middleware.RoleMiddleware(entity.RoleEmployee, entity.RoleModerator)).
+		Get("/{pvzId}/docks", pvzHandler.listDocks)
+
+	r.With(middleware.RoleMiddleware(entity.RoleEmployee, entity.RoleModerator)).
+		Get("/{pvzId}/stock", pvzHandler.getStock)
+
+	r.With(middleware.RoleMiddleware(entity.RoleEmployee)).
+		Post("/{pvzId}/pickup/{code}", productHandler.getProductByPickupCode)
+
+	r.With(middleware.RoleMiddleware(entity.RoleModerator)).
+		Post("/{pvzId}/cells", pvzHandler.createCell)
+
+	r.With(middleware.RoleMiddleware(entity.RoleEmployee, entity.RoleModerator)).
+		Get("/{pvzId}/cells", pvzHandler.listCells)
+
+	r.With(middleware.RoleMiddleware(entity.RoleEmployee, entity.RoleModerator)).
+		Get("/{pvzId}/overdue", productHandler.listOverdue)
 }
 
 type pvzHandler struct {
@@ -115,13 +230,13 @@ func newPVZHandler(pvzService service.PVZ) *pvzHandler {
 }
 
 // @Summary Создание ПВЗ
-// @Description Только для модераторов. Создаёт пункт выдачи заказов (ПВЗ) в одном из поддерживаемых городов: Москва, Санкт-Петербург, Казань.
+// @Description Только для модераторов. Создаёт пункт выдачи заказов (ПВЗ) в одном из поддерживаемых городов: Москва, Санкт-Петербург, Казань. Часовой пояс задаётся в формате IANA.
 // @Tags pvz
 // @Accept json
 // @Produce json
 // @Param input body createPVZRequest true "Данные для создания ПВЗ"
 // @Success 201 {object} createPVZResponse "ПВЗ успешно создан"
-// @Failure 400 {object} httpresponse.ErrorResponse "Неверный город или некорректное тело запроса"
+// @Failure 400 {object} httpresponse.ErrorResponse "Неверный город, часовой пояс или некорректное тело запроса"
 // @Failure 401 {object} httpresponse.ErrorResponse "Пользователь не авторизован"
 // @Failure 403 {object} httpresponse.ErrorResponse "Доступ запрещён: требуется роль модератора"
 // @Failure 500 {object} httpresponse.ErrorResponse "Внутренняя ошибка сервера"
@@ -135,11 +250,13 @@ func (h *pvzHandler) createPVZ(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	pvz, err := h.pvzService.Create(r.Context(), req.City)
+	pvz, err := h.pvzService.Create(r.Context(), req.City, req.Timezone)
 	if err != nil {
 		switch {
 		case errors.Is(err, service.ErrInvalidCity):
 			httpresponse.Error(w, http.StatusBadRequest, "invalid city")
+		case errors.Is(err, service.ErrInvalidTimezone):
+			httpresponse.Error(w, http.StatusBadRequest, "invalid timezone")
 		default:
 			httpresponse.Error(w, http.StatusInternalServerError, "internal server error")
 		}
@@ -150,17 +267,21 @@ func (h *pvzHandler) createPVZ(w http.ResponseWriter, r *http.Request) {
 		ID:               pvz.ID.String(),
 		RegistrationDate: pvz.RegistrationDate.Format(time.RFC3339),
 		City:             pvz.City,
+		Timezone:         pvz.Timezone,
 	}
 	httpresponse.JSON(w, http.StatusCreated, resp)
 }
 
 // @Summary Получение списка ПВЗ с приёмками и товарами
-// @Description Доступно для сотрудников и модераторов. Возвращает список ПВЗ с информацией о приёмках и товарах, с поддержкой пагинации и фильтрации по датам приёмок.
+// @Description Доступно для сотрудников и модераторов. Возвращает список ПВЗ с информацией о приёмках и товарах, с поддержкой пагинации и фильтрации по датам приёмок и номеру накладной. По умолчанию возвращаются приёмки всех видов; параметр kind оставляет только поставки (delivery), возвраты (return) или приёмки перемещений (transfer).
 // @Tags pvz
 // @Accept json
 // @Produce json
 // @Param startDate query string false "Начальная дата приёмок (формат: RFC3339)" example "2025-04-01T00:00:00Z"
 // @Param endDate query string false "Конечная дата приёмок (формат: RFC3339)" example "2025-04-18T23:59:59Z"
+// @Param waybillNumber query string false "Номер накладной: возвращаются только приёмки по этой накладной"
+// @Param kind query string false "Вид приёмок: delivery, return или transfer (по умолчанию все)" Enums(delivery, return, transfer)
+// @Param localTime query bool false "Трактовать startDate и endDate как местное время каждого ПВЗ (смещение в дате игнорируется, допускается формат 2006-01-02T15:04:05)"
 // @Param page query int false "Номер страницы (начинается с 1)" example 1
 // @Param limit query int false "Количество записей на страницу (1-30)" example 10
 // @Success 200 {object} listPVZWithDetailsResponse "Список ПВЗ с приёмками и товарами"
@@ -172,32 +293,50 @@ func (h *pvzHandler) createPVZ(w http.ResponseWriter, r *http.Request) {
 // @Router /api/v1/pvz [get]
 func (h *pvzHandler) listPVZWithDetails(w http.ResponseWriter, r *http.Request) {
 	var (
-		startDate *time.Time
-		endDate   *time.Time
-		page      int
-		limit     int
+		filter entity.PVZFilter
+		page   int
+		limit  int
 
 		err error
 	)
 
+	localTimeQuery := r.URL.Query().Get("localTime")
+	if localTimeQuery != "" {
+		filter.LocalTime, err = strconv.ParseBool(localTimeQuery)
+		if err != nil {
+			httpresponse.Error(w, http.StatusBadRequest, "invalid local time flag")
+			return
+		}
+	}
+
 	startDateQuery := r.URL.Query().Get("startDate")
 	if startDateQuery != "" {
-		date, err := time.Parse(time.RFC3339, startDateQuery)
+		date, err := parseDateQuery(startDateQuery, filter.LocalTime)
 		if err != nil {
 			httpresponse.Error(w, http.StatusBadRequest, "invalid start date")
 			return
 		}
-		startDate = &date
+		filter.StartDate = &date
 	}
 
 	endDateQuery := r.URL.Query().Get("endDate")
 	if endDateQuery != "" {
-		date, err := time.Parse(time.RFC3339, endDateQuery)
+		date, err := parseDateQuery(endDateQuery, filter.LocalTime)
 		if err != nil {
 			httpresponse.Error(w, http.StatusBadRequest, "invalid end date")
 			return
 		}
-		endDate = &date
+		filter.EndDate = &date
+	}
+
+	filter.WaybillNumber = r.URL.Query().Get("waybillNumber")
+
+	filter.Kind = r.URL.Query().Get("kind")
+	switch filter.Kind {
+	case "", entity.ReceptionKindDelivery, entity.ReceptionKindReturn, entity.ReceptionKindTransfer:
+	default:
+		httpresponse.Error(w, http.StatusBadRequest, "invalid reception kind")
+		return
 	}
 
 	pageQuery := r.URL.Query().Get("page")
@@ -218,7 +357,7 @@ func (h *pvzHandler) listPVZWithDetails(w http.ResponseWriter, r *http.Request)
 		}
 	}
 
-	pvzs, err := h.pvzService.ListWithDetails(r.Context(), startDate, endDate, page, limit)
+	pvzs, err := h.pvzService.ListWithDetails(r.Context(), filter, page, limit)
 	if err != nil {
 		httpresponse.Error(w, http.StatusBadRequest, "internal server")
 		return
@@ -230,30 +369,107 @@ func (h *pvzHandler) listPVZWithDetails(w http.ResponseWriter, r *http.Request)
 	for i, pvz := range pvzs {
 		receptions := make([]receptionDetails, len(pvz.Receptions))
 		for j, r := range pvz.Receptions {
-			products := make([]productDetails, len(r.Products))
-			for k, p := range r.Products {
-				products[k] = productDetails{
-					ID:          p.ID,
-					DateTime:    p.DateTime.Format(time.RFC3339),
-					Type:        p.Type,
-					ReceptionID: p.ReceptionID,
-				}
-			}
 			receptions[j] = receptionDetails{
-				ID:       r.Reception.ID,
-				DateTime: r.Reception.DateTime.Format(time.RFC3339),
-				PVZID:    r.Reception.PVZID,
-				Status:   r.Reception.Status,
-				Products: products,
+				ID:                r.Reception.ID,
+				DateTime:          r.Reception.DateTime.Format(time.RFC3339),
+				PVZID:             r.Reception.PVZID,
+				DockID:            uuidString(r.Reception.DockID),
+				Kind:              r.Reception.Kind,
+				Status:            r.Reception.Status,
+				Products:          newProductDetails(r.Products),
+				Manifest:          newManifestDTO(r.Reception.Manifest),
+				DiscrepancyReport: newDiscrepancyReportDTO(r.DiscrepancyReport),
+				BlindCount:        newBlindCountDTO(r.BlindCount),
+				StatusHistory:     newStatusHistoryDTO(r.StatusHistory),
+				StaleReason:       r.Reception.StaleReason,
+				OpenedBy:          uuidString(r.Reception.OpenedBy),
+				ClosedBy:          uuidString(r.Reception.ClosedBy),
+				deliveryDTO:       newDeliveryDTO(r.Reception.Delivery),
+			}
+			if totals := entity.SumDeclaredValues(r.Products); len(totals) > 0 {
+				receptions[j].DeclaredValue = newValueTotalsDTO(totals)
+			}
+			if len(r.DeletedProducts) > 0 {
+				receptions[j].DeletedProducts = newProductDetails(r.DeletedProducts)
+			}
+			if r.Reception.StaleFlaggedAt != nil {
+				receptions[j].StaleFlaggedAt = r.Reception.StaleFlaggedAt.Format(time.RFC3339)
+			}
+			if r.Reception.ClosedAt != nil {
+				receptions[j].ClosedAt = r.Reception.ClosedAt.Format(time.RFC3339)
 			}
 		}
 		resp.PVZs[i] = pvzWithDetails{
 			ID:               pvz.PVZ.ID,
 			RegistrationDate: pvz.PVZ.RegistrationDate.Format(time.RFC3339),
 			City:             pvz.PVZ.City,
+			Timezone:         pvz.PVZ.Timezone,
 			Receptions:       receptions,
 		}
 	}
 
 	httpresponse.JSON(w, http.StatusOK, resp)
 }
+
+// parseDateQuery разбирает дату фильтра. Для местного времени ПВЗ смещение
+// не несёт смысла, поэтому дополнительно принимается дата без зоны.
+func parseDateQuery(value string, localTime bool) (time.Time, error) {
+	date, err := time.Parse(time.RFC3339, value)
+	if err == nil || !localTime {
+		return date, err
+	}
+	return time.Parse("2006-01-02T15:04:05", value)
+}
+
+func newStatusHistoryDTO(history []entity.StatusChange) []statusChangeDTO {
+	if len(history) == 0 {
+		return nil
+	}
+	items := make([]statusChangeDTO, len(history))
+	for i, change := range history {
+		items[i] = statusChangeDTO{
+			From:      change.FromStatus,
+			To:        change.ToStatus,
+			Reason:    change.Reason,
+			ChangedBy: uuidString(change.ChangedBy),
+			ChangedAt: change.ChangedAt.Format(time.RFC3339),
+		}
+	}
+	return items
+}
+
+func newProductDetails(products []entity.Product) []productDetails {
+	items := make([]productDetails, len(products))
+	for i, p := range products {
+		items[i] = productDetails{
+			ID:                p.ID,
+			DateTime:          p.DateTime.Format(time.RFC3339),
+			Type:              p.Type,
+			Barcode:           p.Barcode,
+			Attributes:        p.Attributes,
+			ReceptionID:       p.ReceptionID,
+			Position:          p.OrderNumber,
+			Status:            p.Status,
+			Cell:              newProductCellResponse(&p),
+			AddedBy:           uuidString(p.AddedBy),
+			DeletedBy:         uuidString(p.DeletedBy),
+			ReturnReason:      p.ReturnReason,
+			OriginalProductID: uuidString(p.OriginalProductID),
+			DeclaredValue:     p.DeclaredValue,
+			Currency:          p.Currency,
+		}
+		if p.DeletedAt != nil {
+			items[i].DeletedAt = p.DeletedAt.Format(time.RFC3339)
+		}
+	}
+	return items
+}
+
+// uuidString возвращает пустую строку для uuid.Nil, чтобы поле
+// пропускалось в ответе, если автор операции неизвестен.
+func uuidString(id uuid.UUID) string {
+	if id == uuid.Nil {
+		return ""
+	}
+	return id.String()
+}
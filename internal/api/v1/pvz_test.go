@@ -30,7 +30,7 @@ func TestCreatePVZ(t *testing.T) {
 			request: createPVZRequest{City: "Москва"},
 			preparePVZService: func(mockService *mocks.PVZ) {
 				pvzID := uuid.New()
-				mockService.On("Create", mock.Anything, "Москва").
+				mockService.On("Create", mock.Anything, "Москва", "").
 					Return(&entity.PVZ{
 						ID:               pvzID,
 						RegistrationDate: time.Now(),
@@ -48,17 +48,27 @@ func TestCreatePVZ(t *testing.T) {
 			name:    "invalid city",
 			request: createPVZRequest{City: "НекорректныйГород"},
 			preparePVZService: func(mockService *mocks.PVZ) {
-				mockService.On("Create", mock.Anything, "НекорректныйГород").
+				mockService.On("Create", mock.Anything, "НекорректныйГород", "").
 					Return(nil, service.ErrInvalidCity)
 			},
 			expectedHTTPStatus: http.StatusBadRequest,
 			expectedResponse:   httpresponse.ErrorResponse{Error: "invalid city"},
 		},
+		{
+			name:    "invalid timezone",
+			request: createPVZRequest{City: "Казань", Timezone: "Europe/Nowhere"},
+			preparePVZService: func(mockService *mocks.PVZ) {
+				mockService.On("Create", mock.Anything, "Казань", "Europe/Nowhere").
+					Return(nil, service.ErrInvalidTimezone)
+			},
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse:   httpresponse.ErrorResponse{Error: "invalid timezone"},
+		},
 		{
 			name:    "internal server error",
 			request: createPVZRequest{City: "Москва"},
 			preparePVZService: func(mockService *mocks.PVZ) {
-				mockService.On("Create", mock.Anything, "Москва").
+				mockService.On("Create", mock.Anything, "Москва", "").
 					Return(nil, errors.New("database error"))
 			},
 			expectedHTTPStatus: http.StatusInternalServerError,
@@ -136,7 +146,8 @@ func TestListPVZWithDetails(t *testing.T) {
 				productID := uuid.New()
 				startDate, _ := time.Parse(time.RFC3339, "2025-04-01T00:00:00Z")
 				endDate, _ := time.Parse(time.RFC3339, "2025-04-18T23:59:59Z")
-				mockService.On("ListWithDetails", mock.Anything, &startDate, &endDate, 1, 10).
+				mockService.On("ListWithDetails", mock.Anything,
+					entity.PVZFilter{StartDate: &startDate, EndDate: &endDate}, 1, 10).
 					Return([]entity.PVZWithDetails{
 						{
 							PVZ: entity.PVZ{
@@ -210,6 +221,63 @@ func TestListPVZWithDetails(t *testing.T) {
 			expectedHTTPStatus: http.StatusBadRequest,
 			expectedResponse:   httpresponse.ErrorResponse{Error: "invalid end date"},
 		},
+		{
+			name: "local time filter without offset",
+			queryParams: map[string]string{
+				"startDate": "2025-04-01T00:00:00",
+				"endDate":   "2025-04-01T23:59:59",
+				"localTime": "true",
+			},
+			preparePVZService: func(mockService *mocks.PVZ) {
+				startDate := time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)
+				endDate := time.Date(2025, 4, 1, 23, 59, 59, 0, time.UTC)
+				mockService.On("ListWithDetails", mock.Anything,
+					entity.PVZFilter{StartDate: &startDate, EndDate: &endDate, LocalTime: true}, 0, 0).
+					Return([]entity.PVZWithDetails{}, nil)
+			},
+			expectedHTTPStatus: http.StatusOK,
+			expectedResponse:   listPVZWithDetailsResponse{PVZs: []pvzWithDetails{}},
+		},
+		{
+			name: "date without offset requires local time",
+			queryParams: map[string]string{
+				"startDate": "2025-04-01T00:00:00",
+			},
+			preparePVZService:  func(mockService *mocks.PVZ) {},
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse:   httpresponse.ErrorResponse{Error: "invalid start date"},
+		},
+		{
+			name: "invalid local time flag",
+			queryParams: map[string]string{
+				"localTime": "maybe",
+			},
+			preparePVZService:  func(mockService *mocks.PVZ) {},
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse:   httpresponse.ErrorResponse{Error: "invalid local time flag"},
+		},
+		{
+			name: "return receptions",
+			queryParams: map[string]string{
+				"kind": "return",
+			},
+			preparePVZService: func(mockService *mocks.PVZ) {
+				mockService.On("ListWithDetails", mock.Anything,
+					entity.PVZFilter{Kind: entity.ReceptionKindReturn}, 0, 0).
+					Return([]entity.PVZWithDetails{}, nil)
+			},
+			expectedHTTPStatus: http.StatusOK,
+			expectedResponse:   listPVZWithDetailsResponse{PVZs: []pvzWithDetails{}},
+		},
+		{
+			name: "invalid reception kind",
+			queryParams: map[string]string{
+				"kind": "exchange",
+			},
+			preparePVZService:  func(mockService *mocks.PVZ) {},
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse:   httpresponse.ErrorResponse{Error: "invalid reception kind"},
+		},
 		{
 			name: "invalid page",
 			queryParams: map[string]string{
@@ -235,7 +303,7 @@ func TestListPVZWithDetails(t *testing.T) {
 				"limit": "10",
 			},
 			preparePVZService: func(mockService *mocks.PVZ) {
-				mockService.On("ListWithDetails", mock.Anything, (*time.Time)(nil), (*time.Time)(nil), 1, 10).
+				mockService.On("ListWithDetails", mock.Anything, entity.PVZFilter{}, 1, 10).
 					Return(nil, errors.New("database error"))
 			},
 			expectedHTTPStatus: http.StatusBadRequest,
@@ -245,7 +313,7 @@ func TestListPVZWithDetails(t *testing.T) {
 			name:        "no query params",
 			queryParams: map[string]string{},
 			preparePVZService: func(mockService *mocks.PVZ) {
-				mockService.On("ListWithDetails", mock.Anything, (*time.Time)(nil), (*time.Time)(nil), 0, 0).
+				mockService.On("ListWithDetails", mock.Anything, entity.PVZFilter{}, 0, 0).
 					Return([]entity.PVZWithDetails{}, nil)
 			},
 			expectedHTTPStatus: http.StatusOK,
@@ -1,6 +1,7 @@
 package v1
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"github.com/GlebMoskalev/go-pickup-point-api/internal/api/middleware"
@@ -9,6 +10,7 @@ import (
 	"github.com/GlebMoskalev/go-pickup-point-api/pkg/httpresponse"
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
+	"io"
 	"net/http"
 	"time"
 )
@@ -18,6 +20,18 @@ type createReceptionRequest struct {
 	// Идентификатор ПВЗ
 	// format: uuid
 	PVZID string `json:"pvz_id"`
+	// Идентификатор дока; если не задан, приёмка открывается в доке по умолчанию
+	// format: uuid
+	DockID string `json:"dockId,omitempty"`
+	// Вид приёмки: delivery — поставка, return — возврат от покупателей; по умолчанию delivery.
+	// Приёмки перемещений (transfer) заводятся только при отправке перемещения
+	// enum: delivery, return
+	Kind string `json:"kind,omitempty" example:"delivery"`
+	// Завести приёмку черновиком; черновик не занимает док и начинается отдельным запросом
+	Draft bool `json:"draft,omitempty"`
+	// Ожидаемый состав поставки; если не задан, приёмка «слепая»
+	Manifest []manifestItemDTO `json:"manifest,omitempty"`
+	deliveryDTO
 }
 
 // @Description Ответ с данными о созданной приёмке
@@ -31,15 +45,84 @@ type createReceptionResponse struct {
 	// Идентификатор ПВЗ
 	// format: uuid
 	PVZID string `json:"pvzId"`
+	// Идентификатор дока
+	// format: uuid
+	DockID string `json:"dockId,omitempty"`
+	// Вид приёмки
+	// enum: delivery, return, transfer
+	Kind string `json:"kind"`
 	// Статус приёмки
-	// enum: open, close
+	// enum: draft, in_progress, close, verified, cancelled
 	Status string `json:"status"`
+	// Ожидаемый состав поставки
+	Manifest []manifestItemDTO `json:"manifest,omitempty"`
+	// Идентификатор пользователя, открывшего приёмку
+	// format: uuid
+	OpenedBy string `json:"openedBy,omitempty"`
+	deliveryDTO
+}
+
+// @Description Сведения о поставке от перевозчика
+type deliveryDTO struct {
+	// Перевозчик
+	Carrier string `json:"carrier,omitempty" example:"СДЭК"`
+	// Номер накладной
+	WaybillNumber string `json:"waybillNumber,omitempty" example:"WB-2025-000123"`
+	// Государственный номер машины
+	VehiclePlate string `json:"vehiclePlate,omitempty" example:"А123ВС777"`
+	// Комментарий
+	Comment string `json:"comment,omitempty"`
+}
+
+func newDeliveryDTO(info entity.DeliveryInfo) deliveryDTO {
+	return deliveryDTO{
+		Carrier:       info.Carrier,
+		WaybillNumber: info.WaybillNumber,
+		VehiclePlate:  info.VehiclePlate,
+		Comment:       info.Comment,
+	}
+}
+
+func (d deliveryDTO) toEntity() entity.DeliveryInfo {
+	return entity.DeliveryInfo{
+		Carrier:       d.Carrier,
+		WaybillNumber: d.WaybillNumber,
+		VehiclePlate:  d.VehiclePlate,
+		Comment:       d.Comment,
+	}
 }
 
 // @Description Ответ с сообщением о закрытие приемки
 type closeReceptionResponse struct {
 	// Сообщение о статусе закрытия приёмки
 	Message string `json:"message"`
+	// Отчёт о расхождениях с манифестом; отсутствует, если приёмка создавалась без манифеста
+	DiscrepancyReport *discrepancyReportDTO `json:"discrepancyReport,omitempty"`
+	// Результат «слепого» пересчёта; отсутствует, если приёмка закрывалась без пересчёта
+	BlindCount *blindCountDTO `json:"blindCount,omitempty"`
+}
+
+// @Description Запрос на закрытие приёмки
+type closeReceptionRequest struct {
+	// Пересчитанное сотрудником количество товаров по типам; если задано, приёмка закрывается только после сверки с учтёнными товарами
+	CountedTotals []manifestItemDTO `json:"countedTotals,omitempty"`
+}
+
+// @Description Запрос на смену статуса приёмки
+type receptionStatusRequest struct {
+	// Причина смены статуса
+	Reason string `json:"reason" example:"закрыта по ошибке до выгрузки второй машины"`
+}
+
+// @Description Ошибка недопустимого перехода статуса приёмки
+type transitionErrorResponse struct {
+	// Текст ошибки
+	Error string `json:"error" example:"invalid status transition"`
+	// Текущий статус приёмки
+	// enum: draft, in_progress, close, verified, cancelled
+	CurrentStatus string `json:"currentStatus" example:"close"`
+	// Переходы, допустимые из текущего статуса
+	AllowedActions []string `json:"allowedActions" example:"reopen,verify"`
 }
 
 func SetupReceptionRoutes(r chi.Router, receptionService service.Reception) {
@@ -47,6 +130,21 @@ func SetupReceptionRoutes(r chi.Router, receptionService service.Reception) {
 
 	r.With(middleware.RoleMiddleware(entity.RoleEmployee)).
 		Post("/", handler.createReception)
+
+	r.With(middleware.RoleMiddleware(entity.RoleEmployee)).
+		Post("/{receptionId}/start", handler.startReception)
+
+	r.With(middleware.RoleMiddleware(entity.RoleModerator)).
+		Post("/{receptionId}/reopen", handler.reopenReception)
+
+	r.With(middleware.RoleMiddleware(entity.RoleModerator)).
+		Post("/{receptionId}/verify", handler.verifyReception)
+
+	r.With(middleware.RoleMiddleware(entity.RoleEmployee, entity.RoleModerator)).
+		Post("/{receptionId}/cancel", handler.cancelReception)
+
+	r.With(middleware.RoleMiddleware(entity.RoleEmployee, entity.RoleModerator)).
+		Get("/{receptionId}/act", handler.getAcceptanceAct)
 }
 
 type receptionHandler struct {
@@ -58,13 +156,13 @@ func newReceptionHandler(receptionService service.Reception) *receptionHandler {
 }
 
 // @Summary Создание приёмки товаров
-// @Description Создаёт новую приёмку товаров в указанном ПВЗ. Доступно только для сотрудников ПВЗ. Нельзя создать, если есть открытая приёмка.
+// @Description Создаёт новую приёмку товаров в указанном ПВЗ. Доступно только для сотрудников ПВЗ. Приёмка открывается в указанном доке или в доке по умолчанию; нельзя создать, если в доке есть открытая приёмка. С draft=true приёмка заводится черновиком, который начинается запросом /start. Можно передать манифест — ожидаемое количество товаров по типам, а также перевозчика, номер накладной, номер машины и комментарий. С kind=return открывается приёмка возвратов от покупателей: товары в неё добавляются с причиной возврата.
 // @Tags receptions
 // @Accept json
 // @Produce json
 // @Param input body createReceptionRequest true "Данные для создания приёмки"
 // @Success 201 {object} createReceptionResponse
-// @Failure 400 {object} httpresponse.ErrorResponse "Неверный идентификатор ПВЗ или открытая приёмка существует"
+// @Failure 400 {object} httpresponse.ErrorResponse "Неверный идентификатор ПВЗ или дока, вид приёмки, манифест или данные о поставке, открытая приёмка существует или ПВЗ не работает в это время"
 // @Failure 401 {object} httpresponse.ErrorResponse "Пользователь не авторизован"
 // @Failure 403 {object} httpresponse.ErrorResponse "Доступ запрещён"
 // @Failure 500 {object} httpresponse.ErrorResponse "Внутренняя ошибка сервера"
@@ -81,13 +179,50 @@ func (h *receptionHandler) createReception(w http.ResponseWriter, r *http.Reques
 		httpresponse.Error(w, http.StatusBadRequest, "invalid pvz id")
 		return
 	}
-	reception, err := h.receptionService.Create(r.Context(), req.PVZID)
+	if req.DockID != "" {
+		if _, err := uuid.Parse(req.DockID); err != nil {
+			httpresponse.Error(w, http.StatusBadRequest, "invalid dock id")
+			return
+		}
+	}
+	claims, ok := middleware.UserClaimsFromContext(r.Context())
+	if !ok {
+		httpresponse.Error(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	params := entity.ReceptionParams{
+		PVZID:    req.PVZID,
+		DockID:   req.DockID,
+		Kind:     req.Kind,
+		Draft:    req.Draft,
+		Manifest: newManifest(req.Manifest),
+		Delivery: req.deliveryDTO.toEntity(),
+		OpenedBy: claims.UserID,
+	}
+	reception, err := h.receptionService.Create(r.Context(), params)
 	if err != nil {
 		switch {
 		case errors.Is(err, service.ErrInvalidPVZID):
 			httpresponse.Error(w, http.StatusBadRequest, "invalid pvz id")
+		case errors.Is(err, service.ErrInvalidDockID):
+			httpresponse.Error(w, http.StatusBadRequest, "invalid dock id")
+		case errors.Is(err, service.ErrInvalidReceptionKind):
+			httpresponse.Error(w, http.StatusBadRequest, "invalid reception kind")
+		case errors.Is(err, service.ErrInvalidManifest):
+			httpresponse.Error(w, http.StatusBadRequest, "invalid manifest")
+		case errors.Is(err, service.ErrInvalidCarrier):
+			httpresponse.Error(w, http.StatusBadRequest, "invalid carrier")
+		case errors.Is(err, service.ErrInvalidWaybill):
+			httpresponse.Error(w, http.StatusBadRequest, "invalid waybill number")
+		case errors.Is(err, service.ErrInvalidVehiclePlate):
+			httpresponse.Error(w, http.StatusBadRequest, "invalid vehicle plate")
+		case errors.Is(err, service.ErrCommentTooLong):
+			httpresponse.Error(w, http.StatusBadRequest, "comment is too long")
 		case errors.Is(err, service.ErrOpenReceptionExists):
 			httpresponse.Error(w, http.StatusBadRequest, "open reception already exists")
+		case errors.Is(err, service.ErrOutsideWorkingHours):
+			httpresponse.Error(w, http.StatusBadRequest, "pvz is closed at this time")
 		default:
 			httpresponse.Error(w, http.StatusInternalServerError, "internal server error")
 		}
@@ -95,46 +230,223 @@ func (h *receptionHandler) createReception(w http.ResponseWriter, r *http.Reques
 	}
 
 	resp := createReceptionResponse{
-		ID:       reception.ID.String(),
-		DateTime: reception.DateTime.Format(time.RFC3339),
-		PVZID:    reception.PVZID.String(),
-		Status:   reception.Status,
+		ID:          reception.ID.String(),
+		DateTime:    reception.DateTime.Format(time.RFC3339),
+		PVZID:       reception.PVZID.String(),
+		DockID:      uuidString(reception.DockID),
+		Kind:        reception.Kind,
+		Status:      reception.Status,
+		Manifest:    newManifestDTO(reception.Manifest),
+		OpenedBy:    uuidString(reception.OpenedBy),
+		deliveryDTO: newDeliveryDTO(reception.Delivery),
 	}
 	httpresponse.JSON(w, http.StatusCreated, resp)
 }
 
 // @Summary Закрытие последней приёмки
-// @Description Закрывает последнюю открытое приёмку в ПВЗ. Доступно только для сотрудников ПВЗ. Приёмка должна быть открытой.
+// @Description Закрывает последнюю открытое приёмку в ПВЗ. Доступно только для сотрудников ПВЗ. Приёмка должна быть открытой. Если в ПВЗ открыто несколько приёмок в разных доках, нужно указать dockId или receptionId. Если приёмка создавалась с манифестом, возвращается отчёт о недостачах и излишках. Если в теле переданы пересчитанные количества (countedTotals), они сверяются с учтёнными товарами: при расхождении приёмка не закрывается либо закрывается с отметкой о расхождении — в зависимости от настроек ПВЗ.
 // @Tags pvz
 // @Accept json
 // @Produce json
 // @Param pvzId path string true "Идентификатор ПВЗ"
+// @Param dockId query string false "Идентификатор дока"
+// @Param receptionId query string false "Идентификатор приёмки"
+// @Param input body closeReceptionRequest false "Пересчитанные количества"
 // @Success 200 {object} closeReceptionResponse
-// @Failure 400 {object} httpresponse.ErrorResponse "Неверный идентификатор ПВЗ или приёмка не найдена"
+// @Failure 400 {object} httpresponse.ErrorResponse "Неверный идентификатор ПВЗ, дока или приёмки, пересчёт, приёмка не найдена или не указан док"
+// @Failure 404 {object} httpresponse.ErrorResponse "Приёмка не найдена"
 // @Failure 401 {object} httpresponse.ErrorResponse "Пользователь не авторизован"
 // @Failure 403 {object} httpresponse.ErrorResponse "Доступ запрещён"
+// @Failure 409 {object} httpresponse.ErrorResponse "Пересчёт не совпал с учтёнными товарами или в приёмку перемещения приняты не все товары"
 // @Failure 500 {object} httpresponse.ErrorResponse "Внутренняя ошибка сервера"
 // @Security JWT
 // @Router /api/v1/pvz/{pvzId}/close_last_reception [post]
 func (h *receptionHandler) closeLastReception(w http.ResponseWriter, r *http.Request) {
-	pvzID := chi.URLParam(r, "pvzId")
-	if _, err := uuid.Parse(pvzID); err != nil {
-		httpresponse.Error(w, http.StatusBadRequest, "invalid pvz id")
+	target, msg := receptionTargetFromRequest(r)
+	if msg != "" {
+		httpresponse.Error(w, http.StatusBadRequest, msg)
+		return
+	}
+
+	claims, ok := middleware.UserClaimsFromContext(r.Context())
+	if !ok {
+		httpresponse.Error(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	var req closeReceptionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid request body")
 		return
 	}
 
-	err := h.receptionService.CloseLastReception(r.Context(), pvzID)
+	result, err := h.receptionService.CloseLastReception(r.Context(), target, claims.UserID,
+		newCountedTotals(req.CountedTotals))
 	if err != nil {
 		switch {
 		case errors.Is(err, service.ErrInvalidPVZID):
 			httpresponse.Error(w, http.StatusBadRequest, "invalid pvz id")
+		case errors.Is(err, service.ErrInvalidBlindCount):
+			httpresponse.Error(w, http.StatusBadRequest, "invalid counted totals")
+		case errors.Is(err, service.ErrBlindCountMismatch):
+			httpresponse.Error(w, http.StatusConflict, "counted totals do not match recorded products")
+		case errors.Is(err, service.ErrTransferItemsPending):
+			httpresponse.Error(w, http.StatusConflict, "transfer reception has items not yet received")
 		case errors.Is(err, service.ErrNoOpenReception):
 			httpresponse.Error(w, http.StatusBadRequest, "no open reception exists")
+		case errors.Is(err, service.ErrDockRequired):
+			httpresponse.Error(w, http.StatusBadRequest, "several open receptions, dock or reception id is required")
+		case errors.Is(err, service.ErrReceptionNotFound):
+			httpresponse.Error(w, http.StatusNotFound, "reception not found")
+		default:
+			httpresponse.Error(w, http.StatusInternalServerError, "internal server error")
+		}
+		return
+	}
+
+	httpresponse.JSON(w, http.StatusOK, closeReceptionResponse{
+		Message:           "close reception",
+		DiscrepancyReport: newDiscrepancyReportDTO(result.DiscrepancyReport),
+		BlindCount:        newBlindCountDTO(result.BlindCount),
+	})
+}
+
+// @Summary Повторное открытие приёмки
+// @Description Только для модераторов. Открывает закрытую приёмку, если в её доке нет другой открытой приёмки. Причина и автор изменения сохраняются в истории статусов.
+// @Tags receptions
+// @Accept json
+// @Produce json
+// @Param receptionId path string true "Идентификатор приёмки"
+// @Param input body receptionStatusRequest true "Причина"
+// @Success 200 {object} createReceptionResponse
+// @Failure 400 {object} httpresponse.ErrorResponse "Неверный идентификатор, не указана причина или в доке есть открытая приёмка"
+// @Failure 401 {object} httpresponse.ErrorResponse "Пользователь не авторизован"
+// @Failure 403 {object} httpresponse.ErrorResponse "Доступ запрещён: требуется роль модератора"
+// @Failure 404 {object} httpresponse.ErrorResponse "Приёмка не найдена"
+// @Failure 409 {object} transitionErrorResponse "Приёмка не закрыта"
+// @Failure 500 {object} httpresponse.ErrorResponse "Внутренняя ошибка сервера"
+// @Security JWT
+// @Router /api/v1/receptions/{receptionId}/reopen [post]
+func (h *receptionHandler) reopenReception(w http.ResponseWriter, r *http.Request) {
+	h.changeStatus(w, r, h.receptionService.Reopen)
+}
+
+// @Summary Начало приёмки из черновика
+// @Description Только для сотрудников. Переводит черновик в работу, если в его доке нет другой открытой приёмки, а ПВЗ, соблюдающий рабочие часы, сейчас работает. Тело запроса необязательно; причина сохраняется в истории статусов как комментарий.
+// @Tags receptions
+// @Accept json
+// @Produce json
+// @Param receptionId path string true "Идентификатор приёмки"
+// @Param input body receptionStatusRequest false "Комментарий"
+// @Success 200 {object} createReceptionResponse
+// @Failure 400 {object} httpresponse.ErrorResponse "Неверный идентификатор, в доке есть открытая приёмка или ПВЗ сейчас не работает"
+// @Failure 401 {object} httpresponse.ErrorResponse "Пользователь не авторизован"
+// @Failure 403 {object} httpresponse.ErrorResponse "Доступ запрещён"
+// @Failure 404 {object} httpresponse.ErrorResponse "Приёмка не найдена"
+// @Failure 409 {object} transitionErrorResponse "Приёмка не является черновиком"
+// @Failure 500 {object} httpresponse.ErrorResponse "Внутренняя ошибка сервера"
+// @Security JWT
+// @Router /api/v1/receptions/{receptionId}/start [post]
+func (h *receptionHandler) startReception(w http.ResponseWriter, r *http.Request) {
+	h.changeStatus(w, r, h.receptionService.Start)
+}
+
+// @Summary Проверка приёмки
+// @Description Только для модераторов. Отмечает закрытую приёмку как проверенную; после этого её нельзя открыть повторно. Тело запроса необязательно; причина сохраняется в истории статусов как комментарий.
+// @Tags receptions
+// @Accept json
+// @Produce json
+// @Param receptionId path string true "Идентификатор приёмки"
+// @Param input body receptionStatusRequest false "Комментарий"
+// @Success 200 {object} createReceptionResponse
+// @Failure 400 {object} httpresponse.ErrorResponse "Неверный идентификатор"
+// @Failure 401 {object} httpresponse.ErrorResponse "Пользователь не авторизован"
+// @Failure 403 {object} httpresponse.ErrorResponse "Доступ запрещён: требуется роль модератора"
+// @Failure 404 {object} httpresponse.ErrorResponse "Приёмка не найдена"
+// @Failure 409 {object} transitionErrorResponse "Приёмка не закрыта"
+// @Failure 500 {object} httpresponse.ErrorResponse "Внутренняя ошибка сервера"
+// @Security JWT
+// @Router /api/v1/receptions/{receptionId}/verify [post]
+func (h *receptionHandler) verifyReception(w http.ResponseWriter, r *http.Request) {
+	h.changeStatus(w, r, h.receptionService.Verify)
+}
+
+// @Summary Отмена приёмки
+// @Description Доступно для сотрудников и модераторов. Отменяет черновик или открытую по ошибке приёмку. Причина и автор изменения сохраняются в истории статусов. Товары отменённой приёмки помечаются удалёнными; приёмку, товары которой уже выданы или отправлены, отменить нельзя.
+// @Tags receptions
+// @Accept json
+// @Produce json
+// @Param receptionId path string true "Идентификатор приёмки"
+// @Param input body receptionStatusRequest true "Причина"
+// @Success 200 {object} createReceptionResponse
+// @Failure 400 {object} httpresponse.ErrorResponse "Неверный идентификатор или не указана причина"
+// @Failure 401 {object} httpresponse.ErrorResponse "Пользователь не авторизован"
+// @Failure 403 {object} httpresponse.ErrorResponse "Доступ запрещён"
+// @Failure 404 {object} httpresponse.ErrorResponse "Приёмка не найдена"
+// @Failure 409 {object} transitionErrorResponse "Приёмка уже закрыта или отменена либо её товары уже не хранятся"
+// @Failure 500 {object} httpresponse.ErrorResponse "Внутренняя ошибка сервера"
+// @Security JWT
+// @Router /api/v1/receptions/{receptionId}/cancel [post]
+func (h *receptionHandler) cancelReception(w http.ResponseWriter, r *http.Request) {
+	h.changeStatus(w, r, h.receptionService.Cancel)
+}
+
+type statusChangeFunc func(ctx context.Context, receptionID, reason string, userID uuid.UUID) (*entity.Reception, error)
+
+func (h *receptionHandler) changeStatus(w http.ResponseWriter, r *http.Request, change statusChangeFunc) {
+	receptionID := chi.URLParam(r, "receptionId")
+	if _, err := uuid.Parse(receptionID); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid reception id")
+		return
+	}
+
+	claims, ok := middleware.UserClaimsFromContext(r.Context())
+	if !ok {
+		httpresponse.Error(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	// Тело необязательно для переходов без обязательной причины; её отсутствие проверяет сервис.
+	var req receptionStatusRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	reception, err := change(r.Context(), receptionID, req.Reason, claims.UserID)
+	if err != nil {
+		var transitionErr *service.TransitionError
+		switch {
+		case errors.As(err, &transitionErr):
+			httpresponse.JSON(w, http.StatusConflict, transitionErrorResponse{
+				Error:          "invalid status transition",
+				CurrentStatus:  transitionErr.Current,
+				AllowedActions: entity.AllowedReceptionEvents(transitionErr.Current),
+			})
+		case errors.Is(err, service.ErrReasonRequired):
+			httpresponse.Error(w, http.StatusBadRequest, "reason is required")
+		case errors.Is(err, service.ErrReceptionNotFound):
+			httpresponse.Error(w, http.StatusNotFound, "reception not found")
+		case errors.Is(err, service.ErrOpenReceptionExists):
+			httpresponse.Error(w, http.StatusBadRequest, "open reception already exists")
+		case errors.Is(err, service.ErrOutsideWorkingHours):
+			httpresponse.Error(w, http.StatusBadRequest, "pvz is closed at this time")
+		case errors.Is(err, service.ErrTransferReception):
+			httpresponse.Error(w, http.StatusConflict, "transfer reception cannot be cancelled")
+		case errors.Is(err, service.ErrProductNotStored):
+			httpresponse.Error(w, http.StatusConflict, "reception has products that are no longer stored")
 		default:
 			httpresponse.Error(w, http.StatusInternalServerError, "internal server error")
 		}
 		return
 	}
 
-	httpresponse.JSON(w, http.StatusOK, closeReceptionResponse{Message: "close reception"})
+	resp := createReceptionResponse{
+		ID:       reception.ID.String(),
+		DateTime: reception.DateTime.Format(time.RFC3339),
+		PVZID:    reception.PVZID.String(),
+		DockID:   uuidString(reception.DockID),
+		Status:   reception.Status,
+	}
+	httpresponse.JSON(w, http.StatusOK, resp)
 }
@@ -2,8 +2,10 @@ package v1
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/api/middleware"
 	"github.com/GlebMoskalev/go-pickup-point-api/internal/entity"
 	"github.com/GlebMoskalev/go-pickup-point-api/internal/service"
 	"github.com/GlebMoskalev/go-pickup-point-api/internal/service/mocks"
@@ -14,11 +16,16 @@ import (
 	"github.com/stretchr/testify/mock"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 )
 
 func TestCreateReception(t *testing.T) {
+	manifestPVZID := uuid.New()
+	dockID := uuid.New()
+	userID := uuid.New()
+	claims := &entity.UserClaims{UserID: userID, Role: entity.RoleEmployee}
 	testCases := []struct {
 		name                    string
 		request                 any
@@ -32,7 +39,7 @@ func TestCreateReception(t *testing.T) {
 			prepareReceptionService: func(mockService *mocks.Reception) {
 				receptionID := uuid.New()
 				pvzID := uuid.New()
-				mockService.On("Create", mock.Anything, mock.AnythingOfType("string")).
+				mockService.On("Create", mock.Anything, mock.AnythingOfType("entity.ReceptionParams")).
 					Return(&entity.Reception{
 						ID:       receptionID,
 						DateTime: time.Now(),
@@ -48,6 +55,122 @@ func TestCreateReception(t *testing.T) {
 				Status:   "in_progress",
 			},
 		},
+		{
+			name: "creation with manifest",
+			request: createReceptionRequest{
+				PVZID:    manifestPVZID.String(),
+				Manifest: []manifestItemDTO{{Type: "обувь", Count: 5}},
+			},
+			prepareReceptionService: func(mockService *mocks.Reception) {
+				manifest := []entity.ManifestItem{{ProductType: "обувь", ExpectedCount: 5}}
+				mockService.On("Create", mock.Anything, entity.ReceptionParams{
+					PVZID:    manifestPVZID.String(),
+					Manifest: manifest,
+					OpenedBy: userID,
+				}).
+					Return(&entity.Reception{
+						ID:       uuid.New(),
+						DateTime: time.Now(),
+						PVZID:    manifestPVZID,
+						Status:   "in_progress",
+						Manifest: manifest,
+						OpenedBy: userID,
+					}, nil)
+			},
+			expectedHTTPStatus: http.StatusCreated,
+			expectedResponse: createReceptionResponse{
+				Status:   "in_progress",
+				Manifest: []manifestItemDTO{{Type: "обувь", Count: 5}},
+				OpenedBy: userID.String(),
+			},
+		},
+		{
+			name:    "invalid manifest",
+			request: createReceptionRequest{PVZID: uuid.New().String(), Manifest: []manifestItemDTO{{Type: "обувь"}}},
+			prepareReceptionService: func(mockService *mocks.Reception) {
+				mockService.On("Create", mock.Anything, mock.AnythingOfType("entity.ReceptionParams")).
+					Return(nil, service.ErrInvalidManifest)
+			},
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse:   httpresponse.ErrorResponse{Error: "invalid manifest"},
+		},
+		{
+			name: "creation with delivery info",
+			request: createReceptionRequest{
+				PVZID:       manifestPVZID.String(),
+				deliveryDTO: deliveryDTO{Carrier: "СДЭК", WaybillNumber: "WB-1", VehiclePlate: "А123ВС777"},
+			},
+			prepareReceptionService: func(mockService *mocks.Reception) {
+				delivery := entity.DeliveryInfo{Carrier: "СДЭК", WaybillNumber: "WB-1", VehiclePlate: "А123ВС777"}
+				mockService.On("Create", mock.Anything, entity.ReceptionParams{
+					PVZID:    manifestPVZID.String(),
+					Delivery: delivery,
+					OpenedBy: userID,
+				}).
+					Return(&entity.Reception{
+						ID:       uuid.New(),
+						DateTime: time.Now(),
+						PVZID:    manifestPVZID,
+						Status:   "in_progress",
+						Delivery: delivery,
+					}, nil)
+			},
+			expectedHTTPStatus: http.StatusCreated,
+			expectedResponse: createReceptionResponse{
+				Status:      "in_progress",
+				deliveryDTO: deliveryDTO{Carrier: "СДЭК", WaybillNumber: "WB-1", VehiclePlate: "А123ВС777"},
+			},
+		},
+		{
+			name: "invalid vehicle plate",
+			request: createReceptionRequest{
+				PVZID:       uuid.New().String(),
+				deliveryDTO: deliveryDTO{VehiclePlate: "?"},
+			},
+			prepareReceptionService: func(mockService *mocks.Reception) {
+				mockService.On("Create", mock.Anything, mock.AnythingOfType("entity.ReceptionParams")).
+					Return(nil, service.ErrInvalidVehiclePlate)
+			},
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse:   httpresponse.ErrorResponse{Error: "invalid vehicle plate"},
+		},
+		{
+			name:    "creation in dock",
+			request: createReceptionRequest{PVZID: manifestPVZID.String(), DockID: dockID.String()},
+			prepareReceptionService: func(mockService *mocks.Reception) {
+				mockService.On("Create", mock.Anything, entity.ReceptionParams{
+					PVZID:    manifestPVZID.String(),
+					DockID:   dockID.String(),
+					OpenedBy: userID,
+				}).
+					Return(&entity.Reception{
+						ID:       uuid.New(),
+						DateTime: time.Now(),
+						PVZID:    manifestPVZID,
+						DockID:   dockID,
+						Status:   "in_progress",
+					}, nil)
+			},
+			expectedHTTPStatus: http.StatusCreated,
+			expectedResponse:   createReceptionResponse{Status: "in_progress", DockID: dockID.String()},
+		},
+		{
+			name:                    "invalid dock id",
+			request:                 createReceptionRequest{PVZID: uuid.New().String(), DockID: "gate-2"},
+			prepareReceptionService: func(mockService *mocks.Reception) {},
+			expectedHTTPStatus:      http.StatusBadRequest,
+			expectedResponse:        httpresponse.ErrorResponse{Error: "invalid dock id"},
+		},
+		{
+			name:    "dock of another pvz",
+			request: createReceptionRequest{PVZID: uuid.New().String(), DockID: dockID.String()},
+			prepareReceptionService: func(mockService *mocks.Reception) {
+				mockService.On("Create", mock.Anything, mock.AnythingOfType("entity.ReceptionParams")).
+					Return(nil, service.ErrInvalidDockID)
+			},
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse:   httpresponse.ErrorResponse{Error: "invalid dock id"},
+		},
 		{
 			name:                    "invalid pvz id",
 			request:                 createReceptionRequest{PVZID: "not-a-uuid"},
@@ -55,11 +178,39 @@ func TestCreateReception(t *testing.T) {
 			expectedHTTPStatus:      http.StatusBadRequest,
 			expectedResponse:        httpresponse.ErrorResponse{Error: "invalid pvz id"},
 		},
+		{
+			name:    "return reception",
+			request: createReceptionRequest{PVZID: uuid.New().String(), Kind: entity.ReceptionKindReturn},
+			prepareReceptionService: func(mockService *mocks.Reception) {
+				mockService.On("Create", mock.Anything, mock.MatchedBy(func(params entity.ReceptionParams) bool {
+					return params.Kind == entity.ReceptionKindReturn
+				})).
+					Return(&entity.Reception{
+						ID:       uuid.New(),
+						DateTime: time.Now(),
+						PVZID:    uuid.New(),
+						Kind:     entity.ReceptionKindReturn,
+						Status:   "in_progress",
+					}, nil)
+			},
+			expectedHTTPStatus: http.StatusCreated,
+			expectedResponse:   createReceptionResponse{Status: "in_progress", Kind: entity.ReceptionKindReturn},
+		},
+		{
+			name:    "invalid reception kind",
+			request: createReceptionRequest{PVZID: uuid.New().String(), Kind: "exchange"},
+			prepareReceptionService: func(mockService *mocks.Reception) {
+				mockService.On("Create", mock.Anything, mock.AnythingOfType("entity.ReceptionParams")).
+					Return(nil, service.ErrInvalidReceptionKind)
+			},
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse:   httpresponse.ErrorResponse{Error: "invalid reception kind"},
+		},
 		{
 			name:    "open reception exists",
 			request: createReceptionRequest{PVZID: uuid.New().String()},
 			prepareReceptionService: func(mockService *mocks.Reception) {
-				mockService.On("Create", mock.Anything, mock.AnythingOfType("string")).
+				mockService.On("Create", mock.Anything, mock.AnythingOfType("entity.ReceptionParams")).
 					Return(nil, service.ErrOpenReceptionExists)
 			},
 			expectedHTTPStatus: http.StatusBadRequest,
@@ -69,7 +220,7 @@ func TestCreateReception(t *testing.T) {
 			name:    "invalid pvz id from service",
 			request: createReceptionRequest{PVZID: uuid.New().String()},
 			prepareReceptionService: func(mockService *mocks.Reception) {
-				mockService.On("Create", mock.Anything, mock.AnythingOfType("string")).
+				mockService.On("Create", mock.Anything, mock.AnythingOfType("entity.ReceptionParams")).
 					Return(nil, service.ErrInvalidPVZID)
 			},
 			expectedHTTPStatus: http.StatusBadRequest,
@@ -79,7 +230,7 @@ func TestCreateReception(t *testing.T) {
 			name:    "internal server error",
 			request: createReceptionRequest{PVZID: uuid.New().String()},
 			prepareReceptionService: func(mockService *mocks.Reception) {
-				mockService.On("Create", mock.Anything, mock.AnythingOfType("string")).
+				mockService.On("Create", mock.Anything, mock.AnythingOfType("entity.ReceptionParams")).
 					Return(nil, errors.New("database error"))
 			},
 			expectedHTTPStatus: http.StatusInternalServerError,
@@ -106,6 +257,7 @@ func TestCreateReception(t *testing.T) {
 				t.Fatalf("failed to marshal request: %v", err)
 			}
 			req := httptest.NewRequest("POST", "/receptions", bytes.NewReader(reqBody))
+			req = req.WithContext(context.WithValue(req.Context(), middleware.ClaimsContext, claims))
 			rec := httptest.NewRecorder()
 
 			handler.createReception(rec, req)
@@ -125,6 +277,11 @@ func TestCreateReception(t *testing.T) {
 				_, err = time.Parse(time.RFC3339, actualResponse.DateTime)
 				assert.NoError(t, err, "DateTime should be in correct format")
 				assert.Equal(t, tc.expectedResponse.(createReceptionResponse).Status, actualResponse.Status)
+				assert.Equal(t, tc.expectedResponse.(createReceptionResponse).Manifest, actualResponse.Manifest)
+				assert.Equal(t, tc.expectedResponse.(createReceptionResponse).OpenedBy, actualResponse.OpenedBy)
+				assert.Equal(t, tc.expectedResponse.(createReceptionResponse).DockID, actualResponse.DockID)
+				assert.Equal(t, tc.expectedResponse.(createReceptionResponse).Kind, actualResponse.Kind)
+				assert.Equal(t, tc.expectedResponse.(createReceptionResponse).deliveryDTO, actualResponse.deliveryDTO)
 			} else {
 				var actualResponse httpresponse.ErrorResponse
 				err := json.NewDecoder(rec.Body).Decode(&actualResponse)
@@ -138,9 +295,17 @@ func TestCreateReception(t *testing.T) {
 }
 
 func TestCloseLastReception(t *testing.T) {
+	userID := uuid.New()
+	pvzID := uuid.New()
+	receptionID := uuid.New()
+	claims := &entity.UserClaims{UserID: userID, Role: entity.RoleEmployee}
+
 	testCases := []struct {
 		name                    string
 		pvzID                   string
+		query                   string
+		body                    string
+		anonymous               bool
 		prepareReceptionService func(mockService *mocks.Reception)
 		expectedHTTPStatus      int
 		expectedResponse        any
@@ -149,12 +314,116 @@ func TestCloseLastReception(t *testing.T) {
 			name:  "successful closure",
 			pvzID: uuid.New().String(),
 			prepareReceptionService: func(mockService *mocks.Reception) {
-				mockService.On("CloseLastReception", mock.Anything, mock.AnythingOfType("string")).
-					Return(nil)
+				mockService.On("CloseLastReception", mock.Anything, mock.AnythingOfType("entity.ReceptionTarget"), userID,
+					[]entity.TypeCount(nil)).
+					Return(&entity.CloseResult{}, nil)
 			},
 			expectedHTTPStatus: http.StatusOK,
 			expectedResponse:   closeReceptionResponse{Message: "close reception"},
 		},
+		{
+			name:  "successful closure with discrepancy report",
+			pvzID: uuid.New().String(),
+			prepareReceptionService: func(mockService *mocks.Reception) {
+				mockService.On("CloseLastReception", mock.Anything, mock.AnythingOfType("entity.ReceptionTarget"), userID,
+					[]entity.TypeCount(nil)).
+					Return(&entity.CloseResult{DiscrepancyReport: &entity.DiscrepancyReport{
+						ReceptionID: uuid.New(),
+						Items: []entity.DiscrepancyItem{
+							{ProductType: "обувь", ExpectedCount: 5, ReceivedCount: 3},
+							{ProductType: "одежда", ExpectedCount: 0, ReceivedCount: 1},
+						},
+					}}, nil)
+			},
+			expectedHTTPStatus: http.StatusOK,
+			expectedResponse: closeReceptionResponse{
+				Message: "close reception",
+				DiscrepancyReport: &discrepancyReportDTO{
+					HasDiscrepancies: true,
+					Items: []discrepancyItemDTO{
+						{Type: "обувь", Expected: 5, Received: 3, Shortage: 2},
+						{Type: "одежда", Received: 1, Surplus: 1},
+					},
+				},
+			},
+		},
+		{
+			name:  "closure by reception id",
+			pvzID: pvzID.String(),
+			query: "?receptionId=" + receptionID.String(),
+			prepareReceptionService: func(mockService *mocks.Reception) {
+				mockService.On("CloseLastReception", mock.Anything,
+					entity.ReceptionTarget{PVZID: pvzID.String(), ReceptionID: receptionID.String()}, userID,
+					[]entity.TypeCount(nil)).
+					Return(&entity.CloseResult{}, nil)
+			},
+			expectedHTTPStatus: http.StatusOK,
+			expectedResponse:   closeReceptionResponse{Message: "close reception"},
+		},
+		{
+			name:  "closure with blind count flagged",
+			pvzID: pvzID.String(),
+			body:  `{"countedTotals":[{"type":"обувь","count":3}]}`,
+			prepareReceptionService: func(mockService *mocks.Reception) {
+				mockService.On("CloseLastReception", mock.Anything, mock.AnythingOfType("entity.ReceptionTarget"), userID,
+					[]entity.TypeCount{{ProductType: "обувь", Count: 3}}).
+					Return(&entity.CloseResult{BlindCount: &entity.BlindCountReport{
+						ReceptionID: receptionID,
+						Items:       []entity.BlindCountItem{{ProductType: "обувь", CountedCount: 3, RecordedCount: 2}},
+					}}, nil)
+			},
+			expectedHTTPStatus: http.StatusOK,
+			expectedResponse: closeReceptionResponse{
+				Message: "close reception",
+				BlindCount: &blindCountDTO{
+					HasMismatch: true,
+					Items:       []blindCountItemDTO{{Type: "обувь", Counted: 3, Recorded: 2}},
+				},
+			},
+		},
+		{
+			name:  "empty blind count is not a plain close",
+			pvzID: pvzID.String(),
+			body:  `{"countedTotals":[]}`,
+			prepareReceptionService: func(mockService *mocks.Reception) {
+				mockService.On("CloseLastReception", mock.Anything, mock.AnythingOfType("entity.ReceptionTarget"), userID,
+					[]entity.TypeCount{}).
+					Return(nil, service.ErrBlindCountMismatch)
+			},
+			expectedHTTPStatus: http.StatusConflict,
+			expectedResponse:   httpresponse.ErrorResponse{Error: "counted totals do not match recorded products"},
+		},
+		{
+			name:  "transfer items not yet received",
+			pvzID: pvzID.String(),
+			prepareReceptionService: func(mockService *mocks.Reception) {
+				mockService.On("CloseLastReception", mock.Anything, mock.AnythingOfType("entity.ReceptionTarget"), userID,
+					[]entity.TypeCount(nil)).
+					Return(nil, service.ErrTransferItemsPending)
+			},
+			expectedHTTPStatus: http.StatusConflict,
+			expectedResponse:   httpresponse.ErrorResponse{Error: "transfer reception has items not yet received"},
+		},
+		{
+			name:  "invalid blind count",
+			pvzID: pvzID.String(),
+			body:  `{"countedTotals":[{"type":"обувь","count":-1}]}`,
+			prepareReceptionService: func(mockService *mocks.Reception) {
+				mockService.On("CloseLastReception", mock.Anything, mock.AnythingOfType("entity.ReceptionTarget"), userID,
+					mock.AnythingOfType("[]entity.TypeCount")).
+					Return(nil, service.ErrInvalidBlindCount)
+			},
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse:   httpresponse.ErrorResponse{Error: "invalid counted totals"},
+		},
+		{
+			name:                    "invalid body",
+			pvzID:                   pvzID.String(),
+			body:                    `{"countedTotals":`,
+			prepareReceptionService: func(mockService *mocks.Reception) {},
+			expectedHTTPStatus:      http.StatusBadRequest,
+			expectedResponse:        httpresponse.ErrorResponse{Error: "invalid request body"},
+		},
 		{
 			name:                    "invalid pvz id",
 			pvzID:                   "not-a-uuid",
@@ -162,12 +431,54 @@ func TestCloseLastReception(t *testing.T) {
 			expectedHTTPStatus:      http.StatusBadRequest,
 			expectedResponse:        httpresponse.ErrorResponse{Error: "invalid pvz id"},
 		},
+		{
+			name:                    "invalid reception id",
+			pvzID:                   pvzID.String(),
+			query:                   "?receptionId=not-a-uuid",
+			prepareReceptionService: func(mockService *mocks.Reception) {},
+			expectedHTTPStatus:      http.StatusBadRequest,
+			expectedResponse:        httpresponse.ErrorResponse{Error: "invalid reception id"},
+		},
+		{
+			name:  "several open receptions",
+			pvzID: pvzID.String(),
+			prepareReceptionService: func(mockService *mocks.Reception) {
+				mockService.On("CloseLastReception", mock.Anything, mock.AnythingOfType("entity.ReceptionTarget"), userID,
+					[]entity.TypeCount(nil)).
+					Return(nil, service.ErrDockRequired)
+			},
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse: httpresponse.ErrorResponse{
+				Error: "several open receptions, dock or reception id is required",
+			},
+		},
+		{
+			name:  "reception not found",
+			pvzID: pvzID.String(),
+			query: "?receptionId=" + receptionID.String(),
+			prepareReceptionService: func(mockService *mocks.Reception) {
+				mockService.On("CloseLastReception", mock.Anything, mock.AnythingOfType("entity.ReceptionTarget"), userID,
+					[]entity.TypeCount(nil)).
+					Return(nil, service.ErrReceptionNotFound)
+			},
+			expectedHTTPStatus: http.StatusNotFound,
+			expectedResponse:   httpresponse.ErrorResponse{Error: "reception not found"},
+		},
+		{
+			name:                    "missing claims",
+			pvzID:                   uuid.New().String(),
+			anonymous:               true,
+			prepareReceptionService: func(mockService *mocks.Reception) {},
+			expectedHTTPStatus:      http.StatusUnauthorized,
+			expectedResponse:        httpresponse.ErrorResponse{Error: "unauthorized"},
+		},
 		{
 			name:  "no open reception",
 			pvzID: uuid.New().String(),
 			prepareReceptionService: func(mockService *mocks.Reception) {
-				mockService.On("CloseLastReception", mock.Anything, mock.AnythingOfType("string")).
-					Return(service.ErrNoOpenReception)
+				mockService.On("CloseLastReception", mock.Anything, mock.AnythingOfType("entity.ReceptionTarget"), userID,
+					[]entity.TypeCount(nil)).
+					Return(nil, service.ErrNoOpenReception)
 			},
 			expectedHTTPStatus: http.StatusBadRequest,
 			expectedResponse:   httpresponse.ErrorResponse{Error: "no open reception exists"},
@@ -176,8 +487,9 @@ func TestCloseLastReception(t *testing.T) {
 			name:  "invalid pvz id from service",
 			pvzID: uuid.New().String(),
 			prepareReceptionService: func(mockService *mocks.Reception) {
-				mockService.On("CloseLastReception", mock.Anything, mock.AnythingOfType("string")).
-					Return(service.ErrInvalidPVZID)
+				mockService.On("CloseLastReception", mock.Anything, mock.AnythingOfType("entity.ReceptionTarget"), userID,
+					[]entity.TypeCount(nil)).
+					Return(nil, service.ErrInvalidPVZID)
 			},
 			expectedHTTPStatus: http.StatusBadRequest,
 			expectedResponse:   httpresponse.ErrorResponse{Error: "invalid pvz id"},
@@ -186,8 +498,9 @@ func TestCloseLastReception(t *testing.T) {
 			name:  "internal server error",
 			pvzID: uuid.New().String(),
 			prepareReceptionService: func(mockService *mocks.Reception) {
-				mockService.On("CloseLastReception", mock.Anything, mock.AnythingOfType("string")).
-					Return(errors.New("database error"))
+				mockService.On("CloseLastReception", mock.Anything, mock.AnythingOfType("entity.ReceptionTarget"), userID,
+					[]entity.TypeCount(nil)).
+					Return(nil, errors.New("database error"))
 			},
 			expectedHTTPStatus: http.StatusInternalServerError,
 			expectedResponse:   httpresponse.ErrorResponse{Error: "internal server error"},
@@ -203,7 +516,11 @@ func TestCloseLastReception(t *testing.T) {
 
 			r := chi.NewRouter()
 			r.Post("/pvz/{pvzId}/close_last_reception", handler.closeLastReception)
-			req := httptest.NewRequest("POST", "/pvz/"+tc.pvzID+"/close_last_reception", nil)
+			req := httptest.NewRequest("POST", "/pvz/"+tc.pvzID+"/close_last_reception"+tc.query,
+				strings.NewReader(tc.body))
+			if !tc.anonymous {
+				req = req.WithContext(context.WithValue(req.Context(), middleware.ClaimsContext, claims))
+			}
 			rec := httptest.NewRecorder()
 
 			r.ServeHTTP(rec, req)
@@ -228,3 +545,296 @@ func TestCloseLastReception(t *testing.T) {
 		})
 	}
 }
+
+func TestChangeReceptionStatus(t *testing.T) {
+	receptionID := uuid.New()
+	pvzID := uuid.New()
+	userID := uuid.New()
+	claims := &entity.UserClaims{UserID: userID, Role: entity.RoleModerator}
+
+	testCases := []struct {
+		name                    string
+		action                  string
+		receptionID             string
+		request                 any
+		claims                  *entity.UserClaims
+		prepareReceptionService func(mockService *mocks.Reception)
+		expectedHTTPStatus      int
+		expectedResponse        any
+	}{
+		{
+			name:        "successful reopen",
+			action:      "reopen",
+			receptionID: receptionID.String(),
+			request:     receptionStatusRequest{Reason: "закрыта по ошибке"},
+			claims:      claims,
+			prepareReceptionService: func(mockService *mocks.Reception) {
+				mockService.On("Reopen", mock.Anything, receptionID.String(), "закрыта по ошибке", userID).
+					Return(&entity.Reception{
+						ID:       receptionID,
+						DateTime: time.Date(2025, 4, 1, 10, 0, 0, 0, time.UTC),
+						PVZID:    pvzID,
+						Status:   entity.StatusInProgress,
+					}, nil)
+			},
+			expectedHTTPStatus: http.StatusOK,
+			expectedResponse: createReceptionResponse{
+				ID:       receptionID.String(),
+				DateTime: "2025-04-01T10:00:00Z",
+				PVZID:    pvzID.String(),
+				Status:   entity.StatusInProgress,
+			},
+		},
+		{
+			name:        "successful cancel",
+			action:      "cancel",
+			receptionID: receptionID.String(),
+			request:     receptionStatusRequest{Reason: "не тот ПВЗ"},
+			claims:      claims,
+			prepareReceptionService: func(mockService *mocks.Reception) {
+				mockService.On("Cancel", mock.Anything, receptionID.String(), "не тот ПВЗ", userID).
+					Return(&entity.Reception{
+						ID:       receptionID,
+						DateTime: time.Date(2025, 4, 1, 10, 0, 0, 0, time.UTC),
+						PVZID:    pvzID,
+						Status:   entity.StatusCancelled,
+					}, nil)
+			},
+			expectedHTTPStatus: http.StatusOK,
+			expectedResponse: createReceptionResponse{
+				ID:       receptionID.String(),
+				DateTime: "2025-04-01T10:00:00Z",
+				PVZID:    pvzID.String(),
+				Status:   entity.StatusCancelled,
+			},
+		},
+		{
+			name:                    "invalid reception id",
+			action:                  "cancel",
+			receptionID:             "not-a-uuid",
+			request:                 receptionStatusRequest{Reason: "ошибка"},
+			claims:                  claims,
+			prepareReceptionService: func(mockService *mocks.Reception) {},
+			expectedHTTPStatus:      http.StatusBadRequest,
+			expectedResponse:        httpresponse.ErrorResponse{Error: "invalid reception id"},
+		},
+		{
+			name:                    "missing claims",
+			action:                  "cancel",
+			receptionID:             receptionID.String(),
+			request:                 receptionStatusRequest{Reason: "ошибка"},
+			prepareReceptionService: func(mockService *mocks.Reception) {},
+			expectedHTTPStatus:      http.StatusUnauthorized,
+			expectedResponse:        httpresponse.ErrorResponse{Error: "unauthorized"},
+		},
+		{
+			name:                    "invalid request body",
+			action:                  "reopen",
+			receptionID:             receptionID.String(),
+			request:                 "invalid json",
+			claims:                  claims,
+			prepareReceptionService: func(mockService *mocks.Reception) {},
+			expectedHTTPStatus:      http.StatusBadRequest,
+			expectedResponse:        httpresponse.ErrorResponse{Error: "invalid request body"},
+		},
+		{
+			name:        "reason required",
+			action:      "cancel",
+			receptionID: receptionID.String(),
+			request:     receptionStatusRequest{},
+			claims:      claims,
+			prepareReceptionService: func(mockService *mocks.Reception) {
+				mockService.On("Cancel", mock.Anything, receptionID.String(), "", userID).
+					Return(nil, service.ErrReasonRequired)
+			},
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse:   httpresponse.ErrorResponse{Error: "reason is required"},
+		},
+		{
+			name:        "reception not found",
+			action:      "reopen",
+			receptionID: receptionID.String(),
+			request:     receptionStatusRequest{Reason: "ошибка"},
+			claims:      claims,
+			prepareReceptionService: func(mockService *mocks.Reception) {
+				mockService.On("Reopen", mock.Anything, receptionID.String(), "ошибка", userID).
+					Return(nil, service.ErrReceptionNotFound)
+			},
+			expectedHTTPStatus: http.StatusNotFound,
+			expectedResponse:   httpresponse.ErrorResponse{Error: "reception not found"},
+		},
+		{
+			name:        "invalid transition",
+			action:      "reopen",
+			receptionID: receptionID.String(),
+			request:     receptionStatusRequest{Reason: "ошибка"},
+			claims:      claims,
+			prepareReceptionService: func(mockService *mocks.Reception) {
+				mockService.On("Reopen", mock.Anything, receptionID.String(), "ошибка", userID).
+					Return(nil, &service.TransitionError{Current: entity.StatusInProgress, Event: entity.TransitionReopen})
+			},
+			expectedHTTPStatus: http.StatusConflict,
+			expectedResponse: transitionErrorResponse{
+				Error:          "invalid status transition",
+				CurrentStatus:  entity.StatusInProgress,
+				AllowedActions: []string{entity.TransitionClose, entity.TransitionCancel},
+			},
+		},
+		{
+			name:        "verify of verified reception",
+			action:      "verify",
+			receptionID: receptionID.String(),
+			request:     receptionStatusRequest{},
+			claims:      claims,
+			prepareReceptionService: func(mockService *mocks.Reception) {
+				mockService.On("Verify", mock.Anything, receptionID.String(), "", userID).
+					Return(nil, &service.TransitionError{Current: entity.StatusVerified, Event: entity.TransitionVerify})
+			},
+			expectedHTTPStatus: http.StatusConflict,
+			expectedResponse: transitionErrorResponse{
+				Error:          "invalid status transition",
+				CurrentStatus:  entity.StatusVerified,
+				AllowedActions: []string{},
+			},
+		},
+		{
+			name:        "successful start without body",
+			action:      "start",
+			receptionID: receptionID.String(),
+			claims:      claims,
+			prepareReceptionService: func(mockService *mocks.Reception) {
+				mockService.On("Start", mock.Anything, receptionID.String(), "", userID).
+					Return(&entity.Reception{
+						ID:       receptionID,
+						DateTime: time.Date(2025, 4, 1, 10, 0, 0, 0, time.UTC),
+						PVZID:    pvzID,
+						Status:   entity.StatusInProgress,
+					}, nil)
+			},
+			expectedHTTPStatus: http.StatusOK,
+			expectedResponse: createReceptionResponse{
+				ID:       receptionID.String(),
+				DateTime: "2025-04-01T10:00:00Z",
+				PVZID:    pvzID.String(),
+				Status:   entity.StatusInProgress,
+			},
+		},
+		{
+			name:        "start outside working hours",
+			action:      "start",
+			receptionID: receptionID.String(),
+			claims:      claims,
+			prepareReceptionService: func(mockService *mocks.Reception) {
+				mockService.On("Start", mock.Anything, receptionID.String(), "", userID).
+					Return(nil, service.ErrOutsideWorkingHours)
+			},
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse:   httpresponse.ErrorResponse{Error: "pvz is closed at this time"},
+		},
+		{
+			name:        "successful verify",
+			action:      "verify",
+			receptionID: receptionID.String(),
+			request:     receptionStatusRequest{Reason: "пересчитано"},
+			claims:      claims,
+			prepareReceptionService: func(mockService *mocks.Reception) {
+				mockService.On("Verify", mock.Anything, receptionID.String(), "пересчитано", userID).
+					Return(&entity.Reception{
+						ID:       receptionID,
+						DateTime: time.Date(2025, 4, 1, 10, 0, 0, 0, time.UTC),
+						PVZID:    pvzID,
+						Status:   entity.StatusVerified,
+					}, nil)
+			},
+			expectedHTTPStatus: http.StatusOK,
+			expectedResponse: createReceptionResponse{
+				ID:       receptionID.String(),
+				DateTime: "2025-04-01T10:00:00Z",
+				PVZID:    pvzID.String(),
+				Status:   entity.StatusVerified,
+			},
+		},
+		{
+			name:        "open reception exists",
+			action:      "reopen",
+			receptionID: receptionID.String(),
+			request:     receptionStatusRequest{Reason: "ошибка"},
+			claims:      claims,
+			prepareReceptionService: func(mockService *mocks.Reception) {
+				mockService.On("Reopen", mock.Anything, receptionID.String(), "ошибка", userID).
+					Return(nil, service.ErrOpenReceptionExists)
+			},
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse:   httpresponse.ErrorResponse{Error: "open reception already exists"},
+		},
+		{
+			name:        "internal server error",
+			action:      "cancel",
+			receptionID: receptionID.String(),
+			request:     receptionStatusRequest{Reason: "ошибка"},
+			claims:      claims,
+			prepareReceptionService: func(mockService *mocks.Reception) {
+				mockService.On("Cancel", mock.Anything, receptionID.String(), "ошибка", userID).
+					Return(nil, errors.New("database error"))
+			},
+			expectedHTTPStatus: http.StatusInternalServerError,
+			expectedResponse:   httpresponse.ErrorResponse{Error: "internal server error"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			receptionService := mocks.NewReception(t)
+			tc.prepareReceptionService(receptionService)
+
+			handler := newReceptionHandler(receptionService)
+
+			var reqBody []byte
+			if tc.request != nil {
+				var err error
+				reqBody, err = json.Marshal(tc.request)
+				if err != nil {
+					t.Fatalf("failed to marshal request: %v", err)
+				}
+			}
+			r := chi.NewRouter()
+			r.Post("/receptions/{receptionId}/start", handler.startReception)
+			r.Post("/receptions/{receptionId}/reopen", handler.reopenReception)
+			r.Post("/receptions/{receptionId}/verify", handler.verifyReception)
+			r.Post("/receptions/{receptionId}/cancel", handler.cancelReception)
+			req := httptest.NewRequest("POST", "/receptions/"+tc.receptionID+"/"+tc.action, bytes.NewReader(reqBody))
+			if tc.claims != nil {
+				req = req.WithContext(context.WithValue(req.Context(), middleware.ClaimsContext, tc.claims))
+			}
+			rec := httptest.NewRecorder()
+
+			r.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedHTTPStatus, rec.Code)
+
+			switch tc.expectedHTTPStatus {
+			case http.StatusOK:
+				var actualResponse createReceptionResponse
+				err := json.NewDecoder(rec.Body).Decode(&actualResponse)
+				if err != nil {
+					t.Fatalf("failed to decode response body: %v", err)
+				}
+				assert.Equal(t, tc.expectedResponse, actualResponse)
+			case http.StatusConflict:
+				var actualResponse transitionErrorResponse
+				err := json.NewDecoder(rec.Body).Decode(&actualResponse)
+				if err != nil {
+					t.Fatalf("failed to decode response body: %v", err)
+				}
+				assert.Equal(t, tc.expectedResponse, actualResponse)
+			default:
+				var actualResponse httpresponse.ErrorResponse
+				err := json.NewDecoder(rec.Body).Decode(&actualResponse)
+				if err != nil {
+					t.Fatalf("failed to decode response body: %v", err)
+				}
+				assert.Equal(t, tc.expectedResponse, actualResponse)
+			}
+		})
+	}
+}
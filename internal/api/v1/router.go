@@ -28,6 +28,15 @@ func NewRouter(services *service.Services) *chi.Mux {
 
 			r.Route("/products", func(r chi.Router) {
 				SetupProductRoutes(r, services.Product)
+				SetupDamageRoutes(r, services.Damage)
+			})
+
+			r.Route("/product-types", func(r chi.Router) {
+				SetupProductTypeRoutes(r, services.ProductType)
+			})
+
+			r.Route("/transfers", func(r chi.Router) {
+				SetupTransferRoutes(r, services.Transfer)
 			})
 		})
 	})
@@ -0,0 +1,195 @@
+package v1
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/entity"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/service"
+	"github.com/GlebMoskalev/go-pickup-point-api/pkg/httpresponse"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"net/http"
+	"time"
+)
+
+// @Description Рабочие часы ПВЗ в один из дней недели
+type workingHoursDTO struct {
+	// День недели (0 — воскресенье, 6 — суббота)
+	Weekday int `json:"weekday" example:"1"`
+	// Время открытия по местному времени ПВЗ (HH:MM)
+	OpenTime string `json:"openTime" example:"09:00"`
+	// Время закрытия по местному времени ПВЗ (HH:MM)
+	CloseTime string `json:"closeTime" example:"21:00"`
+}
+
+// @Description Исключение из недельного расписания на конкретную дату
+type holidayDTO struct {
+	// Дата исключения
+	// format: date
+	Date string `json:"date" example:"2025-01-01"`
+	// Время открытия (HH:MM); пустое значение означает выходной
+	OpenTime string `json:"openTime,omitempty"`
+	// Время закрытия (HH:MM); пустое значение означает выходной
+	CloseTime string `json:"closeTime,omitempty"`
+	// Комментарий
+	Comment string `json:"comment,omitempty"`
+}
+
+// @Description Запрос для установки расписания ПВЗ
+type setScheduleRequest struct {
+	// Часовой пояс ПВЗ в формате IANA
+	Timezone string `json:"timezone" example:"Europe/Moscow"`
+	// Запрещать создание приёмок вне рабочего времени
+	EnforceWorkingHours bool `json:"enforceWorkingHours"`
+	// Недельное расписание
+	WorkingHours []workingHoursDTO `json:"workingHours"`
+	// Праздничные и особые дни
+	Holidays []holidayDTO `json:"holidays"`
+}
+
+// @Description Расписание работы ПВЗ
+type scheduleResponse struct {
+	// Идентификатор ПВЗ
+	// format: uuid
+	PVZID string `json:"pvzId"`
+	// Часовой пояс ПВЗ в формате IANA
+	Timezone string `json:"timezone"`
+	// Запрещено ли создание приёмок вне рабочего времени
+	EnforceWorkingHours bool `json:"enforceWorkingHours"`
+	// Недельное расписание
+	WorkingHours []workingHoursDTO `json:"workingHours"`
+	// Праздничные и особые дни
+	Holidays []holidayDTO `json:"holidays"`
+}
+
+func newScheduleResponse(schedule *entity.PVZSchedule) scheduleResponse {
+	resp := scheduleResponse{
+		PVZID:               schedule.PVZID.String(),
+		Timezone:            schedule.Timezone,
+		EnforceWorkingHours: schedule.EnforceWorkingHours,
+		WorkingHours:        make([]workingHoursDTO, len(schedule.WorkingHours)),
+		Holidays:            make([]holidayDTO, len(schedule.Holidays)),
+	}
+	for i, wh := range schedule.WorkingHours {
+		resp.WorkingHours[i] = workingHoursDTO{
+			Weekday:   int(wh.Weekday),
+			OpenTime:  wh.OpenTime,
+			CloseTime: wh.CloseTime,
+		}
+	}
+	for i, h := range schedule.Holidays {
+		resp.Holidays[i] = holidayDTO{
+			Date:      h.Date.Format(time.DateOnly),
+			OpenTime:  h.OpenTime,
+			CloseTime: h.CloseTime,
+			Comment:   h.Comment,
+		}
+	}
+	return resp
+}
+
+// @Summary Получение расписания ПВЗ
+// @Description Доступно для сотрудников и модераторов. Возвращает часовой пояс, недельное расписание и праздничные дни ПВЗ.
+// @Tags pvz
+// @Produce json
+// @Param pvzId path string true "Идентификатор ПВЗ"
+// @Success 200 {object} scheduleResponse
+// @Failure 400 {object} httpresponse.ErrorResponse "Неверный идентификатор ПВЗ"
+// @Failure 401 {object} httpresponse.ErrorResponse "Пользователь не авторизован"
+// @Failure 403 {object} httpresponse.ErrorResponse "Доступ запрещён"
+// @Failure 500 {object} httpresponse.ErrorResponse "Внутренняя ошибка сервера"
+// @Security JWT
+// @Router /api/v1/pvz/{pvzId}/schedule [get]
+func (h *pvzHandler) getSchedule(w http.ResponseWriter, r *http.Request) {
+	pvzID := chi.URLParam(r, "pvzId")
+	if _, err := uuid.Parse(pvzID); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid pvz id")
+		return
+	}
+
+	schedule, err := h.pvzService.GetSchedule(r.Context(), pvzID)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidPVZID):
+			httpresponse.Error(w, http.StatusBadRequest, "invalid pvz id")
+		default:
+			httpresponse.Error(w, http.StatusInternalServerError, "internal server error")
+		}
+		return
+	}
+
+	httpresponse.JSON(w, http.StatusOK, newScheduleResponse(schedule))
+}
+
+// @Summary Установка расписания ПВЗ
+// @Description Только для модераторов. Полностью заменяет часовой пояс, недельное расписание и праздничные дни ПВЗ. Время задаётся по местному времени ПВЗ.
+// @Tags pvz
+// @Accept json
+// @Produce json
+// @Param pvzId path string true "Идентификатор ПВЗ"
+// @Param input body setScheduleRequest true "Расписание ПВЗ"
+// @Success 200 {object} scheduleResponse
+// @Failure 400 {object} httpresponse.ErrorResponse "Неверный идентификатор ПВЗ, часовой пояс или расписание"
+// @Failure 401 {object} httpresponse.ErrorResponse "Пользователь не авторизован"
+// @Failure 403 {object} httpresponse.ErrorResponse "Доступ запрещён: требуется роль модератора"
+// @Failure 500 {object} httpresponse.ErrorResponse "Внутренняя ошибка сервера"
+// @Security JWT
+// @Router /api/v1/pvz/{pvzId}/schedule [put]
+func (h *pvzHandler) setSchedule(w http.ResponseWriter, r *http.Request) {
+	pvzID, err := uuid.Parse(chi.URLParam(r, "pvzId"))
+	if err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid pvz id")
+		return
+	}
+
+	var req setScheduleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	schedule := entity.PVZSchedule{
+		PVZID:               pvzID,
+		Timezone:            req.Timezone,
+		EnforceWorkingHours: req.EnforceWorkingHours,
+		WorkingHours:        make([]entity.WorkingHours, len(req.WorkingHours)),
+		Holidays:            make([]entity.Holiday, len(req.Holidays)),
+	}
+	for i, wh := range req.WorkingHours {
+		schedule.WorkingHours[i] = entity.WorkingHours{
+			Weekday:   time.Weekday(wh.Weekday),
+			OpenTime:  wh.OpenTime,
+			CloseTime: wh.CloseTime,
+		}
+	}
+	for i, hd := range req.Holidays {
+		date, err := time.Parse(time.DateOnly, hd.Date)
+		if err != nil {
+			httpresponse.Error(w, http.StatusBadRequest, "invalid holiday date")
+			return
+		}
+		schedule.Holidays[i] = entity.Holiday{
+			Date:      date,
+			OpenTime:  hd.OpenTime,
+			CloseTime: hd.CloseTime,
+			Comment:   hd.Comment,
+		}
+	}
+
+	updated, err := h.pvzService.SetSchedule(r.Context(), schedule)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidPVZID):
+			httpresponse.Error(w, http.StatusBadRequest, "invalid pvz id")
+		case errors.Is(err, service.ErrInvalidTimezone):
+			httpresponse.Error(w, http.StatusBadRequest, "invalid timezone")
+		case errors.Is(err, service.ErrInvalidSchedule):
+			httpresponse.Error(w, http.StatusBadRequest, "invalid schedule")
+		default:
+			httpresponse.Error(w, http.StatusInternalServerError, "internal server error")
+		}
+		return
+	}
+
+	httpresponse.JSON(w, http.StatusOK, newScheduleResponse(updated))
+}
@@ -0,0 +1,259 @@
+package v1
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/entity"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/service"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/service/mocks"
+	"github.com/GlebMoskalev/go-pickup-point-api/pkg/httpresponse"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestGetSchedule(t *testing.T) {
+	pvzID := uuid.New()
+
+	testCases := []struct {
+		name               string
+		pvzID              string
+		preparePVZService  func(mockService *mocks.PVZ)
+		expectedHTTPStatus int
+		expectedResponse   any
+	}{
+		{
+			name:  "successful get",
+			pvzID: pvzID.String(),
+			preparePVZService: func(mockService *mocks.PVZ) {
+				mockService.On("GetSchedule", mock.Anything, pvzID.String()).
+					Return(&entity.PVZSchedule{
+						PVZID:    pvzID,
+						Timezone: entity.DefaultTimezone,
+						WorkingHours: []entity.WorkingHours{
+							{Weekday: time.Monday, OpenTime: "09:00", CloseTime: "21:00"},
+						},
+						Holidays: []entity.Holiday{
+							{Date: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), Comment: "Новый год"},
+						},
+					}, nil)
+			},
+			expectedHTTPStatus: http.StatusOK,
+			expectedResponse: scheduleResponse{
+				PVZID:        pvzID.String(),
+				Timezone:     entity.DefaultTimezone,
+				WorkingHours: []workingHoursDTO{{Weekday: 1, OpenTime: "09:00", CloseTime: "21:00"}},
+				Holidays:     []holidayDTO{{Date: "2025-01-01", Comment: "Новый год"}},
+			},
+		},
+		{
+			name:               "invalid pvz id",
+			pvzID:              "not-a-uuid",
+			preparePVZService:  func(mockService *mocks.PVZ) {},
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse:   httpresponse.ErrorResponse{Error: "invalid pvz id"},
+		},
+		{
+			name:  "pvz not found",
+			pvzID: pvzID.String(),
+			preparePVZService: func(mockService *mocks.PVZ) {
+				mockService.On("GetSchedule", mock.Anything, pvzID.String()).
+					Return(nil, service.ErrInvalidPVZID)
+			},
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse:   httpresponse.ErrorResponse{Error: "invalid pvz id"},
+		},
+		{
+			name:  "internal server error",
+			pvzID: pvzID.String(),
+			preparePVZService: func(mockService *mocks.PVZ) {
+				mockService.On("GetSchedule", mock.Anything, pvzID.String()).
+					Return(nil, errors.New("database error"))
+			},
+			expectedHTTPStatus: http.StatusInternalServerError,
+			expectedResponse:   httpresponse.ErrorResponse{Error: "internal server error"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pvzService := mocks.NewPVZ(t)
+			tc.preparePVZService(pvzService)
+
+			handler := newPVZHandler(pvzService)
+
+			r := chi.NewRouter()
+			r.Get("/pvz/{pvzId}/schedule", handler.getSchedule)
+			req := httptest.NewRequest("GET", "/pvz/"+tc.pvzID+"/schedule", nil)
+			rec := httptest.NewRecorder()
+
+			r.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedHTTPStatus, rec.Code)
+
+			if tc.expectedHTTPStatus == http.StatusOK {
+				var actualResponse scheduleResponse
+				err := json.NewDecoder(rec.Body).Decode(&actualResponse)
+				if err != nil {
+					t.Fatalf("failed to decode response body: %v", err)
+				}
+				assert.Equal(t, tc.expectedResponse, actualResponse)
+			} else {
+				var actualResponse httpresponse.ErrorResponse
+				err := json.NewDecoder(rec.Body).Decode(&actualResponse)
+				if err != nil {
+					t.Fatalf("failed to decode response body: %v", err)
+				}
+				assert.Equal(t, tc.expectedResponse, actualResponse)
+			}
+		})
+	}
+}
+
+func TestSetSchedule(t *testing.T) {
+	pvzID := uuid.New()
+	validRequest := setScheduleRequest{
+		Timezone:            "Europe/Samara",
+		EnforceWorkingHours: true,
+		WorkingHours:        []workingHoursDTO{{Weekday: 6, OpenTime: "10:00", CloseTime: "18:00"}},
+		Holidays:            []holidayDTO{{Date: "2025-03-08"}},
+	}
+	expectedSchedule := entity.PVZSchedule{
+		PVZID:               pvzID,
+		Timezone:            "Europe/Samara",
+		EnforceWorkingHours: true,
+		WorkingHours:        []entity.WorkingHours{{Weekday: time.Saturday, OpenTime: "10:00", CloseTime: "18:00"}},
+		Holidays:            []entity.Holiday{{Date: time.Date(2025, 3, 8, 0, 0, 0, 0, time.UTC)}},
+	}
+
+	testCases := []struct {
+		name               string
+		pvzID              string
+		request            any
+		preparePVZService  func(mockService *mocks.PVZ)
+		expectedHTTPStatus int
+		expectedResponse   any
+	}{
+		{
+			name:    "successful set",
+			pvzID:   pvzID.String(),
+			request: validRequest,
+			preparePVZService: func(mockService *mocks.PVZ) {
+				mockService.On("SetSchedule", mock.Anything, expectedSchedule).
+					Return(&expectedSchedule, nil)
+			},
+			expectedHTTPStatus: http.StatusOK,
+			expectedResponse: scheduleResponse{
+				PVZID:               pvzID.String(),
+				Timezone:            "Europe/Samara",
+				EnforceWorkingHours: true,
+				WorkingHours:        validRequest.WorkingHours,
+				Holidays:            validRequest.Holidays,
+			},
+		},
+		{
+			name:               "invalid pvz id",
+			pvzID:              "not-a-uuid",
+			request:            validRequest,
+			preparePVZService:  func(mockService *mocks.PVZ) {},
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse:   httpresponse.ErrorResponse{Error: "invalid pvz id"},
+		},
+		{
+			name:               "invalid request body",
+			pvzID:              pvzID.String(),
+			request:            "invalid json",
+			preparePVZService:  func(mockService *mocks.PVZ) {},
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse:   httpresponse.ErrorResponse{Error: "invalid request body"},
+		},
+		{
+			name:  "invalid holiday date",
+			pvzID: pvzID.String(),
+			request: setScheduleRequest{
+				Timezone: entity.DefaultTimezone,
+				Holidays: []holidayDTO{{Date: "08.03.2025"}},
+			},
+			preparePVZService:  func(mockService *mocks.PVZ) {},
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse:   httpresponse.ErrorResponse{Error: "invalid holiday date"},
+		},
+		{
+			name:    "invalid timezone",
+			pvzID:   pvzID.String(),
+			request: validRequest,
+			preparePVZService: func(mockService *mocks.PVZ) {
+				mockService.On("SetSchedule", mock.Anything, expectedSchedule).
+					Return(nil, service.ErrInvalidTimezone)
+			},
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse:   httpresponse.ErrorResponse{Error: "invalid timezone"},
+		},
+		{
+			name:    "invalid schedule",
+			pvzID:   pvzID.String(),
+			request: validRequest,
+			preparePVZService: func(mockService *mocks.PVZ) {
+				mockService.On("SetSchedule", mock.Anything, expectedSchedule).
+					Return(nil, service.ErrInvalidSchedule)
+			},
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse:   httpresponse.ErrorResponse{Error: "invalid schedule"},
+		},
+		{
+			name:    "internal server error",
+			pvzID:   pvzID.String(),
+			request: validRequest,
+			preparePVZService: func(mockService *mocks.PVZ) {
+				mockService.On("SetSchedule", mock.Anything, expectedSchedule).
+					Return(nil, errors.New("database error"))
+			},
+			expectedHTTPStatus: http.StatusInternalServerError,
+			expectedResponse:   httpresponse.ErrorResponse{Error: "internal server error"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pvzService := mocks.NewPVZ(t)
+			tc.preparePVZService(pvzService)
+
+			handler := newPVZHandler(pvzService)
+
+			reqBody, err := json.Marshal(tc.request)
+			if err != nil {
+				t.Fatalf("failed to marshal request: %v", err)
+			}
+			r := chi.NewRouter()
+			r.Put("/pvz/{pvzId}/schedule", handler.setSchedule)
+			req := httptest.NewRequest("PUT", "/pvz/"+tc.pvzID+"/schedule", bytes.NewReader(reqBody))
+			rec := httptest.NewRecorder()
+
+			r.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedHTTPStatus, rec.Code)
+
+			if tc.expectedHTTPStatus == http.StatusOK {
+				var actualResponse scheduleResponse
+				err := json.NewDecoder(rec.Body).Decode(&actualResponse)
+				if err != nil {
+					t.Fatalf("failed to decode response body: %v", err)
+				}
+				assert.Equal(t, tc.expectedResponse, actualResponse)
+			} else {
+				var actualResponse httpresponse.ErrorResponse
+				err := json.NewDecoder(rec.Body).Decode(&actualResponse)
+				if err != nil {
+					t.Fatalf("failed to decode response body: %v", err)
+				}
+				assert.Equal(t, tc.expectedResponse, actualResponse)
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package v1
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/entity"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/service"
+	"github.com/GlebMoskalev/go-pickup-point-api/pkg/httpresponse"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"net/http"
+)
+
+// @Description Настройки приёмки и хранения в ПВЗ
+type pvzSettingsDTO struct {
+	// Что делать, если «слепой» пересчёт при закрытии не совпал с учтёнными товарами:
+	// reject — не закрывать приёмку, flag — закрыть с отметкой о расхождении
+	// enum: reject, flag
+	BlindCountPolicy string `json:"blindCountPolicy" example:"reject"`
+	// Срок хранения товаров в днях, от 1 до 365; 0 или отсутствие — срок по умолчанию.
+	// Срок типа товара важнее срока ПВЗ
+	StorageDays int `json:"storageDays,omitempty" example:"14"`
+}
+
+func newPVZSettingsDTO(settings *entity.PVZSettings) pvzSettingsDTO {
+	return pvzSettingsDTO{BlindCountPolicy: settings.BlindCountPolicy, StorageDays: settings.StorageDays}
+}
+
+// @Summary Настройки ПВЗ
+// @Description Доступно для сотрудников и модераторов. Возвращает настройки приёмки и хранения в ПВЗ.
+// @Tags pvz
+// @Produce json
+// @Param pvzId path string true "Идентификатор ПВЗ"
+// @Success 200 {object} pvzSettingsDTO
+// @Failure 400 {object} httpresponse.ErrorResponse "Неверный идентификатор ПВЗ"
+// @Failure 401 {object} httpresponse.ErrorResponse "Пользователь не авторизован"
+// @Failure 403 {object} httpresponse.ErrorResponse "Доступ запрещён"
+// @Failure 500 {object} httpresponse.ErrorResponse "Внутренняя ошибка сервера"
+// @Security JWT
+// @Router /api/v1/pvz/{pvzId}/settings [get]
+func (h *pvzHandler) getSettings(w http.ResponseWriter, r *http.Request) {
+	pvzID := chi.URLParam(r, "pvzId")
+	if _, err := uuid.Parse(pvzID); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid pvz id")
+		return
+	}
+
+	settings, err := h.pvzService.GetSettings(r.Context(), pvzID)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidPVZID):
+			httpresponse.Error(w, http.StatusBadRequest, "invalid pvz id")
+		default:
+			httpresponse.Error(w, http.StatusInternalServerError, "internal server error")
+		}
+		return
+	}
+
+	httpresponse.JSON(w, http.StatusOK, newPVZSettingsDTO(settings))
+}
+
+// @Summary Изменение настроек ПВЗ
+// @Description Только для модераторов. Полностью заменяет настройки приёмки и хранения в ПВЗ.
+// @Tags pvz
+// @Accept json
+// @Produce json
+// @Param pvzId path string true "Идентификатор ПВЗ"
+// @Param input body pvzSettingsDTO true "Настройки ПВЗ"
+// @Success 200 {object} pvzSettingsDTO
+// @Failure 400 {object} httpresponse.ErrorResponse "Неверный идентификатор ПВЗ или настройки"
+// @Failure 401 {object} httpresponse.ErrorResponse "Пользователь не авторизован"
+// @Failure 403 {object} httpresponse.ErrorResponse "Доступ запрещён: требуется роль модератора"
+// @Failure 500 {object} httpresponse.ErrorResponse "Внутренняя ошибка сервера"
+// @Security JWT
+// @Router /api/v1/pvz/{pvzId}/settings [put]
+func (h *pvzHandler) setSettings(w http.ResponseWriter, r *http.Request) {
+	pvzID, err := uuid.Parse(chi.URLParam(r, "pvzId"))
+	if err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid pvz id")
+		return
+	}
+
+	var req pvzSettingsDTO
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	settings, err := h.pvzService.SetSettings(r.Context(), entity.PVZSettings{
+		PVZID:            pvzID,
+		BlindCountPolicy: req.BlindCountPolicy,
+		StorageDays:      req.StorageDays,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidPVZID):
+			httpresponse.Error(w, http.StatusBadRequest, "invalid pvz id")
+		case errors.Is(err, service.ErrInvalidSettings):
+			httpresponse.Error(w, http.StatusBadRequest, "invalid settings")
+		default:
+			httpresponse.Error(w, http.StatusInternalServerError, "internal server error")
+		}
+		return
+	}
+
+	httpresponse.JSON(w, http.StatusOK, newPVZSettingsDTO(settings))
+}
@@ -0,0 +1,150 @@
+package v1
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/entity"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/service"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/service/mocks"
+	"github.com/GlebMoskalev/go-pickup-point-api/pkg/httpresponse"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestPVZSettings(t *testing.T) {
+	pvzID := uuid.New()
+	flagSettings := entity.PVZSettings{PVZID: pvzID, BlindCountPolicy: entity.BlindCountPolicyFlag}
+
+	testCases := []struct {
+		name               string
+		method             string
+		pvzID              string
+		request            any
+		preparePVZService  func(mockService *mocks.PVZ)
+		expectedHTTPStatus int
+		expectedResponse   any
+	}{
+		{
+			name:   "get settings",
+			method: http.MethodGet,
+			pvzID:  pvzID.String(),
+			preparePVZService: func(mockService *mocks.PVZ) {
+				mockService.On("GetSettings", mock.Anything, pvzID.String()).Return(&flagSettings, nil)
+			},
+			expectedHTTPStatus: http.StatusOK,
+			expectedResponse:   pvzSettingsDTO{BlindCountPolicy: entity.BlindCountPolicyFlag},
+		},
+		{
+			name:   "get settings of unknown pvz",
+			method: http.MethodGet,
+			pvzID:  pvzID.String(),
+			preparePVZService: func(mockService *mocks.PVZ) {
+				mockService.On("GetSettings", mock.Anything, pvzID.String()).Return(nil, service.ErrInvalidPVZID)
+			},
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse:   httpresponse.ErrorResponse{Error: "invalid pvz id"},
+		},
+		{
+			name:    "set settings",
+			method:  http.MethodPut,
+			pvzID:   pvzID.String(),
+			request: pvzSettingsDTO{BlindCountPolicy: entity.BlindCountPolicyFlag},
+			preparePVZService: func(mockService *mocks.PVZ) {
+				mockService.On("SetSettings", mock.Anything, flagSettings).Return(&flagSettings, nil)
+			},
+			expectedHTTPStatus: http.StatusOK,
+			expectedResponse:   pvzSettingsDTO{BlindCountPolicy: entity.BlindCountPolicyFlag},
+		},
+		{
+			name:    "set storage period",
+			method:  http.MethodPut,
+			pvzID:   pvzID.String(),
+			request: pvzSettingsDTO{BlindCountPolicy: entity.BlindCountPolicyReject, StorageDays: 14},
+			preparePVZService: func(mockService *mocks.PVZ) {
+				settings := entity.PVZSettings{PVZID: pvzID, BlindCountPolicy: entity.BlindCountPolicyReject,
+					StorageDays: 14}
+				mockService.On("SetSettings", mock.Anything, settings).Return(&settings, nil)
+			},
+			expectedHTTPStatus: http.StatusOK,
+			expectedResponse:   pvzSettingsDTO{BlindCountPolicy: entity.BlindCountPolicyReject, StorageDays: 14},
+		},
+		{
+			name:               "set settings with invalid pvz id",
+			method:             http.MethodPut,
+			pvzID:              "not-a-uuid",
+			request:            pvzSettingsDTO{BlindCountPolicy: entity.BlindCountPolicyFlag},
+			preparePVZService:  func(mockService *mocks.PVZ) {},
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse:   httpresponse.ErrorResponse{Error: "invalid pvz id"},
+		},
+		{
+			name:    "invalid policy",
+			method:  http.MethodPut,
+			pvzID:   pvzID.String(),
+			request: pvzSettingsDTO{BlindCountPolicy: "ignore"},
+			preparePVZService: func(mockService *mocks.PVZ) {
+				mockService.On("SetSettings", mock.Anything, mock.AnythingOfType("entity.PVZSettings")).
+					Return(nil, service.ErrInvalidSettings)
+			},
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse:   httpresponse.ErrorResponse{Error: "invalid settings"},
+		},
+		{
+			name:    "internal server error",
+			method:  http.MethodPut,
+			pvzID:   pvzID.String(),
+			request: pvzSettingsDTO{BlindCountPolicy: entity.BlindCountPolicyReject},
+			preparePVZService: func(mockService *mocks.PVZ) {
+				mockService.On("SetSettings", mock.Anything, mock.AnythingOfType("entity.PVZSettings")).
+					Return(nil, errors.New("database error"))
+			},
+			expectedHTTPStatus: http.StatusInternalServerError,
+			expectedResponse:   httpresponse.ErrorResponse{Error: "internal server error"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pvzService := mocks.NewPVZ(t)
+			tc.preparePVZService(pvzService)
+
+			handler := newPVZHandler(pvzService)
+
+			reqBody, err := json.Marshal(tc.request)
+			if err != nil {
+				t.Fatalf("failed to marshal request: %v", err)
+			}
+			r := chi.NewRouter()
+			r.Get("/pvz/{pvzId}/settings", handler.getSettings)
+			r.Put("/pvz/{pvzId}/settings", handler.setSettings)
+			req := httptest.NewRequest(tc.method, "/pvz/"+tc.pvzID+"/settings", bytes.NewReader(reqBody))
+			rec := httptest.NewRecorder()
+
+			r.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedHTTPStatus, rec.Code)
+
+			if tc.expectedHTTPStatus == http.StatusOK {
+				var actualResponse pvzSettingsDTO
+				err := json.NewDecoder(rec.Body).Decode(&actualResponse)
+				if err != nil {
+					t.Fatalf("failed to decode response body: %v", err)
+				}
+				assert.Equal(t, tc.expectedResponse, actualResponse)
+			} else {
+				var actualResponse httpresponse.ErrorResponse
+				err := json.NewDecoder(rec.Body).Decode(&actualResponse)
+				if err != nil {
+					t.Fatalf("failed to decode response body: %v", err)
+				}
+				assert.Equal(t, tc.expectedResponse, actualResponse)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package v1
+
+import (
+	"errors"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/entity"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/service"
+	"github.com/GlebMoskalev/go-pickup-point-api/pkg/httpresponse"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"net/http"
+)
+
+// @Description Число товаров ПВЗ по статусам
+type pvzStockResponse struct {
+	// Идентификатор ПВЗ
+	// format: uuid
+	PVZID string `json:"pvzId"`
+	// Хранящиеся товары
+	Stored int `json:"stored"`
+	// Выданные покупателям товары
+	Issued int `json:"issued"`
+	// Возвращённые отправителю товары
+	ReturnedToSender int `json:"returnedToSender"`
+	// Товары, отправленные в другой ПВЗ и ещё не принятые там
+	InTransit int `json:"inTransit"`
+	// Объявленная ценность хранящихся товаров по валютам
+	StoredValue []valueTotalDTO `json:"storedValue"`
+	// Предел страховой суммы ПВЗ в минимальных единицах валюты; не задан, если проверка отключена
+	InsuredCeiling int64 `json:"insuredCeiling,omitempty" example:"500000000"`
+	// Валюта предела страховой суммы
+	InsuredCurrency string `json:"insuredCurrency,omitempty" example:"RUB"`
+	// Ценность хранящихся товаров превышает предел страховой суммы
+	ExceedsCeiling bool `json:"exceedsCeiling"`
+}
+
+// @Description Суммарная объявленная ценность товаров в одной валюте
+type valueTotalDTO struct {
+	// Валюта, код ISO 4217
+	Currency string `json:"currency" example:"RUB"`
+	// Сумма в минимальных единицах валюты
+	Amount int64 `json:"amount" example:"1299000"`
+	// Число товаров с объявленной ценностью в этой валюте
+	Count int `json:"count" example:"1"`
+}
+
+// @Summary Остатки ПВЗ
+// @Description Доступно для сотрудников и модераторов. Возвращает число хранящихся, выданных, возвращённых отправителю и отправленных в другой ПВЗ товаров; удалённые товары и товары отменённых приёмок не учитываются. Также возвращает объявленную ценность хранящихся товаров по валютам и признак превышения предела страховой суммы.
+// @Tags pvz
+// @Produce json
+// @Param pvzId path string true "Идентификатор ПВЗ"
+// @Success 200 {object} pvzStockResponse
+// @Failure 400 {object} httpresponse.ErrorResponse "Неверный идентификатор ПВЗ"
+// @Failure 401 {object} httpresponse.ErrorResponse "Пользователь не авторизован"
+// @Failure 403 {object} httpresponse.ErrorResponse "Доступ запрещён"
+// @Failure 500 {object} httpresponse.ErrorResponse "Внутренняя ошибка сервера"
+// @Security JWT
+// @Router /api/v1/pvz/{pvzId}/stock [get]
+func (h *pvzHandler) getStock(w http.ResponseWriter, r *http.Request) {
+	pvzID := chi.URLParam(r, "pvzId")
+	if _, err := uuid.Parse(pvzID); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid pvz id")
+		return
+	}
+
+	stock, err := h.pvzService.GetStock(r.Context(), pvzID)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidPVZID):
+			httpresponse.Error(w, http.StatusBadRequest, "invalid pvz id")
+		default:
+			httpresponse.Error(w, http.StatusInternalServerError, "internal server error")
+		}
+		return
+	}
+
+	httpresponse.JSON(w, http.StatusOK, pvzStockResponse{
+		PVZID:            stock.PVZID.String(),
+		Stored:           stock.Stored,
+		Issued:           stock.Issued,
+		ReturnedToSender: stock.ReturnedToSender,
+		InTransit:        stock.InTransit,
+		StoredValue:      newValueTotalsDTO(stock.StoredValue),
+		InsuredCeiling:   stock.InsuredCeiling,
+		InsuredCurrency:  stock.InsuredCurrency,
+		ExceedsCeiling:   stock.ExceedsCeiling,
+	})
+}
+
+func newValueTotalsDTO(totals []entity.ValueTotal) []valueTotalDTO {
+	items := make([]valueTotalDTO, len(totals))
+	for i, total := range totals {
+		items[i] = valueTotalDTO{Currency: total.Currency, Amount: total.Amount, Count: total.Count}
+	}
+	return items
+}
@@ -0,0 +1,128 @@
+package v1
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/entity"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/service"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/service/mocks"
+	"github.com/GlebMoskalev/go-pickup-point-api/pkg/httpresponse"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestPVZStock(t *testing.T) {
+	pvzID := uuid.New()
+
+	testCases := []struct {
+		name               string
+		pvzID              string
+		preparePVZService  func(mockService *mocks.PVZ)
+		expectedHTTPStatus int
+		expectedResponse   any
+	}{
+		{
+			name:  "get stock",
+			pvzID: pvzID.String(),
+			preparePVZService: func(mockService *mocks.PVZ) {
+				mockService.On("GetStock", mock.Anything, pvzID.String()).
+					Return(&entity.ProductStock{PVZID: pvzID, Stored: 5, Issued: 2, ReturnedToSender: 1}, nil)
+			},
+			expectedHTTPStatus: http.StatusOK,
+			expectedResponse: pvzStockResponse{PVZID: pvzID.String(), Stored: 5, Issued: 2, ReturnedToSender: 1,
+				StoredValue: []valueTotalDTO{}},
+		},
+		{
+			name:  "stock over insured ceiling",
+			pvzID: pvzID.String(),
+			preparePVZService: func(mockService *mocks.PVZ) {
+				mockService.On("GetStock", mock.Anything, pvzID.String()).
+					Return(&entity.ProductStock{
+						PVZID:  pvzID,
+						Stored: 3,
+						StoredValue: []entity.ValueTotal{
+							{Currency: "EUR", Amount: 5000, Count: 1},
+							{Currency: "RUB", Amount: 700000, Count: 2},
+						},
+						InsuredCeiling:  500000,
+						InsuredCurrency: "RUB",
+						ExceedsCeiling:  true,
+					}, nil)
+			},
+			expectedHTTPStatus: http.StatusOK,
+			expectedResponse: pvzStockResponse{
+				PVZID:  pvzID.String(),
+				Stored: 3,
+				StoredValue: []valueTotalDTO{
+					{Currency: "EUR", Amount: 5000, Count: 1},
+					{Currency: "RUB", Amount: 700000, Count: 2},
+				},
+				InsuredCeiling:  500000,
+				InsuredCurrency: "RUB",
+				ExceedsCeiling:  true,
+			},
+		},
+		{
+			name:               "invalid pvz id",
+			pvzID:              "not-a-uuid",
+			preparePVZService:  func(mockService *mocks.PVZ) {},
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse:   httpresponse.ErrorResponse{Error: "invalid pvz id"},
+		},
+		{
+			name:  "unknown pvz",
+			pvzID: pvzID.String(),
+			preparePVZService: func(mockService *mocks.PVZ) {
+				mockService.On("GetStock", mock.Anything, pvzID.String()).Return(nil, service.ErrInvalidPVZID)
+			},
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse:   httpresponse.ErrorResponse{Error: "invalid pvz id"},
+		},
+		{
+			name:  "internal server error",
+			pvzID: pvzID.String(),
+			preparePVZService: func(mockService *mocks.PVZ) {
+				mockService.On("GetStock", mock.Anything, pvzID.String()).Return(nil, errors.New("database error"))
+			},
+			expectedHTTPStatus: http.StatusInternalServerError,
+			expectedResponse:   httpresponse.ErrorResponse{Error: "internal server error"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pvzService := mocks.NewPVZ(t)
+			tc.preparePVZService(pvzService)
+
+			handler := newPVZHandler(pvzService)
+
+			r := chi.NewRouter()
+			r.Get("/pvz/{pvzId}/stock", handler.getStock)
+			req := httptest.NewRequest(http.MethodGet, "/pvz/"+tc.pvzID+"/stock", nil)
+			rec := httptest.NewRecorder()
+
+			r.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedHTTPStatus, rec.Code)
+
+			if tc.expectedHTTPStatus == http.StatusOK {
+				var actualResponse pvzStockResponse
+				if err := json.NewDecoder(rec.Body).Decode(&actualResponse); err != nil {
+					t.Fatalf("failed to decode response body: %v", err)
+				}
+				assert.Equal(t, tc.expectedResponse, actualResponse)
+			} else {
+				var actualResponse httpresponse.ErrorResponse
+				if err := json.NewDecoder(rec.Body).Decode(&actualResponse); err != nil {
+					t.Fatalf("failed to decode response body: %v", err)
+				}
+				assert.Equal(t, tc.expectedResponse, actualResponse)
+			}
+		})
+	}
+}
@@ -0,0 +1,251 @@
+package v1
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/api/middleware"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/entity"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/service"
+	"github.com/GlebMoskalev/go-pickup-point-api/pkg/httpresponse"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"net/http"
+	"time"
+)
+
+// @Description Запрос для создания ячейки хранения
+type createStorageCellRequest struct {
+	// Стеллаж (полка), на котором находится ячейка
+	Shelf string `json:"shelf" example:"A"`
+	// Код ячейки, уникальный в пределах ПВЗ
+	Code string `json:"code" example:"A-03"`
+	// Тип товара из каталога; без типа ячейка принимает любые товары
+	ProductType string `json:"productType,omitempty" example:"обувь"`
+	// Вместимость ячейки в товарах, от 1 до 10000
+	Capacity int `json:"capacity" example:"20"`
+}
+
+// @Description Ячейка хранения ПВЗ
+type storageCellDTO struct {
+	// Идентификатор ячейки
+	// format: uuid
+	ID string `json:"id"`
+	// Идентификатор ПВЗ
+	// format: uuid
+	PVZID string `json:"pvzId"`
+	// Стеллаж
+	Shelf string `json:"shelf" example:"A"`
+	// Код ячейки
+	Code string `json:"code" example:"A-03"`
+	// Тип товара, для которого предназначена ячейка
+	ProductType string `json:"productType,omitempty" example:"обувь"`
+	// Вместимость ячейки
+	Capacity int `json:"capacity" example:"20"`
+	// Число хранящихся в ячейке товаров
+	Occupied int `json:"occupied" example:"7"`
+	// Число свободных мест
+	Free int `json:"free" example:"13"`
+	// Дата создания ячейки
+	// format: date-time
+	CreatedAt string `json:"createdAt"`
+}
+
+// @Description Схема хранения ПВЗ
+type listStorageCellsResponse struct {
+	Cells []storageCellDTO `json:"cells"`
+}
+
+// @Description Ячейка, в которой хранится товар
+type productCellResponse struct {
+	// Идентификатор ячейки
+	// format: uuid
+	ID string `json:"id"`
+	// Стеллаж
+	Shelf string `json:"shelf" example:"A"`
+	// Код ячейки
+	Code string `json:"code" example:"A-03"`
+}
+
+// @Description Запрос на перемещение товара в другую ячейку
+type assignCellRequest struct {
+	// Идентификатор ячейки ПВЗ товара
+	// format: uuid
+	CellID string `json:"cellId"`
+}
+
+func newStorageCellDTO(cell entity.StorageCell) storageCellDTO {
+	return storageCellDTO{
+		ID:          cell.ID.String(),
+		PVZID:       cell.PVZID.String(),
+		Shelf:       cell.Shelf,
+		Code:        cell.Code,
+		ProductType: cell.ProductType,
+		Capacity:    cell.Capacity,
+		Occupied:    cell.Occupied,
+		Free:        cell.Free(),
+		CreatedAt:   cell.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+func newProductCellResponse(product *entity.Product) *productCellResponse {
+	if product.CellID == uuid.Nil {
+		return nil
+	}
+	return &productCellResponse{ID: product.CellID.String(), Shelf: product.CellShelf, Code: product.CellCode}
+}
+
+// @Summary Создание ячейки хранения
+// @Description Только для модераторов. Добавляет ячейку в схему хранения ПВЗ. Ячейка с типом товара принимает только товары этого типа.
+// @Tags pvz
+// @Accept json
+// @Produce json
+// @Param pvzId path string true "Идентификатор ПВЗ"
+// @Param input body createStorageCellRequest true "Данные ячейки"
+// @Success 201 {object} storageCellDTO
+// @Failure 400 {object} httpresponse.ErrorResponse "Неверный идентификатор ПВЗ, данные ячейки или тип товара"
+// @Failure 401 {object} httpresponse.ErrorResponse "Пользователь не авторизован"
+// @Failure 403 {object} httpresponse.ErrorResponse "Доступ запрещён: требуется роль модератора"
+// @Failure 409 {object} httpresponse.ErrorResponse "Ячейка с таким кодом уже есть"
+// @Failure 500 {object} httpresponse.ErrorResponse "Внутренняя ошибка сервера"
+// @Security JWT
+// @Router /api/v1/pvz/{pvzId}/cells [post]
+func (h *pvzHandler) createCell(w http.ResponseWriter, r *http.Request) {
+	pvzID, err := uuid.Parse(chi.URLParam(r, "pvzId"))
+	if err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid pvz id")
+		return
+	}
+
+	var req createStorageCellRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	cell, err := h.pvzService.CreateCell(r.Context(), entity.StorageCell{
+		PVZID:       pvzID,
+		Shelf:       req.Shelf,
+		Code:        req.Code,
+		ProductType: req.ProductType,
+		Capacity:    req.Capacity,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidPVZID):
+			httpresponse.Error(w, http.StatusBadRequest, "invalid pvz id")
+		case errors.Is(err, service.ErrInvalidStorageCell):
+			httpresponse.Error(w, http.StatusBadRequest, "invalid storage cell")
+		case errors.Is(err, service.ErrInvalidProductType):
+			httpresponse.Error(w, http.StatusBadRequest, "invalid product type")
+		case errors.Is(err, service.ErrStorageCellExists):
+			httpresponse.Error(w, http.StatusConflict, "storage cell already exists")
+		default:
+			httpresponse.Error(w, http.StatusInternalServerError, "internal server error")
+		}
+		return
+	}
+
+	httpresponse.JSON(w, http.StatusCreated, newStorageCellDTO(*cell))
+}
+
+// @Summary Схема хранения ПВЗ
+// @Description Доступно для сотрудников и модераторов. Возвращает ячейки ПВЗ по стеллажам с их занятостью.
+// @Tags pvz
+// @Produce json
+// @Param pvzId path string true "Идентификатор ПВЗ"
+// @Success 200 {object} listStorageCellsResponse
+// @Failure 400 {object} httpresponse.ErrorResponse "Неверный идентификатор ПВЗ"
+// @Failure 401 {object} httpresponse.ErrorResponse "Пользователь не авторизован"
+// @Failure 403 {object} httpresponse.ErrorResponse "Доступ запрещён"
+// @Failure 500 {object} httpresponse.ErrorResponse "Внутренняя ошибка сервера"
+// @Security JWT
+// @Router /api/v1/pvz/{pvzId}/cells [get]
+func (h *pvzHandler) listCells(w http.ResponseWriter, r *http.Request) {
+	pvzID := chi.URLParam(r, "pvzId")
+	if _, err := uuid.Parse(pvzID); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid pvz id")
+		return
+	}
+
+	cells, err := h.pvzService.ListCells(r.Context(), pvzID)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidPVZID):
+			httpresponse.Error(w, http.StatusBadRequest, "invalid pvz id")
+		default:
+			httpresponse.Error(w, http.StatusInternalServerError, "internal server error")
+		}
+		return
+	}
+
+	resp := listStorageCellsResponse{Cells: make([]storageCellDTO, len(cells))}
+	for i, cell := range cells {
+		resp.Cells[i] = newStorageCellDTO(cell)
+	}
+	httpresponse.JSON(w, http.StatusOK, resp)
+}
+
+// @Summary Перемещение товара в другую ячейку
+// @Description Только для сотрудников ПВЗ. Перекладывает хранящийся товар в ячейку его ПВЗ, если ячейка принимает тип товара и в ней есть место.
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param productId path string true "Идентификатор товара"
+// @Param input body assignCellRequest true "Новая ячейка"
+// @Success 200 {object} createProductResponse
+// @Failure 400 {object} httpresponse.ErrorResponse "Неверный идентификатор товара или ячейки, ячейка не принимает этот тип товара"
+// @Failure 401 {object} httpresponse.ErrorResponse "Пользователь не авторизован"
+// @Failure 403 {object} httpresponse.ErrorResponse "Доступ запрещён"
+// @Failure 404 {object} httpresponse.ErrorResponse "Товар или ячейка не найдены"
+// @Failure 409 {object} httpresponse.ErrorResponse "Ячейка заполнена или товар уже не хранится"
+// @Failure 500 {object} httpresponse.ErrorResponse "Внутренняя ошибка сервера"
+// @Security JWT
+// @Router /api/v1/products/{productId}/cell [put]
+func (h *productHandler) assignCell(w http.ResponseWriter, r *http.Request) {
+	productID := chi.URLParam(r, "productId")
+	if _, err := uuid.Parse(productID); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid product id")
+		return
+	}
+
+	var req assignCellRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if _, err := uuid.Parse(req.CellID); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid cell id")
+		return
+	}
+
+	claims, ok := middleware.UserClaimsFromContext(r.Context())
+	if !ok {
+		httpresponse.Error(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	product, err := h.productService.AssignCell(r.Context(), entity.CellAssignment{
+		ProductID: productID,
+		CellID:    req.CellID,
+		UserID:    claims.UserID,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrCellTypeMismatch):
+			httpresponse.Error(w, http.StatusBadRequest, "storage cell does not accept this product type")
+		case errors.Is(err, service.ErrProductNotFound):
+			httpresponse.Error(w, http.StatusNotFound, "product not found")
+		case errors.Is(err, service.ErrStorageCellNotFound):
+			httpresponse.Error(w, http.StatusNotFound, "storage cell not found")
+		case errors.Is(err, service.ErrStorageCellFull):
+			httpresponse.Error(w, http.StatusConflict, "storage cell is full")
+		case errors.Is(err, service.ErrProductNotStored):
+			httpresponse.Error(w, http.StatusConflict, "product is not stored")
+		default:
+			httpresponse.Error(w, http.StatusInternalServerError, "internal server error")
+		}
+		return
+	}
+
+	httpresponse.JSON(w, http.StatusOK, newCreateProductResponse(product))
+}
@@ -0,0 +1,372 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/api/middleware"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/entity"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/service"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/service/mocks"
+	"github.com/GlebMoskalev/go-pickup-point-api/pkg/httpresponse"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestCreateCell(t *testing.T) {
+	pvzID := uuid.New()
+	cellID := uuid.New()
+	createdAt := time.Date(2026, 5, 4, 9, 0, 0, 0, time.UTC)
+	validRequest := createStorageCellRequest{Shelf: "A", Code: "A-03", ProductType: "обувь", Capacity: 20}
+	cell := entity.StorageCell{PVZID: pvzID, Shelf: "A", Code: "A-03", ProductType: "обувь", Capacity: 20}
+
+	testCases := []struct {
+		name               string
+		pvzID              string
+		request            any
+		preparePVZService  func(mockService *mocks.PVZ)
+		expectedHTTPStatus int
+		expectedResponse   any
+	}{
+		{
+			name:    "successful creation",
+			pvzID:   pvzID.String(),
+			request: validRequest,
+			preparePVZService: func(mockService *mocks.PVZ) {
+				created := cell
+				created.ID = cellID
+				created.CreatedAt = createdAt
+				mockService.On("CreateCell", mock.Anything, cell).Return(&created, nil)
+			},
+			expectedHTTPStatus: http.StatusCreated,
+			expectedResponse: storageCellDTO{
+				ID:          cellID.String(),
+				PVZID:       pvzID.String(),
+				Shelf:       "A",
+				Code:        "A-03",
+				ProductType: "обувь",
+				Capacity:    20,
+				Free:        20,
+				CreatedAt:   "2026-05-04T09:00:00Z",
+			},
+		},
+		{
+			name:               "invalid pvz id",
+			pvzID:              "not-a-uuid",
+			request:            validRequest,
+			preparePVZService:  func(mockService *mocks.PVZ) {},
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse:   httpresponse.ErrorResponse{Error: "invalid pvz id"},
+		},
+		{
+			name:               "invalid request body",
+			pvzID:              pvzID.String(),
+			request:            "invalid",
+			preparePVZService:  func(mockService *mocks.PVZ) {},
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse:   httpresponse.ErrorResponse{Error: "invalid request body"},
+		},
+		{
+			name:    "invalid storage cell",
+			pvzID:   pvzID.String(),
+			request: validRequest,
+			preparePVZService: func(mockService *mocks.PVZ) {
+				mockService.On("CreateCell", mock.Anything, cell).Return(nil, service.ErrInvalidStorageCell)
+			},
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse:   httpresponse.ErrorResponse{Error: "invalid storage cell"},
+		},
+		{
+			name:    "unknown product type",
+			pvzID:   pvzID.String(),
+			request: validRequest,
+			preparePVZService: func(mockService *mocks.PVZ) {
+				mockService.On("CreateCell", mock.Anything, cell).Return(nil, service.ErrInvalidProductType)
+			},
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse:   httpresponse.ErrorResponse{Error: "invalid product type"},
+		},
+		{
+			name:    "duplicate code",
+			pvzID:   pvzID.String(),
+			request: validRequest,
+			preparePVZService: func(mockService *mocks.PVZ) {
+				mockService.On("CreateCell", mock.Anything, cell).Return(nil, service.ErrStorageCellExists)
+			},
+			expectedHTTPStatus: http.StatusConflict,
+			expectedResponse:   httpresponse.ErrorResponse{Error: "storage cell already exists"},
+		},
+		{
+			name:    "internal error",
+			pvzID:   pvzID.String(),
+			request: validRequest,
+			preparePVZService: func(mockService *mocks.PVZ) {
+				mockService.On("CreateCell", mock.Anything, cell).Return(nil, errors.New("unexpected"))
+			},
+			expectedHTTPStatus: http.StatusInternalServerError,
+			expectedResponse:   httpresponse.ErrorResponse{Error: "internal server error"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pvzService := mocks.NewPVZ(t)
+			tc.preparePVZService(pvzService)
+
+			handler := newPVZHandler(pvzService)
+
+			reqBody, err := json.Marshal(tc.request)
+			if err != nil {
+				t.Fatalf("failed to marshal request: %v", err)
+			}
+			r := chi.NewRouter()
+			r.Post("/pvz/{pvzId}/cells", handler.createCell)
+			req := httptest.NewRequest("POST", "/pvz/"+tc.pvzID+"/cells", bytes.NewReader(reqBody))
+			rec := httptest.NewRecorder()
+
+			r.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedHTTPStatus, rec.Code)
+
+			if tc.expectedHTTPStatus == http.StatusCreated {
+				var actualResponse storageCellDTO
+				if err := json.NewDecoder(rec.Body).Decode(&actualResponse); err != nil {
+					t.Fatalf("failed to decode response body: %v", err)
+				}
+				assert.Equal(t, tc.expectedResponse, actualResponse)
+			} else {
+				var actualResponse httpresponse.ErrorResponse
+				if err := json.NewDecoder(rec.Body).Decode(&actualResponse); err != nil {
+					t.Fatalf("failed to decode response body: %v", err)
+				}
+				assert.Equal(t, tc.expectedResponse, actualResponse)
+			}
+		})
+	}
+}
+
+func TestListCells(t *testing.T) {
+	pvzID := uuid.New()
+	cellID := uuid.New()
+	createdAt := time.Date(2026, 5, 4, 9, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name               string
+		pvzID              string
+		preparePVZService  func(mockService *mocks.PVZ)
+		expectedHTTPStatus int
+		expectedResponse   any
+	}{
+		{
+			name:  "successful list",
+			pvzID: pvzID.String(),
+			preparePVZService: func(mockService *mocks.PVZ) {
+				mockService.On("ListCells", mock.Anything, pvzID.String()).Return([]entity.StorageCell{
+					{ID: cellID, PVZID: pvzID, Shelf: "A", Code: "A-01", Capacity: 10, Occupied: 7,
+						CreatedAt: createdAt},
+				}, nil)
+			},
+			expectedHTTPStatus: http.StatusOK,
+			expectedResponse: listStorageCellsResponse{Cells: []storageCellDTO{{
+				ID:        cellID.String(),
+				PVZID:     pvzID.String(),
+				Shelf:     "A",
+				Code:      "A-01",
+				Capacity:  10,
+				Occupied:  7,
+				Free:      3,
+				CreatedAt: "2026-05-04T09:00:00Z",
+			}}},
+		},
+		{
+			name:               "invalid pvz id",
+			pvzID:              "not-a-uuid",
+			preparePVZService:  func(mockService *mocks.PVZ) {},
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse:   httpresponse.ErrorResponse{Error: "invalid pvz id"},
+		},
+		{
+			name:  "pvz not found",
+			pvzID: pvzID.String(),
+			preparePVZService: func(mockService *mocks.PVZ) {
+				mockService.On("ListCells", mock.Anything, pvzID.String()).Return(nil, service.ErrInvalidPVZID)
+			},
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse:   httpresponse.ErrorResponse{Error: "invalid pvz id"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pvzService := mocks.NewPVZ(t)
+			tc.preparePVZService(pvzService)
+
+			handler := newPVZHandler(pvzService)
+
+			r := chi.NewRouter()
+			r.Get("/pvz/{pvzId}/cells", handler.listCells)
+			req := httptest.NewRequest("GET", "/pvz/"+tc.pvzID+"/cells", nil)
+			rec := httptest.NewRecorder()
+
+			r.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedHTTPStatus, rec.Code)
+
+			if tc.expectedHTTPStatus == http.StatusOK {
+				var actualResponse listStorageCellsResponse
+				if err := json.NewDecoder(rec.Body).Decode(&actualResponse); err != nil {
+					t.Fatalf("failed to decode response body: %v", err)
+				}
+				assert.Equal(t, tc.expectedResponse, actualResponse)
+			} else {
+				var actualResponse httpresponse.ErrorResponse
+				if err := json.NewDecoder(rec.Body).Decode(&actualResponse); err != nil {
+					t.Fatalf("failed to decode response body: %v", err)
+				}
+				assert.Equal(t, tc.expectedResponse, actualResponse)
+			}
+		})
+	}
+}
+
+func TestAssignCell(t *testing.T) {
+	userID := uuid.New()
+	productID := uuid.New()
+	cellID := uuid.New()
+	claims := &entity.UserClaims{UserID: userID, Role: entity.RoleEmployee}
+	addedAt := time.Date(2026, 5, 4, 12, 0, 0, 0, time.UTC)
+	moved := &entity.Product{ID: productID, DateTime: addedAt, Type: "обувь", ReceptionID: uuid.New(),
+		Status: entity.ProductStatusStored, CellID: cellID, CellShelf: "B", CellCode: "B-02", AddedBy: uuid.New()}
+	params := entity.CellAssignment{ProductID: productID.String(), CellID: cellID.String(), UserID: userID}
+
+	testCases := []struct {
+		name                  string
+		productID             string
+		request               any
+		prepareProductService func(mockService *mocks.Product)
+		expectedHTTPStatus    int
+		expectedResponse      any
+	}{
+		{
+			name:      "successful reassignment",
+			productID: productID.String(),
+			request:   assignCellRequest{CellID: cellID.String()},
+			prepareProductService: func(mockService *mocks.Product) {
+				mockService.On("AssignCell", mock.Anything, params).Return(moved, nil)
+			},
+			expectedHTTPStatus: http.StatusOK,
+			expectedResponse:   newCreateProductResponse(moved),
+		},
+		{
+			name:                  "invalid product id",
+			productID:             "invalid",
+			request:               assignCellRequest{CellID: cellID.String()},
+			prepareProductService: func(mockService *mocks.Product) {},
+			expectedHTTPStatus:    http.StatusBadRequest,
+			expectedResponse:      httpresponse.ErrorResponse{Error: "invalid product id"},
+		},
+		{
+			name:                  "invalid cell id",
+			productID:             productID.String(),
+			request:               assignCellRequest{CellID: "invalid"},
+			prepareProductService: func(mockService *mocks.Product) {},
+			expectedHTTPStatus:    http.StatusBadRequest,
+			expectedResponse:      httpresponse.ErrorResponse{Error: "invalid cell id"},
+		},
+		{
+			name:      "cell type mismatch",
+			productID: productID.String(),
+			request:   assignCellRequest{CellID: cellID.String()},
+			prepareProductService: func(mockService *mocks.Product) {
+				mockService.On("AssignCell", mock.Anything, params).Return(nil, service.ErrCellTypeMismatch)
+			},
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse:   httpresponse.ErrorResponse{Error: "storage cell does not accept this product type"},
+		},
+		{
+			name:      "cell not found",
+			productID: productID.String(),
+			request:   assignCellRequest{CellID: cellID.String()},
+			prepareProductService: func(mockService *mocks.Product) {
+				mockService.On("AssignCell", mock.Anything, params).Return(nil, service.ErrStorageCellNotFound)
+			},
+			expectedHTTPStatus: http.StatusNotFound,
+			expectedResponse:   httpresponse.ErrorResponse{Error: "storage cell not found"},
+		},
+		{
+			name:      "cell is full",
+			productID: productID.String(),
+			request:   assignCellRequest{CellID: cellID.String()},
+			prepareProductService: func(mockService *mocks.Product) {
+				mockService.On("AssignCell", mock.Anything, params).Return(nil, service.ErrStorageCellFull)
+			},
+			expectedHTTPStatus: http.StatusConflict,
+			expectedResponse:   httpresponse.ErrorResponse{Error: "storage cell is full"},
+		},
+		{
+			name:      "product not stored",
+			productID: productID.String(),
+			request:   assignCellRequest{CellID: cellID.String()},
+			prepareProductService: func(mockService *mocks.Product) {
+				mockService.On("AssignCell", mock.Anything, params).Return(nil, service.ErrProductNotStored)
+			},
+			expectedHTTPStatus: http.StatusConflict,
+			expectedResponse:   httpresponse.ErrorResponse{Error: "product is not stored"},
+		},
+		{
+			name:      "internal error",
+			productID: productID.String(),
+			request:   assignCellRequest{CellID: cellID.String()},
+			prepareProductService: func(mockService *mocks.Product) {
+				mockService.On("AssignCell", mock.Anything, params).Return(nil, service.ErrInternal)
+			},
+			expectedHTTPStatus: http.StatusInternalServerError,
+			expectedResponse:   httpresponse.ErrorResponse{Error: "internal server error"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			productService := mocks.NewProduct(t)
+			tc.prepareProductService(productService)
+
+			handler := newProductHandler(productService)
+
+			reqBody, err := json.Marshal(tc.request)
+			if err != nil {
+				t.Fatalf("failed to marshal request: %v", err)
+			}
+			r := chi.NewRouter()
+			r.Put("/products/{productId}/cell", handler.assignCell)
+			req := httptest.NewRequest(http.MethodPut, "/products/"+tc.productID+"/cell", bytes.NewReader(reqBody))
+			req = req.WithContext(context.WithValue(req.Context(), middleware.ClaimsContext, claims))
+			rec := httptest.NewRecorder()
+
+			r.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedHTTPStatus, rec.Code)
+			switch expected := tc.expectedResponse.(type) {
+			case createProductResponse:
+				var actualResponse createProductResponse
+				if err := json.NewDecoder(rec.Body).Decode(&actualResponse); err != nil {
+					t.Fatalf("failed to decode response body: %v", err)
+				}
+				assert.Equal(t, expected, actualResponse)
+				assert.Equal(t, &productCellResponse{ID: cellID.String(), Shelf: "B", Code: "B-02"}, actualResponse.Cell)
+			default:
+				var actualResponse httpresponse.ErrorResponse
+				if err := json.NewDecoder(rec.Body).Decode(&actualResponse); err != nil {
+					t.Fatalf("failed to decode response body: %v", err)
+				}
+				assert.Equal(t, expected, actualResponse)
+			}
+		})
+	}
+}
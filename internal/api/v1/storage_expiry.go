@@ -0,0 +1,126 @@
+package v1
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/api/middleware"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/entity"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/service"
+	"github.com/GlebMoskalev/go-pickup-point-api/pkg/httpresponse"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"net/http"
+)
+
+// @Description Запрос на возврат товара отправителю
+type returnToSenderRequest struct {
+	// Идентификатор ПВЗ, из которого отправляется товар
+	// format: uuid
+	PVZID string `json:"pvzId"`
+}
+
+// @Summary Очередь на возврат отправителю
+// @Description Доступно для сотрудников и модераторов. Возвращает хранящиеся в ПВЗ товары с истёкшим сроком хранения, начиная с самых давних.
+// @Tags pvz
+// @Produce json
+// @Param pvzId path string true "Идентификатор ПВЗ"
+// @Success 200 {array} createProductResponse
+// @Failure 400 {object} httpresponse.ErrorResponse "Неверный идентификатор ПВЗ"
+// @Failure 401 {object} httpresponse.ErrorResponse "Пользователь не авторизован"
+// @Failure 403 {object} httpresponse.ErrorResponse "Доступ запрещён"
+// @Failure 500 {object} httpresponse.ErrorResponse "Внутренняя ошибка сервера"
+// @Security JWT
+// @Router /api/v1/pvz/{pvzId}/overdue [get]
+func (h *productHandler) listOverdue(w http.ResponseWriter, r *http.Request) {
+	pvzID := chi.URLParam(r, "pvzId")
+	if _, err := uuid.Parse(pvzID); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid pvz id")
+		return
+	}
+
+	products, err := h.productService.ListOverdue(r.Context(), pvzID)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidPVZID):
+			httpresponse.Error(w, http.StatusBadRequest, "invalid pvz id")
+		default:
+			httpresponse.Error(w, http.StatusInternalServerError, "internal server error")
+		}
+		return
+	}
+
+	resp := make([]createProductResponse, len(products))
+	for i := range products {
+		resp[i] = newCreateProductResponse(&products[i])
+	}
+	httpresponse.JSON(w, http.StatusOK, resp)
+}
+
+// @Summary Возврат товара отправителю
+// @Description Только для сотрудников ПВЗ. Подтверждает отправку товара из очереди на возврат обратно отправителю, сохраняя, кто и когда его отправил. Товар должен храниться в этом ПВЗ и иметь истёкший срок хранения.
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param productId path string true "Идентификатор товара"
+// @Param input body returnToSenderRequest true "ПВЗ"
+// @Success 200 {object} createProductResponse
+// @Failure 400 {object} httpresponse.ErrorResponse "Неверный идентификатор товара или ПВЗ"
+// @Failure 401 {object} httpresponse.ErrorResponse "Пользователь не авторизован"
+// @Failure 403 {object} httpresponse.ErrorResponse "Доступ запрещён"
+// @Failure 404 {object} httpresponse.ErrorResponse "Товар не найден в этом ПВЗ"
+// @Failure 409 {object} productStatusConflictResponse "Товар не хранится или срок его хранения не истёк"
+// @Failure 500 {object} httpresponse.ErrorResponse "Внутренняя ошибка сервера"
+// @Security JWT
+// @Router /api/v1/products/{productId}/return-to-sender [post]
+func (h *productHandler) returnToSender(w http.ResponseWriter, r *http.Request) {
+	productID := chi.URLParam(r, "productId")
+	if _, err := uuid.Parse(productID); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid product id")
+		return
+	}
+
+	var req returnToSenderRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if _, err := uuid.Parse(req.PVZID); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid pvz id")
+		return
+	}
+
+	claims, ok := middleware.UserClaimsFromContext(r.Context())
+	if !ok {
+		httpresponse.Error(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	product, err := h.productService.ReturnToSender(r.Context(), entity.ReturnToSenderParams{
+		ProductID:  productID,
+		PVZID:      req.PVZID,
+		ReturnedBy: claims.UserID,
+	})
+	if err != nil {
+		var transitionErr *service.TransitionError
+		switch {
+		case errors.As(err, &transitionErr):
+			httpresponse.JSON(w, http.StatusConflict, productStatusConflictResponse{
+				Error:         "product is not stored",
+				CurrentStatus: transitionErr.Current,
+			})
+		case errors.Is(err, service.ErrInvalidTransition):
+			httpresponse.JSON(w, http.StatusConflict, productStatusConflictResponse{Error: "product is not stored"})
+		case errors.Is(err, service.ErrProductNotOverdue):
+			httpresponse.Error(w, http.StatusConflict, "product storage period has not expired")
+		case errors.Is(err, service.ErrInvalidPVZID):
+			httpresponse.Error(w, http.StatusBadRequest, "invalid pvz id")
+		case errors.Is(err, service.ErrProductNotFound):
+			httpresponse.Error(w, http.StatusNotFound, "product not found")
+		default:
+			httpresponse.Error(w, http.StatusInternalServerError, "internal server error")
+		}
+		return
+	}
+
+	httpresponse.JSON(w, http.StatusOK, newCreateProductResponse(product))
+}
@@ -0,0 +1,244 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/api/middleware"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/entity"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/service"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/service/mocks"
+	"github.com/GlebMoskalev/go-pickup-point-api/pkg/httpresponse"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestListOverdue(t *testing.T) {
+	pvzID := uuid.New()
+	overdueAt := time.Date(2026, 5, 10, 3, 0, 0, 0, time.UTC)
+	overdue := []entity.Product{{ID: uuid.New(), DateTime: overdueAt.AddDate(0, 0, -8), Type: "обувь",
+		ReceptionID: uuid.New(), PVZID: pvzID, OrderNumber: 1, Status: entity.ProductStatusStored,
+		AddedBy: uuid.New(), OverdueAt: &overdueAt}}
+
+	testCases := []struct {
+		name                  string
+		pvzID                 string
+		prepareProductService func(mockService *mocks.Product)
+		expectedHTTPStatus    int
+		expectedResponse      any
+	}{
+		{
+			name:  "queue returned",
+			pvzID: pvzID.String(),
+			prepareProductService: func(mockService *mocks.Product) {
+				mockService.On("ListOverdue", mock.Anything, pvzID.String()).Return(overdue, nil)
+			},
+			expectedHTTPStatus: http.StatusOK,
+			expectedResponse:   []createProductResponse{newCreateProductResponse(&overdue[0])},
+		},
+		{
+			name:                  "invalid pvz id",
+			pvzID:                 "not-a-uuid",
+			prepareProductService: func(mockService *mocks.Product) {},
+			expectedHTTPStatus:    http.StatusBadRequest,
+			expectedResponse:      httpresponse.ErrorResponse{Error: "invalid pvz id"},
+		},
+		{
+			name:  "unknown pvz",
+			pvzID: pvzID.String(),
+			prepareProductService: func(mockService *mocks.Product) {
+				mockService.On("ListOverdue", mock.Anything, pvzID.String()).Return(nil, service.ErrInvalidPVZID)
+			},
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse:   httpresponse.ErrorResponse{Error: "invalid pvz id"},
+		},
+		{
+			name:  "internal server error",
+			pvzID: pvzID.String(),
+			prepareProductService: func(mockService *mocks.Product) {
+				mockService.On("ListOverdue", mock.Anything, pvzID.String()).Return(nil, errors.New("database error"))
+			},
+			expectedHTTPStatus: http.StatusInternalServerError,
+			expectedResponse:   httpresponse.ErrorResponse{Error: "internal server error"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			productService := mocks.NewProduct(t)
+			tc.prepareProductService(productService)
+
+			handler := newProductHandler(productService)
+
+			r := chi.NewRouter()
+			r.Get("/pvz/{pvzId}/overdue", handler.listOverdue)
+			req := httptest.NewRequest(http.MethodGet, "/pvz/"+tc.pvzID+"/overdue", nil)
+			rec := httptest.NewRecorder()
+
+			r.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedHTTPStatus, rec.Code)
+
+			switch expected := tc.expectedResponse.(type) {
+			case []createProductResponse:
+				var actualResponse []createProductResponse
+				if err := json.NewDecoder(rec.Body).Decode(&actualResponse); err != nil {
+					t.Fatalf("failed to decode response body: %v", err)
+				}
+				assert.Equal(t, expected, actualResponse)
+				assert.Equal(t, "2026-05-10T03:00:00Z", actualResponse[0].OverdueAt)
+			default:
+				var actualResponse httpresponse.ErrorResponse
+				if err := json.NewDecoder(rec.Body).Decode(&actualResponse); err != nil {
+					t.Fatalf("failed to decode response body: %v", err)
+				}
+				assert.Equal(t, expected, actualResponse)
+			}
+		})
+	}
+}
+
+func TestReturnToSender(t *testing.T) {
+	userID := uuid.New()
+	pvzID := uuid.New().String()
+	productID := uuid.New()
+	claims := &entity.UserClaims{UserID: userID, Role: entity.RoleEmployee}
+	returnedAt := time.Date(2026, 5, 12, 10, 0, 0, 0, time.UTC)
+	overdueAt := returnedAt.AddDate(0, 0, -2)
+	returned := &entity.Product{ID: productID, DateTime: returnedAt.AddDate(0, 0, -10), Type: "обувь",
+		ReceptionID: uuid.New(), OrderNumber: 2, Status: entity.ProductStatusReturnedToSender, AddedBy: uuid.New(),
+		OverdueAt: &overdueAt, ReturnedBy: userID, ReturnedAt: &returnedAt}
+	validRequest := returnToSenderRequest{PVZID: pvzID}
+	params := entity.ReturnToSenderParams{ProductID: productID.String(), PVZID: pvzID, ReturnedBy: userID}
+
+	testCases := []struct {
+		name                  string
+		productID             string
+		request               any
+		prepareProductService func(mockService *mocks.Product)
+		expectedHTTPStatus    int
+		expectedResponse      any
+	}{
+		{
+			name:      "successful return",
+			productID: productID.String(),
+			request:   validRequest,
+			prepareProductService: func(mockService *mocks.Product) {
+				mockService.On("ReturnToSender", mock.Anything, params).Return(returned, nil)
+			},
+			expectedHTTPStatus: http.StatusOK,
+			expectedResponse:   newCreateProductResponse(returned),
+		},
+		{
+			name:                  "invalid product id",
+			productID:             "not-a-uuid",
+			request:               validRequest,
+			prepareProductService: func(mockService *mocks.Product) {},
+			expectedHTTPStatus:    http.StatusBadRequest,
+			expectedResponse:      httpresponse.ErrorResponse{Error: "invalid product id"},
+		},
+		{
+			name:                  "invalid pvz id",
+			productID:             productID.String(),
+			request:               returnToSenderRequest{PVZID: "not-a-uuid"},
+			prepareProductService: func(mockService *mocks.Product) {},
+			expectedHTTPStatus:    http.StatusBadRequest,
+			expectedResponse:      httpresponse.ErrorResponse{Error: "invalid pvz id"},
+		},
+		{
+			name:      "product not found",
+			productID: productID.String(),
+			request:   validRequest,
+			prepareProductService: func(mockService *mocks.Product) {
+				mockService.On("ReturnToSender", mock.Anything, params).Return(nil, service.ErrProductNotFound)
+			},
+			expectedHTTPStatus: http.StatusNotFound,
+			expectedResponse:   httpresponse.ErrorResponse{Error: "product not found"},
+		},
+		{
+			name:      "storage period not expired",
+			productID: productID.String(),
+			request:   validRequest,
+			prepareProductService: func(mockService *mocks.Product) {
+				mockService.On("ReturnToSender", mock.Anything, params).Return(nil, service.ErrProductNotOverdue)
+			},
+			expectedHTTPStatus: http.StatusConflict,
+			expectedResponse:   httpresponse.ErrorResponse{Error: "product storage period has not expired"},
+		},
+		{
+			name:      "already issued",
+			productID: productID.String(),
+			request:   validRequest,
+			prepareProductService: func(mockService *mocks.Product) {
+				mockService.On("ReturnToSender", mock.Anything, params).Return(nil, &service.TransitionError{
+					Current: entity.ProductStatusIssued, Event: entity.ProductEventReturnToSender})
+			},
+			expectedHTTPStatus: http.StatusConflict,
+			expectedResponse: productStatusConflictResponse{Error: "product is not stored",
+				CurrentStatus: entity.ProductStatusIssued},
+		},
+		{
+			name:      "internal server error",
+			productID: productID.String(),
+			request:   validRequest,
+			prepareProductService: func(mockService *mocks.Product) {
+				mockService.On("ReturnToSender", mock.Anything, params).Return(nil, errors.New("database error"))
+			},
+			expectedHTTPStatus: http.StatusInternalServerError,
+			expectedResponse:   httpresponse.ErrorResponse{Error: "internal server error"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			productService := mocks.NewProduct(t)
+			tc.prepareProductService(productService)
+
+			handler := newProductHandler(productService)
+
+			reqBody, err := json.Marshal(tc.request)
+			if err != nil {
+				t.Fatalf("failed to marshal request: %v", err)
+			}
+			r := chi.NewRouter()
+			r.Post("/products/{productId}/return-to-sender", handler.returnToSender)
+			req := httptest.NewRequest(http.MethodPost, "/products/"+tc.productID+"/return-to-sender",
+				bytes.NewReader(reqBody))
+			req = req.WithContext(context.WithValue(req.Context(), middleware.ClaimsContext, claims))
+			rec := httptest.NewRecorder()
+
+			r.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedHTTPStatus, rec.Code)
+
+			switch expected := tc.expectedResponse.(type) {
+			case createProductResponse:
+				var actualResponse createProductResponse
+				if err := json.NewDecoder(rec.Body).Decode(&actualResponse); err != nil {
+					t.Fatalf("failed to decode response body: %v", err)
+				}
+				assert.Equal(t, expected, actualResponse)
+				assert.Equal(t, userID.String(), actualResponse.ReturnedBy)
+			case productStatusConflictResponse:
+				var actualResponse productStatusConflictResponse
+				if err := json.NewDecoder(rec.Body).Decode(&actualResponse); err != nil {
+					t.Fatalf("failed to decode response body: %v", err)
+				}
+				assert.Equal(t, expected, actualResponse)
+			default:
+				var actualResponse httpresponse.ErrorResponse
+				if err := json.NewDecoder(rec.Body).Decode(&actualResponse); err != nil {
+					t.Fatalf("failed to decode response body: %v", err)
+				}
+				assert.Equal(t, expected, actualResponse)
+			}
+		})
+	}
+}
@@ -0,0 +1,419 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/api/middleware"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/entity"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/service"
+	"github.com/GlebMoskalev/go-pickup-point-api/pkg/httpresponse"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"net/http"
+	"time"
+)
+
+func SetupTransferRoutes(r chi.Router, transferService service.Transfer) {
+	handler := newTransferHandler(transferService)
+
+	r.With(middleware.RoleMiddleware(entity.RoleEmployee, entity.RoleModerator)).
+		Post("/", handler.createTransfer)
+
+	r.With(middleware.RoleMiddleware(entity.RoleEmployee, entity.RoleModerator)).
+		Get("/{transferId}", handler.getTransfer)
+
+	r.With(middleware.RoleMiddleware(entity.RoleEmployee, entity.RoleModerator)).
+		Post("/{transferId}/dispatch", handler.dispatchTransfer)
+
+	r.With(middleware.RoleMiddleware(entity.RoleEmployee, entity.RoleModerator)).
+		Post("/{transferId}/cancel", handler.cancelTransfer)
+
+	r.With(middleware.RoleMiddleware(entity.RoleEmployee)).
+		Post("/{transferId}/items/{productId}/receive", handler.receiveTransferItem)
+}
+
+type transferHandler struct {
+	transferService service.Transfer
+}
+
+func newTransferHandler(transferService service.Transfer) *transferHandler {
+	return &transferHandler{transferService: transferService}
+}
+
+// @Description Запрос на перемещение товаров в другой ПВЗ
+type createTransferRequest struct {
+	// Идентификатор ПВЗ отправления
+	// format: uuid
+	FromPVZID string `json:"fromPvzId"`
+	// Идентификатор ПВЗ назначения
+	// format: uuid
+	ToPVZID string `json:"toPvzId"`
+	// Идентификаторы перемещаемых товаров, от 1 до 1000
+	ProductIDs []string `json:"productIds"`
+}
+
+// @Description Товар перемещения
+type transferItemResponse struct {
+	// Идентификатор товара
+	// format: uuid
+	ProductID string `json:"productId"`
+	// Тип товара
+	Type string `json:"type"`
+	// Штрихкод товара
+	Barcode string `json:"barcode,omitempty"`
+	// Кто принял товар в ПВЗ назначения
+	// format: uuid
+	ReceivedBy string `json:"receivedBy,omitempty"`
+	// Время приёмки товара в ПВЗ назначения
+	// format: date-time
+	ReceivedAt string `json:"receivedAt,omitempty"`
+}
+
+// @Description Перемещение товаров между ПВЗ
+type transferResponse struct {
+	// Идентификатор перемещения
+	// format: uuid
+	ID string `json:"id"`
+	// Идентификатор ПВЗ отправления
+	// format: uuid
+	FromPVZID string `json:"fromPvzId"`
+	// Идентификатор ПВЗ назначения
+	// format: uuid
+	ToPVZID string `json:"toPvzId"`
+	// Статус перемещения
+	Status string `json:"status" enums:"created,in_transit,received,cancelled"`
+	// Приёмка в ПВЗ назначения, заводится при отправке
+	// format: uuid
+	ReceptionID string `json:"receptionId,omitempty"`
+	// Кто создал перемещение
+	// format: uuid
+	CreatedBy string `json:"createdBy,omitempty"`
+	// Время создания
+	// format: date-time
+	CreatedAt string `json:"createdAt"`
+	// Кто отправил товары
+	// format: uuid
+	DispatchedBy string `json:"dispatchedBy,omitempty"`
+	// Время отправки
+	// format: date-time
+	DispatchedAt string `json:"dispatchedAt,omitempty"`
+	// Время приёмки всех товаров
+	// format: date-time
+	ReceivedAt string `json:"receivedAt,omitempty"`
+	// Товары перемещения
+	Items []transferItemResponse `json:"items"`
+}
+
+func newTransferResponse(transfer *entity.Transfer) transferResponse {
+	resp := transferResponse{
+		ID:           transfer.ID.String(),
+		FromPVZID:    transfer.FromPVZID.String(),
+		ToPVZID:      transfer.ToPVZID.String(),
+		Status:       transfer.Status,
+		ReceptionID:  uuidString(transfer.ReceptionID),
+		CreatedBy:    uuidString(transfer.CreatedBy),
+		CreatedAt:    transfer.CreatedAt.Format(time.RFC3339),
+		DispatchedBy: uuidString(transfer.DispatchedBy),
+		Items:        make([]transferItemResponse, len(transfer.Items)),
+	}
+	if transfer.DispatchedAt != nil {
+		resp.DispatchedAt = transfer.DispatchedAt.Format(time.RFC3339)
+	}
+	if transfer.ReceivedAt != nil {
+		resp.ReceivedAt = transfer.ReceivedAt.Format(time.RFC3339)
+	}
+	for i, item := range transfer.Items {
+		resp.Items[i] = transferItemResponse{
+			ProductID:  item.ProductID.String(),
+			Type:       item.Type,
+			Barcode:    item.Barcode,
+			ReceivedBy: uuidString(item.ReceivedBy),
+		}
+		if item.ReceivedAt != nil {
+			resp.Items[i].ReceivedAt = item.ReceivedAt.Format(time.RFC3339)
+		}
+	}
+	return resp
+}
+
+// @Summary Создание перемещения
+// @Description Доступно для сотрудников и модераторов. Заводит заказ на перемещение хранящихся товаров из одного ПВЗ в другой, например при переполнении или закрытии ПВЗ. Товары должны храниться в ПВЗ отправления, относиться к закрытым приёмкам и не входить в другие незавершённые перемещения.
+// @Tags transfers
+// @Accept json
+// @Produce json
+// @Param input body createTransferRequest true "ПВЗ и товары"
+// @Success 201 {object} transferResponse
+// @Failure 400 {object} httpresponse.ErrorResponse "Неверный запрос, идентификатор ПВЗ или список товаров"
+// @Failure 401 {object} httpresponse.ErrorResponse "Пользователь не авторизован"
+// @Failure 403 {object} httpresponse.ErrorResponse "Доступ запрещён"
+// @Failure 404 {object} httpresponse.ErrorResponse "Товар не найден в ПВЗ отправления"
+// @Failure 409 {object} httpresponse.ErrorResponse "Товар не хранится, его приёмка не закрыта или он уже перемещается"
+// @Failure 500 {object} httpresponse.ErrorResponse "Внутренняя ошибка сервера"
+// @Security JWT
+// @Router /api/v1/transfers [post]
+func (h *transferHandler) createTransfer(w http.ResponseWriter, r *http.Request) {
+	var req createTransferRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	claims, ok := middleware.UserClaimsFromContext(r.Context())
+	if !ok {
+		httpresponse.Error(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	transfer, err := h.transferService.Create(r.Context(), entity.TransferParams{
+		FromPVZID:  req.FromPVZID,
+		ToPVZID:    req.ToPVZID,
+		ProductIDs: req.ProductIDs,
+		CreatedBy:  claims.UserID,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidPVZID):
+			httpresponse.Error(w, http.StatusBadRequest, "invalid pvz id")
+		case errors.Is(err, service.ErrInvalidTransfer):
+			httpresponse.Error(w, http.StatusBadRequest, "invalid transfer")
+		case errors.Is(err, service.ErrProductNotFound):
+			httpresponse.Error(w, http.StatusNotFound, "product not found")
+		case errors.Is(err, service.ErrProductNotStored):
+			httpresponse.Error(w, http.StatusConflict, "product is not stored")
+		case errors.Is(err, service.ErrProductNotReceived):
+			httpresponse.Error(w, http.StatusConflict, "product reception is not closed")
+		case errors.Is(err, service.ErrProductInTransfer):
+			httpresponse.Error(w, http.StatusConflict, "product is already in an active transfer")
+		default:
+			httpresponse.Error(w, http.StatusInternalServerError, "internal server error")
+		}
+		return
+	}
+
+	httpresponse.JSON(w, http.StatusCreated, newTransferResponse(transfer))
+}
+
+// @Summary Перемещение
+// @Description Доступно для сотрудников и модераторов. Возвращает перемещение с товарами и отметками об их приёмке.
+// @Tags transfers
+// @Produce json
+// @Param transferId path string true "Идентификатор перемещения"
+// @Success 200 {object} transferResponse
+// @Failure 400 {object} httpresponse.ErrorResponse "Неверный идентификатор перемещения"
+// @Failure 401 {object} httpresponse.ErrorResponse "Пользователь не авторизован"
+// @Failure 403 {object} httpresponse.ErrorResponse "Доступ запрещён"
+// @Failure 404 {object} httpresponse.ErrorResponse "Перемещение не найдено"
+// @Failure 500 {object} httpresponse.ErrorResponse "Внутренняя ошибка сервера"
+// @Security JWT
+// @Router /api/v1/transfers/{transferId} [get]
+func (h *transferHandler) getTransfer(w http.ResponseWriter, r *http.Request) {
+	transferID := chi.URLParam(r, "transferId")
+	if _, err := uuid.Parse(transferID); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid transfer id")
+		return
+	}
+
+	transfer, err := h.transferService.Get(r.Context(), transferID)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrTransferNotFound):
+			httpresponse.Error(w, http.StatusNotFound, "transfer not found")
+		default:
+			httpresponse.Error(w, http.StatusInternalServerError, "internal server error")
+		}
+		return
+	}
+
+	httpresponse.JSON(w, http.StatusOK, newTransferResponse(transfer))
+}
+
+// @Summary Отправка перемещения
+// @Description Доступно для сотрудников и модераторов. Переводит товары в статус in_transit и заводит в ПВЗ назначения черновик приёмки с манифестом по отправленным товарам. Отправить можно только созданное перемещение.
+// @Tags transfers
+// @Produce json
+// @Param transferId path string true "Идентификатор перемещения"
+// @Success 200 {object} transferResponse
+// @Failure 400 {object} httpresponse.ErrorResponse "Неверный идентификатор перемещения"
+// @Failure 401 {object} httpresponse.ErrorResponse "Пользователь не авторизован"
+// @Failure 403 {object} httpresponse.ErrorResponse "Доступ запрещён"
+// @Failure 404 {object} httpresponse.ErrorResponse "Перемещение или товар не найдены"
+// @Failure 409 {object} httpresponse.ErrorResponse "Перемещение уже отправлено или отменено, либо товар больше не хранится"
+// @Failure 500 {object} httpresponse.ErrorResponse "Внутренняя ошибка сервера"
+// @Security JWT
+// @Router /api/v1/transfers/{transferId}/dispatch [post]
+func (h *transferHandler) dispatchTransfer(w http.ResponseWriter, r *http.Request) {
+	h.changeTransfer(w, r, h.transferService.Dispatch)
+}
+
+// @Summary Отмена перемещения
+// @Description Доступно для сотрудников и модераторов. Отменяет перемещение, пока товары не отправлены.
+// @Tags transfers
+// @Produce json
+// @Param transferId path string true "Идентификатор перемещения"
+// @Success 200 {object} transferResponse
+// @Failure 400 {object} httpresponse.ErrorResponse "Неверный идентификатор перемещения"
+// @Failure 401 {object} httpresponse.ErrorResponse "Пользователь не авторизован"
+// @Failure 403 {object} httpresponse.ErrorResponse "Доступ запрещён"
+// @Failure 404 {object} httpresponse.ErrorResponse "Перемещение не найдено"
+// @Failure 409 {object} httpresponse.ErrorResponse "Перемещение уже отправлено или отменено"
+// @Failure 500 {object} httpresponse.ErrorResponse "Внутренняя ошибка сервера"
+// @Security JWT
+// @Router /api/v1/transfers/{transferId}/cancel [post]
+func (h *transferHandler) cancelTransfer(w http.ResponseWriter, r *http.Request) {
+	h.changeTransfer(w, r, h.transferService.Cancel)
+}
+
+func (h *transferHandler) changeTransfer(w http.ResponseWriter, r *http.Request,
+	change func(ctx context.Context, transferID string, userID uuid.UUID) (*entity.Transfer, error)) {
+	transferID := chi.URLParam(r, "transferId")
+	if _, err := uuid.Parse(transferID); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid transfer id")
+		return
+	}
+
+	claims, ok := middleware.UserClaimsFromContext(r.Context())
+	if !ok {
+		httpresponse.Error(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	transfer, err := change(r.Context(), transferID, claims.UserID)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrTransferNotFound):
+			httpresponse.Error(w, http.StatusNotFound, "transfer not found")
+		case errors.Is(err, service.ErrInvalidTransferStatus):
+			httpresponse.Error(w, http.StatusConflict, "invalid transfer status")
+		case errors.Is(err, service.ErrProductNotFound):
+			httpresponse.Error(w, http.StatusNotFound, "product not found")
+		case errors.Is(err, service.ErrProductNotStored):
+			httpresponse.Error(w, http.StatusConflict, "product is not stored")
+		default:
+			httpresponse.Error(w, http.StatusInternalServerError, "internal server error")
+		}
+		return
+	}
+
+	httpresponse.JSON(w, http.StatusOK, newTransferResponse(transfer))
+}
+
+// @Summary Приёмка товара перемещения
+// @Description Только для сотрудников ПВЗ. Принимает отправленный товар в приёмку ПВЗ назначения: товар заводится в ней новой строкой с новым кодом получения и ячейкой, а в приёмке отправления остаётся в статусе transferred. Приёмку нужно предварительно начать. Когда приняты все товары, перемещение завершается.
+// @Tags transfers
+// @Produce json
+// @Param transferId path string true "Идентификатор перемещения"
+// @Param productId path string true "Идентификатор товара"
+// @Success 200 {object} createProductResponse
+// @Failure 400 {object} httpresponse.ErrorResponse "Неверный идентификатор перемещения или товара"
+// @Failure 401 {object} httpresponse.ErrorResponse "Пользователь не авторизован"
+// @Failure 403 {object} httpresponse.ErrorResponse "Доступ запрещён"
+// @Failure 404 {object} httpresponse.ErrorResponse "Перемещение или товар не найдены"
+// @Failure 409 {object} httpresponse.ErrorResponse "Перемещение не отправлено, товар уже принят, приёмка не начата или товар с таким штрихкодом уже хранится"
+// @Failure 500 {object} httpresponse.ErrorResponse "Внутренняя ошибка сервера"
+// @Security JWT
+// @Router /api/v1/transfers/{transferId}/items/{productId}/receive [post]
+func (h *transferHandler) receiveTransferItem(w http.ResponseWriter, r *http.Request) {
+	transferID := chi.URLParam(r, "transferId")
+	if _, err := uuid.Parse(transferID); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid transfer id")
+		return
+	}
+	productID := chi.URLParam(r, "productId")
+	if _, err := uuid.Parse(productID); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid product id")
+		return
+	}
+
+	claims, ok := middleware.UserClaimsFromContext(r.Context())
+	if !ok {
+		httpresponse.Error(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	product, err := h.transferService.ReceiveItem(r.Context(), transferID, productID, claims.UserID)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrTransferNotFound):
+			httpresponse.Error(w, http.StatusNotFound, "transfer not found")
+		case errors.Is(err, service.ErrProductNotFound):
+			httpresponse.Error(w, http.StatusNotFound, "product not found")
+		case errors.Is(err, service.ErrInvalidTransferStatus):
+			httpresponse.Error(w, http.StatusConflict, "invalid transfer status")
+		case errors.Is(err, service.ErrTransferItemReceived):
+			httpresponse.Error(w, http.StatusConflict, "transfer item is already received")
+		case errors.Is(err, service.ErrInvalidReceptionStatus):
+			httpresponse.Error(w, http.StatusConflict, "reception is not open")
+		case errors.Is(err, service.ErrBarcodeExists):
+			httpresponse.Error(w, http.StatusConflict, "product with this barcode is already stored")
+		default:
+			httpresponse.Error(w, http.StatusInternalServerError, "internal server error")
+		}
+		return
+	}
+
+	httpresponse.JSON(w, http.StatusOK, newCreateProductResponse(product))
+}
+
+// @Description Запись истории местонахождения товара
+type productLocationResponse struct {
+	// ПВЗ, в котором хранится товар; пусто, пока товар в пути
+	// format: uuid
+	PVZID string `json:"pvzId,omitempty"`
+	// Приёмка, в которой товар хранится в ПВЗ
+	// format: uuid
+	ReceptionID string `json:"receptionId,omitempty"`
+	// Перемещение, с которым товар отправлен или принят
+	// format: uuid
+	TransferID string `json:"transferId,omitempty"`
+	// Кто изменил местонахождение
+	// format: uuid
+	ChangedBy string `json:"changedBy,omitempty"`
+	// Время изменения
+	// format: date-time
+	ChangedAt string `json:"changedAt"`
+}
+
+// @Summary История местонахождения товара
+// @Description Доступно для сотрудников и модераторов. Возвращает ПВЗ, в которых хранился товар, и перемещения между ними в хронологическом порядке.
+// @Tags products
+// @Produce json
+// @Param productId path string true "Идентификатор товара"
+// @Success 200 {array} productLocationResponse
+// @Failure 400 {object} httpresponse.ErrorResponse "Неверный идентификатор товара"
+// @Failure 401 {object} httpresponse.ErrorResponse "Пользователь не авторизован"
+// @Failure 403 {object} httpresponse.ErrorResponse "Доступ запрещён"
+// @Failure 404 {object} httpresponse.ErrorResponse "Товар не найден"
+// @Failure 500 {object} httpresponse.ErrorResponse "Внутренняя ошибка сервера"
+// @Security JWT
+// @Router /api/v1/products/{productId}/locations [get]
+func (h *productHandler) listProductLocations(w http.ResponseWriter, r *http.Request) {
+	productID := chi.URLParam(r, "productId")
+	if _, err := uuid.Parse(productID); err != nil {
+		httpresponse.Error(w, http.StatusBadRequest, "invalid product id")
+		return
+	}
+
+	locations, err := h.productService.ListLocations(r.Context(), productID)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrProductNotFound):
+			httpresponse.Error(w, http.StatusNotFound, "product not found")
+		default:
+			httpresponse.Error(w, http.StatusInternalServerError, "internal server error")
+		}
+		return
+	}
+
+	resp := make([]productLocationResponse, len(locations))
+	for i, location := range locations {
+		resp[i] = productLocationResponse{
+			PVZID:       uuidString(location.PVZID),
+			ReceptionID: uuidString(location.ReceptionID),
+			TransferID:  uuidString(location.TransferID),
+			ChangedBy:   uuidString(location.ChangedBy),
+			ChangedAt:   location.ChangedAt.Format(time.RFC3339),
+		}
+	}
+	httpresponse.JSON(w, http.StatusOK, resp)
+}
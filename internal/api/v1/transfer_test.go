@@ -0,0 +1,427 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/api/middleware"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/entity"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/service"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/service/mocks"
+	"github.com/GlebMoskalev/go-pickup-point-api/pkg/httpresponse"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestCreateTransfer(t *testing.T) {
+	userID := uuid.New()
+	transferID := uuid.New()
+	fromPVZID := uuid.New()
+	toPVZID := uuid.New()
+	productID := uuid.New()
+	createdAt := time.Date(2026, 6, 1, 10, 0, 0, 0, time.UTC)
+	validRequest := createTransferRequest{
+		FromPVZID:  fromPVZID.String(),
+		ToPVZID:    toPVZID.String(),
+		ProductIDs: []string{productID.String()},
+	}
+	params := entity.TransferParams{
+		FromPVZID:  fromPVZID.String(),
+		ToPVZID:    toPVZID.String(),
+		ProductIDs: []string{productID.String()},
+		CreatedBy:  userID,
+	}
+
+	testCases := []struct {
+		name                   string
+		request                any
+		prepareTransferService func(mockService *mocks.Transfer)
+		expectedHTTPStatus     int
+		expectedResponse       any
+	}{
+		{
+			name:    "successful creation",
+			request: validRequest,
+			prepareTransferService: func(mockService *mocks.Transfer) {
+				mockService.On("Create", mock.Anything, params).Return(&entity.Transfer{
+					ID:        transferID,
+					FromPVZID: fromPVZID,
+					ToPVZID:   toPVZID,
+					Status:    entity.TransferStatusCreated,
+					CreatedBy: userID,
+					CreatedAt: createdAt,
+					Items: []entity.TransferItem{
+						{ProductID: productID, Type: entity.ProductTypeShoes, Barcode: "4600000000011"},
+					},
+				}, nil)
+			},
+			expectedHTTPStatus: http.StatusCreated,
+			expectedResponse: transferResponse{
+				ID:        transferID.String(),
+				FromPVZID: fromPVZID.String(),
+				ToPVZID:   toPVZID.String(),
+				Status:    entity.TransferStatusCreated,
+				CreatedBy: userID.String(),
+				CreatedAt: "2026-06-01T10:00:00Z",
+				Items: []transferItemResponse{
+					{ProductID: productID.String(), Type: entity.ProductTypeShoes, Barcode: "4600000000011"},
+				},
+			},
+		},
+		{
+			name:                   "invalid request body",
+			request:                "invalid",
+			prepareTransferService: func(mockService *mocks.Transfer) {},
+			expectedHTTPStatus:     http.StatusBadRequest,
+			expectedResponse:       httpresponse.ErrorResponse{Error: "invalid request body"},
+		},
+		{
+			name:    "invalid transfer",
+			request: validRequest,
+			prepareTransferService: func(mockService *mocks.Transfer) {
+				mockService.On("Create", mock.Anything, params).Return(nil, service.ErrInvalidTransfer)
+			},
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse:   httpresponse.ErrorResponse{Error: "invalid transfer"},
+		},
+		{
+			name:    "product not found",
+			request: validRequest,
+			prepareTransferService: func(mockService *mocks.Transfer) {
+				mockService.On("Create", mock.Anything, params).Return(nil, service.ErrProductNotFound)
+			},
+			expectedHTTPStatus: http.StatusNotFound,
+			expectedResponse:   httpresponse.ErrorResponse{Error: "product not found"},
+		},
+		{
+			name:    "product in another transfer",
+			request: validRequest,
+			prepareTransferService: func(mockService *mocks.Transfer) {
+				mockService.On("Create", mock.Anything, params).Return(nil, service.ErrProductInTransfer)
+			},
+			expectedHTTPStatus: http.StatusConflict,
+			expectedResponse:   httpresponse.ErrorResponse{Error: "product is already in an active transfer"},
+		},
+		{
+			name:    "internal error",
+			request: validRequest,
+			prepareTransferService: func(mockService *mocks.Transfer) {
+				mockService.On("Create", mock.Anything, params).Return(nil, errors.New("unexpected"))
+			},
+			expectedHTTPStatus: http.StatusInternalServerError,
+			expectedResponse:   httpresponse.ErrorResponse{Error: "internal server error"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			transferService := mocks.NewTransfer(t)
+			tc.prepareTransferService(transferService)
+
+			handler := newTransferHandler(transferService)
+
+			reqBody, err := json.Marshal(tc.request)
+			if err != nil {
+				t.Fatalf("failed to marshal request: %v", err)
+			}
+			req := httptest.NewRequest("POST", "/transfers", bytes.NewReader(reqBody))
+			claims := &entity.UserClaims{UserID: userID, Role: entity.RoleModerator}
+			req = req.WithContext(context.WithValue(req.Context(), middleware.ClaimsContext, claims))
+			rec := httptest.NewRecorder()
+
+			handler.createTransfer(rec, req)
+
+			assert.Equal(t, tc.expectedHTTPStatus, rec.Code)
+
+			if tc.expectedHTTPStatus == http.StatusCreated {
+				var actualResponse transferResponse
+				if err := json.NewDecoder(rec.Body).Decode(&actualResponse); err != nil {
+					t.Fatalf("failed to decode response body: %v", err)
+				}
+				assert.Equal(t, tc.expectedResponse, actualResponse)
+			} else {
+				var actualResponse httpresponse.ErrorResponse
+				if err := json.NewDecoder(rec.Body).Decode(&actualResponse); err != nil {
+					t.Fatalf("failed to decode response body: %v", err)
+				}
+				assert.Equal(t, tc.expectedResponse, actualResponse)
+			}
+		})
+	}
+}
+
+func TestDispatchTransfer(t *testing.T) {
+	userID := uuid.New()
+	transferID := uuid.New()
+	receptionID := uuid.New()
+	dispatchedAt := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name                   string
+		transferID             string
+		prepareTransferService func(mockService *mocks.Transfer)
+		expectedHTTPStatus     int
+		expectedResponse       any
+	}{
+		{
+			name:       "successful dispatch",
+			transferID: transferID.String(),
+			prepareTransferService: func(mockService *mocks.Transfer) {
+				mockService.On("Dispatch", mock.Anything, transferID.String(), userID).Return(&entity.Transfer{
+					ID:           transferID,
+					Status:       entity.TransferStatusInTransit,
+					ReceptionID:  receptionID,
+					DispatchedBy: userID,
+					DispatchedAt: &dispatchedAt,
+				}, nil)
+			},
+			expectedHTTPStatus: http.StatusOK,
+			expectedResponse: transferResponse{
+				ID:           transferID.String(),
+				FromPVZID:    uuid.Nil.String(),
+				ToPVZID:      uuid.Nil.String(),
+				Status:       entity.TransferStatusInTransit,
+				ReceptionID:  receptionID.String(),
+				CreatedAt:    time.Time{}.Format(time.RFC3339),
+				DispatchedBy: userID.String(),
+				DispatchedAt: "2026-06-01T12:00:00Z",
+				Items:        []transferItemResponse{},
+			},
+		},
+		{
+			name:                   "invalid transfer id",
+			transferID:             "not-a-uuid",
+			prepareTransferService: func(mockService *mocks.Transfer) {},
+			expectedHTTPStatus:     http.StatusBadRequest,
+			expectedResponse:       httpresponse.ErrorResponse{Error: "invalid transfer id"},
+		},
+		{
+			name:       "transfer not found",
+			transferID: transferID.String(),
+			prepareTransferService: func(mockService *mocks.Transfer) {
+				mockService.On("Dispatch", mock.Anything, transferID.String(), userID).
+					Return(nil, service.ErrTransferNotFound)
+			},
+			expectedHTTPStatus: http.StatusNotFound,
+			expectedResponse:   httpresponse.ErrorResponse{Error: "transfer not found"},
+		},
+		{
+			name:       "already dispatched",
+			transferID: transferID.String(),
+			prepareTransferService: func(mockService *mocks.Transfer) {
+				mockService.On("Dispatch", mock.Anything, transferID.String(), userID).
+					Return(nil, service.ErrInvalidTransferStatus)
+			},
+			expectedHTTPStatus: http.StatusConflict,
+			expectedResponse:   httpresponse.ErrorResponse{Error: "invalid transfer status"},
+		},
+		{
+			name:       "product no longer stored",
+			transferID: transferID.String(),
+			prepareTransferService: func(mockService *mocks.Transfer) {
+				mockService.On("Dispatch", mock.Anything, transferID.String(), userID).
+					Return(nil, service.ErrProductNotStored)
+			},
+			expectedHTTPStatus: http.StatusConflict,
+			expectedResponse:   httpresponse.ErrorResponse{Error: "product is not stored"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			transferService := mocks.NewTransfer(t)
+			tc.prepareTransferService(transferService)
+
+			handler := newTransferHandler(transferService)
+
+			r := chi.NewRouter()
+			r.Post("/transfers/{transferId}/dispatch", handler.dispatchTransfer)
+			req := httptest.NewRequest("POST", "/transfers/"+tc.transferID+"/dispatch", nil)
+			claims := &entity.UserClaims{UserID: userID, Role: entity.RoleEmployee}
+			req = req.WithContext(context.WithValue(req.Context(), middleware.ClaimsContext, claims))
+			rec := httptest.NewRecorder()
+
+			r.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedHTTPStatus, rec.Code)
+
+			if tc.expectedHTTPStatus == http.StatusOK {
+				var actualResponse transferResponse
+				if err := json.NewDecoder(rec.Body).Decode(&actualResponse); err != nil {
+					t.Fatalf("failed to decode response body: %v", err)
+				}
+				assert.Equal(t, tc.expectedResponse, actualResponse)
+			} else {
+				var actualResponse httpresponse.ErrorResponse
+				if err := json.NewDecoder(rec.Body).Decode(&actualResponse); err != nil {
+					t.Fatalf("failed to decode response body: %v", err)
+				}
+				assert.Equal(t, tc.expectedResponse, actualResponse)
+			}
+		})
+	}
+}
+
+func TestReceiveTransferItem(t *testing.T) {
+	userID := uuid.New()
+	transferID := uuid.New()
+	productID := uuid.New()
+	receptionID := uuid.New()
+	pvzID := uuid.New()
+
+	testCases := []struct {
+		name                   string
+		productID              string
+		prepareTransferService func(mockService *mocks.Transfer)
+		expectedHTTPStatus     int
+		expectedError          string
+	}{
+		{
+			name:      "successful receipt",
+			productID: productID.String(),
+			prepareTransferService: func(mockService *mocks.Transfer) {
+				mockService.On("ReceiveItem", mock.Anything, transferID.String(), productID.String(), userID).
+					Return(&entity.Product{
+						ID:          productID,
+						Type:        entity.ProductTypeShoes,
+						ReceptionID: receptionID,
+						PVZID:       pvzID,
+						Status:      entity.ProductStatusStored,
+						PickupCode:  "042917",
+					}, nil)
+			},
+			expectedHTTPStatus: http.StatusOK,
+		},
+		{
+			name:                   "invalid product id",
+			productID:              "not-a-uuid",
+			prepareTransferService: func(mockService *mocks.Transfer) {},
+			expectedHTTPStatus:     http.StatusBadRequest,
+			expectedError:          "invalid product id",
+		},
+		{
+			name:      "already received",
+			productID: productID.String(),
+			prepareTransferService: func(mockService *mocks.Transfer) {
+				mockService.On("ReceiveItem", mock.Anything, transferID.String(), productID.String(), userID).
+					Return(nil, service.ErrTransferItemReceived)
+			},
+			expectedHTTPStatus: http.StatusConflict,
+			expectedError:      "transfer item is already received",
+		},
+		{
+			name:      "reception not started",
+			productID: productID.String(),
+			prepareTransferService: func(mockService *mocks.Transfer) {
+				mockService.On("ReceiveItem", mock.Anything, transferID.String(), productID.String(), userID).
+					Return(nil, service.ErrInvalidReceptionStatus)
+			},
+			expectedHTTPStatus: http.StatusConflict,
+			expectedError:      "reception is not open",
+		},
+		{
+			name:      "product not in transfer",
+			productID: productID.String(),
+			prepareTransferService: func(mockService *mocks.Transfer) {
+				mockService.On("ReceiveItem", mock.Anything, transferID.String(), productID.String(), userID).
+					Return(nil, service.ErrProductNotFound)
+			},
+			expectedHTTPStatus: http.StatusNotFound,
+			expectedError:      "product not found",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			transferService := mocks.NewTransfer(t)
+			tc.prepareTransferService(transferService)
+
+			handler := newTransferHandler(transferService)
+
+			r := chi.NewRouter()
+			r.Post("/transfers/{transferId}/items/{productId}/receive", handler.receiveTransferItem)
+			req := httptest.NewRequest("POST",
+				"/transfers/"+transferID.String()+"/items/"+tc.productID+"/receive", nil)
+			claims := &entity.UserClaims{UserID: userID, Role: entity.RoleEmployee}
+			req = req.WithContext(context.WithValue(req.Context(), middleware.ClaimsContext, claims))
+			rec := httptest.NewRecorder()
+
+			r.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedHTTPStatus, rec.Code)
+
+			if tc.expectedHTTPStatus == http.StatusOK {
+				var actualResponse createProductResponse
+				if err := json.NewDecoder(rec.Body).Decode(&actualResponse); err != nil {
+					t.Fatalf("failed to decode response body: %v", err)
+				}
+				assert.Equal(t, productID.String(), actualResponse.ID)
+				assert.Equal(t, receptionID.String(), actualResponse.ReceptionID)
+				assert.Equal(t, pvzID.String(), actualResponse.PVZID)
+				assert.Equal(t, "042917", actualResponse.PickupCode)
+			} else {
+				var actualResponse httpresponse.ErrorResponse
+				if err := json.NewDecoder(rec.Body).Decode(&actualResponse); err != nil {
+					t.Fatalf("failed to decode response body: %v", err)
+				}
+				assert.Equal(t, tc.expectedError, actualResponse.Error)
+			}
+		})
+	}
+}
+
+func TestListProductLocations(t *testing.T) {
+	productID := uuid.New()
+	fromPVZID := uuid.New()
+	receptionID := uuid.New()
+	transferID := uuid.New()
+	userID := uuid.New()
+
+	t.Run("successful list", func(t *testing.T) {
+		productService := mocks.NewProduct(t)
+		productService.On("ListLocations", mock.Anything, productID.String()).Return([]entity.ProductLocation{
+			{ProductID: productID, PVZID: fromPVZID, ReceptionID: receptionID,
+				ChangedAt: time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC)},
+			{ProductID: productID, TransferID: transferID, ChangedBy: userID,
+				ChangedAt: time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)},
+		}, nil)
+
+		r := chi.NewRouter()
+		r.Get("/products/{productId}/locations", newProductHandler(productService).listProductLocations)
+		req := httptest.NewRequest("GET", "/products/"+productID.String()+"/locations", nil)
+		rec := httptest.NewRecorder()
+
+		r.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		var actualResponse []productLocationResponse
+		if err := json.NewDecoder(rec.Body).Decode(&actualResponse); err != nil {
+			t.Fatalf("failed to decode response body: %v", err)
+		}
+		assert.Equal(t, []productLocationResponse{
+			{PVZID: fromPVZID.String(), ReceptionID: receptionID.String(), ChangedAt: "2026-05-01T09:00:00Z"},
+			{TransferID: transferID.String(), ChangedBy: userID.String(), ChangedAt: "2026-06-01T12:00:00Z"},
+		}, actualResponse)
+	})
+
+	t.Run("product not found", func(t *testing.T) {
+		productService := mocks.NewProduct(t)
+		productService.On("ListLocations", mock.Anything, productID.String()).Return(nil, service.ErrProductNotFound)
+
+		r := chi.NewRouter()
+		r.Get("/products/{productId}/locations", newProductHandler(productService).listProductLocations)
+		req := httptest.NewRequest("GET", "/products/"+productID.String()+"/locations", nil)
+		rec := httptest.NewRecorder()
+
+		r.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
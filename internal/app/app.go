@@ -8,11 +8,13 @@ import (
 	v1 "github.com/GlebMoskalev/go-pickup-point-api/internal/api/v1"
 	"github.com/GlebMoskalev/go-pickup-point-api/internal/repo"
 	"github.com/GlebMoskalev/go-pickup-point-api/internal/service"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/worker"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"log/slog"
 	"net/http"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
 )
 
@@ -31,11 +33,59 @@ func Run(configPath string) {
 	}
 	defer dbPool.Close()
 
-	repositories := repo.NewRepositories(dbPool)
+	blobStore, err := repo.NewBlobStore(cfg.BlobStore)
+	if err != nil {
+		slog.Error("blob store config error", "error", err)
+		return
+	}
+
+	repositories := repo.NewRepositories(dbPool, blobStore)
 
 	services := service.NewServices(repositories, cfg)
 	router := v1.NewRouter(services)
 
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+	var workers sync.WaitGroup
+	if cfg.StaleReceptions.IsEnabled() {
+		staleWorker, err := worker.NewStaleReceptions(services.Reception, cfg.StaleReceptions)
+		if err != nil {
+			slog.Error("stale receptions worker config error", "error", err)
+			return
+		}
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			staleWorker.Run(workerCtx)
+		}()
+	}
+
+	if cfg.StorageExpiry.IsEnabled() {
+		expiryWorker, err := worker.NewStorageExpiry(services.Product, cfg.StorageExpiry)
+		if err != nil {
+			slog.Error("storage expiry worker config error", "error", err)
+			return
+		}
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			expiryWorker.Run(workerCtx)
+		}()
+	}
+
+	if cfg.InsuredValue.IsEnabled() {
+		insuredWorker, err := worker.NewInsuredValue(services.PVZ, cfg.InsuredValue)
+		if err != nil {
+			slog.Error("insured value worker config error", "error", err)
+			return
+		}
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			insuredWorker.Run(workerCtx)
+		}()
+	}
+
 	serverAddr := fmt.Sprintf("%s:%s", cfg.Server.Host, cfg.Server.Port)
 	server := &http.Server{
 		Addr:    serverAddr,
@@ -93,5 +143,8 @@ func Run(configPath string) {
 		}
 	}
 
+	stopWorkers()
+	workers.Wait()
+
 	slog.Info("servers stopped")
 }
@@ -0,0 +1,41 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// AcceptanceAct — акт приёма закрытой приёмки, который передаётся перевозчику.
+// ContentHash — SHA-256 от содержимого акта; по нему проверяется, что акт не изменён.
+// Version — номер редакции: каждое повторное закрытие приёмки фиксирует новую редакцию.
+type AcceptanceAct struct {
+	ReceptionID uuid.UUID
+	PVZ         PVZ
+	Delivery    DeliveryInfo
+	OpenedAt    time.Time
+	ClosedAt    *time.Time
+	OpenedBy    uuid.UUID
+	ClosedBy    uuid.UUID
+	Counts      []TypeCount
+	// DeclaredValue — объявленная ценность принятых товаров по валютам.
+	DeclaredValue []ValueTotal
+	Products      []Product
+	ContentHash   string
+	Version       int
+}
+
+// ActSnapshot — акт, зафиксированный при закрытии приёмки: каноническое содержимое в JSON
+// и SHA-256 от него. Акт выдаётся из снимка, а не собирается заново из текущих данных.
+// Снимки не перезаписываются: повторное закрытие добавляет снимок со следующим Version.
+type ActSnapshot struct {
+	ReceptionID uuid.UUID
+	Version     int
+	Content     []byte
+	ContentHash string
+	CreatedAt   time.Time
+}
+
+type TypeCount struct {
+	ProductType string
+	Count       int
+}
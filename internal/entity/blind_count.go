@@ -0,0 +1,46 @@
+package entity
+
+import "github.com/google/uuid"
+
+// Политики закрытия приёмки, если «слепой» пересчёт не сошёлся с учтёнными товарами.
+const (
+	BlindCountPolicyReject = "reject"
+	BlindCountPolicyFlag   = "flag"
+)
+
+// PVZSettings — настройки процесса приёмки в ПВЗ. StorageDays — срок хранения товаров в днях;
+// 0 — срок по умолчанию.
+type PVZSettings struct {
+	PVZID            uuid.UUID `db:"id"`
+	BlindCountPolicy string    `db:"blind_count_policy"`
+	StorageDays      int       `db:"storage_days"`
+}
+
+// BlindCountItem сравнивает пересчитанное сотрудником и учтённое в системе количество товаров одного типа.
+type BlindCountItem struct {
+	ProductType   string
+	CountedCount  int
+	RecordedCount int
+}
+
+// BlindCountReport — результат «слепого» пересчёта при закрытии приёмки.
+type BlindCountReport struct {
+	ReceptionID uuid.UUID
+	Items       []BlindCountItem
+}
+
+func (r BlindCountReport) HasMismatch() bool {
+	for _, item := range r.Items {
+		if item.CountedCount != item.RecordedCount {
+			return true
+		}
+	}
+	return false
+}
+
+// CloseResult — итог закрытия приёмки. DiscrepancyReport заполняется для приёмок с манифестом,
+// BlindCount — при закрытии с пересчётом.
+type CloseResult struct {
+	DiscrepancyReport *DiscrepancyReport
+	BlindCount        *BlindCountReport
+}
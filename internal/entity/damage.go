@@ -0,0 +1,33 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+	"io"
+	"time"
+)
+
+// DamageParams — отметка о повреждении хранящегося товара.
+type DamageParams struct {
+	ProductID   string
+	Description string
+	MarkedBy    uuid.UUID
+}
+
+// ProductPhoto — фотография повреждения товара. Сам файл хранится в blob-хранилище под ключом BlobKey.
+type ProductPhoto struct {
+	ID          uuid.UUID `db:"id"`
+	ProductID   uuid.UUID `db:"product_id"`
+	BlobKey     string    `db:"blob_key"`
+	ContentType string    `db:"content_type"`
+	Size        int64     `db:"size"`
+	UploadedBy  uuid.UUID `db:"uploaded_by"`
+	UploadedAt  time.Time `db:"uploaded_at"`
+}
+
+// PhotoUpload — загрузка фотографии повреждения. Size — размер Content в байтах.
+type PhotoUpload struct {
+	ProductID  string
+	Content    io.Reader
+	Size       int64
+	UploadedBy uuid.UUID
+}
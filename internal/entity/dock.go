@@ -0,0 +1,27 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// DefaultDockName — имя дока, который создаётся для каждого ПВЗ автоматически.
+const DefaultDockName = "основной"
+
+// Dock — зона разгрузки (ворота) ПВЗ. В каждом доке может быть своя открытая приёмка,
+// поэтому крупный ПВЗ принимает несколько машин одновременно.
+type Dock struct {
+	ID        uuid.UUID `db:"id"`
+	PVZID     uuid.UUID `db:"pvz_id"`
+	Name      string    `db:"name"`
+	IsDefault bool      `db:"is_default"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+// ReceptionTarget указывает открытую приёмку, с которой работает запрос. Приёмка
+// выбирается по ReceptionID, затем по DockID, иначе — единственная открытая приёмка ПВЗ.
+type ReceptionTarget struct {
+	PVZID       string
+	DockID      string
+	ReceptionID string
+}
@@ -0,0 +1,41 @@
+package entity
+
+import "github.com/google/uuid"
+
+// ManifestItem — ожидаемое по накладной количество товаров одного типа.
+type ManifestItem struct {
+	ProductType   string
+	ExpectedCount int
+}
+
+// DiscrepancyItem сравнивает ожидаемое и фактически принятое количество товаров одного типа.
+type DiscrepancyItem struct {
+	ProductType   string
+	ExpectedCount int
+	ReceivedCount int
+}
+
+// Shortage — сколько товаров не доехало.
+func (i DiscrepancyItem) Shortage() int {
+	return max(i.ExpectedCount-i.ReceivedCount, 0)
+}
+
+// Surplus — сколько товаров принято сверх ожидаемого.
+func (i DiscrepancyItem) Surplus() int {
+	return max(i.ReceivedCount-i.ExpectedCount, 0)
+}
+
+// DiscrepancyReport формируется при закрытии приёмки с манифестом.
+type DiscrepancyReport struct {
+	ReceptionID uuid.UUID
+	Items       []DiscrepancyItem
+}
+
+func (r DiscrepancyReport) HasDiscrepancies() bool {
+	for _, item := range r.Items {
+		if item.ExpectedCount != item.ReceivedCount {
+			return true
+		}
+	}
+	return false
+}
@@ -1,20 +1,109 @@
 package entity
 
 import (
+	"encoding/json"
 	"github.com/google/uuid"
 	"time"
 )
 
+// Типы, заведённые в каталоге изначально. Остальные типы добавляют модераторы.
 const (
 	ProductTypeElectronics = "электроника"
 	ProductTypeClothes     = "одежда"
 	ProductTypeShoes       = "обувь"
 )
 
+// Product — товар приёмки. OrderNumber — позиция товара в приёмке: номера идут с 1 без пропусков,
+// удалённые товары свои номера сохраняют. CellID, CellShelf и CellCode указывают ячейку хранения;
+// у товара без ячейки они пустые. ReturnReason и OriginalProductID заполняются у товаров,
+// возвращённых покупателями. Товар повреждён, если DamagedAt не пустой. DeclaredValue — объявленная
+// ценность в минимальных единицах валюты Currency; у товара без объявленной ценности Currency пустая.
+// SourceProductID заполняется у товара, принятого по перемещению, и указывает на его строку в ПВЗ отправления.
 type Product struct {
-	ID          uuid.UUID `db:"id"`
-	DateTime    time.Time `db:"date_time"`
-	Type        string    `db:"type"`
+	ID                uuid.UUID       `db:"id"`
+	DateTime          time.Time       `db:"date_time"`
+	Type              string          `db:"type"`
+	Barcode           string          `db:"barcode"`
+	Attributes        json.RawMessage `db:"attributes"`
+	ReceptionID       uuid.UUID       `db:"reception_id"`
+	PVZID             uuid.UUID       `db:"pvz_id"`
+	OrderNumber       int             `db:"order_number"`
+	Status            string          `db:"status"`
+	PickupCode        string          `db:"pickup_code"`
+	CellID            uuid.UUID       `db:"cell_id"`
+	CellShelf         string          `db:"cell_shelf"`
+	CellCode          string          `db:"cell_code"`
+	ReturnReason      string          `db:"return_reason"`
+	OriginalProductID uuid.UUID       `db:"original_product_id"`
+	AddedBy           uuid.UUID       `db:"added_by"`
+	DeletedBy         uuid.UUID       `db:"deleted_by"`
+	DeletedAt         *time.Time      `db:"deleted_at"`
+	IssuedBy          uuid.UUID       `db:"issued_by"`
+	IssuedAt          *time.Time      `db:"issued_at"`
+	StoredAt          time.Time       `db:"stored_at"`
+	OverdueAt         *time.Time      `db:"overdue_at"`
+	ReturnedBy        uuid.UUID       `db:"returned_by"`
+	ReturnedAt        *time.Time      `db:"returned_at"`
+	DamageDescription string          `db:"damage_description"`
+	DamagedBy         uuid.UUID       `db:"damaged_by"`
+	DamagedAt         *time.Time      `db:"damaged_at"`
+	DeclaredValue     int64           `db:"declared_value"`
+	Currency          string          `db:"currency"`
+	SourceProductID   uuid.UUID       `db:"source_product_id"`
+}
+
+// ProductParams — данные для добавления товара в открытую приёмку. Barcode и Attributes необязательны.
+// ReturnReason обязателен в приёмке-возврате и недопустим в поставке. DeclaredValue и Currency
+// необязательны, но задаются вместе.
+type ProductParams struct {
+	ReceptionTarget
+	Type          string
+	Barcode       string
+	Attributes    json.RawMessage
+	ReturnReason  string
+	DeclaredValue int64
+	Currency      string
+	AddedBy       uuid.UUID
+}
+
+// IssueParams — выдача товара покупателю по коду получения.
+type IssueParams struct {
+	ProductID  string
+	PVZID      string
+	PickupCode string
+	IssuedBy   uuid.UUID
+}
+
+// ReturnToSenderParams — подтверждение отправки товара с истёкшим сроком хранения обратно отправителю.
+type ReturnToSenderParams struct {
+	ProductID  string
+	PVZID      string
+	ReturnedBy uuid.UUID
+}
+
+// ProductStock — число товаров ПВЗ по статусам. InTransit — товары, отправленные из ПВЗ
+// и ещё не принятые в ПВЗ назначения.
+type ProductStock struct {
+	PVZID            uuid.UUID
+	Stored           int
+	Issued           int
+	ReturnedToSender int
+	InTransit        int
+	// StoredValue — объявленная ценность хранящихся товаров по валютам.
+	StoredValue []ValueTotal
+	// InsuredCeiling — лимит застрахованной ценности ПВЗ в валюте InsuredCurrency; 0 — лимит не задан.
+	InsuredCeiling  int64
+	InsuredCurrency string
+	ExceedsCeiling  bool
+}
+
+// ProductLocation — запись истории местонахождения товара: ПВЗ и приёмка, в которых он хранится,
+// или перемещение, в котором он находится в пути (тогда PVZID и ReceptionID пустые).
+type ProductLocation struct {
+	ProductID   uuid.UUID `db:"product_id"`
+	PVZID       uuid.UUID `db:"pvz_id"`
 	ReceptionID uuid.UUID `db:"reception_id"`
-	OrderNumber int       `db:"order_number"`
+	TransferID  uuid.UUID `db:"transfer_id"`
+	ChangedBy   uuid.UUID `db:"changed_by"`
+	ChangedAt   time.Time `db:"changed_at"`
 }
@@ -0,0 +1,54 @@
+package entity
+
+import (
+	"encoding/json"
+	"github.com/google/uuid"
+)
+
+// Режимы пакетного добавления товаров: all_or_nothing отклоняет весь пакет при ошибке
+// в любой позиции, best_effort добавляет корректные позиции и сообщает об остальных.
+const (
+	BatchModeAllOrNothing = "all_or_nothing"
+	BatchModeBestEffort   = "best_effort"
+)
+
+// ProductBatchParams — пакет товаров для одной открытой приёмки.
+type ProductBatchParams struct {
+	ReceptionTarget
+	Mode    string
+	Items   []ProductBatchItem
+	AddedBy uuid.UUID
+}
+
+type ProductBatchItem struct {
+	Type          string
+	Barcode       string
+	Attributes    json.RawMessage
+	ReturnReason  string
+	DeclaredValue int64
+	Currency      string
+}
+
+// ProductBatchResult содержит результат по каждой позиции пакета в исходном порядке:
+// добавленный товар или ошибку. Позиции без товара и без ошибки не добавлены из-за
+// ошибок в других позициях.
+type ProductBatchResult struct {
+	ReceptionID uuid.UUID
+	Items       []ProductBatchItemResult
+}
+
+type ProductBatchItemResult struct {
+	Product *Product
+	Err     error
+}
+
+// Created возвращает число добавленных товаров.
+func (r ProductBatchResult) Created() int {
+	created := 0
+	for _, item := range r.Items {
+		if item.Product != nil {
+			created++
+		}
+	}
+	return created
+}
@@ -0,0 +1,46 @@
+package entity
+
+// Статусы товара после приёмки.
+const (
+	ProductStatusStored           = "stored"
+	ProductStatusIssued           = "issued"
+	ProductStatusReturnedToSender = "returned_to_sender"
+	ProductStatusInTransit        = "in_transit"
+	ProductStatusTransferred      = "transferred"
+)
+
+// События жизненного цикла товара.
+const (
+	ProductEventIssue          = "issue"
+	ProductEventReturnToSender = "return_to_sender"
+	ProductEventDispatch       = "dispatch"
+	ProductEventReceive        = "receive"
+)
+
+type productTransition struct {
+	Event string
+	From  string
+	To    string
+}
+
+// productTransitions — допустимые переходы между статусами товара. Выданный и возвращённый
+// отправителю товар в ПВЗ больше не хранится, поэтому переходов из этих статусов нет.
+// Принятый в ПВЗ назначения товар остаётся в приёмке отправления перемещённым, а в приёмке
+// назначения хранится новой строкой.
+var productTransitions = []productTransition{
+	{Event: ProductEventIssue, From: ProductStatusStored, To: ProductStatusIssued},
+	{Event: ProductEventReturnToSender, From: ProductStatusStored, To: ProductStatusReturnedToSender},
+	{Event: ProductEventDispatch, From: ProductStatusStored, To: ProductStatusInTransit},
+	{Event: ProductEventReceive, From: ProductStatusInTransit, To: ProductStatusTransferred},
+}
+
+// NextProductStatus возвращает статус, в который товар переходит из status по событию event.
+// Второе значение false, если переход недопустим.
+func NextProductStatus(status, event string) (string, bool) {
+	for _, t := range productTransitions {
+		if t.From == status && t.Event == event {
+			return t.To, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,19 @@
+package entity
+
+import (
+	"encoding/json"
+	"github.com/google/uuid"
+	"time"
+)
+
+// ProductType — тип товара из каталога. AttributesSchema — необязательная JSON-схема
+// дополнительных атрибутов (например, размер обуви или IMEI), по которой проверяются
+// атрибуты товара при добавлении. Без схемы товар этого типа не может иметь атрибутов.
+// StorageDays — срок хранения товаров типа в днях; 0 — срок ПВЗ или срок по умолчанию.
+type ProductType struct {
+	Name             string          `db:"name"`
+	AttributesSchema json.RawMessage `db:"attributes_schema"`
+	CreatedAt        time.Time       `db:"created_at"`
+	CreatedBy        uuid.UUID       `db:"created_by"`
+	StorageDays      int             `db:"storage_days"`
+}
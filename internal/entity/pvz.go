@@ -11,13 +11,27 @@ const (
 	CityKazan  = "Казань"
 )
 
+const DefaultTimezone = "Europe/Moscow"
+
 type PVZ struct {
 	ID               uuid.UUID `db:"id"`
 	RegistrationDate time.Time `db:"registration_date"`
 	City             string    `db:"city"`
+	Timezone         string    `db:"timezone"`
 }
 
 type PVZWithDetails struct {
 	PVZ        PVZ                `json:"pvz"`
 	Receptions []ReceptionDetails `json:"receptions"`
 }
+
+// PVZFilter ограничивает выборку ListWithDetails по дате приёмок и номеру накладной.
+// При LocalTime даты трактуются как время на часах ПВЗ, а не как абсолютные моменты.
+type PVZFilter struct {
+	StartDate     *time.Time
+	EndDate       *time.Time
+	LocalTime     bool
+	WaybillNumber string
+	// Kind — вид приёмок в выдаче; пустой означает поставки.
+	Kind string
+}
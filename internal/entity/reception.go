@@ -5,19 +5,87 @@ import (
 	"time"
 )
 
+// Статусы приёмки. Допустимые переходы между ними заданы в reception_state.go.
 const (
+	StatusDraft      = "draft"
 	StatusInProgress = "in_progress"
 	StatusClose      = "close"
+	StatusVerified   = "verified"
+	StatusCancelled  = "cancelled"
+)
+
+// Виды приёмки: поставка от отправителя, возврат товаров покупателями или приёмка товаров,
+// перемещённых из другого ПВЗ. Приёмки перемещений заводятся только при отправке перемещения.
+const (
+	ReceptionKindDelivery = "delivery"
+	ReceptionKindReturn   = "return"
+	ReceptionKindTransfer = "transfer"
+)
+
+// Политики обработки приёмок, открытых дольше допустимого.
+const (
+	StalePolicyClose = "close"
+	StalePolicyFlag  = "flag"
 )
 
 type Reception struct {
 	ID       uuid.UUID `db:"id"`
 	DateTime time.Time `db:"date_time"`
 	PVZID    uuid.UUID `db:"pvz_id"`
+	DockID   uuid.UUID `db:"dock_id"`
+	Kind     string    `db:"kind"`
 	Status   string    `db:"status"`
+	Manifest []ManifestItem
+	Delivery DeliveryInfo
+	OpenedBy uuid.UUID  `db:"opened_by"`
+	ClosedBy uuid.UUID  `db:"closed_by"`
+	ClosedAt *time.Time `db:"closed_at"`
+	// InProgressSince — когда приёмка последний раз перешла в in_progress; от него считается зависание.
+	InProgressSince time.Time `db:"in_progress_since"`
+	// StaleFlaggedAt и StaleReason заполняются фоновой проверкой зависших приёмок.
+	StaleFlaggedAt *time.Time `db:"stale_flagged_at"`
+	StaleReason    string     `db:"stale_reason"`
+}
+
+// ReceptionParams — данные для открытия приёмки. Manifest может быть пустым («слепая» приёмка).
+// Без DockID приёмка открывается в доке ПВЗ по умолчанию. Draft заводит приёмку черновиком,
+// который начинается отдельным переходом TransitionStart. Пустой Kind означает поставку.
+type ReceptionParams struct {
+	PVZID    string
+	DockID   string
+	Kind     string
+	Draft    bool
+	Manifest []ManifestItem
+	Delivery DeliveryInfo
+	OpenedBy uuid.UUID
+}
+
+// DeliveryInfo — сведения о поставке от перевозчика. Все поля необязательны.
+type DeliveryInfo struct {
+	Carrier       string `db:"carrier"`
+	WaybillNumber string `db:"waybill_number"`
+	VehiclePlate  string `db:"vehicle_plate"`
+	Comment       string `db:"comment"`
 }
 
 type ReceptionDetails struct {
 	Reception Reception `json:"reception"`
 	Products  []Product `json:"products"`
+	// DeletedProducts — удалённые из приёмки товары, сохраняются для аудита.
+	DeletedProducts []Product `json:"deletedProducts"`
+	// DiscrepancyReport заполняется только для закрытых приёмок с манифестом.
+	DiscrepancyReport *DiscrepancyReport `json:"discrepancyReport"`
+	// BlindCount заполняется, только если приёмку закрывали с «слепым» пересчётом.
+	BlindCount    *BlindCountReport `json:"blindCount"`
+	StatusHistory []StatusChange    `json:"statusHistory"`
+}
+
+// StatusChange — запись журнала смены статуса приёмки: кто, когда и почему.
+type StatusChange struct {
+	ReceptionID uuid.UUID `db:"reception_id"`
+	FromStatus  string    `db:"from_status"`
+	ToStatus    string    `db:"to_status"`
+	Reason      string    `db:"reason"`
+	ChangedBy   uuid.UUID `db:"changed_by"`
+	ChangedAt   time.Time `db:"changed_at"`
 }
@@ -0,0 +1,48 @@
+package entity
+
+// События жизненного цикла приёмки.
+const (
+	TransitionStart  = "start"
+	TransitionClose  = "close"
+	TransitionReopen = "reopen"
+	TransitionVerify = "verify"
+	TransitionCancel = "cancel"
+)
+
+type receptionTransition struct {
+	Event string
+	From  string
+	To    string
+}
+
+// receptionTransitions — единственное описание допустимых переходов между статусами приёмки.
+var receptionTransitions = []receptionTransition{
+	{Event: TransitionStart, From: StatusDraft, To: StatusInProgress},
+	{Event: TransitionClose, From: StatusInProgress, To: StatusClose},
+	{Event: TransitionReopen, From: StatusClose, To: StatusInProgress},
+	{Event: TransitionVerify, From: StatusClose, To: StatusVerified},
+	{Event: TransitionCancel, From: StatusDraft, To: StatusCancelled},
+	{Event: TransitionCancel, From: StatusInProgress, To: StatusCancelled},
+}
+
+// NextReceptionStatus возвращает статус, в который приёмка переходит из status по событию event.
+// Второе значение false, если переход недопустим.
+func NextReceptionStatus(status, event string) (string, bool) {
+	for _, t := range receptionTransitions {
+		if t.From == status && t.Event == event {
+			return t.To, true
+		}
+	}
+	return "", false
+}
+
+// AllowedReceptionEvents возвращает события, допустимые для приёмки в статусе status.
+func AllowedReceptionEvents(status string) []string {
+	events := make([]string, 0)
+	for _, t := range receptionTransitions {
+		if t.From == status {
+			events = append(events, t.Event)
+		}
+	}
+	return events
+}
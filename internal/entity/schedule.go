@@ -0,0 +1,32 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// Время в расписании хранится в формате HH:MM по местному времени ПВЗ.
+const TimeOfDayLayout = "15:04"
+
+type WorkingHours struct {
+	Weekday   time.Weekday `db:"weekday"`
+	OpenTime  string       `db:"open_time"`
+	CloseTime string       `db:"close_time"`
+}
+
+// Holiday переопределяет недельное расписание на конкретную дату.
+// Пустые OpenTime и CloseTime означают, что ПВЗ в этот день закрыт.
+type Holiday struct {
+	Date      time.Time `db:"date"`
+	OpenTime  string    `db:"open_time"`
+	CloseTime string    `db:"close_time"`
+	Comment   string    `db:"comment"`
+}
+
+type PVZSchedule struct {
+	PVZID               uuid.UUID      `db:"pvz_id"`
+	Timezone            string         `db:"timezone"`
+	EnforceWorkingHours bool           `db:"enforce_working_hours"`
+	WorkingHours        []WorkingHours `json:"working_hours"`
+	Holidays            []Holiday      `json:"holidays"`
+}
@@ -0,0 +1,36 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// StorageCell — ячейка стеллажа ПВЗ. Ячейка с ProductType принимает только товары этого типа,
+// ячейка без типа — любые. Occupied — число хранящихся в ячейке товаров.
+type StorageCell struct {
+	ID          uuid.UUID `db:"id"`
+	PVZID       uuid.UUID `db:"pvz_id"`
+	Shelf       string    `db:"shelf"`
+	Code        string    `db:"code"`
+	ProductType string    `db:"product_type"`
+	Capacity    int       `db:"capacity"`
+	Occupied    int       `db:"occupied"`
+	CreatedAt   time.Time `db:"created_at"`
+}
+
+// Free возвращает число свободных мест в ячейке.
+func (c StorageCell) Free() int {
+	return max(c.Capacity-c.Occupied, 0)
+}
+
+// Accepts сообщает, можно ли положить в ячейку товар указанного типа.
+func (c StorageCell) Accepts(productType string) bool {
+	return c.ProductType == "" || c.ProductType == productType
+}
+
+// CellAssignment — ручное перемещение товара в другую ячейку.
+type CellAssignment struct {
+	ProductID string
+	CellID    string
+	UserID    uuid.UUID
+}
@@ -0,0 +1,54 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// Статусы перемещения: created — заказ создан, товары ещё в ПВЗ отправления; in_transit —
+// товары отправлены; received — все товары приняты в ПВЗ назначения; cancelled — заказ отменён
+// до отправки.
+const (
+	TransferStatusCreated   = "created"
+	TransferStatusInTransit = "in_transit"
+	TransferStatusReceived  = "received"
+	TransferStatusCancelled = "cancelled"
+)
+
+// Transfer — перемещение хранящихся товаров из ПВЗ FromPVZID в ПВЗ ToPVZID. ReceptionID — приёмка
+// в ПВЗ назначения, заведённая при отправке; до отправки пустой.
+type Transfer struct {
+	ID           uuid.UUID  `db:"id"`
+	FromPVZID    uuid.UUID  `db:"from_pvz_id"`
+	ToPVZID      uuid.UUID  `db:"to_pvz_id"`
+	Status       string     `db:"status"`
+	ReceptionID  uuid.UUID  `db:"reception_id"`
+	CreatedBy    uuid.UUID  `db:"created_by"`
+	CreatedAt    time.Time  `db:"created_at"`
+	DispatchedBy uuid.UUID  `db:"dispatched_by"`
+	DispatchedAt *time.Time `db:"dispatched_at"`
+	ReceivedAt   *time.Time `db:"received_at"`
+	Items        []TransferItem
+}
+
+// Active сообщает, что перемещение ещё не завершено и не отменено.
+func (t Transfer) Active() bool {
+	return t.Status == TransferStatusCreated || t.Status == TransferStatusInTransit
+}
+
+// TransferItem — товар перемещения. ReceivedAt заполняется при приёмке товара в ПВЗ назначения.
+type TransferItem struct {
+	ProductID  uuid.UUID  `db:"product_id"`
+	Type       string     `db:"type"`
+	Barcode    string     `db:"barcode"`
+	ReceivedBy uuid.UUID  `db:"received_by"`
+	ReceivedAt *time.Time `db:"received_at"`
+}
+
+// TransferParams — заказ на перемещение товаров между ПВЗ.
+type TransferParams struct {
+	FromPVZID  string
+	ToPVZID    string
+	ProductIDs []string
+	CreatedBy  uuid.UUID
+}
@@ -0,0 +1,45 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+	"sort"
+)
+
+// ValueTotal — суммарная объявленная ценность товаров в одной валюте. Amount — в минимальных
+// единицах валюты, Count — число товаров с объявленной ценностью.
+type ValueTotal struct {
+	Currency string
+	Amount   int64
+	Count    int
+}
+
+// PVZValue — объявленная ценность хранящихся в ПВЗ товаров в одной валюте.
+type PVZValue struct {
+	PVZID uuid.UUID
+	ValueTotal
+}
+
+// SumDeclaredValues суммирует объявленную ценность товаров по валютам; товары без ценности
+// пропускаются. Итоги отсортированы по коду валюты.
+func SumDeclaredValues(products []Product) []ValueTotal {
+	totals := make(map[string]*ValueTotal)
+	for _, product := range products {
+		if product.Currency == "" {
+			continue
+		}
+		total, ok := totals[product.Currency]
+		if !ok {
+			total = &ValueTotal{Currency: product.Currency}
+			totals[product.Currency] = total
+		}
+		total.Amount += product.DeclaredValue
+		total.Count++
+	}
+
+	result := make([]ValueTotal, 0, len(totals))
+	for _, total := range totals {
+		result = append(result, *total)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Currency < result[j].Currency })
+	return result
+}
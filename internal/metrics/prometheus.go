@@ -47,4 +47,79 @@ var (
 			Help: "Total number of added products",
 		},
 	)
+
+	ReturnReceptionsCreated = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "return_receptions_created_total",
+			Help: "Total number of created customer return receptions",
+		},
+	)
+
+	ProductsReturned = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "products_returned_total",
+			Help: "Total number of products returned by customers",
+		},
+	)
+
+	ProductsTransferred = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "products_transferred_total",
+			Help: "Total number of products dispatched to other PVZs",
+		},
+	)
+
+	ProductsIssued = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "products_issued_total",
+			Help: "Total number of products issued to customers",
+		},
+	)
+
+	ProductsOverdue = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "products_overdue_total",
+			Help: "Total number of stored products queued for return to sender after the storage period",
+		},
+	)
+
+	ProductsReturnedToSender = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "products_returned_to_sender_total",
+			Help: "Total number of products dispatched back to sender",
+		},
+	)
+
+	ProductsMarkedDamaged = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "products_marked_damaged_total",
+			Help: "Total number of products marked damaged",
+		},
+	)
+
+	StaleReceptions = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "stale_receptions_total",
+			Help: "Total number of receptions auto-closed or flagged as stale",
+		},
+		[]string{"policy"},
+	)
+
+	// Метки ПВЗ у метрик ценности нет: число ПВЗ не ограничено. Превысившие лимит ПВЗ
+	// перечисляются в журнале.
+	StoredDeclaredValue = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "stored_declared_value",
+			Help: "Declared value of products stored in all PVZs, in minor currency units",
+		},
+		[]string{"currency"},
+	)
+
+	PVZInsuredValueExceeded = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "pvz_insured_value_exceeded",
+			Help: "Number of PVZs whose stored declared value exceeds the insured value ceiling",
+		},
+		[]string{"currency"},
+	)
 )
@@ -0,0 +1,7 @@
+// Package blobstore содержит реализации хранилища файлов: каталог на диске и S3-совместимое
+// хранилище.
+package blobstore
+
+import "errors"
+
+var ErrInvalidKey = errors.New("invalid blob key")
@@ -0,0 +1,118 @@
+package blobstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/repo/repoerr"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+)
+
+// Local хранит файлы в каталоге на диске; ключ — относительный путь внутри каталога.
+type Local struct {
+	dir string
+}
+
+func NewLocal(dir string) (*Local, error) {
+	if dir == "" {
+		return nil, fmt.Errorf("blob store dir is required")
+	}
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create blob store dir: %w", err)
+	}
+	return &Local{dir: dir}, nil
+}
+
+// Put записывает файл во временный файл рядом с целевым и переименовывает его, поэтому
+// читатели не увидят недописанный файл.
+func (s *Local) Put(ctx context.Context, key string, content io.Reader, size int64, contentType string) error {
+	log := slog.With("layer", "LocalBlobStore", "operation", "Put", "key", key, "size", size)
+	log.Debug("starting blob write")
+
+	path, err := s.path(key)
+	if err != nil {
+		log.Warn("invalid blob key")
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		log.Error("failed to create blob dir", "error", err)
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		log.Error("failed to create temp file", "error", err)
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	written, err := io.Copy(tmp, io.LimitReader(content, size+1))
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		log.Error("failed to write blob", "error", err)
+		return err
+	}
+	if written != size {
+		log.Error("blob size mismatch", "written", written)
+		return fmt.Errorf("blob size mismatch: expected %d bytes, got %d", size, written)
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		log.Error("failed to move blob", "error", err)
+		return err
+	}
+
+	log.Info("blob written")
+	return nil
+}
+
+func (s *Local) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	log := slog.With("layer", "LocalBlobStore", "operation", "Get", "key", key)
+	log.Debug("starting blob read")
+
+	path, err := s.path(key)
+	if err != nil {
+		log.Warn("invalid blob key")
+		return nil, err
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			log.Warn("blob not found")
+			return nil, repoerr.ErrNotFound
+		}
+		log.Error("failed to open blob", "error", err)
+		return nil, err
+	}
+	return file, nil
+}
+
+// Delete удаляет файл; отсутствующий файл ошибкой не считается.
+func (s *Local) Delete(ctx context.Context, key string) error {
+	log := slog.With("layer", "LocalBlobStore", "operation", "Delete", "key", key)
+	log.Debug("starting blob deletion")
+
+	path, err := s.path(key)
+	if err != nil {
+		log.Warn("invalid blob key")
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		log.Error("failed to delete blob", "error", err)
+		return err
+	}
+	return nil
+}
+
+// path не даёт ключу выйти за пределы каталога хранилища.
+func (s *Local) path(key string) (string, error) {
+	if key == "" || !filepath.IsLocal(key) {
+		return "", ErrInvalidKey
+	}
+	return filepath.Join(s.dir, filepath.FromSlash(key)), nil
+}
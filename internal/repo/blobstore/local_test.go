@@ -0,0 +1,47 @@
+package blobstore
+
+import (
+	"bytes"
+	"context"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/repo/repoerr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLocal(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	store, err := NewLocal(dir)
+	require.NoError(t, err)
+
+	content := []byte("png bytes")
+	key := "products/a1/b2.png"
+	require.NoError(t, store.Put(ctx, key, bytes.NewReader(content), int64(len(content)), "image/png"))
+
+	reader, err := store.Get(ctx, key)
+	require.NoError(t, err)
+	got, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	require.NoError(t, reader.Close())
+	assert.Equal(t, content, got)
+
+	err = store.Put(ctx, "products/a1/short.png", strings.NewReader("short"), 100, "image/png")
+	assert.Error(t, err)
+	_, err = os.Stat(filepath.Join(dir, "products", "a1", "short.png"))
+	assert.ErrorIs(t, err, os.ErrNotExist, "incomplete upload is not kept")
+
+	require.NoError(t, store.Delete(ctx, key))
+	require.NoError(t, store.Delete(ctx, key), "deleting a missing blob is not an error")
+	_, err = store.Get(ctx, key)
+	assert.ErrorIs(t, err, repoerr.ErrNotFound)
+
+	for _, invalid := range []string{"", "../outside", "/etc/passwd", "products/../../outside"} {
+		_, err := store.Get(ctx, invalid)
+		assert.ErrorIs(t, err, ErrInvalidKey, invalid)
+	}
+}
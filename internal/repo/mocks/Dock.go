@@ -0,0 +1,119 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	entity "github.com/GlebMoskalev/go-pickup-point-api/internal/entity"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Dock is an autogenerated mock type for the Dock type
+type Dock struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, pvzID, name
+func (_m *Dock) Create(ctx context.Context, pvzID string, name string) (*entity.Dock, error) {
+	ret := _m.Called(ctx, pvzID, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 *entity.Dock
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*entity.Dock, error)); ok {
+		return rf(ctx, pvzID, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *entity.Dock); ok {
+		r0 = rf(ctx, pvzID, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Dock)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, pvzID, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByID provides a mock function with given fields: ctx, dockID
+func (_m *Dock) GetByID(ctx context.Context, dockID string) (*entity.Dock, error) {
+	ret := _m.Called(ctx, dockID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 *entity.Dock
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*entity.Dock, error)); ok {
+		return rf(ctx, dockID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.Dock); ok {
+		r0 = rf(ctx, dockID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Dock)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, dockID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// List provides a mock function with given fields: ctx, pvzID
+func (_m *Dock) List(ctx context.Context, pvzID string) ([]entity.Dock, error) {
+	ret := _m.Called(ctx, pvzID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []entity.Dock
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]entity.Dock, error)); ok {
+		return rf(ctx, pvzID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []entity.Dock); ok {
+		r0 = rf(ctx, pvzID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Dock)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, pvzID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewDock creates a new instance of Dock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewDock(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Dock {
+	mock := &Dock{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
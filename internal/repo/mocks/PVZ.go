@@ -7,8 +7,6 @@ import (
 
 	entity "github.com/GlebMoskalev/go-pickup-point-api/internal/entity"
 	mock "github.com/stretchr/testify/mock"
-
-	time "time"
 )
 
 // PVZ is an autogenerated mock type for the PVZ type
@@ -16,9 +14,39 @@ type PVZ struct {
 	mock.Mock
 }
 
-// Create provides a mock function with given fields: ctx, city
-func (_m *PVZ) Create(ctx context.Context, city string) (*entity.PVZ, error) {
-	ret := _m.Called(ctx, city)
+// CountProductsByStatus provides a mock function with given fields: ctx, pvzID
+func (_m *PVZ) CountProductsByStatus(ctx context.Context, pvzID string) (map[string]int, error) {
+	ret := _m.Called(ctx, pvzID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountProductsByStatus")
+	}
+
+	var r0 map[string]int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (map[string]int, error)); ok {
+		return rf(ctx, pvzID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) map[string]int); ok {
+		r0 = rf(ctx, pvzID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]int)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, pvzID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Create provides a mock function with given fields: ctx, city, timezone
+func (_m *PVZ) Create(ctx context.Context, city string, timezone string) (*entity.PVZ, error) {
+	ret := _m.Called(ctx, city, timezone)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Create")
@@ -26,19 +54,19 @@ func (_m *PVZ) Create(ctx context.Context, city string) (*entity.PVZ, error) {
 
 	var r0 *entity.PVZ
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (*entity.PVZ, error)); ok {
-		return rf(ctx, city)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*entity.PVZ, error)); ok {
+		return rf(ctx, city, timezone)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.PVZ); ok {
-		r0 = rf(ctx, city)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *entity.PVZ); ok {
+		r0 = rf(ctx, city, timezone)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*entity.PVZ)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, city)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, city, timezone)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -64,9 +92,129 @@ func (_m *PVZ) Exists(ctx context.Context, pvzID string) bool {
 	return r0
 }
 
-// ListWithDetails provides a mock function with given fields: ctx, startDate, endDate, page, limit
-func (_m *PVZ) ListWithDetails(ctx context.Context, startDate *time.Time, endDate *time.Time, page int, limit int) ([]entity.PVZWithDetails, error) {
-	ret := _m.Called(ctx, startDate, endDate, page, limit)
+// GetByID provides a mock function with given fields: ctx, pvzID
+func (_m *PVZ) GetByID(ctx context.Context, pvzID string) (*entity.PVZ, error) {
+	ret := _m.Called(ctx, pvzID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 *entity.PVZ
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*entity.PVZ, error)); ok {
+		return rf(ctx, pvzID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.PVZ); ok {
+		r0 = rf(ctx, pvzID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.PVZ)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, pvzID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSchedule provides a mock function with given fields: ctx, pvzID
+func (_m *PVZ) GetSchedule(ctx context.Context, pvzID string) (*entity.PVZSchedule, error) {
+	ret := _m.Called(ctx, pvzID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSchedule")
+	}
+
+	var r0 *entity.PVZSchedule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*entity.PVZSchedule, error)); ok {
+		return rf(ctx, pvzID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.PVZSchedule); ok {
+		r0 = rf(ctx, pvzID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.PVZSchedule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, pvzID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSettings provides a mock function with given fields: ctx, pvzID
+func (_m *PVZ) GetSettings(ctx context.Context, pvzID string) (*entity.PVZSettings, error) {
+	ret := _m.Called(ctx, pvzID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSettings")
+	}
+
+	var r0 *entity.PVZSettings
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*entity.PVZSettings, error)); ok {
+		return rf(ctx, pvzID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.PVZSettings); ok {
+		r0 = rf(ctx, pvzID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.PVZSettings)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, pvzID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListStoredValues provides a mock function with given fields: ctx
+func (_m *PVZ) ListStoredValues(ctx context.Context) ([]entity.PVZValue, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListStoredValues")
+	}
+
+	var r0 []entity.PVZValue
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]entity.PVZValue, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []entity.PVZValue); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.PVZValue)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListWithDetails provides a mock function with given fields: ctx, filter, page, limit
+func (_m *PVZ) ListWithDetails(ctx context.Context, filter entity.PVZFilter, page int, limit int) ([]entity.PVZWithDetails, error) {
+	ret := _m.Called(ctx, filter, page, limit)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ListWithDetails")
@@ -74,19 +222,85 @@ func (_m *PVZ) ListWithDetails(ctx context.Context, startDate *time.Time, endDat
 
 	var r0 []entity.PVZWithDetails
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, *time.Time, *time.Time, int, int) ([]entity.PVZWithDetails, error)); ok {
-		return rf(ctx, startDate, endDate, page, limit)
+	if rf, ok := ret.Get(0).(func(context.Context, entity.PVZFilter, int, int) ([]entity.PVZWithDetails, error)); ok {
+		return rf(ctx, filter, page, limit)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, *time.Time, *time.Time, int, int) []entity.PVZWithDetails); ok {
-		r0 = rf(ctx, startDate, endDate, page, limit)
+	if rf, ok := ret.Get(0).(func(context.Context, entity.PVZFilter, int, int) []entity.PVZWithDetails); ok {
+		r0 = rf(ctx, filter, page, limit)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]entity.PVZWithDetails)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, *time.Time, *time.Time, int, int) error); ok {
-		r1 = rf(ctx, startDate, endDate, page, limit)
+	if rf, ok := ret.Get(1).(func(context.Context, entity.PVZFilter, int, int) error); ok {
+		r1 = rf(ctx, filter, page, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetSchedule provides a mock function with given fields: ctx, schedule
+func (_m *PVZ) SetSchedule(ctx context.Context, schedule entity.PVZSchedule) error {
+	ret := _m.Called(ctx, schedule)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetSchedule")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.PVZSchedule) error); ok {
+		r0 = rf(ctx, schedule)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetSettings provides a mock function with given fields: ctx, settings
+func (_m *PVZ) SetSettings(ctx context.Context, settings entity.PVZSettings) error {
+	ret := _m.Called(ctx, settings)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetSettings")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.PVZSettings) error); ok {
+		r0 = rf(ctx, settings)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SumStoredValue provides a mock function with given fields: ctx, pvzID
+func (_m *PVZ) SumStoredValue(ctx context.Context, pvzID string) ([]entity.ValueTotal, error) {
+	ret := _m.Called(ctx, pvzID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SumStoredValue")
+	}
+
+	var r0 []entity.ValueTotal
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]entity.ValueTotal, error)); ok {
+		return rf(ctx, pvzID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []entity.ValueTotal); ok {
+		r0 = rf(ctx, pvzID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.ValueTotal)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, pvzID)
 	} else {
 		r1 = ret.Error(1)
 	}
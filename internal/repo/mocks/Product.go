@@ -7,6 +7,10 @@ import (
 
 	entity "github.com/GlebMoskalev/go-pickup-point-api/internal/entity"
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+
+	uuid "github.com/google/uuid"
 )
 
 // Product is an autogenerated mock type for the Product type
@@ -14,21 +18,281 @@ type Product struct {
 	mock.Mock
 }
 
-// Create provides a mock function with given fields: ctx, receptionID, productType
-func (_m *Product) Create(ctx context.Context, receptionID string, productType string) (*entity.Product, error) {
-	ret := _m.Called(ctx, receptionID, productType)
+// CountByType provides a mock function with given fields: ctx, receptionID
+func (_m *Product) CountByType(ctx context.Context, receptionID string) (map[string]int, error) {
+	ret := _m.Called(ctx, receptionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountByType")
+	}
+
+	var r0 map[string]int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (map[string]int, error)); ok {
+		return rf(ctx, receptionID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) map[string]int); ok {
+		r0 = rf(ctx, receptionID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]int)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, receptionID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Create provides a mock function with given fields: ctx, product
+func (_m *Product) Create(ctx context.Context, product entity.Product) (*entity.Product, error) {
+	ret := _m.Called(ctx, product)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Create")
 	}
 
+	var r0 *entity.Product
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Product) (*entity.Product, error)); ok {
+		return rf(ctx, product)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Product) *entity.Product); ok {
+		r0 = rf(ctx, product)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Product)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.Product) error); ok {
+		r1 = rf(ctx, product)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateBatch provides a mock function with given fields: ctx, products
+func (_m *Product) CreateBatch(ctx context.Context, products []entity.Product) ([]entity.Product, error) {
+	ret := _m.Called(ctx, products)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateBatch")
+	}
+
+	var r0 []entity.Product
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []entity.Product) ([]entity.Product, error)); ok {
+		return rf(ctx, products)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []entity.Product) []entity.Product); ok {
+		r0 = rf(ctx, products)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Product)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []entity.Product) error); ok {
+		r1 = rf(ctx, products)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Delete provides a mock function with given fields: ctx, productID, deletedBy
+func (_m *Product) Delete(ctx context.Context, productID string, deletedBy uuid.UUID) error {
+	ret := _m.Called(ctx, productID, deletedBy)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID) error); ok {
+		r0 = rf(ctx, productID, deletedBy)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteByReception provides a mock function with given fields: ctx, receptionID, deletedBy
+func (_m *Product) DeleteByReception(ctx context.Context, receptionID string, deletedBy uuid.UUID) (int, error) {
+	ret := _m.Called(ctx, receptionID, deletedBy)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteByReception")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID) (int, error)); ok {
+		return rf(ctx, receptionID, deletedBy)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID) int); ok {
+		r0 = rf(ctx, receptionID, deletedBy)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, uuid.UUID) error); ok {
+		r1 = rf(ctx, receptionID, deletedBy)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteLastProduct provides a mock function with given fields: ctx, receptionID, deletedBy
+func (_m *Product) DeleteLastProduct(ctx context.Context, receptionID string, deletedBy uuid.UUID) error {
+	ret := _m.Called(ctx, receptionID, deletedBy)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteLastProduct")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID) error); ok {
+		r0 = rf(ctx, receptionID, deletedBy)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Dispatch provides a mock function with given fields: ctx, productIDs, transferID, userID
+func (_m *Product) Dispatch(ctx context.Context, productIDs []uuid.UUID, transferID uuid.UUID, userID uuid.UUID) error {
+	ret := _m.Called(ctx, productIDs, transferID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Dispatch")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []uuid.UUID, uuid.UUID, uuid.UUID) error); ok {
+		r0 = rf(ctx, productIDs, transferID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FlagOverdue provides a mock function with given fields: ctx, defaultDays
+func (_m *Product) FlagOverdue(ctx context.Context, defaultDays int) (int, error) {
+	ret := _m.Called(ctx, defaultDays)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FlagOverdue")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (int, error)); ok {
+		return rf(ctx, defaultDays)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) int); ok {
+		r0 = rf(ctx, defaultDays)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, defaultDays)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByBarcode provides a mock function with given fields: ctx, barcode
+func (_m *Product) GetByBarcode(ctx context.Context, barcode string) (*entity.Product, error) {
+	ret := _m.Called(ctx, barcode)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByBarcode")
+	}
+
+	var r0 *entity.Product
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*entity.Product, error)); ok {
+		return rf(ctx, barcode)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.Product); ok {
+		r0 = rf(ctx, barcode)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Product)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, barcode)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByID provides a mock function with given fields: ctx, productID
+func (_m *Product) GetByID(ctx context.Context, productID string) (*entity.Product, error) {
+	ret := _m.Called(ctx, productID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 *entity.Product
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*entity.Product, error)); ok {
+		return rf(ctx, productID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.Product); ok {
+		r0 = rf(ctx, productID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Product)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, productID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByPickupCode provides a mock function with given fields: ctx, pvzID, code
+func (_m *Product) GetByPickupCode(ctx context.Context, pvzID string, code string) (*entity.Product, error) {
+	ret := _m.Called(ctx, pvzID, code)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByPickupCode")
+	}
+
 	var r0 *entity.Product
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*entity.Product, error)); ok {
-		return rf(ctx, receptionID, productType)
+		return rf(ctx, pvzID, code)
 	}
 	if rf, ok := ret.Get(0).(func(context.Context, string, string) *entity.Product); ok {
-		r0 = rf(ctx, receptionID, productType)
+		r0 = rf(ctx, pvzID, code)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*entity.Product)
@@ -36,7 +300,65 @@ func (_m *Product) Create(ctx context.Context, receptionID string, productType s
 	}
 
 	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
-		r1 = rf(ctx, receptionID, productType)
+		r1 = rf(ctx, pvzID, code)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Issue provides a mock function with given fields: ctx, productID, issuedBy
+func (_m *Product) Issue(ctx context.Context, productID string, issuedBy uuid.UUID) (time.Time, error) {
+	ret := _m.Called(ctx, productID, issuedBy)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Issue")
+	}
+
+	var r0 time.Time
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID) (time.Time, error)); ok {
+		return rf(ctx, productID, issuedBy)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID) time.Time); ok {
+		r0 = rf(ctx, productID, issuedBy)
+	} else {
+		r0 = ret.Get(0).(time.Time)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, uuid.UUID) error); ok {
+		r1 = rf(ctx, productID, issuedBy)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListByBarcodes provides a mock function with given fields: ctx, barcodes
+func (_m *Product) ListByBarcodes(ctx context.Context, barcodes []string) ([]entity.Product, error) {
+	ret := _m.Called(ctx, barcodes)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListByBarcodes")
+	}
+
+	var r0 []entity.Product
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) ([]entity.Product, error)); ok {
+		return rf(ctx, barcodes)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) []entity.Product); ok {
+		r0 = rf(ctx, barcodes)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Product)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, barcodes)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -44,17 +366,253 @@ func (_m *Product) Create(ctx context.Context, receptionID string, productType s
 	return r0, r1
 }
 
-// DeleteLastProduct provides a mock function with given fields: ctx, receptionID
-func (_m *Product) DeleteLastProduct(ctx context.Context, receptionID string) error {
+// ListByReception provides a mock function with given fields: ctx, receptionID
+func (_m *Product) ListByReception(ctx context.Context, receptionID string) ([]entity.Product, error) {
 	ret := _m.Called(ctx, receptionID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteLastProduct")
+		panic("no return value specified for ListByReception")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+	var r0 []entity.Product
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]entity.Product, error)); ok {
+		return rf(ctx, receptionID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []entity.Product); ok {
 		r0 = rf(ctx, receptionID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Product)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, receptionID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListIssuedByBarcodes provides a mock function with given fields: ctx, barcodes
+func (_m *Product) ListIssuedByBarcodes(ctx context.Context, barcodes []string) ([]entity.Product, error) {
+	ret := _m.Called(ctx, barcodes)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListIssuedByBarcodes")
+	}
+
+	var r0 []entity.Product
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) ([]entity.Product, error)); ok {
+		return rf(ctx, barcodes)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) []entity.Product); ok {
+		r0 = rf(ctx, barcodes)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Product)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, barcodes)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListLocations provides a mock function with given fields: ctx, productID
+func (_m *Product) ListLocations(ctx context.Context, productID string) ([]entity.ProductLocation, error) {
+	ret := _m.Called(ctx, productID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListLocations")
+	}
+
+	var r0 []entity.ProductLocation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]entity.ProductLocation, error)); ok {
+		return rf(ctx, productID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []entity.ProductLocation); ok {
+		r0 = rf(ctx, productID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.ProductLocation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, productID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListOverdue provides a mock function with given fields: ctx, pvzID
+func (_m *Product) ListOverdue(ctx context.Context, pvzID string) ([]entity.Product, error) {
+	ret := _m.Called(ctx, pvzID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListOverdue")
+	}
+
+	var r0 []entity.Product
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]entity.Product, error)); ok {
+		return rf(ctx, pvzID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []entity.Product); ok {
+		r0 = rf(ctx, pvzID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Product)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, pvzID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LockByIDs provides a mock function with given fields: ctx, productIDs
+func (_m *Product) LockByIDs(ctx context.Context, productIDs []uuid.UUID) ([]entity.Product, error) {
+	ret := _m.Called(ctx, productIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LockByIDs")
+	}
+
+	var r0 []entity.Product
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []uuid.UUID) ([]entity.Product, error)); ok {
+		return rf(ctx, productIDs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []uuid.UUID) []entity.Product); ok {
+		r0 = rf(ctx, productIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Product)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []uuid.UUID) error); ok {
+		r1 = rf(ctx, productIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MarkDamaged provides a mock function with given fields: ctx, productID, description, markedBy
+func (_m *Product) MarkDamaged(ctx context.Context, productID string, description string, markedBy uuid.UUID) (time.Time, error) {
+	ret := _m.Called(ctx, productID, description, markedBy)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkDamaged")
+	}
+
+	var r0 time.Time
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, uuid.UUID) (time.Time, error)); ok {
+		return rf(ctx, productID, description, markedBy)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, uuid.UUID) time.Time); ok {
+		r0 = rf(ctx, productID, description, markedBy)
+	} else {
+		r0 = ret.Get(0).(time.Time)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, uuid.UUID) error); ok {
+		r1 = rf(ctx, productID, description, markedBy)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Receive provides a mock function with given fields: ctx, product, transferID, userID
+func (_m *Product) Receive(ctx context.Context, product entity.Product, transferID uuid.UUID, userID uuid.UUID) (*entity.Product, error) {
+	ret := _m.Called(ctx, product, transferID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Receive")
+	}
+
+	var r0 *entity.Product
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Product, uuid.UUID, uuid.UUID) (*entity.Product, error)); ok {
+		return rf(ctx, product, transferID, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Product, uuid.UUID, uuid.UUID) *entity.Product); ok {
+		r0 = rf(ctx, product, transferID, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Product)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.Product, uuid.UUID, uuid.UUID) error); ok {
+		r1 = rf(ctx, product, transferID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReturnToSender provides a mock function with given fields: ctx, productID, returnedBy
+func (_m *Product) ReturnToSender(ctx context.Context, productID string, returnedBy uuid.UUID) (time.Time, error) {
+	ret := _m.Called(ctx, productID, returnedBy)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReturnToSender")
+	}
+
+	var r0 time.Time
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID) (time.Time, error)); ok {
+		return rf(ctx, productID, returnedBy)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID) time.Time); ok {
+		r0 = rf(ctx, productID, returnedBy)
+	} else {
+		r0 = ret.Get(0).(time.Time)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, uuid.UUID) error); ok {
+		r1 = rf(ctx, productID, returnedBy)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetCell provides a mock function with given fields: ctx, productID, cellID
+func (_m *Product) SetCell(ctx context.Context, productID string, cellID string) error {
+	ret := _m.Called(ctx, productID, cellID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetCell")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, productID, cellID)
 	} else {
 		r0 = ret.Error(0)
 	}
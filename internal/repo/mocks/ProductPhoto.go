@@ -0,0 +1,119 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	entity "github.com/GlebMoskalev/go-pickup-point-api/internal/entity"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ProductPhoto is an autogenerated mock type for the ProductPhoto type
+type ProductPhoto struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, photo
+func (_m *ProductPhoto) Create(ctx context.Context, photo entity.ProductPhoto) (*entity.ProductPhoto, error) {
+	ret := _m.Called(ctx, photo)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 *entity.ProductPhoto
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.ProductPhoto) (*entity.ProductPhoto, error)); ok {
+		return rf(ctx, photo)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.ProductPhoto) *entity.ProductPhoto); ok {
+		r0 = rf(ctx, photo)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.ProductPhoto)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.ProductPhoto) error); ok {
+		r1 = rf(ctx, photo)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByID provides a mock function with given fields: ctx, productID, photoID
+func (_m *ProductPhoto) GetByID(ctx context.Context, productID string, photoID string) (*entity.ProductPhoto, error) {
+	ret := _m.Called(ctx, productID, photoID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 *entity.ProductPhoto
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*entity.ProductPhoto, error)); ok {
+		return rf(ctx, productID, photoID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *entity.ProductPhoto); ok {
+		r0 = rf(ctx, productID, photoID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.ProductPhoto)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, productID, photoID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// List provides a mock function with given fields: ctx, productID
+func (_m *ProductPhoto) List(ctx context.Context, productID string) ([]entity.ProductPhoto, error) {
+	ret := _m.Called(ctx, productID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []entity.ProductPhoto
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]entity.ProductPhoto, error)); ok {
+		return rf(ctx, productID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []entity.ProductPhoto); ok {
+		r0 = rf(ctx, productID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.ProductPhoto)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, productID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewProductPhoto creates a new instance of ProductPhoto. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewProductPhoto(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ProductPhoto {
+	mock := &ProductPhoto{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -7,6 +7,10 @@ import (
 
 	entity "github.com/GlebMoskalev/go-pickup-point-api/internal/entity"
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+
+	uuid "github.com/google/uuid"
 )
 
 // Reception is an autogenerated mock type for the Reception type
@@ -14,17 +18,35 @@ type Reception struct {
 	mock.Mock
 }
 
-// Close provides a mock function with given fields: ctx, receptionID
-func (_m *Reception) Close(ctx context.Context, receptionID string) error {
-	ret := _m.Called(ctx, receptionID)
+// AddStatusChange provides a mock function with given fields: ctx, change
+func (_m *Reception) AddStatusChange(ctx context.Context, change entity.StatusChange) error {
+	ret := _m.Called(ctx, change)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddStatusChange")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.StatusChange) error); ok {
+		r0 = rf(ctx, change)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Close provides a mock function with given fields: ctx, receptionID, closedBy
+func (_m *Reception) Close(ctx context.Context, receptionID string, closedBy uuid.UUID) error {
+	ret := _m.Called(ctx, receptionID, closedBy)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Close")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
-		r0 = rf(ctx, receptionID)
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID) error); ok {
+		r0 = rf(ctx, receptionID, closedBy)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -32,21 +54,137 @@ func (_m *Reception) Close(ctx context.Context, receptionID string) error {
 	return r0
 }
 
-// Create provides a mock function with given fields: ctx, pvzID
-func (_m *Reception) Create(ctx context.Context, pvzID string) (*entity.Reception, error) {
-	ret := _m.Called(ctx, pvzID)
+// CountPendingTransferItems provides a mock function with given fields: ctx, receptionID
+func (_m *Reception) CountPendingTransferItems(ctx context.Context, receptionID string) (int, error) {
+	ret := _m.Called(ctx, receptionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountPendingTransferItems")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (int, error)); ok {
+		return rf(ctx, receptionID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) int); ok {
+		r0 = rf(ctx, receptionID)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, receptionID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Create provides a mock function with given fields: ctx, params
+func (_m *Reception) Create(ctx context.Context, params entity.ReceptionParams) (*entity.Reception, error) {
+	ret := _m.Called(ctx, params)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Create")
 	}
 
+	var r0 *entity.Reception
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.ReceptionParams) (*entity.Reception, error)); ok {
+		return rf(ctx, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.ReceptionParams) *entity.Reception); ok {
+		r0 = rf(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Reception)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.ReceptionParams) error); ok {
+		r1 = rf(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FlagStale provides a mock function with given fields: ctx, receptionID, reason
+func (_m *Reception) FlagStale(ctx context.Context, receptionID string, reason string) (bool, error) {
+	ret := _m.Called(ctx, receptionID, reason)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FlagStale")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (bool, error)); ok {
+		return rf(ctx, receptionID, reason)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) bool); ok {
+		r0 = rf(ctx, receptionID, reason)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, receptionID, reason)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAct provides a mock function with given fields: ctx, receptionID
+func (_m *Reception) GetAct(ctx context.Context, receptionID string) (*entity.ActSnapshot, error) {
+	ret := _m.Called(ctx, receptionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAct")
+	}
+
+	var r0 *entity.ActSnapshot
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*entity.ActSnapshot, error)); ok {
+		return rf(ctx, receptionID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.ActSnapshot); ok {
+		r0 = rf(ctx, receptionID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.ActSnapshot)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, receptionID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByID provides a mock function with given fields: ctx, receptionID
+func (_m *Reception) GetByID(ctx context.Context, receptionID string) (*entity.Reception, error) {
+	ret := _m.Called(ctx, receptionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
 	var r0 *entity.Reception
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context, string) (*entity.Reception, error)); ok {
-		return rf(ctx, pvzID)
+		return rf(ctx, receptionID)
 	}
 	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.Reception); ok {
-		r0 = rf(ctx, pvzID)
+		r0 = rf(ctx, receptionID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*entity.Reception)
@@ -54,7 +192,7 @@ func (_m *Reception) Create(ctx context.Context, pvzID string) (*entity.Receptio
 	}
 
 	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, pvzID)
+		r1 = rf(ctx, receptionID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -92,9 +230,39 @@ func (_m *Reception) GetLastOpenReception(ctx context.Context, pvzID string) (*e
 	return r0, r1
 }
 
-// HasOpenReception provides a mock function with given fields: ctx, pvzID
-func (_m *Reception) HasOpenReception(ctx context.Context, pvzID string) (bool, error) {
-	ret := _m.Called(ctx, pvzID)
+// GetManifest provides a mock function with given fields: ctx, receptionID
+func (_m *Reception) GetManifest(ctx context.Context, receptionID string) ([]entity.ManifestItem, error) {
+	ret := _m.Called(ctx, receptionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetManifest")
+	}
+
+	var r0 []entity.ManifestItem
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]entity.ManifestItem, error)); ok {
+		return rf(ctx, receptionID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []entity.ManifestItem); ok {
+		r0 = rf(ctx, receptionID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.ManifestItem)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, receptionID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// HasOpenReception provides a mock function with given fields: ctx, pvzID, dockID
+func (_m *Reception) HasOpenReception(ctx context.Context, pvzID string, dockID string) (bool, error) {
+	ret := _m.Called(ctx, pvzID, dockID)
 
 	if len(ret) == 0 {
 		panic("no return value specified for HasOpenReception")
@@ -102,13 +270,103 @@ func (_m *Reception) HasOpenReception(ctx context.Context, pvzID string) (bool,
 
 	var r0 bool
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (bool, error)); ok {
+		return rf(ctx, pvzID, dockID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) bool); ok {
+		r0 = rf(ctx, pvzID, dockID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, pvzID, dockID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListStale provides a mock function with given fields: ctx, inProgressBefore
+func (_m *Reception) ListStale(ctx context.Context, inProgressBefore time.Time) ([]entity.Reception, error) {
+	ret := _m.Called(ctx, inProgressBefore)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListStale")
+	}
+
+	var r0 []entity.Reception
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) ([]entity.Reception, error)); ok {
+		return rf(ctx, inProgressBefore)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []entity.Reception); ok {
+		r0 = rf(ctx, inProgressBefore)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Reception)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, inProgressBefore)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LockByID provides a mock function with given fields: ctx, receptionID
+func (_m *Reception) LockByID(ctx context.Context, receptionID string) (*entity.Reception, error) {
+	ret := _m.Called(ctx, receptionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LockByID")
+	}
+
+	var r0 *entity.Reception
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*entity.Reception, error)); ok {
+		return rf(ctx, receptionID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.Reception); ok {
+		r0 = rf(ctx, receptionID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Reception)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, receptionID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LockLastOpenReception provides a mock function with given fields: ctx, pvzID
+func (_m *Reception) LockLastOpenReception(ctx context.Context, pvzID string) (*entity.Reception, error) {
+	ret := _m.Called(ctx, pvzID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LockLastOpenReception")
+	}
+
+	var r0 *entity.Reception
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*entity.Reception, error)); ok {
 		return rf(ctx, pvzID)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.Reception); ok {
 		r0 = rf(ctx, pvzID)
 	} else {
-		r0 = ret.Get(0).(bool)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Reception)
+		}
 	}
 
 	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
@@ -120,6 +378,108 @@ func (_m *Reception) HasOpenReception(ctx context.Context, pvzID string) (bool,
 	return r0, r1
 }
 
+// LockOpenReceptionByDock provides a mock function with given fields: ctx, dockID
+func (_m *Reception) LockOpenReceptionByDock(ctx context.Context, dockID string) (*entity.Reception, error) {
+	ret := _m.Called(ctx, dockID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LockOpenReceptionByDock")
+	}
+
+	var r0 *entity.Reception
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*entity.Reception, error)); ok {
+		return rf(ctx, dockID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.Reception); ok {
+		r0 = rf(ctx, dockID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Reception)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, dockID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SaveAct provides a mock function with given fields: ctx, snapshot
+func (_m *Reception) SaveAct(ctx context.Context, snapshot entity.ActSnapshot) error {
+	ret := _m.Called(ctx, snapshot)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveAct")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.ActSnapshot) error); ok {
+		r0 = rf(ctx, snapshot)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SaveBlindCount provides a mock function with given fields: ctx, report
+func (_m *Reception) SaveBlindCount(ctx context.Context, report entity.BlindCountReport) error {
+	ret := _m.Called(ctx, report)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveBlindCount")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.BlindCountReport) error); ok {
+		r0 = rf(ctx, report)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SaveDiscrepancyReport provides a mock function with given fields: ctx, report
+func (_m *Reception) SaveDiscrepancyReport(ctx context.Context, report entity.DiscrepancyReport) error {
+	ret := _m.Called(ctx, report)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveDiscrepancyReport")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.DiscrepancyReport) error); ok {
+		r0 = rf(ctx, report)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetStatus provides a mock function with given fields: ctx, receptionID, status
+func (_m *Reception) SetStatus(ctx context.Context, receptionID string, status string) error {
+	ret := _m.Called(ctx, receptionID, status)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetStatus")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, receptionID, status)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewReception creates a new instance of Reception. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewReception(t interface {
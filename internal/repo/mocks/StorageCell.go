@@ -0,0 +1,119 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	entity "github.com/GlebMoskalev/go-pickup-point-api/internal/entity"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// StorageCell is an autogenerated mock type for the StorageCell type
+type StorageCell struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, cell
+func (_m *StorageCell) Create(ctx context.Context, cell entity.StorageCell) (*entity.StorageCell, error) {
+	ret := _m.Called(ctx, cell)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 *entity.StorageCell
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.StorageCell) (*entity.StorageCell, error)); ok {
+		return rf(ctx, cell)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.StorageCell) *entity.StorageCell); ok {
+		r0 = rf(ctx, cell)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.StorageCell)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.StorageCell) error); ok {
+		r1 = rf(ctx, cell)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// List provides a mock function with given fields: ctx, pvzID
+func (_m *StorageCell) List(ctx context.Context, pvzID string) ([]entity.StorageCell, error) {
+	ret := _m.Called(ctx, pvzID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []entity.StorageCell
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]entity.StorageCell, error)); ok {
+		return rf(ctx, pvzID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []entity.StorageCell); ok {
+		r0 = rf(ctx, pvzID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.StorageCell)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, pvzID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LockByPVZ provides a mock function with given fields: ctx, pvzID
+func (_m *StorageCell) LockByPVZ(ctx context.Context, pvzID string) ([]entity.StorageCell, error) {
+	ret := _m.Called(ctx, pvzID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LockByPVZ")
+	}
+
+	var r0 []entity.StorageCell
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]entity.StorageCell, error)); ok {
+		return rf(ctx, pvzID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []entity.StorageCell); ok {
+		r0 = rf(ctx, pvzID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.StorageCell)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, pvzID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewStorageCell creates a new instance of StorageCell. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewStorageCell(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *StorageCell {
+	mock := &StorageCell{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,205 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	entity "github.com/GlebMoskalev/go-pickup-point-api/internal/entity"
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
+)
+
+// Transfer is an autogenerated mock type for the Transfer type
+type Transfer struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, transfer
+func (_m *Transfer) Create(ctx context.Context, transfer entity.Transfer) (*entity.Transfer, error) {
+	ret := _m.Called(ctx, transfer)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 *entity.Transfer
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Transfer) (*entity.Transfer, error)); ok {
+		return rf(ctx, transfer)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Transfer) *entity.Transfer); ok {
+		r0 = rf(ctx, transfer)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Transfer)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.Transfer) error); ok {
+		r1 = rf(ctx, transfer)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByID provides a mock function with given fields: ctx, transferID
+func (_m *Transfer) GetByID(ctx context.Context, transferID string) (*entity.Transfer, error) {
+	ret := _m.Called(ctx, transferID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 *entity.Transfer
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*entity.Transfer, error)); ok {
+		return rf(ctx, transferID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.Transfer); ok {
+		r0 = rf(ctx, transferID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Transfer)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, transferID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListActiveProductIDs provides a mock function with given fields: ctx, productIDs
+func (_m *Transfer) ListActiveProductIDs(ctx context.Context, productIDs []uuid.UUID) ([]uuid.UUID, error) {
+	ret := _m.Called(ctx, productIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListActiveProductIDs")
+	}
+
+	var r0 []uuid.UUID
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []uuid.UUID) ([]uuid.UUID, error)); ok {
+		return rf(ctx, productIDs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []uuid.UUID) []uuid.UUID); ok {
+		r0 = rf(ctx, productIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]uuid.UUID)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []uuid.UUID) error); ok {
+		r1 = rf(ctx, productIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LockByID provides a mock function with given fields: ctx, transferID
+func (_m *Transfer) LockByID(ctx context.Context, transferID string) (*entity.Transfer, error) {
+	ret := _m.Called(ctx, transferID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LockByID")
+	}
+
+	var r0 *entity.Transfer
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*entity.Transfer, error)); ok {
+		return rf(ctx, transferID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.Transfer); ok {
+		r0 = rf(ctx, transferID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Transfer)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, transferID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReceiveItem provides a mock function with given fields: ctx, transferID, productID, userID
+func (_m *Transfer) ReceiveItem(ctx context.Context, transferID uuid.UUID, productID uuid.UUID, userID uuid.UUID) error {
+	ret := _m.Called(ctx, transferID, productID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReceiveItem")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, uuid.UUID) error); ok {
+		r0 = rf(ctx, transferID, productID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetDispatched provides a mock function with given fields: ctx, transferID, receptionID, userID
+func (_m *Transfer) SetDispatched(ctx context.Context, transferID uuid.UUID, receptionID uuid.UUID, userID uuid.UUID) error {
+	ret := _m.Called(ctx, transferID, receptionID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetDispatched")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, uuid.UUID) error); ok {
+		r0 = rf(ctx, transferID, receptionID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetStatus provides a mock function with given fields: ctx, transferID, status
+func (_m *Transfer) SetStatus(ctx context.Context, transferID uuid.UUID, status string) error {
+	ret := _m.Called(ctx, transferID, status)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetStatus")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) error); ok {
+		r0 = rf(ctx, transferID, status)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewTransfer creates a new instance of Transfer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewTransfer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Transfer {
+	mock := &Transfer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
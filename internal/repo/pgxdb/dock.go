@@ -0,0 +1,104 @@
+package pgxdb
+
+import (
+	"context"
+	"errors"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/entity"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/repo/repoerr"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"log/slog"
+)
+
+type DockRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewDockRepo(db *pgxpool.Pool) *DockRepo {
+	return &DockRepo{db: db}
+}
+
+func (r *DockRepo) Create(ctx context.Context, pvzID, name string) (*entity.Dock, error) {
+	log := slog.With("layer", "DockRepo", "operation", "Create", "pvzID", pvzID, "name", name)
+	log.Debug("starting dock creation")
+
+	query := `
+	INSERT INTO docks (pvz_id, name)
+	VALUES ($1, $2)
+	RETURNING id, pvz_id, name, is_default, created_at
+`
+	var dock entity.Dock
+	err := conn(ctx, r.db).QueryRow(ctx, query, pvzID, name).
+		Scan(&dock.ID, &dock.PVZID, &dock.Name, &dock.IsDefault, &dock.CreatedAt)
+	if err != nil {
+		var pgxError *pgconn.PgError
+		if errors.As(err, &pgxError) && pgxError.Code == "23505" {
+			log.Warn("dock with this name already exists")
+			return nil, repoerr.ErrDuplicateEntry
+		}
+		log.Error("failed to create dock", "error", err)
+		return nil, err
+	}
+
+	log.Info("dock created successfully", "dockID", dock.ID.String())
+	return &dock, nil
+}
+
+func (r *DockRepo) List(ctx context.Context, pvzID string) ([]entity.Dock, error) {
+	log := slog.With("layer", "DockRepo", "operation", "List", "pvzID", pvzID)
+	log.Debug("listing docks")
+
+	query := `
+	SELECT id, pvz_id, name, is_default, created_at
+	FROM docks
+	WHERE pvz_id = $1
+	ORDER BY is_default DESC, created_at, name
+`
+	rows, err := conn(ctx, r.db).Query(ctx, query, pvzID)
+	if err != nil {
+		log.Error("failed to list docks", "error", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var docks []entity.Dock
+	for rows.Next() {
+		var dock entity.Dock
+		if err := rows.Scan(&dock.ID, &dock.PVZID, &dock.Name, &dock.IsDefault, &dock.CreatedAt); err != nil {
+			log.Error("failed to scan dock", "error", err)
+			return nil, err
+		}
+		docks = append(docks, dock)
+	}
+	if err := rows.Err(); err != nil {
+		log.Error("rows error", "error", err)
+		return nil, err
+	}
+
+	log.Debug("docks listed", "count", len(docks))
+	return docks, nil
+}
+
+func (r *DockRepo) GetByID(ctx context.Context, dockID string) (*entity.Dock, error) {
+	log := slog.With("layer", "DockRepo", "operation", "GetByID", "dockID", dockID)
+	log.Debug("starting get dock")
+
+	query := `
+	SELECT id, pvz_id, name, is_default, created_at
+	FROM docks
+	WHERE id = $1
+`
+	var dock entity.Dock
+	err := conn(ctx, r.db).QueryRow(ctx, query, dockID).
+		Scan(&dock.ID, &dock.PVZID, &dock.Name, &dock.IsDefault, &dock.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			log.Warn("dock not found")
+			return nil, repoerr.ErrNoRows
+		}
+		log.Error("failed to get dock", "error", err)
+		return nil, err
+	}
+	return &dock, nil
+}
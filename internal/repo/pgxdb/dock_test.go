@@ -0,0 +1,98 @@
+package pgxdb_test
+
+import (
+	"context"
+	"github.com/GlebMoskalev/go-pickup-point-api/integration/helperstest"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/entity"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/repo/pgxdb"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/repo/repoerr"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestDockRepoCreateAndList(t *testing.T) {
+	ctx := context.Background()
+
+	postgresContainer, dbCfg := helperstest.SetupPostgresContainer(t, ctx)
+	defer postgresContainer.Terminate(ctx)
+
+	dbPool := helperstest.SetupDatabaseConnection(t, ctx, dbCfg)
+	defer dbPool.Close()
+
+	helperstest.ApplyMigrations(t, dbCfg)
+
+	dockRepo := pgxdb.NewDockRepo(dbPool)
+	pvzID := helperstest.CreatePVZ(t, ctx, dbPool)
+
+	docks, err := dockRepo.List(ctx, pvzID.String())
+	require.NoError(t, err)
+	require.Len(t, docks, 1, "default dock must be created with pvz")
+	require.True(t, docks[0].IsDefault)
+	require.Equal(t, entity.DefaultDockName, docks[0].Name)
+
+	dock, err := dockRepo.Create(ctx, pvzID.String(), "ворота 2")
+	require.NoError(t, err)
+	require.False(t, dock.IsDefault)
+
+	_, err = dockRepo.Create(ctx, pvzID.String(), "ворота 2")
+	require.ErrorIs(t, err, repoerr.ErrDuplicateEntry)
+
+	docks, err = dockRepo.List(ctx, pvzID.String())
+	require.NoError(t, err)
+	require.Len(t, docks, 2)
+	require.True(t, docks[0].IsDefault, "default dock goes first")
+
+	found, err := dockRepo.GetByID(ctx, dock.ID.String())
+	require.NoError(t, err)
+	require.Equal(t, pvzID, found.PVZID)
+
+	_, err = dockRepo.GetByID(ctx, uuid.New().String())
+	require.ErrorIs(t, err, repoerr.ErrNoRows)
+}
+
+func TestReceptionRepoParallelReceptionsInDocks(t *testing.T) {
+	ctx := context.Background()
+
+	postgresContainer, dbCfg := helperstest.SetupPostgresContainer(t, ctx)
+	defer postgresContainer.Terminate(ctx)
+
+	dbPool := helperstest.SetupDatabaseConnection(t, ctx, dbCfg)
+	defer dbPool.Close()
+
+	helperstest.ApplyMigrations(t, dbCfg)
+
+	receptionRepo := pgxdb.NewReceptionRepo(dbPool)
+	dockRepo := pgxdb.NewDockRepo(dbPool)
+	pvzID := helperstest.CreatePVZ(t, ctx, dbPool)
+	otherPVZID := helperstest.CreatePVZ(t, ctx, dbPool)
+
+	dock, err := dockRepo.Create(ctx, pvzID.String(), "ворота 2")
+	require.NoError(t, err)
+	otherDock, err := dockRepo.Create(ctx, otherPVZID.String(), "ворота 2")
+	require.NoError(t, err)
+
+	first, err := receptionRepo.Create(ctx, entity.ReceptionParams{PVZID: pvzID.String()})
+	require.NoError(t, err)
+	second, err := receptionRepo.Create(ctx, entity.ReceptionParams{PVZID: pvzID.String(), DockID: dock.ID.String()})
+	require.NoError(t, err)
+	require.Equal(t, dock.ID, second.DockID)
+	require.NotEqual(t, first.DockID, second.DockID)
+
+	_, err = receptionRepo.Create(ctx, entity.ReceptionParams{PVZID: pvzID.String(), DockID: dock.ID.String()})
+	require.ErrorIs(t, err, repoerr.ErrDuplicateEntry)
+
+	_, err = receptionRepo.Create(ctx, entity.ReceptionParams{PVZID: pvzID.String(), DockID: otherDock.ID.String()})
+	require.Error(t, err, "dock of another pvz must be rejected")
+
+	hasOpen, err := receptionRepo.HasOpenReception(ctx, pvzID.String(), dock.ID.String())
+	require.NoError(t, err)
+	require.True(t, hasOpen)
+
+	_, err = receptionRepo.GetLastOpenReception(ctx, pvzID.String())
+	require.ErrorIs(t, err, repoerr.ErrMultipleRows)
+
+	locked, err := receptionRepo.LockOpenReceptionByDock(ctx, dock.ID.String())
+	require.NoError(t, err)
+	require.Equal(t, second.ID, locked.ID)
+}
@@ -0,0 +1,31 @@
+package pgxdb
+
+import (
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"time"
+)
+
+// nullUUID сохраняет uuid.Nil как NULL, например для действий фоновых задач без пользователя.
+func nullUUID(id uuid.UUID) *uuid.UUID {
+	if id == uuid.Nil {
+		return nil
+	}
+	return &id
+}
+
+// uuidOrNil возвращает uuid.Nil для NULL.
+func uuidOrNil(v pgtype.UUID) uuid.UUID {
+	if !v.Valid {
+		return uuid.Nil
+	}
+	return v.Bytes
+}
+
+// timeOrNil возвращает nil для NULL.
+func timeOrNil(v pgtype.Timestamptz) *time.Time {
+	if !v.Valid {
+		return nil
+	}
+	return &v.Time
+}
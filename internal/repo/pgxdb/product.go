@@ -2,16 +2,96 @@ package pgxdb
 
 import (
 	"context"
+	"crypto/rand"
 	"errors"
+	"fmt"
 	"github.com/GlebMoskalev/go-pickup-point-api/internal/entity"
 	"github.com/GlebMoskalev/go-pickup-point-api/internal/repo/repoerr"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"log/slog"
+	"math/big"
+	"slices"
 	"time"
 )
 
+const (
+	storedBarcodeIndex    = "products_stored_barcode_idx"
+	storedPickupCodeIndex = "products_stored_pickup_code_idx"
+	pickupCodeAttempts    = 5
+)
+
+// errPickupCodeTaken — код получения занят товаром, добавленным параллельно в тот же ПВЗ.
+var errPickupCodeTaken = errors.New("pickup code is taken")
+
+// insertProductQuery присваивает товару следующий номер в его приёмке. Увеличение счётчика
+// блокирует строку приёмки до конца транзакции, поэтому номера идут подряд и не повторяются.
+// Первая запись истории местонахождения добавляется тем же запросом.
+const insertProductQuery = `
+	WITH next_number AS (
+	    UPDATE receptions
+	    SET last_order_number = last_order_number + 1
+	    WHERE id = $2
+	    RETURNING last_order_number, pvz_id
+	), inserted AS (
+	    INSERT INTO products (type, reception_id, added_by, barcode, attributes, pickup_code, cell_id,
+	                          return_reason, original_product_id, declared_value, currency, order_number, pvz_id)
+	    VALUES ($1, $2, $3, NULLIF($4, ''), $5, $6, $7, NULLIF($8, ''), $9, NULLIF($10::BIGINT, 0),
+	            NULLIF($11, ''), (SELECT last_order_number FROM next_number), (SELECT pvz_id FROM next_number))
+	    RETURNING id, date_time, order_number, status, pvz_id, reception_id, added_by
+	), location AS (
+	    INSERT INTO product_locations (product_id, pvz_id, reception_id, changed_by, changed_at)
+	    SELECT id, pvz_id, reception_id, added_by, date_time
+	    FROM inserted
+	)
+	SELECT id, date_time, order_number, status, pvz_id
+	FROM inserted
+`
+
+// transferProductQuery отмечает товар в пути принятым в ПВЗ назначения. Строка товара остаётся
+// в приёмке отправления с прежним номером и ПВЗ.
+const transferProductQuery = `
+	UPDATE products
+	SET status = 'transferred'
+	WHERE id = $1 AND status = 'in_transit' AND deleted_at IS NULL
+`
+
+// receiveProductQuery заводит перемещённый товар новой строкой в приёмке ПВЗ назначения: товар
+// получает следующий номер в приёмке, новый код получения и ячейку. В историю местонахождения
+// запись о прибытии добавляется и новой строке, и строке в ПВЗ отправления.
+const receiveProductQuery = `
+	WITH next_number AS (
+	    UPDATE receptions
+	    SET last_order_number = last_order_number + 1
+	    WHERE id = $2
+	    RETURNING last_order_number, pvz_id
+	), inserted AS (
+	    INSERT INTO products (type, reception_id, added_by, barcode, attributes, pickup_code, cell_id,
+	                          declared_value, currency, damage_description, damaged_by, damaged_at,
+	                          order_number, pvz_id, source_product_id)
+	    SELECT type, $2, $6, barcode, attributes, $3, $4, declared_value, currency, damage_description,
+	           damaged_by, damaged_at, (SELECT last_order_number FROM next_number),
+	           (SELECT pvz_id FROM next_number), id
+	    FROM products
+	    WHERE id = $1
+	    RETURNING id, date_time, type, barcode, attributes, reception_id, pvz_id, order_number, status,
+	              pickup_code, added_by, source_product_id
+	), location AS (
+	    INSERT INTO product_locations (product_id, pvz_id, reception_id, transfer_id, changed_by)
+	    SELECT id, pvz_id, reception_id, $5, $6
+	    FROM inserted
+	    UNION ALL
+	    SELECT source_product_id, pvz_id, reception_id, $5, $6
+	    FROM inserted
+	)
+	SELECT id, date_time, type, COALESCE(barcode, ''), attributes, reception_id, pvz_id, order_number, status,
+	       pickup_code, added_by, source_product_id
+	FROM inserted
+`
+
 type ProductRepo struct {
 	db *pgxpool.Pool
 }
@@ -20,15 +100,32 @@ func NewProductRepo(db *pgxpool.Pool) *ProductRepo {
 	return &ProductRepo{db: db}
 }
 
-func (r *ProductRepo) Create(ctx context.Context, receptionID, productType string) (*entity.Product, error) {
-	log := slog.With("layer", "ProductRepo", "operation", "Create", "receptionID", receptionID, "type", productType)
+func (r *ProductRepo) Create(ctx context.Context, product entity.Product) (*entity.Product, error) {
+	log := slog.With("layer", "ProductRepo", "operation", "Create", "receptionID", product.ReceptionID.String(),
+		"type", product.Type)
 	log.Debug("starting product creation")
 
-	tx, err := r.db.Begin(ctx)
+	created, err := r.insertProducts(ctx, log, []entity.Product{product})
 	if err != nil {
-		log.Error("failed to begin transaction", "error", err)
 		return nil, err
 	}
+
+	log.Info("product created successfully", "productID", created[0].ID.String())
+	return &created[0], nil
+}
+
+// DeleteLastProduct помечает последний товар приёмки удалённым; запись остаётся для аудита.
+// Если последний товар уже не хранится (выдан, отправлен или возвращён), удаления не происходит.
+func (r *ProductRepo) DeleteLastProduct(ctx context.Context, receptionID string, deletedBy uuid.UUID) error {
+	log := slog.With("layer", "ProductRepo", "operation", "DeleteLastProduct", "receptionID", receptionID)
+	log.Debug("starting product deletion")
+
+	tx, err := conn(ctx, r.db).Begin(ctx)
+	if err != nil {
+		log.Error("failed to begin transaction", "error", err)
+		return err
+	}
+
 	defer func() {
 		if err != nil {
 			if rollbackErr := tx.Rollback(ctx); rollbackErr != nil {
@@ -38,44 +135,342 @@ func (r *ProductRepo) Create(ctx context.Context, receptionID, productType strin
 	}()
 
 	query := `
-	INSERT INTO products (type, reception_id) 
-	VALUES ($1, $2)
-	RETURNING id, date_time, reception_id
+	UPDATE products
+	SET deleted_at = NOW(), deleted_by = $2
+	WHERE id = (
+	    SELECT id
+	    FROM products
+	    WHERE reception_id = $1 AND deleted_at IS NULL
+	    ORDER BY order_number DESC 
+	    LIMIT 1
+	) AND status = 'stored'
+	RETURNING id
 `
-	var id, receptionUUID uuid.UUID
-	var dateTime time.Time
 
-	err = tx.QueryRow(ctx, query, productType, receptionID).Scan(&id, &dateTime, &receptionUUID)
+	var id uuid.UUID
+	err = tx.QueryRow(ctx, query, receptionID, nullUUID(deletedBy)).Scan(&id)
 	if err != nil {
-		log.Error("failed to create product", "error", err)
-		return nil, err
+		if errors.Is(err, pgx.ErrNoRows) {
+			log.Error("not found product")
+			return repoerr.ErrNoRows
+		}
+		log.Error("failed to delete product", "error", err)
+		return err
 	}
 
 	if err = tx.Commit(ctx); err != nil {
 		log.Error("failed to commit transaction", "error", err)
+		return err
+	}
+
+	log.Info("product deleted successfully", "productID", id.String())
+	return nil
+}
+
+func (r *ProductRepo) CountByType(ctx context.Context, receptionID string) (map[string]int, error) {
+	log := slog.With("layer", "ProductRepo", "operation", "CountByType", "receptionID", receptionID)
+	log.Debug("counting products by type")
+
+	query := `
+	SELECT type, COUNT(*)
+	FROM products
+	WHERE reception_id = $1 AND deleted_at IS NULL
+	GROUP BY type
+`
+	rows, err := conn(ctx, r.db).Query(ctx, query, receptionID)
+	if err != nil {
+		log.Error("failed to count products", "error", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := make(map[string]int)
+	for rows.Next() {
+		var (
+			productType string
+			count       int
+		)
+		if err := rows.Scan(&productType, &count); err != nil {
+			log.Error("failed to scan product count", "error", err)
+			return nil, err
+		}
+		counts[productType] = count
+	}
+	if err := rows.Err(); err != nil {
+		log.Error("rows error", "error", err)
 		return nil, err
 	}
 
-	product := entity.Product{
-		ID:          id,
-		DateTime:    dateTime,
-		Type:        productType,
-		ReceptionID: receptionUUID,
+	log.Debug("products counted", "types", len(counts))
+	return counts, nil
+}
+
+// ListByReception возвращает неудалённые товары приёмки в порядке добавления.
+func (r *ProductRepo) ListByReception(ctx context.Context, receptionID string) ([]entity.Product, error) {
+	log := slog.With("layer", "ProductRepo", "operation", "ListByReception", "receptionID", receptionID)
+	log.Debug("starting list products")
+
+	query := `
+	SELECT id, date_time, type, COALESCE(barcode, ''), attributes, reception_id, order_number, status, added_by,
+	       COALESCE(return_reason, ''), original_product_id, COALESCE(declared_value, 0), COALESCE(currency, '')
+	FROM products
+	WHERE reception_id = $1 AND deleted_at IS NULL
+	ORDER BY order_number
+`
+	rows, err := conn(ctx, r.db).Query(ctx, query, receptionID)
+	if err != nil {
+		log.Error("failed to list products", "error", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	products := []entity.Product{}
+	for rows.Next() {
+		var (
+			product    entity.Product
+			addedBy    pgtype.UUID
+			originalID pgtype.UUID
+		)
+		err := rows.Scan(&product.ID, &product.DateTime, &product.Type, &product.Barcode, &product.Attributes,
+			&product.ReceptionID, &product.OrderNumber, &product.Status, &addedBy, &product.ReturnReason,
+			&originalID, &product.DeclaredValue, &product.Currency)
+		if err != nil {
+			log.Error("failed to scan product", "error", err)
+			return nil, err
+		}
+		product.AddedBy = uuidOrNil(addedBy)
+		product.OriginalProductID = uuidOrNil(originalID)
+		products = append(products, product)
+	}
+	if err := rows.Err(); err != nil {
+		log.Error("failed to iterate products", "error", err)
+		return nil, err
 	}
+	return products, nil
+}
+
+// GetByBarcode возвращает хранящийся (неудалённый и не выданный) товар с указанным штрихкодом.
+func (r *ProductRepo) GetByBarcode(ctx context.Context, barcode string) (*entity.Product, error) {
+	log := slog.With("layer", "ProductRepo", "operation", "GetByBarcode", "barcode", barcode)
+	log.Debug("starting get product by barcode")
 
-	log.Info("product created successfully", "productID", id.String())
+	query := `
+	SELECT p.id, p.date_time, p.type, p.barcode, p.attributes, p.reception_id, p.pvz_id, p.order_number, p.status,
+	       p.added_by, p.cell_id, COALESCE(c.shelf, ''), COALESCE(c.code, ''), COALESCE(p.declared_value, 0),
+	       COALESCE(p.currency, '')
+	FROM products p
+	LEFT JOIN storage_cells c ON c.id = p.cell_id
+	WHERE p.barcode = $1 AND p.deleted_at IS NULL AND p.status = 'stored'
+`
+	var (
+		product entity.Product
+		addedBy pgtype.UUID
+		cellID  pgtype.UUID
+	)
+	err := conn(ctx, r.db).QueryRow(ctx, query, barcode).Scan(&product.ID, &product.DateTime, &product.Type,
+		&product.Barcode, &product.Attributes, &product.ReceptionID, &product.PVZID, &product.OrderNumber,
+		&product.Status, &addedBy, &cellID, &product.CellShelf, &product.CellCode, &product.DeclaredValue,
+		&product.Currency)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			log.Debug("product not found")
+			return nil, repoerr.ErrNoRows
+		}
+		log.Error("failed to get product", "error", err)
+		return nil, err
+	}
+	product.AddedBy = uuidOrNil(addedBy)
+	product.CellID = uuidOrNil(cellID)
 	return &product, nil
 }
 
-func (r *ProductRepo) DeleteLastProduct(ctx context.Context, receptionID string) error {
-	log := slog.With("layer", "ProductRepo", "operation", "DeleteLastProduct", "receptionID", receptionID)
+// GetByID возвращает товар, в том числе удалённый.
+func (r *ProductRepo) GetByID(ctx context.Context, productID string) (*entity.Product, error) {
+	log := slog.With("layer", "ProductRepo", "operation", "GetByID", "productID", productID)
+	log.Debug("starting get product")
+
+	query := `
+	SELECT p.id, p.date_time, p.type, COALESCE(p.barcode, ''), p.attributes, p.reception_id, p.pvz_id,
+	       p.order_number, p.status, p.pickup_code, p.cell_id, COALESCE(c.shelf, ''), COALESCE(c.code, ''),
+	       COALESCE(p.return_reason, ''), p.original_product_id, p.added_by, p.deleted_by, p.deleted_at,
+	       p.issued_by, p.issued_at, p.stored_at, p.overdue_at, p.returned_by, p.returned_at,
+	       COALESCE(p.damage_description, ''), p.damaged_by, p.damaged_at, COALESCE(p.declared_value, 0),
+	       COALESCE(p.currency, ''), p.source_product_id
+	FROM products p
+	LEFT JOIN storage_cells c ON c.id = p.cell_id
+	WHERE p.id = $1
+`
+	var (
+		product    entity.Product
+		cellID     pgtype.UUID
+		originalID pgtype.UUID
+		addedBy    pgtype.UUID
+		deletedBy  pgtype.UUID
+		deletedAt  pgtype.Timestamptz
+		issuedBy   pgtype.UUID
+		issuedAt   pgtype.Timestamptz
+		overdueAt  pgtype.Timestamptz
+		returnedBy pgtype.UUID
+		returnedAt pgtype.Timestamptz
+		damagedBy  pgtype.UUID
+		damagedAt  pgtype.Timestamptz
+		sourceID   pgtype.UUID
+	)
+	err := conn(ctx, r.db).QueryRow(ctx, query, productID).Scan(&product.ID, &product.DateTime, &product.Type,
+		&product.Barcode, &product.Attributes, &product.ReceptionID, &product.PVZID, &product.OrderNumber,
+		&product.Status, &product.PickupCode, &cellID, &product.CellShelf, &product.CellCode,
+		&product.ReturnReason, &originalID, &addedBy, &deletedBy, &deletedAt, &issuedBy, &issuedAt,
+		&product.StoredAt, &overdueAt, &returnedBy, &returnedAt, &product.DamageDescription, &damagedBy, &damagedAt,
+		&product.DeclaredValue, &product.Currency, &sourceID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			log.Warn("product not found")
+			return nil, repoerr.ErrNoRows
+		}
+		log.Error("failed to get product", "error", err)
+		return nil, err
+	}
+	product.CellID = uuidOrNil(cellID)
+	product.OriginalProductID = uuidOrNil(originalID)
+	product.SourceProductID = uuidOrNil(sourceID)
+	product.AddedBy = uuidOrNil(addedBy)
+	product.DeletedBy = uuidOrNil(deletedBy)
+	product.DeletedAt = timeOrNil(deletedAt)
+	product.IssuedBy = uuidOrNil(issuedBy)
+	product.IssuedAt = timeOrNil(issuedAt)
+	product.OverdueAt = timeOrNil(overdueAt)
+	product.ReturnedBy = uuidOrNil(returnedBy)
+	product.ReturnedAt = timeOrNil(returnedAt)
+	product.DamagedBy = uuidOrNil(damagedBy)
+	product.DamagedAt = timeOrNil(damagedAt)
+	return &product, nil
+}
+
+// GetByPickupCode возвращает хранящийся в ПВЗ товар с указанным кодом получения.
+func (r *ProductRepo) GetByPickupCode(ctx context.Context, pvzID, code string) (*entity.Product, error) {
+	log := slog.With("layer", "ProductRepo", "operation", "GetByPickupCode", "pvzID", pvzID)
+	log.Debug("starting get product by pickup code")
+
+	query := `
+	SELECT p.id, p.date_time, p.type, COALESCE(p.barcode, ''), p.attributes, p.reception_id, p.pvz_id,
+	       p.order_number, p.status, p.pickup_code, p.cell_id, COALESCE(c.shelf, ''), COALESCE(c.code, ''),
+	       p.added_by, COALESCE(p.declared_value, 0), COALESCE(p.currency, '')
+	FROM products p
+	LEFT JOIN storage_cells c ON c.id = p.cell_id
+	WHERE p.pvz_id = $1 AND p.pickup_code = $2 AND p.status = 'stored' AND p.deleted_at IS NULL
+`
+	var (
+		product entity.Product
+		cellID  pgtype.UUID
+		addedBy pgtype.UUID
+	)
+	err := conn(ctx, r.db).QueryRow(ctx, query, pvzID, code).Scan(&product.ID, &product.DateTime, &product.Type,
+		&product.Barcode, &product.Attributes, &product.ReceptionID, &product.PVZID, &product.OrderNumber,
+		&product.Status, &product.PickupCode, &cellID, &product.CellShelf, &product.CellCode, &addedBy,
+		&product.DeclaredValue, &product.Currency)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			log.Debug("product not found")
+			return nil, repoerr.ErrNoRows
+		}
+		log.Error("failed to get product", "error", err)
+		return nil, err
+	}
+	product.CellID = uuidOrNil(cellID)
+	product.AddedBy = uuidOrNil(addedBy)
+	return &product, nil
+}
+
+// Delete помечает хранящийся товар удалённым. Уже удалённый или не хранящийся товар не найдётся.
+func (r *ProductRepo) Delete(ctx context.Context, productID string, deletedBy uuid.UUID) error {
+	log := slog.With("layer", "ProductRepo", "operation", "Delete", "productID", productID)
 	log.Debug("starting product deletion")
 
-	tx, err := r.db.Begin(ctx)
+	query := `
+	UPDATE products
+	SET deleted_at = NOW(), deleted_by = $2
+	WHERE id = $1 AND deleted_at IS NULL AND status = 'stored'
+`
+	tag, err := conn(ctx, r.db).Exec(ctx, query, productID, nullUUID(deletedBy))
 	if err != nil {
-		log.Error("failed to begin transaction", "error", err)
+		log.Error("failed to delete product", "error", err)
 		return err
 	}
+	if tag.RowsAffected() == 0 {
+		log.Warn("product not found")
+		return repoerr.ErrNoRows
+	}
+
+	log.Info("product deleted successfully")
+	return nil
+}
+
+// DeleteByReception помечает удалёнными все хранящиеся товары приёмки и возвращает их число.
+func (r *ProductRepo) DeleteByReception(ctx context.Context, receptionID string, deletedBy uuid.UUID) (int, error) {
+	log := slog.With("layer", "ProductRepo", "operation", "DeleteByReception", "receptionID", receptionID)
+	log.Debug("starting reception products deletion")
+
+	query := `
+	UPDATE products
+	SET deleted_at = NOW(), deleted_by = $2
+	WHERE reception_id = $1 AND deleted_at IS NULL AND status = 'stored'
+`
+	tag, err := conn(ctx, r.db).Exec(ctx, query, receptionID, nullUUID(deletedBy))
+	if err != nil {
+		log.Error("failed to delete products", "error", err)
+		return 0, err
+	}
+
+	deleted := int(tag.RowsAffected())
+	log.Info("reception products deleted successfully", "count", deleted)
+	return deleted, nil
+}
+
+// CreateBatch добавляет товары одним пакетом запросов и возвращает их в исходном порядке.
+// Повтор хранящегося штрихкода отменяет весь пакет.
+func (r *ProductRepo) CreateBatch(ctx context.Context, products []entity.Product) ([]entity.Product, error) {
+	log := slog.With("layer", "ProductRepo", "operation", "CreateBatch", "count", len(products))
+	log.Debug("starting batch product creation")
+
+	created, err := r.insertProducts(ctx, log, products)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info("products created successfully", "count", len(created))
+	return created, nil
+}
+
+// insertProducts добавляет товары с кодами получения, свободными в ПВЗ их приёмок. Если свободный
+// код параллельно занял другой товар, вставка повторяется с новыми кодами.
+func (r *ProductRepo) insertProducts(ctx context.Context, log *slog.Logger,
+	products []entity.Product) ([]entity.Product, error) {
+	for attempt := 1; ; attempt++ {
+		created, err := r.tryInsertProducts(ctx, log, products)
+		if errors.Is(err, errPickupCodeTaken) && attempt < pickupCodeAttempts {
+			log.Warn("pickup code collision, retrying", "attempt", attempt)
+			continue
+		}
+		if err != nil {
+			var pgxError *pgconn.PgError
+			if errors.As(err, &pgxError) && pgxError.Code == "23505" &&
+				pgxError.ConstraintName == storedBarcodeIndex {
+				log.Warn("stored product with barcode already exists")
+				return nil, repoerr.ErrDuplicateEntry
+			}
+			log.Error("failed to create products", "error", err)
+			return nil, err
+		}
+		return created, nil
+	}
+}
+
+func (r *ProductRepo) tryInsertProducts(ctx context.Context, log *slog.Logger,
+	products []entity.Product) ([]entity.Product, error) {
+	tx, err := conn(ctx, r.db).Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
 
 	defer func() {
 		if err != nil {
@@ -85,34 +480,551 @@ func (r *ProductRepo) DeleteLastProduct(ctx context.Context, receptionID string)
 		}
 	}()
 
+	codes, err := freePickupCodes(ctx, tx, products)
+	if err != nil {
+		return nil, err
+	}
+
+	created := make([]entity.Product, len(products))
+	batch := &pgx.Batch{}
+	for i, product := range products {
+		product.PickupCode = codes[i]
+		batch.Queue(insertProductQuery, product.Type, product.ReceptionID, nullUUID(product.AddedBy), product.Barcode,
+			product.Attributes, product.PickupCode, nullUUID(product.CellID), product.ReturnReason,
+			nullUUID(product.OriginalProductID), product.DeclaredValue, product.Currency)
+		created[i] = product
+	}
+
+	results := tx.SendBatch(ctx, batch)
+	for i := range created {
+		err = results.QueryRow().Scan(&created[i].ID, &created[i].DateTime, &created[i].OrderNumber,
+			&created[i].Status, &created[i].PVZID)
+		if err != nil {
+			break
+		}
+	}
+	if closeErr := results.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		var pgxError *pgconn.PgError
+		if errors.As(err, &pgxError) && pgxError.Code == "23505" &&
+			pgxError.ConstraintName == storedPickupCodeIndex {
+			err = errPickupCodeTaken
+		}
+		return nil, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+// freePickupCodes подбирает товарам различные коды получения, не занятые хранящимися товарами
+// в ПВЗ их приёмок.
+func freePickupCodes(ctx context.Context, tx pgx.Tx, products []entity.Product) ([]string, error) {
 	query := `
-	DELETE FROM products
-	WHERE id = (
-	    SELECT id
-	    FROM products
-	    WHERE reception_id = $1
-	    ORDER BY order_number DESC 
-	    LIMIT 1
-	)
-	RETURNING id
+	SELECT c.code
+	FROM unnest($1::uuid[], $2::text[]) AS c(reception_id, code)
+	INNER JOIN receptions r ON r.id = c.reception_id
+	INNER JOIN products p ON p.pvz_id = r.pvz_id AND p.pickup_code = c.code
+	WHERE p.status = 'stored' AND p.deleted_at IS NULL
 `
+	codes := make([]string, len(products))
+	used := make(map[string]bool, len(products))
+	pending := make([]int, len(products))
+	for i := range pending {
+		pending[i] = i
+	}
 
-	var id uuid.UUID
-	err = tx.QueryRow(ctx, query, receptionID).Scan(&id)
+	for attempt := 0; attempt < pickupCodeAttempts && len(pending) > 0; attempt++ {
+		receptionIDs := make([]uuid.UUID, len(pending))
+		candidates := make([]string, len(pending))
+		for j, i := range pending {
+			code, err := newPickupCode()
+			for err == nil && used[code] {
+				code, err = newPickupCode()
+			}
+			if err != nil {
+				return nil, err
+			}
+			used[code] = true
+			codes[i] = code
+			receptionIDs[j] = products[i].ReceptionID
+			candidates[j] = code
+		}
+
+		rows, err := tx.Query(ctx, query, receptionIDs, candidates)
+		if err != nil {
+			return nil, err
+		}
+		taken, err := pgx.CollectRows(rows, pgx.RowTo[string])
+		if err != nil {
+			return nil, err
+		}
+
+		pending = slices.DeleteFunc(pending, func(i int) bool { return !slices.Contains(taken, codes[i]) })
+	}
+	if len(pending) > 0 {
+		return nil, errPickupCodeTaken
+	}
+	return codes, nil
+}
+
+// ListByBarcodes возвращает хранящиеся товары с указанными штрихкодами.
+func (r *ProductRepo) ListByBarcodes(ctx context.Context, barcodes []string) ([]entity.Product, error) {
+	log := slog.With("layer", "ProductRepo", "operation", "ListByBarcodes", "count", len(barcodes))
+	log.Debug("listing products by barcodes")
+
+	query := `
+	SELECT id, date_time, type, barcode, attributes, reception_id, order_number, status, added_by
+	FROM products
+	WHERE barcode = ANY($1) AND deleted_at IS NULL AND status = 'stored'
+`
+	rows, err := conn(ctx, r.db).Query(ctx, query, barcodes)
+	if err != nil {
+		log.Error("failed to list products", "error", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var products []entity.Product
+	for rows.Next() {
+		var (
+			product entity.Product
+			addedBy pgtype.UUID
+		)
+		err := rows.Scan(&product.ID, &product.DateTime, &product.Type, &product.Barcode, &product.Attributes,
+			&product.ReceptionID, &product.OrderNumber, &product.Status, &addedBy)
+		if err != nil {
+			log.Error("failed to scan product", "error", err)
+			return nil, err
+		}
+		product.AddedBy = uuidOrNil(addedBy)
+		products = append(products, product)
+	}
+	if err := rows.Err(); err != nil {
+		log.Error("rows error", "error", err)
+		return nil, err
+	}
+	return products, nil
+}
+
+// ListIssuedByBarcodes возвращает для каждого из указанных штрихкодов последний выданный товар.
+func (r *ProductRepo) ListIssuedByBarcodes(ctx context.Context, barcodes []string) ([]entity.Product, error) {
+	log := slog.With("layer", "ProductRepo", "operation", "ListIssuedByBarcodes", "count", len(barcodes))
+	log.Debug("listing issued products by barcodes")
+
+	query := `
+	SELECT DISTINCT ON (barcode) id, barcode, reception_id, pvz_id, status, issued_at
+	FROM products
+	WHERE barcode = ANY($1) AND status = 'issued'
+	ORDER BY barcode, issued_at DESC
+`
+	rows, err := conn(ctx, r.db).Query(ctx, query, barcodes)
+	if err != nil {
+		log.Error("failed to list products", "error", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var products []entity.Product
+	for rows.Next() {
+		var (
+			product  entity.Product
+			issuedAt pgtype.Timestamptz
+		)
+		err := rows.Scan(&product.ID, &product.Barcode, &product.ReceptionID, &product.PVZID, &product.Status,
+			&issuedAt)
+		if err != nil {
+			log.Error("failed to scan product", "error", err)
+			return nil, err
+		}
+		product.IssuedAt = timeOrNil(issuedAt)
+		products = append(products, product)
+	}
+	if err := rows.Err(); err != nil {
+		log.Error("rows error", "error", err)
+		return nil, err
+	}
+	return products, nil
+}
+
+// Issue отмечает хранящийся товар выданным и возвращает время выдачи. Товар, который уже
+// выдан, возвращён или удалён, не найдётся.
+func (r *ProductRepo) Issue(ctx context.Context, productID string, issuedBy uuid.UUID) (time.Time, error) {
+	log := slog.With("layer", "ProductRepo", "operation", "Issue", "productID", productID)
+	log.Debug("starting product issuance")
+
+	query := `
+	UPDATE products
+	SET status = 'issued', issued_at = NOW(), issued_by = $2
+	WHERE id = $1 AND status = 'stored' AND deleted_at IS NULL
+	RETURNING issued_at
+`
+	var issuedAt time.Time
+	err := conn(ctx, r.db).QueryRow(ctx, query, productID, nullUUID(issuedBy)).Scan(&issuedAt)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			log.Error("not found product")
-			return repoerr.ErrNoRows
+			log.Warn("stored product not found")
+			return time.Time{}, repoerr.ErrNoRows
 		}
-		log.Error("failed to delete product", "error", err)
+		log.Error("failed to issue product", "error", err)
+		return time.Time{}, err
+	}
+
+	log.Info("product issued successfully")
+	return issuedAt, nil
+}
+
+// SetCell перекладывает хранящийся товар в ячейку. Выданный, возвращённый или удалённый товар
+// не найдётся.
+func (r *ProductRepo) SetCell(ctx context.Context, productID, cellID string) error {
+	log := slog.With("layer", "ProductRepo", "operation", "SetCell", "productID", productID, "cellID", cellID)
+	log.Debug("starting product cell update")
+
+	query := `
+	UPDATE products
+	SET cell_id = $2
+	WHERE id = $1 AND status = 'stored' AND deleted_at IS NULL
+`
+	tag, err := conn(ctx, r.db).Exec(ctx, query, productID, cellID)
+	if err != nil {
+		log.Error("failed to update product cell", "error", err)
 		return err
 	}
+	if tag.RowsAffected() == 0 {
+		log.Warn("stored product not found")
+		return repoerr.ErrNoRows
+	}
 
-	if err = tx.Commit(ctx); err != nil {
-		log.Error("failed to commit transaction", "error", err)
+	log.Info("product cell updated successfully")
+	return nil
+}
+
+// newPickupCode возвращает случайный шестизначный код получения.
+func newPickupCode() (string, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(1_000_000))
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%06d", n.Int64()), nil
+}
+
+// LockByIDs блокирует товары до конца транзакции и возвращает их, в том числе удалённые.
+// Ненайденные идентификаторы пропускаются.
+func (r *ProductRepo) LockByIDs(ctx context.Context, productIDs []uuid.UUID) ([]entity.Product, error) {
+	log := slog.With("layer", "ProductRepo", "operation", "LockByIDs", "count", len(productIDs))
+	log.Debug("locking products")
+
+	query := `
+	SELECT id, date_time, type, COALESCE(barcode, ''), reception_id, pvz_id, status, deleted_at
+	FROM products
+	WHERE id = ANY($1)
+	ORDER BY id
+	FOR UPDATE
+`
+	rows, err := conn(ctx, r.db).Query(ctx, query, productIDs)
+	if err != nil {
+		log.Error("failed to lock products", "error", err)
+		return nil, err
+	}
+	products, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (entity.Product, error) {
+		var (
+			product   entity.Product
+			deletedAt pgtype.Timestamptz
+		)
+		err := row.Scan(&product.ID, &product.DateTime, &product.Type, &product.Barcode, &product.ReceptionID,
+			&product.PVZID, &product.Status, &deletedAt)
+		product.DeletedAt = timeOrNil(deletedAt)
+		return product, err
+	})
+	if err != nil {
+		log.Error("failed to scan products", "error", err)
+		return nil, err
+	}
+	return products, nil
+}
+
+// Dispatch отправляет хранящиеся товары по перемещению transferID: товары переходят в статус
+// in_transit и освобождают ячейки. Если хотя бы один товар уже не хранится, возвращает ErrNoRows.
+func (r *ProductRepo) Dispatch(ctx context.Context, productIDs []uuid.UUID, transferID, userID uuid.UUID) error {
+	log := slog.With("layer", "ProductRepo", "operation", "Dispatch", "transferID", transferID.String(),
+		"count", len(productIDs))
+	log.Debug("starting products dispatch")
+
+	query := `
+	WITH moved AS (
+	    UPDATE products
+	    SET status = 'in_transit', cell_id = NULL
+	    WHERE id = ANY($1) AND status = 'stored' AND deleted_at IS NULL
+	    RETURNING id
+	)
+	INSERT INTO product_locations (product_id, transfer_id, changed_by)
+	SELECT id, $2, $3
+	FROM moved
+`
+	tag, err := conn(ctx, r.db).Exec(ctx, query, productIDs, transferID, nullUUID(userID))
+	if err != nil {
+		log.Error("failed to dispatch products", "error", err)
 		return err
 	}
+	if int(tag.RowsAffected()) != len(productIDs) {
+		log.Warn("some products are not stored", "dispatched", tag.RowsAffected())
+		return repoerr.ErrNoRows
+	}
 
-	log.Info("product deleted successfully", "productID", id.String())
+	log.Info("products dispatched successfully")
 	return nil
 }
+
+// Receive принимает товар в пути product.ID в приёмку product.ReceptionID и ячейку product.CellID.
+// Строка товара в ПВЗ отправления переходит в статус transferred, а в приёмке назначения заводится
+// новая строка со ссылкой на неё; её и возвращает метод. Код получения подбирается свободным в ПВЗ
+// приёмки. Товар, который не находится в пути, не найдётся.
+func (r *ProductRepo) Receive(ctx context.Context, product entity.Product,
+	transferID, userID uuid.UUID) (*entity.Product, error) {
+	log := slog.With("layer", "ProductRepo", "operation", "Receive", "productID", product.ID.String(),
+		"receptionID", product.ReceptionID.String())
+	log.Debug("starting product receipt")
+
+	for attempt := 1; ; attempt++ {
+		received, err := r.tryReceive(ctx, log, product, transferID, userID)
+		if errors.Is(err, errPickupCodeTaken) && attempt < pickupCodeAttempts {
+			log.Warn("pickup code collision, retrying", "attempt", attempt)
+			continue
+		}
+		if err != nil {
+			var pgxError *pgconn.PgError
+			switch {
+			case errors.Is(err, pgx.ErrNoRows):
+				log.Warn("product in transit not found")
+				return nil, repoerr.ErrNoRows
+			case errors.As(err, &pgxError) && pgxError.Code == "23505" &&
+				pgxError.ConstraintName == storedBarcodeIndex:
+				log.Warn("stored product with barcode already exists")
+				return nil, repoerr.ErrDuplicateEntry
+			}
+			log.Error("failed to receive product", "error", err)
+			return nil, err
+		}
+
+		log.Info("product received successfully")
+		return received, nil
+	}
+}
+
+func (r *ProductRepo) tryReceive(ctx context.Context, log *slog.Logger, product entity.Product,
+	transferID, userID uuid.UUID) (*entity.Product, error) {
+	tx, err := conn(ctx, r.db).Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if err != nil {
+			if rollbackErr := tx.Rollback(ctx); rollbackErr != nil {
+				log.Error("failed to rollback transaction", "error", rollbackErr)
+			}
+		}
+	}()
+
+	codes, err := freePickupCodes(ctx, tx, []entity.Product{product})
+	if err != nil {
+		return nil, err
+	}
+
+	tag, err := tx.Exec(ctx, transferProductQuery, product.ID)
+	if err != nil {
+		return nil, err
+	}
+	if tag.RowsAffected() == 0 {
+		err = pgx.ErrNoRows
+		return nil, err
+	}
+
+	received := product
+	var addedBy, sourceID pgtype.UUID
+	err = tx.QueryRow(ctx, receiveProductQuery, product.ID, product.ReceptionID, codes[0], nullUUID(product.CellID),
+		transferID, nullUUID(userID)).Scan(&received.ID, &received.DateTime, &received.Type, &received.Barcode,
+		&received.Attributes, &received.ReceptionID, &received.PVZID, &received.OrderNumber, &received.Status,
+		&received.PickupCode, &addedBy, &sourceID)
+	if err != nil {
+		var pgxError *pgconn.PgError
+		if errors.As(err, &pgxError) && pgxError.Code == "23505" &&
+			pgxError.ConstraintName == storedPickupCodeIndex {
+			err = errPickupCodeTaken
+		}
+		return nil, err
+	}
+	received.AddedBy = uuidOrNil(addedBy)
+	received.SourceProductID = uuidOrNil(sourceID)
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return &received, nil
+}
+
+// ListLocations возвращает историю местонахождения товара в хронологическом порядке.
+func (r *ProductRepo) ListLocations(ctx context.Context, productID string) ([]entity.ProductLocation, error) {
+	log := slog.With("layer", "ProductRepo", "operation", "ListLocations", "productID", productID)
+	log.Debug("listing product locations")
+
+	query := `
+	SELECT product_id, pvz_id, reception_id, transfer_id, changed_by, changed_at
+	FROM product_locations
+	WHERE product_id = $1
+	ORDER BY changed_at, id
+`
+	rows, err := conn(ctx, r.db).Query(ctx, query, productID)
+	if err != nil {
+		log.Error("failed to list product locations", "error", err)
+		return nil, err
+	}
+	locations, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (entity.ProductLocation, error) {
+		var (
+			location                                  entity.ProductLocation
+			pvzID, receptionID, transferID, changedBy pgtype.UUID
+		)
+		err := row.Scan(&location.ProductID, &pvzID, &receptionID, &transferID, &changedBy, &location.ChangedAt)
+		location.PVZID = uuidOrNil(pvzID)
+		location.ReceptionID = uuidOrNil(receptionID)
+		location.TransferID = uuidOrNil(transferID)
+		location.ChangedBy = uuidOrNil(changedBy)
+		return location, err
+	})
+	if err != nil {
+		log.Error("failed to scan product locations", "error", err)
+		return nil, err
+	}
+	return locations, nil
+}
+
+// FlagOverdue помещает в очередь на возврат отправителю хранящиеся товары закрытых приёмок, срок
+// хранения которых истёк, и возвращает их число. Срок берётся из типа товара, затем из настроек ПВЗ,
+// иначе — defaultDays. Отсчёт идёт от поступления товара в ПВЗ, но не раньше закрытия приёмки.
+func (r *ProductRepo) FlagOverdue(ctx context.Context, defaultDays int) (int, error) {
+	log := slog.With("layer", "ProductRepo", "operation", "FlagOverdue", "defaultDays", defaultDays)
+	log.Debug("starting overdue products flagging")
+
+	query := `
+	UPDATE products pr
+	SET overdue_at = NOW()
+	FROM receptions r, pvz p, product_types t
+	WHERE r.id = pr.reception_id AND p.id = pr.pvz_id AND t.name = pr.type
+	  AND pr.status = 'stored' AND pr.deleted_at IS NULL AND pr.overdue_at IS NULL
+	  AND r.status IN ('close', 'verified')
+	  AND GREATEST(pr.stored_at, r.closed_at) +
+	      make_interval(days => COALESCE(t.storage_days, p.storage_days, $1)) <= NOW()
+`
+	tag, err := conn(ctx, r.db).Exec(ctx, query, defaultDays)
+	if err != nil {
+		log.Error("failed to flag overdue products", "error", err)
+		return 0, err
+	}
+
+	flagged := int(tag.RowsAffected())
+	if flagged > 0 {
+		log.Info("overdue products flagged", "count", flagged)
+	}
+	return flagged, nil
+}
+
+// ListOverdue возвращает очередь ПВЗ на возврат отправителю: хранящиеся товары с истёкшим сроком
+// хранения, начиная с самых давних.
+func (r *ProductRepo) ListOverdue(ctx context.Context, pvzID string) ([]entity.Product, error) {
+	log := slog.With("layer", "ProductRepo", "operation", "ListOverdue", "pvzID", pvzID)
+	log.Debug("listing overdue products")
+
+	query := `
+	SELECT p.id, p.date_time, p.type, COALESCE(p.barcode, ''), p.attributes, p.reception_id, p.pvz_id,
+	       p.order_number, p.status, p.pickup_code, p.cell_id, COALESCE(c.shelf, ''), COALESCE(c.code, ''),
+	       p.added_by, p.stored_at, p.overdue_at
+	FROM products p
+	LEFT JOIN storage_cells c ON c.id = p.cell_id
+	WHERE p.pvz_id = $1 AND p.status = 'stored' AND p.deleted_at IS NULL AND p.overdue_at IS NOT NULL
+	ORDER BY p.overdue_at, p.stored_at, p.id
+`
+	rows, err := conn(ctx, r.db).Query(ctx, query, pvzID)
+	if err != nil {
+		log.Error("failed to list overdue products", "error", err)
+		return nil, err
+	}
+	products, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (entity.Product, error) {
+		var (
+			product   entity.Product
+			cellID    pgtype.UUID
+			addedBy   pgtype.UUID
+			overdueAt pgtype.Timestamptz
+		)
+		err := row.Scan(&product.ID, &product.DateTime, &product.Type, &product.Barcode, &product.Attributes,
+			&product.ReceptionID, &product.PVZID, &product.OrderNumber, &product.Status, &product.PickupCode,
+			&cellID, &product.CellShelf, &product.CellCode, &addedBy, &product.StoredAt, &overdueAt)
+		product.CellID = uuidOrNil(cellID)
+		product.AddedBy = uuidOrNil(addedBy)
+		product.OverdueAt = timeOrNil(overdueAt)
+		return product, err
+	})
+	if err != nil {
+		log.Error("failed to scan overdue products", "error", err)
+		return nil, err
+	}
+	return products, nil
+}
+
+// ReturnToSender отмечает товар из очереди на возврат отправленным отправителю и возвращает время
+// отправки. Товар, который не хранится или не попал в очередь, не найдётся.
+func (r *ProductRepo) ReturnToSender(ctx context.Context, productID string, returnedBy uuid.UUID) (time.Time, error) {
+	log := slog.With("layer", "ProductRepo", "operation", "ReturnToSender", "productID", productID)
+	log.Debug("starting product return to sender")
+
+	query := `
+	UPDATE products
+	SET status = 'returned_to_sender', returned_at = NOW(), returned_by = $2
+	WHERE id = $1 AND status = 'stored' AND deleted_at IS NULL AND overdue_at IS NOT NULL
+	RETURNING returned_at
+`
+	var returnedAt time.Time
+	err := conn(ctx, r.db).QueryRow(ctx, query, productID, nullUUID(returnedBy)).Scan(&returnedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			log.Warn("overdue product not found")
+			return time.Time{}, repoerr.ErrNoRows
+		}
+		log.Error("failed to return product to sender", "error", err)
+		return time.Time{}, err
+	}
+
+	log.Info("product returned to sender successfully")
+	return returnedAt, nil
+}
+
+// MarkDamaged отмечает хранящийся товар повреждённым. Повторная отметка заменяет описание,
+// автора и время.
+func (r *ProductRepo) MarkDamaged(ctx context.Context, productID, description string,
+	markedBy uuid.UUID) (time.Time, error) {
+	log := slog.With("layer", "ProductRepo", "operation", "MarkDamaged", "productID", productID)
+	log.Debug("starting product damage marking")
+
+	query := `
+	UPDATE products
+	SET damage_description = $2, damaged_by = $3, damaged_at = NOW()
+	WHERE id = $1 AND status = 'stored' AND deleted_at IS NULL
+	RETURNING damaged_at
+`
+	var damagedAt time.Time
+	err := conn(ctx, r.db).QueryRow(ctx, query, productID, description, nullUUID(markedBy)).Scan(&damagedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			log.Warn("stored product not found")
+			return time.Time{}, repoerr.ErrNoRows
+		}
+		log.Error("failed to mark product damaged", "error", err)
+		return time.Time{}, err
+	}
+
+	log.Info("product marked damaged")
+	return damagedAt, nil
+}
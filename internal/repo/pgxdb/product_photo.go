@@ -0,0 +1,107 @@
+package pgxdb
+
+import (
+	"context"
+	"errors"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/entity"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/repo/repoerr"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"log/slog"
+)
+
+type ProductPhotoRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewProductPhotoRepo(db *pgxpool.Pool) *ProductPhotoRepo {
+	return &ProductPhotoRepo{db: db}
+}
+
+// Create сохраняет сведения о фотографии, файл которой уже записан в blob-хранилище.
+func (r *ProductPhotoRepo) Create(ctx context.Context, photo entity.ProductPhoto) (*entity.ProductPhoto, error) {
+	log := slog.With("layer", "ProductPhotoRepo", "operation", "Create", "productID", photo.ProductID.String(),
+		"photoID", photo.ID.String())
+	log.Debug("starting product photo creation")
+
+	query := `
+	INSERT INTO product_photos (id, product_id, blob_key, content_type, size, uploaded_by)
+	VALUES ($1, $2, $3, $4, $5, $6)
+	RETURNING uploaded_at
+`
+	err := conn(ctx, r.db).QueryRow(ctx, query, photo.ID, photo.ProductID, photo.BlobKey, photo.ContentType,
+		photo.Size, nullUUID(photo.UploadedBy)).Scan(&photo.UploadedAt)
+	if err != nil {
+		log.Error("failed to create product photo", "error", err)
+		return nil, err
+	}
+
+	log.Info("product photo created successfully")
+	return &photo, nil
+}
+
+// List возвращает фотографии товара в порядке загрузки.
+func (r *ProductPhotoRepo) List(ctx context.Context, productID string) ([]entity.ProductPhoto, error) {
+	log := slog.With("layer", "ProductPhotoRepo", "operation", "List", "productID", productID)
+	log.Debug("listing product photos")
+
+	query := `
+	SELECT id, product_id, blob_key, content_type, size, uploaded_by, uploaded_at
+	FROM product_photos
+	WHERE product_id = $1
+	ORDER BY uploaded_at, id
+`
+	rows, err := conn(ctx, r.db).Query(ctx, query, productID)
+	if err != nil {
+		log.Error("failed to list product photos", "error", err)
+		return nil, err
+	}
+	photos, err := pgx.CollectRows(rows, scanProductPhoto)
+	if err != nil {
+		log.Error("failed to scan product photos", "error", err)
+		return nil, err
+	}
+
+	log.Debug("product photos listed", "count", len(photos))
+	return photos, nil
+}
+
+// GetByID возвращает фотографию товара; фотография другого товара не найдётся.
+func (r *ProductPhotoRepo) GetByID(ctx context.Context, productID, photoID string) (*entity.ProductPhoto, error) {
+	log := slog.With("layer", "ProductPhotoRepo", "operation", "GetByID", "productID", productID,
+		"photoID", photoID)
+	log.Debug("starting get product photo")
+
+	query := `
+	SELECT id, product_id, blob_key, content_type, size, uploaded_by, uploaded_at
+	FROM product_photos
+	WHERE product_id = $1 AND id = $2
+`
+	rows, err := conn(ctx, r.db).Query(ctx, query, productID, photoID)
+	if err != nil {
+		log.Error("failed to get product photo", "error", err)
+		return nil, err
+	}
+	photo, err := pgx.CollectExactlyOneRow(rows, scanProductPhoto)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			log.Warn("product photo not found")
+			return nil, repoerr.ErrNoRows
+		}
+		log.Error("failed to scan product photo", "error", err)
+		return nil, err
+	}
+	return &photo, nil
+}
+
+func scanProductPhoto(row pgx.CollectableRow) (entity.ProductPhoto, error) {
+	var (
+		photo      entity.ProductPhoto
+		uploadedBy pgtype.UUID
+	)
+	err := row.Scan(&photo.ID, &photo.ProductID, &photo.BlobKey, &photo.ContentType, &photo.Size, &uploadedBy,
+		&photo.UploadedAt)
+	photo.UploadedBy = uuidOrNil(uploadedBy)
+	return photo, err
+}
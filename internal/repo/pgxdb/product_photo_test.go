@@ -0,0 +1,87 @@
+package pgxdb_test
+
+import (
+	"context"
+	"github.com/GlebMoskalev/go-pickup-point-api/integration/helperstest"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/entity"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/repo/pgxdb"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/repo/repoerr"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestProductPhotoRepo(t *testing.T) {
+	ctx := context.Background()
+
+	postgresContainer, dbCfg := helperstest.SetupPostgresContainer(t, ctx)
+	defer postgresContainer.Terminate(ctx)
+
+	dbPool := helperstest.SetupDatabaseConnection(t, ctx, dbCfg)
+	defer dbPool.Close()
+
+	helperstest.ApplyMigrations(t, dbCfg)
+
+	productRepo := pgxdb.NewProductRepo(dbPool)
+	photoRepo := pgxdb.NewProductPhotoRepo(dbPool)
+	pvzID := helperstest.CreatePVZ(t, ctx, dbPool)
+	receptionID := helperstest.CreateAndCloseReception(t, ctx, dbPool, pvzID)
+	userID := uuid.New()
+
+	product, err := productRepo.Create(ctx, entity.Product{ReceptionID: receptionID, Type: entity.ProductTypeShoes,
+		Barcode: "4600000000042"})
+	require.NoError(t, err)
+
+	_, err = productRepo.MarkDamaged(ctx, uuid.NewString(), "смята коробка", userID)
+	assert.ErrorIs(t, err, repoerr.ErrNoRows)
+
+	_, err = productRepo.MarkDamaged(ctx, product.ID.String(), "смята коробка", userID)
+	require.NoError(t, err)
+	damagedAt, err := productRepo.MarkDamaged(ctx, product.ID.String(), "смята коробка, порвана упаковка", userID)
+	require.NoError(t, err, "repeated marking replaces the description")
+
+	damaged, err := productRepo.GetByID(ctx, product.ID.String())
+	require.NoError(t, err)
+	assert.Equal(t, "смята коробка, порвана упаковка", damaged.DamageDescription)
+	assert.Equal(t, userID, damaged.DamagedBy)
+	require.NotNil(t, damaged.DamagedAt)
+	assert.True(t, damagedAt.Equal(*damaged.DamagedAt))
+
+	photos, err := photoRepo.List(ctx, product.ID.String())
+	require.NoError(t, err)
+	assert.Empty(t, photos)
+
+	first, err := photoRepo.Create(ctx, entity.ProductPhoto{ID: uuid.New(), ProductID: product.ID,
+		BlobKey: "products/" + product.ID.String() + "/1.jpg", ContentType: "image/jpeg", Size: 2048,
+		UploadedBy: userID})
+	require.NoError(t, err)
+	assert.False(t, first.UploadedAt.IsZero())
+	second, err := photoRepo.Create(ctx, entity.ProductPhoto{ID: uuid.New(), ProductID: product.ID,
+		BlobKey: "products/" + product.ID.String() + "/2.png", ContentType: "image/png", Size: 1024,
+		UploadedBy: userID})
+	require.NoError(t, err)
+
+	_, err = photoRepo.Create(ctx, entity.ProductPhoto{ID: uuid.New(), ProductID: product.ID,
+		BlobKey: first.BlobKey, ContentType: "image/jpeg", Size: 2048, UploadedBy: userID})
+	assert.Error(t, err, "blob key is unique")
+
+	photos, err = photoRepo.List(ctx, product.ID.String())
+	require.NoError(t, err)
+	require.Len(t, photos, 2)
+	assert.Equal(t, first.ID, photos[0].ID)
+	assert.Equal(t, second.ID, photos[1].ID)
+
+	found, err := photoRepo.GetByID(ctx, product.ID.String(), second.ID.String())
+	require.NoError(t, err)
+	assert.Equal(t, second.BlobKey, found.BlobKey)
+	assert.Equal(t, int64(1024), found.Size)
+	assert.Equal(t, userID, found.UploadedBy)
+
+	_, err = photoRepo.GetByID(ctx, uuid.NewString(), second.ID.String())
+	assert.ErrorIs(t, err, repoerr.ErrNoRows, "photo of another product is not found")
+
+	require.NoError(t, productRepo.Delete(ctx, product.ID.String(), userID))
+	_, err = productRepo.MarkDamaged(ctx, product.ID.String(), "смята коробка", userID)
+	assert.ErrorIs(t, err, repoerr.ErrNoRows, "deleted product cannot be marked damaged")
+}
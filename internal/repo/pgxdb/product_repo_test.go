@@ -7,8 +7,13 @@ import (
 	"github.com/GlebMoskalev/go-pickup-point-api/internal/repo/pgxdb"
 	"github.com/GlebMoskalev/go-pickup-point-api/internal/repo/repoerr"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"sort"
+	"sync"
 	"testing"
+	"time"
 )
 
 func TestProductRepoCreate(t *testing.T) {
@@ -26,28 +31,29 @@ func TestProductRepoCreate(t *testing.T) {
 	pvzID := helperstest.CreatePVZ(t, ctx, dbPool)
 	validReceptionID := helperstest.CreateReception(t, ctx, dbPool, pvzID)
 	invalidReceptionID := uuid.New()
+	userID := uuid.New()
 
 	testCases := []struct {
 		name        string
-		receptionID string
+		receptionID uuid.UUID
 		productType string
 		expectError bool
 	}{
 		{
 			name:        "Create product successfully with shoes type",
-			receptionID: validReceptionID.String(),
+			receptionID: validReceptionID,
 			productType: entity.ProductTypeShoes,
 			expectError: false,
 		},
 		{
 			name:        "Create product successfully with electronics type",
-			receptionID: validReceptionID.String(),
+			receptionID: validReceptionID,
 			productType: entity.ProductTypeElectronics,
 			expectError: false,
 		},
 		{
 			name:        "Create product with invalid reception ID",
-			receptionID: invalidReceptionID.String(),
+			receptionID: invalidReceptionID,
 			productType: entity.ProductTypeElectronics,
 			expectError: true,
 		},
@@ -55,7 +61,11 @@ func TestProductRepoCreate(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			product, err := productRepo.Create(ctx, tc.receptionID, tc.productType)
+			product, err := productRepo.Create(ctx, entity.Product{
+				ReceptionID: tc.receptionID,
+				Type:        tc.productType,
+				AddedBy:     userID,
+			})
 
 			if tc.expectError {
 				require.Error(t, err)
@@ -64,13 +74,14 @@ func TestProductRepoCreate(t *testing.T) {
 				require.NoError(t, err)
 				require.NotNil(t, product)
 				require.Equal(t, tc.productType, product.Type)
-				var exists bool
+				require.Equal(t, userID, product.AddedBy)
+				var addedBy uuid.UUID
 				err = dbPool.QueryRow(ctx,
-					`SELECT EXISTS (SELECT id FROM products WHERE id = $1)`,
+					`SELECT added_by FROM products WHERE id = $1`,
 					product.ID,
-				).Scan(&exists)
+				).Scan(&addedBy)
 				require.NoError(t, err)
-				require.True(t, exists)
+				require.Equal(t, userID, addedBy)
 			}
 		})
 	}
@@ -92,10 +103,12 @@ func TestProductRepoDeleteLastProduct(t *testing.T) {
 	validReceptionID := helperstest.CreateReception(t, ctx, dbPool, pvzID)
 	invalidReceptionID := uuid.New()
 
-	product1, err := productRepo.Create(ctx, validReceptionID.String(), entity.ProductTypeShoes)
+	userID := uuid.New()
+
+	product1, err := productRepo.Create(ctx, entity.Product{ReceptionID: validReceptionID, Type: entity.ProductTypeShoes})
 	require.NoError(t, err)
 
-	product2, err := productRepo.Create(ctx, validReceptionID.String(), entity.ProductTypeElectronics)
+	product2, err := productRepo.Create(ctx, entity.Product{ReceptionID: validReceptionID, Type: entity.ProductTypeElectronics})
 	require.NoError(t, err)
 
 	testCases := []struct {
@@ -119,7 +132,7 @@ func TestProductRepoDeleteLastProduct(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			err := productRepo.DeleteLastProduct(ctx, tc.receptionID)
+			err := productRepo.DeleteLastProduct(ctx, tc.receptionID, userID)
 
 			if tc.expectError {
 				require.Error(t, err)
@@ -129,21 +142,462 @@ func TestProductRepoDeleteLastProduct(t *testing.T) {
 			} else {
 				require.NoError(t, err)
 
-				var exists bool
+				var (
+					deletedBy pgtype.UUID
+					deletedAt pgtype.Timestamptz
+				)
 				err = dbPool.QueryRow(ctx,
-					`SELECT EXISTS (SELECT id FROM products WHERE id = $1)`,
+					`SELECT deleted_by, deleted_at FROM products WHERE id = $1`,
 					product2.ID,
-				).Scan(&exists)
+				).Scan(&deletedBy, &deletedAt)
 				require.NoError(t, err)
-				require.False(t, exists)
+				require.True(t, deletedAt.Valid)
+				require.Equal(t, userID, uuid.UUID(deletedBy.Bytes))
 
 				err = dbPool.QueryRow(ctx,
-					`SELECT EXISTS (SELECT id FROM products WHERE id = $1)`,
+					`SELECT deleted_by, deleted_at FROM products WHERE id = $1`,
 					product1.ID,
-				).Scan(&exists)
+				).Scan(&deletedBy, &deletedAt)
 				require.NoError(t, err)
-				require.True(t, exists)
+				require.False(t, deletedAt.Valid)
+
+				err = productRepo.DeleteLastProduct(ctx, tc.receptionID, userID)
+				require.NoError(t, err, "deleted product must not be deleted again")
+				err = dbPool.QueryRow(ctx,
+					`SELECT deleted_at FROM products WHERE id = $1`,
+					product1.ID,
+				).Scan(&deletedAt)
+				require.NoError(t, err)
+				require.True(t, deletedAt.Valid)
 			}
 		})
 	}
 }
+
+func TestProductRepoListByReception(t *testing.T) {
+	ctx := context.Background()
+
+	postgresContainer, dbCfg := helperstest.SetupPostgresContainer(t, ctx)
+	defer postgresContainer.Terminate(ctx)
+
+	dbPool := helperstest.SetupDatabaseConnection(t, ctx, dbCfg)
+	defer dbPool.Close()
+
+	helperstest.ApplyMigrations(t, dbCfg)
+
+	productRepo := pgxdb.NewProductRepo(dbPool)
+	pvzID := helperstest.CreatePVZ(t, ctx, dbPool)
+	receptionID := helperstest.CreateReception(t, ctx, dbPool, pvzID)
+
+	first, err := productRepo.Create(ctx, entity.Product{ReceptionID: receptionID, Type: entity.ProductTypeShoes})
+	require.NoError(t, err)
+	second, err := productRepo.Create(ctx, entity.Product{ReceptionID: receptionID, Type: entity.ProductTypeClothes})
+	require.NoError(t, err)
+	_, err = productRepo.Create(ctx, entity.Product{ReceptionID: receptionID, Type: entity.ProductTypeElectronics})
+	require.NoError(t, err)
+	require.NoError(t, productRepo.DeleteLastProduct(ctx, receptionID.String(), uuid.New()))
+
+	products, err := productRepo.ListByReception(ctx, receptionID.String())
+	require.NoError(t, err)
+	require.Len(t, products, 2, "deleted products must not be listed")
+	require.Equal(t, first.ID, products[0].ID)
+	require.Equal(t, second.ID, products[1].ID)
+}
+
+func TestProductRepoBarcode(t *testing.T) {
+	ctx := context.Background()
+
+	postgresContainer, dbCfg := helperstest.SetupPostgresContainer(t, ctx)
+	defer postgresContainer.Terminate(ctx)
+
+	dbPool := helperstest.SetupDatabaseConnection(t, ctx, dbCfg)
+	defer dbPool.Close()
+
+	helperstest.ApplyMigrations(t, dbCfg)
+
+	productRepo := pgxdb.NewProductRepo(dbPool)
+	pvzID := helperstest.CreatePVZ(t, ctx, dbPool)
+	receptionID := helperstest.CreateReception(t, ctx, dbPool, pvzID)
+
+	_, err := productRepo.Create(ctx, entity.Product{ReceptionID: receptionID, Type: entity.ProductTypeShoes})
+	require.NoError(t, err)
+	_, err = productRepo.Create(ctx, entity.Product{ReceptionID: receptionID, Type: entity.ProductTypeShoes})
+	require.NoError(t, err, "products without barcode must not conflict")
+
+	stored, err := productRepo.Create(ctx, entity.Product{ReceptionID: receptionID, Type: entity.ProductTypeShoes,
+		Barcode: "ORD-1001"})
+	require.NoError(t, err)
+
+	_, err = productRepo.Create(ctx, entity.Product{ReceptionID: receptionID, Type: entity.ProductTypeClothes,
+		Barcode: "ORD-1001"})
+	require.ErrorIs(t, err, repoerr.ErrDuplicateEntry)
+
+	found, err := productRepo.GetByBarcode(ctx, "ORD-1001")
+	require.NoError(t, err)
+	require.Equal(t, stored.ID, found.ID)
+	require.Equal(t, "ORD-1001", found.Barcode)
+
+	_, err = productRepo.GetByBarcode(ctx, "ORD-404")
+	require.ErrorIs(t, err, repoerr.ErrNoRows)
+
+	require.NoError(t, productRepo.DeleteLastProduct(ctx, receptionID.String(), uuid.New()))
+	_, err = productRepo.GetByBarcode(ctx, "ORD-1001")
+	require.ErrorIs(t, err, repoerr.ErrNoRows, "deleted products are not stored")
+
+	_, err = productRepo.Create(ctx, entity.Product{ReceptionID: receptionID, Type: entity.ProductTypeShoes,
+		Barcode: "ORD-1001"})
+	require.NoError(t, err, "barcode of a deleted product can be scanned again")
+}
+
+func TestProductRepoDelete(t *testing.T) {
+	ctx := context.Background()
+
+	postgresContainer, dbCfg := helperstest.SetupPostgresContainer(t, ctx)
+	defer postgresContainer.Terminate(ctx)
+
+	dbPool := helperstest.SetupDatabaseConnection(t, ctx, dbCfg)
+	defer dbPool.Close()
+
+	helperstest.ApplyMigrations(t, dbCfg)
+
+	productRepo := pgxdb.NewProductRepo(dbPool)
+	pvzID := helperstest.CreatePVZ(t, ctx, dbPool)
+	receptionID := helperstest.CreateReception(t, ctx, dbPool, pvzID)
+
+	first, err := productRepo.Create(ctx, entity.Product{ReceptionID: receptionID, Type: entity.ProductTypeShoes})
+	require.NoError(t, err)
+	second, err := productRepo.Create(ctx, entity.Product{ReceptionID: receptionID, Type: entity.ProductTypeClothes})
+	require.NoError(t, err)
+
+	userID := uuid.New()
+	require.NoError(t, productRepo.Delete(ctx, first.ID.String(), userID))
+	require.ErrorIs(t, productRepo.Delete(ctx, first.ID.String(), userID), repoerr.ErrNoRows)
+
+	deleted, err := productRepo.GetByID(ctx, first.ID.String())
+	require.NoError(t, err)
+	require.NotNil(t, deleted.DeletedAt)
+	require.Equal(t, userID, deleted.DeletedBy)
+
+	products, err := productRepo.ListByReception(ctx, receptionID.String())
+	require.NoError(t, err)
+	require.Len(t, products, 1)
+	require.Equal(t, second.ID, products[0].ID, "deleting from the middle keeps later products")
+
+	_, err = productRepo.GetByID(ctx, uuid.New().String())
+	require.ErrorIs(t, err, repoerr.ErrNoRows)
+}
+
+func TestProductRepoDeleteByReception(t *testing.T) {
+	ctx := context.Background()
+
+	postgresContainer, dbCfg := helperstest.SetupPostgresContainer(t, ctx)
+	defer postgresContainer.Terminate(ctx)
+
+	dbPool := helperstest.SetupDatabaseConnection(t, ctx, dbCfg)
+	defer dbPool.Close()
+
+	helperstest.ApplyMigrations(t, dbCfg)
+
+	productRepo := pgxdb.NewProductRepo(dbPool)
+	pvzID := helperstest.CreatePVZ(t, ctx, dbPool)
+	receptionID := helperstest.CreateReception(t, ctx, dbPool, pvzID)
+	otherReceptionID := helperstest.CreateReceptionWithStatus(t, ctx, dbPool, pvzID, entity.StatusClose)
+
+	for _, barcode := range []string{"ORD-1", "ORD-2"} {
+		_, err := productRepo.Create(ctx, entity.Product{ReceptionID: receptionID, Type: entity.ProductTypeShoes,
+			Barcode: barcode})
+		require.NoError(t, err)
+	}
+	other, err := productRepo.Create(ctx, entity.Product{ReceptionID: otherReceptionID, Type: entity.ProductTypeShoes})
+	require.NoError(t, err)
+
+	userID := uuid.New()
+	deleted, err := productRepo.DeleteByReception(ctx, receptionID.String(), userID)
+	require.NoError(t, err)
+	require.Equal(t, 2, deleted)
+
+	products, err := productRepo.ListByReception(ctx, receptionID.String())
+	require.NoError(t, err)
+	require.Empty(t, products)
+
+	kept, err := productRepo.GetByID(ctx, other.ID.String())
+	require.NoError(t, err)
+	require.Nil(t, kept.DeletedAt, "products of other receptions are kept")
+
+	_, err = productRepo.Create(ctx, entity.Product{ReceptionID: otherReceptionID, Type: entity.ProductTypeShoes,
+		Barcode: "ORD-1"})
+	require.NoError(t, err, "barcodes of deleted products are released")
+}
+
+func TestProductRepoCreateBatch(t *testing.T) {
+	ctx := context.Background()
+
+	postgresContainer, dbCfg := helperstest.SetupPostgresContainer(t, ctx)
+	defer postgresContainer.Terminate(ctx)
+
+	dbPool := helperstest.SetupDatabaseConnection(t, ctx, dbCfg)
+	defer dbPool.Close()
+
+	helperstest.ApplyMigrations(t, dbCfg)
+
+	productRepo := pgxdb.NewProductRepo(dbPool)
+	pvzID := helperstest.CreatePVZ(t, ctx, dbPool)
+	receptionID := helperstest.CreateReception(t, ctx, dbPool, pvzID)
+
+	created, err := productRepo.CreateBatch(ctx, []entity.Product{
+		{ReceptionID: receptionID, Type: entity.ProductTypeShoes, Barcode: "ORD-1"},
+		{ReceptionID: receptionID, Type: entity.ProductTypeClothes},
+		{ReceptionID: receptionID, Type: entity.ProductTypeElectronics, Barcode: "ORD-2"},
+	})
+	require.NoError(t, err)
+	require.Len(t, created, 3)
+	require.Equal(t, entity.ProductTypeShoes, created[0].Type, "products are returned in input order")
+	require.Equal(t, "ORD-2", created[2].Barcode)
+
+	stored, err := productRepo.ListByBarcodes(ctx, []string{"ORD-1", "ORD-2", "ORD-404"})
+	require.NoError(t, err)
+	require.Len(t, stored, 2)
+
+	_, err = productRepo.CreateBatch(ctx, []entity.Product{
+		{ReceptionID: receptionID, Type: entity.ProductTypeShoes, Barcode: "ORD-3"},
+		{ReceptionID: receptionID, Type: entity.ProductTypeShoes, Barcode: "ORD-1"},
+	})
+	require.ErrorIs(t, err, repoerr.ErrDuplicateEntry)
+
+	products, err := productRepo.ListByReception(ctx, receptionID.String())
+	require.NoError(t, err)
+	require.Len(t, products, 3, "failed batch inserts nothing")
+}
+
+func TestProductRepoOrderNumber(t *testing.T) {
+	ctx := context.Background()
+
+	postgresContainer, dbCfg := helperstest.SetupPostgresContainer(t, ctx)
+	defer postgresContainer.Terminate(ctx)
+
+	dbPool := helperstest.SetupDatabaseConnection(t, ctx, dbCfg)
+	defer dbPool.Close()
+
+	helperstest.ApplyMigrations(t, dbCfg)
+
+	productRepo := pgxdb.NewProductRepo(dbPool)
+	pvzID := helperstest.CreatePVZ(t, ctx, dbPool)
+	receptionID := helperstest.CreateReception(t, ctx, dbPool, pvzID)
+
+	first, err := productRepo.Create(ctx, entity.Product{ReceptionID: receptionID, Type: entity.ProductTypeShoes})
+	require.NoError(t, err)
+	require.Equal(t, 1, first.OrderNumber)
+	second, err := productRepo.Create(ctx, entity.Product{ReceptionID: receptionID, Type: entity.ProductTypeShoes})
+	require.NoError(t, err)
+	require.Equal(t, 2, second.OrderNumber)
+
+	require.NoError(t, productRepo.DeleteLastProduct(ctx, receptionID.String(), uuid.New()))
+	third, err := productRepo.Create(ctx, entity.Product{ReceptionID: receptionID, Type: entity.ProductTypeShoes})
+	require.NoError(t, err)
+	require.Equal(t, 3, third.OrderNumber, "deleted products keep their numbers")
+
+	otherPVZID := helperstest.CreatePVZ(t, ctx, dbPool)
+	otherReceptionID := helperstest.CreateReception(t, ctx, dbPool, otherPVZID)
+	other, err := productRepo.Create(ctx, entity.Product{ReceptionID: otherReceptionID, Type: entity.ProductTypeShoes})
+	require.NoError(t, err)
+	require.Equal(t, 1, other.OrderNumber, "each reception has its own sequence")
+
+	const workers = 20
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		numbers []int
+	)
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			product, err := productRepo.Create(ctx, entity.Product{ReceptionID: receptionID,
+				Type: entity.ProductTypeClothes})
+			if !assert.NoError(t, err) {
+				return
+			}
+			mu.Lock()
+			numbers = append(numbers, product.OrderNumber)
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	sort.Ints(numbers)
+	require.Len(t, numbers, workers)
+	for i, number := range numbers {
+		require.Equal(t, 4+i, number, "concurrent inserts get consecutive numbers")
+	}
+}
+
+func TestProductRepoIssue(t *testing.T) {
+	ctx := context.Background()
+
+	postgresContainer, dbCfg := helperstest.SetupPostgresContainer(t, ctx)
+	defer postgresContainer.Terminate(ctx)
+
+	dbPool := helperstest.SetupDatabaseConnection(t, ctx, dbCfg)
+	defer dbPool.Close()
+
+	helperstest.ApplyMigrations(t, dbCfg)
+
+	productRepo := pgxdb.NewProductRepo(dbPool)
+	pvzID := helperstest.CreatePVZ(t, ctx, dbPool)
+	receptionID := helperstest.CreateAndCloseReception(t, ctx, dbPool, pvzID)
+
+	product, err := productRepo.Create(ctx, entity.Product{ReceptionID: receptionID, Type: entity.ProductTypeShoes,
+		Barcode: "ORD-1"})
+	require.NoError(t, err)
+	require.Equal(t, entity.ProductStatusStored, product.Status)
+	require.Regexp(t, `^[0-9]{6}$`, product.PickupCode)
+
+	userID := uuid.New()
+	issuedAt, err := productRepo.Issue(ctx, product.ID.String(), userID)
+	require.NoError(t, err)
+	_, err = productRepo.Issue(ctx, product.ID.String(), userID)
+	require.ErrorIs(t, err, repoerr.ErrNoRows, "issued product cannot be issued again")
+
+	issued, err := productRepo.GetByID(ctx, product.ID.String())
+	require.NoError(t, err)
+	require.Equal(t, entity.ProductStatusIssued, issued.Status)
+	require.Equal(t, product.PickupCode, issued.PickupCode)
+	require.Equal(t, userID, issued.IssuedBy)
+	require.WithinDuration(t, issuedAt, *issued.IssuedAt, time.Millisecond)
+
+	require.ErrorIs(t, productRepo.Delete(ctx, product.ID.String(), userID), repoerr.ErrNoRows,
+		"issued product cannot be deleted")
+	require.ErrorIs(t, productRepo.DeleteLastProduct(ctx, receptionID.String(), userID), repoerr.ErrNoRows,
+		"issued last product cannot be deleted")
+
+	_, err = productRepo.GetByBarcode(ctx, "ORD-1")
+	require.ErrorIs(t, err, repoerr.ErrNoRows, "issued products are not stored")
+	_, err = productRepo.Create(ctx, entity.Product{ReceptionID: receptionID, Type: entity.ProductTypeShoes,
+		Barcode: "ORD-1"})
+	require.NoError(t, err, "barcode of an issued product can be received again")
+}
+
+func TestProductRepoPickupCode(t *testing.T) {
+	ctx := context.Background()
+
+	postgresContainer, dbCfg := helperstest.SetupPostgresContainer(t, ctx)
+	defer postgresContainer.Terminate(ctx)
+
+	dbPool := helperstest.SetupDatabaseConnection(t, ctx, dbCfg)
+	defer dbPool.Close()
+
+	helperstest.ApplyMigrations(t, dbCfg)
+
+	productRepo := pgxdb.NewProductRepo(dbPool)
+	pvzID := helperstest.CreatePVZ(t, ctx, dbPool)
+	receptionID := helperstest.CreateReception(t, ctx, dbPool, pvzID)
+
+	batch := make([]entity.Product, 1000)
+	for i := range batch {
+		batch[i] = entity.Product{ReceptionID: receptionID, Type: entity.ProductTypeClothes}
+	}
+	created, err := productRepo.CreateBatch(ctx, batch)
+	require.NoError(t, err)
+
+	codes := make(map[string]bool, len(created))
+	for _, product := range created {
+		require.Equal(t, pvzID, product.PVZID)
+		require.False(t, codes[product.PickupCode], "pickup codes are unique within a pvz")
+		codes[product.PickupCode] = true
+	}
+
+	product := created[0]
+	found, err := productRepo.GetByPickupCode(ctx, pvzID.String(), product.PickupCode)
+	require.NoError(t, err)
+	require.Equal(t, product.ID, found.ID)
+	require.Equal(t, pvzID, found.PVZID)
+
+	otherPVZID := helperstest.CreatePVZ(t, ctx, dbPool)
+	_, err = productRepo.GetByPickupCode(ctx, otherPVZID.String(), product.PickupCode)
+	require.ErrorIs(t, err, repoerr.ErrNoRows, "codes are scoped to a pvz")
+
+	_, err = productRepo.Issue(ctx, product.ID.String(), uuid.New())
+	require.NoError(t, err)
+	_, err = productRepo.GetByPickupCode(ctx, pvzID.String(), product.PickupCode)
+	require.ErrorIs(t, err, repoerr.ErrNoRows, "issued products are not looked up by code")
+}
+
+func TestProductRepoReturn(t *testing.T) {
+	ctx := context.Background()
+
+	postgresContainer, dbCfg := helperstest.SetupPostgresContainer(t, ctx)
+	defer postgresContainer.Terminate(ctx)
+
+	dbPool := helperstest.SetupDatabaseConnection(t, ctx, dbCfg)
+	defer dbPool.Close()
+
+	helperstest.ApplyMigrations(t, dbCfg)
+
+	productRepo := pgxdb.NewProductRepo(dbPool)
+	receptionRepo := pgxdb.NewReceptionRepo(dbPool)
+	pvzRepo := pgxdb.NewPVZRepo(dbPool)
+	pvzID := helperstest.CreatePVZ(t, ctx, dbPool)
+	deliveryID := helperstest.CreateReception(t, ctx, dbPool, pvzID)
+
+	first, err := productRepo.Create(ctx, entity.Product{ReceptionID: deliveryID, Type: entity.ProductTypeShoes,
+		Barcode: "4600000000011"})
+	require.NoError(t, err)
+	_, err = productRepo.Issue(ctx, first.ID.String(), uuid.New())
+	require.NoError(t, err)
+	second, err := productRepo.Create(ctx, entity.Product{ReceptionID: deliveryID, Type: entity.ProductTypeShoes,
+		Barcode: "4600000000011"})
+	require.NoError(t, err)
+	_, err = productRepo.Issue(ctx, second.ID.String(), uuid.New())
+	require.NoError(t, err)
+
+	issued, err := productRepo.ListIssuedByBarcodes(ctx, []string{"4600000000011", "4600000000028"})
+	require.NoError(t, err)
+	require.Len(t, issued, 1)
+	assert.Equal(t, second.ID, issued[0].ID, "the latest issued product is the original")
+
+	returnPVZID := helperstest.CreatePVZ(t, ctx, dbPool)
+	reception, err := receptionRepo.Create(ctx, entity.ReceptionParams{PVZID: returnPVZID.String(),
+		Kind: entity.ReceptionKindReturn})
+	require.NoError(t, err)
+	assert.Equal(t, entity.ReceptionKindReturn, reception.Kind)
+
+	returned, err := productRepo.Create(ctx, entity.Product{ReceptionID: reception.ID, Type: entity.ProductTypeShoes,
+		Barcode: "4600000000011", ReturnReason: "брак", OriginalProductID: second.ID})
+	require.NoError(t, err)
+
+	found, err := productRepo.GetByID(ctx, returned.ID.String())
+	require.NoError(t, err)
+	assert.Equal(t, "брак", found.ReturnReason)
+	assert.Equal(t, second.ID, found.OriginalProductID)
+
+	all, err := pvzRepo.ListWithDetails(ctx, entity.PVZFilter{}, 1, 30)
+	require.NoError(t, err)
+	kinds := make(map[string]bool)
+	for _, pvz := range all {
+		for _, details := range pvz.Receptions {
+			kinds[details.Reception.Kind] = true
+		}
+	}
+	assert.True(t, kinds[entity.ReceptionKindDelivery], "no kind filter lists deliveries")
+	assert.True(t, kinds[entity.ReceptionKindReturn], "no kind filter lists returns")
+
+	deliveries, err := pvzRepo.ListWithDetails(ctx, entity.PVZFilter{Kind: entity.ReceptionKindDelivery}, 1, 30)
+	require.NoError(t, err)
+	for _, pvz := range deliveries {
+		for _, details := range pvz.Receptions {
+			assert.Equal(t, entity.ReceptionKindDelivery, details.Reception.Kind)
+		}
+	}
+
+	returns, err := pvzRepo.ListWithDetails(ctx, entity.PVZFilter{Kind: entity.ReceptionKindReturn}, 1, 30)
+	require.NoError(t, err)
+	var receptions []entity.ReceptionDetails
+	for _, pvz := range returns {
+		receptions = append(receptions, pvz.Receptions...)
+	}
+	require.Len(t, receptions, 1)
+	assert.Equal(t, reception.ID, receptions[0].Reception.ID)
+	require.Len(t, receptions[0].Products, 1)
+	assert.Equal(t, "брак", receptions[0].Products[0].ReturnReason)
+	assert.Equal(t, second.ID, receptions[0].Products[0].OriginalProductID)
+}
@@ -0,0 +1,171 @@
+package pgxdb
+
+import (
+	"context"
+	"errors"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/entity"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/repo/repoerr"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"log/slog"
+)
+
+type ProductTypeRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewProductTypeRepo(db *pgxpool.Pool) *ProductTypeRepo {
+	return &ProductTypeRepo{db: db}
+}
+
+func (r *ProductTypeRepo) Create(ctx context.Context, productType entity.ProductType) (*entity.ProductType, error) {
+	log := slog.With("layer", "ProductTypeRepo", "operation", "Create", "name", productType.Name)
+	log.Debug("starting product type creation")
+
+	query := `
+	INSERT INTO product_types (name, attributes_schema, created_by, storage_days)
+	VALUES ($1, $2, $3, NULLIF($4, 0))
+	RETURNING created_at
+`
+	err := conn(ctx, r.db).QueryRow(ctx, query, productType.Name, productType.AttributesSchema,
+		nullUUID(productType.CreatedBy), productType.StorageDays).Scan(&productType.CreatedAt)
+	if err != nil {
+		var pgxError *pgconn.PgError
+		if errors.As(err, &pgxError) && pgxError.Code == "23505" {
+			log.Warn("product type already exists")
+			return nil, repoerr.ErrDuplicateEntry
+		}
+		log.Error("failed to create product type", "error", err)
+		return nil, err
+	}
+
+	log.Info("product type created successfully")
+	return &productType, nil
+}
+
+func (r *ProductTypeRepo) List(ctx context.Context) ([]entity.ProductType, error) {
+	log := slog.With("layer", "ProductTypeRepo", "operation", "List")
+	log.Debug("listing product types")
+
+	query := `
+	SELECT name, attributes_schema, created_at, created_by, COALESCE(storage_days, 0)
+	FROM product_types
+	ORDER BY name
+`
+	rows, err := conn(ctx, r.db).Query(ctx, query)
+	if err != nil {
+		log.Error("failed to list product types", "error", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var productTypes []entity.ProductType
+	for rows.Next() {
+		var (
+			productType entity.ProductType
+			createdBy   pgtype.UUID
+		)
+		err := rows.Scan(&productType.Name, &productType.AttributesSchema, &productType.CreatedAt, &createdBy,
+			&productType.StorageDays)
+		if err != nil {
+			log.Error("failed to scan product type", "error", err)
+			return nil, err
+		}
+		productType.CreatedBy = uuidOrNil(createdBy)
+		productTypes = append(productTypes, productType)
+	}
+	if err := rows.Err(); err != nil {
+		log.Error("rows error", "error", err)
+		return nil, err
+	}
+
+	log.Debug("product types listed", "count", len(productTypes))
+	return productTypes, nil
+}
+
+func (r *ProductTypeRepo) GetByName(ctx context.Context, name string) (*entity.ProductType, error) {
+	log := slog.With("layer", "ProductTypeRepo", "operation", "GetByName", "name", name)
+	log.Debug("starting get product type")
+
+	query := `
+	SELECT name, attributes_schema, created_at, created_by, COALESCE(storage_days, 0)
+	FROM product_types
+	WHERE name = $1
+`
+	var (
+		productType entity.ProductType
+		createdBy   pgtype.UUID
+	)
+	err := conn(ctx, r.db).QueryRow(ctx, query, name).
+		Scan(&productType.Name, &productType.AttributesSchema, &productType.CreatedAt, &createdBy,
+			&productType.StorageDays)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			log.Warn("product type not found")
+			return nil, repoerr.ErrNoRows
+		}
+		log.Error("failed to get product type", "error", err)
+		return nil, err
+	}
+	productType.CreatedBy = uuidOrNil(createdBy)
+	return &productType, nil
+}
+
+// SetSchema заменяет схему атрибутов типа; nil удаляет схему.
+func (r *ProductTypeRepo) SetSchema(ctx context.Context, productType entity.ProductType) (*entity.ProductType, error) {
+	log := slog.With("layer", "ProductTypeRepo", "operation", "SetSchema", "name", productType.Name)
+	log.Debug("starting product type schema update")
+
+	query := `
+	UPDATE product_types SET attributes_schema = $2
+	WHERE name = $1
+	RETURNING created_at, created_by, COALESCE(storage_days, 0)
+`
+	var createdBy pgtype.UUID
+	err := conn(ctx, r.db).QueryRow(ctx, query, productType.Name, productType.AttributesSchema).
+		Scan(&productType.CreatedAt, &createdBy, &productType.StorageDays)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			log.Warn("product type not found")
+			return nil, repoerr.ErrNoRows
+		}
+		log.Error("failed to update product type", "error", err)
+		return nil, err
+	}
+	productType.CreatedBy = uuidOrNil(createdBy)
+
+	log.Info("product type schema updated")
+	return &productType, nil
+}
+
+// SetStorageDays задаёт срок хранения товаров типа в днях; 0 сбрасывает срок.
+func (r *ProductTypeRepo) SetStorageDays(ctx context.Context, name string, days int) (*entity.ProductType, error) {
+	log := slog.With("layer", "ProductTypeRepo", "operation", "SetStorageDays", "name", name, "days", days)
+	log.Debug("starting product type storage period update")
+
+	query := `
+	UPDATE product_types SET storage_days = NULLIF($2, 0)
+	WHERE name = $1
+	RETURNING name, attributes_schema, created_at, created_by, COALESCE(storage_days, 0)
+`
+	var (
+		productType entity.ProductType
+		createdBy   pgtype.UUID
+	)
+	err := conn(ctx, r.db).QueryRow(ctx, query, name, days).Scan(&productType.Name, &productType.AttributesSchema,
+		&productType.CreatedAt, &createdBy, &productType.StorageDays)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			log.Warn("product type not found")
+			return nil, repoerr.ErrNoRows
+		}
+		log.Error("failed to update product type", "error", err)
+		return nil, err
+	}
+	productType.CreatedBy = uuidOrNil(createdBy)
+
+	log.Info("product type storage period updated")
+	return &productType, nil
+}
@@ -0,0 +1,68 @@
+package pgxdb_test
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/GlebMoskalev/go-pickup-point-api/integration/helperstest"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/entity"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/repo/pgxdb"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/repo/repoerr"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestProductTypeRepo(t *testing.T) {
+	ctx := context.Background()
+
+	postgresContainer, dbCfg := helperstest.SetupPostgresContainer(t, ctx)
+	defer postgresContainer.Terminate(ctx)
+
+	dbPool := helperstest.SetupDatabaseConnection(t, ctx, dbCfg)
+	defer dbPool.Close()
+
+	helperstest.ApplyMigrations(t, dbCfg)
+
+	typeRepo := pgxdb.NewProductTypeRepo(dbPool)
+	productRepo := pgxdb.NewProductRepo(dbPool)
+
+	seeded, err := typeRepo.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, seeded, 3, "initial types must be migrated from the enum")
+
+	_, err = productRepo.Create(ctx, entity.Product{ReceptionID: helperstest.CreateReception(t, ctx, dbPool,
+		helperstest.CreatePVZ(t, ctx, dbPool)), Type: "продукты"})
+	require.Error(t, err, "types outside the catalog are rejected")
+
+	moderatorID := uuid.New()
+	created, err := typeRepo.Create(ctx, entity.ProductType{Name: "продукты", CreatedBy: moderatorID})
+	require.NoError(t, err)
+	require.False(t, created.CreatedAt.IsZero())
+
+	_, err = typeRepo.Create(ctx, entity.ProductType{Name: "продукты"})
+	require.ErrorIs(t, err, repoerr.ErrDuplicateEntry)
+
+	schema := json.RawMessage(`{"type": "object", "properties": {"weight": {"type": "number"}}}`)
+	updated, err := typeRepo.SetSchema(ctx, entity.ProductType{Name: "продукты", AttributesSchema: schema})
+	require.NoError(t, err)
+	require.Equal(t, moderatorID, updated.CreatedBy)
+
+	found, err := typeRepo.GetByName(ctx, "продукты")
+	require.NoError(t, err)
+	require.JSONEq(t, string(schema), string(found.AttributesSchema))
+
+	_, err = typeRepo.GetByName(ctx, "мебель")
+	require.ErrorIs(t, err, repoerr.ErrNoRows)
+	_, err = typeRepo.SetSchema(ctx, entity.ProductType{Name: "мебель"})
+	require.ErrorIs(t, err, repoerr.ErrNoRows)
+
+	receptionID := helperstest.CreateReception(t, ctx, dbPool, helperstest.CreatePVZ(t, ctx, dbPool))
+	_, err = productRepo.Create(ctx, entity.Product{ReceptionID: receptionID, Type: "продукты",
+		Attributes: json.RawMessage(`{"weight": 1.5}`)})
+	require.NoError(t, err)
+
+	products, err := productRepo.ListByReception(ctx, receptionID.String())
+	require.NoError(t, err)
+	require.Len(t, products, 1)
+	require.JSONEq(t, `{"weight": 1.5}`, string(products[0].Attributes))
+}
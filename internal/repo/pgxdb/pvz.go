@@ -2,9 +2,12 @@ package pgxdb
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/GlebMoskalev/go-pickup-point-api/internal/entity"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/repo/repoerr"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"log/slog"
@@ -21,11 +24,11 @@ func NewPVZRepo(db *pgxpool.Pool) *PVZRepo {
 	return &PVZRepo{db: db}
 }
 
-func (r *PVZRepo) Create(ctx context.Context, city string) (*entity.PVZ, error) {
-	log := slog.With("layer", "PVZRepo", "operation", "Create", "city", city)
+func (r *PVZRepo) Create(ctx context.Context, city, timezone string) (*entity.PVZ, error) {
+	log := slog.With("layer", "PVZRepo", "operation", "Create", "city", city, "timezone", timezone)
 	log.Debug("starting pvz creation")
 
-	tx, err := r.db.Begin(ctx)
+	tx, err := conn(ctx, r.db).Begin(ctx)
 	if err != nil {
 		log.Error("failed to begin transaction", "error", err)
 		return nil, err
@@ -40,13 +43,13 @@ func (r *PVZRepo) Create(ctx context.Context, city string) (*entity.PVZ, error)
 	}()
 
 	query := `
-	INSERT INTO pvz (city)
-	VALUES ($1)
+	INSERT INTO pvz (city, timezone)
+	VALUES ($1, $2)
 	RETURNING id, registration_date
 `
 	var id uuid.UUID
 	var date time.Time
-	err = tx.QueryRow(ctx, query, city).Scan(&id, &date)
+	err = tx.QueryRow(ctx, query, city, timezone).Scan(&id, &date)
 	if err != nil {
 		log.Error("failed to create pvz", "error", err)
 		return nil, err
@@ -61,6 +64,7 @@ func (r *PVZRepo) Create(ctx context.Context, city string) (*entity.PVZ, error)
 		ID:               id,
 		RegistrationDate: date,
 		City:             city,
+		Timezone:         timezone,
 	}
 
 	log.Info("pvz created successfully", "pvzID", id.String())
@@ -80,7 +84,7 @@ func (r *PVZRepo) Exists(ctx context.Context, pvzID string) bool {
 `
 
 	var exists bool
-	err := r.db.QueryRow(ctx, query, pvzID).Scan(&exists)
+	err := conn(ctx, r.db).QueryRow(ctx, query, pvzID).Scan(&exists)
 	if err != nil {
 		log.Error("failed to check pvz existence", "error", err)
 		return false
@@ -90,32 +94,71 @@ func (r *PVZRepo) Exists(ctx context.Context, pvzID string) bool {
 	return exists
 }
 
+func (r *PVZRepo) GetByID(ctx context.Context, pvzID string) (*entity.PVZ, error) {
+	log := slog.With("layer", "PVZRepo", "operation", "GetByID", "pvzID", pvzID)
+	log.Debug("starting get pvz")
+
+	query := `
+	SELECT id, registration_date, city, timezone
+	FROM pvz
+	WHERE id = $1
+`
+	var pvz entity.PVZ
+	err := conn(ctx, r.db).QueryRow(ctx, query, pvzID).
+		Scan(&pvz.ID, &pvz.RegistrationDate, &pvz.City, &pvz.Timezone)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			log.Warn("not found pvz")
+			return nil, repoerr.ErrNotFound
+		}
+		log.Error("failed to get pvz", "error", err)
+		return nil, err
+	}
+	return &pvz, nil
+}
+
 func (r *PVZRepo) ListWithDetails(ctx context.Context,
-	startDate, endDate *time.Time,
+	filter entity.PVZFilter,
 	page, limit int) ([]entity.PVZWithDetails, error) {
-	log := slog.With("layer", "PVZRepo", "operation", "ListWithDetails", "page", page, "limit", limit)
+	log := slog.With("layer", "PVZRepo", "operation", "ListWithDetails", "page", page, "limit", limit,
+		"localTime", filter.LocalTime)
 	log.Debug("starting list pvz with details")
 
 	query := `
 	SELECT
-	    p.id AS pvz_id, p.registration_date, p.city,
-	    r.id AS reception_id, r.date_time AS reception_date_time, r.pvz_id, r.status,
-	    pr.id AS product_id, pr.date_time AS product_date_time, pr.type AS product_type
+	    p.id AS pvz_id, p.registration_date, p.city, p.timezone,
+	    r.id AS reception_id, r.date_time AS reception_date_time, r.pvz_id, r.dock_id, r.kind, r.status,
+	    r.stale_flagged_at, r.stale_reason, r.opened_by, r.closed_by, r.closed_at,
+	    r.carrier, r.waybill_number, r.vehicle_plate, r.comment,
+	    pr.id AS product_id, pr.date_time AS product_date_time, pr.type AS product_type, pr.barcode, pr.attributes,
+	    pr.order_number, pr.status AS product_status, pr.added_by, pr.deleted_by, pr.deleted_at,
+	    pr.cell_id, sc.shelf AS cell_shelf, sc.code AS cell_code, pr.return_reason, pr.original_product_id,
+	    pr.declared_value, pr.currency
 	FROM pvz p
 	INNER JOIN receptions r ON p.id = r.pvz_id
 	LEFT JOIN products pr ON r.id = pr.reception_id
+	LEFT JOIN storage_cells sc ON sc.id = pr.cell_id
 `
 
 	var args []any
 	var conditions []string
-	if startDate != nil {
-		conditions = append(conditions, "r.date_time >= $1")
-		args = append(args, *startDate)
+	if filter.Kind != "" {
+		conditions = append(conditions, "r.kind = $1")
+		args = append(args, filter.Kind)
 	}
-	if endDate != nil {
+	if filter.StartDate != nil {
 		idx := len(args) + 1
-		conditions = append(conditions, "r.date_time<=$"+strconv.Itoa(idx))
-		args = append(args, *endDate)
+		conditions = append(conditions, "r.date_time >= "+dateBound(idx, filter.LocalTime))
+		args = append(args, dateArg(*filter.StartDate, filter.LocalTime))
+	}
+	if filter.EndDate != nil {
+		idx := len(args) + 1
+		conditions = append(conditions, "r.date_time <= "+dateBound(idx, filter.LocalTime))
+		args = append(args, dateArg(*filter.EndDate, filter.LocalTime))
+	}
+	if filter.WaybillNumber != "" {
+		conditions = append(conditions, fmt.Sprintf("r.waybill_number = $%d", len(args)+1))
+		args = append(args, filter.WaybillNumber)
 	}
 
 	if len(conditions) > 0 {
@@ -124,12 +167,12 @@ func (r *PVZRepo) ListWithDetails(ctx context.Context,
 
 	idx := len(args) + 1
 	query += fmt.Sprintf(`
-	ORDER BY p.registration_date, r.date_time DESC
+	ORDER BY p.registration_date, r.date_time DESC, pr.order_number
 	LIMIT $%d OFFSET  $%d
 `, idx, idx+1)
 
 	args = append(args, limit, (page-1)*limit)
-	rows, err := r.db.Query(ctx, query, args...)
+	rows, err := conn(ctx, r.db).Query(ctx, query, args...)
 	if err != nil {
 		log.Error("failed to execute query", "error", err)
 		return nil, err
@@ -142,21 +185,47 @@ func (r *PVZRepo) ListWithDetails(ctx context.Context,
 			pvzID            uuid.UUID
 			registrationDate time.Time
 			city             string
+			timezone         string
 
 			receptionID    pgtype.UUID
 			receptionDate  pgtype.Timestamp
 			receptionPVZID uuid.UUID
+			dockID         pgtype.UUID
+			kind           pgtype.Text
 			status         pgtype.Text
+			staleFlaggedAt pgtype.Timestamptz
+			staleReason    pgtype.Text
+			openedBy       pgtype.UUID
+			closedBy       pgtype.UUID
+			closedAt       pgtype.Timestamptz
+			delivery       entity.DeliveryInfo
 
 			productID   pgtype.UUID
 			productDate pgtype.Timestamp
 			productType pgtype.Text
+			barcode     pgtype.Text
+			attributes  []byte
+			orderNumber pgtype.Int4
+			productStat pgtype.Text
+			addedBy     pgtype.UUID
+			deletedBy   pgtype.UUID
+			deletedAt   pgtype.Timestamptz
+			cellID      pgtype.UUID
+			cellShelf   pgtype.Text
+			cellCode    pgtype.Text
+			reason      pgtype.Text
+			originalID  pgtype.UUID
+			value       pgtype.Int8
+			currency    pgtype.Text
 		)
 
 		err := rows.Scan(
-			&pvzID, &registrationDate, &city,
-			&receptionID, &receptionDate, &receptionPVZID, &status,
-			&productID, &productDate, &productType,
+			&pvzID, &registrationDate, &city, &timezone,
+			&receptionID, &receptionDate, &receptionPVZID, &dockID, &kind, &status, &staleFlaggedAt, &staleReason,
+			&openedBy, &closedBy, &closedAt,
+			&delivery.Carrier, &delivery.WaybillNumber, &delivery.VehiclePlate, &delivery.Comment,
+			&productID, &productDate, &productType, &barcode, &attributes, &orderNumber, &productStat, &addedBy,
+			&deletedBy, &deletedAt, &cellID, &cellShelf, &cellCode, &reason, &originalID, &value, &currency,
 		)
 		if err != nil {
 			log.Error("failed to scan row", "error", err)
@@ -169,6 +238,7 @@ func (r *PVZRepo) ListWithDetails(ctx context.Context,
 					ID:               pvzID,
 					RegistrationDate: registrationDate,
 					City:             city,
+					Timezone:         timezone,
 				},
 				Receptions: []entity.ReceptionDetails{},
 			}
@@ -193,14 +263,23 @@ func (r *PVZRepo) ListWithDetails(ctx context.Context,
 			if reception == nil {
 				reception = &entity.ReceptionDetails{
 					Reception: entity.Reception{
-						ID:       receptionUUID,
-						DateTime: receptionDate.Time,
-						PVZID:    receptionPVZID,
-						Status:   status.String,
+						ID:             receptionUUID,
+						DateTime:       receptionDate.Time,
+						PVZID:          receptionPVZID,
+						DockID:         uuidOrNil(dockID),
+						Kind:           kind.String,
+						Status:         status.String,
+						Delivery:       delivery,
+						OpenedBy:       uuidOrNil(openedBy),
+						ClosedBy:       uuidOrNil(closedBy),
+						ClosedAt:       timeOrNil(closedAt),
+						StaleFlaggedAt: timeOrNil(staleFlaggedAt),
+						StaleReason:    staleReason.String,
 					},
 					Products: []entity.Product{},
 				}
 				pvz.Receptions = append(pvz.Receptions, *reception)
+				reception = &pvz.Receptions[len(pvz.Receptions)-1]
 			}
 
 			if productID.Valid {
@@ -211,13 +290,31 @@ func (r *PVZRepo) ListWithDetails(ctx context.Context,
 				}
 
 				product := entity.Product{
-					ID:          productUUID,
-					DateTime:    productDate.Time,
-					Type:        productType.String,
-					ReceptionID: receptionUUID,
+					ID:                productUUID,
+					DateTime:          productDate.Time,
+					Type:              productType.String,
+					Barcode:           barcode.String,
+					Attributes:        attributes,
+					ReceptionID:       receptionUUID,
+					OrderNumber:       int(orderNumber.Int32),
+					Status:            productStat.String,
+					CellID:            uuidOrNil(cellID),
+					CellShelf:         cellShelf.String,
+					CellCode:          cellCode.String,
+					AddedBy:           uuidOrNil(addedBy),
+					ReturnReason:      reason.String,
+					OriginalProductID: uuidOrNil(originalID),
+					DeletedBy:         uuidOrNil(deletedBy),
+					DeletedAt:         timeOrNil(deletedAt),
+					DeclaredValue:     value.Int64,
+					Currency:          currency.String,
 				}
 
-				reception.Products = append(reception.Products, product)
+				if product.DeletedAt != nil {
+					reception.DeletedProducts = append(reception.DeletedProducts, product)
+				} else {
+					reception.Products = append(reception.Products, product)
+				}
 			}
 		}
 	}
@@ -226,6 +323,26 @@ func (r *PVZRepo) ListWithDetails(ctx context.Context,
 		log.Error("rows error", "error", err)
 		return nil, err
 	}
+
+	receptions := make(map[uuid.UUID]*entity.ReceptionDetails)
+	for _, pvz := range pvzMap {
+		for i := range pvz.Receptions {
+			receptions[pvz.Receptions[i].Reception.ID] = &pvz.Receptions[i]
+		}
+	}
+	if err := r.attachManifests(ctx, receptions); err != nil {
+		log.Error("failed to load manifests", "error", err)
+		return nil, err
+	}
+	if err := r.attachBlindCounts(ctx, receptions); err != nil {
+		log.Error("failed to load blind counts", "error", err)
+		return nil, err
+	}
+	if err := r.attachStatusHistory(ctx, receptions); err != nil {
+		log.Error("failed to load status history", "error", err)
+		return nil, err
+	}
+
 	result := make([]entity.PVZWithDetails, 0, len(pvzMap))
 
 	for _, pvz := range pvzMap {
@@ -235,3 +352,445 @@ func (r *PVZRepo) ListWithDetails(ctx context.Context,
 	log.Info("pvz list retrieved successfully", "count", len(result))
 	return result, nil
 }
+
+// attachManifests дозагружает манифесты и отчёты о расхождениях для приёмок из выборки.
+func (r *PVZRepo) attachManifests(ctx context.Context, receptions map[uuid.UUID]*entity.ReceptionDetails) error {
+	if len(receptions) == 0 {
+		return nil
+	}
+	ids := make([]uuid.UUID, 0, len(receptions))
+	for id := range receptions {
+		ids = append(ids, id)
+	}
+
+	rows, err := conn(ctx, r.db).Query(ctx, `
+	SELECT reception_id, product_type, expected_count
+	FROM reception_manifest_items
+	WHERE reception_id = ANY($1)
+	ORDER BY product_type
+`, ids)
+	if err != nil {
+		return err
+	}
+	for rows.Next() {
+		var (
+			receptionID uuid.UUID
+			item        entity.ManifestItem
+		)
+		if err := rows.Scan(&receptionID, &item.ProductType, &item.ExpectedCount); err != nil {
+			rows.Close()
+			return err
+		}
+		reception := &receptions[receptionID].Reception
+		reception.Manifest = append(reception.Manifest, item)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	rows, err = conn(ctx, r.db).Query(ctx, `
+	SELECT reception_id, product_type, expected_count, received_count
+	FROM reception_discrepancies
+	WHERE reception_id = ANY($1)
+	ORDER BY product_type
+`, ids)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			receptionID uuid.UUID
+			item        entity.DiscrepancyItem
+		)
+		if err := rows.Scan(&receptionID, &item.ProductType, &item.ExpectedCount, &item.ReceivedCount); err != nil {
+			return err
+		}
+		details := receptions[receptionID]
+		if details.DiscrepancyReport == nil {
+			details.DiscrepancyReport = &entity.DiscrepancyReport{ReceptionID: receptionID}
+		}
+		details.DiscrepancyReport.Items = append(details.DiscrepancyReport.Items, item)
+	}
+	return rows.Err()
+}
+
+// attachBlindCounts дозагружает результаты «слепого» пересчёта для приёмок из выборки.
+func (r *PVZRepo) attachBlindCounts(ctx context.Context, receptions map[uuid.UUID]*entity.ReceptionDetails) error {
+	if len(receptions) == 0 {
+		return nil
+	}
+	ids := make([]uuid.UUID, 0, len(receptions))
+	for id := range receptions {
+		ids = append(ids, id)
+	}
+
+	rows, err := conn(ctx, r.db).Query(ctx, `
+	SELECT reception_id, product_type, counted_count, recorded_count
+	FROM reception_blind_counts
+	WHERE reception_id = ANY($1)
+	ORDER BY product_type
+`, ids)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			receptionID uuid.UUID
+			item        entity.BlindCountItem
+		)
+		if err := rows.Scan(&receptionID, &item.ProductType, &item.CountedCount, &item.RecordedCount); err != nil {
+			return err
+		}
+		details := receptions[receptionID]
+		if details.BlindCount == nil {
+			details.BlindCount = &entity.BlindCountReport{ReceptionID: receptionID}
+		}
+		details.BlindCount.Items = append(details.BlindCount.Items, item)
+	}
+	return rows.Err()
+}
+
+// attachStatusHistory дозагружает журнал смены статусов для приёмок из выборки.
+func (r *PVZRepo) attachStatusHistory(ctx context.Context, receptions map[uuid.UUID]*entity.ReceptionDetails) error {
+	if len(receptions) == 0 {
+		return nil
+	}
+	ids := make([]uuid.UUID, 0, len(receptions))
+	for id := range receptions {
+		ids = append(ids, id)
+	}
+
+	rows, err := conn(ctx, r.db).Query(ctx, `
+	SELECT reception_id, from_status, to_status, reason, COALESCE(changed_by, '00000000-0000-0000-0000-000000000000'), changed_at
+	FROM reception_status_history
+	WHERE reception_id = ANY($1)
+	ORDER BY changed_at
+`, ids)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var change entity.StatusChange
+		err := rows.Scan(&change.ReceptionID, &change.FromStatus, &change.ToStatus, &change.Reason,
+			&change.ChangedBy, &change.ChangedAt)
+		if err != nil {
+			return err
+		}
+		details := receptions[change.ReceptionID]
+		details.StatusHistory = append(details.StatusHistory, change)
+	}
+	return rows.Err()
+}
+
+// dateBound возвращает SQL-выражение для границы фильтра по дате.
+// В режиме localTime граница переводится из местного времени каждого ПВЗ в абсолютный момент.
+func dateBound(idx int, localTime bool) string {
+	if localTime {
+		return "($" + strconv.Itoa(idx) + "::timestamp AT TIME ZONE p.timezone)"
+	}
+	return "$" + strconv.Itoa(idx)
+}
+
+func dateArg(date time.Time, localTime bool) any {
+	if localTime {
+		return date.Format("2006-01-02 15:04:05.999999")
+	}
+	return date
+}
+
+func (r *PVZRepo) GetSchedule(ctx context.Context, pvzID string) (*entity.PVZSchedule, error) {
+	log := slog.With("layer", "PVZRepo", "operation", "GetSchedule", "pvzID", pvzID)
+	log.Debug("starting get pvz schedule")
+
+	query := `
+	SELECT id, timezone, enforce_working_hours
+	FROM pvz
+	WHERE id = $1
+`
+	var schedule entity.PVZSchedule
+	err := conn(ctx, r.db).QueryRow(ctx, query, pvzID).Scan(&schedule.PVZID, &schedule.Timezone, &schedule.EnforceWorkingHours)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			log.Warn("not found pvz")
+			return nil, repoerr.ErrNotFound
+		}
+		log.Error("failed to get pvz", "error", err)
+		return nil, err
+	}
+
+	query = `
+	SELECT weekday, to_char(open_time, 'HH24:MI'), to_char(close_time, 'HH24:MI')
+	FROM pvz_working_hours
+	WHERE pvz_id = $1
+	ORDER BY weekday
+`
+	rows, err := conn(ctx, r.db).Query(ctx, query, pvzID)
+	if err != nil {
+		log.Error("failed to get working hours", "error", err)
+		return nil, err
+	}
+	schedule.WorkingHours, err = pgx.CollectRows(rows, func(row pgx.CollectableRow) (entity.WorkingHours, error) {
+		var wh entity.WorkingHours
+		var weekday int16
+		err := row.Scan(&weekday, &wh.OpenTime, &wh.CloseTime)
+		wh.Weekday = time.Weekday(weekday)
+		return wh, err
+	})
+	if err != nil {
+		log.Error("failed to scan working hours", "error", err)
+		return nil, err
+	}
+
+	query = `
+	SELECT date, COALESCE(to_char(open_time, 'HH24:MI'), ''), COALESCE(to_char(close_time, 'HH24:MI'), ''), comment
+	FROM pvz_holidays
+	WHERE pvz_id = $1
+	ORDER BY date
+`
+	rows, err = conn(ctx, r.db).Query(ctx, query, pvzID)
+	if err != nil {
+		log.Error("failed to get holidays", "error", err)
+		return nil, err
+	}
+	schedule.Holidays, err = pgx.CollectRows(rows, func(row pgx.CollectableRow) (entity.Holiday, error) {
+		var h entity.Holiday
+		err := row.Scan(&h.Date, &h.OpenTime, &h.CloseTime, &h.Comment)
+		return h, err
+	})
+	if err != nil {
+		log.Error("failed to scan holidays", "error", err)
+		return nil, err
+	}
+
+	log.Debug("pvz schedule retrieved", "workingDays", len(schedule.WorkingHours), "holidays", len(schedule.Holidays))
+	return &schedule, nil
+}
+
+func (r *PVZRepo) SetSchedule(ctx context.Context, schedule entity.PVZSchedule) error {
+	log := slog.With("layer", "PVZRepo", "operation", "SetSchedule", "pvzID", schedule.PVZID.String())
+	log.Debug("starting set pvz schedule")
+
+	tx, err := conn(ctx, r.db).Begin(ctx)
+	if err != nil {
+		log.Error("failed to begin transaction", "error", err)
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			if rollbackErr := tx.Rollback(ctx); rollbackErr != nil {
+				log.Error("failed to rollback transaction", "error", rollbackErr)
+			}
+		}
+	}()
+
+	query := `
+	UPDATE pvz
+	SET timezone = $2, enforce_working_hours = $3
+	WHERE id = $1
+`
+	tag, err := tx.Exec(ctx, query, schedule.PVZID, schedule.Timezone, schedule.EnforceWorkingHours)
+	if err != nil {
+		log.Error("failed to update pvz", "error", err)
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		log.Warn("not found pvz")
+		err = repoerr.ErrNotFound
+		return err
+	}
+
+	if _, err = tx.Exec(ctx, `DELETE FROM pvz_working_hours WHERE pvz_id = $1`, schedule.PVZID); err != nil {
+		log.Error("failed to clear working hours", "error", err)
+		return err
+	}
+	for _, wh := range schedule.WorkingHours {
+		_, err = tx.Exec(ctx, `
+		INSERT INTO pvz_working_hours (pvz_id, weekday, open_time, close_time)
+		VALUES ($1, $2, $3::time, $4::time)`,
+			schedule.PVZID, int16(wh.Weekday), wh.OpenTime, wh.CloseTime)
+		if err != nil {
+			log.Error("failed to insert working hours", "error", err, "weekday", wh.Weekday)
+			return err
+		}
+	}
+
+	if _, err = tx.Exec(ctx, `DELETE FROM pvz_holidays WHERE pvz_id = $1`, schedule.PVZID); err != nil {
+		log.Error("failed to clear holidays", "error", err)
+		return err
+	}
+	for _, h := range schedule.Holidays {
+		_, err = tx.Exec(ctx, `
+		INSERT INTO pvz_holidays (pvz_id, date, open_time, close_time, comment)
+		VALUES ($1, $2::date, NULLIF($3, '')::time, NULLIF($4, '')::time, $5)`,
+			schedule.PVZID, h.Date.Format(time.DateOnly), h.OpenTime, h.CloseTime, h.Comment)
+		if err != nil {
+			log.Error("failed to insert holiday", "error", err, "date", h.Date.Format(time.DateOnly))
+			return err
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		log.Error("failed to commit transaction", "error", err)
+		return err
+	}
+
+	log.Info("pvz schedule updated successfully")
+	return nil
+}
+
+// GetSettings возвращает настройки приёмки и хранения ПВЗ.
+func (r *PVZRepo) GetSettings(ctx context.Context, pvzID string) (*entity.PVZSettings, error) {
+	log := slog.With("layer", "PVZRepo", "operation", "GetSettings", "pvzID", pvzID)
+	log.Debug("starting get pvz settings")
+
+	query := `
+	SELECT id, blind_count_policy, COALESCE(storage_days, 0)
+	FROM pvz
+	WHERE id = $1
+`
+	var settings entity.PVZSettings
+	err := conn(ctx, r.db).QueryRow(ctx, query, pvzID).Scan(&settings.PVZID, &settings.BlindCountPolicy,
+		&settings.StorageDays)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			log.Warn("not found pvz")
+			return nil, repoerr.ErrNotFound
+		}
+		log.Error("failed to get pvz settings", "error", err)
+		return nil, err
+	}
+
+	return &settings, nil
+}
+
+// SetSettings заменяет настройки приёмки и хранения ПВЗ.
+func (r *PVZRepo) SetSettings(ctx context.Context, settings entity.PVZSettings) error {
+	log := slog.With("layer", "PVZRepo", "operation", "SetSettings", "pvzID", settings.PVZID.String())
+	log.Debug("starting set pvz settings")
+
+	query := `
+	UPDATE pvz
+	SET blind_count_policy = $2, storage_days = NULLIF($3, 0)
+	WHERE id = $1
+`
+	tag, err := conn(ctx, r.db).Exec(ctx, query, settings.PVZID, settings.BlindCountPolicy, settings.StorageDays)
+	if err != nil {
+		log.Error("failed to update pvz settings", "error", err)
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		log.Warn("not found pvz")
+		return repoerr.ErrNotFound
+	}
+
+	log.Info("pvz settings updated", "blindCountPolicy", settings.BlindCountPolicy,
+		"storageDays", settings.StorageDays)
+	return nil
+}
+
+// CountProductsByStatus возвращает число неудалённых товаров ПВЗ по статусам. Товары отменённых
+// приёмок не учитываются: в ПВЗ они не поступили.
+func (r *PVZRepo) CountProductsByStatus(ctx context.Context, pvzID string) (map[string]int, error) {
+	log := slog.With("layer", "PVZRepo", "operation", "CountProductsByStatus", "pvzID", pvzID)
+	log.Debug("counting products by status")
+
+	query := `
+	SELECT pr.status, COUNT(*)
+	FROM products pr
+	INNER JOIN receptions r ON r.id = pr.reception_id
+	WHERE r.pvz_id = $1 AND r.status <> 'cancelled' AND pr.deleted_at IS NULL
+	GROUP BY pr.status
+`
+	rows, err := conn(ctx, r.db).Query(ctx, query, pvzID)
+	if err != nil {
+		log.Error("failed to count products", "error", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := make(map[string]int)
+	for rows.Next() {
+		var (
+			status string
+			count  int
+		)
+		if err := rows.Scan(&status, &count); err != nil {
+			log.Error("failed to scan product count", "error", err)
+			return nil, err
+		}
+		counts[status] = count
+	}
+	if err := rows.Err(); err != nil {
+		log.Error("rows error", "error", err)
+		return nil, err
+	}
+	return counts, nil
+}
+
+// SumStoredValue возвращает объявленную ценность хранящихся в ПВЗ товаров по валютам.
+func (r *PVZRepo) SumStoredValue(ctx context.Context, pvzID string) ([]entity.ValueTotal, error) {
+	log := slog.With("layer", "PVZRepo", "operation", "SumStoredValue", "pvzID", pvzID)
+	log.Debug("summing stored value")
+
+	query := `
+	SELECT pr.currency, SUM(pr.declared_value)::BIGINT, COUNT(*)
+	FROM products pr
+	INNER JOIN receptions r ON r.id = pr.reception_id
+	WHERE pr.pvz_id = $1 AND pr.status = 'stored' AND pr.deleted_at IS NULL
+	  AND pr.declared_value IS NOT NULL AND r.status <> 'cancelled'
+	GROUP BY pr.currency
+	ORDER BY pr.currency
+`
+	rows, err := conn(ctx, r.db).Query(ctx, query, pvzID)
+	if err != nil {
+		log.Error("failed to sum stored value", "error", err)
+		return nil, err
+	}
+	totals, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (entity.ValueTotal, error) {
+		var total entity.ValueTotal
+		err := row.Scan(&total.Currency, &total.Amount, &total.Count)
+		return total, err
+	})
+	if err != nil {
+		log.Error("failed to scan stored value", "error", err)
+		return nil, err
+	}
+	return totals, nil
+}
+
+// ListStoredValues возвращает объявленную ценность хранящихся товаров по всем ПВЗ и валютам.
+// ПВЗ без товаров с объявленной ценностью в выборку не попадают.
+func (r *PVZRepo) ListStoredValues(ctx context.Context) ([]entity.PVZValue, error) {
+	log := slog.With("layer", "PVZRepo", "operation", "ListStoredValues")
+	log.Debug("listing stored values")
+
+	query := `
+	SELECT pr.pvz_id, pr.currency, SUM(pr.declared_value)::BIGINT, COUNT(*)
+	FROM products pr
+	INNER JOIN receptions r ON r.id = pr.reception_id
+	WHERE pr.status = 'stored' AND pr.deleted_at IS NULL
+	  AND pr.declared_value IS NOT NULL AND r.status <> 'cancelled'
+	GROUP BY pr.pvz_id, pr.currency
+	ORDER BY pr.pvz_id, pr.currency
+`
+	rows, err := conn(ctx, r.db).Query(ctx, query)
+	if err != nil {
+		log.Error("failed to list stored values", "error", err)
+		return nil, err
+	}
+	values, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (entity.PVZValue, error) {
+		var value entity.PVZValue
+		err := row.Scan(&value.PVZID, &value.Currency, &value.Amount, &value.Count)
+		return value, err
+	})
+	if err != nil {
+		log.Error("failed to scan stored values", "error", err)
+		return nil, err
+	}
+	return values, nil
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/GlebMoskalev/go-pickup-point-api/integration/helperstest"
 	"github.com/GlebMoskalev/go-pickup-point-api/internal/entity"
 	"github.com/GlebMoskalev/go-pickup-point-api/internal/repo/pgxdb"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/repo/repoerr"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 )
@@ -54,7 +55,7 @@ func TestPVZRepoCreate(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			pvz, err := pvzRepo.Create(ctx, tc.city)
+			pvz, err := pvzRepo.Create(ctx, tc.city, entity.DefaultTimezone)
 
 			if tc.expectError {
 				require.Error(t, err)
@@ -63,6 +64,7 @@ func TestPVZRepoCreate(t *testing.T) {
 				require.NoError(t, err)
 				require.NotNil(t, pvz)
 				require.Equal(t, tc.city, pvz.City)
+				require.Equal(t, entity.DefaultTimezone, pvz.Timezone)
 				require.NotEqual(t, uuid.Nil, pvz.ID)
 				require.False(t, pvz.RegistrationDate.IsZero())
 
@@ -91,7 +93,7 @@ func TestPVZRepoExists(t *testing.T) {
 
 	pvzRepo := pgxdb.NewPVZRepo(dbPool)
 
-	pvz, err := pvzRepo.Create(ctx, entity.CityMoscow)
+	pvz, err := pvzRepo.Create(ctx, entity.CityMoscow, entity.DefaultTimezone)
 	require.NoError(t, err)
 
 	invalidPVZID := uuid.New().String()
@@ -135,33 +137,42 @@ func TestPVZRepoListWithDetails(t *testing.T) {
 	pvzRepo := pgxdb.NewPVZRepo(dbPool)
 	productRepo := pgxdb.NewProductRepo(dbPool)
 
-	pvz1, err := pvzRepo.Create(ctx, entity.CityMoscow)
+	pvz1, err := pvzRepo.Create(ctx, entity.CityMoscow, entity.DefaultTimezone)
 	require.NoError(t, err)
 
-	pvz2, err := pvzRepo.Create(ctx, entity.CitySPB)
+	pvz2, err := pvzRepo.Create(ctx, entity.CitySPB, "Asia/Vladivostok")
 	require.NoError(t, err)
 
 	reception1ID := helperstest.CreateReception(t, ctx, dbPool, pvz1.ID)
 	reception2ID := helperstest.CreateReceptionWithStatus(t, ctx, dbPool, pvz1.ID, entity.StatusClose)
 	reception3ID := helperstest.CreateReception(t, ctx, dbPool, pvz2.ID)
 
-	_, err = productRepo.Create(ctx, reception1ID.String(), entity.ProductTypeShoes)
+	_, err = productRepo.Create(ctx, entity.Product{ReceptionID: reception1ID, Type: entity.ProductTypeShoes})
 	require.NoError(t, err)
-	_, err = productRepo.Create(ctx, reception1ID.String(), entity.ProductTypeElectronics)
+	_, err = productRepo.Create(ctx, entity.Product{ReceptionID: reception1ID, Type: entity.ProductTypeElectronics})
 	require.NoError(t, err)
-	_, err = productRepo.Create(ctx, reception2ID.String(), entity.ProductTypeShoes)
+	_, err = productRepo.Create(ctx, entity.Product{ReceptionID: reception2ID, Type: entity.ProductTypeShoes})
 	require.NoError(t, err)
-	_, err = productRepo.Create(ctx, reception3ID.String(), entity.ProductTypeElectronics)
+	_, err = productRepo.Create(ctx, entity.Product{ReceptionID: reception3ID, Type: entity.ProductTypeElectronics})
 	require.NoError(t, err)
 
 	now := time.Now()
 	pastTime := now.Add(-24 * time.Hour)
 	futureTime := now.Add(24 * time.Hour)
 
+	// Московское время через час. На часах владивостокского ПВЗ (UTC+10) это время
+	// было шесть часов назад, поэтому его приёмки под такую границу не попадают.
+	moscow, err := time.LoadLocation(entity.DefaultTimezone)
+	require.NoError(t, err)
+	moscowWallClock := now.In(moscow).Add(time.Hour)
+	localStart := time.Date(moscowWallClock.Year(), moscowWallClock.Month(), moscowWallClock.Day(),
+		moscowWallClock.Hour(), moscowWallClock.Minute(), moscowWallClock.Second(), 0, time.UTC)
+
 	testCases := []struct {
 		name           string
 		startDate      *time.Time
 		endDate        *time.Time
+		localTime      bool
 		page           int
 		limit          int
 		expectCount    int
@@ -203,11 +214,22 @@ func TestPVZRepoListWithDetails(t *testing.T) {
 			expectCount:    1,
 			expectedCities: []string{entity.CityMoscow},
 		},
+		{
+			name:           "List PVZs with end date in pvz local time",
+			startDate:      nil,
+			endDate:        &localStart,
+			localTime:      true,
+			page:           1,
+			limit:          10,
+			expectCount:    1,
+			expectedCities: []string{entity.CityMoscow},
+		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			pvzList, err := pvzRepo.ListWithDetails(ctx, tc.startDate, tc.endDate, tc.page, tc.limit)
+			filter := entity.PVZFilter{StartDate: tc.startDate, EndDate: tc.endDate, LocalTime: tc.localTime}
+			pvzList, err := pvzRepo.ListWithDetails(ctx, filter, tc.page, tc.limit)
 			require.NoError(t, err)
 			require.Len(t, pvzList, tc.expectCount)
 
@@ -231,3 +253,310 @@ func TestPVZRepoListWithDetails(t *testing.T) {
 		})
 	}
 }
+
+func TestPVZRepoListWithDetailsAttribution(t *testing.T) {
+	ctx := context.Background()
+
+	postgresContainer, dbCfg := helperstest.SetupPostgresContainer(t, ctx)
+	defer postgresContainer.Terminate(ctx)
+
+	dbPool := helperstest.SetupDatabaseConnection(t, ctx, dbCfg)
+	defer dbPool.Close()
+
+	helperstest.ApplyMigrations(t, dbCfg)
+
+	pvzRepo := pgxdb.NewPVZRepo(dbPool)
+	receptionRepo := pgxdb.NewReceptionRepo(dbPool)
+	productRepo := pgxdb.NewProductRepo(dbPool)
+
+	openedBy, closedBy, addedBy, deletedBy := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+
+	pvz, err := pvzRepo.Create(ctx, entity.CityKazan, entity.DefaultTimezone)
+	require.NoError(t, err)
+
+	reception, err := receptionRepo.Create(ctx, entity.ReceptionParams{PVZID: pvz.ID.String(), OpenedBy: openedBy})
+	require.NoError(t, err)
+
+	kept, err := productRepo.Create(ctx, entity.Product{ReceptionID: reception.ID, Type: entity.ProductTypeShoes, AddedBy: addedBy})
+	require.NoError(t, err)
+	deleted, err := productRepo.Create(ctx, entity.Product{ReceptionID: reception.ID, Type: entity.ProductTypeClothes, AddedBy: addedBy})
+	require.NoError(t, err)
+
+	require.NoError(t, productRepo.DeleteLastProduct(ctx, reception.ID.String(), deletedBy))
+	require.NoError(t, receptionRepo.Close(ctx, reception.ID.String(), closedBy))
+
+	pvzList, err := pvzRepo.ListWithDetails(ctx, entity.PVZFilter{}, 1, 10)
+	require.NoError(t, err)
+	require.Len(t, pvzList, 1)
+	require.Len(t, pvzList[0].Receptions, 1)
+
+	details := pvzList[0].Receptions[0]
+	require.Equal(t, openedBy, details.Reception.OpenedBy)
+	require.Equal(t, closedBy, details.Reception.ClosedBy)
+	require.NotNil(t, details.Reception.ClosedAt)
+
+	require.Len(t, details.Products, 1)
+	require.Equal(t, kept.ID, details.Products[0].ID)
+	require.Equal(t, addedBy, details.Products[0].AddedBy)
+
+	require.Len(t, details.DeletedProducts, 1)
+	require.Equal(t, deleted.ID, details.DeletedProducts[0].ID)
+	require.Equal(t, deletedBy, details.DeletedProducts[0].DeletedBy)
+	require.NotNil(t, details.DeletedProducts[0].DeletedAt)
+}
+
+func TestPVZRepoListWithDetailsByWaybill(t *testing.T) {
+	ctx := context.Background()
+
+	postgresContainer, dbCfg := helperstest.SetupPostgresContainer(t, ctx)
+	defer postgresContainer.Terminate(ctx)
+
+	dbPool := helperstest.SetupDatabaseConnection(t, ctx, dbCfg)
+	defer dbPool.Close()
+
+	helperstest.ApplyMigrations(t, dbCfg)
+
+	pvzRepo := pgxdb.NewPVZRepo(dbPool)
+	receptionRepo := pgxdb.NewReceptionRepo(dbPool)
+
+	pvz1, err := pvzRepo.Create(ctx, entity.CityMoscow, entity.DefaultTimezone)
+	require.NoError(t, err)
+	pvz2, err := pvzRepo.Create(ctx, entity.CityKazan, entity.DefaultTimezone)
+	require.NoError(t, err)
+
+	delivery := entity.DeliveryInfo{
+		Carrier:       "СДЭК",
+		WaybillNumber: "WB-42",
+		VehiclePlate:  "А123ВС777",
+		Comment:       "две коробки помяты",
+	}
+	_, err = receptionRepo.Create(ctx, entity.ReceptionParams{PVZID: pvz1.ID.String(), Delivery: delivery})
+	require.NoError(t, err)
+	_, err = receptionRepo.Create(ctx, entity.ReceptionParams{
+		PVZID:    pvz2.ID.String(),
+		Delivery: entity.DeliveryInfo{WaybillNumber: "WB-43"},
+	})
+	require.NoError(t, err)
+
+	pvzList, err := pvzRepo.ListWithDetails(ctx, entity.PVZFilter{WaybillNumber: "WB-42"}, 1, 10)
+	require.NoError(t, err)
+	require.Len(t, pvzList, 1)
+	require.Equal(t, pvz1.ID, pvzList[0].PVZ.ID)
+	require.Len(t, pvzList[0].Receptions, 1)
+	require.Equal(t, delivery, pvzList[0].Receptions[0].Reception.Delivery)
+
+	pvzList, err = pvzRepo.ListWithDetails(ctx, entity.PVZFilter{WaybillNumber: "WB-00"}, 1, 10)
+	require.NoError(t, err)
+	require.Empty(t, pvzList)
+}
+
+func TestPVZRepoSchedule(t *testing.T) {
+	ctx := context.Background()
+
+	postgresContainer, dbCfg := helperstest.SetupPostgresContainer(t, ctx)
+	defer postgresContainer.Terminate(ctx)
+
+	dbPool := helperstest.SetupDatabaseConnection(t, ctx, dbCfg)
+	defer dbPool.Close()
+
+	helperstest.ApplyMigrations(t, dbCfg)
+
+	pvzRepo := pgxdb.NewPVZRepo(dbPool)
+
+	pvz, err := pvzRepo.Create(ctx, entity.CityKazan, entity.DefaultTimezone)
+	require.NoError(t, err)
+
+	schedule, err := pvzRepo.GetSchedule(ctx, pvz.ID.String())
+	require.NoError(t, err)
+	require.Equal(t, entity.DefaultTimezone, schedule.Timezone)
+	require.False(t, schedule.EnforceWorkingHours)
+	require.Empty(t, schedule.WorkingHours)
+	require.Empty(t, schedule.Holidays)
+
+	newSchedule := entity.PVZSchedule{
+		PVZID:               pvz.ID,
+		Timezone:            "Europe/Samara",
+		EnforceWorkingHours: true,
+		WorkingHours: []entity.WorkingHours{
+			{Weekday: time.Monday, OpenTime: "09:00", CloseTime: "21:00"},
+			{Weekday: time.Saturday, OpenTime: "10:00", CloseTime: "18:30"},
+		},
+		Holidays: []entity.Holiday{
+			{Date: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), Comment: "Новый год"},
+			{Date: time.Date(2025, 3, 7, 0, 0, 0, 0, time.UTC), OpenTime: "09:00", CloseTime: "15:00"},
+		},
+	}
+	require.NoError(t, pvzRepo.SetSchedule(ctx, newSchedule))
+
+	schedule, err = pvzRepo.GetSchedule(ctx, pvz.ID.String())
+	require.NoError(t, err)
+	require.Equal(t, newSchedule.Timezone, schedule.Timezone)
+	require.True(t, schedule.EnforceWorkingHours)
+	require.Equal(t, newSchedule.WorkingHours, schedule.WorkingHours)
+	require.Len(t, schedule.Holidays, 2)
+	require.Equal(t, "2025-01-01", schedule.Holidays[0].Date.Format(time.DateOnly))
+	require.Empty(t, schedule.Holidays[0].OpenTime)
+	require.Equal(t, "Новый год", schedule.Holidays[0].Comment)
+	require.Equal(t, "15:00", schedule.Holidays[1].CloseTime)
+
+	newSchedule.PVZID = uuid.New()
+	require.ErrorIs(t, pvzRepo.SetSchedule(ctx, newSchedule), repoerr.ErrNotFound)
+
+	_, err = pvzRepo.GetSchedule(ctx, uuid.New().String())
+	require.ErrorIs(t, err, repoerr.ErrNotFound)
+}
+
+func TestPVZRepoSettings(t *testing.T) {
+	ctx := context.Background()
+
+	postgresContainer, dbCfg := helperstest.SetupPostgresContainer(t, ctx)
+	defer postgresContainer.Terminate(ctx)
+
+	dbPool := helperstest.SetupDatabaseConnection(t, ctx, dbCfg)
+	defer dbPool.Close()
+
+	helperstest.ApplyMigrations(t, dbCfg)
+
+	pvzRepo := pgxdb.NewPVZRepo(dbPool)
+	receptionRepo := pgxdb.NewReceptionRepo(dbPool)
+
+	pvz, err := pvzRepo.Create(ctx, entity.CityKazan, entity.DefaultTimezone)
+	require.NoError(t, err)
+
+	settings, err := pvzRepo.GetSettings(ctx, pvz.ID.String())
+	require.NoError(t, err)
+	require.Equal(t, entity.BlindCountPolicyReject, settings.BlindCountPolicy)
+
+	require.NoError(t, pvzRepo.SetSettings(ctx, entity.PVZSettings{PVZID: pvz.ID, BlindCountPolicy: entity.BlindCountPolicyFlag}))
+	settings, err = pvzRepo.GetSettings(ctx, pvz.ID.String())
+	require.NoError(t, err)
+	require.Equal(t, entity.BlindCountPolicyFlag, settings.BlindCountPolicy)
+
+	_, err = pvzRepo.GetSettings(ctx, uuid.New().String())
+	require.ErrorIs(t, err, repoerr.ErrNotFound)
+	err = pvzRepo.SetSettings(ctx, entity.PVZSettings{PVZID: uuid.New(), BlindCountPolicy: entity.BlindCountPolicyFlag})
+	require.ErrorIs(t, err, repoerr.ErrNotFound)
+
+	receptionID := helperstest.CreateAndCloseReception(t, ctx, dbPool, pvz.ID)
+	report := entity.BlindCountReport{
+		ReceptionID: receptionID,
+		Items:       []entity.BlindCountItem{{ProductType: entity.ProductTypeShoes, CountedCount: 3, RecordedCount: 2}},
+	}
+	require.NoError(t, receptionRepo.SaveBlindCount(ctx, report))
+
+	list, err := pvzRepo.ListWithDetails(ctx, entity.PVZFilter{}, 1, 30)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	require.Len(t, list[0].Receptions, 1)
+	require.NotNil(t, list[0].Receptions[0].BlindCount)
+	require.Equal(t, report.Items, list[0].Receptions[0].BlindCount.Items)
+}
+
+func TestPVZRepoCountProductsByStatus(t *testing.T) {
+	ctx := context.Background()
+
+	postgresContainer, dbCfg := helperstest.SetupPostgresContainer(t, ctx)
+	defer postgresContainer.Terminate(ctx)
+
+	dbPool := helperstest.SetupDatabaseConnection(t, ctx, dbCfg)
+	defer dbPool.Close()
+
+	helperstest.ApplyMigrations(t, dbCfg)
+
+	pvzRepo := pgxdb.NewPVZRepo(dbPool)
+	productRepo := pgxdb.NewProductRepo(dbPool)
+	pvzID := helperstest.CreatePVZ(t, ctx, dbPool)
+	closedID := helperstest.CreateAndCloseReception(t, ctx, dbPool, pvzID)
+	cancelledID := helperstest.CreateReceptionWithStatus(t, ctx, dbPool, pvzID, entity.StatusCancelled)
+
+	var products []entity.Product
+	for range 3 {
+		product, err := productRepo.Create(ctx, entity.Product{ReceptionID: closedID, Type: entity.ProductTypeShoes})
+		require.NoError(t, err)
+		products = append(products, *product)
+	}
+	_, err := productRepo.Issue(ctx, products[0].ID.String(), uuid.New())
+	require.NoError(t, err)
+	require.NoError(t, productRepo.Delete(ctx, products[2].ID.String(), uuid.New()))
+	_, err = productRepo.Create(ctx, entity.Product{ReceptionID: cancelledID, Type: entity.ProductTypeShoes})
+	require.NoError(t, err)
+
+	otherPVZID := helperstest.CreatePVZ(t, ctx, dbPool)
+	otherReceptionID := helperstest.CreateAndCloseReception(t, ctx, dbPool, otherPVZID)
+	_, err = productRepo.Create(ctx, entity.Product{ReceptionID: otherReceptionID, Type: entity.ProductTypeShoes})
+	require.NoError(t, err)
+
+	counts, err := pvzRepo.CountProductsByStatus(ctx, pvzID.String())
+	require.NoError(t, err)
+	require.Equal(t, map[string]int{entity.ProductStatusStored: 1, entity.ProductStatusIssued: 1}, counts)
+}
+
+func TestPVZRepoStoredValue(t *testing.T) {
+	ctx := context.Background()
+
+	postgresContainer, dbCfg := helperstest.SetupPostgresContainer(t, ctx)
+	defer postgresContainer.Terminate(ctx)
+
+	dbPool := helperstest.SetupDatabaseConnection(t, ctx, dbCfg)
+	defer dbPool.Close()
+
+	helperstest.ApplyMigrations(t, dbCfg)
+
+	pvzRepo := pgxdb.NewPVZRepo(dbPool)
+	productRepo := pgxdb.NewProductRepo(dbPool)
+	pvzID := helperstest.CreatePVZ(t, ctx, dbPool)
+	closedID := helperstest.CreateAndCloseReception(t, ctx, dbPool, pvzID)
+	cancelledID := helperstest.CreateReceptionWithStatus(t, ctx, dbPool, pvzID, entity.StatusCancelled)
+
+	var products []entity.Product
+	for _, value := range []entity.Product{
+		{DeclaredValue: 100000, Currency: "RUB"},
+		{DeclaredValue: 250000, Currency: "RUB"},
+		{DeclaredValue: 5000, Currency: "EUR"},
+		{DeclaredValue: 900000, Currency: "RUB"},
+		{DeclaredValue: 700000, Currency: "RUB"},
+		{},
+	} {
+		value.ReceptionID = closedID
+		value.Type = entity.ProductTypeShoes
+		product, err := productRepo.Create(ctx, value)
+		require.NoError(t, err)
+		products = append(products, *product)
+	}
+	_, err := productRepo.Issue(ctx, products[3].ID.String(), uuid.New())
+	require.NoError(t, err)
+	require.NoError(t, productRepo.Delete(ctx, products[4].ID.String(), uuid.New()))
+	_, err = productRepo.Create(ctx, entity.Product{ReceptionID: cancelledID, Type: entity.ProductTypeShoes,
+		DeclaredValue: 300000, Currency: "RUB"})
+	require.NoError(t, err)
+
+	stored, err := productRepo.GetByID(ctx, products[0].ID.String())
+	require.NoError(t, err)
+	require.Equal(t, int64(100000), stored.DeclaredValue)
+	require.Equal(t, "RUB", stored.Currency)
+
+	otherPVZID := helperstest.CreatePVZ(t, ctx, dbPool)
+	otherReceptionID := helperstest.CreateAndCloseReception(t, ctx, dbPool, otherPVZID)
+	_, err = productRepo.Create(ctx, entity.Product{ReceptionID: otherReceptionID, Type: entity.ProductTypeShoes,
+		DeclaredValue: 42000, Currency: "RUB"})
+	require.NoError(t, err)
+
+	totals, err := pvzRepo.SumStoredValue(ctx, pvzID.String())
+	require.NoError(t, err)
+	require.Equal(t, []entity.ValueTotal{
+		{Currency: "EUR", Amount: 5000, Count: 1},
+		{Currency: "RUB", Amount: 350000, Count: 2},
+	}, totals)
+
+	values, err := pvzRepo.ListStoredValues(ctx)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []entity.PVZValue{
+		{PVZID: pvzID, ValueTotal: entity.ValueTotal{Currency: "EUR", Amount: 5000, Count: 1}},
+		{PVZID: pvzID, ValueTotal: entity.ValueTotal{Currency: "RUB", Amount: 350000, Count: 2}},
+		{PVZID: otherPVZID, ValueTotal: entity.ValueTotal{Currency: "RUB", Amount: 42000, Count: 1}},
+	}, values)
+
+	_, err = productRepo.Create(ctx, entity.Product{ReceptionID: closedID, Type: entity.ProductTypeShoes,
+		DeclaredValue: 1000})
+	require.Error(t, err, "declared value without currency violates the check constraint")
+}
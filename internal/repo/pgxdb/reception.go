@@ -7,11 +7,16 @@ import (
 	"github.com/GlebMoskalev/go-pickup-point-api/internal/repo/repoerr"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"log/slog"
 	"time"
 )
 
+// singleOpenReceptionIndex гарантирует не более одной приёмки in_progress на док.
+const singleOpenReceptionIndex = "receptions_single_open_per_dock"
+
 type ReceptionRepo struct {
 	db *pgxpool.Pool
 }
@@ -20,11 +25,12 @@ func NewReceptionRepo(db *pgxpool.Pool) *ReceptionRepo {
 	return &ReceptionRepo{db: db}
 }
 
-func (r *ReceptionRepo) Create(ctx context.Context, pvzID string) (*entity.Reception, error) {
-	log := slog.With("layer", "ReceptionRepo", "operation", "Create", "pvzID", pvzID)
+func (r *ReceptionRepo) Create(ctx context.Context, params entity.ReceptionParams) (*entity.Reception, error) {
+	log := slog.With("layer", "ReceptionRepo", "operation", "Create", "pvzID", params.PVZID,
+		"dockID", params.DockID)
 	log.Debug("starting reception creation")
 
-	tx, err := r.db.Begin(ctx)
+	tx, err := conn(ctx, r.db).Begin(ctx)
 	if err != nil {
 		log.Error("failed to begin transaction", "error", err)
 		return nil, err
@@ -38,19 +44,59 @@ func (r *ReceptionRepo) Create(ctx context.Context, pvzID string) (*entity.Recep
 		}
 	}()
 
+	// Без дока приёмка попадает в док ПВЗ по умолчанию (триггер receptions_default_dock).
 	query := `
-	INSERT INTO receptions (pvz_id, status)
-	VALUES ($1, 'in_progress')
-	RETURNING id, date_time, pvz_id
+	INSERT INTO receptions (pvz_id, dock_id, kind, status, opened_by, carrier, waybill_number, vehicle_plate,
+	                        comment, in_progress_since)
+	VALUES ($1, NULLIF($2, '')::uuid, $3, $4, $5, $6, $7, $8, $9,
+	        CASE WHEN $4 = 'in_progress' THEN NOW() END)
+	RETURNING id, date_time, pvz_id, dock_id
 `
-	var id, pvzUUID uuid.UUID
+	status := entity.StatusInProgress
+	if params.Draft {
+		status = entity.StatusDraft
+	}
+	kind := params.Kind
+	if kind == "" {
+		kind = entity.ReceptionKindDelivery
+	}
+	var id, pvzUUID, dockID uuid.UUID
 	var dateTime time.Time
-	err = tx.QueryRow(ctx, query, pvzID).Scan(&id, &dateTime, &pvzUUID)
+	delivery := params.Delivery
+	err = tx.QueryRow(ctx, query, params.PVZID, params.DockID, kind, status, nullUUID(params.OpenedBy),
+		delivery.Carrier, delivery.WaybillNumber, delivery.VehiclePlate, delivery.Comment,
+	).Scan(&id, &dateTime, &pvzUUID, &dockID)
 	if err != nil {
+		var pgxError *pgconn.PgError
+		if errors.As(err, &pgxError) && pgxError.Code == "23505" &&
+			pgxError.ConstraintName == singleOpenReceptionIndex {
+			log.Warn("open reception already exists")
+			return nil, repoerr.ErrDuplicateEntry
+		}
 		log.Error("failed to create reception", "error", err)
 		return nil, err
 	}
 
+	for _, item := range params.Manifest {
+		_, err = tx.Exec(ctx, `
+		INSERT INTO reception_manifest_items (reception_id, product_type, expected_count)
+		VALUES ($1, $2, $3)
+`, id, item.ProductType, item.ExpectedCount)
+		if err != nil {
+			log.Error("failed to save manifest item", "error", err, "type", item.ProductType)
+			return nil, err
+		}
+	}
+
+	_, err = tx.Exec(ctx, `
+	INSERT INTO reception_status_history (reception_id, from_status, to_status, reason, changed_by)
+	VALUES ($1, '', $2, '', $3)
+`, id, status, nullUUID(params.OpenedBy))
+	if err != nil {
+		log.Error("failed to save initial status", "error", err)
+		return nil, err
+	}
+
 	if err = tx.Commit(ctx); err != nil {
 		log.Error("failed to commit transaction", "error", err)
 		return nil, err
@@ -60,15 +106,23 @@ func (r *ReceptionRepo) Create(ctx context.Context, pvzID string) (*entity.Recep
 		ID:       id,
 		DateTime: dateTime,
 		PVZID:    pvzUUID,
-		Status:   entity.StatusInProgress,
+		DockID:   dockID,
+		Kind:     kind,
+		Status:   status,
+		Manifest: params.Manifest,
+		Delivery: params.Delivery,
+		OpenedBy: params.OpenedBy,
 	}
 
 	log.Info("reception created successfully", "receptionID", id.String())
 	return reception, nil
 }
 
-func (r *ReceptionRepo) HasOpenReception(ctx context.Context, pvzID string) (bool, error) {
-	log := slog.With("layer", "ReceptionRepo", "operation", "HasOpenReception", "pvzID", pvzID)
+// HasOpenReception проверяет, есть ли открытая приёмка в доке dockID ПВЗ.
+// Пустой dockID означает док по умолчанию.
+func (r *ReceptionRepo) HasOpenReception(ctx context.Context, pvzID, dockID string) (bool, error) {
+	log := slog.With("layer", "ReceptionRepo", "operation", "HasOpenReception", "pvzID", pvzID,
+		"dockID", dockID)
 	log.Debug("checking for open reception")
 
 	query := `
@@ -76,10 +130,14 @@ func (r *ReceptionRepo) HasOpenReception(ctx context.Context, pvzID string) (boo
         SELECT 1
         FROM receptions
         WHERE pvz_id = $1 AND status = 'in_progress'
+          AND dock_id = COALESCE(
+              NULLIF($2, '')::uuid,
+              (SELECT id FROM docks WHERE pvz_id = $1 AND is_default)
+          )
     )
     `
 	var exists bool
-	err := r.db.QueryRow(ctx, query, pvzID).Scan(&exists)
+	err := conn(ctx, r.db).QueryRow(ctx, query, pvzID, dockID).Scan(&exists)
 	if err != nil {
 		log.Error("failed to check open reception", "error", err)
 		return false, err
@@ -89,37 +147,85 @@ func (r *ReceptionRepo) HasOpenReception(ctx context.Context, pvzID string) (boo
 	return exists, nil
 }
 
+// GetLastOpenReception возвращает открытую приёмку ПВЗ. Если открыто несколько приёмок
+// в разных доках, выбрать одну нельзя — возвращается repoerr.ErrMultipleRows.
 func (r *ReceptionRepo) GetLastOpenReception(ctx context.Context, pvzID string) (*entity.Reception, error) {
 	log := slog.With("layer", "ReceptionRepo", "operation", "GetLastOpenReception", "pvzID", pvzID)
 	log.Debug("retrieving last open reception")
 
+	return r.getOpenReception(ctx, log, "pvz_id", pvzID, "")
+}
+
+// LockLastOpenReception блокирует строку открытой приёмки до конца транзакции,
+// сериализуя добавление и удаление товаров с её закрытием. Имеет смысл только внутри TxManager.WithinTx.
+func (r *ReceptionRepo) LockLastOpenReception(ctx context.Context, pvzID string) (*entity.Reception, error) {
+	log := slog.With("layer", "ReceptionRepo", "operation", "LockLastOpenReception", "pvzID", pvzID)
+	log.Debug("locking last open reception")
+
+	return r.getOpenReception(ctx, log, "pvz_id", pvzID, "FOR UPDATE")
+}
+
+// LockOpenReceptionByDock блокирует открытую приёмку дока до конца транзакции.
+func (r *ReceptionRepo) LockOpenReceptionByDock(ctx context.Context, dockID string) (*entity.Reception, error) {
+	log := slog.With("layer", "ReceptionRepo", "operation", "LockOpenReceptionByDock", "dockID", dockID)
+	log.Debug("locking open reception of dock")
+
+	return r.getOpenReception(ctx, log, "dock_id", dockID, "FOR UPDATE")
+}
+
+// getOpenReception выбирает открытую приёмку по column. Читаются две строки, чтобы
+// отличить единственную приёмку от нескольких параллельных.
+func (r *ReceptionRepo) getOpenReception(ctx context.Context, log *slog.Logger, column, value,
+	lockClause string) (*entity.Reception, error) {
 	query := `
-	SELECT id, pvz_id, status, date_time
+	SELECT id, pvz_id, dock_id, kind, status, date_time
 	FROM receptions
-	WHERE pvz_id = $1 AND status = 'in_progress'
+	WHERE ` + column + ` = $1 AND status = 'in_progress'
 	ORDER BY date_time DESC
-	LIMIT 1
-`
+	LIMIT 2
+	` + lockClause
 
-	var reception entity.Reception
-	err := r.db.QueryRow(ctx, query, pvzID).Scan(&reception.ID, &reception.PVZID, &reception.Status, &reception.DateTime)
+	rows, err := conn(ctx, r.db).Query(ctx, query, value)
 	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			log.Error("not found open reception")
-			return nil, repoerr.ErrNoRows
+		log.Error("failed to get open reception", "error", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var receptions []entity.Reception
+	for rows.Next() {
+		var reception entity.Reception
+		err := rows.Scan(&reception.ID, &reception.PVZID, &reception.DockID, &reception.Kind, &reception.Status,
+			&reception.DateTime)
+		if err != nil {
+			log.Error("failed to scan reception", "error", err)
+			return nil, err
 		}
-		log.Error("failed to get last open reception", "error", err)
+		receptions = append(receptions, reception)
+	}
+	if err := rows.Err(); err != nil {
+		log.Error("rows error", "error", err)
 		return nil, err
 	}
-	log.Info("last open reception retrieved", "receptionID", reception.ID.String())
-	return &reception, nil
+
+	switch len(receptions) {
+	case 0:
+		log.Error("not found open reception")
+		return nil, repoerr.ErrNoRows
+	case 1:
+		log.Info("open reception retrieved", "receptionID", receptions[0].ID.String())
+		return &receptions[0], nil
+	default:
+		log.Warn("several open receptions found")
+		return nil, repoerr.ErrMultipleRows
+	}
 }
 
-func (r *ReceptionRepo) Close(ctx context.Context, receptionID string) error {
+func (r *ReceptionRepo) Close(ctx context.Context, receptionID string, closedBy uuid.UUID) error {
 	log := slog.With("layer", "ReceptionRepo", "operation", "Close", "receptionID", receptionID)
 	log.Debug("starting reception closure")
 
-	tx, err := r.db.Begin(ctx)
+	tx, err := conn(ctx, r.db).Begin(ctx)
 	if err != nil {
 		log.Error("failed to begin transaction", "error", err)
 		return err
@@ -135,12 +241,12 @@ func (r *ReceptionRepo) Close(ctx context.Context, receptionID string) error {
 
 	query := `
 	UPDATE receptions
-	SET status = 'close'
+	SET status = 'close', closed_by = $2, closed_at = NOW()
 	WHERE id = $1 AND status = 'in_progress'
 	RETURNING id
 `
 	var id uuid.UUID
-	err = tx.QueryRow(ctx, query, receptionID).Scan(&id)
+	err = tx.QueryRow(ctx, query, receptionID, nullUUID(closedBy)).Scan(&id)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			log.Error("not found in_progress reception")
@@ -158,3 +264,384 @@ func (r *ReceptionRepo) Close(ctx context.Context, receptionID string) error {
 	log.Info("reception closed successfully", "receptionID", id.String())
 	return nil
 }
+
+func (r *ReceptionRepo) GetManifest(ctx context.Context, receptionID string) ([]entity.ManifestItem, error) {
+	log := slog.With("layer", "ReceptionRepo", "operation", "GetManifest", "receptionID", receptionID)
+	log.Debug("retrieving reception manifest")
+
+	query := `
+	SELECT product_type, expected_count
+	FROM reception_manifest_items
+	WHERE reception_id = $1
+	ORDER BY product_type
+`
+	rows, err := conn(ctx, r.db).Query(ctx, query, receptionID)
+	if err != nil {
+		log.Error("failed to get manifest", "error", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var manifest []entity.ManifestItem
+	for rows.Next() {
+		var item entity.ManifestItem
+		if err := rows.Scan(&item.ProductType, &item.ExpectedCount); err != nil {
+			log.Error("failed to scan manifest item", "error", err)
+			return nil, err
+		}
+		manifest = append(manifest, item)
+	}
+	if err := rows.Err(); err != nil {
+		log.Error("rows error", "error", err)
+		return nil, err
+	}
+
+	log.Debug("manifest retrieved", "items", len(manifest))
+	return manifest, nil
+}
+
+// CountPendingTransferItems возвращает число товаров перемещения, которые отправлены в приёмку
+// и ещё не приняты в ней.
+func (r *ReceptionRepo) CountPendingTransferItems(ctx context.Context, receptionID string) (int, error) {
+	log := slog.With("layer", "ReceptionRepo", "operation", "CountPendingTransferItems", "receptionID", receptionID)
+	log.Debug("counting pending transfer items")
+
+	query := `
+	SELECT COUNT(*)
+	FROM transfers t
+	INNER JOIN transfer_items i ON i.transfer_id = t.id
+	WHERE t.reception_id = $1 AND t.status = 'in_transit' AND i.received_at IS NULL
+`
+	var pending int
+	if err := conn(ctx, r.db).QueryRow(ctx, query, receptionID).Scan(&pending); err != nil {
+		log.Error("failed to count pending transfer items", "error", err)
+		return 0, err
+	}
+	return pending, nil
+}
+
+func (r *ReceptionRepo) SaveDiscrepancyReport(ctx context.Context, report entity.DiscrepancyReport) error {
+	log := slog.With("layer", "ReceptionRepo", "operation", "SaveDiscrepancyReport",
+		"receptionID", report.ReceptionID.String())
+	log.Debug("starting discrepancy report saving")
+
+	tx, err := conn(ctx, r.db).Begin(ctx)
+	if err != nil {
+		log.Error("failed to begin transaction", "error", err)
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			if rollbackErr := tx.Rollback(ctx); rollbackErr != nil {
+				log.Error("failed to rollback transaction", "error", rollbackErr)
+			}
+		}
+	}()
+
+	_, err = tx.Exec(ctx, `DELETE FROM reception_discrepancies WHERE reception_id = $1`, report.ReceptionID)
+	if err != nil {
+		log.Error("failed to clear previous report", "error", err)
+		return err
+	}
+
+	for _, item := range report.Items {
+		_, err = tx.Exec(ctx, `
+		INSERT INTO reception_discrepancies (reception_id, product_type, expected_count, received_count)
+		VALUES ($1, $2, $3, $4)
+`, report.ReceptionID, item.ProductType, item.ExpectedCount, item.ReceivedCount)
+		if err != nil {
+			log.Error("failed to save discrepancy item", "error", err, "type", item.ProductType)
+			return err
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		log.Error("failed to commit transaction", "error", err)
+		return err
+	}
+
+	log.Info("discrepancy report saved", "hasDiscrepancies", report.HasDiscrepancies())
+	return nil
+}
+
+// SaveAct сохраняет снимок акта приёма следующей редакцией. Снимки прошлых закрытий приёмки
+// остаются без изменений.
+func (r *ReceptionRepo) SaveAct(ctx context.Context, snapshot entity.ActSnapshot) error {
+	log := slog.With("layer", "ReceptionRepo", "operation", "SaveAct",
+		"receptionID", snapshot.ReceptionID.String())
+	log.Debug("starting acceptance act saving")
+
+	query := `
+	INSERT INTO acceptance_acts (reception_id, version, content, content_hash)
+	SELECT $1, COALESCE(MAX(version), 0) + 1, $2, $3
+	FROM acceptance_acts
+	WHERE reception_id = $1
+	RETURNING version
+`
+	var version int
+	err := conn(ctx, r.db).QueryRow(ctx, query, snapshot.ReceptionID, string(snapshot.Content),
+		snapshot.ContentHash).Scan(&version)
+	if err != nil {
+		log.Error("failed to save acceptance act", "error", err)
+		return err
+	}
+
+	log.Info("acceptance act saved", "version", version, "contentHash", snapshot.ContentHash)
+	return nil
+}
+
+// GetAct возвращает последнюю редакцию снимка акта приёма или repoerr.ErrNoRows, если акт не зафиксирован.
+func (r *ReceptionRepo) GetAct(ctx context.Context, receptionID string) (*entity.ActSnapshot, error) {
+	log := slog.With("layer", "ReceptionRepo", "operation", "GetAct", "receptionID", receptionID)
+	log.Debug("starting get acceptance act")
+
+	query := `
+	SELECT reception_id, version, content::TEXT, content_hash, created_at
+	FROM acceptance_acts
+	WHERE reception_id = $1
+	ORDER BY version DESC
+	LIMIT 1
+`
+	var (
+		snapshot entity.ActSnapshot
+		content  string
+	)
+	err := conn(ctx, r.db).QueryRow(ctx, query, receptionID).Scan(&snapshot.ReceptionID, &snapshot.Version,
+		&content, &snapshot.ContentHash, &snapshot.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			log.Debug("acceptance act not found")
+			return nil, repoerr.ErrNoRows
+		}
+		log.Error("failed to get acceptance act", "error", err)
+		return nil, err
+	}
+	snapshot.Content = []byte(content)
+	return &snapshot, nil
+}
+
+// SaveBlindCount сохраняет результат «слепого» пересчёта, заменяя сохранённый ранее.
+func (r *ReceptionRepo) SaveBlindCount(ctx context.Context, report entity.BlindCountReport) error {
+	log := slog.With("layer", "ReceptionRepo", "operation", "SaveBlindCount",
+		"receptionID", report.ReceptionID.String())
+	log.Debug("starting blind count saving")
+
+	tx, err := conn(ctx, r.db).Begin(ctx)
+	if err != nil {
+		log.Error("failed to begin transaction", "error", err)
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			if rollbackErr := tx.Rollback(ctx); rollbackErr != nil {
+				log.Error("failed to rollback transaction", "error", rollbackErr)
+			}
+		}
+	}()
+
+	_, err = tx.Exec(ctx, `DELETE FROM reception_blind_counts WHERE reception_id = $1`, report.ReceptionID)
+	if err != nil {
+		log.Error("failed to clear previous blind count", "error", err)
+		return err
+	}
+
+	for _, item := range report.Items {
+		_, err = tx.Exec(ctx, `
+		INSERT INTO reception_blind_counts (reception_id, product_type, counted_count, recorded_count)
+		VALUES ($1, $2, $3, $4)
+`, report.ReceptionID, item.ProductType, item.CountedCount, item.RecordedCount)
+		if err != nil {
+			log.Error("failed to save blind count item", "error", err, "type", item.ProductType)
+			return err
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		log.Error("failed to commit transaction", "error", err)
+		return err
+	}
+
+	log.Info("blind count saved", "hasMismatch", report.HasMismatch())
+	return nil
+}
+
+// LockByID возвращает приёмку, блокируя её строку до конца транзакции.
+func (r *ReceptionRepo) LockByID(ctx context.Context, receptionID string) (*entity.Reception, error) {
+	log := slog.With("layer", "ReceptionRepo", "operation", "LockByID", "receptionID", receptionID)
+	log.Debug("locking reception")
+
+	query := `
+	SELECT id, pvz_id, dock_id, kind, status, date_time
+	FROM receptions
+	WHERE id = $1
+	FOR UPDATE
+`
+	var reception entity.Reception
+	err := conn(ctx, r.db).QueryRow(ctx, query, receptionID).Scan(&reception.ID, &reception.PVZID,
+		&reception.DockID, &reception.Kind, &reception.Status, &reception.DateTime)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			log.Error("reception not found")
+			return nil, repoerr.ErrNoRows
+		}
+		log.Error("failed to lock reception", "error", err)
+		return nil, err
+	}
+	return &reception, nil
+}
+
+func (r *ReceptionRepo) GetByID(ctx context.Context, receptionID string) (*entity.Reception, error) {
+	log := slog.With("layer", "ReceptionRepo", "operation", "GetByID", "receptionID", receptionID)
+	log.Debug("starting get reception")
+
+	query := `
+	SELECT id, date_time, pvz_id, dock_id, kind, status, opened_by, closed_by, closed_at,
+	       carrier, waybill_number, vehicle_plate, comment
+	FROM receptions
+	WHERE id = $1
+`
+	var (
+		reception          entity.Reception
+		openedBy, closedBy pgtype.UUID
+		closedAt           pgtype.Timestamptz
+	)
+	err := conn(ctx, r.db).QueryRow(ctx, query, receptionID).Scan(
+		&reception.ID, &reception.DateTime, &reception.PVZID, &reception.DockID, &reception.Kind,
+		&reception.Status, &openedBy, &closedBy, &closedAt,
+		&reception.Delivery.Carrier, &reception.Delivery.WaybillNumber,
+		&reception.Delivery.VehiclePlate, &reception.Delivery.Comment,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			log.Warn("reception not found")
+			return nil, repoerr.ErrNoRows
+		}
+		log.Error("failed to get reception", "error", err)
+		return nil, err
+	}
+	reception.OpenedBy = uuidOrNil(openedBy)
+	reception.ClosedBy = uuidOrNil(closedBy)
+	reception.ClosedAt = timeOrNil(closedAt)
+	return &reception, nil
+}
+
+// SetStatus переводит приёмку в новый статус. Повторное открытие при уже открытой
+// приёмке в том же доке отклоняется индексом singleOpenReceptionIndex.
+func (r *ReceptionRepo) SetStatus(ctx context.Context, receptionID, status string) error {
+	log := slog.With("layer", "ReceptionRepo", "operation", "SetStatus", "receptionID", receptionID,
+		"status", status)
+	log.Debug("starting reception status update")
+
+	// При повторном открытии сведения о закрытии и пометка о зависании теряют смысл; они остаются
+	// в истории статусов. Срок зависания отсчитывается заново с момента перехода в in_progress.
+	query := `
+	UPDATE receptions
+	SET status = $2,
+	    closed_by = CASE WHEN $2 = 'in_progress' THEN NULL ELSE closed_by END,
+	    closed_at = CASE WHEN $2 = 'in_progress' THEN NULL ELSE closed_at END,
+	    in_progress_since = CASE WHEN $2 = 'in_progress' THEN NOW() ELSE in_progress_since END,
+	    stale_flagged_at = CASE WHEN $2 = 'in_progress' THEN NULL ELSE stale_flagged_at END,
+	    stale_reason = CASE WHEN $2 = 'in_progress' THEN NULL ELSE stale_reason END
+	WHERE id = $1
+`
+	tag, err := conn(ctx, r.db).Exec(ctx, query, receptionID, status)
+	if err != nil {
+		var pgxError *pgconn.PgError
+		if errors.As(err, &pgxError) && pgxError.Code == "23505" &&
+			pgxError.ConstraintName == singleOpenReceptionIndex {
+			log.Warn("open reception already exists")
+			return repoerr.ErrDuplicateEntry
+		}
+		log.Error("failed to update reception status", "error", err)
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		log.Error("reception not found")
+		return repoerr.ErrNoRows
+	}
+
+	log.Info("reception status updated")
+	return nil
+}
+
+func (r *ReceptionRepo) AddStatusChange(ctx context.Context, change entity.StatusChange) error {
+	log := slog.With("layer", "ReceptionRepo", "operation", "AddStatusChange",
+		"receptionID", change.ReceptionID.String(), "from", change.FromStatus, "to", change.ToStatus)
+	log.Debug("saving status change")
+
+	query := `
+	INSERT INTO reception_status_history (reception_id, from_status, to_status, reason, changed_by)
+	VALUES ($1, $2, $3, $4, $5)
+`
+	_, err := conn(ctx, r.db).Exec(ctx, query, change.ReceptionID, change.FromStatus, change.ToStatus,
+		change.Reason, nullUUID(change.ChangedBy))
+	if err != nil {
+		log.Error("failed to save status change", "error", err)
+		return err
+	}
+	return nil
+}
+
+// ListStale возвращает открытые приёмки, находящиеся в статусе in_progress с момента раньше
+// inProgressBefore. Для черновиков и повторно открытых приёмок отсчёт идёт от последнего перехода
+// в in_progress, а не от создания.
+func (r *ReceptionRepo) ListStale(ctx context.Context, inProgressBefore time.Time) ([]entity.Reception, error) {
+	log := slog.With("layer", "ReceptionRepo", "operation", "ListStale", "inProgressBefore", inProgressBefore)
+	log.Debug("listing stale receptions")
+
+	query := `
+	SELECT id, pvz_id, status, date_time, COALESCE(in_progress_since, date_time), stale_flagged_at,
+	       COALESCE(stale_reason, '')
+	FROM receptions
+	WHERE status = 'in_progress' AND COALESCE(in_progress_since, date_time) < $1
+	ORDER BY COALESCE(in_progress_since, date_time)
+`
+	rows, err := conn(ctx, r.db).Query(ctx, query, inProgressBefore)
+	if err != nil {
+		log.Error("failed to list stale receptions", "error", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var receptions []entity.Reception
+	for rows.Next() {
+		var reception entity.Reception
+		err := rows.Scan(&reception.ID, &reception.PVZID, &reception.Status, &reception.DateTime,
+			&reception.InProgressSince, &reception.StaleFlaggedAt, &reception.StaleReason)
+		if err != nil {
+			log.Error("failed to scan reception", "error", err)
+			return nil, err
+		}
+		receptions = append(receptions, reception)
+	}
+	if err := rows.Err(); err != nil {
+		log.Error("rows error", "error", err)
+		return nil, err
+	}
+
+	log.Debug("stale receptions listed", "count", len(receptions))
+	return receptions, nil
+}
+
+// FlagStale помечает открытую приёмку как зависшую. Возвращает false, если она уже помечена или закрыта.
+func (r *ReceptionRepo) FlagStale(ctx context.Context, receptionID, reason string) (bool, error) {
+	log := slog.With("layer", "ReceptionRepo", "operation", "FlagStale", "receptionID", receptionID)
+	log.Debug("flagging stale reception")
+
+	query := `
+	UPDATE receptions
+	SET stale_flagged_at = NOW(), stale_reason = $2
+	WHERE id = $1 AND status = 'in_progress' AND stale_flagged_at IS NULL
+`
+	tag, err := conn(ctx, r.db).Exec(ctx, query, receptionID, reason)
+	if err != nil {
+		log.Error("failed to flag reception", "error", err)
+		return false, err
+	}
+
+	flagged := tag.RowsAffected() > 0
+	log.Debug("stale flag processed", "flagged", flagged)
+	return flagged, nil
+}
@@ -2,12 +2,17 @@ package pgxdb_test
 
 import (
 	"context"
+	"errors"
 	"github.com/GlebMoskalev/go-pickup-point-api/integration/helperstest"
 	"github.com/GlebMoskalev/go-pickup-point-api/internal/entity"
 	"github.com/GlebMoskalev/go-pickup-point-api/internal/repo/pgxdb"
 	"github.com/GlebMoskalev/go-pickup-point-api/internal/repo/repoerr"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/stretchr/testify/require"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -32,12 +37,19 @@ func TestReceptionRepoCreate(t *testing.T) {
 		name        string
 		pvzID       string
 		expectError bool
+		expectedErr error
 	}{
 		{
 			name:        "Create reception successfully",
 			pvzID:       validPvzID.String(),
 			expectError: false,
 		},
+		{
+			name:        "Create second open reception for the same PVZ",
+			pvzID:       validPvzID.String(),
+			expectError: true,
+			expectedErr: repoerr.ErrDuplicateEntry,
+		},
 		{
 			name:        "Create reception with invalid PVZ ID",
 			pvzID:       invalidPvzID.String(),
@@ -52,10 +64,13 @@ func TestReceptionRepoCreate(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			reception, err := receptionRepo.Create(ctx, tc.pvzID)
+			reception, err := receptionRepo.Create(ctx, entity.ReceptionParams{PVZID: tc.pvzID})
 
 			if tc.expectError {
 				require.Error(t, err)
+				if tc.expectedErr != nil {
+					require.ErrorIs(t, err, tc.expectedErr)
+				}
 				require.Nil(t, reception)
 			} else {
 				require.NoError(t, err)
@@ -92,7 +107,7 @@ func TestReceptionRepoHasOpenReception(t *testing.T) {
 	emptyPvzID := helperstest.CreatePVZ(t, ctx, dbPool)
 	invalidPvzID := uuid.New()
 
-	reception, err := receptionRepo.Create(ctx, pvzID.String())
+	reception, err := receptionRepo.Create(ctx, entity.ReceptionParams{PVZID: pvzID.String()})
 	require.NoError(t, err)
 	require.NotNil(t, reception)
 
@@ -130,7 +145,7 @@ func TestReceptionRepoHasOpenReception(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			hasOpen, err := receptionRepo.HasOpenReception(ctx, tc.pvzID)
+			hasOpen, err := receptionRepo.HasOpenReception(ctx, tc.pvzID, "")
 
 			if tc.expectError {
 				require.Error(t, err)
@@ -159,13 +174,14 @@ func TestReceptionRepoGetLastOpenReception(t *testing.T) {
 	emptyPvzID := helperstest.CreatePVZ(t, ctx, dbPool)
 	invalidPvzID := uuid.New()
 
-	firstReception, err := receptionRepo.Create(ctx, pvzID.String())
+	firstReception, err := receptionRepo.Create(ctx, entity.ReceptionParams{PVZID: pvzID.String()})
 	require.NoError(t, err)
 	require.NotNil(t, firstReception)
+	require.NoError(t, receptionRepo.Close(ctx, firstReception.ID.String(), uuid.Nil))
 
 	time.Sleep(10 * time.Millisecond)
 
-	secondReception, err := receptionRepo.Create(ctx, pvzID.String())
+	secondReception, err := receptionRepo.Create(ctx, entity.ReceptionParams{PVZID: pvzID.String()})
 	require.NoError(t, err)
 	require.NotNil(t, secondReception)
 
@@ -177,7 +193,7 @@ func TestReceptionRepoGetLastOpenReception(t *testing.T) {
 		checkDetails bool
 	}{
 		{
-			name:         "Get last open reception - should return latest",
+			name:         "Get last open reception - should skip closed",
 			pvzID:        pvzID.String(),
 			expectError:  false,
 			checkDetails: true,
@@ -239,13 +255,14 @@ func TestReceptionRepoClose(t *testing.T) {
 
 	pvzID := helperstest.CreatePVZ(t, ctx, dbPool)
 
-	openReception, err := receptionRepo.Create(ctx, pvzID.String())
+	openReception, err := receptionRepo.Create(ctx, entity.ReceptionParams{PVZID: pvzID.String()})
 	require.NoError(t, err)
 	require.NotNil(t, openReception)
 
 	closedReceptionID := helperstest.CreateAndCloseReception(t, ctx, dbPool, pvzID)
 
 	invalidReceptionID := uuid.New()
+	userID := uuid.New()
 
 	testCases := []struct {
 		name        string
@@ -279,7 +296,7 @@ func TestReceptionRepoClose(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			err := receptionRepo.Close(ctx, tc.receptionID)
+			err := receptionRepo.Close(ctx, tc.receptionID, userID)
 
 			if tc.expectError {
 				require.Error(t, err)
@@ -289,14 +306,403 @@ func TestReceptionRepoClose(t *testing.T) {
 			} else {
 				require.NoError(t, err)
 
-				var status string
+				var (
+					status   string
+					closedBy uuid.UUID
+					closedAt pgtype.Timestamptz
+				)
 				err = dbPool.QueryRow(ctx,
-					`SELECT status FROM receptions WHERE id = $1`,
+					`SELECT status, closed_by, closed_at FROM receptions WHERE id = $1`,
 					tc.receptionID,
-				).Scan(&status)
+				).Scan(&status, &closedBy, &closedAt)
 				require.NoError(t, err)
 				require.Equal(t, "close", status)
+				require.Equal(t, userID, closedBy)
+				require.True(t, closedAt.Valid)
 			}
 		})
 	}
 }
+
+func TestReceptionRepoCreateConcurrent(t *testing.T) {
+	ctx := context.Background()
+
+	postgresContainer, dbCfg := helperstest.SetupPostgresContainer(t, ctx)
+	defer postgresContainer.Terminate(ctx)
+
+	dbPool := helperstest.SetupDatabaseConnection(t, ctx, dbCfg)
+	defer dbPool.Close()
+
+	helperstest.ApplyMigrations(t, dbCfg)
+
+	receptionRepo := pgxdb.NewReceptionRepo(dbPool)
+
+	pvzID := helperstest.CreatePVZ(t, ctx, dbPool)
+
+	const workers = 20
+	var (
+		wg         sync.WaitGroup
+		created    atomic.Int32
+		duplicates atomic.Int32
+		start      = make(chan struct{})
+	)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			_, err := receptionRepo.Create(ctx, entity.ReceptionParams{PVZID: pvzID.String()})
+			switch {
+			case err == nil:
+				created.Add(1)
+			case errors.Is(err, repoerr.ErrDuplicateEntry):
+				duplicates.Add(1)
+			default:
+				t.Errorf("unexpected error: %v", err)
+			}
+		}()
+	}
+	close(start)
+	wg.Wait()
+
+	require.Equal(t, int32(1), created.Load())
+	require.Equal(t, int32(workers-1), duplicates.Load())
+
+	var openCount int
+	err := dbPool.QueryRow(ctx,
+		`SELECT COUNT(*) FROM receptions WHERE pvz_id = $1 AND status = 'in_progress'`,
+		pvzID,
+	).Scan(&openCount)
+	require.NoError(t, err)
+	require.Equal(t, 1, openCount)
+}
+
+func TestReceptionRepoManifest(t *testing.T) {
+	ctx := context.Background()
+
+	postgresContainer, dbCfg := helperstest.SetupPostgresContainer(t, ctx)
+	defer postgresContainer.Terminate(ctx)
+
+	dbPool := helperstest.SetupDatabaseConnection(t, ctx, dbCfg)
+	defer dbPool.Close()
+
+	helperstest.ApplyMigrations(t, dbCfg)
+
+	receptionRepo := pgxdb.NewReceptionRepo(dbPool)
+	productRepo := pgxdb.NewProductRepo(dbPool)
+
+	pvzID := helperstest.CreatePVZ(t, ctx, dbPool)
+	manifest := []entity.ManifestItem{
+		{ProductType: entity.ProductTypeClothes, ExpectedCount: 2},
+		{ProductType: entity.ProductTypeElectronics, ExpectedCount: 1},
+	}
+
+	reception, err := receptionRepo.Create(ctx, entity.ReceptionParams{PVZID: pvzID.String(), Manifest: manifest})
+	require.NoError(t, err)
+	require.Equal(t, manifest, reception.Manifest)
+
+	stored, err := receptionRepo.GetManifest(ctx, reception.ID.String())
+	require.NoError(t, err)
+	require.ElementsMatch(t, manifest, stored)
+
+	for _, productType := range []string{entity.ProductTypeClothes, entity.ProductTypeShoes} {
+		_, err := productRepo.Create(ctx, entity.Product{ReceptionID: reception.ID, Type: productType})
+		require.NoError(t, err)
+	}
+	counts, err := productRepo.CountByType(ctx, reception.ID.String())
+	require.NoError(t, err)
+	require.Equal(t, map[string]int{entity.ProductTypeClothes: 1, entity.ProductTypeShoes: 1}, counts)
+
+	report := entity.DiscrepancyReport{
+		ReceptionID: reception.ID,
+		Items: []entity.DiscrepancyItem{
+			{ProductType: entity.ProductTypeClothes, ExpectedCount: 2, ReceivedCount: 1},
+			{ProductType: entity.ProductTypeElectronics, ExpectedCount: 1, ReceivedCount: 0},
+			{ProductType: entity.ProductTypeShoes, ExpectedCount: 0, ReceivedCount: 1},
+		},
+	}
+	require.NoError(t, receptionRepo.Close(ctx, reception.ID.String(), uuid.Nil))
+	require.NoError(t, receptionRepo.SaveDiscrepancyReport(ctx, report))
+
+	pvzRepo := pgxdb.NewPVZRepo(dbPool)
+	list, err := pvzRepo.ListWithDetails(ctx, entity.PVZFilter{}, 1, 30)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	require.Len(t, list[0].Receptions, 1)
+	details := list[0].Receptions[0]
+	require.Len(t, details.Products, 2)
+	require.ElementsMatch(t, manifest, details.Reception.Manifest)
+	require.NotNil(t, details.DiscrepancyReport)
+	require.ElementsMatch(t, report.Items, details.DiscrepancyReport.Items)
+}
+
+func TestReceptionRepoStatusChange(t *testing.T) {
+	ctx := context.Background()
+
+	postgresContainer, dbCfg := helperstest.SetupPostgresContainer(t, ctx)
+	defer postgresContainer.Terminate(ctx)
+
+	dbPool := helperstest.SetupDatabaseConnection(t, ctx, dbCfg)
+	defer dbPool.Close()
+
+	helperstest.ApplyMigrations(t, dbCfg)
+
+	receptionRepo := pgxdb.NewReceptionRepo(dbPool)
+
+	pvzID := helperstest.CreatePVZ(t, ctx, dbPool)
+	closedID := helperstest.CreateAndCloseReception(t, ctx, dbPool, pvzID)
+
+	reception, err := receptionRepo.LockByID(ctx, closedID.String())
+	require.NoError(t, err)
+	require.Equal(t, entity.StatusClose, reception.Status)
+
+	_, err = receptionRepo.LockByID(ctx, uuid.New().String())
+	require.ErrorIs(t, err, repoerr.ErrNoRows)
+
+	openID := helperstest.CreateReception(t, ctx, dbPool, pvzID)
+	err = receptionRepo.SetStatus(ctx, closedID.String(), entity.StatusInProgress)
+	require.ErrorIs(t, err, repoerr.ErrDuplicateEntry, "second open reception must be rejected")
+
+	require.NoError(t, receptionRepo.SetStatus(ctx, openID.String(), entity.StatusCancelled))
+	require.NoError(t, receptionRepo.SetStatus(ctx, closedID.String(), entity.StatusInProgress))
+	require.ErrorIs(t, receptionRepo.SetStatus(ctx, uuid.New().String(), entity.StatusClose), repoerr.ErrNoRows)
+
+	userID := uuid.New()
+	require.NoError(t, receptionRepo.AddStatusChange(ctx, entity.StatusChange{
+		ReceptionID: closedID,
+		FromStatus:  entity.StatusClose,
+		ToStatus:    entity.StatusInProgress,
+		Reason:      "закрыта по ошибке",
+		ChangedBy:   userID,
+	}))
+
+	pvzRepo := pgxdb.NewPVZRepo(dbPool)
+	list, err := pvzRepo.ListWithDetails(ctx, entity.PVZFilter{}, 1, 30)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	for _, details := range list[0].Receptions {
+		switch details.Reception.ID {
+		case closedID:
+			require.Equal(t, entity.StatusInProgress, details.Reception.Status)
+			require.Len(t, details.StatusHistory, 1)
+			require.Equal(t, userID, details.StatusHistory[0].ChangedBy)
+			require.Equal(t, "закрыта по ошибке", details.StatusHistory[0].Reason)
+		case openID:
+			require.Equal(t, entity.StatusCancelled, details.Reception.Status)
+			require.Empty(t, details.StatusHistory)
+		}
+	}
+}
+
+func TestReceptionRepoCreateDraft(t *testing.T) {
+	ctx := context.Background()
+
+	postgresContainer, dbCfg := helperstest.SetupPostgresContainer(t, ctx)
+	defer postgresContainer.Terminate(ctx)
+
+	dbPool := helperstest.SetupDatabaseConnection(t, ctx, dbCfg)
+	defer dbPool.Close()
+
+	helperstest.ApplyMigrations(t, dbCfg)
+
+	receptionRepo := pgxdb.NewReceptionRepo(dbPool)
+
+	pvzID := helperstest.CreatePVZ(t, ctx, dbPool)
+	openID := helperstest.CreateReception(t, ctx, dbPool, pvzID)
+
+	userID := uuid.New()
+	draft, err := receptionRepo.Create(ctx, entity.ReceptionParams{PVZID: pvzID.String(), Draft: true, OpenedBy: userID})
+	require.NoError(t, err, "draft must not conflict with the open reception")
+	require.Equal(t, entity.StatusDraft, draft.Status)
+
+	err = receptionRepo.SetStatus(ctx, draft.ID.String(), entity.StatusInProgress)
+	require.ErrorIs(t, err, repoerr.ErrDuplicateEntry)
+
+	require.NoError(t, receptionRepo.SetStatus(ctx, openID.String(), entity.StatusClose))
+	require.NoError(t, receptionRepo.SetStatus(ctx, draft.ID.String(), entity.StatusInProgress))
+	require.NoError(t, receptionRepo.SetStatus(ctx, openID.String(), entity.StatusVerified))
+
+	pvzRepo := pgxdb.NewPVZRepo(dbPool)
+	list, err := pvzRepo.ListWithDetails(ctx, entity.PVZFilter{}, 1, 30)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	for _, details := range list[0].Receptions {
+		if details.Reception.ID != draft.ID {
+			continue
+		}
+		require.Len(t, details.StatusHistory, 1)
+		require.Equal(t, "", details.StatusHistory[0].FromStatus)
+		require.Equal(t, entity.StatusDraft, details.StatusHistory[0].ToStatus)
+		require.Equal(t, userID, details.StatusHistory[0].ChangedBy)
+	}
+}
+
+func TestReceptionRepoStale(t *testing.T) {
+	ctx := context.Background()
+
+	postgresContainer, dbCfg := helperstest.SetupPostgresContainer(t, ctx)
+	defer postgresContainer.Terminate(ctx)
+
+	dbPool := helperstest.SetupDatabaseConnection(t, ctx, dbCfg)
+	defer dbPool.Close()
+
+	helperstest.ApplyMigrations(t, dbCfg)
+
+	receptionRepo := pgxdb.NewReceptionRepo(dbPool)
+
+	staleID := helperstest.CreateReception(t, ctx, dbPool, helperstest.CreatePVZ(t, ctx, dbPool))
+	freshID := helperstest.CreateReception(t, ctx, dbPool, helperstest.CreatePVZ(t, ctx, dbPool))
+	_, err := dbPool.Exec(ctx, `UPDATE receptions SET date_time = NOW() - INTERVAL '2 days' WHERE id = $1`, staleID)
+	require.NoError(t, err)
+
+	stale, err := receptionRepo.ListStale(ctx, time.Now().Add(-24*time.Hour))
+	require.NoError(t, err)
+	require.Len(t, stale, 1)
+	require.Equal(t, staleID, stale[0].ID)
+	require.Nil(t, stale[0].StaleFlaggedAt)
+
+	flagged, err := receptionRepo.FlagStale(ctx, staleID.String(), "открыта дольше 24h")
+	require.NoError(t, err)
+	require.True(t, flagged)
+
+	flagged, err = receptionRepo.FlagStale(ctx, staleID.String(), "открыта дольше 24h")
+	require.NoError(t, err)
+	require.False(t, flagged, "reception should be flagged only once")
+
+	stale, err = receptionRepo.ListStale(ctx, time.Now().Add(-24*time.Hour))
+	require.NoError(t, err)
+	require.Len(t, stale, 1)
+	require.NotNil(t, stale[0].StaleFlaggedAt)
+	require.Equal(t, "открыта дольше 24h", stale[0].StaleReason)
+	require.NotEqual(t, freshID, stale[0].ID)
+}
+
+func TestReceptionRepoStaleAfterReopen(t *testing.T) {
+	ctx := context.Background()
+
+	postgresContainer, dbCfg := helperstest.SetupPostgresContainer(t, ctx)
+	defer postgresContainer.Terminate(ctx)
+
+	dbPool := helperstest.SetupDatabaseConnection(t, ctx, dbCfg)
+	defer dbPool.Close()
+
+	helperstest.ApplyMigrations(t, dbCfg)
+
+	receptionRepo := pgxdb.NewReceptionRepo(dbPool)
+	pvzID := helperstest.CreatePVZ(t, ctx, dbPool)
+
+	reopened, err := receptionRepo.Create(ctx, entity.ReceptionParams{PVZID: pvzID.String()})
+	require.NoError(t, err)
+	draft, err := receptionRepo.Create(ctx, entity.ReceptionParams{PVZID: helperstest.CreatePVZ(t, ctx, dbPool).String(),
+		Draft: true})
+	require.NoError(t, err)
+	_, err = dbPool.Exec(ctx, `
+	UPDATE receptions
+	SET date_time = NOW() - INTERVAL '2 days',
+	    in_progress_since = CASE WHEN status = 'in_progress' THEN NOW() - INTERVAL '2 days' END
+	WHERE id = ANY($1)`, []uuid.UUID{reopened.ID, draft.ID})
+	require.NoError(t, err)
+
+	stale, err := receptionRepo.ListStale(ctx, time.Now().Add(-24*time.Hour))
+	require.NoError(t, err)
+	require.Len(t, stale, 1)
+	require.Equal(t, reopened.ID, stale[0].ID)
+	flagged, err := receptionRepo.FlagStale(ctx, reopened.ID.String(), "открыта дольше 24h")
+	require.NoError(t, err)
+	require.True(t, flagged)
+
+	require.NoError(t, receptionRepo.SetStatus(ctx, reopened.ID.String(), entity.StatusClose))
+	require.NoError(t, receptionRepo.SetStatus(ctx, reopened.ID.String(), entity.StatusInProgress))
+	require.NoError(t, receptionRepo.SetStatus(ctx, draft.ID.String(), entity.StatusInProgress))
+
+	stale, err = receptionRepo.ListStale(ctx, time.Now().Add(-24*time.Hour))
+	require.NoError(t, err)
+	require.Empty(t, stale, "reopened and just started receptions must not be stale")
+
+	var flaggedAt *time.Time
+	err = dbPool.QueryRow(ctx, `SELECT stale_flagged_at FROM receptions WHERE id = $1`, reopened.ID).
+		Scan(&flaggedAt)
+	require.NoError(t, err)
+	require.Nil(t, flaggedAt, "stale flag is cleared on reopen")
+}
+
+func TestReceptionRepoGetByID(t *testing.T) {
+	ctx := context.Background()
+
+	postgresContainer, dbCfg := helperstest.SetupPostgresContainer(t, ctx)
+	defer postgresContainer.Terminate(ctx)
+
+	dbPool := helperstest.SetupDatabaseConnection(t, ctx, dbCfg)
+	defer dbPool.Close()
+
+	helperstest.ApplyMigrations(t, dbCfg)
+
+	receptionRepo := pgxdb.NewReceptionRepo(dbPool)
+	pvzID := helperstest.CreatePVZ(t, ctx, dbPool)
+	openedBy, closedBy := uuid.New(), uuid.New()
+	delivery := entity.DeliveryInfo{Carrier: "СДЭК", WaybillNumber: "WB-7", VehiclePlate: "А123ВС777"}
+
+	created, err := receptionRepo.Create(ctx, entity.ReceptionParams{
+		PVZID:    pvzID.String(),
+		Delivery: delivery,
+		OpenedBy: openedBy,
+	})
+	require.NoError(t, err)
+	require.NoError(t, receptionRepo.Close(ctx, created.ID.String(), closedBy))
+
+	reception, err := receptionRepo.GetByID(ctx, created.ID.String())
+	require.NoError(t, err)
+	require.Equal(t, entity.StatusClose, reception.Status)
+	require.Equal(t, pvzID, reception.PVZID)
+	require.Equal(t, delivery, reception.Delivery)
+	require.Equal(t, openedBy, reception.OpenedBy)
+	require.Equal(t, closedBy, reception.ClosedBy)
+	require.NotNil(t, reception.ClosedAt)
+
+	_, err = receptionRepo.GetByID(ctx, uuid.New().String())
+	require.ErrorIs(t, err, repoerr.ErrNoRows)
+}
+
+func TestReceptionRepoAct(t *testing.T) {
+	ctx := context.Background()
+
+	postgresContainer, dbCfg := helperstest.SetupPostgresContainer(t, ctx)
+	defer postgresContainer.Terminate(ctx)
+
+	dbPool := helperstest.SetupDatabaseConnection(t, ctx, dbCfg)
+	defer dbPool.Close()
+
+	helperstest.ApplyMigrations(t, dbCfg)
+
+	receptionRepo := pgxdb.NewReceptionRepo(dbPool)
+	receptionID := helperstest.CreateAndCloseReception(t, ctx, dbPool, helperstest.CreatePVZ(t, ctx, dbPool))
+
+	_, err := receptionRepo.GetAct(ctx, receptionID.String())
+	require.ErrorIs(t, err, repoerr.ErrNoRows)
+
+	// Порядок ключей и пробелы сохраняются: хэш проверяется по исходным байтам.
+	content := []byte(`{"receptionId": "x",  "counts":{"обувь":1}}`)
+	first := entity.ActSnapshot{ReceptionID: receptionID, Content: content, ContentHash: strings.Repeat("a", 64)}
+	require.NoError(t, receptionRepo.SaveAct(ctx, first))
+
+	saved, err := receptionRepo.GetAct(ctx, receptionID.String())
+	require.NoError(t, err)
+	require.Equal(t, content, saved.Content)
+	require.Equal(t, first.ContentHash, saved.ContentHash)
+	require.Equal(t, 1, saved.Version)
+	require.False(t, saved.CreatedAt.IsZero())
+
+	second := entity.ActSnapshot{ReceptionID: receptionID, Content: []byte(`{}`), ContentHash: strings.Repeat("b", 64)}
+	require.NoError(t, receptionRepo.SaveAct(ctx, second))
+	saved, err = receptionRepo.GetAct(ctx, receptionID.String())
+	require.NoError(t, err)
+	require.Equal(t, second.Content, saved.Content)
+	require.Equal(t, second.ContentHash, saved.ContentHash)
+	require.Equal(t, 2, saved.Version)
+
+	var firstHash string
+	err = dbPool.QueryRow(ctx, `SELECT content_hash FROM acceptance_acts WHERE reception_id = $1 AND version = 1`,
+		receptionID).Scan(&firstHash)
+	require.NoError(t, err)
+	require.Equal(t, first.ContentHash, firstHash, "earlier act version is kept")
+}
@@ -0,0 +1,124 @@
+package pgxdb
+
+import (
+	"context"
+	"errors"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/entity"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/repo/repoerr"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"log/slog"
+)
+
+const storageCellTypeFK = "storage_cells_product_type_fkey"
+
+// listCellsQuery возвращает ячейки ПВЗ с числом хранящихся в них товаров.
+const listCellsQuery = `
+	SELECT c.id, c.pvz_id, c.shelf, c.code, COALESCE(c.product_type, ''), c.capacity, c.created_at,
+	       (SELECT COUNT(*)
+	        FROM products p
+	        WHERE p.cell_id = c.id AND p.status = 'stored' AND p.deleted_at IS NULL)
+	FROM storage_cells c
+	WHERE c.pvz_id = $1
+	ORDER BY c.shelf, c.code
+`
+
+type StorageCellRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewStorageCellRepo(db *pgxpool.Pool) *StorageCellRepo {
+	return &StorageCellRepo{db: db}
+}
+
+func (r *StorageCellRepo) Create(ctx context.Context, cell entity.StorageCell) (*entity.StorageCell, error) {
+	log := slog.With("layer", "StorageCellRepo", "operation", "Create", "pvzID", cell.PVZID.String(),
+		"code", cell.Code)
+	log.Debug("starting storage cell creation")
+
+	query := `
+	INSERT INTO storage_cells (pvz_id, shelf, code, product_type, capacity)
+	VALUES ($1, $2, $3, NULLIF($4, ''), $5)
+	RETURNING id, created_at
+`
+	err := conn(ctx, r.db).QueryRow(ctx, query, cell.PVZID, cell.Shelf, cell.Code, cell.ProductType, cell.Capacity).
+		Scan(&cell.ID, &cell.CreatedAt)
+	if err != nil {
+		var pgxError *pgconn.PgError
+		if errors.As(err, &pgxError) {
+			switch {
+			case pgxError.Code == "23505":
+				log.Warn("storage cell with this code already exists")
+				return nil, repoerr.ErrDuplicateEntry
+			case pgxError.Code == "23503" && pgxError.ConstraintName == storageCellTypeFK:
+				log.Warn("product type not found", "type", cell.ProductType)
+				return nil, repoerr.ErrNotFound
+			}
+		}
+		log.Error("failed to create storage cell", "error", err)
+		return nil, err
+	}
+
+	log.Info("storage cell created successfully", "cellID", cell.ID.String())
+	return &cell, nil
+}
+
+func (r *StorageCellRepo) List(ctx context.Context, pvzID string) ([]entity.StorageCell, error) {
+	log := slog.With("layer", "StorageCellRepo", "operation", "List", "pvzID", pvzID)
+	log.Debug("listing storage cells")
+
+	cells, err := r.list(ctx, pvzID)
+	if err != nil {
+		log.Error("failed to list storage cells", "error", err)
+		return nil, err
+	}
+
+	log.Debug("storage cells listed", "count", len(cells))
+	return cells, nil
+}
+
+// LockByPVZ блокирует ячейки ПВЗ до конца транзакции и возвращает их занятость. Занятость
+// читается уже после блокировки, поэтому параллельные размещения в ПВЗ её не превысят.
+func (r *StorageCellRepo) LockByPVZ(ctx context.Context, pvzID string) ([]entity.StorageCell, error) {
+	log := slog.With("layer", "StorageCellRepo", "operation", "LockByPVZ", "pvzID", pvzID)
+	log.Debug("locking storage cells")
+
+	query := `
+	SELECT id
+	FROM storage_cells
+	WHERE pvz_id = $1
+	ORDER BY id
+	FOR UPDATE
+`
+	rows, err := conn(ctx, r.db).Query(ctx, query, pvzID)
+	if err != nil {
+		log.Error("failed to lock storage cells", "error", err)
+		return nil, err
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		log.Error("failed to lock storage cells", "error", err)
+		return nil, err
+	}
+
+	cells, err := r.list(ctx, pvzID)
+	if err != nil {
+		log.Error("failed to list storage cells", "error", err)
+		return nil, err
+	}
+	return cells, nil
+}
+
+func (r *StorageCellRepo) list(ctx context.Context, pvzID string) ([]entity.StorageCell, error) {
+	rows, err := conn(ctx, r.db).Query(ctx, listCellsQuery, pvzID)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (entity.StorageCell, error) {
+		var cell entity.StorageCell
+		err := row.Scan(&cell.ID, &cell.PVZID, &cell.Shelf, &cell.Code, &cell.ProductType, &cell.Capacity,
+			&cell.CreatedAt, &cell.Occupied)
+		return cell, err
+	})
+}
@@ -0,0 +1,82 @@
+package pgxdb_test
+
+import (
+	"context"
+	"github.com/GlebMoskalev/go-pickup-point-api/integration/helperstest"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/entity"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/repo/pgxdb"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/repo/repoerr"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestStorageCellRepo(t *testing.T) {
+	ctx := context.Background()
+
+	postgresContainer, dbCfg := helperstest.SetupPostgresContainer(t, ctx)
+	defer postgresContainer.Terminate(ctx)
+
+	dbPool := helperstest.SetupDatabaseConnection(t, ctx, dbCfg)
+	defer dbPool.Close()
+
+	helperstest.ApplyMigrations(t, dbCfg)
+
+	cellRepo := pgxdb.NewStorageCellRepo(dbPool)
+	productRepo := pgxdb.NewProductRepo(dbPool)
+	txManager := pgxdb.NewTxManager(dbPool)
+	pvzID := helperstest.CreatePVZ(t, ctx, dbPool)
+	receptionID := helperstest.CreateReception(t, ctx, dbPool, pvzID)
+
+	shoes, err := cellRepo.Create(ctx, entity.StorageCell{PVZID: pvzID, Shelf: "A", Code: "A-01",
+		ProductType: entity.ProductTypeShoes, Capacity: 2})
+	require.NoError(t, err)
+	common, err := cellRepo.Create(ctx, entity.StorageCell{PVZID: pvzID, Shelf: "B", Code: "B-01", Capacity: 10})
+	require.NoError(t, err)
+
+	_, err = cellRepo.Create(ctx, entity.StorageCell{PVZID: pvzID, Shelf: "A", Code: "A-01", Capacity: 1})
+	require.ErrorIs(t, err, repoerr.ErrDuplicateEntry)
+	_, err = cellRepo.Create(ctx, entity.StorageCell{PVZID: pvzID, Shelf: "A", Code: "A-02",
+		ProductType: "мебель", Capacity: 1})
+	require.ErrorIs(t, err, repoerr.ErrNotFound)
+
+	product, err := productRepo.Create(ctx, entity.Product{ReceptionID: receptionID, Type: entity.ProductTypeShoes,
+		CellID: shoes.ID})
+	require.NoError(t, err)
+
+	cells, err := cellRepo.List(ctx, pvzID.String())
+	require.NoError(t, err)
+	require.Len(t, cells, 2)
+	require.Equal(t, "A-01", cells[0].Code, "cells are ordered by shelf and code")
+	require.Equal(t, 1, cells[0].Occupied)
+	require.Equal(t, 0, cells[1].Occupied)
+
+	require.NoError(t, productRepo.SetCell(ctx, product.ID.String(), common.ID.String()))
+	found, err := productRepo.GetByPickupCode(ctx, pvzID.String(), product.PickupCode)
+	require.NoError(t, err)
+	require.Equal(t, common.ID, found.CellID)
+	require.Equal(t, "B", found.CellShelf)
+	require.Equal(t, "B-01", found.CellCode)
+
+	err = txManager.WithinTx(ctx, func(ctx context.Context) error {
+		locked, err := cellRepo.LockByPVZ(ctx, pvzID.String())
+		require.NoError(t, err)
+		require.Len(t, locked, 2)
+		require.Equal(t, 1, locked[1].Occupied)
+		return nil
+	})
+	require.NoError(t, err)
+
+	_, err = productRepo.Issue(ctx, product.ID.String(), uuid.New())
+	require.NoError(t, err)
+	cells, err = cellRepo.List(ctx, pvzID.String())
+	require.NoError(t, err)
+	require.Equal(t, 0, cells[1].Occupied, "issued products free their cell")
+	require.ErrorIs(t, productRepo.SetCell(ctx, product.ID.String(), shoes.ID.String()), repoerr.ErrNoRows)
+
+	otherPVZID := helperstest.CreatePVZ(t, ctx, dbPool)
+	otherReceptionID := helperstest.CreateReception(t, ctx, dbPool, otherPVZID)
+	_, err = productRepo.Create(ctx, entity.Product{ReceptionID: otherReceptionID, Type: entity.ProductTypeShoes,
+		CellID: shoes.ID})
+	require.Error(t, err, "product cannot be placed into a cell of another pvz")
+}
@@ -0,0 +1,87 @@
+package pgxdb_test
+
+import (
+	"context"
+	"github.com/GlebMoskalev/go-pickup-point-api/integration/helperstest"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/entity"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/repo/pgxdb"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/repo/repoerr"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestProductRepoStorageExpiry(t *testing.T) {
+	ctx := context.Background()
+
+	postgresContainer, dbCfg := helperstest.SetupPostgresContainer(t, ctx)
+	defer postgresContainer.Terminate(ctx)
+
+	dbPool := helperstest.SetupDatabaseConnection(t, ctx, dbCfg)
+	defer dbPool.Close()
+
+	helperstest.ApplyMigrations(t, dbCfg)
+
+	productRepo := pgxdb.NewProductRepo(dbPool)
+	typeRepo := pgxdb.NewProductTypeRepo(dbPool)
+	pvzRepo := pgxdb.NewPVZRepo(dbPool)
+	pvzID := helperstest.CreatePVZ(t, ctx, dbPool)
+	receptionID := helperstest.CreateAndCloseReception(t, ctx, dbPool, pvzID)
+	userID := uuid.New()
+
+	old, err := productRepo.Create(ctx, entity.Product{ReceptionID: receptionID, Type: entity.ProductTypeShoes,
+		Barcode: "4600000000028"})
+	require.NoError(t, err)
+	fresh, err := productRepo.Create(ctx, entity.Product{ReceptionID: receptionID, Type: entity.ProductTypeShoes,
+		Barcode: "4600000000035"})
+	require.NoError(t, err)
+
+	_, err = dbPool.Exec(ctx, `UPDATE products SET stored_at = NOW() - INTERVAL '10 days' WHERE id = $1`, old.ID)
+	require.NoError(t, err)
+
+	require.NoError(t, pvzRepo.SetSettings(ctx, entity.PVZSettings{PVZID: pvzID,
+		BlindCountPolicy: entity.BlindCountPolicyReject, StorageDays: 30}))
+	flagged, err := productRepo.FlagOverdue(ctx, 7)
+	require.NoError(t, err)
+	assert.Equal(t, 0, flagged, "pvz storage period overrides the default")
+
+	productType, err := typeRepo.SetStorageDays(ctx, entity.ProductTypeShoes, 5)
+	require.NoError(t, err)
+	assert.Equal(t, 5, productType.StorageDays)
+
+	flagged, err = productRepo.FlagOverdue(ctx, 7)
+	require.NoError(t, err)
+	assert.Equal(t, 1, flagged, "product type storage period overrides the pvz one")
+
+	flagged, err = productRepo.FlagOverdue(ctx, 7)
+	require.NoError(t, err)
+	assert.Equal(t, 0, flagged, "already flagged products are skipped")
+
+	overdue, err := productRepo.ListOverdue(ctx, pvzID.String())
+	require.NoError(t, err)
+	require.Len(t, overdue, 1)
+	assert.Equal(t, old.ID, overdue[0].ID)
+	assert.NotNil(t, overdue[0].OverdueAt)
+
+	_, err = productRepo.ReturnToSender(ctx, fresh.ID.String(), userID)
+	assert.ErrorIs(t, err, repoerr.ErrNoRows, "product within storage period cannot be returned")
+
+	_, err = productRepo.ReturnToSender(ctx, old.ID.String(), userID)
+	require.NoError(t, err)
+	_, err = productRepo.ReturnToSender(ctx, old.ID.String(), userID)
+	assert.ErrorIs(t, err, repoerr.ErrNoRows)
+
+	returned, err := productRepo.GetByID(ctx, old.ID.String())
+	require.NoError(t, err)
+	assert.Equal(t, entity.ProductStatusReturnedToSender, returned.Status)
+	assert.Equal(t, userID, returned.ReturnedBy)
+	assert.NotNil(t, returned.ReturnedAt)
+
+	overdue, err = productRepo.ListOverdue(ctx, pvzID.String())
+	require.NoError(t, err)
+	assert.Empty(t, overdue)
+
+	_, err = typeRepo.SetStorageDays(ctx, "unknown", 5)
+	assert.ErrorIs(t, err, repoerr.ErrNoRows)
+}
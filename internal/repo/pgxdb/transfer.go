@@ -0,0 +1,246 @@
+package pgxdb
+
+import (
+	"context"
+	"errors"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/entity"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/repo/repoerr"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"log/slog"
+)
+
+type TransferRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewTransferRepo(db *pgxpool.Pool) *TransferRepo {
+	return &TransferRepo{db: db}
+}
+
+// Create сохраняет заказ на перемещение вместе с товарами.
+func (r *TransferRepo) Create(ctx context.Context, transfer entity.Transfer) (*entity.Transfer, error) {
+	log := slog.With("layer", "TransferRepo", "operation", "Create", "fromPVZID", transfer.FromPVZID.String(),
+		"toPVZID", transfer.ToPVZID.String(), "items", len(transfer.Items))
+	log.Debug("starting transfer creation")
+
+	tx, err := conn(ctx, r.db).Begin(ctx)
+	if err != nil {
+		log.Error("failed to begin transaction", "error", err)
+		return nil, err
+	}
+
+	defer func() {
+		if err != nil {
+			if rollbackErr := tx.Rollback(ctx); rollbackErr != nil {
+				log.Error("failed to rollback transaction", "error", rollbackErr)
+			}
+		}
+	}()
+
+	query := `
+	INSERT INTO transfers (from_pvz_id, to_pvz_id, created_by)
+	VALUES ($1, $2, $3)
+	RETURNING id, status, created_at
+`
+	err = tx.QueryRow(ctx, query, transfer.FromPVZID, transfer.ToPVZID, nullUUID(transfer.CreatedBy)).
+		Scan(&transfer.ID, &transfer.Status, &transfer.CreatedAt)
+	if err != nil {
+		log.Error("failed to create transfer", "error", err)
+		return nil, err
+	}
+
+	productIDs := make([]uuid.UUID, len(transfer.Items))
+	for i, item := range transfer.Items {
+		productIDs[i] = item.ProductID
+	}
+	_, err = tx.Exec(ctx, `
+	INSERT INTO transfer_items (transfer_id, product_id)
+	SELECT $1, unnest($2::uuid[])
+`, transfer.ID, productIDs)
+	if err != nil {
+		log.Error("failed to save transfer items", "error", err)
+		return nil, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		log.Error("failed to commit transaction", "error", err)
+		return nil, err
+	}
+
+	log.Info("transfer created successfully", "transferID", transfer.ID.String())
+	return &transfer, nil
+}
+
+// GetByID возвращает перемещение с товарами.
+func (r *TransferRepo) GetByID(ctx context.Context, transferID string) (*entity.Transfer, error) {
+	log := slog.With("layer", "TransferRepo", "operation", "GetByID", "transferID", transferID)
+	log.Debug("starting get transfer")
+
+	return r.get(ctx, log, transferID, "")
+}
+
+// LockByID блокирует перемещение до конца транзакции и возвращает его с товарами.
+func (r *TransferRepo) LockByID(ctx context.Context, transferID string) (*entity.Transfer, error) {
+	log := slog.With("layer", "TransferRepo", "operation", "LockByID", "transferID", transferID)
+	log.Debug("locking transfer")
+
+	return r.get(ctx, log, transferID, "FOR UPDATE")
+}
+
+func (r *TransferRepo) get(ctx context.Context, log *slog.Logger, transferID, lock string) (*entity.Transfer, error) {
+	query := `
+	SELECT id, from_pvz_id, to_pvz_id, status, reception_id, created_by, created_at,
+	       dispatched_by, dispatched_at, received_at
+	FROM transfers
+	WHERE id = $1
+	` + lock
+	var (
+		transfer     entity.Transfer
+		receptionID  pgtype.UUID
+		createdBy    pgtype.UUID
+		dispatchedBy pgtype.UUID
+		dispatchedAt pgtype.Timestamptz
+		receivedAt   pgtype.Timestamptz
+	)
+	err := conn(ctx, r.db).QueryRow(ctx, query, transferID).Scan(&transfer.ID, &transfer.FromPVZID,
+		&transfer.ToPVZID, &transfer.Status, &receptionID, &createdBy, &transfer.CreatedAt,
+		&dispatchedBy, &dispatchedAt, &receivedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			log.Warn("transfer not found")
+			return nil, repoerr.ErrNoRows
+		}
+		log.Error("failed to get transfer", "error", err)
+		return nil, err
+	}
+	transfer.ReceptionID = uuidOrNil(receptionID)
+	transfer.CreatedBy = uuidOrNil(createdBy)
+	transfer.DispatchedBy = uuidOrNil(dispatchedBy)
+	transfer.DispatchedAt = timeOrNil(dispatchedAt)
+	transfer.ReceivedAt = timeOrNil(receivedAt)
+
+	rows, err := conn(ctx, r.db).Query(ctx, `
+	SELECT i.product_id, p.type, COALESCE(p.barcode, ''), i.received_by, i.received_at
+	FROM transfer_items i
+	INNER JOIN products p ON p.id = i.product_id
+	WHERE i.transfer_id = $1
+	ORDER BY p.date_time, p.id
+`, transferID)
+	if err != nil {
+		log.Error("failed to list transfer items", "error", err)
+		return nil, err
+	}
+	transfer.Items, err = pgx.CollectRows(rows, func(row pgx.CollectableRow) (entity.TransferItem, error) {
+		var (
+			item       entity.TransferItem
+			receivedBy pgtype.UUID
+			receivedAt pgtype.Timestamptz
+		)
+		err := row.Scan(&item.ProductID, &item.Type, &item.Barcode, &receivedBy, &receivedAt)
+		item.ReceivedBy = uuidOrNil(receivedBy)
+		item.ReceivedAt = timeOrNil(receivedAt)
+		return item, err
+	})
+	if err != nil {
+		log.Error("failed to scan transfer items", "error", err)
+		return nil, err
+	}
+	return &transfer, nil
+}
+
+// ListActiveProductIDs возвращает те из товаров, которые уже входят в незавершённые перемещения.
+func (r *TransferRepo) ListActiveProductIDs(ctx context.Context, productIDs []uuid.UUID) ([]uuid.UUID, error) {
+	log := slog.With("layer", "TransferRepo", "operation", "ListActiveProductIDs", "count", len(productIDs))
+	log.Debug("listing products in active transfers")
+
+	query := `
+	SELECT DISTINCT i.product_id
+	FROM transfer_items i
+	INNER JOIN transfers t ON t.id = i.transfer_id
+	WHERE i.product_id = ANY($1) AND t.status IN ('created', 'in_transit')
+`
+	rows, err := conn(ctx, r.db).Query(ctx, query, productIDs)
+	if err != nil {
+		log.Error("failed to list products in active transfers", "error", err)
+		return nil, err
+	}
+	ids, err := pgx.CollectRows(rows, pgx.RowTo[uuid.UUID])
+	if err != nil {
+		log.Error("failed to scan product ids", "error", err)
+		return nil, err
+	}
+	return ids, nil
+}
+
+// SetDispatched отмечает перемещение отправленным и связывает его с приёмкой в ПВЗ назначения.
+func (r *TransferRepo) SetDispatched(ctx context.Context, transferID, receptionID, userID uuid.UUID) error {
+	log := slog.With("layer", "TransferRepo", "operation", "SetDispatched", "transferID", transferID.String(),
+		"receptionID", receptionID.String())
+	log.Debug("starting transfer dispatch")
+
+	query := `
+	UPDATE transfers
+	SET status = 'in_transit', reception_id = $2, dispatched_by = $3, dispatched_at = NOW()
+	WHERE id = $1 AND status = 'created'
+`
+	tag, err := conn(ctx, r.db).Exec(ctx, query, transferID, receptionID, nullUUID(userID))
+	if err != nil {
+		log.Error("failed to dispatch transfer", "error", err)
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		log.Warn("created transfer not found")
+		return repoerr.ErrNoRows
+	}
+	return nil
+}
+
+// SetStatus меняет статус перемещения. Для статуса received сохраняется время приёмки.
+func (r *TransferRepo) SetStatus(ctx context.Context, transferID uuid.UUID, status string) error {
+	log := slog.With("layer", "TransferRepo", "operation", "SetStatus", "transferID", transferID.String(),
+		"status", status)
+	log.Debug("starting transfer status update")
+
+	query := `
+	UPDATE transfers
+	SET status = $2::transfer_statuses_enum,
+	    received_at = CASE WHEN $2::transfer_statuses_enum = 'received' THEN NOW() ELSE received_at END
+	WHERE id = $1
+`
+	tag, err := conn(ctx, r.db).Exec(ctx, query, transferID, status)
+	if err != nil {
+		log.Error("failed to update transfer status", "error", err)
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		log.Warn("transfer not found")
+		return repoerr.ErrNoRows
+	}
+	return nil
+}
+
+// ReceiveItem отмечает товар перемещения принятым. Уже принятый товар не найдётся.
+func (r *TransferRepo) ReceiveItem(ctx context.Context, transferID, productID, userID uuid.UUID) error {
+	log := slog.With("layer", "TransferRepo", "operation", "ReceiveItem", "transferID", transferID.String(),
+		"productID", productID.String())
+	log.Debug("starting transfer item receipt")
+
+	query := `
+	UPDATE transfer_items
+	SET received_by = $3, received_at = NOW()
+	WHERE transfer_id = $1 AND product_id = $2 AND received_at IS NULL
+`
+	tag, err := conn(ctx, r.db).Exec(ctx, query, transferID, productID, nullUUID(userID))
+	if err != nil {
+		log.Error("failed to receive transfer item", "error", err)
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		log.Warn("pending transfer item not found")
+		return repoerr.ErrNoRows
+	}
+	return nil
+}
@@ -0,0 +1,145 @@
+package pgxdb_test
+
+import (
+	"context"
+	"github.com/GlebMoskalev/go-pickup-point-api/integration/helperstest"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/entity"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/repo/pgxdb"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/repo/repoerr"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestTransferRepo(t *testing.T) {
+	ctx := context.Background()
+
+	postgresContainer, dbCfg := helperstest.SetupPostgresContainer(t, ctx)
+	defer postgresContainer.Terminate(ctx)
+
+	dbPool := helperstest.SetupDatabaseConnection(t, ctx, dbCfg)
+	defer dbPool.Close()
+
+	helperstest.ApplyMigrations(t, dbCfg)
+
+	transferRepo := pgxdb.NewTransferRepo(dbPool)
+	productRepo := pgxdb.NewProductRepo(dbPool)
+	receptionRepo := pgxdb.NewReceptionRepo(dbPool)
+	fromPVZID := helperstest.CreatePVZ(t, ctx, dbPool)
+	toPVZID := helperstest.CreatePVZ(t, ctx, dbPool)
+	fromReceptionID := helperstest.CreateReception(t, ctx, dbPool, fromPVZID)
+	userID := uuid.New()
+
+	product, err := productRepo.Create(ctx, entity.Product{ReceptionID: fromReceptionID,
+		Type: entity.ProductTypeShoes, Barcode: "4600000000011"})
+	require.NoError(t, err)
+
+	sourceProducts, err := productRepo.ListByReception(ctx, fromReceptionID.String())
+	require.NoError(t, err)
+	sourceCounts, err := productRepo.CountByType(ctx, fromReceptionID.String())
+	require.NoError(t, err)
+
+	transfer, err := transferRepo.Create(ctx, entity.Transfer{
+		FromPVZID: fromPVZID,
+		ToPVZID:   toPVZID,
+		Items:     []entity.TransferItem{{ProductID: product.ID}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, entity.TransferStatusCreated, transfer.Status)
+
+	active, err := transferRepo.ListActiveProductIDs(ctx, []uuid.UUID{product.ID, uuid.New()})
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{product.ID}, active)
+
+	locked, err := productRepo.LockByIDs(ctx, []uuid.UUID{product.ID})
+	require.NoError(t, err)
+	require.Len(t, locked, 1)
+	assert.Equal(t, fromPVZID, locked[0].PVZID)
+
+	toReception, err := receptionRepo.Create(ctx, entity.ReceptionParams{PVZID: toPVZID.String(),
+		Kind: entity.ReceptionKindTransfer})
+	require.NoError(t, err)
+
+	require.NoError(t, productRepo.Dispatch(ctx, []uuid.UUID{product.ID}, transfer.ID, userID))
+	err = productRepo.Dispatch(ctx, []uuid.UUID{product.ID}, transfer.ID, userID)
+	assert.ErrorIs(t, err, repoerr.ErrNoRows, "in-transit product cannot be dispatched again")
+	require.NoError(t, transferRepo.SetDispatched(ctx, transfer.ID, toReception.ID, userID))
+
+	pending, err := receptionRepo.CountPendingTransferItems(ctx, toReception.ID.String())
+	require.NoError(t, err)
+	assert.Equal(t, 1, pending)
+
+	inTransit, err := productRepo.GetByID(ctx, product.ID.String())
+	require.NoError(t, err)
+	assert.Equal(t, entity.ProductStatusInTransit, inTransit.Status)
+
+	received, err := productRepo.Receive(ctx, entity.Product{ID: product.ID, Type: product.Type,
+		ReceptionID: toReception.ID}, transfer.ID, userID)
+	require.NoError(t, err)
+	assert.NotEqual(t, product.ID, received.ID)
+	assert.Equal(t, product.ID, received.SourceProductID)
+	assert.Equal(t, product.Barcode, received.Barcode)
+	assert.Equal(t, entity.ProductStatusStored, received.Status)
+	assert.Equal(t, toPVZID, received.PVZID)
+	assert.Equal(t, toReception.ID, received.ReceptionID)
+	assert.Equal(t, 1, received.OrderNumber)
+	assert.NotEmpty(t, received.PickupCode)
+
+	source, err := productRepo.GetByID(ctx, product.ID.String())
+	require.NoError(t, err)
+	assert.Equal(t, entity.ProductStatusTransferred, source.Status)
+	assert.Equal(t, fromReceptionID, source.ReceptionID)
+	assert.Equal(t, fromPVZID, source.PVZID)
+	assert.Equal(t, product.OrderNumber, source.OrderNumber)
+
+	afterProducts, err := productRepo.ListByReception(ctx, fromReceptionID.String())
+	require.NoError(t, err)
+	require.Len(t, afterProducts, len(sourceProducts))
+	assert.Equal(t, sourceProducts[0].ID, afterProducts[0].ID)
+	assert.Equal(t, sourceProducts[0].OrderNumber, afterProducts[0].OrderNumber)
+	afterCounts, err := productRepo.CountByType(ctx, fromReceptionID.String())
+	require.NoError(t, err)
+	assert.Equal(t, sourceCounts, afterCounts, "transfer does not change the source reception")
+
+	stored, err := productRepo.GetByBarcode(ctx, product.Barcode)
+	require.NoError(t, err)
+	assert.Equal(t, received.ID, stored.ID)
+
+	require.NoError(t, transferRepo.ReceiveItem(ctx, transfer.ID, product.ID, userID))
+	pending, err = receptionRepo.CountPendingTransferItems(ctx, toReception.ID.String())
+	require.NoError(t, err)
+	assert.Zero(t, pending)
+	err = transferRepo.ReceiveItem(ctx, transfer.ID, product.ID, userID)
+	assert.ErrorIs(t, err, repoerr.ErrNoRows)
+	require.NoError(t, transferRepo.SetStatus(ctx, transfer.ID, entity.TransferStatusReceived))
+
+	found, err := transferRepo.GetByID(ctx, transfer.ID.String())
+	require.NoError(t, err)
+	assert.Equal(t, entity.TransferStatusReceived, found.Status)
+	assert.Equal(t, toReception.ID, found.ReceptionID)
+	assert.NotNil(t, found.ReceivedAt)
+	require.Len(t, found.Items, 1)
+	assert.Equal(t, userID, found.Items[0].ReceivedBy)
+
+	active, err = transferRepo.ListActiveProductIDs(ctx, []uuid.UUID{product.ID})
+	require.NoError(t, err)
+	assert.Empty(t, active)
+
+	locations, err := productRepo.ListLocations(ctx, product.ID.String())
+	require.NoError(t, err)
+	require.Len(t, locations, 3)
+	assert.Equal(t, fromPVZID, locations[0].PVZID)
+	assert.Equal(t, transfer.ID, locations[1].TransferID)
+	assert.Equal(t, uuid.Nil, locations[1].PVZID)
+	assert.Equal(t, toPVZID, locations[2].PVZID)
+	assert.Equal(t, transfer.ID, locations[2].TransferID)
+
+	locations, err = productRepo.ListLocations(ctx, received.ID.String())
+	require.NoError(t, err)
+	require.Len(t, locations, 1)
+	assert.Equal(t, toReception.ID, locations[0].ReceptionID)
+
+	_, err = transferRepo.GetByID(ctx, uuid.NewString())
+	assert.ErrorIs(t, err, repoerr.ErrNoRows)
+}
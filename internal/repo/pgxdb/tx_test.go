@@ -0,0 +1,112 @@
+package pgxdb_test
+
+import (
+	"context"
+	"errors"
+	"github.com/GlebMoskalev/go-pickup-point-api/integration/helperstest"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/entity"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/repo/pgxdb"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/repo/repoerr"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestTxManagerWithinTx(t *testing.T) {
+	ctx := context.Background()
+
+	postgresContainer, dbCfg := helperstest.SetupPostgresContainer(t, ctx)
+	defer postgresContainer.Terminate(ctx)
+
+	dbPool := helperstest.SetupDatabaseConnection(t, ctx, dbCfg)
+	defer dbPool.Close()
+
+	helperstest.ApplyMigrations(t, dbCfg)
+
+	txManager := pgxdb.NewTxManager(dbPool)
+	receptionRepo := pgxdb.NewReceptionRepo(dbPool)
+	productRepo := pgxdb.NewProductRepo(dbPool)
+
+	countProducts := func(receptionID string) int {
+		var count int
+		err := dbPool.QueryRow(ctx, `SELECT COUNT(*) FROM products WHERE reception_id = $1`, receptionID).
+			Scan(&count)
+		require.NoError(t, err)
+		return count
+	}
+
+	t.Run("commit", func(t *testing.T) {
+		pvzID := helperstest.CreatePVZ(t, ctx, dbPool)
+		receptionID := helperstest.CreateReception(t, ctx, dbPool, pvzID)
+
+		err := txManager.WithinTx(ctx, func(ctx context.Context) error {
+			reception, err := receptionRepo.LockLastOpenReception(ctx, pvzID.String())
+			if err != nil {
+				return err
+			}
+			_, err = productRepo.Create(ctx, entity.Product{ReceptionID: reception.ID, Type: entity.ProductTypeShoes})
+			return err
+		})
+		require.NoError(t, err)
+		require.Equal(t, 1, countProducts(receptionID.String()))
+	})
+
+	t.Run("rollback on error", func(t *testing.T) {
+		pvzID := helperstest.CreatePVZ(t, ctx, dbPool)
+		receptionID := helperstest.CreateReception(t, ctx, dbPool, pvzID)
+		errAbort := errors.New("abort")
+
+		err := txManager.WithinTx(ctx, func(ctx context.Context) error {
+			_, err := productRepo.Create(ctx, entity.Product{ReceptionID: receptionID, Type: entity.ProductTypeShoes})
+			require.NoError(t, err)
+			return errAbort
+		})
+		require.ErrorIs(t, err, errAbort)
+		require.Equal(t, 0, countProducts(receptionID.String()))
+	})
+
+	t.Run("lock serializes close and product add", func(t *testing.T) {
+		pvzID := helperstest.CreatePVZ(t, ctx, dbPool)
+		receptionID := helperstest.CreateReception(t, ctx, dbPool, pvzID)
+
+		locked := make(chan struct{})
+		release := make(chan struct{})
+		closeDone := make(chan error, 1)
+		go func() {
+			closeDone <- txManager.WithinTx(ctx, func(ctx context.Context) error {
+				reception, err := receptionRepo.LockLastOpenReception(ctx, pvzID.String())
+				if err != nil {
+					return err
+				}
+				close(locked)
+				<-release
+				return receptionRepo.Close(ctx, reception.ID.String(), uuid.Nil)
+			})
+		}()
+		<-locked
+
+		addDone := make(chan error, 1)
+		go func() {
+			addDone <- txManager.WithinTx(ctx, func(ctx context.Context) error {
+				reception, err := receptionRepo.LockLastOpenReception(ctx, pvzID.String())
+				if err != nil {
+					return err
+				}
+				_, err = productRepo.Create(ctx, entity.Product{ReceptionID: reception.ID, Type: entity.ProductTypeShoes})
+				return err
+			})
+		}()
+
+		select {
+		case err := <-addDone:
+			t.Fatalf("product add should wait for the reception lock, got %v", err)
+		case <-time.After(200 * time.Millisecond):
+		}
+
+		close(release)
+		require.NoError(t, <-closeDone)
+		require.ErrorIs(t, <-addDone, repoerr.ErrNoRows)
+		require.Equal(t, 0, countProducts(receptionID.String()))
+	})
+}
@@ -25,7 +25,7 @@ func (r *UserRepo) Create(ctx context.Context, user entity.User) (*entity.User,
 	log := slog.With("layer", "UserRepo", "operation", "Create", "email", privacy.MaskEmail(user.Email))
 	log.Debug("starting user creation")
 
-	tx, err := r.db.Begin(ctx)
+	tx, err := conn(ctx, r.db).Begin(ctx)
 	if err != nil {
 		log.Error("failed to begin transaction", "error", err)
 		return nil, err
@@ -78,7 +78,7 @@ func (r *UserRepo) GetByEmail(ctx context.Context, email string) (*entity.User,
 	FROM users
 	WHERE email = $1
 `
-	row := r.db.QueryRow(ctx, query, email)
+	row := conn(ctx, r.db).QueryRow(ctx, query, email)
 
 	var user entity.User
 	if err := row.Scan(&user.ID, &user.PasswordHash, &user.Role); err != nil {
@@ -104,7 +104,7 @@ func (r *UserRepo) GetById(ctx context.Context, id uuid.UUID) (*entity.User, err
 	FROM users
 	WHERE id = $1
 `
-	row := r.db.QueryRow(ctx, query, id)
+	row := conn(ctx, r.db).QueryRow(ctx, query, id)
 
 	var user entity.User
 	if err := row.Scan(&user.Email, &user.PasswordHash, &user.Role); err != nil {
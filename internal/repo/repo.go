@@ -2,10 +2,14 @@ package repo
 
 import (
 	"context"
+	"fmt"
+	"github.com/GlebMoskalev/go-pickup-point-api/config"
 	"github.com/GlebMoskalev/go-pickup-point-api/internal/entity"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/repo/blobstore"
 	"github.com/GlebMoskalev/go-pickup-point-api/internal/repo/pgxdb"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"io"
 	"time"
 )
 
@@ -18,37 +22,163 @@ type User interface {
 
 //go:generate go run github.com/vektra/mockery/v2@v2.53.3 --name=PVZ --output=./mocks
 type PVZ interface {
-	Create(ctx context.Context, city string) (*entity.PVZ, error)
+	Create(ctx context.Context, city, timezone string) (*entity.PVZ, error)
 	Exists(ctx context.Context, pvzID string) bool
-	ListWithDetails(ctx context.Context, startDate, endDate *time.Time, page, limit int) ([]entity.PVZWithDetails, error)
+	GetByID(ctx context.Context, pvzID string) (*entity.PVZ, error)
+	ListWithDetails(ctx context.Context, filter entity.PVZFilter, page, limit int) ([]entity.PVZWithDetails, error)
+	GetSchedule(ctx context.Context, pvzID string) (*entity.PVZSchedule, error)
+	SetSchedule(ctx context.Context, schedule entity.PVZSchedule) error
+	GetSettings(ctx context.Context, pvzID string) (*entity.PVZSettings, error)
+	SetSettings(ctx context.Context, settings entity.PVZSettings) error
+	CountProductsByStatus(ctx context.Context, pvzID string) (map[string]int, error)
+	SumStoredValue(ctx context.Context, pvzID string) ([]entity.ValueTotal, error)
+	ListStoredValues(ctx context.Context) ([]entity.PVZValue, error)
 }
 
 //go:generate go run github.com/vektra/mockery/v2@v2.53.3 --name=Reception --output=./mocks
 type Reception interface {
-	Create(ctx context.Context, pvzID string) (*entity.Reception, error)
-	HasOpenReception(ctx context.Context, pvzID string) (bool, error)
+	Create(ctx context.Context, params entity.ReceptionParams) (*entity.Reception, error)
+	HasOpenReception(ctx context.Context, pvzID, dockID string) (bool, error)
 	GetLastOpenReception(ctx context.Context, pvzID string) (*entity.Reception, error)
-	Close(ctx context.Context, receptionID string) error
+	LockLastOpenReception(ctx context.Context, pvzID string) (*entity.Reception, error)
+	LockOpenReceptionByDock(ctx context.Context, dockID string) (*entity.Reception, error)
+	Close(ctx context.Context, receptionID string, closedBy uuid.UUID) error
+	GetManifest(ctx context.Context, receptionID string) ([]entity.ManifestItem, error)
+	CountPendingTransferItems(ctx context.Context, receptionID string) (int, error)
+	SaveDiscrepancyReport(ctx context.Context, report entity.DiscrepancyReport) error
+	SaveBlindCount(ctx context.Context, report entity.BlindCountReport) error
+	SaveAct(ctx context.Context, snapshot entity.ActSnapshot) error
+	GetAct(ctx context.Context, receptionID string) (*entity.ActSnapshot, error)
+	GetByID(ctx context.Context, receptionID string) (*entity.Reception, error)
+	LockByID(ctx context.Context, receptionID string) (*entity.Reception, error)
+	SetStatus(ctx context.Context, receptionID, status string) error
+	AddStatusChange(ctx context.Context, change entity.StatusChange) error
+	ListStale(ctx context.Context, inProgressBefore time.Time) ([]entity.Reception, error)
+	FlagStale(ctx context.Context, receptionID, reason string) (bool, error)
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.53.3 --name=Dock --output=./mocks
+type Dock interface {
+	Create(ctx context.Context, pvzID, name string) (*entity.Dock, error)
+	List(ctx context.Context, pvzID string) ([]entity.Dock, error)
+	GetByID(ctx context.Context, dockID string) (*entity.Dock, error)
 }
 
 //go:generate go run github.com/vektra/mockery/v2@v2.53.3 --name=Product --output=./mocks
 type Product interface {
-	Create(ctx context.Context, receptionID, productType string) (*entity.Product, error)
-	DeleteLastProduct(ctx context.Context, receptionID string) error
+	Create(ctx context.Context, product entity.Product) (*entity.Product, error)
+	DeleteLastProduct(ctx context.Context, receptionID string, deletedBy uuid.UUID) error
+	CountByType(ctx context.Context, receptionID string) (map[string]int, error)
+	ListByReception(ctx context.Context, receptionID string) ([]entity.Product, error)
+	GetByBarcode(ctx context.Context, barcode string) (*entity.Product, error)
+	GetByID(ctx context.Context, productID string) (*entity.Product, error)
+	Delete(ctx context.Context, productID string, deletedBy uuid.UUID) error
+	DeleteByReception(ctx context.Context, receptionID string, deletedBy uuid.UUID) (int, error)
+	CreateBatch(ctx context.Context, products []entity.Product) ([]entity.Product, error)
+	ListByBarcodes(ctx context.Context, barcodes []string) ([]entity.Product, error)
+	ListIssuedByBarcodes(ctx context.Context, barcodes []string) ([]entity.Product, error)
+	Issue(ctx context.Context, productID string, issuedBy uuid.UUID) (time.Time, error)
+	GetByPickupCode(ctx context.Context, pvzID, code string) (*entity.Product, error)
+	SetCell(ctx context.Context, productID, cellID string) error
+	LockByIDs(ctx context.Context, productIDs []uuid.UUID) ([]entity.Product, error)
+	Dispatch(ctx context.Context, productIDs []uuid.UUID, transferID, userID uuid.UUID) error
+	Receive(ctx context.Context, product entity.Product, transferID, userID uuid.UUID) (*entity.Product, error)
+	ListLocations(ctx context.Context, productID string) ([]entity.ProductLocation, error)
+	FlagOverdue(ctx context.Context, defaultDays int) (int, error)
+	ListOverdue(ctx context.Context, pvzID string) ([]entity.Product, error)
+	ReturnToSender(ctx context.Context, productID string, returnedBy uuid.UUID) (time.Time, error)
+	MarkDamaged(ctx context.Context, productID, description string, markedBy uuid.UUID) (time.Time, error)
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.53.3 --name=ProductPhoto --output=./mocks
+type ProductPhoto interface {
+	Create(ctx context.Context, photo entity.ProductPhoto) (*entity.ProductPhoto, error)
+	List(ctx context.Context, productID string) ([]entity.ProductPhoto, error)
+	GetByID(ctx context.Context, productID, photoID string) (*entity.ProductPhoto, error)
+}
+
+// BlobStore хранит файлы по ключу. Get отсутствующего ключа возвращает repoerr.ErrNotFound.
+//
+//go:generate go run github.com/vektra/mockery/v2@v2.53.3 --name=BlobStore --output=./mocks
+type BlobStore interface {
+	Put(ctx context.Context, key string, content io.Reader, size int64, contentType string) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.53.3 --name=Transfer --output=./mocks
+type Transfer interface {
+	Create(ctx context.Context, transfer entity.Transfer) (*entity.Transfer, error)
+	GetByID(ctx context.Context, transferID string) (*entity.Transfer, error)
+	LockByID(ctx context.Context, transferID string) (*entity.Transfer, error)
+	ListActiveProductIDs(ctx context.Context, productIDs []uuid.UUID) ([]uuid.UUID, error)
+	SetDispatched(ctx context.Context, transferID, receptionID, userID uuid.UUID) error
+	SetStatus(ctx context.Context, transferID uuid.UUID, status string) error
+	ReceiveItem(ctx context.Context, transferID, productID, userID uuid.UUID) error
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.53.3 --name=StorageCell --output=./mocks
+type StorageCell interface {
+	Create(ctx context.Context, cell entity.StorageCell) (*entity.StorageCell, error)
+	List(ctx context.Context, pvzID string) ([]entity.StorageCell, error)
+	LockByPVZ(ctx context.Context, pvzID string) ([]entity.StorageCell, error)
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.53.3 --name=ProductType --output=./mocks
+type ProductType interface {
+	Create(ctx context.Context, productType entity.ProductType) (*entity.ProductType, error)
+	List(ctx context.Context) ([]entity.ProductType, error)
+	GetByName(ctx context.Context, name string) (*entity.ProductType, error)
+	SetSchema(ctx context.Context, productType entity.ProductType) (*entity.ProductType, error)
+	SetStorageDays(ctx context.Context, name string, days int) (*entity.ProductType, error)
+}
+
+// Transactor выполняет fn в одной транзакции: все вызовы репозиториев с переданным
+// в fn контекстом используют её. Вложенные вызовы присоединяются к внешней транзакции.
+//
+//go:generate go run github.com/vektra/mockery/v2@v2.53.3 --name=Transactor --output=./mocks
+type Transactor interface {
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
 }
 
 type Repositories struct {
+	Transactor
 	User
 	PVZ
 	Reception
+	Dock
 	Product
+	ProductType
+	StorageCell
+	Transfer
+	ProductPhoto
+	BlobStore
 }
 
-func NewRepositories(db *pgxpool.Pool) *Repositories {
+func NewRepositories(db *pgxpool.Pool, blobStore BlobStore) *Repositories {
 	return &Repositories{
-		User:      pgxdb.NewUserRepo(db),
-		PVZ:       pgxdb.NewPVZRepo(db),
-		Reception: pgxdb.NewReceptionRepo(db),
-		Product:   pgxdb.NewProductRepo(db),
+		Transactor:   pgxdb.NewTxManager(db),
+		User:         pgxdb.NewUserRepo(db),
+		PVZ:          pgxdb.NewPVZRepo(db),
+		Reception:    pgxdb.NewReceptionRepo(db),
+		Dock:         pgxdb.NewDockRepo(db),
+		Product:      pgxdb.NewProductRepo(db),
+		ProductType:  pgxdb.NewProductTypeRepo(db),
+		StorageCell:  pgxdb.NewStorageCellRepo(db),
+		Transfer:     pgxdb.NewTransferRepo(db),
+		ProductPhoto: pgxdb.NewProductPhotoRepo(db),
+		BlobStore:    blobStore,
+	}
+}
+
+// NewBlobStore создаёт хранилище файлов по настройке driver: local или s3.
+func NewBlobStore(cfg config.BlobStore) (BlobStore, error) {
+	switch cfg.Driver {
+	case "local":
+		return blobstore.NewLocal(cfg.Dir)
+	case "s3":
+		return blobstore.NewS3(cfg.S3)
+	default:
+		return nil, fmt.Errorf("unknown blob store driver %q", cfg.Driver)
 	}
 }
@@ -6,4 +6,5 @@ var (
 	ErrDuplicateEntry = errors.New("duplicate entry")
 	ErrNotFound       = errors.New("not found")
 	ErrNoRows         = errors.New("no rows")
+	ErrMultipleRows   = errors.New("multiple rows")
 )
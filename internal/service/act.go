@@ -0,0 +1,285 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/entity"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/repo/repoerr"
+	"github.com/google/uuid"
+	"log/slog"
+	"sort"
+	"time"
+)
+
+// GetAcceptanceAct возвращает последнюю редакцию акта приёма, зафиксированную при закрытии
+// приёмки. Перед выдачей содержимое сверяется с сохранённым хэшем.
+func (s *ReceptionService) GetAcceptanceAct(ctx context.Context, receptionID string) (*entity.AcceptanceAct, error) {
+	log := slog.With("layer", "ReceptionService", "operation", "GetAcceptanceAct", "receptionID", receptionID)
+	log.Debug("starting acceptance act loading")
+
+	reception, err := s.receptionRepo.GetByID(ctx, receptionID)
+	if err != nil {
+		if errors.Is(err, repoerr.ErrNoRows) {
+			log.Warn("reception not found")
+			return nil, ErrReceptionNotFound
+		}
+		log.Error("failed to get reception", "error", err)
+		return nil, ErrInternal
+	}
+
+	if reception.Status != entity.StatusClose && reception.Status != entity.StatusVerified {
+		log.Warn("reception is not closed", "status", reception.Status)
+		return nil, ErrInvalidReceptionStatus
+	}
+
+	snapshot, err := s.receptionRepo.GetAct(ctx, receptionID)
+	if err != nil {
+		log.Error("failed to get acceptance act", "error", err)
+		return nil, ErrInternal
+	}
+
+	act, err := actFromSnapshot(*snapshot)
+	if err != nil {
+		log.Error("stored acceptance act is invalid", "error", err)
+		return nil, ErrInternal
+	}
+
+	log.Info("acceptance act loaded", "version", act.Version, "products", len(act.Products))
+	return act, nil
+}
+
+// saveAcceptanceAct фиксирует акт по текущему состоянию приёмки. Вызывается в транзакции закрытия,
+// поэтому акт отражает приёмку ровно на момент закрытия.
+func (s *ReceptionService) saveAcceptanceAct(ctx context.Context, log *slog.Logger, receptionID uuid.UUID) error {
+	reception, err := s.receptionRepo.GetByID(ctx, receptionID.String())
+	if err != nil {
+		log.Error("failed to get reception", "error", err)
+		return ErrInternal
+	}
+
+	pvz, err := s.pvzRepo.GetByID(ctx, reception.PVZID.String())
+	if err != nil {
+		log.Error("failed to get pvz", "error", err)
+		return ErrInternal
+	}
+
+	products, err := s.productRepo.ListByReception(ctx, receptionID.String())
+	if err != nil {
+		log.Error("failed to list products", "error", err)
+		return ErrInternal
+	}
+
+	act, err := buildAcceptanceAct(*reception, *pvz, products)
+	if err != nil {
+		log.Error("failed to hash acceptance act", "error", err)
+		return ErrInternal
+	}
+	content, err := marshalActContent(act)
+	if err != nil {
+		log.Error("failed to marshal acceptance act", "error", err)
+		return ErrInternal
+	}
+
+	snapshot := entity.ActSnapshot{ReceptionID: receptionID, Content: content, ContentHash: act.ContentHash}
+	if err := s.receptionRepo.SaveAct(ctx, snapshot); err != nil {
+		log.Error("failed to save acceptance act", "error", err)
+		return ErrInternal
+	}
+	return nil
+}
+
+func buildAcceptanceAct(reception entity.Reception, pvz entity.PVZ,
+	products []entity.Product) (*entity.AcceptanceAct, error) {
+	counts := make(map[string]int)
+	for _, product := range products {
+		counts[product.Type]++
+	}
+	types := make([]string, 0, len(counts))
+	for productType := range counts {
+		types = append(types, productType)
+	}
+	sort.Strings(types)
+
+	act := &entity.AcceptanceAct{
+		ReceptionID:   reception.ID,
+		PVZ:           pvz,
+		Delivery:      reception.Delivery,
+		OpenedAt:      reception.DateTime,
+		ClosedAt:      reception.ClosedAt,
+		OpenedBy:      reception.OpenedBy,
+		ClosedBy:      reception.ClosedBy,
+		Counts:        make([]entity.TypeCount, len(types)),
+		DeclaredValue: entity.SumDeclaredValues(products),
+		Products:      products,
+	}
+	for i, productType := range types {
+		act.Counts[i] = entity.TypeCount{ProductType: productType, Count: counts[productType]}
+	}
+
+	hash, err := actContentHash(act)
+	if err != nil {
+		return nil, err
+	}
+	act.ContentHash = hash
+	return act, nil
+}
+
+// actContent — каноническое представление акта для хэширования. Порядок и формат
+// полей фиксированы: любое изменение меняет хэш уже выданных актов. Поля объявленной ценности
+// пропускаются, если она не задана, поэтому хэш актов без неё прежний.
+type actContent struct {
+	ReceptionID   string           `json:"receptionId"`
+	PVZID         string           `json:"pvzId"`
+	City          string           `json:"city"`
+	Carrier       string           `json:"carrier"`
+	WaybillNumber string           `json:"waybillNumber"`
+	VehiclePlate  string           `json:"vehiclePlate"`
+	Comment       string           `json:"comment"`
+	OpenedAt      string           `json:"openedAt"`
+	ClosedAt      string           `json:"closedAt"`
+	OpenedBy      string           `json:"openedBy"`
+	ClosedBy      string           `json:"closedBy"`
+	Counts        map[string]int   `json:"counts"`
+	Products      []actContentItem `json:"products"`
+	DeclaredValue map[string]int64 `json:"declaredValue,omitempty"`
+}
+
+type actContentItem struct {
+	ID            string `json:"id"`
+	Type          string `json:"type"`
+	DateTime      string `json:"dateTime"`
+	DeclaredValue int64  `json:"declaredValue,omitempty"`
+	Currency      string `json:"currency,omitempty"`
+}
+
+func actContentHash(act *entity.AcceptanceAct) (string, error) {
+	data, err := marshalActContent(act)
+	if err != nil {
+		return "", err
+	}
+	return hashActContent(data), nil
+}
+
+func hashActContent(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func marshalActContent(act *entity.AcceptanceAct) ([]byte, error) {
+	content := actContent{
+		ReceptionID:   act.ReceptionID.String(),
+		PVZID:         act.PVZ.ID.String(),
+		City:          act.PVZ.City,
+		Carrier:       act.Delivery.Carrier,
+		WaybillNumber: act.Delivery.WaybillNumber,
+		VehiclePlate:  act.Delivery.VehiclePlate,
+		Comment:       act.Delivery.Comment,
+		OpenedAt:      act.OpenedAt.UTC().Format(time.RFC3339Nano),
+		OpenedBy:      act.OpenedBy.String(),
+		ClosedBy:      act.ClosedBy.String(),
+		Counts:        make(map[string]int, len(act.Counts)),
+		Products:      make([]actContentItem, len(act.Products)),
+	}
+	if act.ClosedAt != nil {
+		content.ClosedAt = act.ClosedAt.UTC().Format(time.RFC3339Nano)
+	}
+	for _, count := range act.Counts {
+		content.Counts[count.ProductType] = count.Count
+	}
+	if len(act.DeclaredValue) > 0 {
+		content.DeclaredValue = make(map[string]int64, len(act.DeclaredValue))
+		for _, total := range act.DeclaredValue {
+			content.DeclaredValue[total.Currency] = total.Amount
+		}
+	}
+	for i, product := range act.Products {
+		content.Products[i] = actContentItem{
+			ID:            product.ID.String(),
+			Type:          product.Type,
+			DateTime:      product.DateTime.UTC().Format(time.RFC3339Nano),
+			DeclaredValue: product.DeclaredValue,
+			Currency:      product.Currency,
+		}
+	}
+
+	return json.Marshal(content)
+}
+
+// actFromSnapshot восстанавливает акт из сохранённого содержимого. Несовпадение хэша означает,
+// что снимок изменён после закрытия приёмки.
+func actFromSnapshot(snapshot entity.ActSnapshot) (*entity.AcceptanceAct, error) {
+	if hashActContent(snapshot.Content) != snapshot.ContentHash {
+		return nil, fmt.Errorf("content hash mismatch")
+	}
+
+	var content actContent
+	if err := json.Unmarshal(snapshot.Content, &content); err != nil {
+		return nil, err
+	}
+
+	act := &entity.AcceptanceAct{
+		ReceptionID: snapshot.ReceptionID,
+		Version:     snapshot.Version,
+		PVZ:         entity.PVZ{City: content.City},
+		Delivery: entity.DeliveryInfo{
+			Carrier:       content.Carrier,
+			WaybillNumber: content.WaybillNumber,
+			VehiclePlate:  content.VehiclePlate,
+			Comment:       content.Comment,
+		},
+		Products:    make([]entity.Product, len(content.Products)),
+		ContentHash: snapshot.ContentHash,
+	}
+	var err error
+	if act.PVZ.ID, err = uuid.Parse(content.PVZID); err != nil {
+		return nil, err
+	}
+	if act.OpenedBy, err = uuid.Parse(content.OpenedBy); err != nil {
+		return nil, err
+	}
+	if act.ClosedBy, err = uuid.Parse(content.ClosedBy); err != nil {
+		return nil, err
+	}
+	if act.OpenedAt, err = time.Parse(time.RFC3339Nano, content.OpenedAt); err != nil {
+		return nil, err
+	}
+	if content.ClosedAt != "" {
+		closedAt, err := time.Parse(time.RFC3339Nano, content.ClosedAt)
+		if err != nil {
+			return nil, err
+		}
+		act.ClosedAt = &closedAt
+	}
+
+	types := make([]string, 0, len(content.Counts))
+	for productType := range content.Counts {
+		types = append(types, productType)
+	}
+	sort.Strings(types)
+	act.Counts = make([]entity.TypeCount, len(types))
+	for i, productType := range types {
+		act.Counts[i] = entity.TypeCount{ProductType: productType, Count: content.Counts[productType]}
+	}
+
+	for i, item := range content.Products {
+		product := entity.Product{
+			ReceptionID:   snapshot.ReceptionID,
+			Type:          item.Type,
+			DeclaredValue: item.DeclaredValue,
+			Currency:      item.Currency,
+		}
+		if product.ID, err = uuid.Parse(item.ID); err != nil {
+			return nil, err
+		}
+		if product.DateTime, err = time.Parse(time.RFC3339Nano, item.DateTime); err != nil {
+			return nil, err
+		}
+		act.Products[i] = product
+	}
+	act.DeclaredValue = entity.SumDeclaredValues(act.Products)
+	return act, nil
+}
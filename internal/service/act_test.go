@@ -0,0 +1,166 @@
+package service
+
+import (
+	"context"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/entity"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/repo/mocks"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/repo/repoerr"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReceptionService_GetAcceptanceAct(t *testing.T) {
+	receptionID := uuid.New()
+	pvzID := uuid.New()
+	closedAt := time.Date(2025, 4, 1, 12, 0, 0, 0, time.UTC)
+	closedReception := &entity.Reception{
+		ID:       receptionID,
+		DateTime: closedAt.Add(-time.Hour),
+		PVZID:    pvzID,
+		Status:   entity.StatusClose,
+		ClosedAt: &closedAt,
+		Delivery: entity.DeliveryInfo{Carrier: "СДЭК", WaybillNumber: "WB-1"},
+	}
+	products := []entity.Product{
+		{ID: uuid.New(), Type: entity.ProductTypeShoes, ReceptionID: receptionID},
+		{ID: uuid.New(), Type: entity.ProductTypeClothes, ReceptionID: receptionID},
+		{ID: uuid.New(), Type: entity.ProductTypeShoes, ReceptionID: receptionID},
+	}
+	pvz := &entity.PVZ{ID: pvzID, City: entity.CityKazan}
+	snapshot := newTestActSnapshot(t, *closedReception, *pvz, products)
+	snapshot.Version = 2
+	tampered := *snapshot
+	tampered.Content = []byte(strings.Replace(string(snapshot.Content), "WB-1", "WB-9", 1))
+
+	testCases := []struct {
+		name           string
+		prepareRepos   func(receptionRepo *mocks.Reception, productRepo *mocks.Product, pvzRepo *mocks.PVZ)
+		expectedCounts []entity.TypeCount
+		expectedError  error
+	}{
+		{
+			name: "act served from snapshot",
+			prepareRepos: func(receptionRepo *mocks.Reception, productRepo *mocks.Product, pvzRepo *mocks.PVZ) {
+				receptionRepo.On("GetByID", mock.Anything, receptionID.String()).Return(closedReception, nil)
+				receptionRepo.On("GetAct", mock.Anything, receptionID.String()).Return(snapshot, nil)
+			},
+			expectedCounts: []entity.TypeCount{
+				{ProductType: entity.ProductTypeShoes, Count: 2},
+				{ProductType: entity.ProductTypeClothes, Count: 1},
+			},
+		},
+		{
+			name: "missing act is not recorded on request",
+			prepareRepos: func(receptionRepo *mocks.Reception, productRepo *mocks.Product, pvzRepo *mocks.PVZ) {
+				receptionRepo.On("GetByID", mock.Anything, receptionID.String()).Return(closedReception, nil)
+				receptionRepo.On("GetAct", mock.Anything, receptionID.String()).Return(nil, repoerr.ErrNoRows)
+			},
+			expectedError: ErrInternal,
+		},
+		{
+			name: "tampered snapshot",
+			prepareRepos: func(receptionRepo *mocks.Reception, productRepo *mocks.Product, pvzRepo *mocks.PVZ) {
+				receptionRepo.On("GetByID", mock.Anything, receptionID.String()).Return(closedReception, nil)
+				receptionRepo.On("GetAct", mock.Anything, receptionID.String()).Return(&tampered, nil)
+			},
+			expectedError: ErrInternal,
+		},
+		{
+			name: "reception not found",
+			prepareRepos: func(receptionRepo *mocks.Reception, productRepo *mocks.Product, pvzRepo *mocks.PVZ) {
+				receptionRepo.On("GetByID", mock.Anything, receptionID.String()).Return(nil, repoerr.ErrNoRows)
+			},
+			expectedError: ErrReceptionNotFound,
+		},
+		{
+			name: "reception still open",
+			prepareRepos: func(receptionRepo *mocks.Reception, productRepo *mocks.Product, pvzRepo *mocks.PVZ) {
+				receptionRepo.On("GetByID", mock.Anything, receptionID.String()).
+					Return(&entity.Reception{ID: receptionID, PVZID: pvzID, Status: entity.StatusInProgress}, nil)
+			},
+			expectedError: ErrInvalidReceptionStatus,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			receptionRepo := mocks.NewReception(t)
+			productRepo := mocks.NewProduct(t)
+			pvzRepo := mocks.NewPVZ(t)
+			tc.prepareRepos(receptionRepo, productRepo, pvzRepo)
+
+			service := NewReceptionService(newPassthroughTransactor(t), receptionRepo, productRepo, pvzRepo,
+				mocks.NewDock(t), newCatalogTypeRepo(t))
+
+			act, err := service.GetAcceptanceAct(context.Background(), receptionID.String())
+
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+				assert.Nil(t, act)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedCounts, act.Counts)
+			assert.Equal(t, products, act.Products)
+			assert.Equal(t, closedReception.Delivery, act.Delivery)
+			assert.Equal(t, pvz.City, act.PVZ.City)
+			assert.Equal(t, closedAt, *act.ClosedAt)
+			assert.Equal(t, snapshot.ContentHash, act.ContentHash)
+			assert.Equal(t, snapshot.Version, act.Version)
+		})
+	}
+}
+
+func newTestActSnapshot(t *testing.T, reception entity.Reception, pvz entity.PVZ,
+	products []entity.Product) *entity.ActSnapshot {
+	act, err := buildAcceptanceAct(reception, pvz, products)
+	if err != nil {
+		t.Fatalf("failed to build act: %v", err)
+	}
+	content, err := marshalActContent(act)
+	if err != nil {
+		t.Fatalf("failed to marshal act: %v", err)
+	}
+	return &entity.ActSnapshot{ReceptionID: reception.ID, Content: content, ContentHash: act.ContentHash}
+}
+
+// expectActSaved ожидает фиксацию акта в транзакции закрытия приёмки.
+func expectActSaved(receptionRepo *mocks.Reception, productRepo *mocks.Product, pvzRepo *mocks.PVZ,
+	receptionID uuid.UUID) {
+	receptionRepo.On("GetByID", mock.Anything, receptionID.String()).
+		Return(&entity.Reception{ID: receptionID, Status: entity.StatusClose}, nil)
+	pvzRepo.On("GetByID", mock.Anything, mock.AnythingOfType("string")).Return(&entity.PVZ{}, nil)
+	productRepo.On("ListByReception", mock.Anything, receptionID.String()).Return(nil, nil)
+	receptionRepo.On("SaveAct", mock.Anything, mock.MatchedBy(func(snapshot entity.ActSnapshot) bool {
+		return snapshot.ReceptionID == receptionID && len(snapshot.ContentHash) == 64
+	})).Return(nil)
+}
+
+func TestBuildAcceptanceActHash(t *testing.T) {
+	closedAt := time.Date(2025, 4, 1, 12, 0, 0, 0, time.UTC)
+	reception := entity.Reception{ID: uuid.New(), DateTime: closedAt.Add(-time.Hour), ClosedAt: &closedAt}
+	pvz := entity.PVZ{ID: uuid.New(), City: entity.CityMoscow}
+	products := []entity.Product{{ID: uuid.New(), Type: entity.ProductTypeShoes}}
+
+	first, err := buildAcceptanceAct(reception, pvz, products)
+	assert.NoError(t, err)
+	second, err := buildAcceptanceAct(reception, pvz, products)
+	assert.NoError(t, err)
+	assert.Equal(t, first.ContentHash, second.ContentHash, "hash must be deterministic")
+
+	reception.Delivery.WaybillNumber = "WB-2"
+	changed, err := buildAcceptanceAct(reception, pvz, products)
+	assert.NoError(t, err)
+	assert.NotEqual(t, first.ContentHash, changed.ContentHash, "hash must cover delivery info")
+
+	valued := []entity.Product{{ID: products[0].ID, Type: entity.ProductTypeShoes, DeclaredValue: 499_000,
+		Currency: "RUB"}}
+	withValue, err := buildAcceptanceAct(reception, pvz, valued)
+	assert.NoError(t, err)
+	assert.Equal(t, []entity.ValueTotal{{Currency: "RUB", Amount: 499_000, Count: 1}}, withValue.DeclaredValue)
+	assert.NotEqual(t, changed.ContentHash, withValue.ContentHash, "hash must cover declared value")
+}
@@ -0,0 +1,198 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"unicode/utf8"
+)
+
+// attributeSchema — поддерживаемое подмножество JSON Schema для атрибутов товара:
+// объект с плоскими свойствами типов string, number, integer и boolean.
+type attributeSchema struct {
+	Schema               string                       `json:"$schema"`
+	Title                string                       `json:"title"`
+	Description          string                       `json:"description"`
+	Type                 string                       `json:"type"`
+	Properties           map[string]attributeProperty `json:"properties"`
+	Required             []string                     `json:"required"`
+	AdditionalProperties *bool                        `json:"additionalProperties"`
+}
+
+type attributeProperty struct {
+	Title       string   `json:"title"`
+	Description string   `json:"description"`
+	Type        string   `json:"type"`
+	Enum        []any    `json:"enum"`
+	Pattern     string   `json:"pattern"`
+	MinLength   *int     `json:"minLength"`
+	MaxLength   *int     `json:"maxLength"`
+	Minimum     *float64 `json:"minimum"`
+	Maximum     *float64 `json:"maximum"`
+
+	pattern *regexp.Regexp
+}
+
+// AttributeError описывает, какой атрибут товара не прошёл проверку по схеме типа.
+type AttributeError struct {
+	Attribute string
+	Reason    string
+}
+
+func (e *AttributeError) Error() string {
+	if e.Attribute == "" {
+		return fmt.Sprintf("%s: %s", ErrInvalidAttributes, e.Reason)
+	}
+	return fmt.Sprintf("%s: %s: %s", ErrInvalidAttributes, e.Attribute, e.Reason)
+}
+
+func (e *AttributeError) Unwrap() error {
+	return ErrInvalidAttributes
+}
+
+// parseAttributeSchema разбирает схему и проверяет, что в ней используются только
+// поддерживаемые ключевые слова.
+func parseAttributeSchema(raw json.RawMessage) (*attributeSchema, error) {
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.DisallowUnknownFields()
+
+	var schema attributeSchema
+	if err := decoder.Decode(&schema); err != nil || schema.Type != "object" {
+		return nil, ErrInvalidAttributeSchema
+	}
+	for name, property := range schema.Properties {
+		switch property.Type {
+		case "string", "number", "integer", "boolean":
+		default:
+			return nil, ErrInvalidAttributeSchema
+		}
+		if property.Pattern != "" {
+			pattern, err := regexp.Compile(property.Pattern)
+			if err != nil {
+				return nil, ErrInvalidAttributeSchema
+			}
+			property.pattern = pattern
+			schema.Properties[name] = property
+		}
+	}
+	for _, name := range schema.Required {
+		if _, ok := schema.Properties[name]; !ok {
+			return nil, ErrInvalidAttributeSchema
+		}
+	}
+	return &schema, nil
+}
+
+func (s *attributeSchema) validate(raw json.RawMessage) error {
+	attributes := map[string]any{}
+	if len(raw) > 0 {
+		decoder := json.NewDecoder(bytes.NewReader(raw))
+		decoder.UseNumber()
+		if err := decoder.Decode(&attributes); err != nil || attributes == nil {
+			return &AttributeError{Reason: "must be an object"}
+		}
+	}
+
+	for _, name := range s.Required {
+		if _, ok := attributes[name]; !ok {
+			return &AttributeError{Attribute: name, Reason: "is required"}
+		}
+	}
+
+	names := make([]string, 0, len(attributes))
+	for name := range attributes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		property, ok := s.Properties[name]
+		if !ok {
+			if s.AdditionalProperties != nil && !*s.AdditionalProperties {
+				return &AttributeError{Attribute: name, Reason: "is not allowed"}
+			}
+			continue
+		}
+		if reason := property.check(attributes[name]); reason != "" {
+			return &AttributeError{Attribute: name, Reason: reason}
+		}
+	}
+	return nil
+}
+
+// check возвращает причину, по которой значение не подходит под описание свойства.
+func (p attributeProperty) check(value any) string {
+	switch p.Type {
+	case "string":
+		str, ok := value.(string)
+		if !ok {
+			return "must be a string"
+		}
+		length := utf8.RuneCountInString(str)
+		if p.MinLength != nil && length < *p.MinLength {
+			return fmt.Sprintf("must be at least %d characters", *p.MinLength)
+		}
+		if p.MaxLength != nil && length > *p.MaxLength {
+			return fmt.Sprintf("must be at most %d characters", *p.MaxLength)
+		}
+		if p.pattern != nil && !p.pattern.MatchString(str) {
+			return fmt.Sprintf("must match %s", p.Pattern)
+		}
+	case "number", "integer":
+		number, ok := value.(json.Number)
+		if !ok {
+			return "must be a " + p.Type
+		}
+		if p.Type == "integer" {
+			if _, err := number.Int64(); err != nil {
+				return "must be an integer"
+			}
+		}
+		f, err := number.Float64()
+		if err != nil {
+			return "must be a " + p.Type
+		}
+		if p.Minimum != nil && f < *p.Minimum {
+			return fmt.Sprintf("must be at least %v", *p.Minimum)
+		}
+		if p.Maximum != nil && f > *p.Maximum {
+			return fmt.Sprintf("must be at most %v", *p.Maximum)
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return "must be a boolean"
+		}
+	}
+
+	if len(p.Enum) > 0 && !slices.ContainsFunc(p.Enum, func(allowed any) bool { return enumEqual(allowed, value) }) {
+		return "must be one of the allowed values"
+	}
+	return ""
+}
+
+// enumEqual сравнивает значение из схемы (float64 для чисел) со значением атрибута (json.Number).
+func enumEqual(allowed, value any) bool {
+	if number, ok := value.(json.Number); ok {
+		f, err := number.Float64()
+		return err == nil && allowed == f
+	}
+	return allowed == value
+}
+
+// validateAttributes проверяет атрибуты товара по схеме его типа. Тип без схемы не допускает атрибутов.
+func validateAttributes(productSchema, attributes json.RawMessage) error {
+	if len(productSchema) == 0 {
+		if len(attributes) == 0 || bytes.Equal(bytes.TrimSpace(attributes), []byte("{}")) {
+			return nil
+		}
+		return &AttributeError{Reason: "product type has no attributes"}
+	}
+
+	schema, err := parseAttributeSchema(productSchema)
+	if err != nil {
+		return err
+	}
+	return schema.validate(attributes)
+}
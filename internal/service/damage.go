@@ -0,0 +1,272 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/entity"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/metrics"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/repo"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/repo/repoerr"
+	"github.com/google/uuid"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	maxDamageDescriptionLength = 1000
+	maxPhotoSize               = 10 << 20
+	maxProductPhotos           = 10
+	photoSniffLength           = 512
+)
+
+// photoExtensions — допустимые форматы фотографий и расширения ключей в хранилище.
+var photoExtensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/webp": ".webp",
+}
+
+type DamageService struct {
+	transactor  repo.Transactor
+	productRepo repo.Product
+	photoRepo   repo.ProductPhoto
+	blobStore   repo.BlobStore
+}
+
+func NewDamageService(transactor repo.Transactor, productRepo repo.Product, photoRepo repo.ProductPhoto,
+	blobStore repo.BlobStore) *DamageService {
+	return &DamageService{
+		transactor:  transactor,
+		productRepo: productRepo,
+		photoRepo:   photoRepo,
+		blobStore:   blobStore,
+	}
+}
+
+// MarkDamaged отмечает хранящийся товар повреждённым. Повторная отметка заменяет описание.
+func (s *DamageService) MarkDamaged(ctx context.Context, params entity.DamageParams) (*entity.Product, error) {
+	log := slog.With("layer", "DamageService", "operation", "MarkDamaged", "productID", params.ProductID,
+		"userID", params.MarkedBy.String())
+	log.Debug("starting product damage marking")
+
+	description := strings.TrimSpace(params.Description)
+	if description == "" || utf8.RuneCountInString(description) > maxDamageDescriptionLength {
+		log.Warn("invalid damage description")
+		return nil, ErrInvalidDamage
+	}
+
+	var product *entity.Product
+	err := withinTx(ctx, s.transactor, log, func(ctx context.Context) error {
+		var err error
+		product, err = s.getProduct(ctx, log, params.ProductID)
+		if err != nil {
+			return err
+		}
+		if product.Status != entity.ProductStatusStored {
+			log.Warn("product is not stored", "status", product.Status)
+			return ErrProductNotStored
+		}
+
+		damagedAt, err := s.productRepo.MarkDamaged(ctx, params.ProductID, description, params.MarkedBy)
+		if err != nil {
+			if errors.Is(err, repoerr.ErrNoRows) {
+				log.Warn("product status changed concurrently")
+				return ErrProductNotStored
+			}
+			log.Error("failed to mark product damaged", "error", err)
+			return ErrInternal
+		}
+		product.DamageDescription = description
+		product.DamagedBy = params.MarkedBy
+		product.DamagedAt = &damagedAt
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	metrics.ProductsMarkedDamaged.Inc()
+	log.Info("product marked damaged")
+	return product, nil
+}
+
+// UploadPhoto сохраняет фотографию повреждения товара в хранилище. Формат определяется по
+// содержимому, а не по заявленному клиентом типу.
+func (s *DamageService) UploadPhoto(ctx context.Context, upload entity.PhotoUpload) (*entity.ProductPhoto, error) {
+	log := slog.With("layer", "DamageService", "operation", "UploadPhoto", "productID", upload.ProductID,
+		"size", upload.Size, "userID", upload.UploadedBy.String())
+	log.Debug("starting damage photo upload")
+
+	if upload.Size <= 0 {
+		log.Warn("empty photo")
+		return nil, ErrInvalidPhoto
+	}
+	if upload.Size > maxPhotoSize {
+		log.Warn("photo is too large")
+		return nil, ErrPhotoTooLarge
+	}
+
+	head := make([]byte, photoSniffLength)
+	n, err := io.ReadFull(upload.Content, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+		log.Warn("failed to read photo", "error", err)
+		return nil, ErrInvalidPhoto
+	}
+	contentType := http.DetectContentType(head[:n])
+	extension, ok := photoExtensions[contentType]
+	if !ok {
+		log.Warn("unsupported photo format", "contentType", contentType)
+		return nil, ErrInvalidPhoto
+	}
+
+	product, err := s.getProduct(ctx, log, upload.ProductID)
+	if err != nil {
+		return nil, err
+	}
+	if product.DamagedAt == nil {
+		log.Warn("product is not marked damaged")
+		return nil, ErrProductNotDamaged
+	}
+
+	// Заведомо лишнюю фотографию не загружаем в хранилище.
+	if err := s.checkPhotoLimit(ctx, log, upload.ProductID); err != nil {
+		return nil, err
+	}
+
+	photo := entity.ProductPhoto{
+		ID:          uuid.New(),
+		ProductID:   product.ID,
+		ContentType: contentType,
+		Size:        upload.Size,
+		UploadedBy:  upload.UploadedBy,
+	}
+	photo.BlobKey = fmt.Sprintf("products/%s/%s%s", product.ID, photo.ID, extension)
+
+	content := io.MultiReader(bytes.NewReader(head[:n]), upload.Content)
+	if err := s.blobStore.Put(ctx, photo.BlobKey, content, upload.Size, contentType); err != nil {
+		log.Error("failed to store photo", "error", err)
+		return nil, ErrInternal
+	}
+
+	created, err := s.savePhoto(ctx, log, photo)
+	if err != nil {
+		if err := s.blobStore.Delete(ctx, photo.BlobKey); err != nil {
+			log.Error("failed to delete orphaned photo", "key", photo.BlobKey, "error", err)
+		}
+		return nil, err
+	}
+
+	log.Info("damage photo uploaded", "photoID", created.ID.String())
+	return created, nil
+}
+
+// savePhoto сохраняет сведения о фотографии. Число фотографий проверяется повторно под блокировкой
+// товара: параллельные загрузки могли пройти первую проверку одновременно.
+func (s *DamageService) savePhoto(ctx context.Context, log *slog.Logger,
+	photo entity.ProductPhoto) (*entity.ProductPhoto, error) {
+	var created *entity.ProductPhoto
+	err := withinTx(ctx, s.transactor, log, func(ctx context.Context) error {
+		if _, err := s.productRepo.LockByIDs(ctx, []uuid.UUID{photo.ProductID}); err != nil {
+			log.Error("failed to lock product", "error", err)
+			return ErrInternal
+		}
+		if err := s.checkPhotoLimit(ctx, log, photo.ProductID.String()); err != nil {
+			return err
+		}
+
+		var err error
+		created, err = s.photoRepo.Create(ctx, photo)
+		if err != nil {
+			log.Error("failed to save photo", "error", err)
+			return ErrInternal
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+// checkPhotoLimit проверяет, что у товара меньше maxProductPhotos фотографий.
+func (s *DamageService) checkPhotoLimit(ctx context.Context, log *slog.Logger, productID string) error {
+	photos, err := s.photoRepo.List(ctx, productID)
+	if err != nil {
+		log.Error("failed to list product photos", "error", err)
+		return ErrInternal
+	}
+	if len(photos) >= maxProductPhotos {
+		log.Warn("product photo limit reached", "photos", len(photos))
+		return ErrTooManyPhotos
+	}
+	return nil
+}
+
+// ListPhotos возвращает фотографии повреждений товара в порядке загрузки.
+func (s *DamageService) ListPhotos(ctx context.Context, productID string) ([]entity.ProductPhoto, error) {
+	log := slog.With("layer", "DamageService", "operation", "ListPhotos", "productID", productID)
+	log.Debug("starting list damage photos")
+
+	if _, err := s.getProduct(ctx, log, productID); err != nil {
+		return nil, err
+	}
+
+	photos, err := s.photoRepo.List(ctx, productID)
+	if err != nil {
+		log.Error("failed to list product photos", "error", err)
+		return nil, ErrInternal
+	}
+	return photos, nil
+}
+
+// GetPhoto возвращает сведения о фотографии и её содержимое; содержимое закрывает вызывающий.
+func (s *DamageService) GetPhoto(ctx context.Context, productID, photoID string) (*entity.ProductPhoto,
+	io.ReadCloser, error) {
+	log := slog.With("layer", "DamageService", "operation", "GetPhoto", "productID", productID,
+		"photoID", photoID)
+	log.Debug("starting get damage photo")
+
+	photo, err := s.photoRepo.GetByID(ctx, productID, photoID)
+	if err != nil {
+		if errors.Is(err, repoerr.ErrNoRows) {
+			log.Warn("photo not found")
+			return nil, nil, ErrPhotoNotFound
+		}
+		log.Error("failed to get photo", "error", err)
+		return nil, nil, ErrInternal
+	}
+
+	content, err := s.blobStore.Get(ctx, photo.BlobKey)
+	if err != nil {
+		if errors.Is(err, repoerr.ErrNotFound) {
+			log.Error("photo file is missing in blob store", "key", photo.BlobKey)
+			return nil, nil, ErrPhotoNotFound
+		}
+		log.Error("failed to read photo", "error", err)
+		return nil, nil, ErrInternal
+	}
+	return photo, content, nil
+}
+
+// getProduct возвращает неудалённый товар.
+func (s *DamageService) getProduct(ctx context.Context, log *slog.Logger, productID string) (*entity.Product, error) {
+	product, err := s.productRepo.GetByID(ctx, productID)
+	if err != nil {
+		if errors.Is(err, repoerr.ErrNoRows) {
+			log.Warn("product not found")
+			return nil, ErrProductNotFound
+		}
+		log.Error("failed to get product", "error", err)
+		return nil, ErrInternal
+	}
+	if product.DeletedAt != nil {
+		log.Warn("product is deleted")
+		return nil, ErrProductNotFound
+	}
+	return product, nil
+}
@@ -0,0 +1,359 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/entity"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/repo/mocks"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/repo/repoerr"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+// pngHeader — сигнатура PNG, по которой определяется формат фотографии.
+var pngHeader = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\x0dIHDR")
+
+func TestDamageService_MarkDamaged(t *testing.T) {
+	userID := uuid.New()
+	productID := uuid.New()
+	damagedAt := time.Now()
+
+	product := func(status string) *entity.Product {
+		return &entity.Product{ID: productID, Type: entity.ProductTypeShoes, Status: status}
+	}
+
+	testCases := []struct {
+		name          string
+		description   string
+		prepareRepo   func(productRepo *mocks.Product)
+		expectedError error
+	}{
+		{
+			name:        "product marked damaged",
+			description: "  смята коробка ",
+			prepareRepo: func(productRepo *mocks.Product) {
+				productRepo.On("GetByID", mock.Anything, productID.String()).
+					Return(product(entity.ProductStatusStored), nil)
+				productRepo.On("MarkDamaged", mock.Anything, productID.String(), "смята коробка", userID).
+					Return(damagedAt, nil)
+			},
+		},
+		{
+			name:          "empty description",
+			description:   "   ",
+			prepareRepo:   func(productRepo *mocks.Product) {},
+			expectedError: ErrInvalidDamage,
+		},
+		{
+			name:          "description too long",
+			description:   strings.Repeat("я", maxDamageDescriptionLength+1),
+			prepareRepo:   func(productRepo *mocks.Product) {},
+			expectedError: ErrInvalidDamage,
+		},
+		{
+			name:        "product not found",
+			description: "смята коробка",
+			prepareRepo: func(productRepo *mocks.Product) {
+				productRepo.On("GetByID", mock.Anything, productID.String()).Return(nil, repoerr.ErrNoRows)
+			},
+			expectedError: ErrProductNotFound,
+		},
+		{
+			name:        "deleted product",
+			description: "смята коробка",
+			prepareRepo: func(productRepo *mocks.Product) {
+				deleted := product(entity.ProductStatusStored)
+				deleted.DeletedAt = &damagedAt
+				productRepo.On("GetByID", mock.Anything, productID.String()).Return(deleted, nil)
+			},
+			expectedError: ErrProductNotFound,
+		},
+		{
+			name:        "issued product",
+			description: "смята коробка",
+			prepareRepo: func(productRepo *mocks.Product) {
+				productRepo.On("GetByID", mock.Anything, productID.String()).
+					Return(product(entity.ProductStatusIssued), nil)
+			},
+			expectedError: ErrProductNotStored,
+		},
+		{
+			name:        "issued concurrently",
+			description: "смята коробка",
+			prepareRepo: func(productRepo *mocks.Product) {
+				productRepo.On("GetByID", mock.Anything, productID.String()).
+					Return(product(entity.ProductStatusStored), nil)
+				productRepo.On("MarkDamaged", mock.Anything, productID.String(), "смята коробка", userID).
+					Return(time.Time{}, repoerr.ErrNoRows)
+			},
+			expectedError: ErrProductNotStored,
+		},
+		{
+			name:        "repo error",
+			description: "смята коробка",
+			prepareRepo: func(productRepo *mocks.Product) {
+				productRepo.On("GetByID", mock.Anything, productID.String()).
+					Return(product(entity.ProductStatusStored), nil)
+				productRepo.On("MarkDamaged", mock.Anything, productID.String(), "смята коробка", userID).
+					Return(time.Time{}, errors.New("database error"))
+			},
+			expectedError: ErrInternal,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			productRepo := mocks.NewProduct(t)
+			tc.prepareRepo(productRepo)
+
+			service := NewDamageService(newPassthroughTransactor(t), productRepo, mocks.NewProductPhoto(t),
+				mocks.NewBlobStore(t))
+
+			damaged, err := service.MarkDamaged(context.Background(), entity.DamageParams{
+				ProductID:   productID.String(),
+				Description: tc.description,
+				MarkedBy:    userID,
+			})
+
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+				assert.Nil(t, damaged)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, "смята коробка", damaged.DamageDescription)
+			assert.Equal(t, userID, damaged.DamagedBy)
+			assert.Equal(t, damagedAt, *damaged.DamagedAt)
+		})
+	}
+}
+
+func TestDamageService_UploadPhoto(t *testing.T) {
+	userID := uuid.New()
+	productID := uuid.New()
+	damagedAt := time.Now()
+	damaged := &entity.Product{ID: productID, Status: entity.ProductStatusStored, DamagedAt: &damagedAt}
+	photoKey := mock.MatchedBy(func(key string) bool {
+		return strings.HasPrefix(key, "products/"+productID.String()+"/") && strings.HasSuffix(key, ".png")
+	})
+
+	testCases := []struct {
+		name          string
+		content       []byte
+		size          int64
+		prepareRepos  func(productRepo *mocks.Product, photoRepo *mocks.ProductPhoto, blobStore *mocks.BlobStore)
+		expectedError error
+	}{
+		{
+			name:    "photo uploaded",
+			content: pngHeader,
+			prepareRepos: func(productRepo *mocks.Product, photoRepo *mocks.ProductPhoto, blobStore *mocks.BlobStore) {
+				productRepo.On("GetByID", mock.Anything, productID.String()).Return(damaged, nil)
+				photoRepo.On("List", mock.Anything, productID.String()).Return(nil, nil)
+				blobStore.On("Put", mock.Anything, photoKey, mock.Anything, int64(len(pngHeader)), "image/png").
+					Run(func(args mock.Arguments) {
+						stored, _ := io.ReadAll(args.Get(2).(io.Reader))
+						assert.Equal(t, pngHeader, stored, "sniffed bytes are stored too")
+					}).Return(nil)
+				productRepo.On("LockByIDs", mock.Anything, []uuid.UUID{productID}).Return([]entity.Product{*damaged}, nil)
+				photoRepo.On("Create", mock.Anything, mock.AnythingOfType("entity.ProductPhoto")).
+					Return(func(ctx context.Context, photo entity.ProductPhoto) (*entity.ProductPhoto, error) {
+						return &photo, nil
+					})
+			},
+		},
+		{
+			name:          "empty photo",
+			content:       nil,
+			prepareRepos:  func(productRepo *mocks.Product, photoRepo *mocks.ProductPhoto, blobStore *mocks.BlobStore) {},
+			expectedError: ErrInvalidPhoto,
+		},
+		{
+			name:          "photo too large",
+			content:       pngHeader,
+			size:          maxPhotoSize + 1,
+			prepareRepos:  func(productRepo *mocks.Product, photoRepo *mocks.ProductPhoto, blobStore *mocks.BlobStore) {},
+			expectedError: ErrPhotoTooLarge,
+		},
+		{
+			name:          "unsupported format",
+			content:       []byte("%PDF-1.7 not a photo"),
+			prepareRepos:  func(productRepo *mocks.Product, photoRepo *mocks.ProductPhoto, blobStore *mocks.BlobStore) {},
+			expectedError: ErrInvalidPhoto,
+		},
+		{
+			name:    "product not found",
+			content: pngHeader,
+			prepareRepos: func(productRepo *mocks.Product, photoRepo *mocks.ProductPhoto, blobStore *mocks.BlobStore) {
+				productRepo.On("GetByID", mock.Anything, productID.String()).Return(nil, repoerr.ErrNoRows)
+			},
+			expectedError: ErrProductNotFound,
+		},
+		{
+			name:    "product not marked damaged",
+			content: pngHeader,
+			prepareRepos: func(productRepo *mocks.Product, photoRepo *mocks.ProductPhoto, blobStore *mocks.BlobStore) {
+				productRepo.On("GetByID", mock.Anything, productID.String()).
+					Return(&entity.Product{ID: productID, Status: entity.ProductStatusStored}, nil)
+			},
+			expectedError: ErrProductNotDamaged,
+		},
+		{
+			name:    "photo limit reached",
+			content: pngHeader,
+			prepareRepos: func(productRepo *mocks.Product, photoRepo *mocks.ProductPhoto, blobStore *mocks.BlobStore) {
+				productRepo.On("GetByID", mock.Anything, productID.String()).Return(damaged, nil)
+				photoRepo.On("List", mock.Anything, productID.String()).
+					Return(make([]entity.ProductPhoto, maxProductPhotos), nil)
+			},
+			expectedError: ErrTooManyPhotos,
+		},
+		{
+			name:    "photo limit reached by concurrent upload",
+			content: pngHeader,
+			prepareRepos: func(productRepo *mocks.Product, photoRepo *mocks.ProductPhoto, blobStore *mocks.BlobStore) {
+				productRepo.On("GetByID", mock.Anything, productID.String()).Return(damaged, nil)
+				photoRepo.On("List", mock.Anything, productID.String()).
+					Return(make([]entity.ProductPhoto, maxProductPhotos-1), nil).Once()
+				blobStore.On("Put", mock.Anything, photoKey, mock.Anything, mock.Anything, "image/png").Return(nil)
+				productRepo.On("LockByIDs", mock.Anything, []uuid.UUID{productID}).Return([]entity.Product{*damaged}, nil)
+				photoRepo.On("List", mock.Anything, productID.String()).
+					Return(make([]entity.ProductPhoto, maxProductPhotos), nil).Once()
+				blobStore.On("Delete", mock.Anything, photoKey).Return(nil)
+			},
+			expectedError: ErrTooManyPhotos,
+		},
+		{
+			name:    "blob store error",
+			content: pngHeader,
+			prepareRepos: func(productRepo *mocks.Product, photoRepo *mocks.ProductPhoto, blobStore *mocks.BlobStore) {
+				productRepo.On("GetByID", mock.Anything, productID.String()).Return(damaged, nil)
+				photoRepo.On("List", mock.Anything, productID.String()).Return(nil, nil)
+				blobStore.On("Put", mock.Anything, photoKey, mock.Anything, mock.Anything, "image/png").
+					Return(errors.New("connection refused"))
+			},
+			expectedError: ErrInternal,
+		},
+		{
+			name:    "metadata error removes stored file",
+			content: pngHeader,
+			prepareRepos: func(productRepo *mocks.Product, photoRepo *mocks.ProductPhoto, blobStore *mocks.BlobStore) {
+				productRepo.On("GetByID", mock.Anything, productID.String()).Return(damaged, nil)
+				photoRepo.On("List", mock.Anything, productID.String()).Return(nil, nil)
+				blobStore.On("Put", mock.Anything, photoKey, mock.Anything, mock.Anything, "image/png").Return(nil)
+				productRepo.On("LockByIDs", mock.Anything, []uuid.UUID{productID}).Return([]entity.Product{*damaged}, nil)
+				photoRepo.On("Create", mock.Anything, mock.AnythingOfType("entity.ProductPhoto")).
+					Return(nil, errors.New("database error"))
+				blobStore.On("Delete", mock.Anything, photoKey).Return(nil)
+			},
+			expectedError: ErrInternal,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			productRepo := mocks.NewProduct(t)
+			photoRepo := mocks.NewProductPhoto(t)
+			blobStore := mocks.NewBlobStore(t)
+			tc.prepareRepos(productRepo, photoRepo, blobStore)
+
+			service := NewDamageService(newPassthroughTransactor(t), productRepo, photoRepo, blobStore)
+
+			size := tc.size
+			if size == 0 {
+				size = int64(len(tc.content))
+			}
+			photo, err := service.UploadPhoto(context.Background(), entity.PhotoUpload{
+				ProductID:  productID.String(),
+				Content:    bytes.NewReader(tc.content),
+				Size:       size,
+				UploadedBy: userID,
+			})
+
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+				assert.Nil(t, photo)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, productID, photo.ProductID)
+			assert.Equal(t, "image/png", photo.ContentType)
+			assert.Equal(t, "products/"+productID.String()+"/"+photo.ID.String()+".png", photo.BlobKey)
+			assert.Equal(t, userID, photo.UploadedBy)
+		})
+	}
+}
+
+func TestDamageService_GetPhoto(t *testing.T) {
+	productID := uuid.NewString()
+	photoID := uuid.NewString()
+	photo := &entity.ProductPhoto{BlobKey: "products/p/f.png", ContentType: "image/png"}
+
+	testCases := []struct {
+		name          string
+		prepareRepos  func(photoRepo *mocks.ProductPhoto, blobStore *mocks.BlobStore)
+		expectedError error
+	}{
+		{
+			name: "photo returned",
+			prepareRepos: func(photoRepo *mocks.ProductPhoto, blobStore *mocks.BlobStore) {
+				photoRepo.On("GetByID", mock.Anything, productID, photoID).Return(photo, nil)
+				blobStore.On("Get", mock.Anything, photo.BlobKey).
+					Return(io.NopCloser(bytes.NewReader(pngHeader)), nil)
+			},
+		},
+		{
+			name: "photo not found",
+			prepareRepos: func(photoRepo *mocks.ProductPhoto, blobStore *mocks.BlobStore) {
+				photoRepo.On("GetByID", mock.Anything, productID, photoID).Return(nil, repoerr.ErrNoRows)
+			},
+			expectedError: ErrPhotoNotFound,
+		},
+		{
+			name: "file missing in blob store",
+			prepareRepos: func(photoRepo *mocks.ProductPhoto, blobStore *mocks.BlobStore) {
+				photoRepo.On("GetByID", mock.Anything, productID, photoID).Return(photo, nil)
+				blobStore.On("Get", mock.Anything, photo.BlobKey).Return(nil, repoerr.ErrNotFound)
+			},
+			expectedError: ErrPhotoNotFound,
+		},
+		{
+			name: "blob store error",
+			prepareRepos: func(photoRepo *mocks.ProductPhoto, blobStore *mocks.BlobStore) {
+				photoRepo.On("GetByID", mock.Anything, productID, photoID).Return(photo, nil)
+				blobStore.On("Get", mock.Anything, photo.BlobKey).Return(nil, errors.New("connection refused"))
+			},
+			expectedError: ErrInternal,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			photoRepo := mocks.NewProductPhoto(t)
+			blobStore := mocks.NewBlobStore(t)
+			tc.prepareRepos(photoRepo, blobStore)
+
+			service := NewDamageService(newPassthroughTransactor(t), mocks.NewProduct(t), photoRepo, blobStore)
+
+			found, content, err := service.GetPhoto(context.Background(), productID, photoID)
+
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+				assert.Nil(t, found)
+				assert.Nil(t, content)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, photo, found)
+			data, err := io.ReadAll(content)
+			assert.NoError(t, err)
+			assert.Equal(t, pngHeader, data)
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package service
+
+import (
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/entity"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	maxCarrierLength = 100
+	maxCommentLength = 1000
+)
+
+var (
+	waybillNumberPattern = regexp.MustCompile(`^[0-9A-ZА-ЯЁ][0-9A-ZА-ЯЁ/-]{0,49}$`)
+	vehiclePlatePattern  = regexp.MustCompile(`^[0-9A-ZА-ЯЁ]{4,12}$`)
+)
+
+// normalizeDelivery обрезает пробелы, приводит номера накладной и машины к верхнему
+// регистру и проверяет формат. Номер машины хранится без пробелов и дефисов.
+func normalizeDelivery(info entity.DeliveryInfo) (entity.DeliveryInfo, error) {
+	info = entity.DeliveryInfo{
+		Carrier:       strings.TrimSpace(info.Carrier),
+		WaybillNumber: normalizeWaybillNumber(info.WaybillNumber),
+		VehiclePlate:  strings.NewReplacer(" ", "", "-", "").Replace(strings.ToUpper(strings.TrimSpace(info.VehiclePlate))),
+		Comment:       strings.TrimSpace(info.Comment),
+	}
+
+	if utf8.RuneCountInString(info.Carrier) > maxCarrierLength {
+		return entity.DeliveryInfo{}, ErrInvalidCarrier
+	}
+	if info.WaybillNumber != "" && !waybillNumberPattern.MatchString(info.WaybillNumber) {
+		return entity.DeliveryInfo{}, ErrInvalidWaybill
+	}
+	if info.VehiclePlate != "" && !vehiclePlatePattern.MatchString(info.VehiclePlate) {
+		return entity.DeliveryInfo{}, ErrInvalidVehiclePlate
+	}
+	if utf8.RuneCountInString(info.Comment) > maxCommentLength {
+		return entity.DeliveryInfo{}, ErrCommentTooLong
+	}
+	return info, nil
+}
+
+func normalizeWaybillNumber(number string) string {
+	return strings.ToUpper(strings.TrimSpace(number))
+}
@@ -0,0 +1,76 @@
+package service
+
+import (
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/entity"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestNormalizeDelivery(t *testing.T) {
+	testCases := []struct {
+		name          string
+		info          entity.DeliveryInfo
+		expectedInfo  entity.DeliveryInfo
+		expectedError error
+	}{
+		{
+			name:         "empty info",
+			info:         entity.DeliveryInfo{},
+			expectedInfo: entity.DeliveryInfo{},
+		},
+		{
+			name: "normalized fields",
+			info: entity.DeliveryInfo{
+				Carrier:       "  СДЭК ",
+				WaybillNumber: " wb-2025/123 ",
+				VehiclePlate:  "а 123 вс-777",
+				Comment:       " две коробки помяты ",
+			},
+			expectedInfo: entity.DeliveryInfo{
+				Carrier:       "СДЭК",
+				WaybillNumber: "WB-2025/123",
+				VehiclePlate:  "А123ВС777",
+				Comment:       "две коробки помяты",
+			},
+		},
+		{
+			name:          "carrier too long",
+			info:          entity.DeliveryInfo{Carrier: strings.Repeat("п", maxCarrierLength+1)},
+			expectedError: ErrInvalidCarrier,
+		},
+		{
+			name:          "waybill with forbidden characters",
+			info:          entity.DeliveryInfo{WaybillNumber: "WB 123"},
+			expectedError: ErrInvalidWaybill,
+		},
+		{
+			name:          "waybill starting with dash",
+			info:          entity.DeliveryInfo{WaybillNumber: "-123"},
+			expectedError: ErrInvalidWaybill,
+		},
+		{
+			name:          "vehicle plate too short",
+			info:          entity.DeliveryInfo{VehiclePlate: "А12"},
+			expectedError: ErrInvalidVehiclePlate,
+		},
+		{
+			name:          "comment too long",
+			info:          entity.DeliveryInfo{Comment: strings.Repeat("к", maxCommentLength+1)},
+			expectedError: ErrCommentTooLong,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			info, err := normalizeDelivery(tc.info)
+
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedInfo, info)
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/entity"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/repo/repoerr"
+	"log/slog"
+	"strings"
+	"unicode/utf8"
+)
+
+const maxDockNameLength = 100
+
+// CreateDock добавляет в ПВЗ док, в котором можно вести отдельную приёмку.
+func (s *PVZService) CreateDock(ctx context.Context, pvzID, name string) (*entity.Dock, error) {
+	log := slog.With("layer", "PVZService", "operation", "CreateDock", "pvzID", pvzID)
+	log.Debug("starting dock creation")
+
+	name = strings.TrimSpace(name)
+	if name == "" || utf8.RuneCountInString(name) > maxDockNameLength {
+		log.Warn("invalid dock name")
+		return nil, ErrInvalidDockName
+	}
+
+	if !s.pvzRepo.Exists(ctx, pvzID) {
+		log.Error("pvz does not exist")
+		return nil, ErrInvalidPVZID
+	}
+
+	dock, err := s.dockRepo.Create(ctx, pvzID, name)
+	if err != nil {
+		if errors.Is(err, repoerr.ErrDuplicateEntry) {
+			log.Warn("dock already exists", "name", name)
+			return nil, ErrDockExists
+		}
+		log.Error("failed to create dock", "error", err)
+		return nil, ErrInternal
+	}
+
+	log.Info("dock created successfully", "dockID", dock.ID.String())
+	return dock, nil
+}
+
+func (s *PVZService) ListDocks(ctx context.Context, pvzID string) ([]entity.Dock, error) {
+	log := slog.With("layer", "PVZService", "operation", "ListDocks", "pvzID", pvzID)
+	log.Debug("starting list docks")
+
+	if !s.pvzRepo.Exists(ctx, pvzID) {
+		log.Error("pvz does not exist")
+		return nil, ErrInvalidPVZID
+	}
+
+	docks, err := s.dockRepo.List(ctx, pvzID)
+	if err != nil {
+		log.Error("failed to list docks", "error", err)
+		return nil, ErrInternal
+	}
+	return docks, nil
+}
@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/GlebMoskalev/go-pickup-point-api/config"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/entity"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/repo/mocks"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/repo/repoerr"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"strings"
+	"testing"
+)
+
+func TestPVZService_CreateDock(t *testing.T) {
+	pvzID := uuid.New()
+
+	testCases := []struct {
+		name          string
+		dockName      string
+		prepareRepos  func(pvzRepo *mocks.PVZ, dockRepo *mocks.Dock)
+		expectedError error
+	}{
+		{
+			name:     "successful creation",
+			dockName: "  ворота 2 ",
+			prepareRepos: func(pvzRepo *mocks.PVZ, dockRepo *mocks.Dock) {
+				pvzRepo.On("Exists", mock.Anything, pvzID.String()).Return(true)
+				dockRepo.On("Create", mock.Anything, pvzID.String(), "ворота 2").
+					Return(&entity.Dock{ID: uuid.New(), PVZID: pvzID, Name: "ворота 2"}, nil)
+			},
+		},
+		{
+			name:          "empty name",
+			dockName:      "   ",
+			prepareRepos:  func(pvzRepo *mocks.PVZ, dockRepo *mocks.Dock) {},
+			expectedError: ErrInvalidDockName,
+		},
+		{
+			name:          "name too long",
+			dockName:      strings.Repeat("д", maxDockNameLength+1),
+			prepareRepos:  func(pvzRepo *mocks.PVZ, dockRepo *mocks.Dock) {},
+			expectedError: ErrInvalidDockName,
+		},
+		{
+			name:     "pvz not found",
+			dockName: "ворота 2",
+			prepareRepos: func(pvzRepo *mocks.PVZ, dockRepo *mocks.Dock) {
+				pvzRepo.On("Exists", mock.Anything, pvzID.String()).Return(false)
+			},
+			expectedError: ErrInvalidPVZID,
+		},
+		{
+			name:     "duplicate name",
+			dockName: entity.DefaultDockName,
+			prepareRepos: func(pvzRepo *mocks.PVZ, dockRepo *mocks.Dock) {
+				pvzRepo.On("Exists", mock.Anything, pvzID.String()).Return(true)
+				dockRepo.On("Create", mock.Anything, pvzID.String(), entity.DefaultDockName).
+					Return(nil, repoerr.ErrDuplicateEntry)
+			},
+			expectedError: ErrDockExists,
+		},
+		{
+			name:     "repo error",
+			dockName: "ворота 2",
+			prepareRepos: func(pvzRepo *mocks.PVZ, dockRepo *mocks.Dock) {
+				pvzRepo.On("Exists", mock.Anything, pvzID.String()).Return(true)
+				dockRepo.On("Create", mock.Anything, pvzID.String(), "ворота 2").
+					Return(nil, errors.New("database error"))
+			},
+			expectedError: ErrInternal,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pvzRepo := mocks.NewPVZ(t)
+			dockRepo := mocks.NewDock(t)
+			tc.prepareRepos(pvzRepo, dockRepo)
+
+			service := NewPVZService(pvzRepo, dockRepo, mocks.NewStorageCell(t), config.InsuredValue{})
+
+			dock, err := service.CreateDock(context.Background(), pvzID.String(), tc.dockName)
+
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+				assert.Nil(t, dock)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, "ворота 2", dock.Name)
+		})
+	}
+}
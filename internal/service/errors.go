@@ -1,6 +1,10 @@
 package service
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/entity"
+)
 
 var (
 	ErrInternal = errors.New("internal server error")
@@ -14,8 +18,101 @@ var (
 
 	ErrInvalidCity         = errors.New("invalid city")
 	ErrInvalidPVZID        = errors.New("invalid pvz id")
+	ErrInvalidTimezone     = errors.New("invalid timezone")
+	ErrInvalidSchedule     = errors.New("invalid schedule")
+	ErrOutsideWorkingHours = errors.New("pvz is closed at this time")
 	ErrOpenReceptionExists = errors.New("open reception exists")
 	ErrNoOpenReception     = errors.New("no open reception exists")
+	ErrDockRequired        = errors.New("pvz has several open receptions, dock or reception id is required")
+	ErrInvalidDockID       = errors.New("invalid dock id")
+	ErrInvalidDockName     = errors.New("invalid dock name")
+	ErrDockExists          = errors.New("dock exists")
+	ErrInvalidManifest     = errors.New("invalid manifest")
+	ErrInvalidBlindCount   = errors.New("invalid blind count")
+	ErrBlindCountMismatch  = errors.New("blind count does not match recorded products")
+	ErrInvalidSettings     = errors.New("invalid pvz settings")
+	ErrInvalidCarrier      = errors.New("invalid carrier")
+	ErrInvalidWaybill      = errors.New("invalid waybill number")
+	ErrInvalidVehiclePlate = errors.New("invalid vehicle plate")
+	ErrCommentTooLong      = errors.New("comment is too long")
 	ErrInvalidProductType  = errors.New("invalid product type")
+	ErrInvalidAttributes   = errors.New("invalid product attributes")
 	ErrNoProducts          = errors.New("no products")
+	ErrInvalidBarcode      = errors.New("invalid barcode")
+	ErrInvalidValue        = errors.New("invalid declared value")
+	ErrBarcodeExists       = errors.New("product with this barcode is already stored")
+	ErrProductNotFound     = errors.New("product not found")
+	ErrInvalidBatch        = errors.New("invalid product batch")
+	ErrBatchRejected       = errors.New("product batch rejected")
+	ErrBarcodeRepeated     = errors.New("barcode is repeated in the batch")
+	ErrInvalidPickupCode   = errors.New("invalid pickup code")
+	ErrProductNotReceived  = errors.New("product reception is not closed")
+	ErrProductNotStored    = errors.New("product is not stored")
+
+	ErrInvalidReceptionKind   = errors.New("invalid reception kind")
+	ErrReturnReasonRequired   = errors.New("return reason is required")
+	ErrUnexpectedReturnReason = errors.New("return reason is allowed only in return receptions")
+	ErrReturnReasonTooLong    = errors.New("return reason is too long")
+
+	ErrInvalidTransfer       = errors.New("invalid transfer")
+	ErrTransferNotFound      = errors.New("transfer not found")
+	ErrInvalidTransferStatus = errors.New("invalid transfer status")
+	ErrProductInTransfer     = errors.New("product is already in an active transfer")
+	ErrTransferItemReceived  = errors.New("transfer item is already received")
+	ErrTransferReception     = errors.New("transfer reception accepts only transferred products")
+	ErrTransferItemsPending  = errors.New("transfer reception has items not yet received")
+
+	ErrInvalidStoragePeriod = errors.New("invalid storage period")
+	ErrProductNotOverdue    = errors.New("product storage period has not expired")
+
+	ErrInvalidDamage     = errors.New("invalid damage description")
+	ErrProductNotDamaged = errors.New("product is not marked damaged")
+	ErrInvalidPhoto      = errors.New("invalid photo")
+	ErrPhotoTooLarge     = errors.New("photo is too large")
+	ErrTooManyPhotos     = errors.New("too many photos")
+	ErrPhotoNotFound     = errors.New("photo not found")
+
+	ErrInvalidStorageCell  = errors.New("invalid storage cell")
+	ErrStorageCellExists   = errors.New("storage cell exists")
+	ErrStorageCellNotFound = errors.New("storage cell not found")
+	ErrStorageCellFull     = errors.New("storage cell is full")
+	ErrCellTypeMismatch    = errors.New("storage cell does not accept this product type")
+
+	ErrInvalidProductTypeName = errors.New("invalid product type name")
+	ErrInvalidAttributeSchema = errors.New("invalid attributes schema")
+	ErrProductTypeExists      = errors.New("product type exists")
+	ErrProductTypeNotFound    = errors.New("product type not found")
+
+	ErrReceptionNotFound      = errors.New("reception not found")
+	ErrInvalidReceptionStatus = errors.New("invalid reception status")
+	ErrInvalidTransition      = errors.New("invalid status transition")
+	ErrReasonRequired         = errors.New("reason is required")
+	ErrInvalidStalePolicy     = errors.New("invalid stale reception policy")
 )
+
+// TransitionError сообщает, что событие Event недопустимо для приёмки в статусе Current.
+type TransitionError struct {
+	Current string
+	Event   string
+}
+
+func (e *TransitionError) Error() string {
+	return fmt.Sprintf("%s: %s is not allowed from %s", ErrInvalidTransition, e.Event, e.Current)
+}
+
+func (e *TransitionError) Unwrap() error {
+	return ErrInvalidTransition
+}
+
+// BarcodeConflictError сообщает, что товар с таким штрихкодом уже хранится в ПВЗ.
+type BarcodeConflictError struct {
+	Existing entity.Product
+}
+
+func (e *BarcodeConflictError) Error() string {
+	return fmt.Sprintf("%s: %s", ErrBarcodeExists, e.Existing.ID)
+}
+
+func (e *BarcodeConflictError) Unwrap() error {
+	return ErrBarcodeExists
+}
@@ -0,0 +1,64 @@
+package service
+
+import (
+	"context"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/entity"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/metrics"
+	"log/slog"
+)
+
+// CheckInsuredValue пересчитывает объявленную ценность хранящихся товаров по всем ПВЗ, обновляет
+// метрики по валютам и возвращает ПВЗ, превысившие лимит застрахованной ценности. О каждом таком
+// ПВЗ пишется предупреждение в журнал. При нулевом лимите превышений не бывает.
+func (s *PVZService) CheckInsuredValue(ctx context.Context) ([]entity.PVZValue, error) {
+	log := slog.With("layer", "PVZService", "operation", "CheckInsuredValue", "ceiling", s.insuredValue.Ceiling,
+		"currency", s.insuredValue.Currency)
+	log.Debug("starting insured value check")
+
+	values, err := s.pvzRepo.ListStoredValues(ctx)
+	if err != nil {
+		log.Error("failed to list stored values", "error", err)
+		return nil, ErrInternal
+	}
+
+	totals := make(map[string]int64)
+	exceeded := []entity.PVZValue{}
+	for _, value := range values {
+		totals[value.Currency] += value.Amount
+		if !s.exceedsCeiling(value.ValueTotal) {
+			continue
+		}
+		log.Warn("pvz exceeds insured value ceiling", "pvzID", value.PVZID.String(), "storedValue", value.Amount,
+			"products", value.Count)
+		exceeded = append(exceeded, value)
+	}
+	s.reportStoredValue(totals)
+	metrics.PVZInsuredValueExceeded.WithLabelValues(s.insuredValue.Currency).Set(float64(len(exceeded)))
+
+	log.Info("insured value check finished", "pvzValues", len(values), "exceeded", len(exceeded))
+	return exceeded, nil
+}
+
+// reportStoredValue выставляет метрику ценности по валютам. Значения заменяются на месте, без
+// сброса метрики, чтобы сбор между сбросом и заполнением не увидел её пустой. Валюты, товаров
+// в которых больше нет, получают ноль.
+func (s *PVZService) reportStoredValue(totals map[string]int64) {
+	s.valueMu.Lock()
+	defer s.valueMu.Unlock()
+
+	for currency := range s.storedCurrencies {
+		if _, ok := totals[currency]; !ok {
+			metrics.StoredDeclaredValue.WithLabelValues(currency).Set(0)
+		}
+	}
+	for currency, amount := range totals {
+		metrics.StoredDeclaredValue.WithLabelValues(currency).Set(float64(amount))
+		s.storedCurrencies[currency] = struct{}{}
+	}
+}
+
+// exceedsCeiling сообщает, превышает ли сумма лимит; суммы в других валютах лимит не превышают.
+func (s *PVZService) exceedsCeiling(total entity.ValueTotal) bool {
+	return s.insuredValue.Ceiling > 0 && total.Currency == s.insuredValue.Currency &&
+		total.Amount > s.insuredValue.Ceiling
+}
@@ -0,0 +1,100 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/GlebMoskalev/go-pickup-point-api/config"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/entity"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/metrics"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/repo/mocks"
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+)
+
+func TestPVZService_CheckInsuredValue(t *testing.T) {
+	overLimit := uuid.New()
+	underLimit := uuid.New()
+	values := []entity.PVZValue{
+		{PVZID: overLimit, ValueTotal: entity.ValueTotal{Currency: "RUB", Amount: 2_000_000, Count: 12}},
+		{PVZID: underLimit, ValueTotal: entity.ValueTotal{Currency: "RUB", Amount: 1_000_000, Count: 3}},
+		{PVZID: underLimit, ValueTotal: entity.ValueTotal{Currency: "USD", Amount: 9_000_000, Count: 1}},
+	}
+
+	testCases := []struct {
+		name             string
+		insuredValue     config.InsuredValue
+		prepareRepo      func(repo *mocks.PVZ)
+		expectedExceeded []entity.PVZValue
+		expectedError    error
+	}{
+		{
+			name:         "pvz over ceiling reported",
+			insuredValue: config.InsuredValue{Ceiling: 1_000_000, Currency: "RUB"},
+			prepareRepo: func(repo *mocks.PVZ) {
+				repo.On("ListStoredValues", mock.Anything).Return(values, nil)
+			},
+			expectedExceeded: []entity.PVZValue{values[0]},
+		},
+		{
+			name:         "ceiling disabled",
+			insuredValue: config.InsuredValue{Currency: "RUB"},
+			prepareRepo: func(repo *mocks.PVZ) {
+				repo.On("ListStoredValues", mock.Anything).Return(values, nil)
+			},
+			expectedExceeded: []entity.PVZValue{},
+		},
+		{
+			name:         "repo error",
+			insuredValue: config.InsuredValue{Ceiling: 1_000_000, Currency: "RUB"},
+			prepareRepo: func(repo *mocks.PVZ) {
+				repo.On("ListStoredValues", mock.Anything).Return(nil, errors.New("database error"))
+			},
+			expectedError: ErrInternal,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pvzRepo := mocks.NewPVZ(t)
+			tc.prepareRepo(pvzRepo)
+			service := NewPVZService(pvzRepo, mocks.NewDock(t), mocks.NewStorageCell(t), tc.insuredValue)
+
+			exceeded, err := service.CheckInsuredValue(context.Background())
+
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+				assert.Nil(t, exceeded)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedExceeded, exceeded)
+			assert.Equal(t, float64(3_000_000), testutil.ToFloat64(metrics.StoredDeclaredValue.WithLabelValues("RUB")))
+			assert.Equal(t, float64(9_000_000), testutil.ToFloat64(metrics.StoredDeclaredValue.WithLabelValues("USD")))
+			assert.Equal(t, float64(len(tc.expectedExceeded)),
+				testutil.ToFloat64(metrics.PVZInsuredValueExceeded.WithLabelValues("RUB")))
+		})
+	}
+}
+
+func TestPVZService_CheckInsuredValueReplacesMetrics(t *testing.T) {
+	pvzID := uuid.New()
+	pvzRepo := mocks.NewPVZ(t)
+	pvzRepo.On("ListStoredValues", mock.Anything).Return([]entity.PVZValue{
+		{PVZID: pvzID, ValueTotal: entity.ValueTotal{Currency: "EUR", Amount: 500, Count: 1}},
+	}, nil).Once()
+	pvzRepo.On("ListStoredValues", mock.Anything).Return([]entity.PVZValue{}, nil).Once()
+	service := NewPVZService(pvzRepo, mocks.NewDock(t), mocks.NewStorageCell(t),
+		config.InsuredValue{Ceiling: 1_000_000, Currency: "RUB"})
+
+	_, err := service.CheckInsuredValue(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, float64(500), testutil.ToFloat64(metrics.StoredDeclaredValue.WithLabelValues("EUR")))
+
+	_, err = service.CheckInsuredValue(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, float64(0), testutil.ToFloat64(metrics.StoredDeclaredValue.WithLabelValues("EUR")),
+		"currency without stored products is reported as zero")
+}
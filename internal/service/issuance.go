@@ -0,0 +1,95 @@
+package service
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/entity"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/metrics"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/repo/repoerr"
+	"log/slog"
+	"strings"
+)
+
+// Issue выдаёт хранящийся товар покупателю. Товар должен относиться к ПВЗ выдачи и к закрытой
+// приёмке, а предъявленный код — совпадать с кодом получения товара. Кто и когда выдал товар,
+// сохраняется в товаре.
+func (s *ProductService) Issue(ctx context.Context, params entity.IssueParams) (*entity.Product, error) {
+	log := slog.With("layer", "ProductService", "operation", "Issue", "productID", params.ProductID,
+		"pvzID", params.PVZID, "userID", params.IssuedBy.String())
+	log.Debug("starting product issuance")
+
+	code := strings.TrimSpace(params.PickupCode)
+	if code == "" {
+		return nil, ErrInvalidPickupCode
+	}
+	if !s.pvzRepo.Exists(ctx, params.PVZID) {
+		log.Error("pvz does not exist")
+		return nil, ErrInvalidPVZID
+	}
+
+	var product *entity.Product
+	err := withinTx(ctx, s.transactor, log, func(ctx context.Context) error {
+		var err error
+		product, err = s.productRepo.GetByID(ctx, params.ProductID)
+		if err != nil {
+			if errors.Is(err, repoerr.ErrNoRows) {
+				log.Warn("product not found")
+				return ErrProductNotFound
+			}
+			log.Error("failed to get product", "error", err)
+			return ErrInternal
+		}
+		if product.DeletedAt != nil {
+			log.Warn("product is deleted")
+			return ErrProductNotFound
+		}
+
+		log = log.With("receptionID", product.ReceptionID.String())
+		// Блокировка приёмки не даёт переоткрыть её, пока товар выдаётся.
+		reception, err := s.receptionRepo.LockByID(ctx, product.ReceptionID.String())
+		if err != nil {
+			log.Error("failed to lock reception", "error", err)
+			return ErrInternal
+		}
+		if reception.PVZID.String() != params.PVZID {
+			log.Warn("product belongs to another pvz", "receptionPVZID", reception.PVZID.String())
+			return ErrProductNotFound
+		}
+		if reception.Status != entity.StatusClose && reception.Status != entity.StatusVerified {
+			log.Warn("reception is not closed", "status", reception.Status)
+			return ErrProductNotReceived
+		}
+
+		status, ok := entity.NextProductStatus(product.Status, entity.ProductEventIssue)
+		if !ok {
+			log.Warn("product cannot be issued", "status", product.Status)
+			return &TransitionError{Current: product.Status, Event: entity.ProductEventIssue}
+		}
+		if subtle.ConstantTimeCompare([]byte(code), []byte(product.PickupCode)) != 1 {
+			log.Warn("pickup code does not match")
+			return ErrInvalidPickupCode
+		}
+
+		issuedAt, err := s.productRepo.Issue(ctx, params.ProductID, params.IssuedBy)
+		if err != nil {
+			if errors.Is(err, repoerr.ErrNoRows) {
+				log.Warn("product status changed concurrently")
+				return ErrInvalidTransition
+			}
+			log.Error("failed to issue product", "error", err)
+			return ErrInternal
+		}
+		product.Status = status
+		product.IssuedBy = params.IssuedBy
+		product.IssuedAt = &issuedAt
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	metrics.ProductsIssued.Inc()
+	log.Info("product issued successfully")
+	return product, nil
+}
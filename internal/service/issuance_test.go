@@ -0,0 +1,177 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/entity"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/repo/mocks"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/repo/repoerr"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+	"time"
+)
+
+func TestProductService_Issue(t *testing.T) {
+	userID := uuid.New()
+	pvzID := uuid.New()
+	productID := uuid.New()
+	receptionID := uuid.New()
+	issuedAt := time.Now()
+	stored := entity.Product{ID: productID, Type: entity.ProductTypeShoes, ReceptionID: receptionID,
+		Status: entity.ProductStatusStored, PickupCode: "042917"}
+	closedReception := &entity.Reception{ID: receptionID, PVZID: pvzID, Status: entity.StatusClose}
+
+	withStatus := func(status string) *entity.Product {
+		product := stored
+		product.Status = status
+		return &product
+	}
+
+	testCases := []struct {
+		name          string
+		pickupCode    string
+		prepareRepos  func(productRepo *mocks.Product, receptionRepo *mocks.Reception, pvzRepo *mocks.PVZ)
+		expectedError error
+	}{
+		{
+			name:       "successful issuance",
+			pickupCode: " 042917 ",
+			prepareRepos: func(productRepo *mocks.Product, receptionRepo *mocks.Reception, pvzRepo *mocks.PVZ) {
+				pvzRepo.On("Exists", mock.Anything, pvzID.String()).Return(true)
+				productRepo.On("GetByID", mock.Anything, productID.String()).Return(withStatus(entity.ProductStatusStored), nil)
+				receptionRepo.On("LockByID", mock.Anything, receptionID.String()).Return(closedReception, nil)
+				productRepo.On("Issue", mock.Anything, productID.String(), userID).Return(issuedAt, nil)
+			},
+		},
+		{
+			name:       "verified reception",
+			pickupCode: "042917",
+			prepareRepos: func(productRepo *mocks.Product, receptionRepo *mocks.Reception, pvzRepo *mocks.PVZ) {
+				pvzRepo.On("Exists", mock.Anything, pvzID.String()).Return(true)
+				productRepo.On("GetByID", mock.Anything, productID.String()).Return(withStatus(entity.ProductStatusStored), nil)
+				receptionRepo.On("LockByID", mock.Anything, receptionID.String()).
+					Return(&entity.Reception{ID: receptionID, PVZID: pvzID, Status: entity.StatusVerified}, nil)
+				productRepo.On("Issue", mock.Anything, productID.String(), userID).Return(issuedAt, nil)
+			},
+		},
+		{
+			name:          "empty pickup code",
+			pickupCode:    "  ",
+			prepareRepos:  func(productRepo *mocks.Product, receptionRepo *mocks.Reception, pvzRepo *mocks.PVZ) {},
+			expectedError: ErrInvalidPickupCode,
+		},
+		{
+			name:       "wrong pickup code",
+			pickupCode: "000000",
+			prepareRepos: func(productRepo *mocks.Product, receptionRepo *mocks.Reception, pvzRepo *mocks.PVZ) {
+				pvzRepo.On("Exists", mock.Anything, pvzID.String()).Return(true)
+				productRepo.On("GetByID", mock.Anything, productID.String()).Return(withStatus(entity.ProductStatusStored), nil)
+				receptionRepo.On("LockByID", mock.Anything, receptionID.String()).Return(closedReception, nil)
+			},
+			expectedError: ErrInvalidPickupCode,
+		},
+		{
+			name:       "unknown pvz",
+			pickupCode: "042917",
+			prepareRepos: func(productRepo *mocks.Product, receptionRepo *mocks.Reception, pvzRepo *mocks.PVZ) {
+				pvzRepo.On("Exists", mock.Anything, pvzID.String()).Return(false)
+			},
+			expectedError: ErrInvalidPVZID,
+		},
+		{
+			name:       "product not found",
+			pickupCode: "042917",
+			prepareRepos: func(productRepo *mocks.Product, receptionRepo *mocks.Reception, pvzRepo *mocks.PVZ) {
+				pvzRepo.On("Exists", mock.Anything, pvzID.String()).Return(true)
+				productRepo.On("GetByID", mock.Anything, productID.String()).Return(nil, repoerr.ErrNoRows)
+			},
+			expectedError: ErrProductNotFound,
+		},
+		{
+			name:       "product of another pvz",
+			pickupCode: "042917",
+			prepareRepos: func(productRepo *mocks.Product, receptionRepo *mocks.Reception, pvzRepo *mocks.PVZ) {
+				pvzRepo.On("Exists", mock.Anything, pvzID.String()).Return(true)
+				productRepo.On("GetByID", mock.Anything, productID.String()).Return(withStatus(entity.ProductStatusStored), nil)
+				receptionRepo.On("LockByID", mock.Anything, receptionID.String()).
+					Return(&entity.Reception{ID: receptionID, PVZID: uuid.New(), Status: entity.StatusClose}, nil)
+			},
+			expectedError: ErrProductNotFound,
+		},
+		{
+			name:       "reception still open",
+			pickupCode: "042917",
+			prepareRepos: func(productRepo *mocks.Product, receptionRepo *mocks.Reception, pvzRepo *mocks.PVZ) {
+				pvzRepo.On("Exists", mock.Anything, pvzID.String()).Return(true)
+				productRepo.On("GetByID", mock.Anything, productID.String()).Return(withStatus(entity.ProductStatusStored), nil)
+				receptionRepo.On("LockByID", mock.Anything, receptionID.String()).
+					Return(&entity.Reception{ID: receptionID, PVZID: pvzID, Status: entity.StatusInProgress}, nil)
+			},
+			expectedError: ErrProductNotReceived,
+		},
+		{
+			name:       "already issued",
+			pickupCode: "042917",
+			prepareRepos: func(productRepo *mocks.Product, receptionRepo *mocks.Reception, pvzRepo *mocks.PVZ) {
+				pvzRepo.On("Exists", mock.Anything, pvzID.String()).Return(true)
+				productRepo.On("GetByID", mock.Anything, productID.String()).Return(withStatus(entity.ProductStatusIssued), nil)
+				receptionRepo.On("LockByID", mock.Anything, receptionID.String()).Return(closedReception, nil)
+			},
+			expectedError: ErrInvalidTransition,
+		},
+		{
+			name:       "issued concurrently",
+			pickupCode: "042917",
+			prepareRepos: func(productRepo *mocks.Product, receptionRepo *mocks.Reception, pvzRepo *mocks.PVZ) {
+				pvzRepo.On("Exists", mock.Anything, pvzID.String()).Return(true)
+				productRepo.On("GetByID", mock.Anything, productID.String()).Return(withStatus(entity.ProductStatusStored), nil)
+				receptionRepo.On("LockByID", mock.Anything, receptionID.String()).Return(closedReception, nil)
+				productRepo.On("Issue", mock.Anything, productID.String(), userID).Return(time.Time{}, repoerr.ErrNoRows)
+			},
+			expectedError: ErrInvalidTransition,
+		},
+		{
+			name:       "product repo error",
+			pickupCode: "042917",
+			prepareRepos: func(productRepo *mocks.Product, receptionRepo *mocks.Reception, pvzRepo *mocks.PVZ) {
+				pvzRepo.On("Exists", mock.Anything, pvzID.String()).Return(true)
+				productRepo.On("GetByID", mock.Anything, productID.String()).Return(withStatus(entity.ProductStatusStored), nil)
+				receptionRepo.On("LockByID", mock.Anything, receptionID.String()).Return(closedReception, nil)
+				productRepo.On("Issue", mock.Anything, productID.String(), userID).
+					Return(time.Time{}, errors.New("database error"))
+			},
+			expectedError: ErrInternal,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			productRepo := mocks.NewProduct(t)
+			receptionRepo := mocks.NewReception(t)
+			pvzRepo := mocks.NewPVZ(t)
+			tc.prepareRepos(productRepo, receptionRepo, pvzRepo)
+
+			service := NewProductService(newPassthroughTransactor(t), productRepo, receptionRepo, pvzRepo, newCatalogTypeRepo(t),
+				newEmptyCellRepo(t))
+
+			product, err := service.Issue(context.Background(), entity.IssueParams{
+				ProductID:  productID.String(),
+				PVZID:      pvzID.String(),
+				PickupCode: tc.pickupCode,
+				IssuedBy:   userID,
+			})
+
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+				assert.Nil(t, product)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, entity.ProductStatusIssued, product.Status)
+			assert.Equal(t, userID, product.IssuedBy)
+			assert.Equal(t, issuedAt, *product.IssuedAt)
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package service
+
+import (
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/entity"
+	"github.com/google/uuid"
+	"sort"
+)
+
+func validateManifest(manifest []entity.ManifestItem, known map[string]bool) error {
+	seen := make(map[string]bool, len(manifest))
+	for _, item := range manifest {
+		if !known[item.ProductType] || item.ExpectedCount <= 0 || seen[item.ProductType] {
+			return ErrInvalidManifest
+		}
+		seen[item.ProductType] = true
+	}
+	return nil
+}
+
+// buildDiscrepancyReport сверяет манифест с фактически принятыми товарами.
+// В отчёт попадают все типы из манифеста и все типы, принятые сверх него.
+func buildDiscrepancyReport(receptionID uuid.UUID, manifest []entity.ManifestItem,
+	received map[string]int) entity.DiscrepancyReport {
+	expected := make(map[string]int, len(manifest))
+	for _, item := range manifest {
+		expected[item.ProductType] = item.ExpectedCount
+	}
+
+	types := make([]string, 0, len(expected)+len(received))
+	for productType := range expected {
+		types = append(types, productType)
+	}
+	for productType := range received {
+		if _, ok := expected[productType]; !ok {
+			types = append(types, productType)
+		}
+	}
+	sort.Strings(types)
+
+	report := entity.DiscrepancyReport{
+		ReceptionID: receptionID,
+		Items:       make([]entity.DiscrepancyItem, len(types)),
+	}
+	for i, productType := range types {
+		report.Items[i] = entity.DiscrepancyItem{
+			ProductType:   productType,
+			ExpectedCount: expected[productType],
+			ReceivedCount: received[productType],
+		}
+	}
+	return report
+}
+
+func validateBlindCount(counted []entity.TypeCount, known map[string]bool) error {
+	seen := make(map[string]bool, len(counted))
+	for _, item := range counted {
+		if !known[item.ProductType] || item.Count < 0 || seen[item.ProductType] {
+			return ErrInvalidBlindCount
+		}
+		seen[item.ProductType] = true
+	}
+	return nil
+}
+
+// buildBlindCountReport сверяет пересчёт сотрудника с учтёнными товарами.
+// Тип, не указанный в пересчёте, считается пересчитанным с нулевым количеством.
+func buildBlindCountReport(receptionID uuid.UUID, counted []entity.TypeCount,
+	recorded map[string]int) entity.BlindCountReport {
+	counts := make(map[string]int, len(counted))
+	for _, item := range counted {
+		counts[item.ProductType] = item.Count
+	}
+
+	types := make([]string, 0, len(counts)+len(recorded))
+	for productType := range counts {
+		types = append(types, productType)
+	}
+	for productType := range recorded {
+		if _, ok := counts[productType]; !ok {
+			types = append(types, productType)
+		}
+	}
+	sort.Strings(types)
+
+	report := entity.BlindCountReport{
+		ReceptionID: receptionID,
+		Items:       make([]entity.BlindCountItem, len(types)),
+	}
+	for i, productType := range types {
+		report.Items[i] = entity.BlindCountItem{
+			ProductType:   productType,
+			CountedCount:  counts[productType],
+			RecordedCount: recorded[productType],
+		}
+	}
+	return report
+}
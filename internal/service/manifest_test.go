@@ -0,0 +1,83 @@
+package service
+
+import (
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/entity"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestBuildDiscrepancyReport(t *testing.T) {
+	receptionID := uuid.New()
+
+	testCases := []struct {
+		name                string
+		manifest            []entity.ManifestItem
+		received            map[string]int
+		expectedItems       []entity.DiscrepancyItem
+		expectDiscrepancies bool
+	}{
+		{
+			name:     "everything received",
+			manifest: []entity.ManifestItem{{ProductType: entity.ProductTypeShoes, ExpectedCount: 2}},
+			received: map[string]int{entity.ProductTypeShoes: 2},
+			expectedItems: []entity.DiscrepancyItem{
+				{ProductType: entity.ProductTypeShoes, ExpectedCount: 2, ReceivedCount: 2},
+			},
+			expectDiscrepancies: false,
+		},
+		{
+			name: "shortage and surplus",
+			manifest: []entity.ManifestItem{
+				{ProductType: entity.ProductTypeShoes, ExpectedCount: 2},
+				{ProductType: entity.ProductTypeClothes, ExpectedCount: 1},
+			},
+			received: map[string]int{entity.ProductTypeShoes: 1, entity.ProductTypeElectronics: 3},
+			expectedItems: []entity.DiscrepancyItem{
+				{ProductType: entity.ProductTypeShoes, ExpectedCount: 2, ReceivedCount: 1},
+				{ProductType: entity.ProductTypeClothes, ExpectedCount: 1, ReceivedCount: 0},
+				{ProductType: entity.ProductTypeElectronics, ExpectedCount: 0, ReceivedCount: 3},
+			},
+			expectDiscrepancies: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			report := buildDiscrepancyReport(receptionID, tc.manifest, tc.received)
+
+			assert.Equal(t, receptionID, report.ReceptionID)
+			assert.ElementsMatch(t, tc.expectedItems, report.Items)
+			assert.Equal(t, tc.expectDiscrepancies, report.HasDiscrepancies())
+		})
+	}
+}
+
+func TestDiscrepancyItemShortageSurplus(t *testing.T) {
+	item := entity.DiscrepancyItem{ExpectedCount: 5, ReceivedCount: 3}
+	assert.Equal(t, 2, item.Shortage())
+	assert.Equal(t, 0, item.Surplus())
+
+	item = entity.DiscrepancyItem{ExpectedCount: 1, ReceivedCount: 4}
+	assert.Equal(t, 0, item.Shortage())
+	assert.Equal(t, 3, item.Surplus())
+}
+
+func TestBuildBlindCountReport(t *testing.T) {
+	receptionID := uuid.New()
+	counted := []entity.TypeCount{
+		{ProductType: entity.ProductTypeShoes, Count: 2},
+		{ProductType: entity.ProductTypeClothes, Count: 0},
+	}
+	recorded := map[string]int{entity.ProductTypeShoes: 2, entity.ProductTypeElectronics: 1}
+
+	report := buildBlindCountReport(receptionID, counted, recorded)
+
+	assert.Equal(t, receptionID, report.ReceptionID)
+	assert.Equal(t, []entity.BlindCountItem{
+		{ProductType: entity.ProductTypeShoes, CountedCount: 2, RecordedCount: 2},
+		{ProductType: entity.ProductTypeClothes, CountedCount: 0, RecordedCount: 0},
+		{ProductType: entity.ProductTypeElectronics, CountedCount: 0, RecordedCount: 1},
+	}, report.Items)
+	assert.True(t, report.HasMismatch(), "uncounted recorded type is a mismatch")
+}
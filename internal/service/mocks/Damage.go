@@ -0,0 +1,160 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	io "io"
+
+	entity "github.com/GlebMoskalev/go-pickup-point-api/internal/entity"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Damage is an autogenerated mock type for the Damage type
+type Damage struct {
+	mock.Mock
+}
+
+// GetPhoto provides a mock function with given fields: ctx, productID, photoID
+func (_m *Damage) GetPhoto(ctx context.Context, productID string, photoID string) (*entity.ProductPhoto, io.ReadCloser, error) {
+	ret := _m.Called(ctx, productID, photoID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPhoto")
+	}
+
+	var r0 *entity.ProductPhoto
+	var r1 io.ReadCloser
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*entity.ProductPhoto, io.ReadCloser, error)); ok {
+		return rf(ctx, productID, photoID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *entity.ProductPhoto); ok {
+		r0 = rf(ctx, productID, photoID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.ProductPhoto)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) io.ReadCloser); ok {
+		r1 = rf(ctx, productID, photoID)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(io.ReadCloser)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, string) error); ok {
+		r2 = rf(ctx, productID, photoID)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ListPhotos provides a mock function with given fields: ctx, productID
+func (_m *Damage) ListPhotos(ctx context.Context, productID string) ([]entity.ProductPhoto, error) {
+	ret := _m.Called(ctx, productID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListPhotos")
+	}
+
+	var r0 []entity.ProductPhoto
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]entity.ProductPhoto, error)); ok {
+		return rf(ctx, productID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []entity.ProductPhoto); ok {
+		r0 = rf(ctx, productID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.ProductPhoto)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, productID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MarkDamaged provides a mock function with given fields: ctx, params
+func (_m *Damage) MarkDamaged(ctx context.Context, params entity.DamageParams) (*entity.Product, error) {
+	ret := _m.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkDamaged")
+	}
+
+	var r0 *entity.Product
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.DamageParams) (*entity.Product, error)); ok {
+		return rf(ctx, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.DamageParams) *entity.Product); ok {
+		r0 = rf(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Product)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.DamageParams) error); ok {
+		r1 = rf(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UploadPhoto provides a mock function with given fields: ctx, upload
+func (_m *Damage) UploadPhoto(ctx context.Context, upload entity.PhotoUpload) (*entity.ProductPhoto, error) {
+	ret := _m.Called(ctx, upload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UploadPhoto")
+	}
+
+	var r0 *entity.ProductPhoto
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.PhotoUpload) (*entity.ProductPhoto, error)); ok {
+		return rf(ctx, upload)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.PhotoUpload) *entity.ProductPhoto); ok {
+		r0 = rf(ctx, upload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.ProductPhoto)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.PhotoUpload) error); ok {
+		r1 = rf(ctx, upload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewDamage creates a new instance of Damage. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewDamage(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Damage {
+	mock := &Damage{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -7,8 +7,6 @@ import (
 
 	entity "github.com/GlebMoskalev/go-pickup-point-api/internal/entity"
 	mock "github.com/stretchr/testify/mock"
-
-	time "time"
 )
 
 // PVZ is an autogenerated mock type for the PVZ type
@@ -16,9 +14,39 @@ type PVZ struct {
 	mock.Mock
 }
 
-// Create provides a mock function with given fields: ctx, city
-func (_m *PVZ) Create(ctx context.Context, city string) (*entity.PVZ, error) {
-	ret := _m.Called(ctx, city)
+// CheckInsuredValue provides a mock function with given fields: ctx
+func (_m *PVZ) CheckInsuredValue(ctx context.Context) ([]entity.PVZValue, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckInsuredValue")
+	}
+
+	var r0 []entity.PVZValue
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]entity.PVZValue, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []entity.PVZValue); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.PVZValue)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Create provides a mock function with given fields: ctx, city, timezone
+func (_m *PVZ) Create(ctx context.Context, city string, timezone string) (*entity.PVZ, error) {
+	ret := _m.Called(ctx, city, timezone)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Create")
@@ -26,19 +54,109 @@ func (_m *PVZ) Create(ctx context.Context, city string) (*entity.PVZ, error) {
 
 	var r0 *entity.PVZ
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (*entity.PVZ, error)); ok {
-		return rf(ctx, city)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*entity.PVZ, error)); ok {
+		return rf(ctx, city, timezone)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.PVZ); ok {
-		r0 = rf(ctx, city)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *entity.PVZ); ok {
+		r0 = rf(ctx, city, timezone)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*entity.PVZ)
 		}
 	}
 
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, city, timezone)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateCell provides a mock function with given fields: ctx, cell
+func (_m *PVZ) CreateCell(ctx context.Context, cell entity.StorageCell) (*entity.StorageCell, error) {
+	ret := _m.Called(ctx, cell)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateCell")
+	}
+
+	var r0 *entity.StorageCell
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.StorageCell) (*entity.StorageCell, error)); ok {
+		return rf(ctx, cell)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.StorageCell) *entity.StorageCell); ok {
+		r0 = rf(ctx, cell)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.StorageCell)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.StorageCell) error); ok {
+		r1 = rf(ctx, cell)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateDock provides a mock function with given fields: ctx, pvzID, name
+func (_m *PVZ) CreateDock(ctx context.Context, pvzID string, name string) (*entity.Dock, error) {
+	ret := _m.Called(ctx, pvzID, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateDock")
+	}
+
+	var r0 *entity.Dock
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*entity.Dock, error)); ok {
+		return rf(ctx, pvzID, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *entity.Dock); ok {
+		r0 = rf(ctx, pvzID, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Dock)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, pvzID, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSchedule provides a mock function with given fields: ctx, pvzID
+func (_m *PVZ) GetSchedule(ctx context.Context, pvzID string) (*entity.PVZSchedule, error) {
+	ret := _m.Called(ctx, pvzID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSchedule")
+	}
+
+	var r0 *entity.PVZSchedule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*entity.PVZSchedule, error)); ok {
+		return rf(ctx, pvzID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.PVZSchedule); ok {
+		r0 = rf(ctx, pvzID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.PVZSchedule)
+		}
+	}
+
 	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, city)
+		r1 = rf(ctx, pvzID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -46,9 +164,129 @@ func (_m *PVZ) Create(ctx context.Context, city string) (*entity.PVZ, error) {
 	return r0, r1
 }
 
-// ListWithDetails provides a mock function with given fields: ctx, startDate, endDate, page, limit
-func (_m *PVZ) ListWithDetails(ctx context.Context, startDate *time.Time, endDate *time.Time, page int, limit int) ([]entity.PVZWithDetails, error) {
-	ret := _m.Called(ctx, startDate, endDate, page, limit)
+// GetSettings provides a mock function with given fields: ctx, pvzID
+func (_m *PVZ) GetSettings(ctx context.Context, pvzID string) (*entity.PVZSettings, error) {
+	ret := _m.Called(ctx, pvzID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSettings")
+	}
+
+	var r0 *entity.PVZSettings
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*entity.PVZSettings, error)); ok {
+		return rf(ctx, pvzID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.PVZSettings); ok {
+		r0 = rf(ctx, pvzID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.PVZSettings)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, pvzID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetStock provides a mock function with given fields: ctx, pvzID
+func (_m *PVZ) GetStock(ctx context.Context, pvzID string) (*entity.ProductStock, error) {
+	ret := _m.Called(ctx, pvzID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStock")
+	}
+
+	var r0 *entity.ProductStock
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*entity.ProductStock, error)); ok {
+		return rf(ctx, pvzID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.ProductStock); ok {
+		r0 = rf(ctx, pvzID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.ProductStock)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, pvzID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListCells provides a mock function with given fields: ctx, pvzID
+func (_m *PVZ) ListCells(ctx context.Context, pvzID string) ([]entity.StorageCell, error) {
+	ret := _m.Called(ctx, pvzID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListCells")
+	}
+
+	var r0 []entity.StorageCell
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]entity.StorageCell, error)); ok {
+		return rf(ctx, pvzID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []entity.StorageCell); ok {
+		r0 = rf(ctx, pvzID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.StorageCell)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, pvzID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListDocks provides a mock function with given fields: ctx, pvzID
+func (_m *PVZ) ListDocks(ctx context.Context, pvzID string) ([]entity.Dock, error) {
+	ret := _m.Called(ctx, pvzID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListDocks")
+	}
+
+	var r0 []entity.Dock
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]entity.Dock, error)); ok {
+		return rf(ctx, pvzID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []entity.Dock); ok {
+		r0 = rf(ctx, pvzID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Dock)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, pvzID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListWithDetails provides a mock function with given fields: ctx, filter, page, limit
+func (_m *PVZ) ListWithDetails(ctx context.Context, filter entity.PVZFilter, page int, limit int) ([]entity.PVZWithDetails, error) {
+	ret := _m.Called(ctx, filter, page, limit)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ListWithDetails")
@@ -56,19 +294,79 @@ func (_m *PVZ) ListWithDetails(ctx context.Context, startDate *time.Time, endDat
 
 	var r0 []entity.PVZWithDetails
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, *time.Time, *time.Time, int, int) ([]entity.PVZWithDetails, error)); ok {
-		return rf(ctx, startDate, endDate, page, limit)
+	if rf, ok := ret.Get(0).(func(context.Context, entity.PVZFilter, int, int) ([]entity.PVZWithDetails, error)); ok {
+		return rf(ctx, filter, page, limit)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, *time.Time, *time.Time, int, int) []entity.PVZWithDetails); ok {
-		r0 = rf(ctx, startDate, endDate, page, limit)
+	if rf, ok := ret.Get(0).(func(context.Context, entity.PVZFilter, int, int) []entity.PVZWithDetails); ok {
+		r0 = rf(ctx, filter, page, limit)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]entity.PVZWithDetails)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, *time.Time, *time.Time, int, int) error); ok {
-		r1 = rf(ctx, startDate, endDate, page, limit)
+	if rf, ok := ret.Get(1).(func(context.Context, entity.PVZFilter, int, int) error); ok {
+		r1 = rf(ctx, filter, page, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetSchedule provides a mock function with given fields: ctx, schedule
+func (_m *PVZ) SetSchedule(ctx context.Context, schedule entity.PVZSchedule) (*entity.PVZSchedule, error) {
+	ret := _m.Called(ctx, schedule)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetSchedule")
+	}
+
+	var r0 *entity.PVZSchedule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.PVZSchedule) (*entity.PVZSchedule, error)); ok {
+		return rf(ctx, schedule)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.PVZSchedule) *entity.PVZSchedule); ok {
+		r0 = rf(ctx, schedule)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.PVZSchedule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.PVZSchedule) error); ok {
+		r1 = rf(ctx, schedule)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetSettings provides a mock function with given fields: ctx, settings
+func (_m *PVZ) SetSettings(ctx context.Context, settings entity.PVZSettings) (*entity.PVZSettings, error) {
+	ret := _m.Called(ctx, settings)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetSettings")
+	}
+
+	var r0 *entity.PVZSettings
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.PVZSettings) (*entity.PVZSettings, error)); ok {
+		return rf(ctx, settings)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.PVZSettings) *entity.PVZSettings); ok {
+		r0 = rf(ctx, settings)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.PVZSettings)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.PVZSettings) error); ok {
+		r1 = rf(ctx, settings)
 	} else {
 		r1 = ret.Error(1)
 	}
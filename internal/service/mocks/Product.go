@@ -7,6 +7,8 @@ import (
 
 	entity "github.com/GlebMoskalev/go-pickup-point-api/internal/entity"
 	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
 )
 
 // Product is an autogenerated mock type for the Product type
@@ -14,21 +16,205 @@ type Product struct {
 	mock.Mock
 }
 
-// Create provides a mock function with given fields: ctx, pvzID, productType
-func (_m *Product) Create(ctx context.Context, pvzID string, productType string) (*entity.Product, error) {
-	ret := _m.Called(ctx, pvzID, productType)
+// AssignCell provides a mock function with given fields: ctx, params
+func (_m *Product) AssignCell(ctx context.Context, params entity.CellAssignment) (*entity.Product, error) {
+	ret := _m.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AssignCell")
+	}
+
+	var r0 *entity.Product
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.CellAssignment) (*entity.Product, error)); ok {
+		return rf(ctx, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.CellAssignment) *entity.Product); ok {
+		r0 = rf(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Product)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.CellAssignment) error); ok {
+		r1 = rf(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Create provides a mock function with given fields: ctx, params
+func (_m *Product) Create(ctx context.Context, params entity.ProductParams) (*entity.Product, error) {
+	ret := _m.Called(ctx, params)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Create")
 	}
 
+	var r0 *entity.Product
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.ProductParams) (*entity.Product, error)); ok {
+		return rf(ctx, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.ProductParams) *entity.Product); ok {
+		r0 = rf(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Product)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.ProductParams) error); ok {
+		r1 = rf(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateBatch provides a mock function with given fields: ctx, params
+func (_m *Product) CreateBatch(ctx context.Context, params entity.ProductBatchParams) (*entity.ProductBatchResult, error) {
+	ret := _m.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateBatch")
+	}
+
+	var r0 *entity.ProductBatchResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.ProductBatchParams) (*entity.ProductBatchResult, error)); ok {
+		return rf(ctx, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.ProductBatchParams) *entity.ProductBatchResult); ok {
+		r0 = rf(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.ProductBatchResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.ProductBatchParams) error); ok {
+		r1 = rf(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Delete provides a mock function with given fields: ctx, productID, pvzID, userID
+func (_m *Product) Delete(ctx context.Context, productID string, pvzID string, userID uuid.UUID) error {
+	ret := _m.Called(ctx, productID, pvzID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, uuid.UUID) error); ok {
+		r0 = rf(ctx, productID, pvzID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteLastProduct provides a mock function with given fields: ctx, target, userID
+func (_m *Product) DeleteLastProduct(ctx context.Context, target entity.ReceptionTarget, userID uuid.UUID) error {
+	ret := _m.Called(ctx, target, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteLastProduct")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.ReceptionTarget, uuid.UUID) error); ok {
+		r0 = rf(ctx, target, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FlagOverdue provides a mock function with given fields: ctx, defaultDays
+func (_m *Product) FlagOverdue(ctx context.Context, defaultDays int) (int, error) {
+	ret := _m.Called(ctx, defaultDays)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FlagOverdue")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (int, error)); ok {
+		return rf(ctx, defaultDays)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) int); ok {
+		r0 = rf(ctx, defaultDays)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, defaultDays)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByBarcode provides a mock function with given fields: ctx, barcode
+func (_m *Product) GetByBarcode(ctx context.Context, barcode string) (*entity.Product, error) {
+	ret := _m.Called(ctx, barcode)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByBarcode")
+	}
+
+	var r0 *entity.Product
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*entity.Product, error)); ok {
+		return rf(ctx, barcode)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.Product); ok {
+		r0 = rf(ctx, barcode)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Product)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, barcode)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByPickupCode provides a mock function with given fields: ctx, pvzID, code
+func (_m *Product) GetByPickupCode(ctx context.Context, pvzID string, code string) (*entity.Product, error) {
+	ret := _m.Called(ctx, pvzID, code)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByPickupCode")
+	}
+
 	var r0 *entity.Product
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*entity.Product, error)); ok {
-		return rf(ctx, pvzID, productType)
+		return rf(ctx, pvzID, code)
 	}
 	if rf, ok := ret.Get(0).(func(context.Context, string, string) *entity.Product); ok {
-		r0 = rf(ctx, pvzID, productType)
+		r0 = rf(ctx, pvzID, code)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*entity.Product)
@@ -36,7 +222,7 @@ func (_m *Product) Create(ctx context.Context, pvzID string, productType string)
 	}
 
 	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
-		r1 = rf(ctx, pvzID, productType)
+		r1 = rf(ctx, pvzID, code)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -44,22 +230,154 @@ func (_m *Product) Create(ctx context.Context, pvzID string, productType string)
 	return r0, r1
 }
 
-// DeleteLastProduct provides a mock function with given fields: ctx, pvzID
-func (_m *Product) DeleteLastProduct(ctx context.Context, pvzID string) error {
+// GetPickupCode provides a mock function with given fields: ctx, productID
+func (_m *Product) GetPickupCode(ctx context.Context, productID string) (*entity.Product, error) {
+	ret := _m.Called(ctx, productID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPickupCode")
+	}
+
+	var r0 *entity.Product
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*entity.Product, error)); ok {
+		return rf(ctx, productID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.Product); ok {
+		r0 = rf(ctx, productID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Product)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, productID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Issue provides a mock function with given fields: ctx, params
+func (_m *Product) Issue(ctx context.Context, params entity.IssueParams) (*entity.Product, error) {
+	ret := _m.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Issue")
+	}
+
+	var r0 *entity.Product
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.IssueParams) (*entity.Product, error)); ok {
+		return rf(ctx, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.IssueParams) *entity.Product); ok {
+		r0 = rf(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Product)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.IssueParams) error); ok {
+		r1 = rf(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListLocations provides a mock function with given fields: ctx, productID
+func (_m *Product) ListLocations(ctx context.Context, productID string) ([]entity.ProductLocation, error) {
+	ret := _m.Called(ctx, productID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListLocations")
+	}
+
+	var r0 []entity.ProductLocation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]entity.ProductLocation, error)); ok {
+		return rf(ctx, productID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []entity.ProductLocation); ok {
+		r0 = rf(ctx, productID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.ProductLocation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, productID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListOverdue provides a mock function with given fields: ctx, pvzID
+func (_m *Product) ListOverdue(ctx context.Context, pvzID string) ([]entity.Product, error) {
 	ret := _m.Called(ctx, pvzID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteLastProduct")
+		panic("no return value specified for ListOverdue")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+	var r0 []entity.Product
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]entity.Product, error)); ok {
+		return rf(ctx, pvzID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []entity.Product); ok {
 		r0 = rf(ctx, pvzID)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Product)
+		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, pvzID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReturnToSender provides a mock function with given fields: ctx, params
+func (_m *Product) ReturnToSender(ctx context.Context, params entity.ReturnToSenderParams) (*entity.Product, error) {
+	ret := _m.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReturnToSender")
+	}
+
+	var r0 *entity.Product
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.ReturnToSenderParams) (*entity.Product, error)); ok {
+		return rf(ctx, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.ReturnToSenderParams) *entity.Product); ok {
+		r0 = rf(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Product)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.ReturnToSenderParams) error); ok {
+		r1 = rf(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 // NewProduct creates a new instance of Product. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
@@ -0,0 +1,151 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	jsontext "encoding/json/jsontext"
+
+	entity "github.com/GlebMoskalev/go-pickup-point-api/internal/entity"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ProductType is an autogenerated mock type for the ProductType type
+type ProductType struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, productType
+func (_m *ProductType) Create(ctx context.Context, productType entity.ProductType) (*entity.ProductType, error) {
+	ret := _m.Called(ctx, productType)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 *entity.ProductType
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.ProductType) (*entity.ProductType, error)); ok {
+		return rf(ctx, productType)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.ProductType) *entity.ProductType); ok {
+		r0 = rf(ctx, productType)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.ProductType)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.ProductType) error); ok {
+		r1 = rf(ctx, productType)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// List provides a mock function with given fields: ctx
+func (_m *ProductType) List(ctx context.Context) ([]entity.ProductType, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []entity.ProductType
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]entity.ProductType, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []entity.ProductType); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.ProductType)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetSchema provides a mock function with given fields: ctx, name, schema
+func (_m *ProductType) SetSchema(ctx context.Context, name string, schema jsontext.Value) (*entity.ProductType, error) {
+	ret := _m.Called(ctx, name, schema)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetSchema")
+	}
+
+	var r0 *entity.ProductType
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, jsontext.Value) (*entity.ProductType, error)); ok {
+		return rf(ctx, name, schema)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, jsontext.Value) *entity.ProductType); ok {
+		r0 = rf(ctx, name, schema)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.ProductType)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, jsontext.Value) error); ok {
+		r1 = rf(ctx, name, schema)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetStorageDays provides a mock function with given fields: ctx, name, days
+func (_m *ProductType) SetStorageDays(ctx context.Context, name string, days int) (*entity.ProductType, error) {
+	ret := _m.Called(ctx, name, days)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetStorageDays")
+	}
+
+	var r0 *entity.ProductType
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) (*entity.ProductType, error)); ok {
+		return rf(ctx, name, days)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) *entity.ProductType); ok {
+		r0 = rf(ctx, name, days)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.ProductType)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = rf(ctx, name, days)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewProductType creates a new instance of ProductType. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewProductType(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ProductType {
+	mock := &ProductType{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
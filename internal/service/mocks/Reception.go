@@ -7,6 +7,10 @@ import (
 
 	entity "github.com/GlebMoskalev/go-pickup-point-api/internal/entity"
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+
+	uuid "github.com/google/uuid"
 )
 
 // Reception is an autogenerated mock type for the Reception type
@@ -14,27 +18,69 @@ type Reception struct {
 	mock.Mock
 }
 
-// CloseLastReception provides a mock function with given fields: ctx, pvzID
-func (_m *Reception) CloseLastReception(ctx context.Context, pvzID string) error {
-	ret := _m.Called(ctx, pvzID)
+// Cancel provides a mock function with given fields: ctx, receptionID, reason, userID
+func (_m *Reception) Cancel(ctx context.Context, receptionID string, reason string, userID uuid.UUID) (*entity.Reception, error) {
+	ret := _m.Called(ctx, receptionID, reason, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Cancel")
+	}
+
+	var r0 *entity.Reception
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, uuid.UUID) (*entity.Reception, error)); ok {
+		return rf(ctx, receptionID, reason, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, uuid.UUID) *entity.Reception); ok {
+		r0 = rf(ctx, receptionID, reason, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Reception)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, uuid.UUID) error); ok {
+		r1 = rf(ctx, receptionID, reason, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CloseLastReception provides a mock function with given fields: ctx, target, userID, counted
+func (_m *Reception) CloseLastReception(ctx context.Context, target entity.ReceptionTarget, userID uuid.UUID, counted []entity.TypeCount) (*entity.CloseResult, error) {
+	ret := _m.Called(ctx, target, userID, counted)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CloseLastReception")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
-		r0 = rf(ctx, pvzID)
+	var r0 *entity.CloseResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.ReceptionTarget, uuid.UUID, []entity.TypeCount) (*entity.CloseResult, error)); ok {
+		return rf(ctx, target, userID, counted)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.ReceptionTarget, uuid.UUID, []entity.TypeCount) *entity.CloseResult); ok {
+		r0 = rf(ctx, target, userID, counted)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.CloseResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.ReceptionTarget, uuid.UUID, []entity.TypeCount) error); ok {
+		r1 = rf(ctx, target, userID, counted)
 	} else {
-		r0 = ret.Error(0)
+		r1 = ret.Error(1)
 	}
 
-	return r0
+	return r0, r1
 }
 
-// Create provides a mock function with given fields: ctx, pvzID
-func (_m *Reception) Create(ctx context.Context, pvzID string) (*entity.Reception, error) {
-	ret := _m.Called(ctx, pvzID)
+// Create provides a mock function with given fields: ctx, params
+func (_m *Reception) Create(ctx context.Context, params entity.ReceptionParams) (*entity.Reception, error) {
+	ret := _m.Called(ctx, params)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Create")
@@ -42,19 +88,167 @@ func (_m *Reception) Create(ctx context.Context, pvzID string) (*entity.Receptio
 
 	var r0 *entity.Reception
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (*entity.Reception, error)); ok {
-		return rf(ctx, pvzID)
+	if rf, ok := ret.Get(0).(func(context.Context, entity.ReceptionParams) (*entity.Reception, error)); ok {
+		return rf(ctx, params)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.Reception); ok {
-		r0 = rf(ctx, pvzID)
+	if rf, ok := ret.Get(0).(func(context.Context, entity.ReceptionParams) *entity.Reception); ok {
+		r0 = rf(ctx, params)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*entity.Reception)
 		}
 	}
 
+	if rf, ok := ret.Get(1).(func(context.Context, entity.ReceptionParams) error); ok {
+		r1 = rf(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAcceptanceAct provides a mock function with given fields: ctx, receptionID
+func (_m *Reception) GetAcceptanceAct(ctx context.Context, receptionID string) (*entity.AcceptanceAct, error) {
+	ret := _m.Called(ctx, receptionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAcceptanceAct")
+	}
+
+	var r0 *entity.AcceptanceAct
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*entity.AcceptanceAct, error)); ok {
+		return rf(ctx, receptionID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.AcceptanceAct); ok {
+		r0 = rf(ctx, receptionID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.AcceptanceAct)
+		}
+	}
+
 	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, pvzID)
+		r1 = rf(ctx, receptionID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ProcessStaleReceptions provides a mock function with given fields: ctx, maxAge, policy
+func (_m *Reception) ProcessStaleReceptions(ctx context.Context, maxAge time.Duration, policy string) (int, error) {
+	ret := _m.Called(ctx, maxAge, policy)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ProcessStaleReceptions")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Duration, string) (int, error)); ok {
+		return rf(ctx, maxAge, policy)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Duration, string) int); ok {
+		r0 = rf(ctx, maxAge, policy)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Duration, string) error); ok {
+		r1 = rf(ctx, maxAge, policy)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Reopen provides a mock function with given fields: ctx, receptionID, reason, userID
+func (_m *Reception) Reopen(ctx context.Context, receptionID string, reason string, userID uuid.UUID) (*entity.Reception, error) {
+	ret := _m.Called(ctx, receptionID, reason, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Reopen")
+	}
+
+	var r0 *entity.Reception
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, uuid.UUID) (*entity.Reception, error)); ok {
+		return rf(ctx, receptionID, reason, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, uuid.UUID) *entity.Reception); ok {
+		r0 = rf(ctx, receptionID, reason, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Reception)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, uuid.UUID) error); ok {
+		r1 = rf(ctx, receptionID, reason, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Start provides a mock function with given fields: ctx, receptionID, comment, userID
+func (_m *Reception) Start(ctx context.Context, receptionID string, comment string, userID uuid.UUID) (*entity.Reception, error) {
+	ret := _m.Called(ctx, receptionID, comment, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Start")
+	}
+
+	var r0 *entity.Reception
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, uuid.UUID) (*entity.Reception, error)); ok {
+		return rf(ctx, receptionID, comment, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, uuid.UUID) *entity.Reception); ok {
+		r0 = rf(ctx, receptionID, comment, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Reception)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, uuid.UUID) error); ok {
+		r1 = rf(ctx, receptionID, comment, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Verify provides a mock function with given fields: ctx, receptionID, comment, userID
+func (_m *Reception) Verify(ctx context.Context, receptionID string, comment string, userID uuid.UUID) (*entity.Reception, error) {
+	ret := _m.Called(ctx, receptionID, comment, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Verify")
+	}
+
+	var r0 *entity.Reception
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, uuid.UUID) (*entity.Reception, error)); ok {
+		return rf(ctx, receptionID, comment, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, uuid.UUID) *entity.Reception); ok {
+		r0 = rf(ctx, receptionID, comment, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Reception)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, uuid.UUID) error); ok {
+		r1 = rf(ctx, receptionID, comment, userID)
 	} else {
 		r1 = ret.Error(1)
 	}
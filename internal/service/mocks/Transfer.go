@@ -0,0 +1,181 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	entity "github.com/GlebMoskalev/go-pickup-point-api/internal/entity"
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
+)
+
+// Transfer is an autogenerated mock type for the Transfer type
+type Transfer struct {
+	mock.Mock
+}
+
+// Cancel provides a mock function with given fields: ctx, transferID, userID
+func (_m *Transfer) Cancel(ctx context.Context, transferID string, userID uuid.UUID) (*entity.Transfer, error) {
+	ret := _m.Called(ctx, transferID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Cancel")
+	}
+
+	var r0 *entity.Transfer
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID) (*entity.Transfer, error)); ok {
+		return rf(ctx, transferID, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID) *entity.Transfer); ok {
+		r0 = rf(ctx, transferID, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Transfer)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, uuid.UUID) error); ok {
+		r1 = rf(ctx, transferID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Create provides a mock function with given fields: ctx, params
+func (_m *Transfer) Create(ctx context.Context, params entity.TransferParams) (*entity.Transfer, error) {
+	ret := _m.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 *entity.Transfer
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.TransferParams) (*entity.Transfer, error)); ok {
+		return rf(ctx, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.TransferParams) *entity.Transfer); ok {
+		r0 = rf(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Transfer)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.TransferParams) error); ok {
+		r1 = rf(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Dispatch provides a mock function with given fields: ctx, transferID, userID
+func (_m *Transfer) Dispatch(ctx context.Context, transferID string, userID uuid.UUID) (*entity.Transfer, error) {
+	ret := _m.Called(ctx, transferID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Dispatch")
+	}
+
+	var r0 *entity.Transfer
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID) (*entity.Transfer, error)); ok {
+		return rf(ctx, transferID, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID) *entity.Transfer); ok {
+		r0 = rf(ctx, transferID, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Transfer)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, uuid.UUID) error); ok {
+		r1 = rf(ctx, transferID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Get provides a mock function with given fields: ctx, transferID
+func (_m *Transfer) Get(ctx context.Context, transferID string) (*entity.Transfer, error) {
+	ret := _m.Called(ctx, transferID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *entity.Transfer
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*entity.Transfer, error)); ok {
+		return rf(ctx, transferID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.Transfer); ok {
+		r0 = rf(ctx, transferID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Transfer)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, transferID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReceiveItem provides a mock function with given fields: ctx, transferID, productID, userID
+func (_m *Transfer) ReceiveItem(ctx context.Context, transferID string, productID string, userID uuid.UUID) (*entity.Product, error) {
+	ret := _m.Called(ctx, transferID, productID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReceiveItem")
+	}
+
+	var r0 *entity.Product
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, uuid.UUID) (*entity.Product, error)); ok {
+		return rf(ctx, transferID, productID, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, uuid.UUID) *entity.Product); ok {
+		r0 = rf(ctx, transferID, productID, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Product)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, uuid.UUID) error); ok {
+		r1 = rf(ctx, transferID, productID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewTransfer creates a new instance of Transfer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewTransfer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Transfer {
+	mock := &Transfer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,58 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/entity"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/repo/repoerr"
+	"log/slog"
+	"strings"
+)
+
+// GetPickupCode возвращает товар с его кодом получения. Удалённый товар не найдётся.
+func (s *ProductService) GetPickupCode(ctx context.Context, productID string) (*entity.Product, error) {
+	log := slog.With("layer", "ProductService", "operation", "GetPickupCode", "productID", productID)
+	log.Debug("starting get pickup code")
+
+	product, err := s.productRepo.GetByID(ctx, productID)
+	if err != nil {
+		if errors.Is(err, repoerr.ErrNoRows) {
+			log.Warn("product not found")
+			return nil, ErrProductNotFound
+		}
+		log.Error("failed to get product", "error", err)
+		return nil, ErrInternal
+	}
+	if product.DeletedAt != nil {
+		log.Warn("product is deleted")
+		return nil, ErrProductNotFound
+	}
+	return product, nil
+}
+
+// GetByPickupCode находит хранящийся в ПВЗ товар по коду получения. Коды уникальны только
+// в пределах ПВЗ, поэтому поиск всегда ограничен одним ПВЗ.
+func (s *ProductService) GetByPickupCode(ctx context.Context, pvzID, code string) (*entity.Product, error) {
+	log := slog.With("layer", "ProductService", "operation", "GetByPickupCode", "pvzID", pvzID)
+	log.Debug("starting get product by pickup code")
+
+	code = strings.TrimSpace(code)
+	if code == "" {
+		return nil, ErrInvalidPickupCode
+	}
+	if !s.pvzRepo.Exists(ctx, pvzID) {
+		log.Error("pvz does not exist")
+		return nil, ErrInvalidPVZID
+	}
+
+	product, err := s.productRepo.GetByPickupCode(ctx, pvzID, code)
+	if err != nil {
+		if errors.Is(err, repoerr.ErrNoRows) {
+			log.Warn("product not found")
+			return nil, ErrProductNotFound
+		}
+		log.Error("failed to get product", "error", err)
+		return nil, ErrInternal
+	}
+	return product, nil
+}
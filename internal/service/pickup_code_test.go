@@ -0,0 +1,150 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/entity"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/repo/mocks"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/repo/repoerr"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+	"time"
+)
+
+func TestProductService_GetPickupCode(t *testing.T) {
+	productID := uuid.New().String()
+	deletedAt := time.Now()
+	stored := &entity.Product{ID: uuid.New(), Status: entity.ProductStatusStored, PickupCode: "042917"}
+
+	testCases := []struct {
+		name          string
+		prepareRepo   func(productRepo *mocks.Product)
+		expectedError error
+	}{
+		{
+			name: "successful get",
+			prepareRepo: func(productRepo *mocks.Product) {
+				productRepo.On("GetByID", mock.Anything, productID).Return(stored, nil)
+			},
+		},
+		{
+			name: "product not found",
+			prepareRepo: func(productRepo *mocks.Product) {
+				productRepo.On("GetByID", mock.Anything, productID).Return(nil, repoerr.ErrNoRows)
+			},
+			expectedError: ErrProductNotFound,
+		},
+		{
+			name: "product deleted",
+			prepareRepo: func(productRepo *mocks.Product) {
+				productRepo.On("GetByID", mock.Anything, productID).
+					Return(&entity.Product{ID: uuid.New(), DeletedAt: &deletedAt}, nil)
+			},
+			expectedError: ErrProductNotFound,
+		},
+		{
+			name: "repository error",
+			prepareRepo: func(productRepo *mocks.Product) {
+				productRepo.On("GetByID", mock.Anything, productID).Return(nil, errors.New("database error"))
+			},
+			expectedError: ErrInternal,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			productRepo := mocks.NewProduct(t)
+			tc.prepareRepo(productRepo)
+
+			service := NewProductService(newPassthroughTransactor(t), productRepo, mocks.NewReception(t),
+				mocks.NewPVZ(t), newCatalogTypeRepo(t), newEmptyCellRepo(t))
+
+			product, err := service.GetPickupCode(context.Background(), productID)
+
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+				assert.Nil(t, product)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, stored, product)
+		})
+	}
+}
+
+func TestProductService_GetByPickupCode(t *testing.T) {
+	pvzID := uuid.New().String()
+	stored := &entity.Product{ID: uuid.New(), Status: entity.ProductStatusStored, PickupCode: "042917"}
+
+	testCases := []struct {
+		name          string
+		code          string
+		prepareRepos  func(productRepo *mocks.Product, pvzRepo *mocks.PVZ)
+		expectedError error
+	}{
+		{
+			name: "successful lookup",
+			code: " 042917 ",
+			prepareRepos: func(productRepo *mocks.Product, pvzRepo *mocks.PVZ) {
+				pvzRepo.On("Exists", mock.Anything, pvzID).Return(true)
+				productRepo.On("GetByPickupCode", mock.Anything, pvzID, "042917").Return(stored, nil)
+			},
+		},
+		{
+			name:          "empty code",
+			code:          " ",
+			prepareRepos:  func(productRepo *mocks.Product, pvzRepo *mocks.PVZ) {},
+			expectedError: ErrInvalidPickupCode,
+		},
+		{
+			name: "pvz does not exist",
+			code: "042917",
+			prepareRepos: func(productRepo *mocks.Product, pvzRepo *mocks.PVZ) {
+				pvzRepo.On("Exists", mock.Anything, pvzID).Return(false)
+			},
+			expectedError: ErrInvalidPVZID,
+		},
+		{
+			name: "product not found",
+			code: "042917",
+			prepareRepos: func(productRepo *mocks.Product, pvzRepo *mocks.PVZ) {
+				pvzRepo.On("Exists", mock.Anything, pvzID).Return(true)
+				productRepo.On("GetByPickupCode", mock.Anything, pvzID, "042917").Return(nil, repoerr.ErrNoRows)
+			},
+			expectedError: ErrProductNotFound,
+		},
+		{
+			name: "repository error",
+			code: "042917",
+			prepareRepos: func(productRepo *mocks.Product, pvzRepo *mocks.PVZ) {
+				pvzRepo.On("Exists", mock.Anything, pvzID).Return(true)
+				productRepo.On("GetByPickupCode", mock.Anything, pvzID, "042917").
+					Return(nil, errors.New("database error"))
+			},
+			expectedError: ErrInternal,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			productRepo := mocks.NewProduct(t)
+			pvzRepo := mocks.NewPVZ(t)
+			tc.prepareRepos(productRepo, pvzRepo)
+
+			service := NewProductService(newPassthroughTransactor(t), productRepo, mocks.NewReception(t),
+				pvzRepo, newCatalogTypeRepo(t), newEmptyCellRepo(t))
+
+			product, err := service.GetByPickupCode(context.Background(), pvzID, tc.code)
+
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+				assert.Nil(t, product)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, stored, product)
+		})
+	}
+}
@@ -2,96 +2,325 @@ package service
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"github.com/GlebMoskalev/go-pickup-point-api/internal/entity"
-	"github.com/GlebMoskalev/go-pickup-point-api/internal/metrics"
 	"github.com/GlebMoskalev/go-pickup-point-api/internal/repo"
 	"github.com/GlebMoskalev/go-pickup-point-api/internal/repo/repoerr"
+	"github.com/google/uuid"
 	"log/slog"
+	"regexp"
+	"strings"
 )
 
+var (
+	barcodePattern  = regexp.MustCompile(`^[0-9A-Z][0-9A-Z-]{3,63}$`)
+	currencyPattern = regexp.MustCompile(`^[A-Z]{3}$`)
+)
+
+// maxDeclaredValue — наибольшая объявленная ценность товара в минимальных единицах валюты:
+// 10 млрд в основных единицах. Ограничение не даёт суммам по ПВЗ переполнить int64.
+const maxDeclaredValue = 1_000_000_000_000
+
 type ProductService struct {
+	transactor    repo.Transactor
 	productRepo   repo.Product
 	receptionRepo repo.Reception
 	pvzRepo       repo.PVZ
+	typeRepo      repo.ProductType
+	cellRepo      repo.StorageCell
 }
 
-func NewProductService(productRepo repo.Product, receptionRepo repo.Reception, pvzRepo repo.PVZ) *ProductService {
+func NewProductService(transactor repo.Transactor, productRepo repo.Product, receptionRepo repo.Reception,
+	pvzRepo repo.PVZ, typeRepo repo.ProductType, cellRepo repo.StorageCell) *ProductService {
 	return &ProductService{
+		transactor:    transactor,
 		productRepo:   productRepo,
 		receptionRepo: receptionRepo,
 		pvzRepo:       pvzRepo,
+		typeRepo:      typeRepo,
+		cellRepo:      cellRepo,
 	}
 }
 
-func (s *ProductService) Create(ctx context.Context, pvzID, productType string) (*entity.Product, error) {
-	log := slog.With("layer", "ProductService", "operation", "Create", "pvzID", pvzID, "type", productType)
+func (s *ProductService) Create(ctx context.Context, params entity.ProductParams) (*entity.Product, error) {
+	log := slog.With("layer", "ProductService", "operation", "Create", "pvzID", params.PVZID,
+		"dockID", params.DockID, "receptionID", params.ReceptionID, "type", params.Type,
+		"userID", params.AddedBy.String())
 	log.Debug("starting product creation")
 
-	if productType != entity.ProductTypeClothes &&
-		productType != entity.ProductTypeElectronics &&
-		productType != entity.ProductTypeShoes {
-		return nil, ErrInvalidProductType
-	}
-
-	if !s.pvzRepo.Exists(ctx, pvzID) {
-		log.Error("pvz does not exist")
-		return nil, ErrInvalidPVZID
-	}
-
-	reception, err := s.receptionRepo.GetLastOpenReception(ctx, pvzID)
+	productType, err := s.typeRepo.GetByName(ctx, params.Type)
 	if err != nil {
 		if errors.Is(err, repoerr.ErrNoRows) {
-			log.Error("not found open error")
-			return nil, ErrNoOpenReception
+			log.Warn("unknown product type")
+			return nil, ErrInvalidProductType
 		}
-		log.Error("failed to check open reception", "error", err)
+		log.Error("failed to get product type", "error", err)
 		return nil, ErrInternal
 	}
 
-	product, err := s.productRepo.Create(ctx, reception.ID.String(), productType)
+	params.Barcode, params.Attributes, err = prepareProduct(*productType, params.Barcode, params.Attributes)
 	if err != nil {
-		log.Error("failed to create product", "error", err)
-		return nil, ErrInternal
+		log.Warn("invalid product", "error", err)
+		return nil, err
+	}
+	currency, err := prepareDeclaredValue(params.DeclaredValue, params.Currency)
+	if err != nil {
+		log.Warn("invalid declared value", "value", params.DeclaredValue, "currency", params.Currency)
+		return nil, err
 	}
+	params.Currency = currency
 
-	metrics.ProductsAdded.Inc()
-	log.Info("product created successfully", "productID", product.ID.String(), "receptionID", reception.ID.String())
+	if err := validateTarget(ctx, s.pvzRepo, log, params.ReceptionTarget); err != nil {
+		return nil, err
+	}
+
+	var (
+		product *entity.Product
+		kind    string
+	)
+	err = withinTx(ctx, s.transactor, log, func(ctx context.Context) error {
+		reception, err := lockOpenReception(ctx, s.receptionRepo, log, params.ReceptionTarget)
+		if err != nil {
+			return err
+		}
+		kind = reception.Kind
+		if reception.Kind == entity.ReceptionKindTransfer {
+			log.Warn("products of transfer reception are managed by transfer")
+			return ErrTransferReception
+		}
+
+		reason, err := prepareReturnReason(reception.Kind, params.ReturnReason)
+		if err != nil {
+			log.Warn("invalid return reason", "kind", reception.Kind, "error", err)
+			return err
+		}
+
+		products := []entity.Product{{
+			ReceptionID:   reception.ID,
+			Type:          params.Type,
+			Barcode:       params.Barcode,
+			Attributes:    params.Attributes,
+			ReturnReason:  reason,
+			DeclaredValue: params.DeclaredValue,
+			Currency:      params.Currency,
+			AddedBy:       params.AddedBy,
+		}}
+		if reception.Kind == entity.ReceptionKindReturn {
+			if err := s.linkOriginals(ctx, log, products); err != nil {
+				return err
+			}
+		}
+		if err := assignCells(ctx, s.cellRepo, log, reception.PVZID.String(), products); err != nil {
+			return err
+		}
+
+		product, err = s.productRepo.Create(ctx, products[0])
+		if err != nil {
+			if errors.Is(err, repoerr.ErrDuplicateEntry) {
+				return s.barcodeConflict(ctx, log, params.Barcode)
+			}
+			log.Error("failed to create product", "error", err)
+			return ErrInternal
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	countAdded(kind, 1)
+	log.Info("product created successfully", "productID", product.ID.String(), "receptionID", product.ReceptionID.String())
 	return product, nil
 }
 
-func (s *ProductService) DeleteLastProduct(ctx context.Context, pvzID string) error {
-	log := slog.With("layer", "ProductService", "operation", "DeleteLastProduct", "pvzID", pvzID)
+func (s *ProductService) DeleteLastProduct(ctx context.Context, target entity.ReceptionTarget, userID uuid.UUID) error {
+	log := slog.With("layer", "ProductService", "operation", "DeleteLastProduct", "pvzID", target.PVZID,
+		"dockID", target.DockID, "receptionID", target.ReceptionID, "userID", userID.String())
+	log.Debug("starting product deletion")
+
+	if err := validateTarget(ctx, s.pvzRepo, log, target); err != nil {
+		return err
+	}
+
+	err := withinTx(ctx, s.transactor, log, func(ctx context.Context) error {
+		reception, err := lockOpenReception(ctx, s.receptionRepo, log, target)
+		if err != nil {
+			return err
+		}
+
+		log = log.With("receptionID", reception.ID.String())
+		if reception.Kind == entity.ReceptionKindTransfer {
+			log.Warn("products of transfer reception are managed by transfer")
+			return ErrTransferReception
+		}
+
+		err = s.productRepo.DeleteLastProduct(ctx, reception.ID.String(), userID)
+		if err != nil {
+			if errors.Is(err, repoerr.ErrNoRows) {
+				return s.lastProductNotDeleted(ctx, log, reception.ID.String())
+			}
+			log.Error("failed to delete product", "error", err)
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	log.Info("product deleted successfully")
+	return nil
+}
+
+// lastProductNotDeleted объясняет, почему последний товар приёмки не удалён: в приёмке нет
+// товаров или последний из них уже не хранится.
+func (s *ProductService) lastProductNotDeleted(ctx context.Context, log *slog.Logger, receptionID string) error {
+	counts, err := s.productRepo.CountByType(ctx, receptionID)
+	if err != nil {
+		log.Error("failed to count products", "error", err)
+		return ErrInternal
+	}
+	if len(counts) > 0 {
+		log.Warn("last product is not stored")
+		return ErrProductNotStored
+	}
+	log.Error("not found product")
+	return ErrNoProducts
+}
+
+// Delete удаляет конкретный товар из его приёмки, пока она открыта. Товар помечается
+// удалённым с автором и временем удаления. Товар должен принадлежать ПВЗ pvzID и храниться:
+// выданный, отправленный или возвращённый после переоткрытия приёмки товар удалить нельзя.
+func (s *ProductService) Delete(ctx context.Context, productID, pvzID string, userID uuid.UUID) error {
+	log := slog.With("layer", "ProductService", "operation", "Delete", "productID", productID, "pvzID", pvzID,
+		"userID", userID.String())
 	log.Debug("starting product deletion")
 
-	if !s.pvzRepo.Exists(ctx, pvzID) {
+	if pvzID == "" || !s.pvzRepo.Exists(ctx, pvzID) {
 		log.Error("pvz does not exist")
 		return ErrInvalidPVZID
 	}
 
-	reception, err := s.receptionRepo.GetLastOpenReception(ctx, pvzID)
-	if err != nil {
+	err := withinTx(ctx, s.transactor, log, func(ctx context.Context) error {
+		product, err := s.productRepo.GetByID(ctx, productID)
+		if err != nil {
+			if errors.Is(err, repoerr.ErrNoRows) {
+				log.Warn("product not found")
+				return ErrProductNotFound
+			}
+			log.Error("failed to get product", "error", err)
+			return ErrInternal
+		}
+		if product.DeletedAt != nil {
+			log.Warn("product already deleted")
+			return ErrProductNotFound
+		}
 
-		if errors.Is(err, repoerr.ErrNoRows) {
-			log.Error("not found open reception")
-			return ErrNoOpenReception
+		log = log.With("receptionID", product.ReceptionID.String())
+		reception, err := s.receptionRepo.LockByID(ctx, product.ReceptionID.String())
+		if err != nil {
+			log.Error("failed to lock reception", "error", err)
+			return ErrInternal
+		}
+		if reception.PVZID.String() != pvzID {
+			log.Warn("product belongs to another pvz", "receptionPVZID", reception.PVZID.String())
+			return ErrProductNotFound
 		}
-		log.Error("failed to check open reception", "error", err)
+		if product.Status != entity.ProductStatusStored {
+			log.Warn("product is not stored", "status", product.Status)
+			return ErrProductNotStored
+		}
+		if reception.Status != entity.StatusInProgress {
+			log.Warn("reception is not open", "status", reception.Status)
+			return ErrInvalidReceptionStatus
+		}
+		if reception.Kind == entity.ReceptionKindTransfer {
+			log.Warn("products of transfer reception are managed by transfer")
+			return ErrTransferReception
+		}
+
+		err = s.productRepo.Delete(ctx, productID, userID)
+		if err != nil {
+			if errors.Is(err, repoerr.ErrNoRows) {
+				log.Warn("product already deleted")
+				return ErrProductNotFound
+			}
+			log.Error("failed to delete product", "error", err)
+			return ErrInternal
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	log.Info("product deleted successfully")
+	return nil
+}
+
+// barcodeConflict находит хранящийся товар с тем же штрихкодом, чтобы вернуть его вместе с ошибкой.
+func (s *ProductService) barcodeConflict(ctx context.Context, log *slog.Logger, barcode string) error {
+	existing, err := s.productRepo.GetByBarcode(ctx, barcode)
+	if err != nil {
+		log.Error("failed to get product with duplicate barcode", "error", err)
 		return ErrInternal
 	}
+	log.Warn("duplicate barcode scan", "barcode", barcode, "existingProductID", existing.ID.String())
+	return &BarcodeConflictError{Existing: *existing}
+}
+
+// GetByBarcode возвращает хранящийся товар по штрихкоду.
+func (s *ProductService) GetByBarcode(ctx context.Context, barcode string) (*entity.Product, error) {
+	log := slog.With("layer", "ProductService", "operation", "GetByBarcode", "barcode", barcode)
+	log.Debug("starting get product by barcode")
 
-	log = log.With("receptionID", reception.ID.String())
+	barcode = normalizeBarcode(barcode)
+	if !barcodePattern.MatchString(barcode) {
+		return nil, ErrInvalidBarcode
+	}
 
-	err = s.productRepo.DeleteLastProduct(ctx, reception.ID.String())
+	product, err := s.productRepo.GetByBarcode(ctx, barcode)
 	if err != nil {
 		if errors.Is(err, repoerr.ErrNoRows) {
-			log.Error("not found product")
-			return ErrNoProducts
+			log.Warn("product not found")
+			return nil, ErrProductNotFound
 		}
-		log.Error("failed to delete product", "error", err)
-		return err
+		log.Error("failed to get product", "error", err)
+		return nil, ErrInternal
 	}
+	return product, nil
+}
 
-	log.Info("product deleted successfully")
-	return nil
+// prepareProduct нормализует штрихкод и проверяет атрибуты товара по схеме его типа.
+func prepareProduct(productType entity.ProductType, barcode string,
+	attributes json.RawMessage) (string, json.RawMessage, error) {
+	barcode = normalizeBarcode(barcode)
+	if barcode != "" && !barcodePattern.MatchString(barcode) {
+		return "", nil, ErrInvalidBarcode
+	}
+	if isNullJSON(attributes) {
+		attributes = nil
+	}
+	if err := validateAttributes(productType.AttributesSchema, attributes); err != nil {
+		return "", nil, err
+	}
+	return barcode, attributes, nil
+}
+
+// prepareDeclaredValue проверяет объявленную ценность и возвращает код валюты в верхнем регистре.
+// Ценность и валюта задаются вместе или не задаются вовсе.
+func prepareDeclaredValue(value int64, currency string) (string, error) {
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	if value == 0 && currency == "" {
+		return "", nil
+	}
+	if value <= 0 || value > maxDeclaredValue || !currencyPattern.MatchString(currency) {
+		return "", ErrInvalidValue
+	}
+	return currency, nil
+}
+
+func normalizeBarcode(barcode string) string {
+	return strings.ToUpper(strings.TrimSpace(barcode))
 }
@@ -0,0 +1,203 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/entity"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/repo/repoerr"
+	"log/slog"
+)
+
+const maxBatchItems = 1000
+
+// CreateBatch добавляет пакет товаров в одну открытую приёмку одной транзакцией. Каталог типов,
+// ПВЗ и приёмка проверяются один раз на весь пакет. В режиме all_or_nothing ошибка в любой позиции
+// отклоняет пакет с ErrBatchRejected; в режиме best_effort добавляются только корректные позиции.
+// Результат по позициям возвращается в обоих случаях.
+func (s *ProductService) CreateBatch(ctx context.Context,
+	params entity.ProductBatchParams) (*entity.ProductBatchResult, error) {
+	log := slog.With("layer", "ProductService", "operation", "CreateBatch", "pvzID", params.PVZID,
+		"dockID", params.DockID, "receptionID", params.ReceptionID, "mode", params.Mode,
+		"items", len(params.Items), "userID", params.AddedBy.String())
+	log.Debug("starting batch product creation")
+
+	if params.Mode == "" {
+		params.Mode = entity.BatchModeAllOrNothing
+	}
+	if params.Mode != entity.BatchModeAllOrNothing && params.Mode != entity.BatchModeBestEffort {
+		return nil, ErrInvalidBatch
+	}
+	if len(params.Items) == 0 || len(params.Items) > maxBatchItems {
+		return nil, ErrInvalidBatch
+	}
+
+	productTypes, err := s.typeRepo.List(ctx)
+	if err != nil {
+		log.Error("failed to list product types", "error", err)
+		return nil, ErrInternal
+	}
+	catalog := make(map[string]entity.ProductType, len(productTypes))
+	for _, productType := range productTypes {
+		catalog[productType.Name] = productType
+	}
+
+	result := &entity.ProductBatchResult{Items: make([]entity.ProductBatchItemResult, len(params.Items))}
+	items := make([]entity.ProductBatchItem, len(params.Items))
+	seen := make(map[string]bool, len(params.Items))
+	for i, item := range params.Items {
+		productType, ok := catalog[item.Type]
+		if !ok {
+			result.Items[i].Err = ErrInvalidProductType
+			continue
+		}
+		item.Barcode, item.Attributes, err = prepareProduct(productType, item.Barcode, item.Attributes)
+		if err != nil {
+			result.Items[i].Err = err
+			continue
+		}
+		item.Currency, err = prepareDeclaredValue(item.DeclaredValue, item.Currency)
+		if err != nil {
+			result.Items[i].Err = err
+			continue
+		}
+		if item.Barcode != "" {
+			if seen[item.Barcode] {
+				result.Items[i].Err = ErrBarcodeRepeated
+				continue
+			}
+			seen[item.Barcode] = true
+		}
+		items[i] = item
+	}
+
+	if err := validateTarget(ctx, s.pvzRepo, log, params.ReceptionTarget); err != nil {
+		return nil, err
+	}
+
+	var kind string
+	err = withinTx(ctx, s.transactor, log, func(ctx context.Context) error {
+		reception, err := lockOpenReception(ctx, s.receptionRepo, log, params.ReceptionTarget)
+		if err != nil {
+			return err
+		}
+		result.ReceptionID = reception.ID
+		kind = reception.Kind
+		if reception.Kind == entity.ReceptionKindTransfer {
+			log.Warn("products of transfer reception are managed by transfer")
+			return ErrTransferReception
+		}
+
+		for i, item := range items {
+			if result.Items[i].Err != nil {
+				continue
+			}
+			items[i].ReturnReason, result.Items[i].Err = prepareReturnReason(reception.Kind, item.ReturnReason)
+		}
+
+		if err := s.markStoredBarcodes(ctx, log, items, result); err != nil {
+			return err
+		}
+		if params.Mode == entity.BatchModeAllOrNothing && hasItemErrors(result) {
+			return nil
+		}
+
+		var (
+			products []entity.Product
+			indexes  []int
+		)
+		for i, item := range items {
+			if result.Items[i].Err != nil {
+				continue
+			}
+			products = append(products, entity.Product{
+				ReceptionID:   reception.ID,
+				Type:          item.Type,
+				Barcode:       item.Barcode,
+				Attributes:    item.Attributes,
+				ReturnReason:  item.ReturnReason,
+				DeclaredValue: item.DeclaredValue,
+				Currency:      item.Currency,
+				AddedBy:       params.AddedBy,
+			})
+			indexes = append(indexes, i)
+		}
+		if len(products) == 0 {
+			return nil
+		}
+		if reception.Kind == entity.ReceptionKindReturn {
+			if err := s.linkOriginals(ctx, log, products); err != nil {
+				return err
+			}
+		}
+		if err := assignCells(ctx, s.cellRepo, log, reception.PVZID.String(), products); err != nil {
+			return err
+		}
+
+		created, err := s.productRepo.CreateBatch(ctx, products)
+		if err != nil {
+			if errors.Is(err, repoerr.ErrDuplicateEntry) {
+				log.Warn("barcode stored concurrently")
+				return ErrBarcodeExists
+			}
+			log.Error("failed to create products", "error", err)
+			return ErrInternal
+		}
+		for i, product := range created {
+			result.Items[indexes[i]].Product = &product
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if params.Mode == entity.BatchModeAllOrNothing && hasItemErrors(result) {
+		log.Warn("batch rejected")
+		return result, ErrBatchRejected
+	}
+
+	created := result.Created()
+	countAdded(kind, created)
+	log.Info("batch processed", "receptionID", result.ReceptionID.String(), "created", created,
+		"failed", len(result.Items)-created)
+	return result, nil
+}
+
+// markStoredBarcodes отмечает позиции, штрихкод которых уже есть у хранящегося товара.
+func (s *ProductService) markStoredBarcodes(ctx context.Context, log *slog.Logger, items []entity.ProductBatchItem,
+	result *entity.ProductBatchResult) error {
+	var barcodes []string
+	for i, item := range items {
+		if result.Items[i].Err == nil && item.Barcode != "" {
+			barcodes = append(barcodes, item.Barcode)
+		}
+	}
+	if len(barcodes) == 0 {
+		return nil
+	}
+
+	stored, err := s.productRepo.ListByBarcodes(ctx, barcodes)
+	if err != nil {
+		log.Error("failed to list stored barcodes", "error", err)
+		return ErrInternal
+	}
+	existing := make(map[string]entity.Product, len(stored))
+	for _, product := range stored {
+		existing[product.Barcode] = product
+	}
+	for i, item := range items {
+		if product, ok := existing[item.Barcode]; ok && result.Items[i].Err == nil {
+			result.Items[i].Err = &BarcodeConflictError{Existing: product}
+		}
+	}
+	return nil
+}
+
+func hasItemErrors(result *entity.ProductBatchResult) bool {
+	for _, item := range result.Items {
+		if item.Err != nil {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,218 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/entity"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/repo/mocks"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/repo/repoerr"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+)
+
+func TestProductService_CreateBatch(t *testing.T) {
+	userID := uuid.New()
+	receptionID := uuid.New()
+	stored := entity.Product{ID: uuid.New(), Type: entity.ProductTypeShoes, Barcode: "STORED-1",
+		ReceptionID: uuid.New()}
+
+	validItems := []entity.ProductBatchItem{
+		{Type: entity.ProductTypeShoes, Barcode: " ord-1 ", Attributes: json.RawMessage(`{"size": 42}`)},
+		{Type: entity.ProductTypeClothes, DeclaredValue: 250_000, Currency: "eur"},
+	}
+	mixedItems := []entity.ProductBatchItem{
+		{Type: entity.ProductTypeShoes, Barcode: "ORD-1"},
+		{Type: "unknown"},
+		{Type: entity.ProductTypeClothes, Barcode: "ord-1"},
+		{Type: entity.ProductTypeClothes, Barcode: stored.Barcode},
+		{Type: entity.ProductTypeClothes, Attributes: json.RawMessage(`{"size": 42}`)},
+		{Type: entity.ProductTypeClothes, DeclaredValue: 250_000},
+	}
+
+	openReception := func(pvzRepo *mocks.PVZ, receptionRepo *mocks.Reception) {
+		pvzRepo.On("Exists", mock.Anything, mock.AnythingOfType("string")).Return(true)
+		receptionRepo.On("LockLastOpenReception", mock.Anything, mock.AnythingOfType("string")).
+			Return(&entity.Reception{ID: receptionID, Status: entity.StatusInProgress}, nil)
+	}
+	createdProducts := func(products []entity.Product) []entity.Product {
+		created := make([]entity.Product, len(products))
+		for i, product := range products {
+			product.ID = uuid.New()
+			created[i] = product
+		}
+		return created
+	}
+
+	testCases := []struct {
+		name         string
+		mode         string
+		items        []entity.ProductBatchItem
+		prepareRepos func(productRepo *mocks.Product, receptionRepo *mocks.Reception, pvzRepo *mocks.PVZ)
+		// expectedItems — статус позиций: "created", "failed" или "" (не добавлена).
+		expectedItems []string
+		expectedErrs  []error
+		expectedError error
+	}{
+		{
+			name:  "all items created",
+			items: validItems,
+			prepareRepos: func(productRepo *mocks.Product, receptionRepo *mocks.Reception, pvzRepo *mocks.PVZ) {
+				openReception(pvzRepo, receptionRepo)
+				productRepo.On("ListByBarcodes", mock.Anything, []string{"ORD-1"}).Return(nil, nil)
+				products := []entity.Product{
+					{ReceptionID: receptionID, Type: entity.ProductTypeShoes, Barcode: "ORD-1",
+						Attributes: json.RawMessage(`{"size": 42}`), AddedBy: userID},
+					{ReceptionID: receptionID, Type: entity.ProductTypeClothes, DeclaredValue: 250_000,
+						Currency: "EUR", AddedBy: userID},
+				}
+				productRepo.On("CreateBatch", mock.Anything, products).Return(createdProducts(products), nil)
+			},
+			expectedItems: []string{"created", "created"},
+		},
+		{
+			name:  "all or nothing rejects batch with invalid items",
+			mode:  entity.BatchModeAllOrNothing,
+			items: mixedItems,
+			prepareRepos: func(productRepo *mocks.Product, receptionRepo *mocks.Reception, pvzRepo *mocks.PVZ) {
+				openReception(pvzRepo, receptionRepo)
+				productRepo.On("ListByBarcodes", mock.Anything, []string{"ORD-1", stored.Barcode}).
+					Return([]entity.Product{stored}, nil)
+			},
+			expectedItems: []string{"", "failed", "failed", "failed", "failed", "failed"},
+			expectedErrs: []error{nil, ErrInvalidProductType, ErrBarcodeRepeated, ErrBarcodeExists,
+				ErrInvalidAttributes, ErrInvalidValue},
+			expectedError: ErrBatchRejected,
+		},
+		{
+			name:  "best effort creates valid items",
+			mode:  entity.BatchModeBestEffort,
+			items: mixedItems,
+			prepareRepos: func(productRepo *mocks.Product, receptionRepo *mocks.Reception, pvzRepo *mocks.PVZ) {
+				openReception(pvzRepo, receptionRepo)
+				productRepo.On("ListByBarcodes", mock.Anything, []string{"ORD-1", stored.Barcode}).
+					Return([]entity.Product{stored}, nil)
+				products := []entity.Product{
+					{ReceptionID: receptionID, Type: entity.ProductTypeShoes, Barcode: "ORD-1", AddedBy: userID},
+				}
+				productRepo.On("CreateBatch", mock.Anything, products).Return(createdProducts(products), nil)
+			},
+			expectedItems: []string{"created", "failed", "failed", "failed", "failed", "failed"},
+			expectedErrs: []error{nil, ErrInvalidProductType, ErrBarcodeRepeated, ErrBarcodeExists,
+				ErrInvalidAttributes, ErrInvalidValue},
+		},
+		{
+			name:  "best effort without valid items",
+			mode:  entity.BatchModeBestEffort,
+			items: []entity.ProductBatchItem{{Type: "unknown"}},
+			prepareRepos: func(productRepo *mocks.Product, receptionRepo *mocks.Reception, pvzRepo *mocks.PVZ) {
+				openReception(pvzRepo, receptionRepo)
+			},
+			expectedItems: []string{"failed"},
+			expectedErrs:  []error{ErrInvalidProductType},
+		},
+		{
+			name:          "invalid mode",
+			mode:          "partial",
+			items:         validItems,
+			prepareRepos:  func(productRepo *mocks.Product, receptionRepo *mocks.Reception, pvzRepo *mocks.PVZ) {},
+			expectedError: ErrInvalidBatch,
+		},
+		{
+			name:          "empty batch",
+			prepareRepos:  func(productRepo *mocks.Product, receptionRepo *mocks.Reception, pvzRepo *mocks.PVZ) {},
+			expectedError: ErrInvalidBatch,
+		},
+		{
+			name:          "batch too large",
+			items:         make([]entity.ProductBatchItem, maxBatchItems+1),
+			prepareRepos:  func(productRepo *mocks.Product, receptionRepo *mocks.Reception, pvzRepo *mocks.PVZ) {},
+			expectedError: ErrInvalidBatch,
+		},
+		{
+			name:  "no open reception",
+			items: validItems,
+			prepareRepos: func(productRepo *mocks.Product, receptionRepo *mocks.Reception, pvzRepo *mocks.PVZ) {
+				pvzRepo.On("Exists", mock.Anything, mock.AnythingOfType("string")).Return(true)
+				receptionRepo.On("LockLastOpenReception", mock.Anything, mock.AnythingOfType("string")).
+					Return(nil, repoerr.ErrNoRows)
+			},
+			expectedError: ErrNoOpenReception,
+		},
+		{
+			name:  "barcode stored concurrently",
+			items: validItems,
+			prepareRepos: func(productRepo *mocks.Product, receptionRepo *mocks.Reception, pvzRepo *mocks.PVZ) {
+				openReception(pvzRepo, receptionRepo)
+				productRepo.On("ListByBarcodes", mock.Anything, []string{"ORD-1"}).Return(nil, nil)
+				productRepo.On("CreateBatch", mock.Anything, mock.Anything).Return(nil, repoerr.ErrDuplicateEntry)
+			},
+			expectedError: ErrBarcodeExists,
+		},
+		{
+			name:  "product repo error",
+			items: validItems,
+			prepareRepos: func(productRepo *mocks.Product, receptionRepo *mocks.Reception, pvzRepo *mocks.PVZ) {
+				openReception(pvzRepo, receptionRepo)
+				productRepo.On("ListByBarcodes", mock.Anything, []string{"ORD-1"}).
+					Return(nil, errors.New("database error"))
+			},
+			expectedError: ErrInternal,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			productRepo := mocks.NewProduct(t)
+			receptionRepo := mocks.NewReception(t)
+			pvzRepo := mocks.NewPVZ(t)
+			tc.prepareRepos(productRepo, receptionRepo, pvzRepo)
+
+			service := NewProductService(newPassthroughTransactor(t), productRepo, receptionRepo, pvzRepo, newCatalogTypeRepo(t),
+				newEmptyCellRepo(t))
+
+			result, err := service.CreateBatch(context.Background(), entity.ProductBatchParams{
+				ReceptionTarget: entity.ReceptionTarget{PVZID: uuid.New().String()},
+				Mode:            tc.mode,
+				Items:           tc.items,
+				AddedBy:         userID,
+			})
+
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+			if tc.expectedItems == nil {
+				assert.Nil(t, result)
+				return
+			}
+
+			assert.Equal(t, receptionID, result.ReceptionID)
+			assert.Len(t, result.Items, len(tc.expectedItems))
+			for i, status := range tc.expectedItems {
+				item := result.Items[i]
+				switch status {
+				case "created":
+					assert.NotNil(t, item.Product, "item %d", i)
+					assert.NoError(t, item.Err, "item %d", i)
+				case "failed":
+					assert.Nil(t, item.Product, "item %d", i)
+					assert.ErrorIs(t, item.Err, tc.expectedErrs[i], "item %d", i)
+				default:
+					assert.Nil(t, item.Product, "item %d", i)
+					assert.NoError(t, item.Err, "item %d", i)
+				}
+			}
+
+			var conflict *BarcodeConflictError
+			for _, item := range result.Items {
+				if errors.As(item.Err, &conflict) {
+					assert.Equal(t, stored, conflict.Existing)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package service
+
+import (
+	"context"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/entity"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/metrics"
+	"log/slog"
+	"strings"
+	"unicode/utf8"
+)
+
+const maxReturnReasonLength = 500
+
+// prepareReturnReason проверяет причину возврата по виду приёмки: в возврате она обязательна,
+// в поставке недопустима.
+func prepareReturnReason(kind, reason string) (string, error) {
+	reason = strings.TrimSpace(reason)
+	if kind != entity.ReceptionKindReturn {
+		if reason != "" {
+			return "", ErrUnexpectedReturnReason
+		}
+		return "", nil
+	}
+	if reason == "" {
+		return "", ErrReturnReasonRequired
+	}
+	if utf8.RuneCountInString(reason) > maxReturnReasonLength {
+		return "", ErrReturnReasonTooLong
+	}
+	return reason, nil
+}
+
+// linkOriginals связывает возвращённые товары с последними выданными товарами с тем же штрихкодом.
+// Товары без штрихкода или с неизвестным штрихкодом остаются без связи.
+func (s *ProductService) linkOriginals(ctx context.Context, log *slog.Logger, products []entity.Product) error {
+	var barcodes []string
+	for _, product := range products {
+		if product.Barcode != "" {
+			barcodes = append(barcodes, product.Barcode)
+		}
+	}
+	if len(barcodes) == 0 {
+		return nil
+	}
+
+	issued, err := s.productRepo.ListIssuedByBarcodes(ctx, barcodes)
+	if err != nil {
+		log.Error("failed to list issued products", "error", err)
+		return ErrInternal
+	}
+	originals := make(map[string]entity.Product, len(issued))
+	for _, product := range issued {
+		originals[product.Barcode] = product
+	}
+	for i := range products {
+		if original, ok := originals[products[i].Barcode]; ok {
+			products[i].OriginalProductID = original.ID
+		}
+	}
+	return nil
+}
+
+// countAdded учитывает добавленные товары в метриках поставок или возвратов.
+func countAdded(kind string, count int) {
+	if kind == entity.ReceptionKindReturn {
+		metrics.ProductsReturned.Add(float64(count))
+		return
+	}
+	metrics.ProductsAdded.Add(float64(count))
+}
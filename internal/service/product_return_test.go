@@ -0,0 +1,171 @@
+package service
+
+import (
+	"context"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/entity"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/repo/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"strings"
+	"testing"
+)
+
+func TestPrepareReturnReason(t *testing.T) {
+	testCases := []struct {
+		name           string
+		kind           string
+		reason         string
+		expectedReason string
+		expectedError  error
+	}{
+		{name: "delivery without reason", kind: entity.ReceptionKindDelivery},
+		{name: "delivery with reason", kind: entity.ReceptionKindDelivery, reason: "брак",
+			expectedError: ErrUnexpectedReturnReason},
+		{name: "return with reason", kind: entity.ReceptionKindReturn, reason: "  не подошёл размер ",
+			expectedReason: "не подошёл размер"},
+		{name: "return without reason", kind: entity.ReceptionKindReturn, reason: "  ",
+			expectedError: ErrReturnReasonRequired},
+		{name: "return reason too long", kind: entity.ReceptionKindReturn, reason: strings.Repeat("я", 501),
+			expectedError: ErrReturnReasonTooLong},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			reason, err := prepareReturnReason(tc.kind, tc.reason)
+			assert.ErrorIs(t, err, tc.expectedError)
+			assert.Equal(t, tc.expectedReason, reason)
+		})
+	}
+}
+
+func TestReceptionService_CreateReturn(t *testing.T) {
+	pvzID := uuid.New()
+
+	pvzRepo := mocks.NewPVZ(t)
+	pvzRepo.On("Exists", mock.Anything, pvzID.String()).Return(true)
+	pvzRepo.On("GetSchedule", mock.Anything, pvzID.String()).
+		Return(&entity.PVZSchedule{Timezone: entity.DefaultTimezone}, nil)
+	receptionRepo := mocks.NewReception(t)
+	receptionRepo.On("HasOpenReception", mock.Anything, pvzID.String(), "").Return(false, nil)
+	receptionRepo.On("Create", mock.Anything, mock.MatchedBy(func(params entity.ReceptionParams) bool {
+		return params.Kind == entity.ReceptionKindReturn
+	})).Return(&entity.Reception{ID: uuid.New(), PVZID: pvzID, Kind: entity.ReceptionKindReturn,
+		Status: entity.StatusInProgress}, nil)
+
+	service := NewReceptionService(newPassthroughTransactor(t), receptionRepo, mocks.NewProduct(t), pvzRepo,
+		mocks.NewDock(t), newCatalogTypeRepo(t))
+
+	reception, err := service.Create(context.Background(), entity.ReceptionParams{
+		PVZID: pvzID.String(),
+		Kind:  entity.ReceptionKindReturn,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, entity.ReceptionKindReturn, reception.Kind)
+
+	_, err = service.Create(context.Background(), entity.ReceptionParams{PVZID: pvzID.String(), Kind: "exchange"})
+	assert.ErrorIs(t, err, ErrInvalidReceptionKind)
+}
+
+func TestProductService_CreateReturn(t *testing.T) {
+	pvzID := uuid.New()
+	receptionID := uuid.New()
+	originalID := uuid.New()
+	barcode := "4600000000011"
+
+	newService := func(t *testing.T, kind string) (*ProductService, *mocks.Product) {
+		pvzRepo := mocks.NewPVZ(t)
+		pvzRepo.On("Exists", mock.Anything, pvzID.String()).Return(true)
+		receptionRepo := mocks.NewReception(t)
+		receptionRepo.On("LockLastOpenReception", mock.Anything, pvzID.String()).
+			Return(&entity.Reception{ID: receptionID, PVZID: pvzID, Kind: kind, Status: entity.StatusInProgress}, nil)
+		productRepo := mocks.NewProduct(t)
+		service := NewProductService(newPassthroughTransactor(t), productRepo, receptionRepo, pvzRepo,
+			newCatalogTypeRepo(t), newEmptyCellRepo(t))
+		return service, productRepo
+	}
+	target := entity.ReceptionTarget{PVZID: pvzID.String()}
+
+	t.Run("links original by barcode", func(t *testing.T) {
+		service, productRepo := newService(t, entity.ReceptionKindReturn)
+		productRepo.On("ListIssuedByBarcodes", mock.Anything, []string{barcode}).
+			Return([]entity.Product{{ID: originalID, Barcode: barcode, Status: entity.ProductStatusIssued}}, nil)
+		productRepo.On("Create", mock.Anything, mock.AnythingOfType("entity.Product")).
+			Return(func(ctx context.Context, product entity.Product) (*entity.Product, error) {
+				product.ID = uuid.New()
+				return &product, nil
+			})
+
+		product, err := service.Create(context.Background(), entity.ProductParams{
+			ReceptionTarget: target,
+			Type:            entity.ProductTypeClothes,
+			Barcode:         barcode,
+			ReturnReason:    " не подошёл размер ",
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, originalID, product.OriginalProductID)
+		assert.Equal(t, "не подошёл размер", product.ReturnReason)
+	})
+
+	t.Run("unknown barcode stays unlinked", func(t *testing.T) {
+		service, productRepo := newService(t, entity.ReceptionKindReturn)
+		productRepo.On("ListIssuedByBarcodes", mock.Anything, []string{barcode}).Return(nil, nil)
+		productRepo.On("Create", mock.Anything, mock.AnythingOfType("entity.Product")).
+			Return(func(ctx context.Context, product entity.Product) (*entity.Product, error) {
+				return &product, nil
+			})
+
+		product, err := service.Create(context.Background(), entity.ProductParams{
+			ReceptionTarget: target,
+			Type:            entity.ProductTypeClothes,
+			Barcode:         barcode,
+			ReturnReason:    "брак",
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, uuid.Nil, product.OriginalProductID)
+	})
+
+	t.Run("reason required in return", func(t *testing.T) {
+		service, _ := newService(t, entity.ReceptionKindReturn)
+
+		_, err := service.Create(context.Background(), entity.ProductParams{
+			ReceptionTarget: target,
+			Type:            entity.ProductTypeClothes,
+		})
+		assert.ErrorIs(t, err, ErrReturnReasonRequired)
+	})
+
+	t.Run("reason rejected in delivery", func(t *testing.T) {
+		service, _ := newService(t, entity.ReceptionKindDelivery)
+
+		_, err := service.Create(context.Background(), entity.ProductParams{
+			ReceptionTarget: target,
+			Type:            entity.ProductTypeClothes,
+			ReturnReason:    "брак",
+		})
+		assert.ErrorIs(t, err, ErrUnexpectedReturnReason)
+	})
+
+	t.Run("batch checks reason per item", func(t *testing.T) {
+		service, productRepo := newService(t, entity.ReceptionKindReturn)
+		productRepo.On("ListByBarcodes", mock.Anything, []string{barcode}).Return(nil, nil)
+		productRepo.On("ListIssuedByBarcodes", mock.Anything, []string{barcode}).
+			Return([]entity.Product{{ID: originalID, Barcode: barcode, Status: entity.ProductStatusIssued}}, nil)
+		productRepo.On("CreateBatch", mock.Anything, mock.AnythingOfType("[]entity.Product")).
+			Return(func(ctx context.Context, products []entity.Product) ([]entity.Product, error) {
+				return products, nil
+			})
+
+		result, err := service.CreateBatch(context.Background(), entity.ProductBatchParams{
+			ReceptionTarget: target,
+			Mode:            entity.BatchModeBestEffort,
+			Items: []entity.ProductBatchItem{
+				{Type: entity.ProductTypeClothes, Barcode: barcode, ReturnReason: "брак"},
+				{Type: entity.ProductTypeClothes},
+			},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, originalID, result.Items[0].Product.OriginalProductID)
+		assert.ErrorIs(t, result.Items[1].Err, ErrReturnReasonRequired)
+	})
+}
@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"github.com/GlebMoskalev/go-pickup-point-api/internal/entity"
 	"github.com/GlebMoskalev/go-pickup-point-api/internal/repo/mocks"
@@ -14,6 +15,8 @@ import (
 )
 
 func TestProductService_Create(t *testing.T) {
+	userID := uuid.New()
+
 	testCases := []struct {
 		name            string
 		pvzID           string
@@ -29,15 +32,20 @@ func TestProductService_Create(t *testing.T) {
 			prepareRepos: func(productRepo *mocks.Product, receptionRepo *mocks.Reception, pvzRepo *mocks.PVZ) {
 				pvzRepo.On("Exists", mock.Anything, mock.AnythingOfType("string")).Return(true)
 				receptionID := uuid.New()
-				receptionRepo.On("GetLastOpenReception", mock.Anything, mock.AnythingOfType("string")).
+				receptionRepo.On("LockLastOpenReception", mock.Anything, mock.AnythingOfType("string")).
 					Return(&entity.Reception{ID: receptionID, Status: "in_progress"}, nil)
 				productID := uuid.New()
-				productRepo.On("Create", mock.Anything, receptionID.String(), entity.ProductTypeElectronics).
+				productRepo.On("Create", mock.Anything, entity.Product{
+					ReceptionID: receptionID,
+					Type:        entity.ProductTypeElectronics,
+					AddedBy:     userID,
+				}).
 					Return(&entity.Product{
 						ID:          productID,
 						DateTime:    time.Now(),
 						Type:        entity.ProductTypeElectronics,
 						ReceptionID: receptionID,
+						AddedBy:     userID,
 					}, nil)
 			},
 			expectedProduct: &entity.Product{
@@ -72,7 +80,7 @@ func TestProductService_Create(t *testing.T) {
 			productType: entity.ProductTypeShoes,
 			prepareRepos: func(productRepo *mocks.Product, receptionRepo *mocks.Reception, pvzRepo *mocks.PVZ) {
 				pvzRepo.On("Exists", mock.Anything, mock.AnythingOfType("string")).Return(true)
-				receptionRepo.On("GetLastOpenReception", mock.Anything, mock.AnythingOfType("string")).
+				receptionRepo.On("LockLastOpenReception", mock.Anything, mock.AnythingOfType("string")).
 					Return(nil, repoerr.ErrNoRows)
 			},
 			expectedProduct: nil,
@@ -84,7 +92,7 @@ func TestProductService_Create(t *testing.T) {
 			productType: entity.ProductTypeElectronics,
 			prepareRepos: func(productRepo *mocks.Product, receptionRepo *mocks.Reception, pvzRepo *mocks.PVZ) {
 				pvzRepo.On("Exists", mock.Anything, mock.AnythingOfType("string")).Return(true)
-				receptionRepo.On("GetLastOpenReception", mock.Anything, mock.AnythingOfType("string")).
+				receptionRepo.On("LockLastOpenReception", mock.Anything, mock.AnythingOfType("string")).
 					Return(nil, errors.New("database error"))
 			},
 			expectedProduct: nil,
@@ -97,9 +105,9 @@ func TestProductService_Create(t *testing.T) {
 			prepareRepos: func(productRepo *mocks.Product, receptionRepo *mocks.Reception, pvzRepo *mocks.PVZ) {
 				pvzRepo.On("Exists", mock.Anything, mock.AnythingOfType("string")).Return(true)
 				receptionID := uuid.New()
-				receptionRepo.On("GetLastOpenReception", mock.Anything, mock.AnythingOfType("string")).
+				receptionRepo.On("LockLastOpenReception", mock.Anything, mock.AnythingOfType("string")).
 					Return(&entity.Reception{ID: receptionID, Status: "in_progress"}, nil)
-				productRepo.On("Create", mock.Anything, receptionID.String(), entity.ProductTypeClothes).
+				productRepo.On("Create", mock.Anything, mock.AnythingOfType("entity.Product")).
 					Return(nil, errors.New("database error"))
 			},
 			expectedProduct: nil,
@@ -114,10 +122,15 @@ func TestProductService_Create(t *testing.T) {
 			pvzRepo := mocks.NewPVZ(t)
 			tc.prepareRepos(productRepo, receptionRepo, pvzRepo)
 
-			service := NewProductService(productRepo, receptionRepo, pvzRepo)
+			service := NewProductService(newPassthroughTransactor(t), productRepo, receptionRepo, pvzRepo, newCatalogTypeRepo(t),
+				newEmptyCellRepo(t))
 			ctx := context.Background()
 
-			product, err := service.Create(ctx, tc.pvzID, tc.productType)
+			product, err := service.Create(ctx, entity.ProductParams{
+				ReceptionTarget: entity.ReceptionTarget{PVZID: tc.pvzID},
+				Type:            tc.productType,
+				AddedBy:         userID,
+			})
 
 			if tc.expectedError != nil {
 				assert.ErrorIs(t, err, tc.expectedError)
@@ -130,6 +143,7 @@ func TestProductService_Create(t *testing.T) {
 				_, err = uuid.Parse(product.ReceptionID.String())
 				assert.NoError(t, err, "ReceptionID should be a valid UUID")
 				assert.Equal(t, tc.expectedProduct.Type, product.Type)
+				assert.Equal(t, userID, product.AddedBy)
 			}
 		})
 	}
@@ -137,6 +151,7 @@ func TestProductService_Create(t *testing.T) {
 
 func TestProductService_DeleteLastProduct(t *testing.T) {
 	databaseErr := errors.New("database error")
+	userID := uuid.New()
 
 	testCases := []struct {
 		name          string
@@ -150,9 +165,9 @@ func TestProductService_DeleteLastProduct(t *testing.T) {
 			prepareRepos: func(productRepo *mocks.Product, receptionRepo *mocks.Reception, pvzRepo *mocks.PVZ) {
 				pvzRepo.On("Exists", mock.Anything, mock.AnythingOfType("string")).Return(true)
 				receptionID := uuid.New()
-				receptionRepo.On("GetLastOpenReception", mock.Anything, mock.AnythingOfType("string")).
+				receptionRepo.On("LockLastOpenReception", mock.Anything, mock.AnythingOfType("string")).
 					Return(&entity.Reception{ID: receptionID, Status: "in_progress"}, nil)
-				productRepo.On("DeleteLastProduct", mock.Anything, receptionID.String()).Return(nil)
+				productRepo.On("DeleteLastProduct", mock.Anything, receptionID.String(), userID).Return(nil)
 			},
 			expectedError: nil,
 		},
@@ -169,7 +184,7 @@ func TestProductService_DeleteLastProduct(t *testing.T) {
 			pvzID: uuid.New().String(),
 			prepareRepos: func(productRepo *mocks.Product, receptionRepo *mocks.Reception, pvzRepo *mocks.PVZ) {
 				pvzRepo.On("Exists", mock.Anything, mock.AnythingOfType("string")).Return(true)
-				receptionRepo.On("GetLastOpenReception", mock.Anything, mock.AnythingOfType("string")).
+				receptionRepo.On("LockLastOpenReception", mock.Anything, mock.AnythingOfType("string")).
 					Return(nil, repoerr.ErrNoRows)
 			},
 			expectedError: ErrNoOpenReception,
@@ -180,19 +195,35 @@ func TestProductService_DeleteLastProduct(t *testing.T) {
 			prepareRepos: func(productRepo *mocks.Product, receptionRepo *mocks.Reception, pvzRepo *mocks.PVZ) {
 				pvzRepo.On("Exists", mock.Anything, mock.AnythingOfType("string")).Return(true)
 				receptionID := uuid.New()
-				receptionRepo.On("GetLastOpenReception", mock.Anything, mock.AnythingOfType("string")).
+				receptionRepo.On("LockLastOpenReception", mock.Anything, mock.AnythingOfType("string")).
 					Return(&entity.Reception{ID: receptionID, Status: "in_progress"}, nil)
-				productRepo.On("DeleteLastProduct", mock.Anything, receptionID.String()).
+				productRepo.On("DeleteLastProduct", mock.Anything, receptionID.String(), userID).
 					Return(repoerr.ErrNoRows)
+				productRepo.On("CountByType", mock.Anything, receptionID.String()).Return(map[string]int{}, nil)
 			},
 			expectedError: ErrNoProducts,
 		},
+		{
+			name:  "last product is not stored",
+			pvzID: uuid.New().String(),
+			prepareRepos: func(productRepo *mocks.Product, receptionRepo *mocks.Reception, pvzRepo *mocks.PVZ) {
+				pvzRepo.On("Exists", mock.Anything, mock.AnythingOfType("string")).Return(true)
+				receptionID := uuid.New()
+				receptionRepo.On("LockLastOpenReception", mock.Anything, mock.AnythingOfType("string")).
+					Return(&entity.Reception{ID: receptionID, Status: "in_progress"}, nil)
+				productRepo.On("DeleteLastProduct", mock.Anything, receptionID.String(), userID).
+					Return(repoerr.ErrNoRows)
+				productRepo.On("CountByType", mock.Anything, receptionID.String()).
+					Return(map[string]int{entity.ProductTypeShoes: 1}, nil)
+			},
+			expectedError: ErrProductNotStored,
+		},
 		{
 			name:  "reception repo error",
 			pvzID: uuid.New().String(),
 			prepareRepos: func(productRepo *mocks.Product, receptionRepo *mocks.Reception, pvzRepo *mocks.PVZ) {
 				pvzRepo.On("Exists", mock.Anything, mock.AnythingOfType("string")).Return(true)
-				receptionRepo.On("GetLastOpenReception", mock.Anything, mock.AnythingOfType("string")).
+				receptionRepo.On("LockLastOpenReception", mock.Anything, mock.AnythingOfType("string")).
 					Return(nil, errors.New("database error"))
 			},
 			expectedError: ErrInternal,
@@ -203,9 +234,9 @@ func TestProductService_DeleteLastProduct(t *testing.T) {
 			prepareRepos: func(productRepo *mocks.Product, receptionRepo *mocks.Reception, pvzRepo *mocks.PVZ) {
 				pvzRepo.On("Exists", mock.Anything, mock.AnythingOfType("string")).Return(true)
 				receptionID := uuid.New()
-				receptionRepo.On("GetLastOpenReception", mock.Anything, mock.AnythingOfType("string")).
+				receptionRepo.On("LockLastOpenReception", mock.Anything, mock.AnythingOfType("string")).
 					Return(&entity.Reception{ID: receptionID, Status: "open"}, nil)
-				productRepo.On("DeleteLastProduct", mock.Anything, receptionID.String()).
+				productRepo.On("DeleteLastProduct", mock.Anything, receptionID.String(), userID).
 					Return(databaseErr)
 			},
 			expectedError: databaseErr,
@@ -219,9 +250,10 @@ func TestProductService_DeleteLastProduct(t *testing.T) {
 			pvzRepo := mocks.NewPVZ(t)
 			tc.prepareRepos(productRepo, receptionRepo, pvzRepo)
 
-			service := NewProductService(productRepo, receptionRepo, pvzRepo)
+			service := NewProductService(newPassthroughTransactor(t), productRepo, receptionRepo, pvzRepo, newCatalogTypeRepo(t),
+				newEmptyCellRepo(t))
 
-			err := service.DeleteLastProduct(context.Background(), tc.pvzID)
+			err := service.DeleteLastProduct(context.Background(), entity.ReceptionTarget{PVZID: tc.pvzID}, userID)
 
 			if tc.expectedError != nil {
 				assert.ErrorIs(t, err, tc.expectedError)
@@ -231,3 +263,469 @@ func TestProductService_DeleteLastProduct(t *testing.T) {
 		})
 	}
 }
+
+func TestProductService_CreateWithBarcode(t *testing.T) {
+	userID := uuid.New()
+	receptionID := uuid.New()
+	existing := entity.Product{ID: uuid.New(), Type: entity.ProductTypeShoes, Barcode: "4601234567890",
+		ReceptionID: uuid.New()}
+
+	testCases := []struct {
+		name            string
+		barcode         string
+		prepareRepos    func(productRepo *mocks.Product, receptionRepo *mocks.Reception, pvzRepo *mocks.PVZ)
+		expectedBarcode string
+		expectedError   error
+	}{
+		{
+			name:    "barcode is normalized",
+			barcode: "  ord-42a ",
+			prepareRepos: func(productRepo *mocks.Product, receptionRepo *mocks.Reception, pvzRepo *mocks.PVZ) {
+				pvzRepo.On("Exists", mock.Anything, mock.AnythingOfType("string")).Return(true)
+				receptionRepo.On("LockLastOpenReception", mock.Anything, mock.AnythingOfType("string")).
+					Return(&entity.Reception{ID: receptionID, Status: entity.StatusInProgress}, nil)
+				productRepo.On("Create", mock.Anything, entity.Product{
+					ReceptionID: receptionID,
+					Type:        entity.ProductTypeShoes,
+					Barcode:     "ORD-42A",
+					AddedBy:     userID,
+				}).Return(&entity.Product{ID: uuid.New(), Type: entity.ProductTypeShoes, Barcode: "ORD-42A",
+					ReceptionID: receptionID}, nil)
+			},
+			expectedBarcode: "ORD-42A",
+		},
+		{
+			name:          "invalid barcode",
+			barcode:       "12 34",
+			prepareRepos:  func(productRepo *mocks.Product, receptionRepo *mocks.Reception, pvzRepo *mocks.PVZ) {},
+			expectedError: ErrInvalidBarcode,
+		},
+		{
+			name:          "barcode too short",
+			barcode:       "123",
+			prepareRepos:  func(productRepo *mocks.Product, receptionRepo *mocks.Reception, pvzRepo *mocks.PVZ) {},
+			expectedError: ErrInvalidBarcode,
+		},
+		{
+			name:    "duplicate scan",
+			barcode: existing.Barcode,
+			prepareRepos: func(productRepo *mocks.Product, receptionRepo *mocks.Reception, pvzRepo *mocks.PVZ) {
+				pvzRepo.On("Exists", mock.Anything, mock.AnythingOfType("string")).Return(true)
+				receptionRepo.On("LockLastOpenReception", mock.Anything, mock.AnythingOfType("string")).
+					Return(&entity.Reception{ID: receptionID, Status: entity.StatusInProgress}, nil)
+				productRepo.On("Create", mock.Anything, mock.AnythingOfType("entity.Product")).
+					Return(nil, repoerr.ErrDuplicateEntry)
+				productRepo.On("GetByBarcode", mock.Anything, existing.Barcode).Return(&existing, nil)
+			},
+			expectedError: ErrBarcodeExists,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			productRepo := mocks.NewProduct(t)
+			receptionRepo := mocks.NewReception(t)
+			pvzRepo := mocks.NewPVZ(t)
+			tc.prepareRepos(productRepo, receptionRepo, pvzRepo)
+
+			service := NewProductService(newPassthroughTransactor(t), productRepo, receptionRepo, pvzRepo, newCatalogTypeRepo(t),
+				newEmptyCellRepo(t))
+
+			product, err := service.Create(context.Background(), entity.ProductParams{
+				ReceptionTarget: entity.ReceptionTarget{PVZID: uuid.New().String()},
+				Type:            entity.ProductTypeShoes,
+				Barcode:         tc.barcode,
+				AddedBy:         userID,
+			})
+
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+				assert.Nil(t, product)
+				var conflict *BarcodeConflictError
+				if errors.As(err, &conflict) {
+					assert.Equal(t, existing, conflict.Existing)
+				}
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedBarcode, product.Barcode)
+		})
+	}
+}
+
+func TestProductService_CreateWithDeclaredValue(t *testing.T) {
+	userID := uuid.New()
+	receptionID := uuid.New()
+
+	testCases := []struct {
+		name             string
+		value            int64
+		currency         string
+		prepareRepos     func(productRepo *mocks.Product, receptionRepo *mocks.Reception, pvzRepo *mocks.PVZ)
+		expectedCurrency string
+		expectedError    error
+	}{
+		{
+			name:     "currency is normalized",
+			value:    149_990,
+			currency: " rub ",
+			prepareRepos: func(productRepo *mocks.Product, receptionRepo *mocks.Reception, pvzRepo *mocks.PVZ) {
+				pvzRepo.On("Exists", mock.Anything, mock.AnythingOfType("string")).Return(true)
+				receptionRepo.On("LockLastOpenReception", mock.Anything, mock.AnythingOfType("string")).
+					Return(&entity.Reception{ID: receptionID, Status: entity.StatusInProgress}, nil)
+				productRepo.On("Create", mock.Anything, entity.Product{
+					ReceptionID:   receptionID,
+					Type:          entity.ProductTypeShoes,
+					DeclaredValue: 149_990,
+					Currency:      "RUB",
+					AddedBy:       userID,
+				}).Return(&entity.Product{ID: uuid.New(), Type: entity.ProductTypeShoes, ReceptionID: receptionID,
+					DeclaredValue: 149_990, Currency: "RUB"}, nil)
+			},
+			expectedCurrency: "RUB",
+		},
+		{
+			name:          "value without currency",
+			value:         149_990,
+			prepareRepos:  func(productRepo *mocks.Product, receptionRepo *mocks.Reception, pvzRepo *mocks.PVZ) {},
+			expectedError: ErrInvalidValue,
+		},
+		{
+			name:          "currency without value",
+			currency:      "RUB",
+			prepareRepos:  func(productRepo *mocks.Product, receptionRepo *mocks.Reception, pvzRepo *mocks.PVZ) {},
+			expectedError: ErrInvalidValue,
+		},
+		{
+			name:          "negative value",
+			value:         -1,
+			currency:      "RUB",
+			prepareRepos:  func(productRepo *mocks.Product, receptionRepo *mocks.Reception, pvzRepo *mocks.PVZ) {},
+			expectedError: ErrInvalidValue,
+		},
+		{
+			name:          "value too large",
+			value:         maxDeclaredValue + 1,
+			currency:      "RUB",
+			prepareRepos:  func(productRepo *mocks.Product, receptionRepo *mocks.Reception, pvzRepo *mocks.PVZ) {},
+			expectedError: ErrInvalidValue,
+		},
+		{
+			name:          "invalid currency code",
+			value:         149_990,
+			currency:      "рубли",
+			prepareRepos:  func(productRepo *mocks.Product, receptionRepo *mocks.Reception, pvzRepo *mocks.PVZ) {},
+			expectedError: ErrInvalidValue,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			productRepo := mocks.NewProduct(t)
+			receptionRepo := mocks.NewReception(t)
+			pvzRepo := mocks.NewPVZ(t)
+			tc.prepareRepos(productRepo, receptionRepo, pvzRepo)
+
+			service := NewProductService(newPassthroughTransactor(t), productRepo, receptionRepo, pvzRepo, newCatalogTypeRepo(t),
+				newEmptyCellRepo(t))
+
+			product, err := service.Create(context.Background(), entity.ProductParams{
+				ReceptionTarget: entity.ReceptionTarget{PVZID: uuid.New().String()},
+				Type:            entity.ProductTypeShoes,
+				DeclaredValue:   tc.value,
+				Currency:        tc.currency,
+				AddedBy:         userID,
+			})
+
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+				assert.Nil(t, product)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.value, product.DeclaredValue)
+			assert.Equal(t, tc.expectedCurrency, product.Currency)
+		})
+	}
+}
+
+func TestProductService_GetByBarcode(t *testing.T) {
+	product := &entity.Product{ID: uuid.New(), Type: entity.ProductTypeClothes, Barcode: "ORD-1001"}
+
+	testCases := []struct {
+		name            string
+		barcode         string
+		prepareRepo     func(productRepo *mocks.Product)
+		expectedProduct *entity.Product
+		expectedError   error
+	}{
+		{
+			name:    "found",
+			barcode: "ord-1001",
+			prepareRepo: func(productRepo *mocks.Product) {
+				productRepo.On("GetByBarcode", mock.Anything, "ORD-1001").Return(product, nil)
+			},
+			expectedProduct: product,
+		},
+		{
+			name:          "invalid barcode",
+			barcode:       "ord_1001",
+			prepareRepo:   func(productRepo *mocks.Product) {},
+			expectedError: ErrInvalidBarcode,
+		},
+		{
+			name:    "not found",
+			barcode: "ORD-1002",
+			prepareRepo: func(productRepo *mocks.Product) {
+				productRepo.On("GetByBarcode", mock.Anything, "ORD-1002").Return(nil, repoerr.ErrNoRows)
+			},
+			expectedError: ErrProductNotFound,
+		},
+		{
+			name:    "repo error",
+			barcode: "ORD-1003",
+			prepareRepo: func(productRepo *mocks.Product) {
+				productRepo.On("GetByBarcode", mock.Anything, "ORD-1003").Return(nil, errors.New("database error"))
+			},
+			expectedError: ErrInternal,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			productRepo := mocks.NewProduct(t)
+			tc.prepareRepo(productRepo)
+
+			service := NewProductService(newPassthroughTransactor(t), productRepo, mocks.NewReception(t), mocks.NewPVZ(t),
+				newCatalogTypeRepo(t), newEmptyCellRepo(t))
+
+			got, err := service.GetByBarcode(context.Background(), tc.barcode)
+
+			assert.ErrorIs(t, err, tc.expectedError)
+			assert.Equal(t, tc.expectedProduct, got)
+		})
+	}
+}
+
+func TestProductService_CreateWithAttributes(t *testing.T) {
+	userID := uuid.New()
+	receptionID := uuid.New()
+
+	testCases := []struct {
+		name          string
+		productType   string
+		attributes    string
+		prepareRepos  func(productRepo *mocks.Product, receptionRepo *mocks.Reception, pvzRepo *mocks.PVZ)
+		expectedError error
+	}{
+		{
+			name:        "valid attributes are stored",
+			productType: entity.ProductTypeShoes,
+			attributes:  `{"size": 42}`,
+			prepareRepos: func(productRepo *mocks.Product, receptionRepo *mocks.Reception, pvzRepo *mocks.PVZ) {
+				pvzRepo.On("Exists", mock.Anything, mock.AnythingOfType("string")).Return(true)
+				receptionRepo.On("LockLastOpenReception", mock.Anything, mock.AnythingOfType("string")).
+					Return(&entity.Reception{ID: receptionID, Status: entity.StatusInProgress}, nil)
+				productRepo.On("Create", mock.Anything, entity.Product{
+					ReceptionID: receptionID,
+					Type:        entity.ProductTypeShoes,
+					Attributes:  json.RawMessage(`{"size": 42}`),
+					AddedBy:     userID,
+				}).Return(&entity.Product{ID: uuid.New(), Type: entity.ProductTypeShoes, ReceptionID: receptionID}, nil)
+			},
+		},
+		{
+			name:        "null attributes are dropped",
+			productType: entity.ProductTypeClothes,
+			attributes:  `null`,
+			prepareRepos: func(productRepo *mocks.Product, receptionRepo *mocks.Reception, pvzRepo *mocks.PVZ) {
+				pvzRepo.On("Exists", mock.Anything, mock.AnythingOfType("string")).Return(true)
+				receptionRepo.On("LockLastOpenReception", mock.Anything, mock.AnythingOfType("string")).
+					Return(&entity.Reception{ID: receptionID, Status: entity.StatusInProgress}, nil)
+				productRepo.On("Create", mock.Anything, entity.Product{
+					ReceptionID: receptionID,
+					Type:        entity.ProductTypeClothes,
+					AddedBy:     userID,
+				}).Return(&entity.Product{ID: uuid.New(), Type: entity.ProductTypeClothes, ReceptionID: receptionID}, nil)
+			},
+		},
+		{
+			name:          "attributes do not match schema",
+			productType:   entity.ProductTypeShoes,
+			attributes:    `{"size": 100}`,
+			prepareRepos:  func(productRepo *mocks.Product, receptionRepo *mocks.Reception, pvzRepo *mocks.PVZ) {},
+			expectedError: ErrInvalidAttributes,
+		},
+		{
+			name:          "type not in catalog",
+			productType:   "продукты",
+			prepareRepos:  func(productRepo *mocks.Product, receptionRepo *mocks.Reception, pvzRepo *mocks.PVZ) {},
+			expectedError: ErrInvalidProductType,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			productRepo := mocks.NewProduct(t)
+			receptionRepo := mocks.NewReception(t)
+			pvzRepo := mocks.NewPVZ(t)
+			tc.prepareRepos(productRepo, receptionRepo, pvzRepo)
+
+			service := NewProductService(newPassthroughTransactor(t), productRepo, receptionRepo, pvzRepo,
+				newCatalogTypeRepo(t), newEmptyCellRepo(t))
+
+			var attributes json.RawMessage
+			if tc.attributes != "" {
+				attributes = json.RawMessage(tc.attributes)
+			}
+			product, err := service.Create(context.Background(), entity.ProductParams{
+				ReceptionTarget: entity.ReceptionTarget{PVZID: uuid.New().String()},
+				Type:            tc.productType,
+				Attributes:      attributes,
+				AddedBy:         userID,
+			})
+
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+				assert.Nil(t, product)
+				return
+			}
+			assert.NoError(t, err)
+			assert.NotNil(t, product)
+		})
+	}
+}
+
+func TestProductService_Delete(t *testing.T) {
+	userID := uuid.New()
+	pvzID := uuid.New()
+	productID := uuid.New()
+	receptionID := uuid.New()
+	deletedAt := time.Now()
+	product := &entity.Product{ID: productID, Type: entity.ProductTypeShoes, ReceptionID: receptionID,
+		Status: entity.ProductStatusStored}
+	openReception := &entity.Reception{ID: receptionID, PVZID: pvzID, Status: entity.StatusInProgress}
+
+	testCases := []struct {
+		name          string
+		pvzID         string
+		prepareRepos  func(productRepo *mocks.Product, receptionRepo *mocks.Reception, pvzRepo *mocks.PVZ)
+		expectedError error
+	}{
+		{
+			name:  "successful deletion",
+			pvzID: pvzID.String(),
+			prepareRepos: func(productRepo *mocks.Product, receptionRepo *mocks.Reception, pvzRepo *mocks.PVZ) {
+				pvzRepo.On("Exists", mock.Anything, pvzID.String()).Return(true)
+				productRepo.On("GetByID", mock.Anything, productID.String()).Return(product, nil)
+				receptionRepo.On("LockByID", mock.Anything, receptionID.String()).Return(openReception, nil)
+				productRepo.On("Delete", mock.Anything, productID.String(), userID).Return(nil)
+			},
+		},
+		{
+			name:          "pvz is required",
+			prepareRepos:  func(productRepo *mocks.Product, receptionRepo *mocks.Reception, pvzRepo *mocks.PVZ) {},
+			expectedError: ErrInvalidPVZID,
+		},
+		{
+			name:  "unknown pvz",
+			pvzID: pvzID.String(),
+			prepareRepos: func(productRepo *mocks.Product, receptionRepo *mocks.Reception, pvzRepo *mocks.PVZ) {
+				pvzRepo.On("Exists", mock.Anything, pvzID.String()).Return(false)
+			},
+			expectedError: ErrInvalidPVZID,
+		},
+		{
+			name:  "product not found",
+			pvzID: pvzID.String(),
+			prepareRepos: func(productRepo *mocks.Product, receptionRepo *mocks.Reception, pvzRepo *mocks.PVZ) {
+				pvzRepo.On("Exists", mock.Anything, pvzID.String()).Return(true)
+				productRepo.On("GetByID", mock.Anything, productID.String()).Return(nil, repoerr.ErrNoRows)
+			},
+			expectedError: ErrProductNotFound,
+		},
+		{
+			name:  "product already deleted",
+			pvzID: pvzID.String(),
+			prepareRepos: func(productRepo *mocks.Product, receptionRepo *mocks.Reception, pvzRepo *mocks.PVZ) {
+				pvzRepo.On("Exists", mock.Anything, pvzID.String()).Return(true)
+				productRepo.On("GetByID", mock.Anything, productID.String()).
+					Return(&entity.Product{ID: productID, ReceptionID: receptionID, DeletedAt: &deletedAt}, nil)
+			},
+			expectedError: ErrProductNotFound,
+		},
+		{
+			name:  "product of another pvz",
+			pvzID: uuid.New().String(),
+			prepareRepos: func(productRepo *mocks.Product, receptionRepo *mocks.Reception, pvzRepo *mocks.PVZ) {
+				pvzRepo.On("Exists", mock.Anything, mock.AnythingOfType("string")).Return(true)
+				productRepo.On("GetByID", mock.Anything, productID.String()).Return(product, nil)
+				receptionRepo.On("LockByID", mock.Anything, receptionID.String()).Return(openReception, nil)
+			},
+			expectedError: ErrProductNotFound,
+		},
+		{
+			name:  "issued product after reopen",
+			pvzID: pvzID.String(),
+			prepareRepos: func(productRepo *mocks.Product, receptionRepo *mocks.Reception, pvzRepo *mocks.PVZ) {
+				pvzRepo.On("Exists", mock.Anything, pvzID.String()).Return(true)
+				productRepo.On("GetByID", mock.Anything, productID.String()).
+					Return(&entity.Product{ID: productID, ReceptionID: receptionID,
+						Status: entity.ProductStatusIssued}, nil)
+				receptionRepo.On("LockByID", mock.Anything, receptionID.String()).Return(openReception, nil)
+			},
+			expectedError: ErrProductNotStored,
+		},
+		{
+			name:  "reception closed",
+			pvzID: pvzID.String(),
+			prepareRepos: func(productRepo *mocks.Product, receptionRepo *mocks.Reception, pvzRepo *mocks.PVZ) {
+				pvzRepo.On("Exists", mock.Anything, pvzID.String()).Return(true)
+				productRepo.On("GetByID", mock.Anything, productID.String()).Return(product, nil)
+				receptionRepo.On("LockByID", mock.Anything, receptionID.String()).
+					Return(&entity.Reception{ID: receptionID, PVZID: pvzID, Status: entity.StatusClose}, nil)
+			},
+			expectedError: ErrInvalidReceptionStatus,
+		},
+		{
+			name:  "deleted concurrently",
+			pvzID: pvzID.String(),
+			prepareRepos: func(productRepo *mocks.Product, receptionRepo *mocks.Reception, pvzRepo *mocks.PVZ) {
+				pvzRepo.On("Exists", mock.Anything, pvzID.String()).Return(true)
+				productRepo.On("GetByID", mock.Anything, productID.String()).Return(product, nil)
+				receptionRepo.On("LockByID", mock.Anything, receptionID.String()).Return(openReception, nil)
+				productRepo.On("Delete", mock.Anything, productID.String(), userID).Return(repoerr.ErrNoRows)
+			},
+			expectedError: ErrProductNotFound,
+		},
+		{
+			name:  "repo error",
+			pvzID: pvzID.String(),
+			prepareRepos: func(productRepo *mocks.Product, receptionRepo *mocks.Reception, pvzRepo *mocks.PVZ) {
+				pvzRepo.On("Exists", mock.Anything, pvzID.String()).Return(true)
+				productRepo.On("GetByID", mock.Anything, productID.String()).Return(nil, errors.New("database error"))
+			},
+			expectedError: ErrInternal,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			productRepo := mocks.NewProduct(t)
+			receptionRepo := mocks.NewReception(t)
+			pvzRepo := mocks.NewPVZ(t)
+			tc.prepareRepos(productRepo, receptionRepo, pvzRepo)
+
+			service := NewProductService(newPassthroughTransactor(t), productRepo, receptionRepo, pvzRepo,
+				newCatalogTypeRepo(t), newEmptyCellRepo(t))
+
+			err := service.Delete(context.Background(), productID.String(), tc.pvzID, userID)
+
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
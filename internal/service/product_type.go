@@ -0,0 +1,153 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/entity"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/repo"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/repo/repoerr"
+	"log/slog"
+	"regexp"
+	"strings"
+)
+
+var productTypeNamePattern = regexp.MustCompile(`^[\p{L}\p{N}][\p{L}\p{N} -]{0,63}$`)
+
+type ProductTypeService struct {
+	typeRepo repo.ProductType
+}
+
+func NewProductTypeService(typeRepo repo.ProductType) *ProductTypeService {
+	return &ProductTypeService{typeRepo: typeRepo}
+}
+
+func (s *ProductTypeService) List(ctx context.Context) ([]entity.ProductType, error) {
+	log := slog.With("layer", "ProductTypeService", "operation", "List")
+	log.Debug("listing product types")
+
+	productTypes, err := s.typeRepo.List(ctx)
+	if err != nil {
+		log.Error("failed to list product types", "error", err)
+		return nil, ErrInternal
+	}
+	return productTypes, nil
+}
+
+func (s *ProductTypeService) Create(ctx context.Context, productType entity.ProductType) (*entity.ProductType, error) {
+	productType.Name = strings.ToLower(strings.TrimSpace(productType.Name))
+	log := slog.With("layer", "ProductTypeService", "operation", "Create", "name", productType.Name,
+		"userID", productType.CreatedBy.String())
+	log.Debug("starting product type creation")
+
+	if !productTypeNamePattern.MatchString(productType.Name) {
+		return nil, ErrInvalidProductTypeName
+	}
+
+	schema, err := normalizeAttributeSchema(productType.AttributesSchema)
+	if err != nil {
+		log.Warn("invalid attributes schema")
+		return nil, err
+	}
+	productType.AttributesSchema = schema
+
+	if err := validateStorageDays(productType.StorageDays); err != nil {
+		log.Warn("invalid storage period", "days", productType.StorageDays)
+		return nil, err
+	}
+
+	created, err := s.typeRepo.Create(ctx, productType)
+	if err != nil {
+		if errors.Is(err, repoerr.ErrDuplicateEntry) {
+			log.Warn("product type already exists")
+			return nil, ErrProductTypeExists
+		}
+		log.Error("failed to create product type", "error", err)
+		return nil, ErrInternal
+	}
+
+	log.Info("product type created successfully")
+	return created, nil
+}
+
+// SetSchema заменяет схему атрибутов типа. Уже принятые товары не перепроверяются:
+// схема действует для товаров, добавленных после изменения.
+func (s *ProductTypeService) SetSchema(ctx context.Context, name string, schema json.RawMessage) (*entity.ProductType, error) {
+	log := slog.With("layer", "ProductTypeService", "operation", "SetSchema", "name", name)
+	log.Debug("starting product type schema update")
+
+	schema, err := normalizeAttributeSchema(schema)
+	if err != nil {
+		log.Warn("invalid attributes schema")
+		return nil, err
+	}
+
+	productType, err := s.typeRepo.SetSchema(ctx, entity.ProductType{Name: name, AttributesSchema: schema})
+	if err != nil {
+		if errors.Is(err, repoerr.ErrNoRows) {
+			log.Warn("product type not found")
+			return nil, ErrProductTypeNotFound
+		}
+		log.Error("failed to update product type", "error", err)
+		return nil, ErrInternal
+	}
+
+	log.Info("product type schema updated")
+	return productType, nil
+}
+
+// SetStorageDays задаёт срок хранения товаров типа в днях; 0 сбрасывает срок, и тогда действует
+// срок ПВЗ или срок по умолчанию. Товары, уже попавшие в очередь на возврат, остаются в ней.
+func (s *ProductTypeService) SetStorageDays(ctx context.Context, name string, days int) (*entity.ProductType, error) {
+	log := slog.With("layer", "ProductTypeService", "operation", "SetStorageDays", "name", name, "days", days)
+	log.Debug("starting product type storage period update")
+
+	if err := validateStorageDays(days); err != nil {
+		log.Warn("invalid storage period")
+		return nil, err
+	}
+
+	productType, err := s.typeRepo.SetStorageDays(ctx, name, days)
+	if err != nil {
+		if errors.Is(err, repoerr.ErrNoRows) {
+			log.Warn("product type not found")
+			return nil, ErrProductTypeNotFound
+		}
+		log.Error("failed to update product type", "error", err)
+		return nil, ErrInternal
+	}
+
+	log.Info("product type storage period updated")
+	return productType, nil
+}
+
+// normalizeAttributeSchema проверяет схему; пустая схема и null сохраняются как отсутствие схемы.
+func normalizeAttributeSchema(schema json.RawMessage) (json.RawMessage, error) {
+	if isNullJSON(schema) {
+		return nil, nil
+	}
+	if _, err := parseAttributeSchema(schema); err != nil {
+		return nil, err
+	}
+	return schema, nil
+}
+
+func isNullJSON(raw json.RawMessage) bool {
+	raw = bytes.TrimSpace(raw)
+	return len(raw) == 0 || bytes.Equal(raw, []byte("null"))
+}
+
+// productTypeNames возвращает имена всех типов каталога для проверки манифеста и пересчёта.
+func productTypeNames(ctx context.Context, typeRepo repo.ProductType, log *slog.Logger) (map[string]bool, error) {
+	productTypes, err := typeRepo.List(ctx)
+	if err != nil {
+		log.Error("failed to list product types", "error", err)
+		return nil, ErrInternal
+	}
+	known := make(map[string]bool, len(productTypes))
+	for _, productType := range productTypes {
+		known[productType.Name] = true
+	}
+	return known, nil
+}
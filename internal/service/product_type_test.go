@@ -0,0 +1,324 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/entity"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/repo/mocks"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/repo/repoerr"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+)
+
+const (
+	shoesSchema       = `{"type": "object", "properties": {"size": {"type": "number", "minimum": 15, "maximum": 55}}}`
+	electronicsSchema = `{"type": "object", "properties": {"imei": {"type": "string", "pattern": "^[0-9]{15}$"}}}`
+	strictSchema      = `{"type": "object", "required": ["imei"], "additionalProperties": false,
+		"properties": {"imei": {"type": "string", "pattern": "^[0-9]{15}$"}}}`
+)
+
+// newCatalogTypeRepo возвращает каталог с исходными тремя типами.
+func newCatalogTypeRepo(t *testing.T) *mocks.ProductType {
+	catalog := []entity.ProductType{
+		{Name: entity.ProductTypeClothes},
+		{Name: entity.ProductTypeElectronics, AttributesSchema: json.RawMessage(electronicsSchema)},
+		{Name: entity.ProductTypeShoes, AttributesSchema: json.RawMessage(shoesSchema)},
+	}
+	typeRepo := mocks.NewProductType(t)
+	typeRepo.On("List", mock.Anything).Return(catalog, nil).Maybe()
+	typeRepo.On("GetByName", mock.Anything, mock.Anything).
+		Return(func(ctx context.Context, name string) (*entity.ProductType, error) {
+			for _, productType := range catalog {
+				if productType.Name == name {
+					return &productType, nil
+				}
+			}
+			return nil, repoerr.ErrNoRows
+		}).Maybe()
+	return typeRepo
+}
+
+func TestValidateAttributes(t *testing.T) {
+	testCases := []struct {
+		name          string
+		schema        string
+		attributes    string
+		expectedError error
+		expectedText  string
+	}{
+		{name: "no schema, no attributes"},
+		{name: "no schema, empty object", attributes: `{}`},
+		{name: "no schema, attributes given", attributes: `{"color": "red"}`,
+			expectedError: ErrInvalidAttributes, expectedText: "product type has no attributes"},
+		{name: "optional attribute omitted", schema: shoesSchema},
+		{name: "valid number", schema: shoesSchema, attributes: `{"size": 42.5}`},
+		{name: "extra attribute allowed", schema: shoesSchema, attributes: `{"size": 42, "color": "red"}`},
+		{name: "number out of range", schema: shoesSchema, attributes: `{"size": 60}`,
+			expectedError: ErrInvalidAttributes, expectedText: "size: must be at most 55"},
+		{name: "wrong type", schema: shoesSchema, attributes: `{"size": "42"}`,
+			expectedError: ErrInvalidAttributes, expectedText: "size: must be a number"},
+		{name: "not an object", schema: shoesSchema, attributes: `[42]`,
+			expectedError: ErrInvalidAttributes, expectedText: "must be an object"},
+		{name: "required attribute missing", schema: strictSchema, attributes: `{}`,
+			expectedError: ErrInvalidAttributes, expectedText: "imei: is required"},
+		{name: "pattern mismatch", schema: strictSchema, attributes: `{"imei": "12345"}`,
+			expectedError: ErrInvalidAttributes, expectedText: "imei: must match"},
+		{name: "additional attribute forbidden", schema: strictSchema,
+			attributes:    `{"imei": "123456789012345", "color": "black"}`,
+			expectedError: ErrInvalidAttributes, expectedText: "color: is not allowed"},
+		{name: "integer and enum", schema: `{"type": "object", "properties": {
+			"pairs": {"type": "integer", "enum": [1, 2]}}}`, attributes: `{"pairs": 2}`},
+		{name: "integer rejects fraction", schema: `{"type": "object", "properties": {
+			"pairs": {"type": "integer"}}}`, attributes: `{"pairs": 1.5}`,
+			expectedError: ErrInvalidAttributes, expectedText: "pairs: must be an integer"},
+		{name: "enum mismatch", schema: `{"type": "object", "properties": {
+			"season": {"type": "string", "enum": ["лето", "зима"]}}}`, attributes: `{"season": "осень"}`,
+			expectedError: ErrInvalidAttributes, expectedText: "season: must be one of the allowed values"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var schema, attributes json.RawMessage
+			if tc.schema != "" {
+				schema = json.RawMessage(tc.schema)
+			}
+			if tc.attributes != "" {
+				attributes = json.RawMessage(tc.attributes)
+			}
+
+			err := validateAttributes(schema, attributes)
+
+			if tc.expectedError == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, tc.expectedError)
+			assert.Contains(t, err.Error(), tc.expectedText)
+		})
+	}
+}
+
+func TestProductTypeService_Create(t *testing.T) {
+	userID := uuid.New()
+
+	testCases := []struct {
+		name          string
+		productType   entity.ProductType
+		prepareRepo   func(typeRepo *mocks.ProductType)
+		expectedName  string
+		expectedError error
+	}{
+		{
+			name:        "successful creation with normalized name",
+			productType: entity.ProductType{Name: "  Продукты ", CreatedBy: userID},
+			prepareRepo: func(typeRepo *mocks.ProductType) {
+				typeRepo.On("Create", mock.Anything, entity.ProductType{Name: "продукты", CreatedBy: userID}).
+					Return(&entity.ProductType{Name: "продукты", CreatedBy: userID}, nil)
+			},
+			expectedName: "продукты",
+		},
+		{
+			name: "creation with schema",
+			productType: entity.ProductType{Name: "обувь детская",
+				AttributesSchema: json.RawMessage(shoesSchema)},
+			prepareRepo: func(typeRepo *mocks.ProductType) {
+				typeRepo.On("Create", mock.Anything, mock.AnythingOfType("entity.ProductType")).
+					Return(&entity.ProductType{Name: "обувь детская", AttributesSchema: json.RawMessage(shoesSchema)}, nil)
+			},
+			expectedName: "обувь детская",
+		},
+		{
+			name:          "invalid name",
+			productType:   entity.ProductType{Name: "-"},
+			prepareRepo:   func(typeRepo *mocks.ProductType) {},
+			expectedError: ErrInvalidProductTypeName,
+		},
+		{
+			name:          "schema is not an object schema",
+			productType:   entity.ProductType{Name: "продукты", AttributesSchema: json.RawMessage(`{"type": "string"}`)},
+			prepareRepo:   func(typeRepo *mocks.ProductType) {},
+			expectedError: ErrInvalidAttributeSchema,
+		},
+		{
+			name: "unsupported keyword",
+			productType: entity.ProductType{Name: "продукты", AttributesSchema: json.RawMessage(
+				`{"type": "object", "properties": {"weight": {"type": "number", "multipleOf": 0.5}}}`)},
+			prepareRepo:   func(typeRepo *mocks.ProductType) {},
+			expectedError: ErrInvalidAttributeSchema,
+		},
+		{
+			name: "required attribute is not described",
+			productType: entity.ProductType{Name: "продукты", AttributesSchema: json.RawMessage(
+				`{"type": "object", "required": ["weight"]}`)},
+			prepareRepo:   func(typeRepo *mocks.ProductType) {},
+			expectedError: ErrInvalidAttributeSchema,
+		},
+		{
+			name:        "type exists",
+			productType: entity.ProductType{Name: entity.ProductTypeShoes},
+			prepareRepo: func(typeRepo *mocks.ProductType) {
+				typeRepo.On("Create", mock.Anything, mock.AnythingOfType("entity.ProductType")).
+					Return(nil, repoerr.ErrDuplicateEntry)
+			},
+			expectedError: ErrProductTypeExists,
+		},
+		{
+			name:        "repo error",
+			productType: entity.ProductType{Name: "продукты"},
+			prepareRepo: func(typeRepo *mocks.ProductType) {
+				typeRepo.On("Create", mock.Anything, mock.AnythingOfType("entity.ProductType")).
+					Return(nil, errors.New("database error"))
+			},
+			expectedError: ErrInternal,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			typeRepo := mocks.NewProductType(t)
+			tc.prepareRepo(typeRepo)
+
+			service := NewProductTypeService(typeRepo)
+
+			productType, err := service.Create(context.Background(), tc.productType)
+
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+				assert.Nil(t, productType)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedName, productType.Name)
+		})
+	}
+}
+
+func TestProductTypeService_SetSchema(t *testing.T) {
+	testCases := []struct {
+		name          string
+		schema        string
+		prepareRepo   func(typeRepo *mocks.ProductType)
+		expectedError error
+	}{
+		{
+			name:   "schema replaced",
+			schema: shoesSchema,
+			prepareRepo: func(typeRepo *mocks.ProductType) {
+				typeRepo.On("SetSchema", mock.Anything, entity.ProductType{Name: entity.ProductTypeShoes,
+					AttributesSchema: json.RawMessage(shoesSchema)}).
+					Return(&entity.ProductType{Name: entity.ProductTypeShoes}, nil)
+			},
+		},
+		{
+			name:   "null removes schema",
+			schema: "null",
+			prepareRepo: func(typeRepo *mocks.ProductType) {
+				typeRepo.On("SetSchema", mock.Anything, entity.ProductType{Name: entity.ProductTypeShoes}).
+					Return(&entity.ProductType{Name: entity.ProductTypeShoes}, nil)
+			},
+		},
+		{
+			name:          "invalid schema",
+			schema:        `{"type": "object", "properties": {"size": {"type": "array"}}}`,
+			prepareRepo:   func(typeRepo *mocks.ProductType) {},
+			expectedError: ErrInvalidAttributeSchema,
+		},
+		{
+			name:   "type not found",
+			schema: shoesSchema,
+			prepareRepo: func(typeRepo *mocks.ProductType) {
+				typeRepo.On("SetSchema", mock.Anything, mock.AnythingOfType("entity.ProductType")).
+					Return(nil, repoerr.ErrNoRows)
+			},
+			expectedError: ErrProductTypeNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			typeRepo := mocks.NewProductType(t)
+			tc.prepareRepo(typeRepo)
+
+			service := NewProductTypeService(typeRepo)
+
+			productType, err := service.SetSchema(context.Background(), entity.ProductTypeShoes, json.RawMessage(tc.schema))
+
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+				assert.Nil(t, productType)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, entity.ProductTypeShoes, productType.Name)
+		})
+	}
+}
+
+func TestProductTypeService_SetStorageDays(t *testing.T) {
+	testCases := []struct {
+		name          string
+		days          int
+		prepareRepo   func(typeRepo *mocks.ProductType)
+		expectedError error
+	}{
+		{
+			name: "storage period set",
+			days: 14,
+			prepareRepo: func(typeRepo *mocks.ProductType) {
+				typeRepo.On("SetStorageDays", mock.Anything, entity.ProductTypeShoes, 14).
+					Return(&entity.ProductType{Name: entity.ProductTypeShoes, StorageDays: 14}, nil)
+			},
+		},
+		{
+			name: "storage period reset",
+			days: 0,
+			prepareRepo: func(typeRepo *mocks.ProductType) {
+				typeRepo.On("SetStorageDays", mock.Anything, entity.ProductTypeShoes, 0).
+					Return(&entity.ProductType{Name: entity.ProductTypeShoes}, nil)
+			},
+		},
+		{
+			name:          "negative period",
+			days:          -1,
+			prepareRepo:   func(typeRepo *mocks.ProductType) {},
+			expectedError: ErrInvalidStoragePeriod,
+		},
+		{
+			name:          "period too long",
+			days:          maxStorageDays + 1,
+			prepareRepo:   func(typeRepo *mocks.ProductType) {},
+			expectedError: ErrInvalidStoragePeriod,
+		},
+		{
+			name: "type not found",
+			days: 14,
+			prepareRepo: func(typeRepo *mocks.ProductType) {
+				typeRepo.On("SetStorageDays", mock.Anything, entity.ProductTypeShoes, 14).
+					Return(nil, repoerr.ErrNoRows)
+			},
+			expectedError: ErrProductTypeNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			typeRepo := mocks.NewProductType(t)
+			tc.prepareRepo(typeRepo)
+
+			service := NewProductTypeService(typeRepo)
+
+			productType, err := service.SetStorageDays(context.Background(), entity.ProductTypeShoes, tc.days)
+
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+				assert.Nil(t, productType)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.days, productType.StorageDays)
+		})
+	}
+}
@@ -2,30 +2,53 @@ package service
 
 import (
 	"context"
+	"errors"
+	"github.com/GlebMoskalev/go-pickup-point-api/config"
 	"github.com/GlebMoskalev/go-pickup-point-api/internal/entity"
 	"github.com/GlebMoskalev/go-pickup-point-api/internal/metrics"
 	"github.com/GlebMoskalev/go-pickup-point-api/internal/repo"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/repo/repoerr"
 	"log/slog"
+	"strings"
+	"sync"
 	"time"
 )
 
 type PVZService struct {
-	pvzRepo repo.PVZ
+	pvzRepo      repo.PVZ
+	dockRepo     repo.Dock
+	cellRepo     repo.StorageCell
+	insuredValue config.InsuredValue
+
+	// valueMu защищает storedCurrencies — валюты, по которым уже выставлена метрика ценности.
+	valueMu          sync.Mutex
+	storedCurrencies map[string]struct{}
 }
 
-func NewPVZService(pvzRepo repo.PVZ) *PVZService {
-	return &PVZService{pvzRepo: pvzRepo}
+func NewPVZService(pvzRepo repo.PVZ, dockRepo repo.Dock, cellRepo repo.StorageCell,
+	insuredValue config.InsuredValue) *PVZService {
+	insuredValue.Currency = strings.ToUpper(insuredValue.Currency)
+	return &PVZService{pvzRepo: pvzRepo, dockRepo: dockRepo, cellRepo: cellRepo, insuredValue: insuredValue,
+		storedCurrencies: make(map[string]struct{})}
 }
 
-func (s *PVZService) Create(ctx context.Context, city string) (*entity.PVZ, error) {
-	log := slog.With("layer", "PVZService", "operation", "Create", "city", city)
+func (s *PVZService) Create(ctx context.Context, city, timezone string) (*entity.PVZ, error) {
+	log := slog.With("layer", "PVZService", "operation", "Create", "city", city, "timezone", timezone)
 	log.Debug("starting create pvz")
 
 	if city != entity.CityMoscow && city != entity.CityKazan && city != entity.CitySPB {
 		return nil, ErrInvalidCity
 	}
 
-	pvz, err := s.pvzRepo.Create(ctx, city)
+	if timezone == "" {
+		timezone = entity.DefaultTimezone
+	}
+	if _, err := time.LoadLocation(timezone); err != nil {
+		log.Warn("invalid timezone provided", "error", err)
+		return nil, ErrInvalidTimezone
+	}
+
+	pvz, err := s.pvzRepo.Create(ctx, city, timezone)
 	if err != nil {
 		log.Error("failed to create pvz", "error", err)
 		return nil, ErrInternal
@@ -36,7 +59,7 @@ func (s *PVZService) Create(ctx context.Context, city string) (*entity.PVZ, erro
 	return pvz, nil
 }
 
-func (s *PVZService) ListWithDetails(ctx context.Context, startDate, endDate *time.Time, page, limit int) ([]entity.PVZWithDetails, error) {
+func (s *PVZService) ListWithDetails(ctx context.Context, filter entity.PVZFilter, page, limit int) ([]entity.PVZWithDetails, error) {
 	log := slog.With("layer", "PVZService", "operation", "ListWithDetails", "page", page, "limit", limit)
 	log.Debug("starting list pvz with details")
 
@@ -48,7 +71,9 @@ func (s *PVZService) ListWithDetails(ctx context.Context, startDate, endDate *ti
 		limit = 30
 	}
 
-	pvzs, err := s.pvzRepo.ListWithDetails(ctx, startDate, endDate, page, limit)
+	filter.WaybillNumber = normalizeWaybillNumber(filter.WaybillNumber)
+
+	pvzs, err := s.pvzRepo.ListWithDetails(ctx, filter, page, limit)
 	if err != nil {
 		log.Error("failed to get list pvz with details", "error", err)
 		return nil, ErrInternal
@@ -57,3 +82,138 @@ func (s *PVZService) ListWithDetails(ctx context.Context, startDate, endDate *ti
 	log.Info("pvz list with details get successfully")
 	return pvzs, err
 }
+
+func (s *PVZService) GetSchedule(ctx context.Context, pvzID string) (*entity.PVZSchedule, error) {
+	log := slog.With("layer", "PVZService", "operation", "GetSchedule", "pvzID", pvzID)
+	log.Debug("starting get pvz schedule")
+
+	schedule, err := s.pvzRepo.GetSchedule(ctx, pvzID)
+	if err != nil {
+		if errors.Is(err, repoerr.ErrNotFound) {
+			log.Warn("pvz does not exist")
+			return nil, ErrInvalidPVZID
+		}
+		log.Error("failed to get pvz schedule", "error", err)
+		return nil, ErrInternal
+	}
+
+	log.Info("pvz schedule get successfully")
+	return schedule, nil
+}
+
+func (s *PVZService) SetSchedule(ctx context.Context, schedule entity.PVZSchedule) (*entity.PVZSchedule, error) {
+	log := slog.With("layer", "PVZService", "operation", "SetSchedule", "pvzID", schedule.PVZID.String())
+	log.Debug("starting set pvz schedule")
+
+	if err := validateSchedule(schedule); err != nil {
+		log.Warn("invalid schedule provided", "error", err)
+		return nil, err
+	}
+
+	err := s.pvzRepo.SetSchedule(ctx, schedule)
+	if err != nil {
+		if errors.Is(err, repoerr.ErrNotFound) {
+			log.Warn("pvz does not exist")
+			return nil, ErrInvalidPVZID
+		}
+		log.Error("failed to set pvz schedule", "error", err)
+		return nil, ErrInternal
+	}
+
+	log.Info("pvz schedule set successfully")
+	return &schedule, nil
+}
+
+func (s *PVZService) GetSettings(ctx context.Context, pvzID string) (*entity.PVZSettings, error) {
+	log := slog.With("layer", "PVZService", "operation", "GetSettings", "pvzID", pvzID)
+	log.Debug("starting get pvz settings")
+
+	settings, err := s.pvzRepo.GetSettings(ctx, pvzID)
+	if err != nil {
+		if errors.Is(err, repoerr.ErrNotFound) {
+			log.Warn("pvz does not exist")
+			return nil, ErrInvalidPVZID
+		}
+		log.Error("failed to get pvz settings", "error", err)
+		return nil, ErrInternal
+	}
+
+	log.Info("pvz settings get successfully")
+	return settings, nil
+}
+
+func (s *PVZService) SetSettings(ctx context.Context, settings entity.PVZSettings) (*entity.PVZSettings, error) {
+	log := slog.With("layer", "PVZService", "operation", "SetSettings", "pvzID", settings.PVZID.String())
+	log.Debug("starting set pvz settings")
+
+	if settings.BlindCountPolicy != entity.BlindCountPolicyReject &&
+		settings.BlindCountPolicy != entity.BlindCountPolicyFlag {
+		log.Warn("invalid blind count policy", "policy", settings.BlindCountPolicy)
+		return nil, ErrInvalidSettings
+	}
+	if err := validateStorageDays(settings.StorageDays); err != nil {
+		log.Warn("invalid storage period", "days", settings.StorageDays)
+		return nil, ErrInvalidSettings
+	}
+
+	err := s.pvzRepo.SetSettings(ctx, settings)
+	if err != nil {
+		if errors.Is(err, repoerr.ErrNotFound) {
+			log.Warn("pvz does not exist")
+			return nil, ErrInvalidPVZID
+		}
+		log.Error("failed to set pvz settings", "error", err)
+		return nil, ErrInternal
+	}
+
+	log.Info("pvz settings set successfully")
+	return &settings, nil
+}
+
+// GetStock возвращает число товаров ПВЗ по статусам: хранящихся, выданных и возвращённых отправителю,
+// а также объявленную ценность хранящихся товаров и лимит застрахованной ценности.
+func (s *PVZService) GetStock(ctx context.Context, pvzID string) (*entity.ProductStock, error) {
+	log := slog.With("layer", "PVZService", "operation", "GetStock", "pvzID", pvzID)
+	log.Debug("starting get pvz stock")
+
+	pvz, err := s.pvzRepo.GetByID(ctx, pvzID)
+	if err != nil {
+		if errors.Is(err, repoerr.ErrNotFound) {
+			log.Warn("pvz does not exist")
+			return nil, ErrInvalidPVZID
+		}
+		log.Error("failed to get pvz", "error", err)
+		return nil, ErrInternal
+	}
+
+	counts, err := s.pvzRepo.CountProductsByStatus(ctx, pvzID)
+	if err != nil {
+		log.Error("failed to count products", "error", err)
+		return nil, ErrInternal
+	}
+
+	storedValue, err := s.pvzRepo.SumStoredValue(ctx, pvzID)
+	if err != nil {
+		log.Error("failed to sum stored value", "error", err)
+		return nil, ErrInternal
+	}
+
+	stock := &entity.ProductStock{
+		PVZID:            pvz.ID,
+		Stored:           counts[entity.ProductStatusStored],
+		Issued:           counts[entity.ProductStatusIssued],
+		ReturnedToSender: counts[entity.ProductStatusReturnedToSender],
+		InTransit:        counts[entity.ProductStatusInTransit],
+		StoredValue:      storedValue,
+	}
+	if s.insuredValue.Ceiling > 0 {
+		stock.InsuredCeiling = s.insuredValue.Ceiling
+		stock.InsuredCurrency = s.insuredValue.Currency
+		for _, total := range storedValue {
+			if s.exceedsCeiling(total) {
+				stock.ExceedsCeiling = true
+			}
+		}
+	}
+	return stock, nil
+}
@@ -3,8 +3,10 @@ package service
 import (
 	"context"
 	"errors"
+	"github.com/GlebMoskalev/go-pickup-point-api/config"
 	"github.com/GlebMoskalev/go-pickup-point-api/internal/entity"
 	"github.com/GlebMoskalev/go-pickup-point-api/internal/repo/mocks"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/repo/repoerr"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -16,6 +18,7 @@ func TestPVZService_Create(t *testing.T) {
 	testCases := []struct {
 		name          string
 		city          string
+		timezone      string
 		prepareRepo   func(repo *mocks.PVZ)
 		expectedPVZ   *entity.PVZ
 		expectedError error
@@ -25,20 +28,49 @@ func TestPVZService_Create(t *testing.T) {
 			city: entity.CityMoscow,
 			prepareRepo: func(repo *mocks.PVZ) {
 				pvzID := uuid.New()
-				repo.On("Create", mock.Anything, entity.CityMoscow).
+				repo.On("Create", mock.Anything, entity.CityMoscow, entity.DefaultTimezone).
 					Return(&entity.PVZ{
 						ID:               pvzID,
 						RegistrationDate: time.Now(),
 						City:             entity.CityMoscow,
+						Timezone:         entity.DefaultTimezone,
 					}, nil)
 			},
 			expectedPVZ: &entity.PVZ{
 				ID:               uuid.UUID{},
 				RegistrationDate: time.Time{},
 				City:             entity.CityMoscow,
+				Timezone:         entity.DefaultTimezone,
 			},
 			expectedError: nil,
 		},
+		{
+			name:     "successful creation with explicit timezone",
+			city:     entity.CityKazan,
+			timezone: "Europe/Samara",
+			prepareRepo: func(repo *mocks.PVZ) {
+				repo.On("Create", mock.Anything, entity.CityKazan, "Europe/Samara").
+					Return(&entity.PVZ{
+						ID:               uuid.New(),
+						RegistrationDate: time.Now(),
+						City:             entity.CityKazan,
+						Timezone:         "Europe/Samara",
+					}, nil)
+			},
+			expectedPVZ: &entity.PVZ{
+				City:     entity.CityKazan,
+				Timezone: "Europe/Samara",
+			},
+			expectedError: nil,
+		},
+		{
+			name:          "invalid timezone",
+			city:          entity.CityMoscow,
+			timezone:      "Mars/Olympus",
+			prepareRepo:   func(repo *mocks.PVZ) {},
+			expectedPVZ:   nil,
+			expectedError: ErrInvalidTimezone,
+		},
 		{
 			name:          "invalid city",
 			city:          "InvalidCity",
@@ -50,7 +82,7 @@ func TestPVZService_Create(t *testing.T) {
 			name: "repo error",
 			city: entity.CityKazan,
 			prepareRepo: func(repo *mocks.PVZ) {
-				repo.On("Create", mock.Anything, entity.CityKazan).
+				repo.On("Create", mock.Anything, entity.CityKazan, entity.DefaultTimezone).
 					Return(nil, errors.New("database error"))
 			},
 			expectedPVZ:   nil,
@@ -62,10 +94,10 @@ func TestPVZService_Create(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			pvzRepo := mocks.NewPVZ(t)
 			tc.prepareRepo(pvzRepo)
-			service := NewPVZService(pvzRepo)
+			service := NewPVZService(pvzRepo, mocks.NewDock(t), mocks.NewStorageCell(t), config.InsuredValue{})
 			ctx := context.Background()
 
-			pvz, err := service.Create(ctx, tc.city)
+			pvz, err := service.Create(ctx, tc.city, tc.timezone)
 
 			if tc.expectedError != nil {
 				assert.ErrorIs(t, err, tc.expectedError)
@@ -76,6 +108,7 @@ func TestPVZService_Create(t *testing.T) {
 				_, err := uuid.Parse(pvz.ID.String())
 				assert.NoError(t, err, "PVZ ID should be a valid UUID")
 				assert.Equal(t, tc.expectedPVZ.City, pvz.City)
+				assert.Equal(t, tc.expectedPVZ.Timezone, pvz.Timezone)
 				assert.False(t, pvz.RegistrationDate.IsZero(), "RegistrationDate should be set")
 			}
 		})
@@ -106,7 +139,7 @@ func TestPVZService_ListWithDetails(t *testing.T) {
 				pvzID := uuid.New()
 				receptionID := uuid.New()
 				productID := uuid.New()
-				repo.On("ListWithDetails", mock.Anything, &startDate, &endDate, 1, 10).
+				repo.On("ListWithDetails", mock.Anything, entity.PVZFilter{StartDate: &startDate, EndDate: &endDate}, 1, 10).
 					Return([]entity.PVZWithDetails{
 						{
 							PVZ: entity.PVZ{
@@ -171,7 +204,7 @@ func TestPVZService_ListWithDetails(t *testing.T) {
 			page:      0,
 			limit:     10,
 			prepareRepo: func(repo *mocks.PVZ) {
-				repo.On("ListWithDetails", mock.Anything, (*time.Time)(nil), (*time.Time)(nil), 1, 10).
+				repo.On("ListWithDetails", mock.Anything, entity.PVZFilter{}, 1, 10).
 					Return([]entity.PVZWithDetails{}, nil)
 			},
 			expectedPVZs:  []entity.PVZWithDetails{},
@@ -184,7 +217,7 @@ func TestPVZService_ListWithDetails(t *testing.T) {
 			page:      1,
 			limit:     50,
 			prepareRepo: func(repo *mocks.PVZ) {
-				repo.On("ListWithDetails", mock.Anything, (*time.Time)(nil), (*time.Time)(nil), 1, 30).
+				repo.On("ListWithDetails", mock.Anything, entity.PVZFilter{}, 1, 30).
 					Return([]entity.PVZWithDetails{}, nil)
 			},
 			expectedPVZs:  []entity.PVZWithDetails{},
@@ -197,7 +230,7 @@ func TestPVZService_ListWithDetails(t *testing.T) {
 			page:      1,
 			limit:     10,
 			prepareRepo: func(repo *mocks.PVZ) {
-				repo.On("ListWithDetails", mock.Anything, &startDate, &endDate, 1, 10).
+				repo.On("ListWithDetails", mock.Anything, entity.PVZFilter{StartDate: &startDate, EndDate: &endDate}, 1, 10).
 					Return(nil, errors.New("database error"))
 			},
 			expectedPVZs:  nil,
@@ -210,7 +243,7 @@ func TestPVZService_ListWithDetails(t *testing.T) {
 			page:      1,
 			limit:     10,
 			prepareRepo: func(repo *mocks.PVZ) {
-				repo.On("ListWithDetails", mock.Anything, (*time.Time)(nil), (*time.Time)(nil), 1, 10).
+				repo.On("ListWithDetails", mock.Anything, entity.PVZFilter{}, 1, 10).
 					Return([]entity.PVZWithDetails{}, nil)
 			},
 			expectedPVZs:  []entity.PVZWithDetails{},
@@ -222,10 +255,11 @@ func TestPVZService_ListWithDetails(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			pvzRepo := mocks.NewPVZ(t)
 			tc.prepareRepo(pvzRepo)
-			service := NewPVZService(pvzRepo)
+			service := NewPVZService(pvzRepo, mocks.NewDock(t), mocks.NewStorageCell(t), config.InsuredValue{})
 			ctx := context.Background()
 
-			pvzs, err := service.ListWithDetails(ctx, tc.startDate, tc.endDate, tc.page, tc.limit)
+			filter := entity.PVZFilter{StartDate: tc.startDate, EndDate: tc.endDate}
+			pvzs, err := service.ListWithDetails(ctx, filter, tc.page, tc.limit)
 
 			if tc.expectedError != nil {
 				assert.ErrorIs(t, err, tc.expectedError)
@@ -267,3 +301,345 @@ func TestPVZService_ListWithDetails(t *testing.T) {
 		})
 	}
 }
+
+func TestPVZService_GetSchedule(t *testing.T) {
+	pvzID := uuid.New()
+
+	testCases := []struct {
+		name          string
+		prepareRepo   func(repo *mocks.PVZ)
+		expectedError error
+	}{
+		{
+			name: "successful get",
+			prepareRepo: func(repo *mocks.PVZ) {
+				repo.On("GetSchedule", mock.Anything, pvzID.String()).
+					Return(&entity.PVZSchedule{PVZID: pvzID, Timezone: entity.DefaultTimezone}, nil)
+			},
+			expectedError: nil,
+		},
+		{
+			name: "pvz not found",
+			prepareRepo: func(repo *mocks.PVZ) {
+				repo.On("GetSchedule", mock.Anything, pvzID.String()).
+					Return(nil, repoerr.ErrNotFound)
+			},
+			expectedError: ErrInvalidPVZID,
+		},
+		{
+			name: "repo error",
+			prepareRepo: func(repo *mocks.PVZ) {
+				repo.On("GetSchedule", mock.Anything, pvzID.String()).
+					Return(nil, errors.New("database error"))
+			},
+			expectedError: ErrInternal,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pvzRepo := mocks.NewPVZ(t)
+			tc.prepareRepo(pvzRepo)
+			service := NewPVZService(pvzRepo, mocks.NewDock(t), mocks.NewStorageCell(t), config.InsuredValue{})
+
+			schedule, err := service.GetSchedule(context.Background(), pvzID.String())
+
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+				assert.Nil(t, schedule)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, pvzID, schedule.PVZID)
+			}
+		})
+	}
+}
+
+func TestPVZService_SetSchedule(t *testing.T) {
+	pvzID := uuid.New()
+	validSchedule := entity.PVZSchedule{
+		PVZID:               pvzID,
+		Timezone:            entity.DefaultTimezone,
+		EnforceWorkingHours: true,
+		WorkingHours: []entity.WorkingHours{
+			{Weekday: time.Monday, OpenTime: "09:00", CloseTime: "21:00"},
+		},
+		Holidays: []entity.Holiday{
+			{Date: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)},
+		},
+	}
+
+	testCases := []struct {
+		name          string
+		schedule      func() entity.PVZSchedule
+		prepareRepo   func(repo *mocks.PVZ)
+		expectedError error
+	}{
+		{
+			name:     "successful set",
+			schedule: func() entity.PVZSchedule { return validSchedule },
+			prepareRepo: func(repo *mocks.PVZ) {
+				repo.On("SetSchedule", mock.Anything, validSchedule).Return(nil)
+			},
+			expectedError: nil,
+		},
+		{
+			name: "invalid timezone",
+			schedule: func() entity.PVZSchedule {
+				s := validSchedule
+				s.Timezone = "Mars/Olympus"
+				return s
+			},
+			prepareRepo:   func(repo *mocks.PVZ) {},
+			expectedError: ErrInvalidTimezone,
+		},
+		{
+			name: "close before open",
+			schedule: func() entity.PVZSchedule {
+				s := validSchedule
+				s.WorkingHours = []entity.WorkingHours{{Weekday: time.Monday, OpenTime: "21:00", CloseTime: "09:00"}}
+				return s
+			},
+			prepareRepo:   func(repo *mocks.PVZ) {},
+			expectedError: ErrInvalidSchedule,
+		},
+		{
+			name: "duplicate weekday",
+			schedule: func() entity.PVZSchedule {
+				s := validSchedule
+				s.WorkingHours = []entity.WorkingHours{
+					{Weekday: time.Monday, OpenTime: "09:00", CloseTime: "21:00"},
+					{Weekday: time.Monday, OpenTime: "10:00", CloseTime: "20:00"},
+				}
+				return s
+			},
+			prepareRepo:   func(repo *mocks.PVZ) {},
+			expectedError: ErrInvalidSchedule,
+		},
+		{
+			name: "holiday with half interval",
+			schedule: func() entity.PVZSchedule {
+				s := validSchedule
+				s.Holidays = []entity.Holiday{{Date: time.Now(), OpenTime: "10:00"}}
+				return s
+			},
+			prepareRepo:   func(repo *mocks.PVZ) {},
+			expectedError: ErrInvalidSchedule,
+		},
+		{
+			name:     "pvz not found",
+			schedule: func() entity.PVZSchedule { return validSchedule },
+			prepareRepo: func(repo *mocks.PVZ) {
+				repo.On("SetSchedule", mock.Anything, validSchedule).Return(repoerr.ErrNotFound)
+			},
+			expectedError: ErrInvalidPVZID,
+		},
+		{
+			name:     "repo error",
+			schedule: func() entity.PVZSchedule { return validSchedule },
+			prepareRepo: func(repo *mocks.PVZ) {
+				repo.On("SetSchedule", mock.Anything, validSchedule).Return(errors.New("database error"))
+			},
+			expectedError: ErrInternal,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pvzRepo := mocks.NewPVZ(t)
+			tc.prepareRepo(pvzRepo)
+			service := NewPVZService(pvzRepo, mocks.NewDock(t), mocks.NewStorageCell(t), config.InsuredValue{})
+
+			schedule, err := service.SetSchedule(context.Background(), tc.schedule())
+
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+				assert.Nil(t, schedule)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, validSchedule, *schedule)
+			}
+		})
+	}
+}
+
+func TestPVZService_SetSettings(t *testing.T) {
+	pvzID := uuid.New()
+
+	testCases := []struct {
+		name          string
+		policy        string
+		storageDays   int
+		prepareRepo   func(repo *mocks.PVZ)
+		expectedError error
+	}{
+		{
+			name:   "successful set",
+			policy: entity.BlindCountPolicyFlag,
+			prepareRepo: func(repo *mocks.PVZ) {
+				repo.On("SetSettings", mock.Anything,
+					entity.PVZSettings{PVZID: pvzID, BlindCountPolicy: entity.BlindCountPolicyFlag}).Return(nil)
+			},
+		},
+		{
+			name:        "storage period set",
+			policy:      entity.BlindCountPolicyReject,
+			storageDays: 14,
+			prepareRepo: func(repo *mocks.PVZ) {
+				repo.On("SetSettings", mock.Anything, entity.PVZSettings{PVZID: pvzID,
+					BlindCountPolicy: entity.BlindCountPolicyReject, StorageDays: 14}).Return(nil)
+			},
+		},
+		{
+			name:          "invalid policy",
+			policy:        "ignore",
+			prepareRepo:   func(repo *mocks.PVZ) {},
+			expectedError: ErrInvalidSettings,
+		},
+		{
+			name:          "storage period too long",
+			policy:        entity.BlindCountPolicyReject,
+			storageDays:   400,
+			prepareRepo:   func(repo *mocks.PVZ) {},
+			expectedError: ErrInvalidSettings,
+		},
+		{
+			name:   "pvz not found",
+			policy: entity.BlindCountPolicyReject,
+			prepareRepo: func(repo *mocks.PVZ) {
+				repo.On("SetSettings", mock.Anything, mock.AnythingOfType("entity.PVZSettings")).
+					Return(repoerr.ErrNotFound)
+			},
+			expectedError: ErrInvalidPVZID,
+		},
+		{
+			name:   "repo error",
+			policy: entity.BlindCountPolicyReject,
+			prepareRepo: func(repo *mocks.PVZ) {
+				repo.On("SetSettings", mock.Anything, mock.AnythingOfType("entity.PVZSettings")).
+					Return(errors.New("database error"))
+			},
+			expectedError: ErrInternal,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pvzRepo := mocks.NewPVZ(t)
+			tc.prepareRepo(pvzRepo)
+			service := NewPVZService(pvzRepo, mocks.NewDock(t), mocks.NewStorageCell(t), config.InsuredValue{})
+
+			settings, err := service.SetSettings(context.Background(),
+				entity.PVZSettings{PVZID: pvzID, BlindCountPolicy: tc.policy, StorageDays: tc.storageDays})
+
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+				assert.Nil(t, settings)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.policy, settings.BlindCountPolicy)
+				assert.Equal(t, tc.storageDays, settings.StorageDays)
+			}
+		})
+	}
+}
+
+func TestPVZService_GetStock(t *testing.T) {
+	pvzID := uuid.New()
+	insuredValue := config.InsuredValue{Ceiling: 1_000_000, Currency: "rub"}
+	counts := map[string]int{entity.ProductStatusStored: 7, entity.ProductStatusIssued: 3}
+
+	testCases := []struct {
+		name          string
+		insuredValue  config.InsuredValue
+		prepareRepo   func(repo *mocks.PVZ)
+		expectedStock *entity.ProductStock
+		expectedError error
+	}{
+		{
+			name:         "successful get",
+			insuredValue: insuredValue,
+			prepareRepo: func(repo *mocks.PVZ) {
+				repo.On("GetByID", mock.Anything, pvzID.String()).Return(&entity.PVZ{ID: pvzID}, nil)
+				repo.On("CountProductsByStatus", mock.Anything, pvzID.String()).Return(counts, nil)
+				repo.On("SumStoredValue", mock.Anything, pvzID.String()).Return([]entity.ValueTotal{
+					{Currency: "RUB", Amount: 1_000_000, Count: 4},
+					{Currency: "USD", Amount: 5_000_000, Count: 1},
+				}, nil)
+			},
+			expectedStock: &entity.ProductStock{PVZID: pvzID, Stored: 7, Issued: 3,
+				StoredValue: []entity.ValueTotal{
+					{Currency: "RUB", Amount: 1_000_000, Count: 4},
+					{Currency: "USD", Amount: 5_000_000, Count: 1},
+				},
+				InsuredCeiling: 1_000_000, InsuredCurrency: "RUB"},
+		},
+		{
+			name:         "ceiling exceeded",
+			insuredValue: insuredValue,
+			prepareRepo: func(repo *mocks.PVZ) {
+				repo.On("GetByID", mock.Anything, pvzID.String()).Return(&entity.PVZ{ID: pvzID}, nil)
+				repo.On("CountProductsByStatus", mock.Anything, pvzID.String()).Return(counts, nil)
+				repo.On("SumStoredValue", mock.Anything, pvzID.String()).
+					Return([]entity.ValueTotal{{Currency: "RUB", Amount: 1_000_001, Count: 5}}, nil)
+			},
+			expectedStock: &entity.ProductStock{PVZID: pvzID, Stored: 7, Issued: 3,
+				StoredValue:    []entity.ValueTotal{{Currency: "RUB", Amount: 1_000_001, Count: 5}},
+				InsuredCeiling: 1_000_000, InsuredCurrency: "RUB", ExceedsCeiling: true},
+		},
+		{
+			name: "no ceiling configured",
+			prepareRepo: func(repo *mocks.PVZ) {
+				repo.On("GetByID", mock.Anything, pvzID.String()).Return(&entity.PVZ{ID: pvzID}, nil)
+				repo.On("CountProductsByStatus", mock.Anything, pvzID.String()).Return(counts, nil)
+				repo.On("SumStoredValue", mock.Anything, pvzID.String()).
+					Return([]entity.ValueTotal{{Currency: "RUB", Amount: 1_000_001, Count: 5}}, nil)
+			},
+			expectedStock: &entity.ProductStock{PVZID: pvzID, Stored: 7, Issued: 3,
+				StoredValue: []entity.ValueTotal{{Currency: "RUB", Amount: 1_000_001, Count: 5}}},
+		},
+		{
+			name: "pvz not found",
+			prepareRepo: func(repo *mocks.PVZ) {
+				repo.On("GetByID", mock.Anything, pvzID.String()).Return(nil, repoerr.ErrNotFound)
+			},
+			expectedError: ErrInvalidPVZID,
+		},
+		{
+			name: "repo error",
+			prepareRepo: func(repo *mocks.PVZ) {
+				repo.On("GetByID", mock.Anything, pvzID.String()).Return(&entity.PVZ{ID: pvzID}, nil)
+				repo.On("CountProductsByStatus", mock.Anything, pvzID.String()).
+					Return(nil, errors.New("database error"))
+			},
+			expectedError: ErrInternal,
+		},
+		{
+			name: "stored value error",
+			prepareRepo: func(repo *mocks.PVZ) {
+				repo.On("GetByID", mock.Anything, pvzID.String()).Return(&entity.PVZ{ID: pvzID}, nil)
+				repo.On("CountProductsByStatus", mock.Anything, pvzID.String()).Return(counts, nil)
+				repo.On("SumStoredValue", mock.Anything, pvzID.String()).Return(nil, errors.New("database error"))
+			},
+			expectedError: ErrInternal,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pvzRepo := mocks.NewPVZ(t)
+			tc.prepareRepo(pvzRepo)
+			service := NewPVZService(pvzRepo, mocks.NewDock(t), mocks.NewStorageCell(t), tc.insuredValue)
+
+			stock, err := service.GetStock(context.Background(), pvzID.String())
+
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+				assert.Nil(t, stock)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedStock, stock)
+			}
+		})
+	}
+}
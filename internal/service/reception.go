@@ -3,82 +3,566 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
 	"github.com/GlebMoskalev/go-pickup-point-api/internal/entity"
 	"github.com/GlebMoskalev/go-pickup-point-api/internal/metrics"
 	"github.com/GlebMoskalev/go-pickup-point-api/internal/repo"
 	"github.com/GlebMoskalev/go-pickup-point-api/internal/repo/repoerr"
+	"github.com/google/uuid"
 	"log/slog"
+	"strings"
+	"time"
 )
 
 type ReceptionService struct {
+	transactor    repo.Transactor
 	receptionRepo repo.Reception
+	productRepo   repo.Product
 	pvzRepo       repo.PVZ
+	dockRepo      repo.Dock
+	typeRepo      repo.ProductType
 }
 
-func NewReceptionService(receptionRepo repo.Reception, pvzRepo repo.PVZ) *ReceptionService {
-	return &ReceptionService{receptionRepo: receptionRepo, pvzRepo: pvzRepo}
+func NewReceptionService(transactor repo.Transactor, receptionRepo repo.Reception, productRepo repo.Product,
+	pvzRepo repo.PVZ, dockRepo repo.Dock, typeRepo repo.ProductType) *ReceptionService {
+	return &ReceptionService{
+		transactor:    transactor,
+		receptionRepo: receptionRepo,
+		productRepo:   productRepo,
+		pvzRepo:       pvzRepo,
+		dockRepo:      dockRepo,
+		typeRepo:      typeRepo,
+	}
 }
 
-func (s *ReceptionService) Create(ctx context.Context, pvzID string) (*entity.Reception, error) {
-	log := slog.With("layer", "ReceptionService", "operation", "Create", "pvzID", pvzID)
+func (s *ReceptionService) Create(ctx context.Context, params entity.ReceptionParams) (*entity.Reception, error) {
+	pvzID := params.PVZID
+	log := slog.With("layer", "ReceptionService", "operation", "Create", "pvzID", pvzID, "dockID", params.DockID)
 	log.Debug("starting reception creation")
 
+	if len(params.Manifest) > 0 {
+		known, err := productTypeNames(ctx, s.typeRepo, log)
+		if err != nil {
+			return nil, err
+		}
+		if err := validateManifest(params.Manifest, known); err != nil {
+			log.Error("invalid manifest")
+			return nil, err
+		}
+	}
+
+	if params.Kind == "" {
+		params.Kind = entity.ReceptionKindDelivery
+	}
+	if params.Kind != entity.ReceptionKindDelivery && params.Kind != entity.ReceptionKindReturn {
+		log.Warn("invalid reception kind", "kind", params.Kind)
+		return nil, ErrInvalidReceptionKind
+	}
+
+	delivery, err := normalizeDelivery(params.Delivery)
+	if err != nil {
+		log.Warn("invalid delivery info", "error", err)
+		return nil, err
+	}
+	params.Delivery = delivery
+
 	if !s.pvzRepo.Exists(ctx, pvzID) {
 		log.Error("pvz does not exist")
 		return nil, ErrInvalidPVZID
 	}
 
-	hasOpen, err := s.receptionRepo.HasOpenReception(ctx, pvzID)
-	if err != nil {
-		log.Error("failed to check open reception", "error", err)
-		return nil, ErrInternal
+	if params.DockID != "" {
+		dock, err := s.dockRepo.GetByID(ctx, params.DockID)
+		if err != nil {
+			if errors.Is(err, repoerr.ErrNoRows) {
+				log.Error("dock not found")
+				return nil, ErrInvalidDockID
+			}
+			log.Error("failed to get dock", "error", err)
+			return nil, ErrInternal
+		}
+		if dock.PVZID.String() != pvzID {
+			log.Error("dock belongs to another pvz", "dockPVZID", dock.PVZID.String())
+			return nil, ErrInvalidDockID
+		}
 	}
 
-	if hasOpen {
-		log.Error("open reception already exists")
-		return nil, ErrOpenReceptionExists
+	if err := s.checkWorkingHours(ctx, log, pvzID); err != nil {
+		return nil, err
+	}
+
+	// Черновик не занимает док, поэтому открытая приёмка ему не мешает.
+	if !params.Draft {
+		hasOpen, err := s.receptionRepo.HasOpenReception(ctx, pvzID, params.DockID)
+		if err != nil {
+			log.Error("failed to check open reception", "error", err)
+			return nil, ErrInternal
+		}
+
+		if hasOpen {
+			log.Error("open reception already exists")
+			return nil, ErrOpenReceptionExists
+		}
 	}
 
-	reception, err := s.receptionRepo.Create(ctx, pvzID)
+	reception, err := s.receptionRepo.Create(ctx, params)
 	if err != nil {
+		if errors.Is(err, repoerr.ErrDuplicateEntry) {
+			log.Error("open reception created concurrently")
+			return nil, ErrOpenReceptionExists
+		}
 		log.Error("failed to create reception", "error", err)
 		return nil, ErrInternal
 	}
 
-	metrics.ReceptionsCreated.Inc()
-	log.Info("reception created successfully", "receptionID", reception.ID.String())
+	if reception.Kind == entity.ReceptionKindReturn {
+		metrics.ReturnReceptionsCreated.Inc()
+	} else {
+		metrics.ReceptionsCreated.Inc()
+	}
+	log.Info("reception created successfully", "receptionID", reception.ID.String(), "kind", reception.Kind)
 	return reception, nil
 }
 
-func (s *ReceptionService) CloseLastReception(ctx context.Context, pvzID string) error {
-	log := slog.With("layer", "ReceptionService", "operation", "CloseLastReception", "pvzID", pvzID)
+// CloseLastReception закрывает открытую приёмку. Переданный counted (не nil) включает «слепой» пересчёт:
+// пересчитанные сотрудником количества сверяются с учтёнными товарами до закрытия.
+func (s *ReceptionService) CloseLastReception(ctx context.Context, target entity.ReceptionTarget,
+	userID uuid.UUID, counted []entity.TypeCount) (*entity.CloseResult, error) {
+	log := slog.With("layer", "ReceptionService", "operation", "CloseLastReception", "pvzID", target.PVZID,
+		"dockID", target.DockID, "receptionID", target.ReceptionID, "userID", userID.String(),
+		"blindCount", counted != nil)
 	log.Debug("starting reception closure")
 
-	if !s.pvzRepo.Exists(ctx, pvzID) {
-		log.Error("pvz does not exist")
-		return ErrInvalidPVZID
+	if len(counted) > 0 {
+		known, err := productTypeNames(ctx, s.typeRepo, log)
+		if err != nil {
+			return nil, err
+		}
+		if err := validateBlindCount(counted, known); err != nil {
+			log.Warn("invalid blind count")
+			return nil, err
+		}
 	}
 
-	reception, err := s.receptionRepo.GetLastOpenReception(ctx, pvzID)
-	if err != nil {
-		if errors.Is(err, repoerr.ErrNoRows) {
-			log.Error("not found open reception")
-			return ErrNoOpenReception
+	if err := validateTarget(ctx, s.pvzRepo, log, target); err != nil {
+		return nil, err
+	}
+
+	result := &entity.CloseResult{}
+	err := withinTx(ctx, s.transactor, log, func(ctx context.Context) error {
+		reception, err := lockOpenReception(ctx, s.receptionRepo, log, target)
+		if err != nil {
+			return err
+		}
+		if err := s.checkTransferReceived(ctx, log, reception); err != nil {
+			return err
+		}
+
+		if counted != nil {
+			result.BlindCount, err = s.checkBlindCount(ctx, log, reception, counted)
+			if err != nil {
+				return err
+			}
+		}
+
+		err = s.receptionRepo.Close(ctx, reception.ID.String(), userID)
+		if err != nil {
+			if errors.Is(err, repoerr.ErrNoRows) {
+				log.Error("not found open reception")
+				return ErrNoOpenReception
+			}
+			log.Error("failed to close reception", "error", err)
+			return ErrInternal
+		}
+
+		result.DiscrepancyReport, err = s.reconcileManifest(ctx, log, reception.ID)
+		if err != nil {
+			return err
+		}
+
+		if result.BlindCount != nil {
+			if err := s.receptionRepo.SaveBlindCount(ctx, *result.BlindCount); err != nil {
+				log.Error("failed to save blind count", "error", err)
+				return ErrInternal
+			}
+		}
+
+		err = s.receptionRepo.AddStatusChange(ctx, entity.StatusChange{
+			ReceptionID: reception.ID,
+			FromStatus:  reception.Status,
+			ToStatus:    entity.StatusClose,
+			ChangedBy:   userID,
+		})
+		if err != nil {
+			log.Error("failed to record status change", "error", err)
+			return ErrInternal
 		}
-		log.Error("failed to check open reception", "error", err)
+
+		return s.saveAcceptanceAct(ctx, log, reception.ID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info("reception closed successfully", "hasReport", result.DiscrepancyReport != nil)
+	return result, nil
+}
+
+// checkTransferReceived не даёт закрыть приёмку перемещения, пока в неё приняты не все отправленные
+// товары: в закрытую приёмку остальные товары принять уже нельзя.
+func (s *ReceptionService) checkTransferReceived(ctx context.Context, log *slog.Logger,
+	reception *entity.Reception) error {
+	if reception.Kind != entity.ReceptionKindTransfer {
+		return nil
+	}
+	pending, err := s.receptionRepo.CountPendingTransferItems(ctx, reception.ID.String())
+	if err != nil {
+		log.Error("failed to count pending transfer items", "error", err)
+		return ErrInternal
+	}
+	if pending > 0 {
+		log.Warn("transfer reception has items not yet received", "pending", pending)
+		return ErrTransferItemsPending
+	}
+	return nil
+}
+
+// checkBlindCount сверяет пересчёт с учтёнными товарами. При расхождении поведение
+// определяется настройкой ПВЗ: отказ в закрытии или закрытие с отметкой о расхождении.
+func (s *ReceptionService) checkBlindCount(ctx context.Context, log *slog.Logger, reception *entity.Reception,
+	counted []entity.TypeCount) (*entity.BlindCountReport, error) {
+	recorded, err := s.productRepo.CountByType(ctx, reception.ID.String())
+	if err != nil {
+		log.Error("failed to count products", "error", err)
+		return nil, ErrInternal
+	}
+
+	report := buildBlindCountReport(reception.ID, counted, recorded)
+	if !report.HasMismatch() {
+		return &report, nil
+	}
+
+	settings, err := s.pvzRepo.GetSettings(ctx, reception.PVZID.String())
+	if err != nil {
+		log.Error("failed to get pvz settings", "error", err)
+		return nil, ErrInternal
+	}
+	if settings.BlindCountPolicy != entity.BlindCountPolicyFlag {
+		log.Warn("blind count does not match recorded products")
+		return nil, ErrBlindCountMismatch
+	}
+
+	log.Warn("reception closed with blind count mismatch")
+	return &report, nil
+}
+
+// reconcileManifest сохраняет отчёт о расхождениях, если приёмка открывалась с манифестом.
+func (s *ReceptionService) reconcileManifest(ctx context.Context, log *slog.Logger,
+	receptionID uuid.UUID) (*entity.DiscrepancyReport, error) {
+	manifest, err := s.receptionRepo.GetManifest(ctx, receptionID.String())
+	if err != nil {
+		log.Error("failed to get manifest", "error", err)
+		return nil, ErrInternal
+	}
+	if len(manifest) == 0 {
+		return nil, nil
+	}
+
+	received, err := s.productRepo.CountByType(ctx, receptionID.String())
+	if err != nil {
+		log.Error("failed to count products", "error", err)
+		return nil, ErrInternal
+	}
+
+	report := buildDiscrepancyReport(receptionID, manifest, received)
+	if err := s.receptionRepo.SaveDiscrepancyReport(ctx, report); err != nil {
+		log.Error("failed to save discrepancy report", "error", err)
+		return nil, ErrInternal
+	}
+	if report.HasDiscrepancies() {
+		log.Warn("reception closed with discrepancies")
+	}
+	return &report, nil
+}
+
+// checkWorkingHours проверяет, что ПВЗ, соблюдающий рабочие часы, сейчас работает.
+func (s *ReceptionService) checkWorkingHours(ctx context.Context, log *slog.Logger, pvzID string) error {
+	schedule, err := s.pvzRepo.GetSchedule(ctx, pvzID)
+	if err != nil {
+		log.Error("failed to get pvz schedule", "error", err)
 		return ErrInternal
 	}
+	if !schedule.EnforceWorkingHours {
+		return nil
+	}
 
-	err = s.receptionRepo.Close(ctx, reception.ID.String())
+	open, err := isWorkingTime(schedule, time.Now())
 	if err != nil {
-		if errors.Is(err, repoerr.ErrNoRows) {
-			log.Error("not found open reception")
-			return ErrNoOpenReception
+		log.Error("failed to check working hours", "error", err)
+		return ErrInternal
+	}
+	if !open {
+		log.Warn("reception outside working hours")
+		return ErrOutsideWorkingHours
+	}
+	return nil
+}
+
+// Start начинает приёмку, заведённую черновиком. Допустимо, только если в её доке нет другой открытой приёмки
+// и ПВЗ, соблюдающий рабочие часы, сейчас работает.
+func (s *ReceptionService) Start(ctx context.Context, receptionID, comment string,
+	userID uuid.UUID) (*entity.Reception, error) {
+	log := slog.With("layer", "ReceptionService", "operation", "Start", "receptionID", receptionID,
+		"userID", userID.String())
+	log.Debug("starting draft reception")
+
+	return s.transition(ctx, log, receptionID, entity.TransitionStart, strings.TrimSpace(comment), userID)
+}
+
+// Reopen повторно открывает закрытую по ошибке приёмку. Допустимо, только если в её доке нет другой открытой приёмки.
+func (s *ReceptionService) Reopen(ctx context.Context, receptionID, reason string,
+	userID uuid.UUID) (*entity.Reception, error) {
+	log := slog.With("layer", "ReceptionService", "operation", "Reopen", "receptionID", receptionID,
+		"userID", userID.String())
+	log.Debug("starting reception reopening")
+
+	if err := requireReason(log, &reason); err != nil {
+		return nil, err
+	}
+	return s.transition(ctx, log, receptionID, entity.TransitionReopen, reason, userID)
+}
+
+// Verify отмечает закрытую приёмку как проверенную. После проверки приёмку нельзя открыть повторно.
+func (s *ReceptionService) Verify(ctx context.Context, receptionID, comment string,
+	userID uuid.UUID) (*entity.Reception, error) {
+	log := slog.With("layer", "ReceptionService", "operation", "Verify", "receptionID", receptionID,
+		"userID", userID.String())
+	log.Debug("starting reception verification")
+
+	return s.transition(ctx, log, receptionID, entity.TransitionVerify, strings.TrimSpace(comment), userID)
+}
+
+// Cancel отменяет черновик или приёмку, открытую по ошибке. Товары отменённой приёмки помечаются
+// удалённым отменившим её пользователем, чтобы не занимать штрихкоды, коды получения и ячейки.
+func (s *ReceptionService) Cancel(ctx context.Context, receptionID, reason string,
+	userID uuid.UUID) (*entity.Reception, error) {
+	log := slog.With("layer", "ReceptionService", "operation", "Cancel", "receptionID", receptionID,
+		"userID", userID.String())
+	log.Debug("starting reception cancellation")
+
+	if err := requireReason(log, &reason); err != nil {
+		return nil, err
+	}
+	return s.transition(ctx, log, receptionID, entity.TransitionCancel, reason, userID)
+}
+
+func requireReason(log *slog.Logger, reason *string) error {
+	*reason = strings.TrimSpace(*reason)
+	if *reason == "" {
+		log.Error("reason is empty")
+		return ErrReasonRequired
+	}
+	return nil
+}
+
+// transition переводит приёмку по событию event и записывает переход в историю статусов.
+func (s *ReceptionService) transition(ctx context.Context, log *slog.Logger, receptionID, event, reason string,
+	userID uuid.UUID) (*entity.Reception, error) {
+	var reception *entity.Reception
+	err := withinTx(ctx, s.transactor, log, func(ctx context.Context) error {
+		var err error
+		reception, err = s.receptionRepo.LockByID(ctx, receptionID)
+		if err != nil {
+			if errors.Is(err, repoerr.ErrNoRows) {
+				log.Error("reception not found")
+				return ErrReceptionNotFound
+			}
+			log.Error("failed to get reception", "error", err)
+			return ErrInternal
 		}
-		log.Error("failed to close reception", "error", err)
+
+		to, ok := entity.NextReceptionStatus(reception.Status, event)
+		if !ok {
+			log.Warn("transition is not allowed", "status", reception.Status, "event", event)
+			return &TransitionError{Current: reception.Status, Event: event}
+		}
+		// Товары перемещения уже в пути в этот ПВЗ, поэтому их приёмку нельзя отменить.
+		if event == entity.TransitionCancel && reception.Kind == entity.ReceptionKindTransfer {
+			log.Warn("transfer reception cannot be cancelled")
+			return ErrTransferReception
+		}
+		if event == entity.TransitionStart {
+			if err := s.checkWorkingHours(ctx, log, reception.PVZID.String()); err != nil {
+				return err
+			}
+		}
+		if event == entity.TransitionCancel && reception.Status == entity.StatusInProgress {
+			if err := s.discardProducts(ctx, log, reception.ID, userID); err != nil {
+				return err
+			}
+		}
+
+		err = s.receptionRepo.SetStatus(ctx, receptionID, to)
+		if err != nil {
+			if errors.Is(err, repoerr.ErrDuplicateEntry) {
+				log.Error("dock already has an open reception")
+				return ErrOpenReceptionExists
+			}
+			log.Error("failed to update reception status", "error", err)
+			return ErrInternal
+		}
+
+		err = s.receptionRepo.AddStatusChange(ctx, entity.StatusChange{
+			ReceptionID: reception.ID,
+			FromStatus:  reception.Status,
+			ToStatus:    to,
+			Reason:      reason,
+			ChangedBy:   userID,
+		})
+		if err != nil {
+			log.Error("failed to record status change", "error", err)
+			return ErrInternal
+		}
+
+		log.Info("reception status changed", "from", reception.Status, "to", to)
+		reception.Status = to
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return reception, nil
+}
+
+// discardProducts помечает удалёнными товары отменяемой приёмки. Если после повторного открытия
+// часть товаров уже выдана или отправлена, приёмку отменить нельзя.
+func (s *ReceptionService) discardProducts(ctx context.Context, log *slog.Logger, receptionID uuid.UUID,
+	userID uuid.UUID) error {
+	products, err := s.productRepo.ListByReception(ctx, receptionID.String())
+	if err != nil {
+		log.Error("failed to list products", "error", err)
 		return ErrInternal
 	}
+	for _, product := range products {
+		if product.Status != entity.ProductStatusStored {
+			log.Warn("reception has products that are no longer stored", "productID", product.ID.String(),
+				"status", product.Status)
+			return ErrProductNotStored
+		}
+	}
 
-	log.Info("reception closed successfully")
+	deleted, err := s.productRepo.DeleteByReception(ctx, receptionID.String(), userID)
+	if err != nil {
+		log.Error("failed to delete products", "error", err)
+		return ErrInternal
+	}
+	log.Info("products of cancelled reception deleted", "count", deleted)
 	return nil
 }
+
+// ProcessStaleReceptions обрабатывает приёмки, открытые дольше maxAge, согласно policy:
+// закрывает их с записью причины в историю статусов или помечает как зависшие.
+// Возвращает число обработанных приёмок; ошибка по одной приёмке не останавливает остальные.
+func (s *ReceptionService) ProcessStaleReceptions(ctx context.Context, maxAge time.Duration,
+	policy string) (int, error) {
+	log := slog.With("layer", "ReceptionService", "operation", "ProcessStaleReceptions",
+		"maxAge", maxAge.String(), "policy", policy)
+	log.Debug("starting stale receptions processing")
+
+	if policy != entity.StalePolicyClose && policy != entity.StalePolicyFlag {
+		log.Error("unknown stale reception policy")
+		return 0, ErrInvalidStalePolicy
+	}
+
+	stale, err := s.receptionRepo.ListStale(ctx, time.Now().Add(-maxAge))
+	if err != nil {
+		log.Error("failed to list stale receptions", "error", err)
+		return 0, ErrInternal
+	}
+
+	reason := fmt.Sprintf("приёмка открыта дольше %s", maxAge)
+	processed := 0
+	for _, reception := range stale {
+		receptionLog := log.With("receptionID", reception.ID.String(), "pvzID", reception.PVZID.String(),
+			"inProgressSince", reception.InProgressSince)
+
+		action := policy
+		if action == entity.StalePolicyClose {
+			var closed bool
+			closed, err = s.autoClose(ctx, receptionLog, reception.ID, reason)
+			// Приёмку перемещения с непринятыми товарами закрыть нельзя, поэтому она только помечается.
+			if errors.Is(err, ErrTransferItemsPending) {
+				action = entity.StalePolicyFlag
+			}
+			if err == nil && !closed {
+				continue
+			}
+		}
+		if action == entity.StalePolicyFlag {
+			var flagged bool
+			flagged, err = s.receptionRepo.FlagStale(ctx, reception.ID.String(), reason)
+			if err == nil && !flagged {
+				continue
+			}
+		}
+		if err != nil {
+			receptionLog.Error("failed to process stale reception", "error", err)
+			continue
+		}
+
+		metrics.StaleReceptions.WithLabelValues(action).Inc()
+		receptionLog.Warn("stale reception processed")
+		processed++
+	}
+
+	log.Info("stale receptions processed", "found", len(stale), "processed", processed)
+	return processed, nil
+}
+
+// autoClose закрывает зависшую приёмку. Возвращает false без ошибки, если приёмку уже закрыли
+// или отменили после выборки зависших.
+func (s *ReceptionService) autoClose(ctx context.Context, log *slog.Logger, receptionID uuid.UUID,
+	reason string) (bool, error) {
+	var closed bool
+	err := withinTx(ctx, s.transactor, log, func(ctx context.Context) error {
+		reception, err := s.receptionRepo.LockByID(ctx, receptionID.String())
+		if err != nil {
+			log.Error("failed to lock reception", "error", err)
+			return ErrInternal
+		}
+		if _, ok := entity.NextReceptionStatus(reception.Status, entity.TransitionClose); !ok {
+			log.Debug("reception can no longer be closed", "status", reception.Status)
+			return nil
+		}
+		if err := s.checkTransferReceived(ctx, log, reception); err != nil {
+			return err
+		}
+
+		if err := s.receptionRepo.Close(ctx, receptionID.String(), uuid.Nil); err != nil {
+			log.Error("failed to close reception", "error", err)
+			return ErrInternal
+		}
+
+		if _, err := s.reconcileManifest(ctx, log, receptionID); err != nil {
+			return err
+		}
+
+		err = s.receptionRepo.AddStatusChange(ctx, entity.StatusChange{
+			ReceptionID: receptionID,
+			FromStatus:  entity.StatusInProgress,
+			ToStatus:    entity.StatusClose,
+			Reason:      "закрыта автоматически: " + reason,
+		})
+		if err != nil {
+			log.Error("failed to record status change", "error", err)
+			return ErrInternal
+		}
+
+		if err := s.saveAcceptanceAct(ctx, log, receptionID); err != nil {
+			return err
+		}
+		closed = true
+		return nil
+	})
+	return closed, err
+}
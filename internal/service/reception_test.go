@@ -4,9 +4,11 @@ import (
 	"context"
 	"errors"
 	"github.com/GlebMoskalev/go-pickup-point-api/internal/entity"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/metrics"
 	"github.com/GlebMoskalev/go-pickup-point-api/internal/repo/mocks"
 	"github.com/GlebMoskalev/go-pickup-point-api/internal/repo/repoerr"
 	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"testing"
@@ -17,6 +19,8 @@ func TestReceptionService_Create(t *testing.T) {
 	testCases := []struct {
 		name              string
 		pvzID             string
+		manifest          []entity.ManifestItem
+		delivery          entity.DeliveryInfo
 		prepareRepos      func(receptionRepo *mocks.Reception, pvzRepo *mocks.PVZ)
 		expectedReception *entity.Reception
 		expectedError     error
@@ -26,9 +30,11 @@ func TestReceptionService_Create(t *testing.T) {
 			pvzID: uuid.New().String(),
 			prepareRepos: func(receptionRepo *mocks.Reception, pvzRepo *mocks.PVZ) {
 				pvzRepo.On("Exists", mock.Anything, mock.AnythingOfType("string")).Return(true)
-				receptionRepo.On("HasOpenReception", mock.Anything, mock.AnythingOfType("string")).Return(false, nil)
+				pvzRepo.On("GetSchedule", mock.Anything, mock.AnythingOfType("string")).
+					Return(&entity.PVZSchedule{Timezone: entity.DefaultTimezone}, nil)
+				receptionRepo.On("HasOpenReception", mock.Anything, mock.AnythingOfType("string"), "").Return(false, nil)
 				receptionID := uuid.New()
-				receptionRepo.On("Create", mock.Anything, mock.AnythingOfType("string")).
+				receptionRepo.On("Create", mock.Anything, mock.AnythingOfType("entity.ReceptionParams")).
 					Return(&entity.Reception{
 						ID:       receptionID,
 						DateTime: time.Now(),
@@ -44,6 +50,25 @@ func TestReceptionService_Create(t *testing.T) {
 			},
 			expectedError: nil,
 		},
+		{
+			name:     "invalid manifest product type",
+			pvzID:    uuid.New().String(),
+			manifest: []entity.ManifestItem{{ProductType: "мебель", ExpectedCount: 1}},
+			prepareRepos: func(receptionRepo *mocks.Reception, pvzRepo *mocks.PVZ) {
+			},
+			expectedError: ErrInvalidManifest,
+		},
+		{
+			name:  "invalid manifest duplicate type",
+			pvzID: uuid.New().String(),
+			manifest: []entity.ManifestItem{
+				{ProductType: entity.ProductTypeShoes, ExpectedCount: 1},
+				{ProductType: entity.ProductTypeShoes, ExpectedCount: 2},
+			},
+			prepareRepos: func(receptionRepo *mocks.Reception, pvzRepo *mocks.PVZ) {
+			},
+			expectedError: ErrInvalidManifest,
+		},
 		{
 			name:  "invalid pvz id",
 			pvzID: uuid.New().String(),
@@ -53,12 +78,55 @@ func TestReceptionService_Create(t *testing.T) {
 			expectedReception: nil,
 			expectedError:     ErrInvalidPVZID,
 		},
+		{
+			name:  "outside working hours",
+			pvzID: uuid.New().String(),
+			prepareRepos: func(receptionRepo *mocks.Reception, pvzRepo *mocks.PVZ) {
+				pvzRepo.On("Exists", mock.Anything, mock.AnythingOfType("string")).Return(true)
+				pvzRepo.On("GetSchedule", mock.Anything, mock.AnythingOfType("string")).
+					Return(&entity.PVZSchedule{
+						Timezone:            "UTC",
+						EnforceWorkingHours: true,
+						Holidays:            []entity.Holiday{{Date: time.Now().UTC()}},
+					}, nil)
+			},
+			expectedReception: nil,
+			expectedError:     ErrOutsideWorkingHours,
+		},
+		{
+			name:  "closed day is ignored without enforcement",
+			pvzID: uuid.New().String(),
+			prepareRepos: func(receptionRepo *mocks.Reception, pvzRepo *mocks.PVZ) {
+				pvzRepo.On("Exists", mock.Anything, mock.AnythingOfType("string")).Return(true)
+				pvzRepo.On("GetSchedule", mock.Anything, mock.AnythingOfType("string")).
+					Return(&entity.PVZSchedule{
+						Timezone: "UTC",
+						Holidays: []entity.Holiday{{Date: time.Now().UTC()}},
+					}, nil)
+				receptionRepo.On("HasOpenReception", mock.Anything, mock.AnythingOfType("string"), "").Return(true, nil)
+			},
+			expectedReception: nil,
+			expectedError:     ErrOpenReceptionExists,
+		},
+		{
+			name:  "get schedule error",
+			pvzID: uuid.New().String(),
+			prepareRepos: func(receptionRepo *mocks.Reception, pvzRepo *mocks.PVZ) {
+				pvzRepo.On("Exists", mock.Anything, mock.AnythingOfType("string")).Return(true)
+				pvzRepo.On("GetSchedule", mock.Anything, mock.AnythingOfType("string")).
+					Return(nil, errors.New("database error"))
+			},
+			expectedReception: nil,
+			expectedError:     ErrInternal,
+		},
 		{
 			name:  "open reception exists",
 			pvzID: uuid.New().String(),
 			prepareRepos: func(receptionRepo *mocks.Reception, pvzRepo *mocks.PVZ) {
 				pvzRepo.On("Exists", mock.Anything, mock.AnythingOfType("string")).Return(true)
-				receptionRepo.On("HasOpenReception", mock.Anything, mock.AnythingOfType("string")).Return(true, nil)
+				pvzRepo.On("GetSchedule", mock.Anything, mock.AnythingOfType("string")).
+					Return(&entity.PVZSchedule{Timezone: entity.DefaultTimezone}, nil)
+				receptionRepo.On("HasOpenReception", mock.Anything, mock.AnythingOfType("string"), "").Return(true, nil)
 			},
 			expectedReception: nil,
 			expectedError:     ErrOpenReceptionExists,
@@ -68,19 +136,68 @@ func TestReceptionService_Create(t *testing.T) {
 			pvzID: uuid.New().String(),
 			prepareRepos: func(receptionRepo *mocks.Reception, pvzRepo *mocks.PVZ) {
 				pvzRepo.On("Exists", mock.Anything, mock.AnythingOfType("string")).Return(true)
-				receptionRepo.On("HasOpenReception", mock.Anything, mock.AnythingOfType("string")).
+				pvzRepo.On("GetSchedule", mock.Anything, mock.AnythingOfType("string")).
+					Return(&entity.PVZSchedule{Timezone: entity.DefaultTimezone}, nil)
+				receptionRepo.On("HasOpenReception", mock.Anything, mock.AnythingOfType("string"), "").
 					Return(false, errors.New("database error"))
 			},
 			expectedReception: nil,
 			expectedError:     ErrInternal,
 		},
+		{
+			name:     "delivery info is normalized",
+			pvzID:    uuid.New().String(),
+			delivery: entity.DeliveryInfo{Carrier: " СДЭК ", WaybillNumber: "wb-1", VehiclePlate: "а 123 вс 777"},
+			prepareRepos: func(receptionRepo *mocks.Reception, pvzRepo *mocks.PVZ) {
+				pvzRepo.On("Exists", mock.Anything, mock.AnythingOfType("string")).Return(true)
+				pvzRepo.On("GetSchedule", mock.Anything, mock.AnythingOfType("string")).
+					Return(&entity.PVZSchedule{Timezone: entity.DefaultTimezone}, nil)
+				receptionRepo.On("HasOpenReception", mock.Anything, mock.AnythingOfType("string"), "").Return(false, nil)
+				delivery := entity.DeliveryInfo{Carrier: "СДЭК", WaybillNumber: "WB-1", VehiclePlate: "А123ВС777"}
+				receptionRepo.On("Create", mock.Anything, mock.MatchedBy(func(params entity.ReceptionParams) bool {
+					return params.Delivery == delivery
+				})).
+					Return(&entity.Reception{
+						ID:       uuid.New(),
+						DateTime: time.Now(),
+						Status:   entity.StatusInProgress,
+						Delivery: delivery,
+					}, nil)
+			},
+			expectedReception: &entity.Reception{Status: entity.StatusInProgress},
+			expectedError:     nil,
+		},
+		{
+			name:              "invalid waybill number",
+			pvzID:             uuid.New().String(),
+			delivery:          entity.DeliveryInfo{WaybillNumber: "WB 1"},
+			prepareRepos:      func(receptionRepo *mocks.Reception, pvzRepo *mocks.PVZ) {},
+			expectedReception: nil,
+			expectedError:     ErrInvalidWaybill,
+		},
+		{
+			name:  "open reception created concurrently",
+			pvzID: uuid.New().String(),
+			prepareRepos: func(receptionRepo *mocks.Reception, pvzRepo *mocks.PVZ) {
+				pvzRepo.On("Exists", mock.Anything, mock.AnythingOfType("string")).Return(true)
+				pvzRepo.On("GetSchedule", mock.Anything, mock.AnythingOfType("string")).
+					Return(&entity.PVZSchedule{Timezone: entity.DefaultTimezone}, nil)
+				receptionRepo.On("HasOpenReception", mock.Anything, mock.AnythingOfType("string"), "").Return(false, nil)
+				receptionRepo.On("Create", mock.Anything, mock.AnythingOfType("entity.ReceptionParams")).
+					Return(nil, repoerr.ErrDuplicateEntry)
+			},
+			expectedReception: nil,
+			expectedError:     ErrOpenReceptionExists,
+		},
 		{
 			name:  "create reception error",
 			pvzID: uuid.New().String(),
 			prepareRepos: func(receptionRepo *mocks.Reception, pvzRepo *mocks.PVZ) {
 				pvzRepo.On("Exists", mock.Anything, mock.AnythingOfType("string")).Return(true)
-				receptionRepo.On("HasOpenReception", mock.Anything, mock.AnythingOfType("string")).Return(false, nil)
-				receptionRepo.On("Create", mock.Anything, mock.AnythingOfType("string")).
+				pvzRepo.On("GetSchedule", mock.Anything, mock.AnythingOfType("string")).
+					Return(&entity.PVZSchedule{Timezone: entity.DefaultTimezone}, nil)
+				receptionRepo.On("HasOpenReception", mock.Anything, mock.AnythingOfType("string"), "").Return(false, nil)
+				receptionRepo.On("Create", mock.Anything, mock.AnythingOfType("entity.ReceptionParams")).
 					Return(nil, errors.New("database error"))
 			},
 			expectedReception: nil,
@@ -94,10 +211,15 @@ func TestReceptionService_Create(t *testing.T) {
 			pvzRepo := mocks.NewPVZ(t)
 			tc.prepareRepos(receptionRepo, pvzRepo)
 
-			service := NewReceptionService(receptionRepo, pvzRepo)
+			service := NewReceptionService(newPassthroughTransactor(t), receptionRepo, mocks.NewProduct(t), pvzRepo,
+				mocks.NewDock(t), newCatalogTypeRepo(t))
 			ctx := context.Background()
 
-			reception, err := service.Create(ctx, tc.pvzID)
+			reception, err := service.Create(ctx, entity.ReceptionParams{
+				PVZID:    tc.pvzID,
+				Manifest: tc.manifest,
+				Delivery: tc.delivery,
+			})
 
 			if tc.expectedError != nil {
 				assert.ErrorIs(t, err, tc.expectedError)
@@ -116,34 +238,239 @@ func TestReceptionService_Create(t *testing.T) {
 	}
 }
 
-func TestReceptionService_CloseLastReception(t *testing.T) {
+func TestReceptionService_CreateInDock(t *testing.T) {
+	pvzID := uuid.New()
+	dockID := uuid.New()
+
 	testCases := []struct {
 		name          string
-		pvzID         string
-		prepareRepos  func(receptionRepo *mocks.Reception, pvzRepo *mocks.PVZ)
+		prepareRepos  func(receptionRepo *mocks.Reception, pvzRepo *mocks.PVZ, dockRepo *mocks.Dock)
 		expectedError error
+	}{
+		{
+			name: "successful creation in dock",
+			prepareRepos: func(receptionRepo *mocks.Reception, pvzRepo *mocks.PVZ, dockRepo *mocks.Dock) {
+				pvzRepo.On("Exists", mock.Anything, pvzID.String()).Return(true)
+				dockRepo.On("GetByID", mock.Anything, dockID.String()).
+					Return(&entity.Dock{ID: dockID, PVZID: pvzID, Name: "ворота 2"}, nil)
+				pvzRepo.On("GetSchedule", mock.Anything, pvzID.String()).
+					Return(&entity.PVZSchedule{Timezone: entity.DefaultTimezone}, nil)
+				receptionRepo.On("HasOpenReception", mock.Anything, pvzID.String(), dockID.String()).Return(false, nil)
+				receptionRepo.On("Create", mock.Anything, entity.ReceptionParams{PVZID: pvzID.String(), DockID: dockID.String(),
+					Kind: entity.ReceptionKindDelivery}).
+					Return(&entity.Reception{ID: uuid.New(), PVZID: pvzID, DockID: dockID, Status: entity.StatusInProgress}, nil)
+			},
+		},
+		{
+			name: "dock not found",
+			prepareRepos: func(receptionRepo *mocks.Reception, pvzRepo *mocks.PVZ, dockRepo *mocks.Dock) {
+				pvzRepo.On("Exists", mock.Anything, pvzID.String()).Return(true)
+				dockRepo.On("GetByID", mock.Anything, dockID.String()).Return(nil, repoerr.ErrNoRows)
+			},
+			expectedError: ErrInvalidDockID,
+		},
+		{
+			name: "dock of another pvz",
+			prepareRepos: func(receptionRepo *mocks.Reception, pvzRepo *mocks.PVZ, dockRepo *mocks.Dock) {
+				pvzRepo.On("Exists", mock.Anything, pvzID.String()).Return(true)
+				dockRepo.On("GetByID", mock.Anything, dockID.String()).
+					Return(&entity.Dock{ID: dockID, PVZID: uuid.New()}, nil)
+			},
+			expectedError: ErrInvalidDockID,
+		},
+		{
+			name: "dock already has open reception",
+			prepareRepos: func(receptionRepo *mocks.Reception, pvzRepo *mocks.PVZ, dockRepo *mocks.Dock) {
+				pvzRepo.On("Exists", mock.Anything, pvzID.String()).Return(true)
+				dockRepo.On("GetByID", mock.Anything, dockID.String()).
+					Return(&entity.Dock{ID: dockID, PVZID: pvzID}, nil)
+				pvzRepo.On("GetSchedule", mock.Anything, pvzID.String()).
+					Return(&entity.PVZSchedule{Timezone: entity.DefaultTimezone}, nil)
+				receptionRepo.On("HasOpenReception", mock.Anything, pvzID.String(), dockID.String()).Return(true, nil)
+			},
+			expectedError: ErrOpenReceptionExists,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			receptionRepo := mocks.NewReception(t)
+			pvzRepo := mocks.NewPVZ(t)
+			dockRepo := mocks.NewDock(t)
+			tc.prepareRepos(receptionRepo, pvzRepo, dockRepo)
+
+			service := NewReceptionService(newPassthroughTransactor(t), receptionRepo, mocks.NewProduct(t), pvzRepo,
+				dockRepo, newCatalogTypeRepo(t))
+
+			reception, err := service.Create(context.Background(), entity.ReceptionParams{
+				PVZID:  pvzID.String(),
+				DockID: dockID.String(),
+			})
+
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+				assert.Nil(t, reception)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, dockID, reception.DockID)
+		})
+	}
+}
+
+func TestReceptionService_CreateDraft(t *testing.T) {
+	pvzID := uuid.New()
+	receptionRepo := mocks.NewReception(t)
+	pvzRepo := mocks.NewPVZ(t)
+	pvzRepo.On("Exists", mock.Anything, pvzID.String()).Return(true)
+	pvzRepo.On("GetSchedule", mock.Anything, pvzID.String()).
+		Return(&entity.PVZSchedule{Timezone: entity.DefaultTimezone}, nil)
+	params := entity.ReceptionParams{PVZID: pvzID.String(), Draft: true, Kind: entity.ReceptionKindDelivery}
+	receptionRepo.On("Create", mock.Anything, params).
+		Return(&entity.Reception{ID: uuid.New(), PVZID: pvzID, Status: entity.StatusDraft}, nil)
+
+	service := NewReceptionService(newPassthroughTransactor(t), receptionRepo, mocks.NewProduct(t), pvzRepo,
+		mocks.NewDock(t), newCatalogTypeRepo(t))
+
+	reception, err := service.Create(context.Background(), params)
+
+	assert.NoError(t, err)
+	assert.Equal(t, entity.StatusDraft, reception.Status)
+	receptionRepo.AssertNotCalled(t, "HasOpenReception", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestReceptionService_CloseLastReception(t *testing.T) {
+	manifestReceptionID := uuid.New()
+	userID := uuid.New()
+	testCases := []struct {
+		name           string
+		pvzID          string
+		prepareRepos   func(receptionRepo *mocks.Reception, productRepo *mocks.Product, pvzRepo *mocks.PVZ)
+		expectedReport *entity.DiscrepancyReport
+		expectedError  error
 	}{
 		{
 			name:  "successful closure",
 			pvzID: uuid.New().String(),
-			prepareRepos: func(receptionRepo *mocks.Reception, pvzRepo *mocks.PVZ) {
+			prepareRepos: func(receptionRepo *mocks.Reception, productRepo *mocks.Product, pvzRepo *mocks.PVZ) {
 				pvzRepo.On("Exists", mock.Anything, mock.AnythingOfType("string")).Return(true)
 				receptionID := uuid.New()
-				receptionRepo.On("GetLastOpenReception", mock.Anything, mock.AnythingOfType("string")).
+				receptionRepo.On("LockLastOpenReception", mock.Anything, mock.AnythingOfType("string")).
 					Return(&entity.Reception{
 						ID:       receptionID,
 						DateTime: time.Now(),
 						PVZID:    uuid.UUID{},
 						Status:   "in_progress",
 					}, nil)
-				receptionRepo.On("Close", mock.Anything, receptionID.String()).Return(nil)
+				receptionRepo.On("Close", mock.Anything, receptionID.String(), userID).Return(nil)
+				receptionRepo.On("GetManifest", mock.Anything, receptionID.String()).Return(nil, nil)
+				receptionRepo.On("AddStatusChange", mock.Anything, entity.StatusChange{
+					ReceptionID: receptionID,
+					FromStatus:  entity.StatusInProgress,
+					ToStatus:    entity.StatusClose,
+					ChangedBy:   userID,
+				}).Return(nil)
+				expectActSaved(receptionRepo, productRepo, pvzRepo, receptionID)
 			},
 			expectedError: nil,
 		},
+		{
+			name:  "transfer reception with items not yet received",
+			pvzID: uuid.New().String(),
+			prepareRepos: func(receptionRepo *mocks.Reception, productRepo *mocks.Product, pvzRepo *mocks.PVZ) {
+				pvzRepo.On("Exists", mock.Anything, mock.AnythingOfType("string")).Return(true)
+				receptionID := uuid.New()
+				receptionRepo.On("LockLastOpenReception", mock.Anything, mock.AnythingOfType("string")).
+					Return(&entity.Reception{ID: receptionID, Status: entity.StatusInProgress,
+						Kind: entity.ReceptionKindTransfer}, nil)
+				receptionRepo.On("CountPendingTransferItems", mock.Anything, receptionID.String()).Return(2, nil)
+			},
+			expectedError: ErrTransferItemsPending,
+		},
+		{
+			name:  "transfer reception with all items received",
+			pvzID: uuid.New().String(),
+			prepareRepos: func(receptionRepo *mocks.Reception, productRepo *mocks.Product, pvzRepo *mocks.PVZ) {
+				pvzRepo.On("Exists", mock.Anything, mock.AnythingOfType("string")).Return(true)
+				receptionID := uuid.New()
+				receptionRepo.On("LockLastOpenReception", mock.Anything, mock.AnythingOfType("string")).
+					Return(&entity.Reception{ID: receptionID, Status: entity.StatusInProgress,
+						Kind: entity.ReceptionKindTransfer}, nil)
+				receptionRepo.On("CountPendingTransferItems", mock.Anything, receptionID.String()).Return(0, nil)
+				receptionRepo.On("Close", mock.Anything, receptionID.String(), userID).Return(nil)
+				receptionRepo.On("GetManifest", mock.Anything, receptionID.String()).Return(nil, nil)
+				receptionRepo.On("AddStatusChange", mock.Anything, mock.AnythingOfType("entity.StatusChange")).
+					Return(nil)
+				expectActSaved(receptionRepo, productRepo, pvzRepo, receptionID)
+			},
+		},
+		{
+			name:  "close history error",
+			pvzID: uuid.New().String(),
+			prepareRepos: func(receptionRepo *mocks.Reception, productRepo *mocks.Product, pvzRepo *mocks.PVZ) {
+				pvzRepo.On("Exists", mock.Anything, mock.AnythingOfType("string")).Return(true)
+				receptionID := uuid.New()
+				receptionRepo.On("LockLastOpenReception", mock.Anything, mock.AnythingOfType("string")).
+					Return(&entity.Reception{ID: receptionID, Status: entity.StatusInProgress}, nil)
+				receptionRepo.On("Close", mock.Anything, receptionID.String(), userID).Return(nil)
+				receptionRepo.On("GetManifest", mock.Anything, receptionID.String()).Return(nil, nil)
+				receptionRepo.On("AddStatusChange", mock.Anything, mock.AnythingOfType("entity.StatusChange")).
+					Return(errors.New("database error"))
+			},
+			expectedError: ErrInternal,
+		},
+		{
+			name:  "closure with manifest discrepancies",
+			pvzID: uuid.New().String(),
+			prepareRepos: func(receptionRepo *mocks.Reception, productRepo *mocks.Product, pvzRepo *mocks.PVZ) {
+				pvzRepo.On("Exists", mock.Anything, mock.AnythingOfType("string")).Return(true)
+				receptionRepo.On("LockLastOpenReception", mock.Anything, mock.AnythingOfType("string")).
+					Return(&entity.Reception{ID: manifestReceptionID, Status: "in_progress"}, nil)
+				receptionRepo.On("Close", mock.Anything, manifestReceptionID.String(), userID).Return(nil)
+				receptionRepo.On("GetManifest", mock.Anything, manifestReceptionID.String()).
+					Return([]entity.ManifestItem{
+						{ProductType: entity.ProductTypeClothes, ExpectedCount: 3},
+						{ProductType: entity.ProductTypeElectronics, ExpectedCount: 1},
+					}, nil)
+				productRepo.On("CountByType", mock.Anything, manifestReceptionID.String()).
+					Return(map[string]int{entity.ProductTypeClothes: 2, entity.ProductTypeShoes: 1,
+						entity.ProductTypeElectronics: 1}, nil)
+				receptionRepo.On("SaveDiscrepancyReport", mock.Anything, mock.AnythingOfType("entity.DiscrepancyReport")).
+					Return(nil)
+				receptionRepo.On("AddStatusChange", mock.Anything, mock.AnythingOfType("entity.StatusChange")).
+					Return(nil)
+				expectActSaved(receptionRepo, productRepo, pvzRepo, manifestReceptionID)
+			},
+			expectedReport: &entity.DiscrepancyReport{
+				ReceptionID: manifestReceptionID,
+				Items: []entity.DiscrepancyItem{
+					{ProductType: entity.ProductTypeClothes, ExpectedCount: 3, ReceivedCount: 2},
+					{ProductType: entity.ProductTypeShoes, ExpectedCount: 0, ReceivedCount: 1},
+					{ProductType: entity.ProductTypeElectronics, ExpectedCount: 1, ReceivedCount: 1},
+				},
+			},
+		},
+		{
+			name:  "save discrepancy report error",
+			pvzID: uuid.New().String(),
+			prepareRepos: func(receptionRepo *mocks.Reception, productRepo *mocks.Product, pvzRepo *mocks.PVZ) {
+				pvzRepo.On("Exists", mock.Anything, mock.AnythingOfType("string")).Return(true)
+				receptionRepo.On("LockLastOpenReception", mock.Anything, mock.AnythingOfType("string")).
+					Return(&entity.Reception{ID: manifestReceptionID, Status: "in_progress"}, nil)
+				receptionRepo.On("Close", mock.Anything, manifestReceptionID.String(), userID).Return(nil)
+				receptionRepo.On("GetManifest", mock.Anything, manifestReceptionID.String()).
+					Return([]entity.ManifestItem{{ProductType: entity.ProductTypeShoes, ExpectedCount: 1}}, nil)
+				productRepo.On("CountByType", mock.Anything, manifestReceptionID.String()).
+					Return(map[string]int{}, nil)
+				receptionRepo.On("SaveDiscrepancyReport", mock.Anything, mock.AnythingOfType("entity.DiscrepancyReport")).
+					Return(errors.New("database error"))
+			},
+			expectedError: ErrInternal,
+		},
 		{
 			name:  "invalid pvz id",
 			pvzID: uuid.New().String(),
-			prepareRepos: func(receptionRepo *mocks.Reception, pvzRepo *mocks.PVZ) {
+			prepareRepos: func(receptionRepo *mocks.Reception, productRepo *mocks.Product, pvzRepo *mocks.PVZ) {
 				pvzRepo.On("Exists", mock.Anything, mock.AnythingOfType("string")).Return(false)
 			},
 			expectedError: ErrInvalidPVZID,
@@ -151,9 +478,9 @@ func TestReceptionService_CloseLastReception(t *testing.T) {
 		{
 			name:  "no open reception",
 			pvzID: uuid.New().String(),
-			prepareRepos: func(receptionRepo *mocks.Reception, pvzRepo *mocks.PVZ) {
+			prepareRepos: func(receptionRepo *mocks.Reception, productRepo *mocks.Product, pvzRepo *mocks.PVZ) {
 				pvzRepo.On("Exists", mock.Anything, mock.AnythingOfType("string")).Return(true)
-				receptionRepo.On("GetLastOpenReception", mock.Anything, mock.AnythingOfType("string")).
+				receptionRepo.On("LockLastOpenReception", mock.Anything, mock.AnythingOfType("string")).
 					Return(nil, repoerr.ErrNoRows)
 			},
 			expectedError: ErrNoOpenReception,
@@ -161,9 +488,9 @@ func TestReceptionService_CloseLastReception(t *testing.T) {
 		{
 			name:  "get open reception error",
 			pvzID: uuid.New().String(),
-			prepareRepos: func(receptionRepo *mocks.Reception, pvzRepo *mocks.PVZ) {
+			prepareRepos: func(receptionRepo *mocks.Reception, productRepo *mocks.Product, pvzRepo *mocks.PVZ) {
 				pvzRepo.On("Exists", mock.Anything, mock.AnythingOfType("string")).Return(true)
-				receptionRepo.On("GetLastOpenReception", mock.Anything, mock.AnythingOfType("string")).
+				receptionRepo.On("LockLastOpenReception", mock.Anything, mock.AnythingOfType("string")).
 					Return(nil, errors.New("database error"))
 			},
 			expectedError: ErrInternal,
@@ -171,17 +498,17 @@ func TestReceptionService_CloseLastReception(t *testing.T) {
 		{
 			name:  "close reception error",
 			pvzID: uuid.New().String(),
-			prepareRepos: func(receptionRepo *mocks.Reception, pvzRepo *mocks.PVZ) {
+			prepareRepos: func(receptionRepo *mocks.Reception, productRepo *mocks.Product, pvzRepo *mocks.PVZ) {
 				pvzRepo.On("Exists", mock.Anything, mock.AnythingOfType("string")).Return(true)
 				receptionID := uuid.New()
-				receptionRepo.On("GetLastOpenReception", mock.Anything, mock.AnythingOfType("string")).
+				receptionRepo.On("LockLastOpenReception", mock.Anything, mock.AnythingOfType("string")).
 					Return(&entity.Reception{
 						ID:       receptionID,
 						DateTime: time.Now(),
 						PVZID:    uuid.UUID{},
 						Status:   "in_progress",
 					}, nil)
-				receptionRepo.On("Close", mock.Anything, receptionID.String()).
+				receptionRepo.On("Close", mock.Anything, receptionID.String(), userID).
 					Return(errors.New("database error"))
 			},
 			expectedError: ErrInternal,
@@ -189,17 +516,17 @@ func TestReceptionService_CloseLastReception(t *testing.T) {
 		{
 			name:  "close reception not found",
 			pvzID: uuid.New().String(),
-			prepareRepos: func(receptionRepo *mocks.Reception, pvzRepo *mocks.PVZ) {
+			prepareRepos: func(receptionRepo *mocks.Reception, productRepo *mocks.Product, pvzRepo *mocks.PVZ) {
 				pvzRepo.On("Exists", mock.Anything, mock.AnythingOfType("string")).Return(true)
 				receptionID := uuid.New()
-				receptionRepo.On("GetLastOpenReception", mock.Anything, mock.AnythingOfType("string")).
+				receptionRepo.On("LockLastOpenReception", mock.Anything, mock.AnythingOfType("string")).
 					Return(&entity.Reception{
 						ID:       receptionID,
 						DateTime: time.Now(),
 						PVZID:    uuid.UUID{},
 						Status:   "in_progress",
 					}, nil)
-				receptionRepo.On("Close", mock.Anything, receptionID.String()).
+				receptionRepo.On("Close", mock.Anything, receptionID.String(), userID).
 					Return(repoerr.ErrNoRows)
 			},
 			expectedError: ErrNoOpenReception,
@@ -209,18 +536,546 @@ func TestReceptionService_CloseLastReception(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			receptionRepo := mocks.NewReception(t)
+			productRepo := mocks.NewProduct(t)
 			pvzRepo := mocks.NewPVZ(t)
-			tc.prepareRepos(receptionRepo, pvzRepo)
+			tc.prepareRepos(receptionRepo, productRepo, pvzRepo)
+
+			service := NewReceptionService(newPassthroughTransactor(t), receptionRepo, productRepo, pvzRepo,
+				mocks.NewDock(t), newCatalogTypeRepo(t))
+			ctx := context.Background()
+
+			result, err := service.CloseLastReception(ctx, entity.ReceptionTarget{PVZID: tc.pvzID}, userID, nil)
+
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Nil(t, result.BlindCount)
+				report := result.DiscrepancyReport
+				if tc.expectedReport != nil {
+					assert.NotNil(t, report)
+					assert.Equal(t, tc.expectedReport.ReceptionID, report.ReceptionID)
+					assert.ElementsMatch(t, tc.expectedReport.Items, report.Items)
+				} else {
+					assert.Nil(t, report)
+				}
+			}
+		})
+	}
+}
+
+func TestReceptionService_CloseWithBlindCount(t *testing.T) {
+	pvzID := uuid.New()
+	receptionID := uuid.New()
+	userID := uuid.New()
+	recorded := map[string]int{entity.ProductTypeShoes: 2, entity.ProductTypeClothes: 1}
+
+	testCases := []struct {
+		name             string
+		counted          []entity.TypeCount
+		prepareRepos     func(receptionRepo *mocks.Reception, productRepo *mocks.Product, pvzRepo *mocks.PVZ)
+		expectedMismatch bool
+		expectedError    error
+	}{
+		{
+			name: "count matches",
+			counted: []entity.TypeCount{
+				{ProductType: entity.ProductTypeShoes, Count: 2},
+				{ProductType: entity.ProductTypeClothes, Count: 1},
+			},
+			prepareRepos: func(receptionRepo *mocks.Reception, productRepo *mocks.Product, pvzRepo *mocks.PVZ) {
+				productRepo.On("CountByType", mock.Anything, receptionID.String()).Return(recorded, nil)
+				receptionRepo.On("Close", mock.Anything, receptionID.String(), userID).Return(nil)
+				receptionRepo.On("GetManifest", mock.Anything, receptionID.String()).Return(nil, nil)
+				receptionRepo.On("SaveBlindCount", mock.Anything, mock.MatchedBy(func(r entity.BlindCountReport) bool {
+					return r.ReceptionID == receptionID && !r.HasMismatch()
+				})).Return(nil)
+				receptionRepo.On("AddStatusChange", mock.Anything, mock.AnythingOfType("entity.StatusChange")).
+					Return(nil)
+				expectActSaved(receptionRepo, productRepo, pvzRepo, receptionID)
+			},
+		},
+		{
+			name:    "mismatch rejected by pvz settings",
+			counted: []entity.TypeCount{{ProductType: entity.ProductTypeShoes, Count: 2}},
+			prepareRepos: func(receptionRepo *mocks.Reception, productRepo *mocks.Product, pvzRepo *mocks.PVZ) {
+				productRepo.On("CountByType", mock.Anything, receptionID.String()).Return(recorded, nil)
+				pvzRepo.On("GetSettings", mock.Anything, pvzID.String()).
+					Return(&entity.PVZSettings{PVZID: pvzID, BlindCountPolicy: entity.BlindCountPolicyReject}, nil)
+			},
+			expectedError: ErrBlindCountMismatch,
+		},
+		{
+			name:    "mismatch flagged by pvz settings",
+			counted: []entity.TypeCount{{ProductType: entity.ProductTypeShoes, Count: 3}},
+			prepareRepos: func(receptionRepo *mocks.Reception, productRepo *mocks.Product, pvzRepo *mocks.PVZ) {
+				productRepo.On("CountByType", mock.Anything, receptionID.String()).Return(recorded, nil)
+				pvzRepo.On("GetSettings", mock.Anything, pvzID.String()).
+					Return(&entity.PVZSettings{PVZID: pvzID, BlindCountPolicy: entity.BlindCountPolicyFlag}, nil)
+				receptionRepo.On("Close", mock.Anything, receptionID.String(), userID).Return(nil)
+				receptionRepo.On("GetManifest", mock.Anything, receptionID.String()).Return(nil, nil)
+				receptionRepo.On("SaveBlindCount", mock.Anything, mock.AnythingOfType("entity.BlindCountReport")).
+					Return(nil)
+				receptionRepo.On("AddStatusChange", mock.Anything, mock.AnythingOfType("entity.StatusChange")).
+					Return(nil)
+				expectActSaved(receptionRepo, productRepo, pvzRepo, receptionID)
+			},
+			expectedMismatch: true,
+		},
+		{
+			name:          "negative count",
+			counted:       []entity.TypeCount{{ProductType: entity.ProductTypeShoes, Count: -1}},
+			prepareRepos:  func(receptionRepo *mocks.Reception, productRepo *mocks.Product, pvzRepo *mocks.PVZ) {},
+			expectedError: ErrInvalidBlindCount,
+		},
+		{
+			name: "duplicate type",
+			counted: []entity.TypeCount{
+				{ProductType: entity.ProductTypeShoes, Count: 1},
+				{ProductType: entity.ProductTypeShoes, Count: 1},
+			},
+			prepareRepos:  func(receptionRepo *mocks.Reception, productRepo *mocks.Product, pvzRepo *mocks.PVZ) {},
+			expectedError: ErrInvalidBlindCount,
+		},
+		{
+			name:    "settings error",
+			counted: []entity.TypeCount{},
+			prepareRepos: func(receptionRepo *mocks.Reception, productRepo *mocks.Product, pvzRepo *mocks.PVZ) {
+				productRepo.On("CountByType", mock.Anything, receptionID.String()).Return(recorded, nil)
+				pvzRepo.On("GetSettings", mock.Anything, pvzID.String()).Return(nil, errors.New("database error"))
+			},
+			expectedError: ErrInternal,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			receptionRepo := mocks.NewReception(t)
+			productRepo := mocks.NewProduct(t)
+			pvzRepo := mocks.NewPVZ(t)
+			if tc.expectedError != ErrInvalidBlindCount {
+				pvzRepo.On("Exists", mock.Anything, pvzID.String()).Return(true)
+				receptionRepo.On("LockLastOpenReception", mock.Anything, pvzID.String()).
+					Return(&entity.Reception{ID: receptionID, PVZID: pvzID, Status: entity.StatusInProgress}, nil)
+			}
+			tc.prepareRepos(receptionRepo, productRepo, pvzRepo)
+
+			service := NewReceptionService(newPassthroughTransactor(t), receptionRepo, productRepo, pvzRepo,
+				mocks.NewDock(t), newCatalogTypeRepo(t))
+
+			result, err := service.CloseLastReception(context.Background(),
+				entity.ReceptionTarget{PVZID: pvzID.String()}, userID, tc.counted)
+
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+				assert.Nil(t, result)
+				receptionRepo.AssertNotCalled(t, "Close", mock.Anything, mock.Anything, mock.Anything)
+				return
+			}
+			assert.NoError(t, err)
+			assert.NotNil(t, result.BlindCount)
+			assert.Equal(t, tc.expectedMismatch, result.BlindCount.HasMismatch())
+		})
+	}
+}
+
+func TestReceptionService_ChangeStatus(t *testing.T) {
+	receptionID := uuid.New()
+	pvzID := uuid.New()
+	userID := uuid.New()
+
+	testCases := []struct {
+		name           string
+		event          string
+		reason         string
+		prepareRepos   func(receptionRepo *mocks.Reception, productRepo *mocks.Product, pvzRepo *mocks.PVZ)
+		expectedStatus string
+		expectedError  error
+	}{
+		{
+			name:   "successful reopen",
+			event:  entity.TransitionReopen,
+			reason: "закрыта по ошибке",
+			prepareRepos: func(receptionRepo *mocks.Reception, productRepo *mocks.Product, pvzRepo *mocks.PVZ) {
+				receptionRepo.On("LockByID", mock.Anything, receptionID.String()).
+					Return(&entity.Reception{ID: receptionID, Status: entity.StatusClose}, nil)
+				receptionRepo.On("SetStatus", mock.Anything, receptionID.String(), entity.StatusInProgress).
+					Return(nil)
+				receptionRepo.On("AddStatusChange", mock.Anything, entity.StatusChange{
+					ReceptionID: receptionID,
+					FromStatus:  entity.StatusClose,
+					ToStatus:    entity.StatusInProgress,
+					Reason:      "закрыта по ошибке",
+					ChangedBy:   userID,
+				}).Return(nil)
+			},
+			expectedStatus: entity.StatusInProgress,
+		},
+		{
+			name:   "successful cancel",
+			reason: " открыта не в том ПВЗ ",
+			prepareRepos: func(receptionRepo *mocks.Reception, productRepo *mocks.Product, pvzRepo *mocks.PVZ) {
+				receptionRepo.On("LockByID", mock.Anything, receptionID.String()).
+					Return(&entity.Reception{ID: receptionID, Status: entity.StatusInProgress}, nil)
+				productRepo.On("ListByReception", mock.Anything, receptionID.String()).
+					Return([]entity.Product{{ID: uuid.New(), Status: entity.ProductStatusStored}}, nil)
+				productRepo.On("DeleteByReception", mock.Anything, receptionID.String(), userID).Return(1, nil)
+				receptionRepo.On("SetStatus", mock.Anything, receptionID.String(), entity.StatusCancelled).
+					Return(nil)
+				receptionRepo.On("AddStatusChange", mock.Anything, mock.MatchedBy(func(c entity.StatusChange) bool {
+					return c.Reason == "открыта не в том ПВЗ" && c.ToStatus == entity.StatusCancelled
+				})).Return(nil)
+			},
+			expectedStatus: entity.StatusCancelled,
+		},
+		{
+			name:          "empty reason",
+			event:         entity.TransitionReopen,
+			reason:        "   ",
+			prepareRepos:  func(receptionRepo *mocks.Reception, productRepo *mocks.Product, pvzRepo *mocks.PVZ) {},
+			expectedError: ErrReasonRequired,
+		},
+		{
+			name:   "reception not found",
+			reason: "ошибка",
+			prepareRepos: func(receptionRepo *mocks.Reception, productRepo *mocks.Product, pvzRepo *mocks.PVZ) {
+				receptionRepo.On("LockByID", mock.Anything, receptionID.String()).
+					Return(nil, repoerr.ErrNoRows)
+			},
+			expectedError: ErrReceptionNotFound,
+		},
+		{
+			name:   "reopen of open reception",
+			event:  entity.TransitionReopen,
+			reason: "ошибка",
+			prepareRepos: func(receptionRepo *mocks.Reception, productRepo *mocks.Product, pvzRepo *mocks.PVZ) {
+				receptionRepo.On("LockByID", mock.Anything, receptionID.String()).
+					Return(&entity.Reception{ID: receptionID, Status: entity.StatusInProgress}, nil)
+			},
+			expectedError: ErrInvalidTransition,
+		},
+		{
+			name:   "cancel after products were issued",
+			reason: "ошибка",
+			prepareRepos: func(receptionRepo *mocks.Reception, productRepo *mocks.Product, pvzRepo *mocks.PVZ) {
+				receptionRepo.On("LockByID", mock.Anything, receptionID.String()).
+					Return(&entity.Reception{ID: receptionID, Status: entity.StatusInProgress}, nil)
+				productRepo.On("ListByReception", mock.Anything, receptionID.String()).
+					Return([]entity.Product{
+						{ID: uuid.New(), Status: entity.ProductStatusStored},
+						{ID: uuid.New(), Status: entity.ProductStatusIssued},
+					}, nil)
+			},
+			expectedError: ErrProductNotStored,
+		},
+		{
+			name:   "cancel of closed reception",
+			reason: "ошибка",
+			prepareRepos: func(receptionRepo *mocks.Reception, productRepo *mocks.Product, pvzRepo *mocks.PVZ) {
+				receptionRepo.On("LockByID", mock.Anything, receptionID.String()).
+					Return(&entity.Reception{ID: receptionID, Status: entity.StatusClose}, nil)
+			},
+			expectedError: ErrInvalidTransition,
+		},
+		{
+			name:   "reopen of verified reception",
+			event:  entity.TransitionReopen,
+			reason: "ошибка",
+			prepareRepos: func(receptionRepo *mocks.Reception, productRepo *mocks.Product, pvzRepo *mocks.PVZ) {
+				receptionRepo.On("LockByID", mock.Anything, receptionID.String()).
+					Return(&entity.Reception{ID: receptionID, Status: entity.StatusVerified}, nil)
+			},
+			expectedError: ErrInvalidTransition,
+		},
+		{
+			name:  "successful start of draft",
+			event: entity.TransitionStart,
+			prepareRepos: func(receptionRepo *mocks.Reception, productRepo *mocks.Product, pvzRepo *mocks.PVZ) {
+				receptionRepo.On("LockByID", mock.Anything, receptionID.String()).
+					Return(&entity.Reception{ID: receptionID, PVZID: pvzID, Status: entity.StatusDraft}, nil)
+				pvzRepo.On("GetSchedule", mock.Anything, pvzID.String()).
+					Return(&entity.PVZSchedule{Timezone: entity.DefaultTimezone}, nil)
+				receptionRepo.On("SetStatus", mock.Anything, receptionID.String(), entity.StatusInProgress).
+					Return(nil)
+				receptionRepo.On("AddStatusChange", mock.Anything, entity.StatusChange{
+					ReceptionID: receptionID,
+					FromStatus:  entity.StatusDraft,
+					ToStatus:    entity.StatusInProgress,
+					ChangedBy:   userID,
+				}).Return(nil)
+			},
+			expectedStatus: entity.StatusInProgress,
+		},
+		{
+			name:  "start of started reception",
+			event: entity.TransitionStart,
+			prepareRepos: func(receptionRepo *mocks.Reception, productRepo *mocks.Product, pvzRepo *mocks.PVZ) {
+				receptionRepo.On("LockByID", mock.Anything, receptionID.String()).
+					Return(&entity.Reception{ID: receptionID, Status: entity.StatusInProgress}, nil)
+			},
+			expectedError: ErrInvalidTransition,
+		},
+		{
+			name:  "start outside working hours",
+			event: entity.TransitionStart,
+			prepareRepos: func(receptionRepo *mocks.Reception, productRepo *mocks.Product, pvzRepo *mocks.PVZ) {
+				receptionRepo.On("LockByID", mock.Anything, receptionID.String()).
+					Return(&entity.Reception{ID: receptionID, PVZID: pvzID, Status: entity.StatusDraft}, nil)
+				pvzRepo.On("GetSchedule", mock.Anything, pvzID.String()).
+					Return(&entity.PVZSchedule{
+						Timezone:            "UTC",
+						EnforceWorkingHours: true,
+						Holidays:            []entity.Holiday{{Date: time.Now().UTC()}},
+					}, nil)
+			},
+			expectedError: ErrOutsideWorkingHours,
+		},
+		{
+			name:  "start when dock has open reception",
+			event: entity.TransitionStart,
+			prepareRepos: func(receptionRepo *mocks.Reception, productRepo *mocks.Product, pvzRepo *mocks.PVZ) {
+				receptionRepo.On("LockByID", mock.Anything, receptionID.String()).
+					Return(&entity.Reception{ID: receptionID, PVZID: pvzID, Status: entity.StatusDraft}, nil)
+				pvzRepo.On("GetSchedule", mock.Anything, pvzID.String()).
+					Return(&entity.PVZSchedule{Timezone: entity.DefaultTimezone}, nil)
+				receptionRepo.On("SetStatus", mock.Anything, receptionID.String(), entity.StatusInProgress).
+					Return(repoerr.ErrDuplicateEntry)
+			},
+			expectedError: ErrOpenReceptionExists,
+		},
+		{
+			name:   "successful verify",
+			event:  entity.TransitionVerify,
+			reason: " пересчитано ",
+			prepareRepos: func(receptionRepo *mocks.Reception, productRepo *mocks.Product, pvzRepo *mocks.PVZ) {
+				receptionRepo.On("LockByID", mock.Anything, receptionID.String()).
+					Return(&entity.Reception{ID: receptionID, Status: entity.StatusClose}, nil)
+				receptionRepo.On("SetStatus", mock.Anything, receptionID.String(), entity.StatusVerified).
+					Return(nil)
+				receptionRepo.On("AddStatusChange", mock.Anything, mock.MatchedBy(func(c entity.StatusChange) bool {
+					return c.Reason == "пересчитано" && c.ToStatus == entity.StatusVerified
+				})).Return(nil)
+			},
+			expectedStatus: entity.StatusVerified,
+		},
+		{
+			name:  "verify of open reception",
+			event: entity.TransitionVerify,
+			prepareRepos: func(receptionRepo *mocks.Reception, productRepo *mocks.Product, pvzRepo *mocks.PVZ) {
+				receptionRepo.On("LockByID", mock.Anything, receptionID.String()).
+					Return(&entity.Reception{ID: receptionID, Status: entity.StatusInProgress}, nil)
+			},
+			expectedError: ErrInvalidTransition,
+		},
+		{
+			name:   "cancel of draft",
+			reason: "поставка не пришла",
+			prepareRepos: func(receptionRepo *mocks.Reception, productRepo *mocks.Product, pvzRepo *mocks.PVZ) {
+				receptionRepo.On("LockByID", mock.Anything, receptionID.String()).
+					Return(&entity.Reception{ID: receptionID, Status: entity.StatusDraft}, nil)
+				receptionRepo.On("SetStatus", mock.Anything, receptionID.String(), entity.StatusCancelled).
+					Return(nil)
+				receptionRepo.On("AddStatusChange", mock.Anything, mock.AnythingOfType("entity.StatusChange")).
+					Return(nil)
+			},
+			expectedStatus: entity.StatusCancelled,
+		},
+		{
+			name:   "reopen when pvz has open reception",
+			event:  entity.TransitionReopen,
+			reason: "ошибка",
+			prepareRepos: func(receptionRepo *mocks.Reception, productRepo *mocks.Product, pvzRepo *mocks.PVZ) {
+				receptionRepo.On("LockByID", mock.Anything, receptionID.String()).
+					Return(&entity.Reception{ID: receptionID, Status: entity.StatusClose}, nil)
+				receptionRepo.On("SetStatus", mock.Anything, receptionID.String(), entity.StatusInProgress).
+					Return(repoerr.ErrDuplicateEntry)
+			},
+			expectedError: ErrOpenReceptionExists,
+		},
+		{
+			name:   "history error",
+			reason: "ошибка",
+			prepareRepos: func(receptionRepo *mocks.Reception, productRepo *mocks.Product, pvzRepo *mocks.PVZ) {
+				receptionRepo.On("LockByID", mock.Anything, receptionID.String()).
+					Return(&entity.Reception{ID: receptionID, Status: entity.StatusInProgress}, nil)
+				productRepo.On("ListByReception", mock.Anything, receptionID.String()).
+					Return([]entity.Product{{ID: uuid.New(), Status: entity.ProductStatusStored}}, nil)
+				productRepo.On("DeleteByReception", mock.Anything, receptionID.String(), userID).Return(1, nil)
+				receptionRepo.On("SetStatus", mock.Anything, receptionID.String(), entity.StatusCancelled).
+					Return(nil)
+				receptionRepo.On("AddStatusChange", mock.Anything, mock.AnythingOfType("entity.StatusChange")).
+					Return(errors.New("database error"))
+			},
+			expectedError: ErrInternal,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			receptionRepo := mocks.NewReception(t)
+			productRepo := mocks.NewProduct(t)
+			pvzRepo := mocks.NewPVZ(t)
+			tc.prepareRepos(receptionRepo, productRepo, pvzRepo)
 
-			service := NewReceptionService(receptionRepo, pvzRepo)
+			service := NewReceptionService(newPassthroughTransactor(t), receptionRepo, productRepo,
+				pvzRepo, mocks.NewDock(t), newCatalogTypeRepo(t))
 			ctx := context.Background()
 
-			err := service.CloseLastReception(ctx, tc.pvzID)
+			change := service.Cancel
+			switch tc.event {
+			case entity.TransitionStart:
+				change = service.Start
+			case entity.TransitionReopen:
+				change = service.Reopen
+			case entity.TransitionVerify:
+				change = service.Verify
+			}
+			reception, err := change(ctx, receptionID.String(), tc.reason, userID)
+
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+				assert.Nil(t, reception)
+				var transitionErr *TransitionError
+				if errors.As(err, &transitionErr) {
+					assert.NotEmpty(t, transitionErr.Current)
+				}
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedStatus, reception.Status)
+			}
+		})
+	}
+}
+
+func TestReceptionService_ProcessStaleReceptions(t *testing.T) {
+	firstID := uuid.New()
+	secondID := uuid.New()
+	stale := []entity.Reception{
+		{ID: firstID, Status: entity.StatusInProgress},
+		{ID: secondID, Status: entity.StatusInProgress},
+	}
+
+	testCases := []struct {
+		name              string
+		policy            string
+		prepareRepos      func(receptionRepo *mocks.Reception, productRepo *mocks.Product, pvzRepo *mocks.PVZ)
+		expectedProcessed int
+		expectedClosed    float64
+		expectedError     error
+	}{
+		{
+			name:   "flag policy skips already flagged",
+			policy: entity.StalePolicyFlag,
+			prepareRepos: func(receptionRepo *mocks.Reception, productRepo *mocks.Product, pvzRepo *mocks.PVZ) {
+				receptionRepo.On("ListStale", mock.Anything, mock.AnythingOfType("time.Time")).Return(stale, nil)
+				receptionRepo.On("FlagStale", mock.Anything, firstID.String(), mock.AnythingOfType("string")).
+					Return(true, nil)
+				receptionRepo.On("FlagStale", mock.Anything, secondID.String(), mock.AnythingOfType("string")).
+					Return(false, nil)
+			},
+			expectedProcessed: 1,
+		},
+		{
+			name:   "close policy records reason",
+			policy: entity.StalePolicyClose,
+			prepareRepos: func(receptionRepo *mocks.Reception, productRepo *mocks.Product, pvzRepo *mocks.PVZ) {
+				receptionRepo.On("ListStale", mock.Anything, mock.AnythingOfType("time.Time")).
+					Return(stale[:1], nil)
+				receptionRepo.On("LockByID", mock.Anything, firstID.String()).Return(&stale[0], nil)
+				receptionRepo.On("Close", mock.Anything, firstID.String(), uuid.Nil).Return(nil)
+				receptionRepo.On("GetManifest", mock.Anything, firstID.String()).Return(nil, nil)
+				receptionRepo.On("AddStatusChange", mock.Anything, mock.MatchedBy(func(c entity.StatusChange) bool {
+					return c.ReceptionID == firstID && c.ToStatus == entity.StatusClose &&
+						c.ChangedBy == uuid.Nil && c.Reason != ""
+				})).Return(nil)
+				expectActSaved(receptionRepo, productRepo, pvzRepo, firstID)
+			},
+			expectedProcessed: 1,
+			expectedClosed:    1,
+		},
+		{
+			name:   "failure on one reception does not stop others",
+			policy: entity.StalePolicyClose,
+			prepareRepos: func(receptionRepo *mocks.Reception, productRepo *mocks.Product, pvzRepo *mocks.PVZ) {
+				receptionRepo.On("ListStale", mock.Anything, mock.AnythingOfType("time.Time")).Return(stale, nil)
+				receptionRepo.On("LockByID", mock.Anything, firstID.String()).
+					Return(nil, errors.New("database error"))
+				receptionRepo.On("LockByID", mock.Anything, secondID.String()).Return(&stale[1], nil)
+				receptionRepo.On("Close", mock.Anything, secondID.String(), uuid.Nil).Return(nil)
+				receptionRepo.On("GetManifest", mock.Anything, secondID.String()).Return(nil, nil)
+				receptionRepo.On("AddStatusChange", mock.Anything, mock.AnythingOfType("entity.StatusChange")).
+					Return(nil)
+				expectActSaved(receptionRepo, productRepo, pvzRepo, secondID)
+			},
+			expectedProcessed: 1,
+			expectedClosed:    1,
+		},
+		{
+			name:   "close policy skips reception closed after listing",
+			policy: entity.StalePolicyClose,
+			prepareRepos: func(receptionRepo *mocks.Reception, productRepo *mocks.Product, pvzRepo *mocks.PVZ) {
+				receptionRepo.On("ListStale", mock.Anything, mock.AnythingOfType("time.Time")).Return(stale, nil)
+				receptionRepo.On("LockByID", mock.Anything, firstID.String()).
+					Return(&entity.Reception{ID: firstID, Status: entity.StatusClose}, nil)
+				receptionRepo.On("LockByID", mock.Anything, secondID.String()).
+					Return(&entity.Reception{ID: secondID, Status: entity.StatusCancelled}, nil)
+			},
+			expectedProcessed: 0,
+			expectedClosed:    0,
+		},
+		{
+			name:   "close policy flags transfer reception with items not yet received",
+			policy: entity.StalePolicyClose,
+			prepareRepos: func(receptionRepo *mocks.Reception, productRepo *mocks.Product, pvzRepo *mocks.PVZ) {
+				transfer := stale[0]
+				transfer.Kind = entity.ReceptionKindTransfer
+				receptionRepo.On("ListStale", mock.Anything, mock.AnythingOfType("time.Time")).
+					Return([]entity.Reception{transfer}, nil)
+				receptionRepo.On("LockByID", mock.Anything, firstID.String()).Return(&transfer, nil)
+				receptionRepo.On("CountPendingTransferItems", mock.Anything, firstID.String()).Return(1, nil)
+				receptionRepo.On("FlagStale", mock.Anything, firstID.String(), mock.AnythingOfType("string")).
+					Return(true, nil)
+			},
+			expectedProcessed: 1,
+		},
+		{
+			name:          "unknown policy",
+			policy:        "delete",
+			prepareRepos:  func(receptionRepo *mocks.Reception, productRepo *mocks.Product, pvzRepo *mocks.PVZ) {},
+			expectedError: ErrInvalidStalePolicy,
+		},
+		{
+			name:   "list error",
+			policy: entity.StalePolicyFlag,
+			prepareRepos: func(receptionRepo *mocks.Reception, productRepo *mocks.Product, pvzRepo *mocks.PVZ) {
+				receptionRepo.On("ListStale", mock.Anything, mock.AnythingOfType("time.Time")).
+					Return(nil, errors.New("database error"))
+			},
+			expectedError: ErrInternal,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			receptionRepo := mocks.NewReception(t)
+			productRepo := mocks.NewProduct(t)
+			pvzRepo := mocks.NewPVZ(t)
+			tc.prepareRepos(receptionRepo, productRepo, pvzRepo)
+
+			service := NewReceptionService(newPassthroughTransactor(t), receptionRepo, productRepo, pvzRepo,
+				mocks.NewDock(t), newCatalogTypeRepo(t))
+
+			closedBefore := testutil.ToFloat64(metrics.StaleReceptions.WithLabelValues(entity.StalePolicyClose))
+			processed, err := service.ProcessStaleReceptions(context.Background(), 24*time.Hour, tc.policy)
 
 			if tc.expectedError != nil {
 				assert.ErrorIs(t, err, tc.expectedError)
 			} else {
 				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedProcessed, processed)
+				assert.Equal(t, tc.expectedClosed,
+					testutil.ToFloat64(metrics.StaleReceptions.WithLabelValues(entity.StalePolicyClose))-closedBefore)
 			}
 		})
 	}
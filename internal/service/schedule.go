@@ -0,0 +1,106 @@
+package service
+
+import (
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/entity"
+	"time"
+)
+
+func parseTimeOfDay(value string) (time.Duration, error) {
+	t, err := time.Parse(entity.TimeOfDayLayout, value)
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+func validateInterval(openTime, closeTime string) bool {
+	open, err := parseTimeOfDay(openTime)
+	if err != nil {
+		return false
+	}
+	closeAt, err := parseTimeOfDay(closeTime)
+	if err != nil {
+		return false
+	}
+	return open < closeAt
+}
+
+func validateSchedule(schedule entity.PVZSchedule) error {
+	if _, err := time.LoadLocation(schedule.Timezone); err != nil || schedule.Timezone == "" {
+		return ErrInvalidTimezone
+	}
+
+	seenDays := make(map[time.Weekday]bool, len(schedule.WorkingHours))
+	for _, wh := range schedule.WorkingHours {
+		if wh.Weekday < time.Sunday || wh.Weekday > time.Saturday || seenDays[wh.Weekday] {
+			return ErrInvalidSchedule
+		}
+		seenDays[wh.Weekday] = true
+		if !validateInterval(wh.OpenTime, wh.CloseTime) {
+			return ErrInvalidSchedule
+		}
+	}
+
+	seenDates := make(map[string]bool, len(schedule.Holidays))
+	for _, h := range schedule.Holidays {
+		date := h.Date.Format(time.DateOnly)
+		if seenDates[date] {
+			return ErrInvalidSchedule
+		}
+		seenDates[date] = true
+		if h.OpenTime == "" && h.CloseTime == "" {
+			continue
+		}
+		if !validateInterval(h.OpenTime, h.CloseTime) {
+			return ErrInvalidSchedule
+		}
+	}
+	return nil
+}
+
+// isWorkingTime сообщает, открыт ли ПВЗ в момент at по его местному времени.
+// ПВЗ без недельного расписания считается работающим круглосуточно,
+// но исключение на конкретную дату действует всегда.
+func isWorkingTime(schedule *entity.PVZSchedule, at time.Time) (bool, error) {
+	loc, err := time.LoadLocation(schedule.Timezone)
+	if err != nil {
+		return false, err
+	}
+	local := at.In(loc)
+	sinceMidnight := time.Duration(local.Hour())*time.Hour +
+		time.Duration(local.Minute())*time.Minute +
+		time.Duration(local.Second())*time.Second
+
+	within := func(openTime, closeTime string) (bool, error) {
+		open, err := parseTimeOfDay(openTime)
+		if err != nil {
+			return false, err
+		}
+		closeAt, err := parseTimeOfDay(closeTime)
+		if err != nil {
+			return false, err
+		}
+		return sinceMidnight >= open && sinceMidnight < closeAt, nil
+	}
+
+	date := local.Format(time.DateOnly)
+	for _, h := range schedule.Holidays {
+		if h.Date.Format(time.DateOnly) != date {
+			continue
+		}
+		if h.OpenTime == "" {
+			return false, nil
+		}
+		return within(h.OpenTime, h.CloseTime)
+	}
+
+	if len(schedule.WorkingHours) == 0 {
+		return true, nil
+	}
+	for _, wh := range schedule.WorkingHours {
+		if wh.Weekday == local.Weekday() {
+			return within(wh.OpenTime, wh.CloseTime)
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,82 @@
+package service
+
+import (
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/entity"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestIsWorkingTime(t *testing.T) {
+	schedule := &entity.PVZSchedule{
+		Timezone: "Europe/Samara",
+		WorkingHours: []entity.WorkingHours{
+			{Weekday: time.Monday, OpenTime: "09:00", CloseTime: "21:00"},
+			{Weekday: time.Tuesday, OpenTime: "09:00", CloseTime: "21:00"},
+		},
+		Holidays: []entity.Holiday{
+			{Date: time.Date(2025, 4, 8, 0, 0, 0, 0, time.UTC)},
+			{Date: time.Date(2025, 4, 15, 0, 0, 0, 0, time.UTC), OpenTime: "12:00", CloseTime: "15:00"},
+		},
+	}
+
+	testCases := []struct {
+		name     string
+		schedule *entity.PVZSchedule
+		at       time.Time
+		expected bool
+	}{
+		{
+			name:     "within hours in local time",
+			schedule: schedule,
+			// 05:30 UTC = 09:30 Europe/Samara, понедельник
+			at:       time.Date(2025, 4, 7, 5, 30, 0, 0, time.UTC),
+			expected: true,
+		},
+		{
+			name:     "before opening in local time although open in UTC+3",
+			schedule: schedule,
+			// 04:30 UTC = 08:30 Europe/Samara
+			at:       time.Date(2025, 4, 7, 4, 30, 0, 0, time.UTC),
+			expected: false,
+		},
+		{
+			name:     "closing time is exclusive",
+			schedule: schedule,
+			at:       time.Date(2025, 4, 7, 17, 0, 0, 0, time.UTC),
+			expected: false,
+		},
+		{
+			name:     "day without working hours",
+			schedule: schedule,
+			at:       time.Date(2025, 4, 9, 10, 0, 0, 0, time.UTC),
+			expected: false,
+		},
+		{
+			name:     "holiday closes working day",
+			schedule: schedule,
+			at:       time.Date(2025, 4, 8, 10, 0, 0, 0, time.UTC),
+			expected: false,
+		},
+		{
+			name:     "shortened holiday",
+			schedule: schedule,
+			at:       time.Date(2025, 4, 15, 9, 0, 0, 0, time.UTC),
+			expected: true,
+		},
+		{
+			name:     "empty schedule is always open",
+			schedule: &entity.PVZSchedule{Timezone: entity.DefaultTimezone},
+			at:       time.Date(2025, 4, 9, 3, 0, 0, 0, time.UTC),
+			expected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			open, err := isWorkingTime(tc.schedule, tc.at)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, open)
+		})
+	}
+}
@@ -2,9 +2,12 @@ package service
 
 import (
 	"context"
+	"encoding/json"
 	"github.com/GlebMoskalev/go-pickup-point-api/config"
 	"github.com/GlebMoskalev/go-pickup-point-api/internal/entity"
 	"github.com/GlebMoskalev/go-pickup-point-api/internal/repo"
+	"github.com/google/uuid"
+	"io"
 	"time"
 )
 
@@ -18,34 +21,98 @@ type Auth interface {
 
 //go:generate go run github.com/vektra/mockery/v2@v2.53.3 --name=PVZ --output=./mocks
 type PVZ interface {
-	Create(ctx context.Context, city string) (*entity.PVZ, error)
-	ListWithDetails(ctx context.Context, startDate, endDate *time.Time, page, limit int) ([]entity.PVZWithDetails, error)
+	Create(ctx context.Context, city, timezone string) (*entity.PVZ, error)
+	ListWithDetails(ctx context.Context, filter entity.PVZFilter, page, limit int) ([]entity.PVZWithDetails, error)
+	GetSchedule(ctx context.Context, pvzID string) (*entity.PVZSchedule, error)
+	SetSchedule(ctx context.Context, schedule entity.PVZSchedule) (*entity.PVZSchedule, error)
+	GetSettings(ctx context.Context, pvzID string) (*entity.PVZSettings, error)
+	SetSettings(ctx context.Context, settings entity.PVZSettings) (*entity.PVZSettings, error)
+	CreateDock(ctx context.Context, pvzID, name string) (*entity.Dock, error)
+	ListDocks(ctx context.Context, pvzID string) ([]entity.Dock, error)
+	GetStock(ctx context.Context, pvzID string) (*entity.ProductStock, error)
+	CreateCell(ctx context.Context, cell entity.StorageCell) (*entity.StorageCell, error)
+	ListCells(ctx context.Context, pvzID string) ([]entity.StorageCell, error)
+	CheckInsuredValue(ctx context.Context) ([]entity.PVZValue, error)
 }
 
 //go:generate go run github.com/vektra/mockery/v2@v2.53.3 --name=Reception --output=./mocks
 type Reception interface {
-	Create(ctx context.Context, pvzID string) (*entity.Reception, error)
-	CloseLastReception(ctx context.Context, pvzID string) error
+	Create(ctx context.Context, params entity.ReceptionParams) (*entity.Reception, error)
+	CloseLastReception(ctx context.Context, target entity.ReceptionTarget, userID uuid.UUID,
+		counted []entity.TypeCount) (*entity.CloseResult, error)
+	Start(ctx context.Context, receptionID, comment string, userID uuid.UUID) (*entity.Reception, error)
+	Reopen(ctx context.Context, receptionID, reason string, userID uuid.UUID) (*entity.Reception, error)
+	Verify(ctx context.Context, receptionID, comment string, userID uuid.UUID) (*entity.Reception, error)
+	Cancel(ctx context.Context, receptionID, reason string, userID uuid.UUID) (*entity.Reception, error)
+	ProcessStaleReceptions(ctx context.Context, maxAge time.Duration, policy string) (int, error)
+	GetAcceptanceAct(ctx context.Context, receptionID string) (*entity.AcceptanceAct, error)
 }
 
 //go:generate go run github.com/vektra/mockery/v2@v2.53.3 --name=Product --output=./mocks
 type Product interface {
-	Create(ctx context.Context, pvzID, productType string) (*entity.Product, error)
-	DeleteLastProduct(ctx context.Context, pvzID string) error
+	Create(ctx context.Context, params entity.ProductParams) (*entity.Product, error)
+	CreateBatch(ctx context.Context, params entity.ProductBatchParams) (*entity.ProductBatchResult, error)
+	DeleteLastProduct(ctx context.Context, target entity.ReceptionTarget, userID uuid.UUID) error
+	Delete(ctx context.Context, productID, pvzID string, userID uuid.UUID) error
+	GetByBarcode(ctx context.Context, barcode string) (*entity.Product, error)
+	Issue(ctx context.Context, params entity.IssueParams) (*entity.Product, error)
+	GetPickupCode(ctx context.Context, productID string) (*entity.Product, error)
+	GetByPickupCode(ctx context.Context, pvzID, code string) (*entity.Product, error)
+	AssignCell(ctx context.Context, params entity.CellAssignment) (*entity.Product, error)
+	ListLocations(ctx context.Context, productID string) ([]entity.ProductLocation, error)
+	FlagOverdue(ctx context.Context, defaultDays int) (int, error)
+	ListOverdue(ctx context.Context, pvzID string) ([]entity.Product, error)
+	ReturnToSender(ctx context.Context, params entity.ReturnToSenderParams) (*entity.Product, error)
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.53.3 --name=Damage --output=./mocks
+type Damage interface {
+	MarkDamaged(ctx context.Context, params entity.DamageParams) (*entity.Product, error)
+	UploadPhoto(ctx context.Context, upload entity.PhotoUpload) (*entity.ProductPhoto, error)
+	ListPhotos(ctx context.Context, productID string) ([]entity.ProductPhoto, error)
+	GetPhoto(ctx context.Context, productID, photoID string) (*entity.ProductPhoto, io.ReadCloser, error)
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.53.3 --name=Transfer --output=./mocks
+type Transfer interface {
+	Create(ctx context.Context, params entity.TransferParams) (*entity.Transfer, error)
+	Get(ctx context.Context, transferID string) (*entity.Transfer, error)
+	Dispatch(ctx context.Context, transferID string, userID uuid.UUID) (*entity.Transfer, error)
+	Cancel(ctx context.Context, transferID string, userID uuid.UUID) (*entity.Transfer, error)
+	ReceiveItem(ctx context.Context, transferID, productID string, userID uuid.UUID) (*entity.Product, error)
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.53.3 --name=ProductType --output=./mocks
+type ProductType interface {
+	List(ctx context.Context) ([]entity.ProductType, error)
+	Create(ctx context.Context, productType entity.ProductType) (*entity.ProductType, error)
+	SetSchema(ctx context.Context, name string, schema json.RawMessage) (*entity.ProductType, error)
+	SetStorageDays(ctx context.Context, name string, days int) (*entity.ProductType, error)
 }
 
 type Services struct {
-	Auth      Auth
-	PVZ       PVZ
-	Reception Reception
-	Product   Product
+	Auth        Auth
+	PVZ         PVZ
+	Reception   Reception
+	Product     Product
+	ProductType ProductType
+	Transfer    Transfer
+	Damage      Damage
 }
 
 func NewServices(repositories *repo.Repositories, cfg *config.Config) *Services {
 	return &Services{
-		Auth:      NewAuthService(repositories.User, cfg.Token, cfg.Salt),
-		PVZ:       NewPVZService(repositories.PVZ),
-		Reception: NewReceptionService(repositories.Reception, repositories.PVZ),
-		Product:   NewProductService(repositories.Product, repositories.Reception, repositories.PVZ),
+		Auth: NewAuthService(repositories.User, cfg.Token, cfg.Salt),
+		PVZ: NewPVZService(repositories.PVZ, repositories.Dock, repositories.StorageCell,
+			cfg.InsuredValue),
+		Reception: NewReceptionService(repositories.Transactor, repositories.Reception, repositories.Product,
+			repositories.PVZ, repositories.Dock, repositories.ProductType),
+		Product: NewProductService(repositories.Transactor, repositories.Product, repositories.Reception,
+			repositories.PVZ, repositories.ProductType, repositories.StorageCell),
+		ProductType: NewProductTypeService(repositories.ProductType),
+		Transfer: NewTransferService(repositories.Transactor, repositories.Transfer, repositories.Product,
+			repositories.Reception, repositories.PVZ, repositories.StorageCell),
+		Damage: NewDamageService(repositories.Transactor, repositories.Product, repositories.ProductPhoto,
+			repositories.BlobStore),
 	}
 }
@@ -0,0 +1,213 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/entity"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/repo"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/repo/repoerr"
+	"github.com/google/uuid"
+	"log/slog"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	maxCellLabelLength = 32
+	maxCellCapacity    = 10000
+)
+
+// CreateCell добавляет ячейку в схему хранения ПВЗ.
+func (s *PVZService) CreateCell(ctx context.Context, cell entity.StorageCell) (*entity.StorageCell, error) {
+	log := slog.With("layer", "PVZService", "operation", "CreateCell", "pvzID", cell.PVZID.String(),
+		"code", cell.Code)
+	log.Debug("starting storage cell creation")
+
+	cell.Shelf = strings.TrimSpace(cell.Shelf)
+	cell.Code = strings.TrimSpace(cell.Code)
+	cell.ProductType = strings.TrimSpace(cell.ProductType)
+	if !validCellLabel(cell.Shelf) || !validCellLabel(cell.Code) ||
+		cell.Capacity < 1 || cell.Capacity > maxCellCapacity {
+		log.Warn("invalid storage cell")
+		return nil, ErrInvalidStorageCell
+	}
+
+	if !s.pvzRepo.Exists(ctx, cell.PVZID.String()) {
+		log.Error("pvz does not exist")
+		return nil, ErrInvalidPVZID
+	}
+
+	created, err := s.cellRepo.Create(ctx, cell)
+	if err != nil {
+		switch {
+		case errors.Is(err, repoerr.ErrDuplicateEntry):
+			log.Warn("storage cell already exists")
+			return nil, ErrStorageCellExists
+		case errors.Is(err, repoerr.ErrNotFound):
+			log.Warn("unknown product type", "type", cell.ProductType)
+			return nil, ErrInvalidProductType
+		}
+		log.Error("failed to create storage cell", "error", err)
+		return nil, ErrInternal
+	}
+
+	log.Info("storage cell created successfully", "cellID", created.ID.String())
+	return created, nil
+}
+
+// ListCells возвращает ячейки ПВЗ с их занятостью.
+func (s *PVZService) ListCells(ctx context.Context, pvzID string) ([]entity.StorageCell, error) {
+	log := slog.With("layer", "PVZService", "operation", "ListCells", "pvzID", pvzID)
+	log.Debug("starting list storage cells")
+
+	if !s.pvzRepo.Exists(ctx, pvzID) {
+		log.Error("pvz does not exist")
+		return nil, ErrInvalidPVZID
+	}
+
+	cells, err := s.cellRepo.List(ctx, pvzID)
+	if err != nil {
+		log.Error("failed to list storage cells", "error", err)
+		return nil, ErrInternal
+	}
+	return cells, nil
+}
+
+// AssignCell перекладывает хранящийся товар в указанную ячейку его ПВЗ. Ячейка должна принимать
+// тип товара и иметь свободное место.
+func (s *ProductService) AssignCell(ctx context.Context, params entity.CellAssignment) (*entity.Product, error) {
+	log := slog.With("layer", "ProductService", "operation", "AssignCell", "productID", params.ProductID,
+		"cellID", params.CellID, "userID", params.UserID.String())
+	log.Debug("starting storage cell assignment")
+
+	cellID, err := uuid.Parse(params.CellID)
+	if err != nil {
+		return nil, ErrStorageCellNotFound
+	}
+
+	var product *entity.Product
+	err = withinTx(ctx, s.transactor, log, func(ctx context.Context) error {
+		var err error
+		product, err = s.productRepo.GetByID(ctx, params.ProductID)
+		if err != nil {
+			if errors.Is(err, repoerr.ErrNoRows) {
+				log.Warn("product not found")
+				return ErrProductNotFound
+			}
+			log.Error("failed to get product", "error", err)
+			return ErrInternal
+		}
+		if product.DeletedAt != nil {
+			log.Warn("product is deleted")
+			return ErrProductNotFound
+		}
+		if product.Status != entity.ProductStatusStored {
+			log.Warn("product is not stored", "status", product.Status)
+			return ErrProductNotStored
+		}
+
+		cells, err := s.cellRepo.LockByPVZ(ctx, product.PVZID.String())
+		if err != nil {
+			log.Error("failed to lock storage cells", "error", err)
+			return ErrInternal
+		}
+		i := slices.IndexFunc(cells, func(cell entity.StorageCell) bool { return cell.ID == cellID })
+		if i < 0 {
+			log.Warn("storage cell not found in product pvz")
+			return ErrStorageCellNotFound
+		}
+		cell := cells[i]
+		if cell.ID == product.CellID {
+			return nil
+		}
+		if !cell.Accepts(product.Type) {
+			log.Warn("storage cell does not accept product type", "type", product.Type,
+				"cellType", cell.ProductType)
+			return ErrCellTypeMismatch
+		}
+		if cell.Free() == 0 {
+			log.Warn("storage cell is full", "capacity", cell.Capacity)
+			return ErrStorageCellFull
+		}
+
+		if err := s.productRepo.SetCell(ctx, params.ProductID, cellID.String()); err != nil {
+			if errors.Is(err, repoerr.ErrNoRows) {
+				log.Warn("product status changed concurrently")
+				return ErrProductNotStored
+			}
+			log.Error("failed to set product cell", "error", err)
+			return ErrInternal
+		}
+		setProductCell(product, cell)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info("storage cell assigned", "cellCode", product.CellCode)
+	return product, nil
+}
+
+// assignCells подбирает ячейки новым товарам ПВЗ. Товар без свободной подходящей ячейки
+// принимается без неё, и сотрудник может назначить ячейку вручную.
+func assignCells(ctx context.Context, cellRepo repo.StorageCell, log *slog.Logger, pvzID string,
+	products []entity.Product) error {
+	cells, err := cellRepo.LockByPVZ(ctx, pvzID)
+	if err != nil {
+		log.Error("failed to lock storage cells", "error", err)
+		return ErrInternal
+	}
+	if len(cells) == 0 {
+		return nil
+	}
+
+	for i := range products {
+		cell := pickCell(cells, products[i].Type)
+		if cell == nil {
+			log.Warn("no free storage cell", "type", products[i].Type)
+			continue
+		}
+		cell.Occupied++
+		setProductCell(&products[i], *cell)
+	}
+	return nil
+}
+
+// pickCell выбирает ячейку для товара: сначала ячейки его типа, затем общие, среди них — с
+// наибольшим числом свободных мест. Возвращает nil, если свободных подходящих ячеек нет.
+func pickCell(cells []entity.StorageCell, productType string) *entity.StorageCell {
+	var best *entity.StorageCell
+	for i := range cells {
+		cell := &cells[i]
+		if !cell.Accepts(productType) || cell.Free() == 0 {
+			continue
+		}
+		if best == nil {
+			best = cell
+			continue
+		}
+		dedicated, bestDedicated := cell.ProductType != "", best.ProductType != ""
+		if dedicated != bestDedicated {
+			if dedicated {
+				best = cell
+			}
+			continue
+		}
+		if cell.Free() > best.Free() {
+			best = cell
+		}
+	}
+	return best
+}
+
+func setProductCell(product *entity.Product, cell entity.StorageCell) {
+	product.CellID = cell.ID
+	product.CellShelf = cell.Shelf
+	product.CellCode = cell.Code
+}
+
+func validCellLabel(label string) bool {
+	return label != "" && utf8.RuneCountInString(label) <= maxCellLabelLength
+}
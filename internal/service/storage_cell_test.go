@@ -0,0 +1,341 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/GlebMoskalev/go-pickup-point-api/config"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/entity"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/repo/mocks"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/repo/repoerr"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+	"time"
+)
+
+// newEmptyCellRepo возвращает репозиторий ячеек ПВЗ без схемы хранения.
+func newEmptyCellRepo(t *testing.T) *mocks.StorageCell {
+	cellRepo := mocks.NewStorageCell(t)
+	cellRepo.On("LockByPVZ", mock.Anything, mock.Anything).Return(nil, nil).Maybe()
+	return cellRepo
+}
+
+func TestPickCell(t *testing.T) {
+	shoes := entity.StorageCell{Code: "A-01", ProductType: entity.ProductTypeShoes, Capacity: 2}
+	fullShoes := entity.StorageCell{Code: "A-02", ProductType: entity.ProductTypeShoes, Capacity: 2, Occupied: 2}
+	common := entity.StorageCell{Code: "B-01", Capacity: 10}
+	roomy := entity.StorageCell{Code: "B-02", Capacity: 10, Occupied: 1}
+	clothes := entity.StorageCell{Code: "C-01", ProductType: entity.ProductTypeClothes, Capacity: 5}
+
+	testCases := []struct {
+		name         string
+		cells        []entity.StorageCell
+		productType  string
+		expectedCode string
+	}{
+		{
+			name:         "dedicated cell first",
+			cells:        []entity.StorageCell{common, shoes},
+			productType:  entity.ProductTypeShoes,
+			expectedCode: "A-01",
+		},
+		{
+			name:         "common cell when dedicated is full",
+			cells:        []entity.StorageCell{fullShoes, roomy, common},
+			productType:  entity.ProductTypeShoes,
+			expectedCode: "B-01",
+		},
+		{
+			name:         "cells of another type are skipped",
+			cells:        []entity.StorageCell{clothes, roomy},
+			productType:  entity.ProductTypeShoes,
+			expectedCode: "B-02",
+		},
+		{
+			name:        "no free cell",
+			cells:       []entity.StorageCell{fullShoes, clothes},
+			productType: entity.ProductTypeShoes,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cell := pickCell(tc.cells, tc.productType)
+			if tc.expectedCode == "" {
+				assert.Nil(t, cell)
+				return
+			}
+			assert.Equal(t, tc.expectedCode, cell.Code)
+		})
+	}
+}
+
+func TestProductService_CreateAssignsCell(t *testing.T) {
+	pvzID := uuid.New()
+	receptionID := uuid.New()
+	cell := entity.StorageCell{ID: uuid.New(), PVZID: pvzID, Shelf: "A", Code: "A-01", Capacity: 1}
+
+	pvzRepo := mocks.NewPVZ(t)
+	pvzRepo.On("Exists", mock.Anything, pvzID.String()).Return(true)
+	receptionRepo := mocks.NewReception(t)
+	receptionRepo.On("LockLastOpenReception", mock.Anything, pvzID.String()).
+		Return(&entity.Reception{ID: receptionID, PVZID: pvzID, Status: entity.StatusInProgress}, nil)
+	cellRepo := mocks.NewStorageCell(t)
+	cellRepo.On("LockByPVZ", mock.Anything, pvzID.String()).
+		Return(func(ctx context.Context, pvzID string) ([]entity.StorageCell, error) {
+			return []entity.StorageCell{cell}, nil
+		})
+	productRepo := mocks.NewProduct(t)
+	productRepo.On("Create", mock.Anything, mock.AnythingOfType("entity.Product")).
+		Return(func(ctx context.Context, product entity.Product) (*entity.Product, error) {
+			product.ID = uuid.New()
+			return &product, nil
+		})
+	productRepo.On("CreateBatch", mock.Anything, mock.AnythingOfType("[]entity.Product")).
+		Return(func(ctx context.Context, products []entity.Product) ([]entity.Product, error) {
+			return products, nil
+		})
+
+	service := NewProductService(newPassthroughTransactor(t), productRepo, receptionRepo, pvzRepo,
+		newCatalogTypeRepo(t), cellRepo)
+
+	product, err := service.Create(context.Background(), entity.ProductParams{
+		ReceptionTarget: entity.ReceptionTarget{PVZID: pvzID.String()},
+		Type:            entity.ProductTypeClothes,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, cell.ID, product.CellID)
+	assert.Equal(t, "A-01", product.CellCode)
+
+	result, err := service.CreateBatch(context.Background(), entity.ProductBatchParams{
+		ReceptionTarget: entity.ReceptionTarget{PVZID: pvzID.String()},
+		Items:           []entity.ProductBatchItem{{Type: entity.ProductTypeClothes}, {Type: entity.ProductTypeClothes}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, cell.ID, result.Items[0].Product.CellID)
+	assert.Equal(t, uuid.Nil, result.Items[1].Product.CellID, "product without free cell is accepted without it")
+}
+
+func TestProductService_AssignCell(t *testing.T) {
+	userID := uuid.New()
+	pvzID := uuid.New()
+	productID := uuid.New().String()
+	deletedAt := time.Now()
+	current := entity.StorageCell{ID: uuid.New(), PVZID: pvzID, Shelf: "A", Code: "A-01", Capacity: 5, Occupied: 1}
+	free := entity.StorageCell{ID: uuid.New(), PVZID: pvzID, Shelf: "B", Code: "B-01", Capacity: 5}
+	full := entity.StorageCell{ID: uuid.New(), PVZID: pvzID, Shelf: "B", Code: "B-02", Capacity: 1, Occupied: 1}
+	shoes := entity.StorageCell{ID: uuid.New(), PVZID: pvzID, Shelf: "C", Code: "C-01",
+		ProductType: entity.ProductTypeShoes, Capacity: 5}
+	cells := []entity.StorageCell{current, free, full, shoes}
+	stored := entity.Product{ID: uuid.New(), Type: entity.ProductTypeClothes, PVZID: pvzID,
+		Status: entity.ProductStatusStored, CellID: current.ID, CellShelf: "A", CellCode: "A-01"}
+
+	withProduct := func(product entity.Product) func(productRepo *mocks.Product, cellRepo *mocks.StorageCell) {
+		return func(productRepo *mocks.Product, cellRepo *mocks.StorageCell) {
+			productRepo.On("GetByID", mock.Anything, productID).Return(&product, nil)
+			cellRepo.On("LockByPVZ", mock.Anything, pvzID.String()).Return(cells, nil).Maybe()
+		}
+	}
+
+	testCases := []struct {
+		name          string
+		cellID        string
+		prepareRepos  func(productRepo *mocks.Product, cellRepo *mocks.StorageCell)
+		expectedCode  string
+		expectedError error
+	}{
+		{
+			name:   "successful reassignment",
+			cellID: free.ID.String(),
+			prepareRepos: func(productRepo *mocks.Product, cellRepo *mocks.StorageCell) {
+				withProduct(stored)(productRepo, cellRepo)
+				productRepo.On("SetCell", mock.Anything, productID, free.ID.String()).Return(nil)
+			},
+			expectedCode: "B-01",
+		},
+		{
+			name:         "same cell",
+			cellID:       current.ID.String(),
+			prepareRepos: withProduct(stored),
+			expectedCode: "A-01",
+		},
+		{
+			name:          "invalid cell id",
+			cellID:        "invalid",
+			prepareRepos:  func(productRepo *mocks.Product, cellRepo *mocks.StorageCell) {},
+			expectedError: ErrStorageCellNotFound,
+		},
+		{
+			name:          "cell of another pvz",
+			cellID:        uuid.New().String(),
+			prepareRepos:  withProduct(stored),
+			expectedError: ErrStorageCellNotFound,
+		},
+		{
+			name:          "cell is full",
+			cellID:        full.ID.String(),
+			prepareRepos:  withProduct(stored),
+			expectedError: ErrStorageCellFull,
+		},
+		{
+			name:          "cell of another type",
+			cellID:        shoes.ID.String(),
+			prepareRepos:  withProduct(stored),
+			expectedError: ErrCellTypeMismatch,
+		},
+		{
+			name:   "product not stored",
+			cellID: free.ID.String(),
+			prepareRepos: withProduct(entity.Product{ID: stored.ID, PVZID: pvzID,
+				Status: entity.ProductStatusIssued}),
+			expectedError: ErrProductNotStored,
+		},
+		{
+			name:          "product deleted",
+			cellID:        free.ID.String(),
+			prepareRepos:  withProduct(entity.Product{ID: stored.ID, PVZID: pvzID, DeletedAt: &deletedAt}),
+			expectedError: ErrProductNotFound,
+		},
+		{
+			name:   "product not found",
+			cellID: free.ID.String(),
+			prepareRepos: func(productRepo *mocks.Product, cellRepo *mocks.StorageCell) {
+				productRepo.On("GetByID", mock.Anything, productID).Return(nil, repoerr.ErrNoRows)
+			},
+			expectedError: ErrProductNotFound,
+		},
+		{
+			name:   "product issued concurrently",
+			cellID: free.ID.String(),
+			prepareRepos: func(productRepo *mocks.Product, cellRepo *mocks.StorageCell) {
+				withProduct(stored)(productRepo, cellRepo)
+				productRepo.On("SetCell", mock.Anything, productID, free.ID.String()).Return(repoerr.ErrNoRows)
+			},
+			expectedError: ErrProductNotStored,
+		},
+		{
+			name:   "cell repo error",
+			cellID: free.ID.String(),
+			prepareRepos: func(productRepo *mocks.Product, cellRepo *mocks.StorageCell) {
+				productRepo.On("GetByID", mock.Anything, productID).Return(&stored, nil)
+				cellRepo.On("LockByPVZ", mock.Anything, pvzID.String()).Return(nil, errors.New("database error"))
+			},
+			expectedError: ErrInternal,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			productRepo := mocks.NewProduct(t)
+			cellRepo := mocks.NewStorageCell(t)
+			tc.prepareRepos(productRepo, cellRepo)
+
+			service := NewProductService(newPassthroughTransactor(t), productRepo, mocks.NewReception(t),
+				mocks.NewPVZ(t), newCatalogTypeRepo(t), cellRepo)
+
+			product, err := service.AssignCell(context.Background(), entity.CellAssignment{
+				ProductID: productID,
+				CellID:    tc.cellID,
+				UserID:    userID,
+			})
+
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+				assert.Nil(t, product)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedCode, product.CellCode)
+		})
+	}
+}
+
+func TestPVZService_CreateCell(t *testing.T) {
+	pvzID := uuid.New()
+	valid := entity.StorageCell{PVZID: pvzID, Shelf: " A ", Code: " A-01 ", Capacity: 20}
+	trimmed := entity.StorageCell{PVZID: pvzID, Shelf: "A", Code: "A-01", Capacity: 20}
+
+	testCases := []struct {
+		name          string
+		cell          entity.StorageCell
+		prepareRepos  func(pvzRepo *mocks.PVZ, cellRepo *mocks.StorageCell)
+		expectedError error
+	}{
+		{
+			name: "successful creation",
+			cell: valid,
+			prepareRepos: func(pvzRepo *mocks.PVZ, cellRepo *mocks.StorageCell) {
+				pvzRepo.On("Exists", mock.Anything, pvzID.String()).Return(true)
+				cellRepo.On("Create", mock.Anything, trimmed).Return(&trimmed, nil)
+			},
+		},
+		{
+			name:          "empty code",
+			cell:          entity.StorageCell{PVZID: pvzID, Shelf: "A", Code: " ", Capacity: 20},
+			prepareRepos:  func(pvzRepo *mocks.PVZ, cellRepo *mocks.StorageCell) {},
+			expectedError: ErrInvalidStorageCell,
+		},
+		{
+			name:          "zero capacity",
+			cell:          entity.StorageCell{PVZID: pvzID, Shelf: "A", Code: "A-01"},
+			prepareRepos:  func(pvzRepo *mocks.PVZ, cellRepo *mocks.StorageCell) {},
+			expectedError: ErrInvalidStorageCell,
+		},
+		{
+			name: "pvz does not exist",
+			cell: valid,
+			prepareRepos: func(pvzRepo *mocks.PVZ, cellRepo *mocks.StorageCell) {
+				pvzRepo.On("Exists", mock.Anything, pvzID.String()).Return(false)
+			},
+			expectedError: ErrInvalidPVZID,
+		},
+		{
+			name: "duplicate code",
+			cell: valid,
+			prepareRepos: func(pvzRepo *mocks.PVZ, cellRepo *mocks.StorageCell) {
+				pvzRepo.On("Exists", mock.Anything, pvzID.String()).Return(true)
+				cellRepo.On("Create", mock.Anything, trimmed).Return(nil, repoerr.ErrDuplicateEntry)
+			},
+			expectedError: ErrStorageCellExists,
+		},
+		{
+			name: "unknown product type",
+			cell: valid,
+			prepareRepos: func(pvzRepo *mocks.PVZ, cellRepo *mocks.StorageCell) {
+				pvzRepo.On("Exists", mock.Anything, pvzID.String()).Return(true)
+				cellRepo.On("Create", mock.Anything, trimmed).Return(nil, repoerr.ErrNotFound)
+			},
+			expectedError: ErrInvalidProductType,
+		},
+		{
+			name: "repo error",
+			cell: valid,
+			prepareRepos: func(pvzRepo *mocks.PVZ, cellRepo *mocks.StorageCell) {
+				pvzRepo.On("Exists", mock.Anything, pvzID.String()).Return(true)
+				cellRepo.On("Create", mock.Anything, trimmed).Return(nil, errors.New("database error"))
+			},
+			expectedError: ErrInternal,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pvzRepo := mocks.NewPVZ(t)
+			cellRepo := mocks.NewStorageCell(t)
+			tc.prepareRepos(pvzRepo, cellRepo)
+
+			service := NewPVZService(pvzRepo, mocks.NewDock(t), cellRepo, config.InsuredValue{})
+
+			cell, err := service.CreateCell(context.Background(), tc.cell)
+
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+				assert.Nil(t, cell)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, "A-01", cell.Code)
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/entity"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/metrics"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/repo/repoerr"
+	"log/slog"
+)
+
+const maxStorageDays = 365
+
+// validateStorageDays проверяет срок хранения в днях; 0 означает, что срок не задан.
+func validateStorageDays(days int) error {
+	if days < 0 || days > maxStorageDays {
+		return ErrInvalidStoragePeriod
+	}
+	return nil
+}
+
+// FlagOverdue помещает хранящиеся товары с истёкшим сроком хранения в очередь на возврат отправителю
+// и возвращает их число. defaultDays действует, если срок не задан ни для типа товара, ни для ПВЗ.
+func (s *ProductService) FlagOverdue(ctx context.Context, defaultDays int) (int, error) {
+	log := slog.With("layer", "ProductService", "operation", "FlagOverdue", "defaultDays", defaultDays)
+	log.Debug("starting overdue products flagging")
+
+	if defaultDays <= 0 || defaultDays > maxStorageDays {
+		return 0, ErrInvalidStoragePeriod
+	}
+
+	flagged, err := s.productRepo.FlagOverdue(ctx, defaultDays)
+	if err != nil {
+		log.Error("failed to flag overdue products", "error", err)
+		return 0, ErrInternal
+	}
+
+	metrics.ProductsOverdue.Add(float64(flagged))
+	return flagged, nil
+}
+
+// ListOverdue возвращает очередь ПВЗ на возврат отправителю.
+func (s *ProductService) ListOverdue(ctx context.Context, pvzID string) ([]entity.Product, error) {
+	log := slog.With("layer", "ProductService", "operation", "ListOverdue", "pvzID", pvzID)
+	log.Debug("starting list overdue products")
+
+	if !s.pvzRepo.Exists(ctx, pvzID) {
+		log.Error("pvz does not exist")
+		return nil, ErrInvalidPVZID
+	}
+
+	products, err := s.productRepo.ListOverdue(ctx, pvzID)
+	if err != nil {
+		log.Error("failed to list overdue products", "error", err)
+		return nil, ErrInternal
+	}
+	return products, nil
+}
+
+// ReturnToSender подтверждает отправку товара из очереди на возврат обратно отправителю. Кто и когда
+// отправил товар, сохраняется в товаре.
+func (s *ProductService) ReturnToSender(ctx context.Context, params entity.ReturnToSenderParams) (*entity.Product, error) {
+	log := slog.With("layer", "ProductService", "operation", "ReturnToSender", "productID", params.ProductID,
+		"pvzID", params.PVZID, "userID", params.ReturnedBy.String())
+	log.Debug("starting product return to sender")
+
+	if !s.pvzRepo.Exists(ctx, params.PVZID) {
+		log.Error("pvz does not exist")
+		return nil, ErrInvalidPVZID
+	}
+
+	var product *entity.Product
+	err := withinTx(ctx, s.transactor, log, func(ctx context.Context) error {
+		var err error
+		product, err = s.productRepo.GetByID(ctx, params.ProductID)
+		if err != nil {
+			if errors.Is(err, repoerr.ErrNoRows) {
+				log.Warn("product not found")
+				return ErrProductNotFound
+			}
+			log.Error("failed to get product", "error", err)
+			return ErrInternal
+		}
+		if product.DeletedAt != nil || product.PVZID.String() != params.PVZID {
+			log.Warn("product is not in the pvz", "productPVZID", product.PVZID.String())
+			return ErrProductNotFound
+		}
+
+		status, ok := entity.NextProductStatus(product.Status, entity.ProductEventReturnToSender)
+		if !ok {
+			log.Warn("product cannot be returned to sender", "status", product.Status)
+			return &TransitionError{Current: product.Status, Event: entity.ProductEventReturnToSender}
+		}
+		if product.OverdueAt == nil {
+			log.Warn("product storage period has not expired")
+			return ErrProductNotOverdue
+		}
+
+		returnedAt, err := s.productRepo.ReturnToSender(ctx, params.ProductID, params.ReturnedBy)
+		if err != nil {
+			if errors.Is(err, repoerr.ErrNoRows) {
+				log.Warn("product status changed concurrently")
+				return ErrInvalidTransition
+			}
+			log.Error("failed to return product to sender", "error", err)
+			return ErrInternal
+		}
+		product.Status = status
+		product.ReturnedBy = params.ReturnedBy
+		product.ReturnedAt = &returnedAt
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	metrics.ProductsReturnedToSender.Inc()
+	log.Info("product returned to sender successfully")
+	return product, nil
+}
@@ -0,0 +1,248 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/entity"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/repo/mocks"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/repo/repoerr"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+	"time"
+)
+
+func TestProductService_FlagOverdue(t *testing.T) {
+	testCases := []struct {
+		name          string
+		defaultDays   int
+		prepareRepo   func(productRepo *mocks.Product)
+		expected      int
+		expectedError error
+	}{
+		{
+			name:        "products flagged",
+			defaultDays: 7,
+			prepareRepo: func(productRepo *mocks.Product) {
+				productRepo.On("FlagOverdue", mock.Anything, 7).Return(3, nil)
+			},
+			expected: 3,
+		},
+		{
+			name:          "zero default period",
+			defaultDays:   0,
+			prepareRepo:   func(productRepo *mocks.Product) {},
+			expectedError: ErrInvalidStoragePeriod,
+		},
+		{
+			name:        "repo error",
+			defaultDays: 7,
+			prepareRepo: func(productRepo *mocks.Product) {
+				productRepo.On("FlagOverdue", mock.Anything, 7).Return(0, errors.New("database error"))
+			},
+			expectedError: ErrInternal,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			productRepo := mocks.NewProduct(t)
+			tc.prepareRepo(productRepo)
+
+			service := NewProductService(newPassthroughTransactor(t), productRepo, mocks.NewReception(t), mocks.NewPVZ(t),
+				newCatalogTypeRepo(t), newEmptyCellRepo(t))
+
+			flagged, err := service.FlagOverdue(context.Background(), tc.defaultDays)
+
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, flagged)
+		})
+	}
+}
+
+func TestProductService_ListOverdue(t *testing.T) {
+	pvzID := uuid.NewString()
+	overdueAt := time.Now()
+	overdue := []entity.Product{{ID: uuid.New(), Status: entity.ProductStatusStored, OverdueAt: &overdueAt}}
+
+	testCases := []struct {
+		name          string
+		prepareRepos  func(productRepo *mocks.Product, pvzRepo *mocks.PVZ)
+		expected      []entity.Product
+		expectedError error
+	}{
+		{
+			name: "queue returned",
+			prepareRepos: func(productRepo *mocks.Product, pvzRepo *mocks.PVZ) {
+				pvzRepo.On("Exists", mock.Anything, pvzID).Return(true)
+				productRepo.On("ListOverdue", mock.Anything, pvzID).Return(overdue, nil)
+			},
+			expected: overdue,
+		},
+		{
+			name: "unknown pvz",
+			prepareRepos: func(productRepo *mocks.Product, pvzRepo *mocks.PVZ) {
+				pvzRepo.On("Exists", mock.Anything, pvzID).Return(false)
+			},
+			expectedError: ErrInvalidPVZID,
+		},
+		{
+			name: "repo error",
+			prepareRepos: func(productRepo *mocks.Product, pvzRepo *mocks.PVZ) {
+				pvzRepo.On("Exists", mock.Anything, pvzID).Return(true)
+				productRepo.On("ListOverdue", mock.Anything, pvzID).Return(nil, errors.New("database error"))
+			},
+			expectedError: ErrInternal,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			productRepo := mocks.NewProduct(t)
+			pvzRepo := mocks.NewPVZ(t)
+			tc.prepareRepos(productRepo, pvzRepo)
+
+			service := NewProductService(newPassthroughTransactor(t), productRepo, mocks.NewReception(t), pvzRepo,
+				newCatalogTypeRepo(t), newEmptyCellRepo(t))
+
+			products, err := service.ListOverdue(context.Background(), pvzID)
+
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+				assert.Nil(t, products)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, products)
+		})
+	}
+}
+
+func TestProductService_ReturnToSender(t *testing.T) {
+	userID := uuid.New()
+	pvzID := uuid.New()
+	productID := uuid.New()
+	overdueAt := time.Now().Add(-time.Hour)
+	returnedAt := time.Now()
+
+	product := func(status string, overdue bool) *entity.Product {
+		p := &entity.Product{ID: productID, PVZID: pvzID, Type: entity.ProductTypeShoes, Status: status}
+		if overdue {
+			p.OverdueAt = &overdueAt
+		}
+		return p
+	}
+
+	testCases := []struct {
+		name          string
+		prepareRepos  func(productRepo *mocks.Product, pvzRepo *mocks.PVZ)
+		expectedError error
+	}{
+		{
+			name: "successful return",
+			prepareRepos: func(productRepo *mocks.Product, pvzRepo *mocks.PVZ) {
+				pvzRepo.On("Exists", mock.Anything, pvzID.String()).Return(true)
+				productRepo.On("GetByID", mock.Anything, productID.String()).
+					Return(product(entity.ProductStatusStored, true), nil)
+				productRepo.On("ReturnToSender", mock.Anything, productID.String(), userID).Return(returnedAt, nil)
+			},
+		},
+		{
+			name: "unknown pvz",
+			prepareRepos: func(productRepo *mocks.Product, pvzRepo *mocks.PVZ) {
+				pvzRepo.On("Exists", mock.Anything, pvzID.String()).Return(false)
+			},
+			expectedError: ErrInvalidPVZID,
+		},
+		{
+			name: "product not found",
+			prepareRepos: func(productRepo *mocks.Product, pvzRepo *mocks.PVZ) {
+				pvzRepo.On("Exists", mock.Anything, pvzID.String()).Return(true)
+				productRepo.On("GetByID", mock.Anything, productID.String()).Return(nil, repoerr.ErrNoRows)
+			},
+			expectedError: ErrProductNotFound,
+		},
+		{
+			name: "product of another pvz",
+			prepareRepos: func(productRepo *mocks.Product, pvzRepo *mocks.PVZ) {
+				pvzRepo.On("Exists", mock.Anything, pvzID.String()).Return(true)
+				other := product(entity.ProductStatusStored, true)
+				other.PVZID = uuid.New()
+				productRepo.On("GetByID", mock.Anything, productID.String()).Return(other, nil)
+			},
+			expectedError: ErrProductNotFound,
+		},
+		{
+			name: "storage period not expired",
+			prepareRepos: func(productRepo *mocks.Product, pvzRepo *mocks.PVZ) {
+				pvzRepo.On("Exists", mock.Anything, pvzID.String()).Return(true)
+				productRepo.On("GetByID", mock.Anything, productID.String()).
+					Return(product(entity.ProductStatusStored, false), nil)
+			},
+			expectedError: ErrProductNotOverdue,
+		},
+		{
+			name: "already issued",
+			prepareRepos: func(productRepo *mocks.Product, pvzRepo *mocks.PVZ) {
+				pvzRepo.On("Exists", mock.Anything, pvzID.String()).Return(true)
+				productRepo.On("GetByID", mock.Anything, productID.String()).
+					Return(product(entity.ProductStatusIssued, true), nil)
+			},
+			expectedError: ErrInvalidTransition,
+		},
+		{
+			name: "issued concurrently",
+			prepareRepos: func(productRepo *mocks.Product, pvzRepo *mocks.PVZ) {
+				pvzRepo.On("Exists", mock.Anything, pvzID.String()).Return(true)
+				productRepo.On("GetByID", mock.Anything, productID.String()).
+					Return(product(entity.ProductStatusStored, true), nil)
+				productRepo.On("ReturnToSender", mock.Anything, productID.String(), userID).
+					Return(time.Time{}, repoerr.ErrNoRows)
+			},
+			expectedError: ErrInvalidTransition,
+		},
+		{
+			name: "repo error",
+			prepareRepos: func(productRepo *mocks.Product, pvzRepo *mocks.PVZ) {
+				pvzRepo.On("Exists", mock.Anything, pvzID.String()).Return(true)
+				productRepo.On("GetByID", mock.Anything, productID.String()).
+					Return(product(entity.ProductStatusStored, true), nil)
+				productRepo.On("ReturnToSender", mock.Anything, productID.String(), userID).
+					Return(time.Time{}, errors.New("database error"))
+			},
+			expectedError: ErrInternal,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			productRepo := mocks.NewProduct(t)
+			pvzRepo := mocks.NewPVZ(t)
+			tc.prepareRepos(productRepo, pvzRepo)
+
+			service := NewProductService(newPassthroughTransactor(t), productRepo, mocks.NewReception(t), pvzRepo,
+				newCatalogTypeRepo(t), newEmptyCellRepo(t))
+
+			returned, err := service.ReturnToSender(context.Background(), entity.ReturnToSenderParams{
+				ProductID:  productID.String(),
+				PVZID:      pvzID.String(),
+				ReturnedBy: userID,
+			})
+
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+				assert.Nil(t, returned)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, entity.ProductStatusReturnedToSender, returned.Status)
+			assert.Equal(t, userID, returned.ReturnedBy)
+			assert.Equal(t, returnedAt, *returned.ReturnedAt)
+		})
+	}
+}
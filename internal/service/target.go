@@ -0,0 +1,88 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/entity"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/repo"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/repo/repoerr"
+	"log/slog"
+)
+
+// lockOpenReception находит и блокирует открытую приёмку, указанную target.
+// Вызывается внутри withinTx. Явно заданные приёмка или док должны относиться к ПВЗ
+// из target; обращение только по ПВЗ возможно, пока в нём открыта одна приёмка.
+func lockOpenReception(ctx context.Context, receptionRepo repo.Reception, log *slog.Logger,
+	target entity.ReceptionTarget) (*entity.Reception, error) {
+	switch {
+	case target.ReceptionID != "":
+		reception, err := receptionRepo.LockByID(ctx, target.ReceptionID)
+		if err != nil {
+			if errors.Is(err, repoerr.ErrNoRows) {
+				log.Error("reception not found")
+				return nil, ErrReceptionNotFound
+			}
+			log.Error("failed to lock reception", "error", err)
+			return nil, ErrInternal
+		}
+		if reception.Status != entity.StatusInProgress {
+			log.Warn("reception is not open", "status", reception.Status)
+			return nil, ErrNoOpenReception
+		}
+		if target.DockID != "" && reception.DockID.String() != target.DockID {
+			log.Warn("reception belongs to another dock", "receptionDockID", reception.DockID.String())
+			return nil, ErrNoOpenReception
+		}
+		return checkTargetPVZ(log, reception, target)
+	case target.DockID != "":
+		reception, err := receptionRepo.LockOpenReceptionByDock(ctx, target.DockID)
+		if err != nil {
+			return nil, openReceptionError(log, err)
+		}
+		return checkTargetPVZ(log, reception, target)
+	default:
+		reception, err := receptionRepo.LockLastOpenReception(ctx, target.PVZID)
+		if err != nil {
+			if errors.Is(err, repoerr.ErrMultipleRows) {
+				log.Warn("pvz has several open receptions")
+				return nil, ErrDockRequired
+			}
+			return nil, openReceptionError(log, err)
+		}
+		return reception, nil
+	}
+}
+
+func checkTargetPVZ(log *slog.Logger, reception *entity.Reception,
+	target entity.ReceptionTarget) (*entity.Reception, error) {
+	if target.PVZID != "" && reception.PVZID.String() != target.PVZID {
+		log.Warn("reception belongs to another pvz", "receptionPVZID", reception.PVZID.String())
+		return nil, ErrNoOpenReception
+	}
+	return reception, nil
+}
+
+func openReceptionError(log *slog.Logger, err error) error {
+	if errors.Is(err, repoerr.ErrNoRows) {
+		log.Error("not found open reception")
+		return ErrNoOpenReception
+	}
+	log.Error("failed to check open reception", "error", err)
+	return ErrInternal
+}
+
+// validateTarget проверяет, что target указывает на существующий ПВЗ или на приёмку/док.
+func validateTarget(ctx context.Context, pvzRepo repo.PVZ, log *slog.Logger, target entity.ReceptionTarget) error {
+	if target.PVZID == "" {
+		if target.DockID == "" && target.ReceptionID == "" {
+			log.Error("reception target is empty")
+			return ErrInvalidPVZID
+		}
+		return nil
+	}
+	if !pvzRepo.Exists(ctx, target.PVZID) {
+		log.Error("pvz does not exist")
+		return ErrInvalidPVZID
+	}
+	return nil
+}
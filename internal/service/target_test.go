@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/entity"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/repo/mocks"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/repo/repoerr"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"log/slog"
+	"testing"
+)
+
+func TestLockOpenReception(t *testing.T) {
+	pvzID := uuid.New()
+	dockID := uuid.New()
+	receptionID := uuid.New()
+	openReception := &entity.Reception{
+		ID:     receptionID,
+		PVZID:  pvzID,
+		DockID: dockID,
+		Status: entity.StatusInProgress,
+	}
+
+	testCases := []struct {
+		name              string
+		target            entity.ReceptionTarget
+		prepareRepo       func(receptionRepo *mocks.Reception)
+		expectedReception *entity.Reception
+		expectedError     error
+	}{
+		{
+			name:   "single open reception of pvz",
+			target: entity.ReceptionTarget{PVZID: pvzID.String()},
+			prepareRepo: func(receptionRepo *mocks.Reception) {
+				receptionRepo.On("LockLastOpenReception", mock.Anything, pvzID.String()).Return(openReception, nil)
+			},
+			expectedReception: openReception,
+		},
+		{
+			name:   "several open receptions require dock",
+			target: entity.ReceptionTarget{PVZID: pvzID.String()},
+			prepareRepo: func(receptionRepo *mocks.Reception) {
+				receptionRepo.On("LockLastOpenReception", mock.Anything, pvzID.String()).
+					Return(nil, repoerr.ErrMultipleRows)
+			},
+			expectedError: ErrDockRequired,
+		},
+		{
+			name:   "open reception of dock",
+			target: entity.ReceptionTarget{PVZID: pvzID.String(), DockID: dockID.String()},
+			prepareRepo: func(receptionRepo *mocks.Reception) {
+				receptionRepo.On("LockOpenReceptionByDock", mock.Anything, dockID.String()).Return(openReception, nil)
+			},
+			expectedReception: openReception,
+		},
+		{
+			name:   "dock without open reception",
+			target: entity.ReceptionTarget{DockID: dockID.String()},
+			prepareRepo: func(receptionRepo *mocks.Reception) {
+				receptionRepo.On("LockOpenReceptionByDock", mock.Anything, dockID.String()).
+					Return(nil, repoerr.ErrNoRows)
+			},
+			expectedError: ErrNoOpenReception,
+		},
+		{
+			name:   "dock of another pvz",
+			target: entity.ReceptionTarget{PVZID: uuid.New().String(), DockID: dockID.String()},
+			prepareRepo: func(receptionRepo *mocks.Reception) {
+				receptionRepo.On("LockOpenReceptionByDock", mock.Anything, dockID.String()).Return(openReception, nil)
+			},
+			expectedError: ErrNoOpenReception,
+		},
+		{
+			name:   "explicit reception",
+			target: entity.ReceptionTarget{ReceptionID: receptionID.String()},
+			prepareRepo: func(receptionRepo *mocks.Reception) {
+				receptionRepo.On("LockByID", mock.Anything, receptionID.String()).Return(openReception, nil)
+			},
+			expectedReception: openReception,
+		},
+		{
+			name:   "explicit reception not found",
+			target: entity.ReceptionTarget{ReceptionID: receptionID.String()},
+			prepareRepo: func(receptionRepo *mocks.Reception) {
+				receptionRepo.On("LockByID", mock.Anything, receptionID.String()).Return(nil, repoerr.ErrNoRows)
+			},
+			expectedError: ErrReceptionNotFound,
+		},
+		{
+			name:   "explicit reception is closed",
+			target: entity.ReceptionTarget{ReceptionID: receptionID.String()},
+			prepareRepo: func(receptionRepo *mocks.Reception) {
+				receptionRepo.On("LockByID", mock.Anything, receptionID.String()).
+					Return(&entity.Reception{ID: receptionID, PVZID: pvzID, Status: entity.StatusClose}, nil)
+			},
+			expectedError: ErrNoOpenReception,
+		},
+		{
+			name:   "explicit reception in another dock",
+			target: entity.ReceptionTarget{DockID: uuid.New().String(), ReceptionID: receptionID.String()},
+			prepareRepo: func(receptionRepo *mocks.Reception) {
+				receptionRepo.On("LockByID", mock.Anything, receptionID.String()).Return(openReception, nil)
+			},
+			expectedError: ErrNoOpenReception,
+		},
+		{
+			name:   "repo error",
+			target: entity.ReceptionTarget{DockID: dockID.String()},
+			prepareRepo: func(receptionRepo *mocks.Reception) {
+				receptionRepo.On("LockOpenReceptionByDock", mock.Anything, dockID.String()).
+					Return(nil, errors.New("database error"))
+			},
+			expectedError: ErrInternal,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			receptionRepo := mocks.NewReception(t)
+			tc.prepareRepo(receptionRepo)
+
+			reception, err := lockOpenReception(context.Background(), receptionRepo, slog.Default(), tc.target)
+
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+				assert.Nil(t, reception)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedReception, reception)
+		})
+	}
+}
@@ -0,0 +1,406 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/entity"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/metrics"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/repo"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/repo/repoerr"
+	"github.com/google/uuid"
+	"log/slog"
+)
+
+const maxTransferItems = 1000
+
+type TransferService struct {
+	transactor    repo.Transactor
+	transferRepo  repo.Transfer
+	productRepo   repo.Product
+	receptionRepo repo.Reception
+	pvzRepo       repo.PVZ
+	cellRepo      repo.StorageCell
+}
+
+func NewTransferService(transactor repo.Transactor, transferRepo repo.Transfer, productRepo repo.Product,
+	receptionRepo repo.Reception, pvzRepo repo.PVZ, cellRepo repo.StorageCell) *TransferService {
+	return &TransferService{
+		transactor:    transactor,
+		transferRepo:  transferRepo,
+		productRepo:   productRepo,
+		receptionRepo: receptionRepo,
+		pvzRepo:       pvzRepo,
+		cellRepo:      cellRepo,
+	}
+}
+
+// Create заводит заказ на перемещение товаров между ПВЗ. Товары должны храниться в ПВЗ отправления,
+// относиться к закрытым приёмкам и не входить в другие незавершённые перемещения.
+func (s *TransferService) Create(ctx context.Context, params entity.TransferParams) (*entity.Transfer, error) {
+	log := slog.With("layer", "TransferService", "operation", "Create", "fromPVZID", params.FromPVZID,
+		"toPVZID", params.ToPVZID, "items", len(params.ProductIDs), "userID", params.CreatedBy.String())
+	log.Debug("starting transfer creation")
+
+	fromPVZID, err := uuid.Parse(params.FromPVZID)
+	if err != nil {
+		return nil, ErrInvalidPVZID
+	}
+	toPVZID, err := uuid.Parse(params.ToPVZID)
+	if err != nil {
+		return nil, ErrInvalidPVZID
+	}
+	if fromPVZID == toPVZID || len(params.ProductIDs) == 0 || len(params.ProductIDs) > maxTransferItems {
+		return nil, ErrInvalidTransfer
+	}
+	productIDs := make([]uuid.UUID, len(params.ProductIDs))
+	seen := make(map[uuid.UUID]bool, len(params.ProductIDs))
+	for i, id := range params.ProductIDs {
+		productID, err := uuid.Parse(id)
+		if err != nil || seen[productID] {
+			return nil, ErrInvalidTransfer
+		}
+		seen[productID] = true
+		productIDs[i] = productID
+	}
+
+	if !s.pvzRepo.Exists(ctx, params.FromPVZID) || !s.pvzRepo.Exists(ctx, params.ToPVZID) {
+		log.Error("pvz does not exist")
+		return nil, ErrInvalidPVZID
+	}
+
+	var transfer *entity.Transfer
+	err = withinTx(ctx, s.transactor, log, func(ctx context.Context) error {
+		products, err := s.lockStoredProducts(ctx, log, fromPVZID, productIDs)
+		if err != nil {
+			return err
+		}
+		if err := s.checkReceived(ctx, log, products); err != nil {
+			return err
+		}
+
+		active, err := s.transferRepo.ListActiveProductIDs(ctx, productIDs)
+		if err != nil {
+			log.Error("failed to list products in active transfers", "error", err)
+			return ErrInternal
+		}
+		if len(active) > 0 {
+			log.Warn("product is already in an active transfer", "productID", active[0].String())
+			return ErrProductInTransfer
+		}
+
+		items := make([]entity.TransferItem, len(products))
+		for i, product := range products {
+			items[i] = entity.TransferItem{ProductID: product.ID, Type: product.Type, Barcode: product.Barcode}
+		}
+		transfer, err = s.transferRepo.Create(ctx, entity.Transfer{
+			FromPVZID: fromPVZID,
+			ToPVZID:   toPVZID,
+			CreatedBy: params.CreatedBy,
+			Items:     items,
+		})
+		if err != nil {
+			log.Error("failed to create transfer", "error", err)
+			return ErrInternal
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info("transfer created successfully", "transferID", transfer.ID.String())
+	return transfer, nil
+}
+
+// Get возвращает перемещение с товарами.
+func (s *TransferService) Get(ctx context.Context, transferID string) (*entity.Transfer, error) {
+	log := slog.With("layer", "TransferService", "operation", "Get", "transferID", transferID)
+	log.Debug("starting get transfer")
+
+	transfer, err := s.transferRepo.GetByID(ctx, transferID)
+	if err != nil {
+		if errors.Is(err, repoerr.ErrNoRows) {
+			return nil, ErrTransferNotFound
+		}
+		log.Error("failed to get transfer", "error", err)
+		return nil, ErrInternal
+	}
+	return transfer, nil
+}
+
+// Dispatch отправляет товары перемещения: они переходят в статус in_transit, а в ПВЗ назначения
+// заводится черновик приёмки с манифестом по отправленным товарам.
+func (s *TransferService) Dispatch(ctx context.Context, transferID string, userID uuid.UUID) (*entity.Transfer, error) {
+	log := slog.With("layer", "TransferService", "operation", "Dispatch", "transferID", transferID,
+		"userID", userID.String())
+	log.Debug("starting transfer dispatch")
+
+	var dispatched int
+	err := withinTx(ctx, s.transactor, log, func(ctx context.Context) error {
+		transfer, err := s.lockTransfer(ctx, log, transferID, entity.TransferStatusCreated)
+		if err != nil {
+			return err
+		}
+
+		productIDs := make([]uuid.UUID, len(transfer.Items))
+		for i, item := range transfer.Items {
+			productIDs[i] = item.ProductID
+		}
+		products, err := s.lockStoredProducts(ctx, log, transfer.FromPVZID, productIDs)
+		if err != nil {
+			return err
+		}
+
+		reception, err := s.receptionRepo.Create(ctx, entity.ReceptionParams{
+			PVZID:    transfer.ToPVZID.String(),
+			Kind:     entity.ReceptionKindTransfer,
+			Draft:    true,
+			Manifest: transferManifest(products),
+			OpenedBy: userID,
+		})
+		if err != nil {
+			log.Error("failed to create transfer reception", "error", err)
+			return ErrInternal
+		}
+
+		err = s.productRepo.Dispatch(ctx, productIDs, transfer.ID, userID)
+		if err != nil {
+			if errors.Is(err, repoerr.ErrNoRows) {
+				log.Warn("product status changed concurrently")
+				return ErrProductNotStored
+			}
+			log.Error("failed to dispatch products", "error", err)
+			return ErrInternal
+		}
+
+		err = s.transferRepo.SetDispatched(ctx, transfer.ID, reception.ID, userID)
+		if err != nil {
+			log.Error("failed to dispatch transfer", "error", err)
+			return ErrInternal
+		}
+		dispatched = len(productIDs)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	metrics.ProductsTransferred.Add(float64(dispatched))
+	log.Info("transfer dispatched successfully", "items", dispatched)
+	return s.Get(ctx, transferID)
+}
+
+// Cancel отменяет перемещение, пока товары не отправлены.
+func (s *TransferService) Cancel(ctx context.Context, transferID string, userID uuid.UUID) (*entity.Transfer, error) {
+	log := slog.With("layer", "TransferService", "operation", "Cancel", "transferID", transferID,
+		"userID", userID.String())
+	log.Debug("starting transfer cancellation")
+
+	err := withinTx(ctx, s.transactor, log, func(ctx context.Context) error {
+		transfer, err := s.lockTransfer(ctx, log, transferID, entity.TransferStatusCreated)
+		if err != nil {
+			return err
+		}
+		if err := s.transferRepo.SetStatus(ctx, transfer.ID, entity.TransferStatusCancelled); err != nil {
+			log.Error("failed to cancel transfer", "error", err)
+			return ErrInternal
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info("transfer cancelled successfully")
+	return s.Get(ctx, transferID)
+}
+
+// ReceiveItem принимает товар перемещения в приёмку ПВЗ назначения. Приёмка должна быть начата.
+// Товар заводится в приёмке новой строкой с новым кодом получения и ячейкой, а его строка в приёмке
+// отправления остаётся перемещённой. Когда приняты все товары, перемещение завершается.
+func (s *TransferService) ReceiveItem(ctx context.Context, transferID, productID string,
+	userID uuid.UUID) (*entity.Product, error) {
+	log := slog.With("layer", "TransferService", "operation", "ReceiveItem", "transferID", transferID,
+		"productID", productID, "userID", userID.String())
+	log.Debug("starting transfer item receipt")
+
+	var product *entity.Product
+	err := withinTx(ctx, s.transactor, log, func(ctx context.Context) error {
+		transfer, err := s.lockTransfer(ctx, log, transferID, entity.TransferStatusInTransit)
+		if err != nil {
+			return err
+		}
+
+		var (
+			item    *entity.TransferItem
+			pending int
+		)
+		for i := range transfer.Items {
+			if transfer.Items[i].ProductID.String() == productID {
+				item = &transfer.Items[i]
+			}
+			if transfer.Items[i].ReceivedAt == nil {
+				pending++
+			}
+		}
+		if item == nil {
+			log.Warn("product is not in the transfer")
+			return ErrProductNotFound
+		}
+		if item.ReceivedAt != nil {
+			log.Warn("transfer item already received")
+			return ErrTransferItemReceived
+		}
+
+		reception, err := s.receptionRepo.LockByID(ctx, transfer.ReceptionID.String())
+		if err != nil {
+			log.Error("failed to lock transfer reception", "error", err)
+			return ErrInternal
+		}
+		if reception.Status != entity.StatusInProgress {
+			log.Warn("transfer reception is not open", "status", reception.Status)
+			return ErrInvalidReceptionStatus
+		}
+
+		products := []entity.Product{{ID: item.ProductID, Type: item.Type, ReceptionID: reception.ID}}
+		if err := assignCells(ctx, s.cellRepo, log, reception.PVZID.String(), products); err != nil {
+			return err
+		}
+
+		product, err = s.productRepo.Receive(ctx, products[0], transfer.ID, userID)
+		if err != nil {
+			switch {
+			case errors.Is(err, repoerr.ErrNoRows):
+				log.Warn("product is not in transit")
+				return ErrProductNotFound
+			case errors.Is(err, repoerr.ErrDuplicateEntry):
+				log.Warn("product with the same barcode is stored")
+				return ErrBarcodeExists
+			}
+			log.Error("failed to receive product", "error", err)
+			return ErrInternal
+		}
+
+		if err := s.transferRepo.ReceiveItem(ctx, transfer.ID, item.ProductID, userID); err != nil {
+			log.Error("failed to receive transfer item", "error", err)
+			return ErrInternal
+		}
+		if pending == 1 {
+			if err := s.transferRepo.SetStatus(ctx, transfer.ID, entity.TransferStatusReceived); err != nil {
+				log.Error("failed to complete transfer", "error", err)
+				return ErrInternal
+			}
+			log.Info("all transfer items received")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info("transfer item received successfully", "receptionID", product.ReceptionID.String())
+	return product, nil
+}
+
+// lockTransfer блокирует перемещение и проверяет, что оно в статусе status.
+func (s *TransferService) lockTransfer(ctx context.Context, log *slog.Logger, transferID,
+	status string) (*entity.Transfer, error) {
+	transfer, err := s.transferRepo.LockByID(ctx, transferID)
+	if err != nil {
+		if errors.Is(err, repoerr.ErrNoRows) {
+			return nil, ErrTransferNotFound
+		}
+		log.Error("failed to lock transfer", "error", err)
+		return nil, ErrInternal
+	}
+	if transfer.Status != status {
+		log.Warn("unexpected transfer status", "status", transfer.Status)
+		return nil, ErrInvalidTransferStatus
+	}
+	return transfer, nil
+}
+
+// lockStoredProducts блокирует товары и проверяет, что все они хранятся в ПВЗ pvzID.
+func (s *TransferService) lockStoredProducts(ctx context.Context, log *slog.Logger, pvzID uuid.UUID,
+	productIDs []uuid.UUID) ([]entity.Product, error) {
+	products, err := s.productRepo.LockByIDs(ctx, productIDs)
+	if err != nil {
+		log.Error("failed to lock products", "error", err)
+		return nil, ErrInternal
+	}
+	if len(products) != len(productIDs) {
+		log.Warn("some products not found", "found", len(products))
+		return nil, ErrProductNotFound
+	}
+	for _, product := range products {
+		if product.DeletedAt != nil || product.PVZID != pvzID {
+			log.Warn("product is not in the pvz", "productID", product.ID.String())
+			return nil, ErrProductNotFound
+		}
+		if product.Status != entity.ProductStatusStored {
+			log.Warn("product is not stored", "productID", product.ID.String(), "status", product.Status)
+			return nil, ErrProductNotStored
+		}
+	}
+	return products, nil
+}
+
+// checkReceived проверяет, что приёмки товаров закрыты: товары из открытой приёмки ещё не приняты.
+func (s *TransferService) checkReceived(ctx context.Context, log *slog.Logger, products []entity.Product) error {
+	checked := make(map[uuid.UUID]bool)
+	for _, product := range products {
+		if checked[product.ReceptionID] {
+			continue
+		}
+		checked[product.ReceptionID] = true
+
+		reception, err := s.receptionRepo.GetByID(ctx, product.ReceptionID.String())
+		if err != nil {
+			log.Error("failed to get reception", "error", err)
+			return ErrInternal
+		}
+		if reception.Status != entity.StatusClose && reception.Status != entity.StatusVerified {
+			log.Warn("reception is not closed", "receptionID", reception.ID.String(), "status", reception.Status)
+			return ErrProductNotReceived
+		}
+	}
+	return nil
+}
+
+// transferManifest считает отправляемые товары по типам для манифеста приёмки в ПВЗ назначения.
+func transferManifest(products []entity.Product) []entity.ManifestItem {
+	var manifest []entity.ManifestItem
+	index := make(map[string]int)
+	for _, product := range products {
+		i, ok := index[product.Type]
+		if !ok {
+			i = len(manifest)
+			index[product.Type] = i
+			manifest = append(manifest, entity.ManifestItem{ProductType: product.Type})
+		}
+		manifest[i].ExpectedCount++
+	}
+	return manifest
+}
+
+// ListLocations возвращает историю местонахождения товара от первой приёмки до текущего ПВЗ.
+func (s *ProductService) ListLocations(ctx context.Context, productID string) ([]entity.ProductLocation, error) {
+	log := slog.With("layer", "ProductService", "operation", "ListLocations", "productID", productID)
+	log.Debug("starting list product locations")
+
+	if _, err := s.productRepo.GetByID(ctx, productID); err != nil {
+		if errors.Is(err, repoerr.ErrNoRows) {
+			log.Warn("product not found")
+			return nil, ErrProductNotFound
+		}
+		log.Error("failed to get product", "error", err)
+		return nil, ErrInternal
+	}
+
+	locations, err := s.productRepo.ListLocations(ctx, productID)
+	if err != nil {
+		log.Error("failed to list product locations", "error", err)
+		return nil, ErrInternal
+	}
+	return locations, nil
+}
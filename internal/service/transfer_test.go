@@ -0,0 +1,305 @@
+package service
+
+import (
+	"context"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/entity"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/repo/mocks"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/repo/repoerr"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+	"time"
+)
+
+func TestTransferService_Create(t *testing.T) {
+	fromPVZID := uuid.New()
+	toPVZID := uuid.New()
+	receptionID := uuid.New()
+	productID := uuid.New()
+	userID := uuid.New()
+	stored := entity.Product{ID: productID, Type: entity.ProductTypeShoes, Barcode: "4600000000011",
+		ReceptionID: receptionID, PVZID: fromPVZID, Status: entity.ProductStatusStored}
+	params := entity.TransferParams{
+		FromPVZID:  fromPVZID.String(),
+		ToPVZID:    toPVZID.String(),
+		ProductIDs: []string{productID.String()},
+		CreatedBy:  userID,
+	}
+
+	testCases := []struct {
+		name             string
+		params           entity.TransferParams
+		product          entity.Product
+		receptionStatus  string
+		activeProductIDs []uuid.UUID
+		expectedError    error
+	}{
+		{name: "successful creation", params: params, product: stored, receptionStatus: entity.StatusClose},
+		{name: "same pvz", params: entity.TransferParams{FromPVZID: fromPVZID.String(), ToPVZID: fromPVZID.String(),
+			ProductIDs: params.ProductIDs}, expectedError: ErrInvalidTransfer},
+		{name: "no products", params: entity.TransferParams{FromPVZID: fromPVZID.String(), ToPVZID: toPVZID.String()},
+			expectedError: ErrInvalidTransfer},
+		{name: "duplicate products", params: entity.TransferParams{FromPVZID: fromPVZID.String(),
+			ToPVZID: toPVZID.String(), ProductIDs: []string{productID.String(), productID.String()}},
+			expectedError: ErrInvalidTransfer},
+		{name: "invalid pvz id", params: entity.TransferParams{FromPVZID: "bad", ToPVZID: toPVZID.String(),
+			ProductIDs: params.ProductIDs}, expectedError: ErrInvalidPVZID},
+		{name: "product in another pvz", params: params, product: entity.Product{ID: productID,
+			ReceptionID: receptionID, PVZID: toPVZID, Status: entity.ProductStatusStored},
+			expectedError: ErrProductNotFound},
+		{name: "product issued", params: params, product: entity.Product{ID: productID, ReceptionID: receptionID,
+			PVZID: fromPVZID, Status: entity.ProductStatusIssued}, expectedError: ErrProductNotStored},
+		{name: "reception still open", params: params, product: stored, receptionStatus: entity.StatusInProgress,
+			expectedError: ErrProductNotReceived},
+		{name: "product in active transfer", params: params, product: stored, receptionStatus: entity.StatusVerified,
+			activeProductIDs: []uuid.UUID{productID}, expectedError: ErrProductInTransfer},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pvzRepo := mocks.NewPVZ(t)
+			pvzRepo.On("Exists", mock.Anything, mock.Anything).Return(true).Maybe()
+			productRepo := mocks.NewProduct(t)
+			productRepo.On("LockByIDs", mock.Anything, []uuid.UUID{productID}).
+				Return([]entity.Product{tc.product}, nil).Maybe()
+			receptionRepo := mocks.NewReception(t)
+			receptionRepo.On("GetByID", mock.Anything, receptionID.String()).
+				Return(&entity.Reception{ID: receptionID, Status: tc.receptionStatus}, nil).Maybe()
+			transferRepo := mocks.NewTransfer(t)
+			transferRepo.On("ListActiveProductIDs", mock.Anything, []uuid.UUID{productID}).
+				Return(tc.activeProductIDs, nil).Maybe()
+			transferRepo.On("Create", mock.Anything, mock.AnythingOfType("entity.Transfer")).
+				Return(func(ctx context.Context, transfer entity.Transfer) (*entity.Transfer, error) {
+					transfer.ID = uuid.New()
+					transfer.Status = entity.TransferStatusCreated
+					return &transfer, nil
+				}).Maybe()
+
+			service := NewTransferService(newPassthroughTransactor(t), transferRepo, productRepo, receptionRepo,
+				pvzRepo, newEmptyCellRepo(t))
+
+			transfer, err := service.Create(context.Background(), tc.params)
+			assert.ErrorIs(t, err, tc.expectedError)
+			if tc.expectedError == nil {
+				assert.Equal(t, fromPVZID, transfer.FromPVZID)
+				assert.Equal(t, toPVZID, transfer.ToPVZID)
+				assert.Equal(t, userID, transfer.CreatedBy)
+				assert.Equal(t, []entity.TransferItem{
+					{ProductID: productID, Type: entity.ProductTypeShoes, Barcode: "4600000000011"},
+				}, transfer.Items)
+			}
+		})
+	}
+}
+
+func TestTransferService_Dispatch(t *testing.T) {
+	transferID := uuid.New()
+	fromPVZID := uuid.New()
+	toPVZID := uuid.New()
+	receptionID := uuid.New()
+	userID := uuid.New()
+	shoesID := uuid.New()
+	clothesID := uuid.New()
+	products := []entity.Product{
+		{ID: shoesID, Type: entity.ProductTypeShoes, PVZID: fromPVZID, Status: entity.ProductStatusStored},
+		{ID: clothesID, Type: entity.ProductTypeClothes, PVZID: fromPVZID, Status: entity.ProductStatusStored},
+	}
+
+	newTransferRepo := func(t *testing.T, status string) *mocks.Transfer {
+		transferRepo := mocks.NewTransfer(t)
+		transferRepo.On("LockByID", mock.Anything, transferID.String()).Return(&entity.Transfer{
+			ID:        transferID,
+			FromPVZID: fromPVZID,
+			ToPVZID:   toPVZID,
+			Status:    status,
+			Items:     []entity.TransferItem{{ProductID: shoesID}, {ProductID: clothesID}},
+		}, nil)
+		return transferRepo
+	}
+
+	t.Run("creates draft reception and moves products", func(t *testing.T) {
+		transferRepo := newTransferRepo(t, entity.TransferStatusCreated)
+		transferRepo.On("SetDispatched", mock.Anything, transferID, receptionID, userID).Return(nil)
+		transferRepo.On("GetByID", mock.Anything, transferID.String()).
+			Return(&entity.Transfer{ID: transferID, Status: entity.TransferStatusInTransit, ReceptionID: receptionID}, nil)
+		productRepo := mocks.NewProduct(t)
+		productRepo.On("LockByIDs", mock.Anything, []uuid.UUID{shoesID, clothesID}).Return(products, nil)
+		productRepo.On("Dispatch", mock.Anything, []uuid.UUID{shoesID, clothesID}, transferID, userID).Return(nil)
+		receptionRepo := mocks.NewReception(t)
+		receptionRepo.On("Create", mock.Anything, entity.ReceptionParams{
+			PVZID: toPVZID.String(),
+			Kind:  entity.ReceptionKindTransfer,
+			Draft: true,
+			Manifest: []entity.ManifestItem{
+				{ProductType: entity.ProductTypeShoes, ExpectedCount: 1},
+				{ProductType: entity.ProductTypeClothes, ExpectedCount: 1},
+			},
+			OpenedBy: userID,
+		}).Return(&entity.Reception{ID: receptionID, PVZID: toPVZID, Status: entity.StatusDraft}, nil)
+
+		service := NewTransferService(newPassthroughTransactor(t), transferRepo, productRepo, receptionRepo,
+			mocks.NewPVZ(t), newEmptyCellRepo(t))
+
+		transfer, err := service.Dispatch(context.Background(), transferID.String(), userID)
+		assert.NoError(t, err)
+		assert.Equal(t, entity.TransferStatusInTransit, transfer.Status)
+		assert.Equal(t, receptionID, transfer.ReceptionID)
+	})
+
+	t.Run("already dispatched", func(t *testing.T) {
+		service := NewTransferService(newPassthroughTransactor(t), newTransferRepo(t, entity.TransferStatusInTransit),
+			mocks.NewProduct(t), mocks.NewReception(t), mocks.NewPVZ(t), newEmptyCellRepo(t))
+
+		_, err := service.Dispatch(context.Background(), transferID.String(), userID)
+		assert.ErrorIs(t, err, ErrInvalidTransferStatus)
+	})
+
+	t.Run("product issued after creation", func(t *testing.T) {
+		issued := []entity.Product{products[0], products[1]}
+		issued[1].Status = entity.ProductStatusIssued
+		productRepo := mocks.NewProduct(t)
+		productRepo.On("LockByIDs", mock.Anything, []uuid.UUID{shoesID, clothesID}).Return(issued, nil)
+
+		service := NewTransferService(newPassthroughTransactor(t), newTransferRepo(t, entity.TransferStatusCreated),
+			productRepo, mocks.NewReception(t), mocks.NewPVZ(t), newEmptyCellRepo(t))
+
+		_, err := service.Dispatch(context.Background(), transferID.String(), userID)
+		assert.ErrorIs(t, err, ErrProductNotStored)
+	})
+
+	t.Run("transfer not found", func(t *testing.T) {
+		transferRepo := mocks.NewTransfer(t)
+		transferRepo.On("LockByID", mock.Anything, transferID.String()).Return(nil, repoerr.ErrNoRows)
+
+		service := NewTransferService(newPassthroughTransactor(t), transferRepo, mocks.NewProduct(t),
+			mocks.NewReception(t), mocks.NewPVZ(t), newEmptyCellRepo(t))
+
+		_, err := service.Dispatch(context.Background(), transferID.String(), userID)
+		assert.ErrorIs(t, err, ErrTransferNotFound)
+	})
+}
+
+func TestTransferService_ReceiveItem(t *testing.T) {
+	transferID := uuid.New()
+	toPVZID := uuid.New()
+	receptionID := uuid.New()
+	userID := uuid.New()
+	firstID := uuid.New()
+	secondID := uuid.New()
+	receivedAt := time.Date(2026, 6, 2, 9, 0, 0, 0, time.UTC)
+
+	newTransferRepo := func(t *testing.T, items []entity.TransferItem) *mocks.Transfer {
+		transferRepo := mocks.NewTransfer(t)
+		transferRepo.On("LockByID", mock.Anything, transferID.String()).Return(&entity.Transfer{
+			ID:          transferID,
+			ToPVZID:     toPVZID,
+			Status:      entity.TransferStatusInTransit,
+			ReceptionID: receptionID,
+			Items:       items,
+		}, nil)
+		return transferRepo
+	}
+	newReceptionRepo := func(t *testing.T, status string) *mocks.Reception {
+		receptionRepo := mocks.NewReception(t)
+		receptionRepo.On("LockByID", mock.Anything, receptionID.String()).
+			Return(&entity.Reception{ID: receptionID, PVZID: toPVZID, Status: status}, nil)
+		return receptionRepo
+	}
+	newProductRepo := func(t *testing.T, productID uuid.UUID) *mocks.Product {
+		productRepo := mocks.NewProduct(t)
+		productRepo.On("Receive", mock.Anything, entity.Product{ID: productID, Type: entity.ProductTypeShoes,
+			ReceptionID: receptionID}, transferID, userID).
+			Return(&entity.Product{ID: uuid.New(), ReceptionID: receptionID, PVZID: toPVZID,
+				Status: entity.ProductStatusStored, SourceProductID: productID}, nil)
+		return productRepo
+	}
+
+	t.Run("last item completes transfer", func(t *testing.T) {
+		transferRepo := newTransferRepo(t, []entity.TransferItem{
+			{ProductID: firstID, Type: entity.ProductTypeShoes, ReceivedAt: &receivedAt},
+			{ProductID: secondID, Type: entity.ProductTypeShoes},
+		})
+		transferRepo.On("ReceiveItem", mock.Anything, transferID, secondID, userID).Return(nil)
+		transferRepo.On("SetStatus", mock.Anything, transferID, entity.TransferStatusReceived).Return(nil)
+
+		service := NewTransferService(newPassthroughTransactor(t), transferRepo, newProductRepo(t, secondID),
+			newReceptionRepo(t, entity.StatusInProgress), mocks.NewPVZ(t), newEmptyCellRepo(t))
+
+		product, err := service.ReceiveItem(context.Background(), transferID.String(), secondID.String(), userID)
+		assert.NoError(t, err)
+		assert.Equal(t, toPVZID, product.PVZID)
+		assert.Equal(t, entity.ProductStatusStored, product.Status)
+		assert.Equal(t, secondID, product.SourceProductID)
+	})
+
+	t.Run("pending items keep transfer in transit", func(t *testing.T) {
+		transferRepo := newTransferRepo(t, []entity.TransferItem{
+			{ProductID: firstID, Type: entity.ProductTypeShoes},
+			{ProductID: secondID, Type: entity.ProductTypeShoes},
+		})
+		transferRepo.On("ReceiveItem", mock.Anything, transferID, firstID, userID).Return(nil)
+
+		service := NewTransferService(newPassthroughTransactor(t), transferRepo, newProductRepo(t, firstID),
+			newReceptionRepo(t, entity.StatusInProgress), mocks.NewPVZ(t), newEmptyCellRepo(t))
+
+		_, err := service.ReceiveItem(context.Background(), transferID.String(), firstID.String(), userID)
+		assert.NoError(t, err)
+	})
+
+	t.Run("item already received", func(t *testing.T) {
+		transferRepo := newTransferRepo(t, []entity.TransferItem{
+			{ProductID: firstID, Type: entity.ProductTypeShoes, ReceivedAt: &receivedAt},
+		})
+
+		service := NewTransferService(newPassthroughTransactor(t), transferRepo, mocks.NewProduct(t),
+			mocks.NewReception(t), mocks.NewPVZ(t), newEmptyCellRepo(t))
+
+		_, err := service.ReceiveItem(context.Background(), transferID.String(), firstID.String(), userID)
+		assert.ErrorIs(t, err, ErrTransferItemReceived)
+	})
+
+	t.Run("product not in transfer", func(t *testing.T) {
+		transferRepo := newTransferRepo(t, []entity.TransferItem{{ProductID: firstID}})
+
+		service := NewTransferService(newPassthroughTransactor(t), transferRepo, mocks.NewProduct(t),
+			mocks.NewReception(t), mocks.NewPVZ(t), newEmptyCellRepo(t))
+
+		_, err := service.ReceiveItem(context.Background(), transferID.String(), secondID.String(), userID)
+		assert.ErrorIs(t, err, ErrProductNotFound)
+	})
+
+	t.Run("reception not started", func(t *testing.T) {
+		transferRepo := newTransferRepo(t, []entity.TransferItem{{ProductID: firstID}})
+
+		service := NewTransferService(newPassthroughTransactor(t), transferRepo, mocks.NewProduct(t),
+			newReceptionRepo(t, entity.StatusDraft), mocks.NewPVZ(t), newEmptyCellRepo(t))
+
+		_, err := service.ReceiveItem(context.Background(), transferID.String(), firstID.String(), userID)
+		assert.ErrorIs(t, err, ErrInvalidReceptionStatus)
+	})
+}
+
+func TestProductService_TransferReceptionGuard(t *testing.T) {
+	pvzID := uuid.New()
+	receptionID := uuid.New()
+
+	pvzRepo := mocks.NewPVZ(t)
+	pvzRepo.On("Exists", mock.Anything, pvzID.String()).Return(true)
+	receptionRepo := mocks.NewReception(t)
+	receptionRepo.On("LockLastOpenReception", mock.Anything, pvzID.String()).Return(&entity.Reception{
+		ID: receptionID, PVZID: pvzID, Kind: entity.ReceptionKindTransfer, Status: entity.StatusInProgress}, nil)
+
+	service := NewProductService(newPassthroughTransactor(t), mocks.NewProduct(t), receptionRepo, pvzRepo,
+		newCatalogTypeRepo(t), newEmptyCellRepo(t))
+
+	target := entity.ReceptionTarget{PVZID: pvzID.String()}
+	_, err := service.Create(context.Background(), entity.ProductParams{
+		ReceptionTarget: target,
+		Type:            entity.ProductTypeClothes,
+	})
+	assert.ErrorIs(t, err, ErrTransferReception)
+
+	err = service.DeleteLastProduct(context.Background(), target, uuid.New())
+	assert.ErrorIs(t, err, ErrTransferReception)
+}
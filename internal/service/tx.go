@@ -0,0 +1,25 @@
+package service
+
+import (
+	"context"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/repo"
+	"log/slog"
+)
+
+// withinTx выполняет fn в транзакции. Ошибки сервиса из fn возвращаются без изменений,
+// а сбои самой транзакции (begin/commit) сводятся к ErrInternal.
+func withinTx(ctx context.Context, transactor repo.Transactor, log *slog.Logger, fn func(ctx context.Context) error) error {
+	var fnErr error
+	err := transactor.WithinTx(ctx, func(ctx context.Context) error {
+		fnErr = fn(ctx)
+		return fnErr
+	})
+	if fnErr != nil {
+		return fnErr
+	}
+	if err != nil {
+		log.Error("transaction failed", "error", err)
+		return ErrInternal
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/repo/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"log/slog"
+	"testing"
+)
+
+// newPassthroughTransactor возвращает мок Transactor, который просто вызывает fn.
+func newPassthroughTransactor(t *testing.T) *mocks.Transactor {
+	transactor := mocks.NewTransactor(t)
+	transactor.On("WithinTx", mock.Anything, mock.Anything).
+		Return(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		}).Maybe()
+	return transactor
+}
+
+func TestWithinTx(t *testing.T) {
+	testCases := []struct {
+		name          string
+		fnErr         error
+		txErr         error
+		expectedError error
+	}{
+		{
+			name: "success",
+		},
+		{
+			name:          "service error is returned as is",
+			fnErr:         ErrNoOpenReception,
+			txErr:         ErrNoOpenReception,
+			expectedError: ErrNoOpenReception,
+		},
+		{
+			name:          "commit failure",
+			txErr:         errors.New("commit failed"),
+			expectedError: ErrInternal,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			transactor := mocks.NewTransactor(t)
+			transactor.On("WithinTx", mock.Anything, mock.Anything).
+				Return(func(ctx context.Context, fn func(ctx context.Context) error) error {
+					_ = fn(ctx)
+					return tc.txErr
+				})
+
+			err := withinTx(context.Background(), transactor, slog.Default(), func(ctx context.Context) error {
+				return tc.fnErr
+			})
+
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
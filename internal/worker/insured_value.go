@@ -0,0 +1,33 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"github.com/GlebMoskalev/go-pickup-point-api/config"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/service"
+)
+
+// InsuredValue периодически сверяет объявленную ценность хранящихся в ПВЗ товаров с лимитом
+// застрахованной ценности.
+type InsuredValue struct {
+	pvzService service.PVZ
+	cfg        config.InsuredValue
+}
+
+func NewInsuredValue(pvzService service.PVZ, cfg config.InsuredValue) (*InsuredValue, error) {
+	if cfg.Ceiling < 0 || cfg.Interval <= 0 {
+		return nil, fmt.Errorf("insured value ceiling must not be negative and interval must be positive")
+	}
+	if len(cfg.Currency) != 3 {
+		return nil, fmt.Errorf("insured value currency must be a three-letter ISO 4217 code")
+	}
+	return &InsuredValue{pvzService: pvzService, cfg: cfg}, nil
+}
+
+// Run сверяет ценность товаров с лимитом сразу и затем каждые Interval, пока не будет отменён ctx.
+func (w *InsuredValue) Run(ctx context.Context) {
+	RunPeriodic(ctx, w.cfg.Interval, "InsuredValue", func(ctx context.Context) error {
+		_, err := w.pvzService.CheckInsuredValue(ctx)
+		return err
+	})
+}
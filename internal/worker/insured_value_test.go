@@ -0,0 +1,55 @@
+package worker
+
+import (
+	"context"
+	"github.com/GlebMoskalev/go-pickup-point-api/config"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/entity"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+	"time"
+)
+
+func TestNewInsuredValue(t *testing.T) {
+	testCases := []struct {
+		name        string
+		cfg         config.InsuredValue
+		expectError bool
+	}{
+		{name: "valid config", cfg: config.InsuredValue{Ceiling: 500_000_000, Currency: "RUB", Interval: time.Minute}},
+		{name: "ceiling disabled", cfg: config.InsuredValue{Currency: "RUB", Interval: time.Minute}},
+		{name: "negative ceiling", cfg: config.InsuredValue{Ceiling: -1, Currency: "RUB", Interval: time.Minute},
+			expectError: true},
+		{name: "zero interval", cfg: config.InsuredValue{Ceiling: 500_000_000, Currency: "RUB"}, expectError: true},
+		{name: "invalid currency", cfg: config.InsuredValue{Ceiling: 500_000_000, Currency: "рубль",
+			Interval: time.Minute}, expectError: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			w, err := NewInsuredValue(mocks.NewPVZ(t), tc.cfg)
+			if tc.expectError {
+				assert.Error(t, err)
+				assert.Nil(t, w)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, w)
+			}
+		})
+	}
+}
+
+func TestInsuredValueRun(t *testing.T) {
+	cfg := config.InsuredValue{Ceiling: 500_000_000, Currency: "RUB", Interval: time.Hour}
+
+	pvzService := mocks.NewPVZ(t)
+	pvzService.On("CheckInsuredValue", mock.Anything).Return([]entity.PVZValue{}, nil).Once()
+
+	w, err := NewInsuredValue(pvzService, cfg)
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	w.Run(ctx)
+}
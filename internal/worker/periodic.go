@@ -0,0 +1,30 @@
+package worker
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// RunPeriodic выполняет fn сразу и затем каждые interval, пока не будет отменён ctx. Ошибка fn
+// записывается в журнал и не прерывает следующие запуски.
+func RunPeriodic(ctx context.Context, interval time.Duration, name string, fn func(ctx context.Context) error) {
+	log := slog.With("layer", "worker", "worker", name)
+	log.Info("worker started", "interval", interval)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := fn(ctx); err != nil {
+			log.Error("worker run failed", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			log.Info("worker stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
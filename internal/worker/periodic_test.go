@@ -0,0 +1,57 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestRunPeriodic(t *testing.T) {
+	calls := make(chan struct{}, 10)
+	runs := 0
+	fn := func(ctx context.Context) error {
+		runs++
+		calls <- struct{}{}
+		if runs == 1 {
+			return errors.New("database error")
+		}
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		RunPeriodic(ctx, 10*time.Millisecond, "test", fn)
+	}()
+
+	for i := 0; i < 3; i++ {
+		select {
+		case <-calls:
+		case <-time.After(time.Second):
+			t.Fatal("worker did not run in time")
+		}
+	}
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("worker did not stop after context cancellation")
+	}
+}
+
+func TestRunPeriodicRunsOnceBeforeStopping(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	runs := 0
+	RunPeriodic(ctx, time.Hour, "test", func(ctx context.Context) error {
+		runs++
+		return nil
+	})
+
+	assert.Equal(t, 1, runs)
+}
@@ -0,0 +1,34 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"github.com/GlebMoskalev/go-pickup-point-api/config"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/entity"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/service"
+)
+
+// StaleReceptions периодически ищет приёмки, которые забыли закрыть, и обрабатывает их
+// согласно политике из конфигурации.
+type StaleReceptions struct {
+	receptionService service.Reception
+	cfg              config.StaleReceptions
+}
+
+func NewStaleReceptions(receptionService service.Reception, cfg config.StaleReceptions) (*StaleReceptions, error) {
+	if cfg.Policy != entity.StalePolicyClose && cfg.Policy != entity.StalePolicyFlag {
+		return nil, fmt.Errorf("unknown stale receptions policy %q", cfg.Policy)
+	}
+	if cfg.MaxAge <= 0 || cfg.Interval <= 0 {
+		return nil, fmt.Errorf("stale receptions max_age and interval must be positive")
+	}
+	return &StaleReceptions{receptionService: receptionService, cfg: cfg}, nil
+}
+
+// Run обрабатывает забытые приёмки сразу и затем каждые Interval, пока не будет отменён ctx.
+func (w *StaleReceptions) Run(ctx context.Context) {
+	RunPeriodic(ctx, w.cfg.Interval, "StaleReceptions", func(ctx context.Context) error {
+		_, err := w.receptionService.ProcessStaleReceptions(ctx, w.cfg.MaxAge, w.cfg.Policy)
+		return err
+	})
+}
@@ -0,0 +1,63 @@
+package worker
+
+import (
+	"context"
+	"github.com/GlebMoskalev/go-pickup-point-api/config"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/entity"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+	"time"
+)
+
+func TestNewStaleReceptions(t *testing.T) {
+	testCases := []struct {
+		name        string
+		cfg         config.StaleReceptions
+		expectError bool
+	}{
+		{
+			name: "valid config",
+			cfg:  config.StaleReceptions{MaxAge: time.Hour, Interval: time.Minute, Policy: entity.StalePolicyClose},
+		},
+		{
+			name:        "unknown policy",
+			cfg:         config.StaleReceptions{MaxAge: time.Hour, Interval: time.Minute, Policy: "delete"},
+			expectError: true,
+		},
+		{
+			name:        "zero interval",
+			cfg:         config.StaleReceptions{MaxAge: time.Hour, Policy: entity.StalePolicyFlag},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			w, err := NewStaleReceptions(mocks.NewReception(t), tc.cfg)
+			if tc.expectError {
+				assert.Error(t, err)
+				assert.Nil(t, w)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, w)
+			}
+		})
+	}
+}
+
+func TestStaleReceptionsRun(t *testing.T) {
+	cfg := config.StaleReceptions{MaxAge: time.Hour, Interval: time.Hour, Policy: entity.StalePolicyFlag}
+
+	receptionService := mocks.NewReception(t)
+	receptionService.On("ProcessStaleReceptions", mock.Anything, time.Hour, entity.StalePolicyFlag).
+		Return(0, nil).Once()
+
+	w, err := NewStaleReceptions(receptionService, cfg)
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	w.Run(ctx)
+}
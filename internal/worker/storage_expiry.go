@@ -0,0 +1,30 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"github.com/GlebMoskalev/go-pickup-point-api/config"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/service"
+)
+
+// StorageExpiry периодически помещает хранящиеся товары с истёкшим сроком хранения в очередь
+// на возврат отправителю.
+type StorageExpiry struct {
+	productService service.Product
+	cfg            config.StorageExpiry
+}
+
+func NewStorageExpiry(productService service.Product, cfg config.StorageExpiry) (*StorageExpiry, error) {
+	if cfg.DefaultDays <= 0 || cfg.Interval <= 0 {
+		return nil, fmt.Errorf("storage expiry default_days and interval must be positive")
+	}
+	return &StorageExpiry{productService: productService, cfg: cfg}, nil
+}
+
+// Run помечает просроченные товары сразу и затем каждые Interval, пока не будет отменён ctx.
+func (w *StorageExpiry) Run(ctx context.Context) {
+	RunPeriodic(ctx, w.cfg.Interval, "StorageExpiry", func(ctx context.Context) error {
+		_, err := w.productService.FlagOverdue(ctx, w.cfg.DefaultDays)
+		return err
+	})
+}
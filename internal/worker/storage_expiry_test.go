@@ -0,0 +1,48 @@
+package worker
+
+import (
+	"context"
+	"github.com/GlebMoskalev/go-pickup-point-api/config"
+	"github.com/GlebMoskalev/go-pickup-point-api/internal/service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+	"time"
+)
+
+func TestNewStorageExpiry(t *testing.T) {
+	testCases := []struct {
+		name        string
+		cfg         config.StorageExpiry
+		expectError bool
+	}{
+		{name: "valid config", cfg: config.StorageExpiry{DefaultDays: 7, Interval: time.Hour}},
+		{name: "zero default period", cfg: config.StorageExpiry{Interval: time.Hour}, expectError: true},
+		{name: "zero interval", cfg: config.StorageExpiry{DefaultDays: 7}, expectError: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			w, err := NewStorageExpiry(mocks.NewProduct(t), tc.cfg)
+			if tc.expectError {
+				assert.Error(t, err)
+				assert.Nil(t, w)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, w)
+			}
+		})
+	}
+}
+
+func TestStorageExpiryRun(t *testing.T) {
+	productService := mocks.NewProduct(t)
+	productService.On("FlagOverdue", mock.Anything, 7).Return(3, nil).Once()
+
+	w, err := NewStorageExpiry(productService, config.StorageExpiry{DefaultDays: 7, Interval: time.Hour})
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	w.Run(ctx)
+}
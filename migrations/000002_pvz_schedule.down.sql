@@ -0,0 +1,5 @@
+DROP TABLE pvz_holidays;
+DROP TABLE pvz_working_hours;
+ALTER TABLE pvz
+    DROP COLUMN enforce_working_hours,
+    DROP COLUMN timezone;
@@ -0,0 +1,21 @@
+ALTER TABLE pvz
+    ADD COLUMN timezone VARCHAR(64) NOT NULL DEFAULT 'Europe/Moscow',
+    ADD COLUMN enforce_working_hours BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE TABLE pvz_working_hours(
+    pvz_id UUID NOT NULL REFERENCES pvz(id) ON DELETE CASCADE,
+    weekday SMALLINT NOT NULL CHECK (weekday BETWEEN 0 AND 6),
+    open_time TIME NOT NULL,
+    close_time TIME NOT NULL CHECK (close_time > open_time),
+    PRIMARY KEY (pvz_id, weekday)
+);
+
+CREATE TABLE pvz_holidays(
+    pvz_id UUID NOT NULL REFERENCES pvz(id) ON DELETE CASCADE,
+    date DATE NOT NULL,
+    open_time TIME,
+    close_time TIME,
+    comment VARCHAR(255) NOT NULL DEFAULT '',
+    PRIMARY KEY (pvz_id, date),
+    CHECK ((open_time IS NULL AND close_time IS NULL) OR close_time > open_time)
+);
@@ -0,0 +1,9 @@
+DROP INDEX receptions_single_open_per_pvz;
+
+UPDATE receptions r
+SET status = 'in_progress'
+FROM receptions_closed_by_migration closed
+WHERE r.id = closed.reception_id
+  AND r.status = 'close';
+
+DROP TABLE receptions_closed_by_migration;
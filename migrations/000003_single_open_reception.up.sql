@@ -0,0 +1,33 @@
+-- Приёмки, закрытые этой миграцией, сохраняются для аудита и отката.
+CREATE TABLE receptions_closed_by_migration (
+    reception_id UUID PRIMARY KEY REFERENCES receptions(id) ON DELETE CASCADE,
+    pvz_id UUID NOT NULL,
+    opened_at TIMESTAMPTZ NOT NULL,
+    closed_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+WITH duplicates AS (
+    SELECT r.id, r.pvz_id, r.date_time
+    FROM receptions r
+    WHERE r.status = 'in_progress'
+      AND EXISTS (
+          SELECT 1
+          FROM receptions newer
+          WHERE newer.pvz_id = r.pvz_id
+            AND newer.status = 'in_progress'
+            AND (newer.date_time, newer.id) > (r.date_time, r.id)
+      )
+), logged AS (
+    INSERT INTO receptions_closed_by_migration (reception_id, pvz_id, opened_at)
+    SELECT id, pvz_id, date_time
+    FROM duplicates
+    RETURNING reception_id
+)
+UPDATE receptions r
+SET status = 'close'
+FROM logged
+WHERE r.id = logged.reception_id;
+
+CREATE UNIQUE INDEX receptions_single_open_per_pvz
+    ON receptions (pvz_id)
+    WHERE status = 'in_progress';
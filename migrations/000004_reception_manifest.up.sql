@@ -0,0 +1,14 @@
+CREATE TABLE reception_manifest_items (
+    reception_id UUID NOT NULL REFERENCES receptions(id) ON DELETE CASCADE,
+    product_type product_types_enum NOT NULL,
+    expected_count INT NOT NULL CHECK (expected_count > 0),
+    PRIMARY KEY (reception_id, product_type)
+);
+
+CREATE TABLE reception_discrepancies (
+    reception_id UUID NOT NULL REFERENCES receptions(id) ON DELETE CASCADE,
+    product_type product_types_enum NOT NULL,
+    expected_count INT NOT NULL,
+    received_count INT NOT NULL,
+    PRIMARY KEY (reception_id, product_type)
+);
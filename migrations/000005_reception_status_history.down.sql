@@ -0,0 +1,13 @@
+DROP TABLE IF EXISTS reception_status_history;
+
+UPDATE receptions SET status = 'close' WHERE status = 'cancelled';
+
+-- Значение из enum нельзя удалить, поэтому тип пересоздаётся.
+DROP INDEX IF EXISTS receptions_single_open_per_pvz;
+ALTER TYPE statuses_enum RENAME TO statuses_enum_old;
+CREATE TYPE statuses_enum AS ENUM('in_progress', 'close');
+ALTER TABLE receptions ALTER COLUMN status TYPE statuses_enum USING status::text::statuses_enum;
+DROP TYPE statuses_enum_old;
+CREATE UNIQUE INDEX receptions_single_open_per_pvz
+    ON receptions (pvz_id)
+    WHERE status = 'in_progress';
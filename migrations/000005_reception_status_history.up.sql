@@ -0,0 +1,13 @@
+ALTER TYPE statuses_enum ADD VALUE IF NOT EXISTS 'cancelled';
+
+CREATE TABLE reception_status_history (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    reception_id UUID NOT NULL REFERENCES receptions(id) ON DELETE CASCADE,
+    from_status VARCHAR(32) NOT NULL,
+    to_status VARCHAR(32) NOT NULL,
+    reason TEXT NOT NULL,
+    changed_by UUID,
+    changed_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX reception_status_history_reception_idx ON reception_status_history (reception_id, changed_at);
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS receptions_in_progress_since_idx;
+
+ALTER TABLE receptions
+    DROP COLUMN IF EXISTS in_progress_since,
+    DROP COLUMN IF EXISTS stale_reason,
+    DROP COLUMN IF EXISTS stale_flagged_at;
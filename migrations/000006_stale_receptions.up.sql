@@ -0,0 +1,16 @@
+-- in_progress_since — момент последнего перехода приёмки в работу; от него считается возраст открытой приёмки.
+ALTER TABLE receptions
+    ADD COLUMN stale_flagged_at TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN stale_reason TEXT,
+    ADD COLUMN in_progress_since TIMESTAMP WITH TIME ZONE;
+
+UPDATE receptions r
+SET in_progress_since = COALESCE((
+    SELECT MAX(h.changed_at)
+    FROM reception_status_history h
+    WHERE h.reception_id = r.id AND h.to_status = 'in_progress'
+), r.date_time)
+WHERE r.status = 'in_progress';
+
+CREATE INDEX receptions_in_progress_since_idx ON receptions (COALESCE(in_progress_since, date_time))
+    WHERE status = 'in_progress';
@@ -0,0 +1,13 @@
+DROP INDEX IF EXISTS products_active_reception_idx;
+
+DELETE FROM products WHERE deleted_at IS NOT NULL;
+
+ALTER TABLE products
+    DROP COLUMN IF EXISTS deleted_at,
+    DROP COLUMN IF EXISTS deleted_by,
+    DROP COLUMN IF EXISTS added_by;
+
+ALTER TABLE receptions
+    DROP COLUMN IF EXISTS closed_at,
+    DROP COLUMN IF EXISTS closed_by,
+    DROP COLUMN IF EXISTS opened_by;
@@ -0,0 +1,12 @@
+-- Пользователи из dummyLogin не хранятся в users, поэтому внешних ключей нет.
+ALTER TABLE receptions
+    ADD COLUMN opened_by UUID,
+    ADD COLUMN closed_by UUID,
+    ADD COLUMN closed_at TIMESTAMP WITH TIME ZONE;
+
+ALTER TABLE products
+    ADD COLUMN added_by UUID,
+    ADD COLUMN deleted_by UUID,
+    ADD COLUMN deleted_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX products_active_reception_idx ON products (reception_id, order_number) WHERE deleted_at IS NULL;
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS receptions_waybill_number_idx;
+
+ALTER TABLE receptions
+    DROP COLUMN IF EXISTS comment,
+    DROP COLUMN IF EXISTS vehicle_plate,
+    DROP COLUMN IF EXISTS waybill_number,
+    DROP COLUMN IF EXISTS carrier;
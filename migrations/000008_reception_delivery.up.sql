@@ -0,0 +1,7 @@
+ALTER TABLE receptions
+    ADD COLUMN carrier TEXT NOT NULL DEFAULT '',
+    ADD COLUMN waybill_number TEXT NOT NULL DEFAULT '',
+    ADD COLUMN vehicle_plate TEXT NOT NULL DEFAULT '',
+    ADD COLUMN comment TEXT NOT NULL DEFAULT '';
+
+CREATE INDEX receptions_waybill_number_idx ON receptions (waybill_number) WHERE waybill_number <> '';
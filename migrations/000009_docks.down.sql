@@ -0,0 +1,34 @@
+-- В ПВЗ снова может быть только одна открытая приёмка. Закрывать лишние приёмки молча нельзя,
+-- поэтому откат прерывается, пока их не закроют вручную.
+DO $$
+BEGIN
+    IF EXISTS (
+        SELECT 1
+        FROM receptions
+        WHERE status = 'in_progress'
+        GROUP BY pvz_id
+        HAVING COUNT(*) > 1
+    ) THEN
+        RAISE EXCEPTION 'some pvz have several open receptions, close them before rolling back docks';
+    END IF;
+END;
+$$;
+
+DROP INDEX IF EXISTS receptions_open_by_pvz_idx;
+DROP INDEX IF EXISTS receptions_single_open_per_dock;
+
+CREATE UNIQUE INDEX receptions_single_open_per_pvz
+    ON receptions (pvz_id)
+    WHERE status = 'in_progress';
+
+DROP TRIGGER IF EXISTS receptions_default_dock ON receptions;
+DROP FUNCTION IF EXISTS set_default_dock();
+
+ALTER TABLE receptions
+    DROP CONSTRAINT IF EXISTS receptions_dock_fk,
+    DROP COLUMN IF EXISTS dock_id;
+
+DROP TRIGGER IF EXISTS pvz_default_dock ON pvz;
+DROP FUNCTION IF EXISTS create_default_dock();
+
+DROP TABLE IF EXISTS docks;
@@ -0,0 +1,62 @@
+-- Док (ворота разгрузки) ПВЗ. У каждого ПВЗ есть док по умолчанию: через него
+-- работают ПВЗ с одной зоной разгрузки и запросы, в которых док не указан.
+CREATE TABLE docks (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    pvz_id UUID NOT NULL REFERENCES pvz(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    is_default BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    UNIQUE (pvz_id, name),
+    UNIQUE (id, pvz_id)
+);
+
+CREATE UNIQUE INDEX docks_single_default_per_pvz ON docks (pvz_id) WHERE is_default;
+
+INSERT INTO docks (pvz_id, name, is_default)
+SELECT id, 'основной', TRUE FROM pvz;
+
+CREATE FUNCTION create_default_dock() RETURNS trigger AS $$
+BEGIN
+    INSERT INTO docks (pvz_id, name, is_default) VALUES (NEW.id, 'основной', TRUE);
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER pvz_default_dock
+    AFTER INSERT ON pvz
+    FOR EACH ROW EXECUTE FUNCTION create_default_dock();
+
+ALTER TABLE receptions ADD COLUMN dock_id UUID;
+
+UPDATE receptions r
+SET dock_id = d.id
+FROM docks d
+WHERE d.pvz_id = r.pvz_id AND d.is_default;
+
+-- Составной внешний ключ не даёт привязать приёмку к доку другого ПВЗ.
+ALTER TABLE receptions
+    ALTER COLUMN dock_id SET NOT NULL,
+    ADD CONSTRAINT receptions_dock_fk FOREIGN KEY (dock_id, pvz_id) REFERENCES docks (id, pvz_id);
+
+CREATE FUNCTION set_default_dock() RETURNS trigger AS $$
+BEGIN
+    IF NEW.dock_id IS NULL THEN
+        SELECT id INTO NEW.dock_id FROM docks WHERE pvz_id = NEW.pvz_id AND is_default;
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER receptions_default_dock
+    BEFORE INSERT ON receptions
+    FOR EACH ROW EXECUTE FUNCTION set_default_dock();
+
+DROP INDEX receptions_single_open_per_pvz;
+
+CREATE UNIQUE INDEX receptions_single_open_per_dock
+    ON receptions (dock_id)
+    WHERE status = 'in_progress';
+
+CREATE INDEX receptions_open_by_pvz_idx
+    ON receptions (pvz_id)
+    WHERE status = 'in_progress';
@@ -0,0 +1,19 @@
+UPDATE receptions SET status = 'cancelled' WHERE status = 'draft';
+UPDATE receptions SET status = 'close' WHERE status = 'verified';
+
+-- Значение из enum нельзя удалить, поэтому тип пересоздаётся вместе с зависящими от статуса индексами.
+DROP INDEX IF EXISTS receptions_single_open_per_dock;
+DROP INDEX IF EXISTS receptions_open_by_pvz_idx;
+DROP INDEX IF EXISTS receptions_in_progress_since_idx;
+ALTER TYPE statuses_enum RENAME TO statuses_enum_old;
+CREATE TYPE statuses_enum AS ENUM('in_progress', 'close', 'cancelled');
+ALTER TABLE receptions ALTER COLUMN status TYPE statuses_enum USING status::text::statuses_enum;
+DROP TYPE statuses_enum_old;
+CREATE UNIQUE INDEX receptions_single_open_per_dock
+    ON receptions (dock_id)
+    WHERE status = 'in_progress';
+CREATE INDEX receptions_open_by_pvz_idx
+    ON receptions (pvz_id)
+    WHERE status = 'in_progress';
+CREATE INDEX receptions_in_progress_since_idx ON receptions (COALESCE(in_progress_since, date_time))
+    WHERE status = 'in_progress';
@@ -0,0 +1,3 @@
+-- draft — приёмка заведена заранее и ещё не начата; verified — закрытая приёмка проверена модератором.
+ALTER TYPE statuses_enum ADD VALUE IF NOT EXISTS 'draft';
+ALTER TYPE statuses_enum ADD VALUE IF NOT EXISTS 'verified';
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS reception_blind_counts;
+
+ALTER TABLE pvz
+    DROP COLUMN IF EXISTS blind_count_policy;
@@ -0,0 +1,11 @@
+ALTER TABLE pvz
+    ADD COLUMN blind_count_policy VARCHAR(16) NOT NULL DEFAULT 'reject'
+        CHECK (blind_count_policy IN ('reject', 'flag'));
+
+CREATE TABLE reception_blind_counts (
+    reception_id UUID NOT NULL REFERENCES receptions(id) ON DELETE CASCADE,
+    product_type product_types_enum NOT NULL,
+    counted_count INT NOT NULL CHECK (counted_count >= 0),
+    recorded_count INT NOT NULL CHECK (recorded_count >= 0),
+    PRIMARY KEY (reception_id, product_type)
+);
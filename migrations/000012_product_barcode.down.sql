@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS products_stored_barcode_idx;
+
+ALTER TABLE products
+    DROP COLUMN IF EXISTS barcode;
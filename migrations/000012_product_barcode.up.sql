@@ -0,0 +1,6 @@
+ALTER TABLE products
+    ADD COLUMN barcode VARCHAR(64);
+
+-- Штрихкод уникален только среди хранящихся (неудалённых) товаров: после удаления его можно отсканировать снова.
+CREATE UNIQUE INDEX products_stored_barcode_idx ON products (barcode)
+    WHERE barcode IS NOT NULL AND deleted_at IS NULL;
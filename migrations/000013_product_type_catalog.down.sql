@@ -0,0 +1,21 @@
+-- Откат возможен, только если используются лишь исходные три типа.
+CREATE TYPE product_types_enum AS ENUM('электроника', 'одежда', 'обувь');
+
+ALTER TABLE reception_blind_counts
+    DROP CONSTRAINT reception_blind_counts_product_type_fkey,
+    ALTER COLUMN product_type TYPE product_types_enum USING product_type::product_types_enum;
+
+ALTER TABLE reception_discrepancies
+    DROP CONSTRAINT reception_discrepancies_product_type_fkey,
+    ALTER COLUMN product_type TYPE product_types_enum USING product_type::product_types_enum;
+
+ALTER TABLE reception_manifest_items
+    DROP CONSTRAINT reception_manifest_items_product_type_fkey,
+    ALTER COLUMN product_type TYPE product_types_enum USING product_type::product_types_enum;
+
+ALTER TABLE products
+    DROP COLUMN attributes,
+    DROP CONSTRAINT products_type_fkey,
+    ALTER COLUMN type TYPE product_types_enum USING type::product_types_enum;
+
+DROP TABLE product_types;
@@ -0,0 +1,30 @@
+CREATE TABLE product_types (
+    name VARCHAR(64) PRIMARY KEY,
+    attributes_schema JSONB,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    created_by UUID
+);
+
+INSERT INTO product_types (name, attributes_schema) VALUES
+    ('электроника', '{"type": "object", "properties": {"imei": {"type": "string", "pattern": "^[0-9]{15}$"}}}'),
+    ('одежда', NULL),
+    ('обувь', '{"type": "object", "properties": {"size": {"type": "number", "minimum": 15, "maximum": 55}}}');
+
+ALTER TABLE products
+    ALTER COLUMN type TYPE VARCHAR(64) USING type::text,
+    ADD CONSTRAINT products_type_fkey FOREIGN KEY (type) REFERENCES product_types (name),
+    ADD COLUMN attributes JSONB;
+
+ALTER TABLE reception_manifest_items
+    ALTER COLUMN product_type TYPE VARCHAR(64) USING product_type::text,
+    ADD CONSTRAINT reception_manifest_items_product_type_fkey FOREIGN KEY (product_type) REFERENCES product_types (name);
+
+ALTER TABLE reception_discrepancies
+    ALTER COLUMN product_type TYPE VARCHAR(64) USING product_type::text,
+    ADD CONSTRAINT reception_discrepancies_product_type_fkey FOREIGN KEY (product_type) REFERENCES product_types (name);
+
+ALTER TABLE reception_blind_counts
+    ALTER COLUMN product_type TYPE VARCHAR(64) USING product_type::text,
+    ADD CONSTRAINT reception_blind_counts_product_type_fkey FOREIGN KEY (product_type) REFERENCES product_types (name);
+
+DROP TYPE product_types_enum;
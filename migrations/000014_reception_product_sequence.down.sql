@@ -0,0 +1,23 @@
+DROP INDEX IF EXISTS products_active_reception_idx;
+ALTER TABLE products
+    DROP CONSTRAINT IF EXISTS products_reception_order_number_key;
+
+-- Сквозная нумерация восстанавливается в порядке добавления товаров.
+CREATE SEQUENCE products_order_number_seq OWNED BY products.order_number;
+
+UPDATE products p
+SET order_number = numbered.position
+FROM (
+    SELECT id, ROW_NUMBER() OVER (ORDER BY date_time, reception_id, order_number) AS position
+    FROM products
+) numbered
+WHERE p.id = numbered.id;
+
+SELECT setval('products_order_number_seq', COALESCE((SELECT MAX(order_number) FROM products), 0) + 1, false);
+ALTER TABLE products
+    ALTER COLUMN order_number SET DEFAULT nextval('products_order_number_seq');
+
+CREATE INDEX products_active_reception_idx ON products (reception_id, order_number) WHERE deleted_at IS NULL;
+
+ALTER TABLE receptions
+    DROP COLUMN IF EXISTS last_order_number;
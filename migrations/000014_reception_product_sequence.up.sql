@@ -0,0 +1,30 @@
+-- Номер товара ведётся внутри приёмки: 1..N без пропусков. Счётчик хранится в приёмке,
+-- и его увеличение блокирует строку приёмки, поэтому параллельные добавления получают разные номера.
+ALTER TABLE receptions
+    ADD COLUMN last_order_number INTEGER NOT NULL DEFAULT 0;
+
+ALTER TABLE products
+    ALTER COLUMN order_number DROP DEFAULT;
+DROP SEQUENCE IF EXISTS products_order_number_seq;
+
+UPDATE products p
+SET order_number = numbered.position
+FROM (
+    SELECT id, ROW_NUMBER() OVER (PARTITION BY reception_id ORDER BY order_number) AS position
+    FROM products
+) numbered
+WHERE p.id = numbered.id;
+
+UPDATE receptions r
+SET last_order_number = counted.last_order_number
+FROM (
+    SELECT reception_id, MAX(order_number) AS last_order_number
+    FROM products
+    GROUP BY reception_id
+) counted
+WHERE r.id = counted.reception_id;
+
+DROP INDEX IF EXISTS products_active_reception_idx;
+ALTER TABLE products
+    ADD CONSTRAINT products_reception_order_number_key UNIQUE (reception_id, order_number);
+CREATE INDEX products_active_reception_idx ON products (reception_id, order_number) WHERE deleted_at IS NULL;
@@ -0,0 +1,12 @@
+-- Откат возможен, только если штрихкоды выданных товаров не приняты повторно.
+DROP INDEX IF EXISTS products_stored_barcode_idx;
+CREATE UNIQUE INDEX products_stored_barcode_idx ON products (barcode)
+    WHERE barcode IS NOT NULL AND deleted_at IS NULL;
+
+ALTER TABLE products
+    DROP COLUMN IF EXISTS issued_at,
+    DROP COLUMN IF EXISTS issued_by,
+    DROP COLUMN IF EXISTS pickup_code,
+    DROP COLUMN IF EXISTS status;
+
+DROP TYPE IF EXISTS product_statuses_enum;
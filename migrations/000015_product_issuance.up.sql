@@ -0,0 +1,21 @@
+-- stored — товар принят и хранится в ПВЗ, issued — выдан покупателю, returned_to_sender — возвращён отправителю.
+CREATE TYPE product_statuses_enum AS ENUM('stored', 'issued', 'returned_to_sender');
+
+ALTER TABLE products
+    ADD COLUMN status product_statuses_enum NOT NULL DEFAULT 'stored',
+    ADD COLUMN pickup_code VARCHAR(16),
+    ADD COLUMN issued_by UUID,
+    ADD COLUMN issued_at TIMESTAMP WITH TIME ZONE;
+
+-- Коды для уже принятых товаров берутся из случайной части UUID v4.
+UPDATE products
+SET pickup_code = LPAD(((('x' || LEFT(REPLACE(uuid_generate_v4()::TEXT, '-', ''), 8))::BIT(32)::BIGINT
+    % 1000000)::TEXT, 6, '0');
+
+ALTER TABLE products
+    ALTER COLUMN pickup_code SET NOT NULL;
+
+-- Выданный или возвращённый товар больше не хранится: его штрихкод можно принять снова.
+DROP INDEX IF EXISTS products_stored_barcode_idx;
+CREATE UNIQUE INDEX products_stored_barcode_idx ON products (barcode)
+    WHERE barcode IS NOT NULL AND deleted_at IS NULL AND status = 'stored';
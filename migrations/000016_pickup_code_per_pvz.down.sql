@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS products_stored_pickup_code_idx;
+
+ALTER TABLE products
+    DROP COLUMN IF EXISTS pvz_id;
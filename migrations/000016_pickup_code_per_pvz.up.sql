@@ -0,0 +1,32 @@
+-- ПВЗ товара хранится в самом товаре, чтобы код получения был уникален в пределах ПВЗ.
+ALTER TABLE products
+    ADD COLUMN pvz_id UUID REFERENCES pvz(id) ON DELETE CASCADE;
+
+UPDATE products p
+SET pvz_id = r.pvz_id
+FROM receptions r
+WHERE r.id = p.reception_id;
+
+ALTER TABLE products
+    ALTER COLUMN pvz_id SET NOT NULL;
+
+-- Повторяющиеся коды хранящихся товаров одного ПВЗ выдаются заново.
+DO $$
+BEGIN
+    LOOP
+        UPDATE products p
+        SET pickup_code = LPAD(((('x' || LEFT(REPLACE(uuid_generate_v4()::TEXT, '-', ''), 8))::BIT(32)::BIGINT
+            % 1000000)::TEXT, 6, '0')
+        WHERE p.status = 'stored' AND p.deleted_at IS NULL
+          AND EXISTS (
+              SELECT 1
+              FROM products d
+              WHERE d.pvz_id = p.pvz_id AND d.pickup_code = p.pickup_code AND d.id < p.id
+                AND d.status = 'stored' AND d.deleted_at IS NULL
+          );
+        EXIT WHEN NOT FOUND;
+    END LOOP;
+END $$;
+
+CREATE UNIQUE INDEX products_stored_pickup_code_idx ON products (pvz_id, pickup_code)
+    WHERE status = 'stored' AND deleted_at IS NULL;
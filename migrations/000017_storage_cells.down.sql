@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS products_stored_cell_idx;
+
+ALTER TABLE products
+    DROP CONSTRAINT IF EXISTS products_cell_fk,
+    DROP COLUMN IF EXISTS cell_id;
+
+DROP TABLE IF EXISTS storage_cells;
@@ -0,0 +1,21 @@
+-- Ячейка стеллажа ПВЗ. Ячейка с типом товара принимает только товары этого типа,
+-- ячейка без типа — любые. Занятость считается по хранящимся товарам.
+CREATE TABLE storage_cells (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    pvz_id UUID NOT NULL REFERENCES pvz(id) ON DELETE CASCADE,
+    shelf TEXT NOT NULL,
+    code TEXT NOT NULL,
+    product_type VARCHAR(64) REFERENCES product_types(name),
+    capacity INTEGER NOT NULL CHECK (capacity > 0),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    UNIQUE (pvz_id, code),
+    UNIQUE (id, pvz_id)
+);
+
+-- Составной внешний ключ не даёт положить товар в ячейку другого ПВЗ.
+ALTER TABLE products
+    ADD COLUMN cell_id UUID,
+    ADD CONSTRAINT products_cell_fk FOREIGN KEY (cell_id, pvz_id) REFERENCES storage_cells (id, pvz_id);
+
+CREATE INDEX products_stored_cell_idx ON products (cell_id)
+    WHERE status = 'stored' AND deleted_at IS NULL;
@@ -0,0 +1,10 @@
+DROP INDEX IF EXISTS products_issued_barcode_idx;
+
+ALTER TABLE products
+    DROP COLUMN IF EXISTS original_product_id,
+    DROP COLUMN IF EXISTS return_reason;
+
+ALTER TABLE receptions
+    DROP COLUMN IF EXISTS kind;
+
+DROP TYPE IF EXISTS reception_kinds_enum;
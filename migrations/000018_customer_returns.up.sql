@@ -0,0 +1,14 @@
+-- Вид приёмки: поставка от отправителя или возврат от покупателя.
+CREATE TYPE reception_kinds_enum AS ENUM('delivery', 'return');
+
+ALTER TABLE receptions
+    ADD COLUMN kind reception_kinds_enum NOT NULL DEFAULT 'delivery';
+
+-- У возвращённого товара есть причина возврата и, если штрихкод известен, ссылка
+-- на выданный ранее товар.
+ALTER TABLE products
+    ADD COLUMN return_reason TEXT,
+    ADD COLUMN original_product_id UUID REFERENCES products(id);
+
+CREATE INDEX products_issued_barcode_idx ON products (barcode, issued_at DESC)
+    WHERE status = 'issued';
@@ -0,0 +1,40 @@
+UPDATE products SET status = 'stored' WHERE status = 'in_transit';
+-- Строки, оставшиеся в ПВЗ отправления после перемещения, помечаются удалёнными: товар хранится
+-- в ПВЗ назначения.
+UPDATE products SET status = 'stored', deleted_at = COALESCE(deleted_at, NOW())
+WHERE status = 'transferred';
+ALTER TABLE products DROP COLUMN IF EXISTS source_product_id;
+UPDATE receptions SET kind = 'delivery' WHERE kind = 'transfer';
+
+DROP TABLE IF EXISTS product_locations;
+DROP TABLE IF EXISTS transfer_items;
+DROP TABLE IF EXISTS transfers;
+
+DROP TYPE IF EXISTS transfer_statuses_enum;
+
+-- Значение из enum нельзя удалить, поэтому типы пересоздаются вместе с зависящими от них индексами.
+ALTER TABLE receptions ALTER COLUMN kind DROP DEFAULT;
+ALTER TYPE reception_kinds_enum RENAME TO reception_kinds_enum_old;
+CREATE TYPE reception_kinds_enum AS ENUM('delivery', 'return');
+ALTER TABLE receptions ALTER COLUMN kind TYPE reception_kinds_enum USING kind::text::reception_kinds_enum;
+ALTER TABLE receptions ALTER COLUMN kind SET DEFAULT 'delivery';
+DROP TYPE reception_kinds_enum_old;
+
+DROP INDEX IF EXISTS products_stored_barcode_idx;
+DROP INDEX IF EXISTS products_stored_pickup_code_idx;
+DROP INDEX IF EXISTS products_stored_cell_idx;
+DROP INDEX IF EXISTS products_issued_barcode_idx;
+ALTER TABLE products ALTER COLUMN status DROP DEFAULT;
+ALTER TYPE product_statuses_enum RENAME TO product_statuses_enum_old;
+CREATE TYPE product_statuses_enum AS ENUM('stored', 'issued', 'returned_to_sender');
+ALTER TABLE products ALTER COLUMN status TYPE product_statuses_enum USING status::text::product_statuses_enum;
+ALTER TABLE products ALTER COLUMN status SET DEFAULT 'stored';
+DROP TYPE product_statuses_enum_old;
+CREATE UNIQUE INDEX products_stored_barcode_idx ON products (barcode)
+    WHERE barcode IS NOT NULL AND deleted_at IS NULL AND status = 'stored';
+CREATE UNIQUE INDEX products_stored_pickup_code_idx ON products (pvz_id, pickup_code)
+    WHERE status = 'stored' AND deleted_at IS NULL;
+CREATE INDEX products_stored_cell_idx ON products (cell_id)
+    WHERE status = 'stored' AND deleted_at IS NULL;
+CREATE INDEX products_issued_barcode_idx ON products (barcode, issued_at DESC)
+    WHERE status = 'issued';
@@ -0,0 +1,55 @@
+-- in_transit — товар отправлен в другой ПВЗ и ещё не принят там; transferred — товар принят
+-- в ПВЗ назначения и остаётся в приёмке отправления только как история; transfer — приёмка
+-- перемещённых товаров в ПВЗ назначения.
+ALTER TYPE product_statuses_enum ADD VALUE IF NOT EXISTS 'in_transit';
+ALTER TYPE product_statuses_enum ADD VALUE IF NOT EXISTS 'transferred';
+ALTER TYPE reception_kinds_enum ADD VALUE IF NOT EXISTS 'transfer';
+
+CREATE TYPE transfer_statuses_enum AS ENUM('created', 'in_transit', 'received', 'cancelled');
+
+-- Перемещение товаров из ПВЗ from_pvz_id в ПВЗ to_pvz_id. Приёмка в ПВЗ назначения
+-- заводится при отправке.
+CREATE TABLE transfers (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    from_pvz_id UUID NOT NULL REFERENCES pvz(id) ON DELETE CASCADE,
+    to_pvz_id UUID NOT NULL REFERENCES pvz(id) ON DELETE CASCADE,
+    status transfer_statuses_enum NOT NULL DEFAULT 'created',
+    reception_id UUID REFERENCES receptions(id) ON DELETE SET NULL,
+    created_by UUID,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    dispatched_by UUID,
+    dispatched_at TIMESTAMP WITH TIME ZONE,
+    received_at TIMESTAMP WITH TIME ZONE,
+    CHECK (from_pvz_id <> to_pvz_id)
+);
+
+CREATE TABLE transfer_items (
+    transfer_id UUID NOT NULL REFERENCES transfers(id) ON DELETE CASCADE,
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    received_by UUID,
+    received_at TIMESTAMP WITH TIME ZONE,
+    PRIMARY KEY (transfer_id, product_id)
+);
+
+CREATE INDEX transfer_items_product_idx ON transfer_items (product_id);
+
+-- В ПВЗ назначения перемещённый товар заводится новой строкой со ссылкой на строку в ПВЗ отправления.
+ALTER TABLE products ADD COLUMN source_product_id UUID REFERENCES products(id);
+
+-- История местонахождения товара: ПВЗ и приёмка, в которых он хранится, либо перемещение,
+-- в котором он находится в пути.
+CREATE TABLE product_locations (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    pvz_id UUID REFERENCES pvz(id) ON DELETE CASCADE,
+    reception_id UUID REFERENCES receptions(id) ON DELETE CASCADE,
+    transfer_id UUID REFERENCES transfers(id) ON DELETE CASCADE,
+    changed_by UUID,
+    changed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX product_locations_product_idx ON product_locations (product_id, changed_at);
+
+INSERT INTO product_locations (product_id, pvz_id, reception_id, changed_by, changed_at)
+SELECT id, pvz_id, reception_id, added_by, date_time
+FROM products;
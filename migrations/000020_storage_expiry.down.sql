@@ -0,0 +1,13 @@
+DROP INDEX IF EXISTS products_overdue_idx;
+
+ALTER TABLE products
+    DROP COLUMN IF EXISTS returned_at,
+    DROP COLUMN IF EXISTS returned_by,
+    DROP COLUMN IF EXISTS overdue_at,
+    DROP COLUMN IF EXISTS stored_at;
+
+ALTER TABLE pvz
+    DROP COLUMN IF EXISTS storage_days;
+
+ALTER TABLE product_types
+    DROP COLUMN IF EXISTS storage_days;
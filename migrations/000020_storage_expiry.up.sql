@@ -0,0 +1,20 @@
+-- Срок хранения в днях: для типа товара, для ПВЗ; если не задан ни там, ни там, действует срок
+-- по умолчанию из конфигурации.
+ALTER TABLE product_types
+    ADD COLUMN storage_days INT CHECK (storage_days > 0);
+
+ALTER TABLE pvz
+    ADD COLUMN storage_days INT CHECK (storage_days > 0);
+
+-- stored_at — начало хранения в текущем ПВЗ; overdue_at — когда товар с истёкшим сроком попал
+-- в очередь на возврат отправителю.
+ALTER TABLE products
+    ADD COLUMN stored_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    ADD COLUMN overdue_at TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN returned_by UUID,
+    ADD COLUMN returned_at TIMESTAMP WITH TIME ZONE;
+
+UPDATE products SET stored_at = date_time;
+
+CREATE INDEX products_overdue_idx ON products (pvz_id, overdue_at)
+    WHERE status = 'stored' AND overdue_at IS NOT NULL;
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS product_photos;
+
+ALTER TABLE products
+    DROP COLUMN IF EXISTS damaged_at,
+    DROP COLUMN IF EXISTS damaged_by,
+    DROP COLUMN IF EXISTS damage_description;
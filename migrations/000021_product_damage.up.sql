@@ -0,0 +1,18 @@
+-- Отметка о повреждении товара; товар повреждён, если damaged_at не пустой.
+ALTER TABLE products
+    ADD COLUMN damage_description TEXT,
+    ADD COLUMN damaged_by UUID,
+    ADD COLUMN damaged_at TIMESTAMP WITH TIME ZONE;
+
+-- Фотографии повреждений. Сам файл хранится в blob-хранилище под ключом blob_key.
+CREATE TABLE product_photos (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    blob_key TEXT NOT NULL UNIQUE,
+    content_type TEXT NOT NULL,
+    size BIGINT NOT NULL CHECK (size > 0),
+    uploaded_by UUID,
+    uploaded_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX product_photos_product_idx ON product_photos (product_id, uploaded_at);
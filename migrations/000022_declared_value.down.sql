@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS products_stored_value_idx;
+
+ALTER TABLE products
+    DROP CONSTRAINT IF EXISTS products_declared_value_currency_check,
+    DROP COLUMN IF EXISTS currency,
+    DROP COLUMN IF EXISTS declared_value;
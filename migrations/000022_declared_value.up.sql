@@ -0,0 +1,10 @@
+-- Объявленная ценность товара в минимальных единицах валюты (копейках, центах) и код валюты
+-- ISO 4217. Ценность и валюта задаются вместе или не задаются вовсе.
+ALTER TABLE products
+    ADD COLUMN declared_value BIGINT CHECK (declared_value > 0),
+    ADD COLUMN currency CHAR(3) CHECK (currency ~ '^[A-Z]{3}$'),
+    ADD CONSTRAINT products_declared_value_currency_check
+        CHECK ((declared_value IS NULL) = (currency IS NULL));
+
+CREATE INDEX products_stored_value_idx ON products (pvz_id, currency)
+    WHERE status = 'stored' AND deleted_at IS NULL AND declared_value IS NOT NULL;
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS acceptance_acts;
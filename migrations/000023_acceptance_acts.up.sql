@@ -0,0 +1,66 @@
+-- Акт фиксируется при закрытии приёмки. Повторное закрытие после переоткрытия добавляет следующую
+-- редакцию, прежние не меняются. Содержимое хранится как текст JSON без нормализации, чтобы хэш
+-- можно было проверить по сохранённым байтам.
+CREATE TABLE acceptance_acts (
+    reception_id UUID NOT NULL REFERENCES receptions(id) ON DELETE CASCADE,
+    version INTEGER NOT NULL,
+    content JSON NOT NULL,
+    content_hash CHAR(64) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (reception_id, version)
+);
+
+-- Приёмкам, закрытым до появления актов, акт фиксируется по текущим данным в том же формате,
+-- в каком его сохраняет сервис: неудалённые товары в порядке добавления, время в UTC, пустые
+-- идентификаторы — нулевой UUID, объявленная ценность опускается, если не задана.
+WITH legacy AS (
+    SELECT r.id,
+           json_strip_nulls(json_build_object(
+               'receptionId', r.id,
+               'pvzId', r.pvz_id,
+               'city', p.city,
+               'carrier', r.carrier,
+               'waybillNumber', r.waybill_number,
+               'vehiclePlate', r.vehicle_plate,
+               'comment', r.comment,
+               'openedAt', to_char(r.date_time AT TIME ZONE 'UTC', 'YYYY-MM-DD"T"HH24:MI:SS.US"Z"'),
+               'closedAt', COALESCE(to_char(r.closed_at AT TIME ZONE 'UTC', 'YYYY-MM-DD"T"HH24:MI:SS.US"Z"'), ''),
+               'openedBy', COALESCE(r.opened_by, '00000000-0000-0000-0000-000000000000'::UUID),
+               'closedBy', COALESCE(r.closed_by, '00000000-0000-0000-0000-000000000000'::UUID),
+               'counts', COALESCE((
+                   SELECT json_object_agg(c.type, c.count)
+                   FROM (
+                       SELECT pr.type::TEXT AS type, COUNT(*) AS count
+                       FROM products pr
+                       WHERE pr.reception_id = r.id AND pr.deleted_at IS NULL
+                       GROUP BY pr.type
+                   ) c
+               ), '{}'::JSON),
+               'products', COALESCE((
+                   SELECT json_agg(json_build_object(
+                       'id', pr.id,
+                       'type', pr.type,
+                       'dateTime', to_char(pr.date_time AT TIME ZONE 'UTC', 'YYYY-MM-DD"T"HH24:MI:SS.US"Z"'),
+                       'declaredValue', pr.declared_value,
+                       'currency', pr.currency
+                   ) ORDER BY pr.order_number)
+                   FROM products pr
+                   WHERE pr.reception_id = r.id AND pr.deleted_at IS NULL
+               ), '[]'::JSON),
+               'declaredValue', (
+                   SELECT json_object_agg(v.currency, v.amount)
+                   FROM (
+                       SELECT pr.currency, SUM(pr.declared_value) AS amount
+                       FROM products pr
+                       WHERE pr.reception_id = r.id AND pr.deleted_at IS NULL AND pr.currency IS NOT NULL
+                       GROUP BY pr.currency
+                   ) v
+               )
+           ))::TEXT AS content
+    FROM receptions r
+    JOIN pvz p ON p.id = r.pvz_id
+    WHERE r.status IN ('close', 'verified')
+)
+INSERT INTO acceptance_acts (reception_id, version, content, content_hash)
+SELECT id, 1, content::JSON, encode(sha256(convert_to(content, 'UTF8')), 'hex')
+FROM legacy;
@@ -0,0 +1,46 @@
+package codeimage
+
+import (
+	"bytes"
+	"github.com/boombuler/barcode"
+	"github.com/boombuler/barcode/code128"
+	"github.com/boombuler/barcode/qr"
+	"image/png"
+)
+
+// Размеры изображений в пикселях: QR квадратный, Code128 — полоса для печати на этикетке.
+const (
+	QRSize        = 256
+	Code128Width  = 400
+	Code128Height = 120
+)
+
+// QR кодирует content в QR-код со средним уровнем коррекции ошибок и возвращает PNG.
+func QR(content string) ([]byte, error) {
+	code, err := qr.Encode(content, qr.M, qr.Auto)
+	if err != nil {
+		return nil, err
+	}
+	return encodePNG(code, QRSize, QRSize)
+}
+
+// Code128 кодирует content в штрихкод Code128 и возвращает PNG.
+func Code128(content string) ([]byte, error) {
+	code, err := code128.Encode(content)
+	if err != nil {
+		return nil, err
+	}
+	return encodePNG(code, Code128Width, Code128Height)
+}
+
+func encodePNG(code barcode.Barcode, width, height int) ([]byte, error) {
+	scaled, err := barcode.Scale(code, width, height)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, scaled); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
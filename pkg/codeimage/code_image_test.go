@@ -0,0 +1,41 @@
+package codeimage
+
+import (
+	"bytes"
+	"image/png"
+	"testing"
+)
+
+func TestImages(t *testing.T) {
+	testCases := []struct {
+		name   string
+		render func(string) ([]byte, error)
+		width  int
+		height int
+	}{
+		{"qr", QR, QRSize, QRSize},
+		{"code128", Code128, Code128Width, Code128Height},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			data, err := tc.render("042917")
+			if err != nil {
+				t.Fatalf("render failed: %v", err)
+			}
+			img, err := png.Decode(bytes.NewReader(data))
+			if err != nil {
+				t.Fatalf("result is not a png: %v", err)
+			}
+			if bounds := img.Bounds(); bounds.Dx() != tc.width || bounds.Dy() != tc.height {
+				t.Errorf("image size = %dx%d, want %dx%d", bounds.Dx(), bounds.Dy(), tc.width, tc.height)
+			}
+		})
+	}
+}
+
+func TestEmptyContent(t *testing.T) {
+	if _, err := Code128(""); err == nil {
+		t.Error("Code128(\"\") should fail")
+	}
+}